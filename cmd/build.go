@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"time"
 
@@ -12,8 +11,10 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/gio"
 	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/internal/metrics"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/errhandler"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/logging"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/loglevel"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/skip"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/git"
 	"github.com/goreleaser/goreleaser/v2/internal/pipeline"
@@ -35,11 +36,14 @@ type buildOpts struct {
 	autoSnapshot bool
 	clean        bool
 	deprecated   bool
+	quiet        bool
+	metrics      metrics.Options
 	parallelism  int
 	timeout      time.Duration
 	singleTarget bool
 	output       string
 	skips        []string
+	debugPipes   []string
 }
 
 func newBuildCmd() *buildCmd {
@@ -56,6 +60,7 @@ Its intended usage is, for example, within Makefiles to avoid setting up ldflags
 It also allows you to generate a local build for your current machine only using the ` + "`--single-target`" + ` option, and specific build IDs using the ` + "`--id`" + ` option in case you have more than one.
 
 When using ` + "`--single-target`" + `, the ` + "`GOOS`" + ` and ` + "`GOARCH`" + ` environment variables are used to determine the target, defaulting to the current machine target if not set.
+Universal binaries are not created in this mode, and it can be combined with ` + "`--snapshot`" + `.
 `,
 		SilenceUsage:      true,
 		SilenceErrors:     true,
@@ -76,7 +81,10 @@ When using ` + "`--single-target`" + `, the ` + "`GOOS`" + ` and ` + "`GOARCH`"
 	cmd.Flags().BoolVar(&root.opts.snapshot, "snapshot", false, "Generate an unversioned snapshot build, skipping all validations")
 	cmd.Flags().BoolVar(&root.opts.autoSnapshot, "auto-snapshot", false, "Automatically sets --snapshot if the repository is dirty")
 	cmd.Flags().BoolVar(&root.opts.clean, "clean", false, "Removes the 'dist' directory before building")
-	cmd.Flags().IntVarP(&root.opts.parallelism, "parallelism", "p", 0, "Number of tasks to run concurrently (default: number of CPUs)")
+	cmd.Flags().BoolVarP(&root.opts.quiet, "quiet", "q", false, "Quiet mode: don't print the summary table at the end")
+	cmd.Flags().StringVar(&root.opts.metrics.File, "metrics-file", "", "Write the duration of each pipe to the given file, in the Prometheus text format")
+	cmd.Flags().StringVar(&root.opts.metrics.Endpoint, "metrics-otlp-endpoint", "", "Push the duration of each pipe to the given OTLP/HTTP metrics endpoint")
+	cmd.Flags().IntVarP(&root.opts.parallelism, "parallelism", "p", 0, "Number of tasks to run concurrently (default: $GORELEASER_PARALLELISM or number of CPUs)")
 	_ = cmd.RegisterFlagCompletionFunc("parallelism", cobra.NoFileCompletions)
 	cmd.Flags().DurationVar(&root.opts.timeout, "timeout", 30*time.Minute, "Timeout to the entire build process")
 	_ = cmd.RegisterFlagCompletionFunc("timeout", cobra.NoFileCompletions)
@@ -109,6 +117,11 @@ When using ` + "`--single-target`" + `, the ` + "`GOOS`" + ` and ` + "`GOARCH`"
 		return skips.Build.Complete(toComplete), cobra.ShellCompDirectiveDefault
 	})
 
+	cmd.Flags().StringSliceVar(&root.opts.debugPipes, "debug-pipes", nil, "Enable debug logs only for the given pipes, e.g. docker,sign")
+	_ = cmd.RegisterFlagCompletionFunc("debug-pipes", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return pipeNames(pipeline.BuildCmdPipeline), cobra.ShellCompDirectiveNoFileComp
+	})
+
 	root.cmd = cmd
 	return root
 }
@@ -123,20 +136,22 @@ func buildProject(options buildOpts) (*context.Context, error) {
 	if err := setupBuildContext(ctx, options); err != nil {
 		return nil, err
 	}
-	return ctx, ctrlc.Default.Run(ctx, func() error {
+	err = ctrlc.Default.Run(ctx, func() error {
 		for _, pipe := range setupPipeline(ctx, options) {
-			if err := skip.Maybe(
+			if err := loglevel.Debug(pipe, skip.Maybe(
 				pipe,
 				logging.Log(
 					pipe.String(),
 					errhandler.Handle(pipe.Run),
 				),
-			)(ctx); err != nil {
+			))(ctx); err != nil {
 				return err
 			}
 		}
 		return nil
 	})
+	exportMetrics(ctx, options.metrics)
+	return ctx, err
 }
 
 func setupPipeline(ctx *context.Context, options buildOpts) []pipeline.Piper {
@@ -149,18 +164,22 @@ func setupPipeline(ctx *context.Context, options buildOpts) []pipeline.Piper {
 func setupBuildContext(ctx *context.Context, options buildOpts) error {
 	ctx.Action = context.ActionBuild
 	ctx.Deprecated = options.deprecated // test only
-	ctx.Parallelism = runtime.GOMAXPROCS(0)
-	if options.parallelism > 0 {
-		ctx.Parallelism = options.parallelism
+	p, err := parallelism(options.parallelism)
+	if err != nil {
+		return err
 	}
+	ctx.Parallelism = p
 	log.Debugf("parallelism: %v", ctx.Parallelism)
 	ctx.Snapshot = options.snapshot
+	ctx.Quiet = options.quiet
 
 	if options.autoSnapshot && git.CheckDirty(ctx) != nil {
 		log.Info("git repository is dirty and --auto-snapshot is set, implying --snapshot")
 		ctx.Snapshot = true
 	}
 
+	setupDebugPipes(ctx, options.debugPipes, pipeline.BuildCmdPipeline)
+
 	if err := skips.SetBuild(ctx, options.skips...); err != nil {
 		return err
 	}
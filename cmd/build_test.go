@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -19,6 +20,17 @@ func TestBuild(t *testing.T) {
 	require.NoError(t, cmd.cmd.Execute())
 }
 
+func TestBuildMetricsFile(t *testing.T) {
+	setup(t)
+	cmd := newBuildCmd()
+	cmd.cmd.SetArgs([]string{"--snapshot", "--quiet", "--metrics-file=goreleaser.prom"})
+	require.NoError(t, cmd.cmd.Execute())
+	bts, err := os.ReadFile("goreleaser.prom")
+	require.NoError(t, err)
+	require.Contains(t, string(bts), `goreleaser_pipe_duration_seconds{project="fake",pipe="building binaries",parent="",status="success"}`)
+	require.FileExists(t, "dist/summary.json")
+}
+
 func TestBuildAutoSnapshot(t *testing.T) {
 	t.Run("clean", func(t *testing.T) {
 		setup(t)
@@ -175,6 +187,24 @@ func TestBuildFlags(t *testing.T) {
 		require.True(t, ctx.SkipTokenCheck)
 	})
 
+	t.Run("single-target", func(t *testing.T) {
+		ctx := setup(buildOpts{
+			singleTarget: true,
+		})
+		require.True(t, ctx.Partial)
+		require.False(t, ctx.Snapshot)
+	})
+
+	t.Run("single-target and snapshot", func(t *testing.T) {
+		ctx := setup(buildOpts{
+			singleTarget: true,
+			snapshot:     true,
+		})
+		require.True(t, ctx.Partial)
+		require.True(t, ctx.Snapshot)
+		requireAll(t, ctx, skips.Validate)
+	})
+
 	t.Run("skips", func(t *testing.T) {
 		ctx := setup(buildOpts{
 			skips: []string{
@@ -8,6 +8,7 @@ import (
 	"github.com/caarlos0/ctrlc"
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/defaults"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/spf13/cobra"
 )
@@ -16,6 +17,7 @@ type checkCmd struct {
 	cmd        *cobra.Command
 	config     string
 	quiet      bool
+	strict     bool
 	deprecated bool
 	checked    int
 }
@@ -38,14 +40,23 @@ func newCheckCmd() *checkCmd {
 			if root.config != "" || len(args) == 0 {
 				args = append(args, root.config)
 			}
-			for _, config := range args {
-				cfg, path, err := loadConfigCheck(config)
+			for _, file := range args {
+				cfg, path, err := loadConfigCheck(file)
 				if err != nil {
 					return err
 				}
 				ctx := context.New(cfg)
 				ctx.Deprecated = root.deprecated
 
+				if root.strict && path != "" && cfg.Version != 2 {
+					errs = append(errs, wrapErrorWithCode(
+						fmt.Errorf("configuration is invalid: %w", config.VersionError{Current: cfg.Version}),
+						1,
+						path,
+					))
+					continue
+				}
+
 				if err := ctrlc.Default.Run(ctx, func() error {
 					log.WithField("path", path).
 						Info(boldStyle.Render("checking"))
@@ -61,9 +72,13 @@ func newCheckCmd() *checkCmd {
 				}
 
 				if ctx.Deprecated {
+					code := 2
+					if root.strict {
+						code = 1
+					}
 					errs = append(errs, wrapErrorWithCode(
 						fmt.Errorf("configuration is valid, but uses deprecated properties"),
-						2,
+						code,
 						path,
 					))
 				}
@@ -95,6 +110,7 @@ func newCheckCmd() *checkCmd {
 	cmd.Flags().StringVarP(&root.config, "config", "f", "", "Configuration file(s) to check")
 	_ = cmd.MarkFlagFilename("config", "yaml", "yml")
 	cmd.Flags().BoolVarP(&root.quiet, "quiet", "q", false, "Quiet mode: no output")
+	cmd.Flags().BoolVar(&root.strict, "strict", false, "Strict mode: fail on deprecated properties and missing 'version: 2'")
 	cmd.Flags().BoolVar(&root.deprecated, "deprecated", false, "Force print the deprecation message - tests only")
 	_ = cmd.Flags().MarkHidden("deprecated")
 	_ = cmd.Flags().MarkHidden("config")
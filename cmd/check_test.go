@@ -61,3 +61,14 @@ func TestCheckConfigDeprecated(t *testing.T) {
 	require.Error(t, cmd.cmd.Execute())
 	require.Equal(t, 1, cmd.checked)
 }
+
+func TestCheckConfigStrict(t *testing.T) {
+	cmd := newCheckCmd()
+	cmd.cmd.SetArgs([]string{"-f", "testdata/good.yml", "--strict"})
+	err := cmd.cmd.Execute()
+	require.Error(t, err)
+	var eerr *exitError
+	require.ErrorAs(t, err, &eerr)
+	require.Equal(t, 1, eerr.code)
+	require.Equal(t, 1, cmd.checked)
+}
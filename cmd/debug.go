@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"sort"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/loglevel"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/announce"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/publish"
+	"github.com/goreleaser/goreleaser/v2/internal/pipeline"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// pipeNames returns the names of the given pipes, including the publishers
+// and announcers, that can be used in --debug-pipes.
+func pipeNames(pipes []pipeline.Piper) []string {
+	seen := map[string]bool{}
+	add := func(p interface{}) {
+		seen[loglevel.Name(p)] = true
+	}
+	for _, p := range pipes {
+		add(p)
+		switch p := p.(type) {
+		case publish.Pipe:
+			for _, publisher := range p.Publishers() {
+				add(publisher)
+			}
+		case announce.Pipe:
+			for _, announcer := range announce.Announcers() {
+				add(announcer)
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setupDebugPipes sets the pipes that should log in debug level, warning
+// about and ignoring the ones that don't exist in the given pipeline.
+func setupDebugPipes(ctx *context.Context, names []string, pipes []pipeline.Piper) {
+	known := map[string]bool{}
+	for _, name := range pipeNames(pipes) {
+		known[name] = true
+	}
+	for _, name := range names {
+		if !known[name] {
+			log.Warnf(logext.Warning("--debug-pipes: unknown pipe %q, ignoring"), name)
+			continue
+		}
+		ctx.DebugPipes = append(ctx.DebugPipes, name)
+	}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/pipeline"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeNames(t *testing.T) {
+	names := pipeNames(pipeline.Pipeline)
+	require.Contains(t, names, "docker")
+	require.Contains(t, names, "sign")
+	require.Contains(t, names, "build")
+	// publishers and announcers
+	require.Contains(t, names, "blob")
+	require.Contains(t, names, "slack")
+	require.IsIncreasing(t, names)
+
+	names = pipeNames(pipeline.BuildCmdPipeline)
+	require.Contains(t, names, "build")
+	require.NotContains(t, names, "docker")
+}
+
+func TestSetupDebugPipes(t *testing.T) {
+	ctx := testctx.New()
+	setupDebugPipes(ctx, []string{"docker", "nope", "slack"}, pipeline.Pipeline)
+	require.Equal(t, []string{"docker", "slack"}, ctx.DebugPipes)
+
+	ctx = testctx.New()
+	setupDebugPipes(ctx, []string{"docker"}, pipeline.BuildCmdPipeline)
+	require.Empty(t, ctx.DebugPipes)
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/deployment"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// reportDeployment updates the GitHub deployment status, if any, with the
+// result of the release.
+func reportDeployment(ctx *context.Context, err error) {
+	if err := (deployment.Pipe{}).Report(ctx, err); err != nil {
+		log.WithError(err).Warn("could not update deployment status")
+	}
+}
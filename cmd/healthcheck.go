@@ -3,13 +3,12 @@ package cmd
 import (
 	"fmt"
 	"io"
-	"os/exec"
-	"sync"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/caarlos0/ctrlc"
 	"github.com/caarlos0/log"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/goreleaser/goreleaser/v2/internal/middleware/skip"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/defaults"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/goreleaser/goreleaser/v2/pkg/healthcheck"
@@ -25,15 +24,18 @@ type healthcheckCmd struct {
 func newHealthcheckCmd() *healthcheckCmd {
 	root := &healthcheckCmd{}
 	cmd := &cobra.Command{
-		Use:               "healthcheck",
-		Aliases:           []string{"hc"},
-		Short:             "Checks if needed tools are installed",
-		Long:              `Check if the needed tools are available in your $PATH, exits 1 if any of them are missing.`,
+		Use:     "healthcheck",
+		Aliases: []string{"hc"},
+		Short:   "Checks if needed tools are installed",
+		Long: `Check if the needed tools are available in your $PATH, and if they meet the minimum required version, if any.
+Prints a table with the results, and exits 1 if any of them are missing or too old.
+
+The same check can be done before a release with ` + "`goreleaser release --healthcheck`" + `.`,
 		SilenceUsage:      true,
 		SilenceErrors:     true,
 		Args:              cobra.NoArgs,
 		ValidArgsFunction: cobra.NoFileCompletions,
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			if root.quiet {
 				log.Log = log.New(io.Discard)
 			}
@@ -52,26 +54,21 @@ func newHealthcheckCmd() *healthcheckCmd {
 					return err
 				}
 
-				log.IncreasePadding()
-				defer log.ResetPadding()
-
-				var errs []error
-				for _, hc := range healthcheck.Healthcheckers {
-					_ = skip.Maybe(hc, func(ctx *context.Context) error {
-						for _, tool := range hc.Dependencies(ctx) {
-							if err := checkPath(tool); err != nil {
-								errs = append(errs, err)
-							}
-						}
-						return nil
-					})(ctx)
+				results, err := healthcheck.Run(ctx)
+				if err != nil {
+					return err
 				}
 
-				if len(errs) == 0 {
-					return nil
+				if !root.quiet {
+					fmt.Fprintln(cmd.OutOrStdout(), renderResults(results))
 				}
 
-				return fmt.Errorf("one or more needed tools are not present")
+				for _, result := range results {
+					if result.Err != nil {
+						return fmt.Errorf("one or more needed tools are not present")
+					}
+				}
+				return nil
 			}); err != nil {
 				return err
 			}
@@ -90,18 +87,35 @@ func newHealthcheckCmd() *healthcheckCmd {
 	return root
 }
 
-var toolsChecked = &sync.Map{}
-
-func checkPath(tool string) error {
-	if _, ok := toolsChecked.LoadOrStore(tool, true); ok {
-		return nil
+func renderResults(results []healthcheck.Result) string {
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Bold(true)
+	errStyle := log.Styles[log.ErrorLevel]
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
 	}
-	if _, err := exec.LookPath(tool); err != nil {
-		st := log.Styles[log.ErrorLevel]
-		log.Warnf("%s %s - %s", st.Render("⚠"), codeStyle.Render(tool), st.Render("not present in path"))
-		return err
+
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TOOL\tNEEDED BY\tVERSION\tMINIMUM\tSTATUS")
+	for _, result := range results {
+		// status is the last column, so its styling doesn't break the
+		// alignment.
+		status := okStyle.Render("✓")
+		if result.Err != nil {
+			status = errStyle.Render("⚠ " + result.Err.Error())
+		}
+		_, _ = fmt.Fprintf(
+			w, "%s\t%s\t%s\t%s\t%s\n",
+			result.Tool,
+			result.Checker,
+			orDash(result.Version),
+			orDash(result.MinVersion),
+			status,
+		)
 	}
-	st := lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Bold(true)
-	log.Infof("%s %s", st.Render("✓"), codeStyle.Render(tool))
-	return nil
+	_ = w.Flush()
+	return strings.TrimSuffix(sb.String(), "\n")
 }
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/metrics"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// exportMetrics exports the pipe timings, if enabled.
+// Failing to do so only warns, as it shouldn't fail the release.
+func exportMetrics(ctx *context.Context, opts metrics.Options) {
+	if err := metrics.Export(ctx, opts); err != nil {
+		log.WithError(err).Warn("could not export metrics")
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+)
+
+// parallelismEnv can be used to set the parallelism when the --parallelism
+// flag is not given.
+const parallelismEnv = "GORELEASER_PARALLELISM"
+
+// parallelism returns the number of tasks to run concurrently, across all the
+// pipes: the given flag value, if set, GORELEASER_PARALLELISM, if set, or
+// GOMAXPROCS (the number of CPUs) otherwise.
+func parallelism(flag int) (int, error) {
+	if flag > 0 {
+		return flag, nil
+	}
+	if env := os.Getenv(parallelismEnv); env != "" {
+		n, err := strconv.Atoi(env)
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("invalid %s: %q, must be a positive number", parallelismEnv, env)
+		}
+		return n, nil
+	}
+	return runtime.GOMAXPROCS(0), nil
+}
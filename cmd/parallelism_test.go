@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelism(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		t.Setenv(parallelismEnv, "")
+		p, err := parallelism(0)
+		require.NoError(t, err)
+		require.Equal(t, runtime.GOMAXPROCS(0), p)
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		t.Setenv(parallelismEnv, "3")
+		p, err := parallelism(5)
+		require.NoError(t, err)
+		require.Equal(t, 5, p)
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv(parallelismEnv, "3")
+		p, err := parallelism(0)
+		require.NoError(t, err)
+		require.Equal(t, 3, p)
+	})
+
+	t.Run("invalid env", func(t *testing.T) {
+		for _, env := range []string{"nope", "0", "-1"} {
+			t.Setenv(parallelismEnv, env)
+			_, err := parallelism(0)
+			require.EqualError(t, err, `invalid GORELEASER_PARALLELISM: "`+env+`", must be a positive number`)
+		}
+	})
+}
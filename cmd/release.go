@@ -1,19 +1,23 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"runtime"
+	"slices"
 	"time"
 
 	"github.com/caarlos0/ctrlc"
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/internal/metrics"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/errhandler"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/logging"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/loglevel"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/skip"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/git"
 	"github.com/goreleaser/goreleaser/v2/internal/pipeline"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/spf13/cobra"
 )
@@ -25,6 +29,8 @@ type releaseCmd struct {
 
 type releaseOpts struct {
 	config            string
+	projects          []string
+	parallel          bool
 	releaseNotesFile  string
 	releaseNotesTmpl  string
 	releaseHeaderFile string
@@ -33,13 +39,23 @@ type releaseOpts struct {
 	releaseFooterTmpl string
 	autoSnapshot      bool
 	snapshot          bool
+	nightly           bool
+	distinctNightly   bool
+	createTag         bool
 	draft             bool
 	failFast          bool
+	collectErrors     bool
 	clean             bool
 	deprecated        bool
+	healthcheck       bool
+	quiet             bool
+	metrics           metrics.Options
 	parallelism       int
+	workers           *context.Workers
 	timeout           time.Duration
 	skips             []string
+	only              []string
+	debugPipes        []string
 }
 
 func newReleaseCmd() *releaseCmd {
@@ -54,6 +70,12 @@ func newReleaseCmd() *releaseCmd {
 		Args:              cobra.NoArgs,
 		ValidArgsFunction: cobra.NoFileCompletions,
 		RunE: timedRunE("release", func(_ *cobra.Command, _ []string) error {
+			if len(root.opts.projects) > 0 {
+				return releaseProjects(root.opts)
+			}
+			if root.opts.parallel {
+				return errors.New("--parallel can only be used with --projects")
+			}
 			ctx, err := releaseProject(root.opts)
 			if err != nil {
 				return err
@@ -65,6 +87,10 @@ func newReleaseCmd() *releaseCmd {
 
 	cmd.Flags().StringVarP(&root.opts.config, "config", "f", "", "Load configuration from file")
 	_ = cmd.MarkFlagFilename("config", "yaml", "yml")
+	cmd.Flags().StringArrayVar(&root.opts.projects, "projects", nil, "Release the projects of the given configuration files, one after the other unless --parallel is set (can be repeated)")
+	_ = cmd.MarkFlagFilename("projects", "yaml", "yml")
+	cmd.MarkFlagsMutuallyExclusive("config", "projects")
+	cmd.Flags().BoolVar(&root.opts.parallel, "parallel", false, "Release the projects given with --projects concurrently, all of them sharing the --parallelism bound")
 	cmd.Flags().StringVar(&root.opts.releaseNotesFile, "release-notes", "", "Load custom release notes from a markdown file (will skip GoReleaser changelog generation)")
 	_ = cmd.MarkFlagFilename("release-notes", "md", "mkd", "markdown")
 	cmd.Flags().StringVar(&root.opts.releaseHeaderFile, "release-header", "", "Load custom release notes header from a markdown file")
@@ -79,10 +105,23 @@ func newReleaseCmd() *releaseCmd {
 	_ = cmd.MarkFlagFilename("release-footer-tmpl", "md", "mkd", "markdown")
 	cmd.Flags().BoolVar(&root.opts.autoSnapshot, "auto-snapshot", false, "Automatically sets --snapshot if the repository is dirty")
 	cmd.Flags().BoolVar(&root.opts.snapshot, "snapshot", false, "Generate an unversioned snapshot release, skipping all validations and without publishing any artifacts (implies --skip=announce,publish,validate)")
+	cmd.Flags().BoolVar(&root.opts.nightly, "nightly", false, "Generate a nightly build, publishing only the artifacts that support it (implies --skip=announce,aur,chocolatey,homebrew,nix,scoop,winget)")
+	cmd.MarkFlagsMutuallyExclusive("snapshot", "nightly")
+	cmd.Flags().BoolVar(&root.opts.distinctNightly, "distinct-nightly-tag", false, "Never consider the nightly tag as the current or previous tag, and fail if nightly.tag_name looks like a stable release tag")
+	cmd.Flags().BoolVar(&root.opts.createTag, "create-tag", false, "Create the tag from git.tag.name_template on the current commit, and push it before publishing")
+	cmd.MarkFlagsMutuallyExclusive("snapshot", "create-tag")
+	cmd.MarkFlagsMutuallyExclusive("nightly", "create-tag")
+	cmd.MarkFlagsMutuallyExclusive("auto-snapshot", "create-tag")
 	cmd.Flags().BoolVar(&root.opts.draft, "draft", false, "Whether to set the release to draft. Overrides release.draft in the configuration file")
 	cmd.Flags().BoolVar(&root.opts.failFast, "fail-fast", false, "Whether to abort the release publishing on the first error")
+	cmd.Flags().BoolVar(&root.opts.collectErrors, "collect-errors", false, "Whether to run all publishers even if some fail, reporting all the errors at the end")
+	cmd.MarkFlagsMutuallyExclusive("fail-fast", "collect-errors")
 	cmd.Flags().BoolVar(&root.opts.clean, "clean", false, "Removes the 'dist' directory")
-	cmd.Flags().IntVarP(&root.opts.parallelism, "parallelism", "p", 0, "Amount tasks to run concurrently (default: number of CPUs)")
+	cmd.Flags().BoolVar(&root.opts.healthcheck, "healthcheck", false, "Checks that all needed tools are installed before doing anything else")
+	cmd.Flags().BoolVarP(&root.opts.quiet, "quiet", "q", false, "Quiet mode: don't print the summary table at the end")
+	cmd.Flags().StringVar(&root.opts.metrics.File, "metrics-file", "", "Write the duration of each pipe to the given file, in the Prometheus text format")
+	cmd.Flags().StringVar(&root.opts.metrics.Endpoint, "metrics-otlp-endpoint", "", "Push the duration of each pipe to the given OTLP/HTTP metrics endpoint")
+	cmd.Flags().IntVarP(&root.opts.parallelism, "parallelism", "p", 0, "Amount tasks to run concurrently (default: $GORELEASER_PARALLELISM or number of CPUs)")
 	_ = cmd.RegisterFlagCompletionFunc("parallelism", cobra.NoFileCompletions)
 	cmd.Flags().DurationVar(&root.opts.timeout, "timeout", 30*time.Minute, "Timeout to the entire release process")
 	_ = cmd.RegisterFlagCompletionFunc("timeout", cobra.NoFileCompletions)
@@ -92,10 +131,25 @@ func newReleaseCmd() *releaseCmd {
 		&root.opts.skips,
 		"skip",
 		nil,
-		fmt.Sprintf("Skip the given options (valid options are %s)", skips.Release.String()),
+		fmt.Sprintf("Skip the given options (valid options are %s, use e.g. announce:twitter or publish:chocolatey to skip a single announcer or publisher)", skips.Release.String()),
 	)
 	_ = cmd.RegisterFlagCompletionFunc("skip", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return skips.Release.Complete(toComplete), cobra.ShellCompDirectiveDefault
+		return completeSkips(skips.Release, pipeline.Pipeline, toComplete), cobra.ShellCompDirectiveDefault
+	})
+
+	cmd.Flags().StringSliceVar(
+		&root.opts.only,
+		"only",
+		nil,
+		"Skip everything that --skip can, but the given options and the ones they require, e.g. --only=docker,publish:release",
+	)
+	_ = cmd.RegisterFlagCompletionFunc("only", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeSkips(skips.Release, pipeline.Pipeline, toComplete), cobra.ShellCompDirectiveDefault
+	})
+
+	cmd.Flags().StringSliceVar(&root.opts.debugPipes, "debug-pipes", nil, "Enable debug logs only for the given pipes, e.g. docker,sign")
+	_ = cmd.RegisterFlagCompletionFunc("debug-pipes", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return pipeNames(pipeline.Pipeline), cobra.ShellCompDirectiveNoFileComp
 	})
 
 	root.cmd = cmd
@@ -107,33 +161,46 @@ func releaseProject(options releaseOpts) (*context.Context, error) {
 	if err != nil {
 		return nil, err
 	}
+	return releaseProjectConfig(cfg, options)
+}
+
+func releaseProjectConfig(cfg config.Project, options releaseOpts) (*context.Context, error) {
 	ctx, cancel := context.NewWithTimeout(cfg, options.timeout)
 	defer cancel()
 	if err := setupReleaseContext(ctx, options); err != nil {
 		return nil, err
 	}
-	return ctx, ctrlc.Default.Run(ctx, func() error {
+	// not using ctrlc.Default, as it can't run multiple tasks at once, and
+	// multiple projects might be released concurrently.
+	err := ctrlc.New().Run(ctx, func() error {
 		for _, pipe := range pipeline.Pipeline {
-			if err := skip.Maybe(
+			if err := loglevel.Debug(pipe, skip.Maybe(
 				pipe,
 				logging.Log(
 					pipe.String(),
 					errhandler.Handle(pipe.Run),
 				),
-			)(ctx); err != nil {
+			))(ctx); err != nil {
 				return err
 			}
 		}
 		return nil
 	})
+	reportDeployment(ctx, err)
+	exportMetrics(ctx, options.metrics)
+	return ctx, err
 }
 
 func setupReleaseContext(ctx *context.Context, options releaseOpts) error {
 	ctx.Action = context.ActionRelease
 	ctx.Deprecated = options.deprecated // test only
-	ctx.Parallelism = runtime.GOMAXPROCS(0)
-	if options.parallelism > 0 {
-		ctx.Parallelism = options.parallelism
+	p, err := parallelism(options.parallelism)
+	if err != nil {
+		return err
+	}
+	ctx.Parallelism = p
+	if options.workers != nil {
+		ctx.Workers = options.workers
 	}
 	log.Debugf("parallelism: %v", ctx.Parallelism)
 	ctx.ReleaseNotesFile = options.releaseNotesFile
@@ -143,8 +210,14 @@ func setupReleaseContext(ctx *context.Context, options releaseOpts) error {
 	ctx.ReleaseFooterFile = options.releaseFooterFile
 	ctx.ReleaseFooterTmpl = options.releaseFooterTmpl
 	ctx.Snapshot = options.snapshot
+	ctx.Nightly = options.nightly
+	ctx.DistinctNightly = options.distinctNightly
+	ctx.CreateTag = options.createTag
 	ctx.FailFast = options.failFast
+	ctx.CollectErrors = options.collectErrors
 	ctx.Clean = options.clean
+	ctx.Healthcheck = options.healthcheck
+	ctx.Quiet = options.quiet
 	if options.autoSnapshot && git.CheckDirty(ctx) != nil {
 		log.Info("git repository is dirty and --auto-snapshot is set, implying --snapshot")
 		ctx.Snapshot = true
@@ -154,13 +227,38 @@ func setupReleaseContext(ctx *context.Context, options releaseOpts) error {
 		ctx.Config.Release.Draft = true
 	}
 
-	if err := skips.SetRelease(ctx, options.skips...); err != nil {
+	setupDebugPipes(ctx, options.debugPipes, pipeline.Pipeline)
+
+	if err := checkNamespacedSkips(options.skips, pipeline.Pipeline); err != nil {
+		return err
+	}
+	keys := options.skips
+	if len(options.only) > 0 {
+		only, err := onlySkips(options.only, options.skips, pipeline.Pipeline)
+		if err != nil {
+			return err
+		}
+		keys = append(slices.Clone(keys), only...)
+	}
+	if err := skips.SetRelease(ctx, keys...); err != nil {
 		return err
 	}
 
 	if ctx.Snapshot {
 		skips.Set(ctx, skips.Publish, skips.Announce, skips.Validate)
 	}
+	if ctx.Nightly {
+		skips.Set(
+			ctx,
+			skips.Announce,
+			skips.AUR,
+			skips.Chocolatey,
+			skips.Homebrew,
+			skips.Nix,
+			skips.Scoop,
+			skips.Winget,
+		)
+	}
 	if skips.Any(ctx, skips.Publish) {
 		skips.Set(ctx, skips.Announce)
 	}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/caarlos0/ctrlc"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/errhandler"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/logging"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/skip"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/release"
+	"github.com/goreleaser/goreleaser/v2/internal/pipeline"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/spf13/cobra"
+)
+
+type releaseNotesCmd struct {
+	cmd  *cobra.Command
+	opts releaseNotesOpts
+}
+
+type releaseNotesOpts struct {
+	config            string
+	releaseNotesFile  string
+	releaseNotesTmpl  string
+	releaseHeaderFile string
+	releaseHeaderTmpl string
+	releaseFooterFile string
+	releaseFooterTmpl string
+	timeout           time.Duration
+}
+
+func newReleaseNotesCmd() *releaseNotesCmd {
+	root := &releaseNotesCmd{}
+	cmd := &cobra.Command{
+		Use:   "release-notes",
+		Short: "Prints the release notes of the current tag",
+		Long: `Generates the changelog and assembles the release body exactly as ` + "`goreleaser release`" + ` would, including the header and footer, and prints it to the standard output.
+
+Nothing is built nor published, and no token is needed unless the changelog is generated from the SCM API.
+The notes are generated for the current tag, which can be set with the ` + "`GORELEASER_CURRENT_TAG`" + ` environment variable.
+To preview the next release, create its tag locally first.
+`,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			body, err := releaseNotes(root.opts)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprint(cmd.OutOrStdout(), body)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&root.opts.config, "config", "f", "", "Load configuration from file")
+	_ = cmd.MarkFlagFilename("config", "yaml", "yml")
+	cmd.Flags().StringVar(&root.opts.releaseNotesFile, "release-notes", "", "Load custom release notes from a markdown file (will skip GoReleaser changelog generation)")
+	_ = cmd.MarkFlagFilename("release-notes", "md", "mkd", "markdown")
+	cmd.Flags().StringVar(&root.opts.releaseHeaderFile, "release-header", "", "Load custom release notes header from a markdown file")
+	_ = cmd.MarkFlagFilename("release-header", "md", "mkd", "markdown")
+	cmd.Flags().StringVar(&root.opts.releaseFooterFile, "release-footer", "", "Load custom release notes footer from a markdown file")
+	_ = cmd.MarkFlagFilename("release-footer", "md", "mkd", "markdown")
+	cmd.Flags().StringVar(&root.opts.releaseNotesTmpl, "release-notes-tmpl", "", "Load custom release notes from a templated markdown file (overrides --release-notes)")
+	_ = cmd.MarkFlagFilename("release-notes-tmpl", "md", "mkd", "markdown")
+	cmd.Flags().StringVar(&root.opts.releaseHeaderTmpl, "release-header-tmpl", "", "Load custom release notes header from a templated markdown file (overrides --release-header)")
+	_ = cmd.MarkFlagFilename("release-header-tmpl", "md", "mkd", "markdown")
+	cmd.Flags().StringVar(&root.opts.releaseFooterTmpl, "release-footer-tmpl", "", "Load custom release notes footer from a templated markdown file (overrides --release-footer)")
+	_ = cmd.MarkFlagFilename("release-footer-tmpl", "md", "mkd", "markdown")
+	cmd.Flags().DurationVar(&root.opts.timeout, "timeout", 5*time.Minute, "Timeout to the entire process")
+	_ = cmd.RegisterFlagCompletionFunc("timeout", cobra.NoFileCompletions)
+
+	root.cmd = cmd
+	return root
+}
+
+func releaseNotes(options releaseNotesOpts) (string, error) {
+	cfg, err := loadConfig(options.config)
+	if err != nil {
+		return "", err
+	}
+
+	// the changelog pipe also writes the notes to the dist folder, we don't
+	// want to touch the real one here.
+	dist, err := os.MkdirTemp("", "goreleaser-release-notes")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dist)
+	cfg.Dist = dist
+
+	ctx, cancel := context.NewWithTimeout(cfg, options.timeout)
+	defer cancel()
+	ctx.ReleaseNotesFile = options.releaseNotesFile
+	ctx.ReleaseNotesTmpl = options.releaseNotesTmpl
+	ctx.ReleaseHeaderFile = options.releaseHeaderFile
+	ctx.ReleaseHeaderTmpl = options.releaseHeaderTmpl
+	ctx.ReleaseFooterFile = options.releaseFooterFile
+	ctx.ReleaseFooterTmpl = options.releaseFooterTmpl
+	ctx.SkipTokenCheck = true
+	skips.Set(ctx, skips.Validate)
+
+	var body string
+	err = ctrlc.Default.Run(ctx, func() error {
+		for _, pipe := range pipeline.ReleaseNotesPipeline {
+			if err := skip.Maybe(
+				pipe,
+				logging.Log(
+					pipe.String(),
+					errhandler.Handle(pipe.Run),
+				),
+			)(ctx); err != nil {
+				return err
+			}
+		}
+		var err error
+		body, err = release.Body(ctx)
+		return err
+	})
+	return body, err
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseNotes(t *testing.T) {
+	setup(t)
+	createFile(t, "goreleaser.yml", `version: 2
+release:
+  header: "# {{ .Tag }}"
+  footer: "Those were the changes on {{ .Tag }}!"
+changelog:
+  abbrev: -1
+`)
+	var out bytes.Buffer
+	cmd := newReleaseNotesCmd()
+	cmd.cmd.SetOut(&out)
+	cmd.cmd.SetArgs([]string{})
+	require.NoError(t, cmd.cmd.Execute())
+	require.Equal(t, "# v0.0.2\n## Changelog\n* assd\n* assssf\n* asas89d\n\nThose were the changes on v0.0.2!\n", out.String())
+	require.NoDirExists(t, "dist")
+}
+
+func TestReleaseNotesDirty(t *testing.T) {
+	setup(t)
+	createFile(t, "foo", "force dirty tree")
+	cmd := newReleaseNotesCmd()
+	cmd.cmd.SetOut(&bytes.Buffer{})
+	cmd.cmd.SetArgs([]string{})
+	require.NoError(t, cmd.cmd.Execute())
+}
+
+func TestReleaseNotesInvalidTemplate(t *testing.T) {
+	setup(t)
+	createFile(t, "goreleaser.yml", `version: 2
+release:
+  footer: "{{ .Nope }"
+`)
+	cmd := newReleaseNotesCmd()
+	cmd.cmd.SetOut(&bytes.Buffer{})
+	cmd.cmd.SetArgs([]string{})
+	require.ErrorContains(t, cmd.cmd.Execute(), "template: failed to apply")
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+type projectConfig struct {
+	path string
+	cfg  config.Project
+}
+
+// releaseProjects releases the project of each of the configuration files in
+// options.projects, one after the other, or concurrently with --parallel.
+//
+// Each project gets its own context, so it reads its own git state and
+// creates its own clients, exactly as if goreleaser was run once for each of
+// them.
+// All the projects share the same workers, so --parallelism bounds all the
+// tasks running at the same time, not the tasks of each project.
+//
+// The errors of all the projects are returned together at the end.
+// With --fail-fast, the projects that did not start yet are not released
+// once one of them fails.
+func releaseProjects(options releaseOpts) error {
+	if options.metrics.File != "" || options.metrics.Endpoint != "" {
+		return errors.New("--metrics-file and --metrics-otlp-endpoint can't be used when releasing multiple projects")
+	}
+	projects, err := loadProjectConfigs(options.projects)
+	if err != nil {
+		return err
+	}
+	p, err := parallelism(options.parallelism)
+	if err != nil {
+		return err
+	}
+	options.workers = &context.Workers{}
+
+	var lock sync.Mutex
+	var errs []error
+	var deprecated *context.Context
+	var failed atomic.Bool
+
+	release := func(project projectConfig) error {
+		if options.failFast && failed.Load() {
+			log.WithField("config", project.path).Warn("skipping project because a previous one failed")
+			return nil
+		}
+		opts := options
+		opts.config = project.path
+		log.WithField("config", project.path).Info("releasing project")
+		ctx, err := releaseProjectConfig(project.cfg, opts)
+
+		lock.Lock()
+		defer lock.Unlock()
+		if ctx != nil && ctx.Deprecated && deprecated == nil {
+			deprecated = ctx
+		}
+		if err != nil {
+			failed.Store(true)
+			errs = append(errs, fmt.Errorf("%s: %w", project.path, err))
+		}
+		return nil
+	}
+
+	if options.parallel {
+		// each project holds a worker while it is released, so the projects
+		// and their tasks never run more than --parallelism at once.
+		ctx := context.New(config.Project{})
+		ctx.Parallelism = p
+		ctx.Workers = options.workers
+		g := semerrgroup.NewShared(ctx)
+		for _, project := range projects {
+			g.Go(func() error {
+				return release(project)
+			})
+		}
+		_ = g.Wait()
+	} else {
+		for _, project := range projects {
+			_ = release(project)
+		}
+	}
+
+	if deprecated != nil {
+		deprecateWarn(deprecated)
+	}
+	return errors.Join(errs...)
+}
+
+// loadProjectConfigs loads the given configuration files, making sure each
+// project uses its own dist directory.
+//
+// Projects without a dist get dist/<project_name>.
+func loadProjectConfigs(paths []string) ([]projectConfig, error) {
+	dists := map[string]string{}
+	projects := make([]projectConfig, 0, len(paths))
+	for _, path := range paths {
+		if path == "-" {
+			return nil, errors.New("can't read the configuration from stdin when releasing multiple projects")
+		}
+		cfg, err := loadConfig(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if cfg.Dist == "" {
+			if cfg.ProjectName == "" {
+				return nil, fmt.Errorf("%s: either dist or project_name must be set when releasing multiple projects", path)
+			}
+			cfg.Dist = filepath.Join("dist", cfg.ProjectName)
+		}
+		dist := filepath.Clean(cfg.Dist)
+		for other, otherPath := range dists {
+			if isWithin(dist, other) || isWithin(other, dist) {
+				return nil, fmt.Errorf("%s and %s have overlapping dist directories %q and %q", otherPath, path, other, dist)
+			}
+		}
+		dists[dist] = path
+		projects = append(projects, projectConfig{path: path, cfg: cfg})
+	}
+	return projects, nil
+}
+
+// isWithin reports whether path is dir or any of its children.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseMultipleProjects(t *testing.T) {
+	for name, args := range map[string][]string{
+		"sequential": nil,
+		"parallel":   {"--parallel", "--parallelism=2"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setup(t)
+			createProjectYaml(t, "a.yaml", "project_name: a")
+			createProjectYaml(t, "b.yaml", "dist: out/b")
+			cmd := newReleaseCmd()
+			cmd.cmd.SetArgs(append([]string{"--projects", "a.yaml", "--projects", "b.yaml", "--snapshot", "--skip=archive"}, args...))
+			require.NoError(t, cmd.cmd.Execute())
+			require.FileExists(t, "dist/a/artifacts.json")
+			require.FileExists(t, "out/b/artifacts.json")
+		})
+	}
+}
+
+func TestReleaseMultipleProjectsErrors(t *testing.T) {
+	t.Run("aggregated", func(t *testing.T) {
+		setup(t)
+		createProjectYaml(t, "a.yaml", "project_name: a\nbefore:\n  hooks: [exit 1]")
+		createProjectYaml(t, "b.yaml", "project_name: b")
+		createProjectYaml(t, "c.yaml", "project_name: c\nbefore:\n  hooks: [exit 2]")
+		cmd := newReleaseCmd()
+		cmd.cmd.SetArgs([]string{"--projects", "a.yaml", "--projects", "b.yaml", "--projects", "c.yaml", "--snapshot", "--skip=archive"})
+		err := cmd.cmd.Execute()
+		require.ErrorContains(t, err, "a.yaml: hook failed")
+		require.ErrorContains(t, err, "c.yaml: hook failed")
+		require.NotContains(t, err.Error(), "b.yaml")
+		require.FileExists(t, "dist/b/artifacts.json")
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		setup(t)
+		createProjectYaml(t, "a.yaml", "project_name: a\nbefore:\n  hooks: [exit 1]")
+		createProjectYaml(t, "b.yaml", "project_name: b")
+		cmd := newReleaseCmd()
+		cmd.cmd.SetArgs([]string{"--projects", "a.yaml", "--projects", "b.yaml", "--snapshot", "--fail-fast"})
+		require.ErrorContains(t, cmd.cmd.Execute(), "a.yaml: hook failed")
+		require.NoDirExists(t, "dist/b")
+	})
+
+	t.Run("no dist nor project name", func(t *testing.T) {
+		setup(t)
+		createProjectYaml(t, "a.yaml", "project_name: a")
+		createProjectYaml(t, "b.yaml", "")
+		cmd := newReleaseCmd()
+		cmd.cmd.SetArgs([]string{"--projects", "a.yaml", "--projects", "b.yaml", "--snapshot"})
+		require.EqualError(t, cmd.cmd.Execute(), "b.yaml: either dist or project_name must be set when releasing multiple projects")
+	})
+
+	t.Run("overlapping dist", func(t *testing.T) {
+		setup(t)
+		createProjectYaml(t, "a.yaml", "dist: out")
+		createProjectYaml(t, "b.yaml", "dist: out/b")
+		cmd := newReleaseCmd()
+		cmd.cmd.SetArgs([]string{"--projects", "a.yaml", "--projects", "b.yaml", "--snapshot"})
+		require.EqualError(t, cmd.cmd.Execute(), `a.yaml and b.yaml have overlapping dist directories "out" and "out/b"`)
+	})
+
+	t.Run("with config", func(t *testing.T) {
+		setup(t)
+		cmd := newReleaseCmd()
+		cmd.cmd.SetArgs([]string{"-f", "a.yaml", "--projects", "b.yaml", "--snapshot"})
+		require.ErrorContains(t, cmd.cmd.Execute(), "none of the others can be")
+	})
+
+	t.Run("parallel without projects", func(t *testing.T) {
+		setup(t)
+		cmd := newReleaseCmd()
+		cmd.cmd.SetArgs([]string{"--parallel", "--snapshot"})
+		require.EqualError(t, cmd.cmd.Execute(), "--parallel can only be used with --projects")
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		setup(t)
+		cmd := newReleaseCmd()
+		cmd.cmd.SetArgs([]string{"--projects", "a.yaml", "--projects", "b.yaml", "--snapshot", "--metrics-file=metrics.txt"})
+		require.EqualError(t, cmd.cmd.Execute(), "--metrics-file and --metrics-otlp-endpoint can't be used when releasing multiple projects")
+	})
+}
+
+func createProjectYaml(tb testing.TB, filename, extra string) {
+	tb.Helper()
+	createFile(tb, filename, "version: 2\n"+extra+`
+builds:
+- goos: [linux]
+  goarch: [amd64]
+`)
+}
@@ -55,6 +55,13 @@ func TestReleaseBrokenProject(t *testing.T) {
 	require.EqualError(t, cmd.cmd.Execute(), "failed to parse dir: .: main.go:1:1: expected 'package', found not")
 }
 
+func TestReleaseFailFastAndCollectErrors(t *testing.T) {
+	setup(t)
+	cmd := newReleaseCmd()
+	cmd.cmd.SetArgs([]string{"--snapshot", "--fail-fast", "--collect-errors"})
+	require.ErrorContains(t, cmd.cmd.Execute(), "[collect-errors fail-fast] were all set")
+}
+
 func TestReleaseFlags(t *testing.T) {
 	setup := func(tb testing.TB, opts releaseOpts) *context.Context {
 		tb.Helper()
@@ -100,6 +107,33 @@ func TestReleaseFlags(t *testing.T) {
 		requireAll(t, ctx, skips.Publish, skips.Validate, skips.Announce)
 	})
 
+	t.Run("nightly", func(t *testing.T) {
+		ctx := setup(t, releaseOpts{
+			nightly: true,
+		})
+		require.True(t, ctx.Nightly)
+		require.False(t, ctx.Snapshot)
+		requireAll(t, ctx, skips.Announce, skips.Homebrew, skips.Scoop, skips.AUR)
+		require.False(t, ctx.Skips[string(skips.Publish)])
+	})
+
+	t.Run("distinct nightly tag", func(t *testing.T) {
+		ctx := setup(t, releaseOpts{
+			nightly:         true,
+			distinctNightly: true,
+		})
+		require.True(t, ctx.Nightly)
+		require.True(t, ctx.DistinctNightly)
+	})
+
+	t.Run("create tag", func(t *testing.T) {
+		ctx := setup(t, releaseOpts{
+			createTag: true,
+		})
+		require.True(t, ctx.CreateTag)
+		require.False(t, ctx.Snapshot)
+	})
+
 	t.Run("skips", func(t *testing.T) {
 		ctx := setup(t, releaseOpts{
 			skips: []string{
@@ -151,4 +185,18 @@ func TestReleaseFlags(t *testing.T) {
 			clean: true,
 		}).Clean)
 	})
+
+	t.Run("collect errors", func(t *testing.T) {
+		require.False(t, setup(t, releaseOpts{}).CollectErrors)
+		require.True(t, setup(t, releaseOpts{
+			collectErrors: true,
+		}).CollectErrors)
+	})
+
+	t.Run("healthcheck", func(t *testing.T) {
+		require.False(t, setup(t, releaseOpts{}).Healthcheck)
+		require.True(t, setup(t, releaseOpts{
+			healthcheck: true,
+		}).Healthcheck)
+	})
 }
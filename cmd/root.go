@@ -3,12 +3,16 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	goversion "github.com/caarlos0/go-version"
 	"github.com/caarlos0/log"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +22,7 @@ var (
 )
 
 func Execute(version goversion.Info, exit func(int), args []string) {
+	httpclient.Version = version.GitVersion
 	newRootCmd(version, exit).Execute(args)
 }
 
@@ -48,9 +53,10 @@ func (cmd *rootCmd) Execute(args []string) {
 }
 
 type rootCmd struct {
-	cmd     *cobra.Command
-	verbose bool
-	exit    func(int)
+	cmd       *cobra.Command
+	verbose   bool
+	logFormat string
+	exit      func(int)
 }
 
 func newRootCmd(version goversion.Info, exit func(int)) *rootCmd {
@@ -74,11 +80,20 @@ Check out our website for more information, examples and documentation: https://
 		SilenceErrors:     true,
 		Args:              cobra.NoArgs,
 		ValidArgsFunction: cobra.NoFileCompletions,
-		PersistentPreRun: func(*cobra.Command, []string) {
+		PersistentPreRunE: func(*cobra.Command, []string) error {
+			switch root.logFormat {
+			case "text":
+			case "json":
+				lipgloss.SetColorProfile(termenv.Ascii)
+				log.Log = logext.NewJSONLogger(os.Stderr)
+			default:
+				return fmt.Errorf("invalid log format %q, valid options are text and json", root.logFormat)
+			}
 			if root.verbose {
 				log.SetLevel(log.DebugLevel)
 				log.Debug("verbose output enabled")
 			}
+			return nil
 		},
 		PersistentPostRun: func(*cobra.Command, []string) {
 			log.Info("thanks for using goreleaser!")
@@ -87,9 +102,12 @@ Check out our website for more information, examples and documentation: https://
 	cmd.SetVersionTemplate("{{.Version}}")
 
 	cmd.PersistentFlags().BoolVar(&root.verbose, "verbose", false, "Enable verbose mode")
+	cmd.PersistentFlags().StringVar(&root.logFormat, "log-format", "text", "Log format, either text or json")
+	_ = cmd.RegisterFlagCompletionFunc("log-format", cobra.FixedCompletions([]string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp))
 	cmd.AddCommand(
 		newBuildCmd().cmd,
 		newReleaseCmd().cmd,
+		newReleaseNotesCmd().cmd,
 		newCheckCmd().cmd,
 		newHealthcheckCmd().cmd,
 		newInitCmd().cmd,
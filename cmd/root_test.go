@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"bytes"
+	"os"
 	"strings"
 	"testing"
 
 	goversion "github.com/caarlos0/go-version"
+	"github.com/caarlos0/log"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 )
@@ -63,6 +65,29 @@ func TestRootReleaseVerbose(t *testing.T) {
 	require.Equal(t, 1, mem.code)
 }
 
+func TestRootLogFormat(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		mem := &exitMemento{}
+		cmd := newRootCmd(testversion, mem.Exit)
+		cmd.Execute([]string{"check", "--log-format=xml", "-f", "testdata/good.yml"})
+		require.Equal(t, 1, mem.code)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		strs, styles := log.Strings, log.Styles
+		t.Cleanup(func() {
+			log.Log = log.New(os.Stderr)
+			log.Strings, log.Styles = strs, styles
+		})
+		setup(t)
+		mem := &exitMemento{}
+		cmd := newRootCmd(testversion, mem.Exit)
+		cmd.Execute([]string{"build", "--snapshot", "--log-format=json"})
+		require.Equal(t, 0, mem.code)
+		require.Equal(t, "info", log.Strings[log.InfoLevel])
+	})
+}
+
 func TestShouldPrependRelease(t *testing.T) {
 	result := func(args []string) bool {
 		return shouldPrependRelease(newRootCmd(testversion, func(_ int) {}).cmd, args)
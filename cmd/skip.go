@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/announce"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/publish"
+	"github.com/goreleaser/goreleaser/v2/internal/pipeline"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+)
+
+// namespacedSkips returns the --skip options that skip a single announcer or
+// publisher of the given pipes, e.g. announce:twitter.
+func namespacedSkips(pipes []pipeline.Piper) []string {
+	var keys []string
+	for _, p := range pipes {
+		switch p := p.(type) {
+		case publish.Pipe:
+			for _, publisher := range p.Publishers() {
+				keys = append(keys, string(skips.Publish.Of(publisher.Key())))
+			}
+		case announce.Pipe:
+			for _, announcer := range announce.Announcers() {
+				keys = append(keys, string(skips.Announce.Of(announcer.Key())))
+			}
+		}
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+// checkNamespacedSkips returns an error if any of the given namespaced
+// --skip options isn't an announcer or publisher of the given pipes.
+func checkNamespacedSkips(keys []string, pipes []pipeline.Piper) error {
+	known := namespacedSkips(pipes)
+	for _, key := range keys {
+		if strings.Contains(key, ":") && !slices.Contains(known, key) {
+			return fmt.Errorf("--skip=%s is not allowed. Valid namespaced options for skip are [%s]", key, strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
+
+// completeSkips completes the given --skip prefix with the given keys, as
+// well as with the namespaced options of the given pipes once the prefix
+// has a colon.
+func completeSkips(keys skips.Keys, pipes []pipeline.Piper, prefix string) []string {
+	result := keys.Complete(prefix)
+	if !strings.Contains(prefix, ":") {
+		return result
+	}
+	for _, key := range namespacedSkips(pipes) {
+		if strings.HasPrefix(key, strings.ToLower(prefix)) {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// onlySkips returns the --skip options equivalent to the given --only ones,
+// i.e. everything that can be skipped but them and the options they require,
+// which are included automatically.
+//
+// validate and before are never skipped by it, and the given --skip options
+// can't skip anything that --only needs.
+func onlySkips(only, skip []string, pipes []pipeline.Piper) ([]string, error) {
+	known := namespacedSkips(pipes)
+	keep := map[string]bool{}
+	var include func(key, by string)
+	include = func(key, by string) {
+		if keep[key] {
+			return
+		}
+		if by != "" {
+			log.Infof("--only=%s requires %s, including it", by, key)
+		}
+		keep[key] = true
+		for _, req := range skips.Requires[skips.Key(key)] {
+			include(string(req), key)
+		}
+	}
+	for _, key := range only {
+		if !slices.Contains(skips.Release, skips.Key(key)) && !slices.Contains(known, key) {
+			return nil, fmt.Errorf("--only=%s is not allowed. Valid options for only are the same as for skip", key)
+		}
+		include(key, "")
+	}
+	for _, key := range skip {
+		if keep[key] {
+			return nil, fmt.Errorf("--skip=%s can't be used with --only=%s, which requires it", key, strings.Join(only, ","))
+		}
+	}
+
+	var result []string
+	for _, key := range skips.Release {
+		switch key {
+		case skips.Validate, skips.Before:
+		case skips.Publish, skips.Announce:
+			if keep[string(key)] {
+				continue
+			}
+			var keys []string
+			var kept bool
+			for _, name := range known {
+				if !strings.HasPrefix(name, string(key)+":") {
+					continue
+				}
+				if keep[name] {
+					kept = true
+					continue
+				}
+				keys = append(keys, name)
+			}
+			if !kept {
+				keys = []string{string(key)}
+			}
+			result = append(result, keys...)
+		default:
+			if !keep[string(key)] {
+				result = append(result, string(key))
+			}
+		}
+	}
+	return result, nil
+}
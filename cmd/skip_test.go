@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/pipeline"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespacedSkips(t *testing.T) {
+	keys := namespacedSkips(pipeline.Pipeline)
+	require.Contains(t, keys, "announce:twitter")
+	require.Contains(t, keys, "publish:chocolatey")
+	require.Contains(t, keys, "publish:brew")
+	require.Contains(t, keys, "publish:docker")
+	require.Contains(t, keys, "publish:docker-manifest")
+	require.Contains(t, keys, "publish:release")
+	require.Contains(t, keys, "publish:release-mirrors")
+	require.Contains(t, keys, "publish:tag")
+	require.Empty(t, namespacedSkips(pipeline.BuildPipeline))
+}
+
+func TestCheckNamespacedSkips(t *testing.T) {
+	require.NoError(t, checkNamespacedSkips([]string{"announce", "announce:twitter", "publish:brew"}, pipeline.Pipeline))
+	require.ErrorContains(t, checkNamespacedSkips([]string{"announce:nope"}, pipeline.Pipeline), "--skip=announce:nope is not allowed")
+	require.ErrorContains(t, checkNamespacedSkips([]string{"sign:nope"}, pipeline.Pipeline), "--skip=sign:nope is not allowed")
+}
+
+func TestCompleteSkips(t *testing.T) {
+	require.Equal(t, []string{"announce"}, completeSkips(skips.Release, pipeline.Pipeline, "ann"))
+	require.Equal(t, []string{"announce:teams", "announce:telegram"}, completeSkips(skips.Release, pipeline.Pipeline, "announce:te"))
+}
+
+func TestReleaseSkipSingle(t *testing.T) {
+	setup(t)
+	cmd := newReleaseCmd()
+	cmd.cmd.SetArgs([]string{"--snapshot", "--skip=announce:nope"})
+	require.ErrorContains(t, cmd.cmd.Execute(), "--skip=announce:nope is not allowed")
+}
+
+func TestOnlySkips(t *testing.T) {
+	t.Run("docker", func(t *testing.T) {
+		keys, err := onlySkips([]string{"docker"}, nil, pipeline.Pipeline)
+		require.NoError(t, err)
+		require.Contains(t, keys, "announce")
+		require.Contains(t, keys, "archive")
+		require.Contains(t, keys, "homebrew")
+		require.Contains(t, keys, "publish:release")
+		require.Contains(t, keys, "publish:brew")
+		require.NotContains(t, keys, "docker")
+		require.NotContains(t, keys, "publish")
+		require.NotContains(t, keys, "publish:docker")
+		require.NotContains(t, keys, "publish:docker-manifest")
+		require.Contains(t, keys, "publish:docker-sign")
+		require.NotContains(t, keys, "validate")
+		require.NotContains(t, keys, "before")
+	})
+
+	t.Run("requirements", func(t *testing.T) {
+		keys, err := onlySkips([]string{"homebrew"}, nil, pipeline.Pipeline)
+		require.NoError(t, err)
+		require.NotContains(t, keys, "archive")
+		require.NotContains(t, keys, "publish:release")
+		require.NotContains(t, keys, "publish:brew")
+		require.NotContains(t, keys, "publish:tag")
+		require.Contains(t, keys, "publish:scoop")
+		require.Contains(t, keys, "scoop")
+	})
+
+	t.Run("tag", func(t *testing.T) {
+		keys, err := onlySkips([]string{"publish:brew"}, nil, pipeline.Pipeline)
+		require.NoError(t, err)
+		require.NotContains(t, keys, "publish:brew")
+		require.NotContains(t, keys, "publish:release")
+		require.NotContains(t, keys, "publish:tag")
+		require.Contains(t, keys, "publish:milestone")
+
+		_, err = onlySkips([]string{"publish:brew"}, []string{"publish:tag"}, pipeline.Pipeline)
+		require.EqualError(t, err, "--skip=publish:tag can't be used with --only=publish:brew, which requires it")
+	})
+
+	t.Run("nothing published", func(t *testing.T) {
+		keys, err := onlySkips([]string{"archive", "announce:slack"}, nil, pipeline.Pipeline)
+		require.NoError(t, err)
+		require.Contains(t, keys, "publish")
+		require.NotContains(t, keys, "publish:release")
+		require.NotContains(t, keys, "announce")
+		require.NotContains(t, keys, "announce:slack")
+		require.Contains(t, keys, "announce:twitter")
+	})
+
+	t.Run("all publishers", func(t *testing.T) {
+		keys, err := onlySkips([]string{"publish"}, nil, pipeline.Pipeline)
+		require.NoError(t, err)
+		require.NotContains(t, keys, "publish")
+		require.NotContains(t, keys, "publish:release")
+	})
+
+	t.Run("with skip", func(t *testing.T) {
+		keys, err := onlySkips([]string{"homebrew"}, []string{"sign"}, pipeline.Pipeline)
+		require.NoError(t, err)
+		require.Contains(t, keys, "sign")
+	})
+
+	t.Run("skip required", func(t *testing.T) {
+		_, err := onlySkips([]string{"homebrew"}, []string{"archive"}, pipeline.Pipeline)
+		require.EqualError(t, err, "--skip=archive can't be used with --only=homebrew, which requires it")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := onlySkips([]string{"nope"}, nil, pipeline.Pipeline)
+		require.EqualError(t, err, "--only=nope is not allowed. Valid options for only are the same as for skip")
+	})
+}
+
+func TestReleaseOnly(t *testing.T) {
+	setup(t)
+	cmd := newReleaseCmd()
+	cmd.cmd.SetArgs([]string{"--skip=publish", "--only=archive"})
+	require.NoError(t, cmd.cmd.Execute())
+	require.FileExists(t, "dist/fake_0.0.2_checksums.txt")
+}
@@ -49,7 +49,16 @@ func Eval(template *tmpl.Template, files []config.File) ([]config.File, error) {
 			prefix = filepath.Dir(longestCommonPrefix(files))
 		}
 
+		excluded, err := excludedFiles(template, f.Exclude)
+		if err != nil {
+			return result, err
+		}
+
 		for _, file := range files {
+			if excluded[filepath.Clean(file)] {
+				log.WithField("file", file).Debug("excluded")
+				continue
+			}
 			dst, err := destinationFor(f, prefix, file)
 			if err != nil {
 				return nil, err
@@ -69,6 +78,26 @@ func Eval(template *tmpl.Template, files []config.File) ([]config.File, error) {
 	return unique(result), nil
 }
 
+// excludedFiles returns the files matching any of the given exclude globs.
+// Directories that match have all their contents excluded.
+func excludedFiles(template *tmpl.Template, excludes []string) (map[string]bool, error) {
+	result := map[string]bool{}
+	for _, exclude := range excludes {
+		glob, err := template.Apply(exclude)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply template %s: %w", exclude, err)
+		}
+		files, err := fileglob.Glob(glob, fileglob.MatchDirectoryIncludesContents)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("globbing failed for exclude pattern %s: %w", glob, err)
+		}
+		for _, file := range files {
+			result[filepath.Clean(file)] = true
+		}
+	}
+	return result, nil
+}
+
 func tmplInfo(template *tmpl.Template, info *config.FileInfo) error {
 	if err := template.ApplyAll(
 		&info.Owner,
@@ -198,6 +198,82 @@ func TestEval(t *testing.T) {
 		}, result)
 	})
 
+	t.Run("exclude", func(t *testing.T) {
+		result, err := Eval(tmpl, []config.File{{
+			Source:  "./testdata/a",
+			Exclude: []string{"./testdata/**/a.txt"},
+		}})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/b/c/d.txt", Destination: "testdata/a/b/c/d.txt"},
+		}, result)
+	})
+
+	t.Run("exclude directory", func(t *testing.T) {
+		result, err := Eval(tmpl, []config.File{{
+			Source:      "./testdata/a",
+			Destination: "usr/local/test",
+			Exclude:     []string{"./testdata/a/b/c"},
+		}})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/a.txt", Destination: "usr/local/test/a.txt"},
+			{Source: "testdata/a/b/a.txt", Destination: "usr/local/test/b/a.txt"},
+		}, result)
+	})
+
+	t.Run("exclude only applies to its own include", func(t *testing.T) {
+		result, err := Eval(tmpl, []config.File{
+			{
+				Source:  "./testdata/a/b",
+				Exclude: []string{"./testdata/**/d.txt"},
+			},
+			{
+				Source: "./testdata/**/d.txt",
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/b/a.txt", Destination: "testdata/a/b/a.txt"},
+			{Source: "testdata/a/b/c/d.txt", Destination: "testdata/a/b/c/d.txt"},
+		}, result)
+	})
+
+	t.Run("exclude no match", func(t *testing.T) {
+		result, err := Eval(tmpl, []config.File{{
+			Source:  "./testdata/a/b/c",
+			Exclude: []string{"./testdata/**/*.pdb", "./testdata/nope"},
+		}})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/b/c/d.txt", Destination: "testdata/a/b/c/d.txt"},
+		}, result)
+	})
+
+	t.Run("templated exclude", func(t *testing.T) {
+		result, err := Eval(tmpl, []config.File{{
+			Source:  "./testdata/a/b",
+			Exclude: []string{"./testdata/**/{{ .Env.FOLDER }}.txt"},
+		}})
+
+		require.NoError(t, err)
+		require.Equal(t, []config.File{
+			{Source: "testdata/a/b/a.txt", Destination: "testdata/a/b/a.txt"},
+		}, result)
+	})
+
+	t.Run("templated exclude error", func(t *testing.T) {
+		_, err := Eval(tmpl, []config.File{{
+			Source:  "./testdata/a/b",
+			Exclude: []string{"./testdata/**/{{ .Env.NOPE }}.txt"},
+		}})
+		testlib.RequireTemplateError(t, err)
+	})
+
 	t.Run("match multiple files within tree without destination", func(t *testing.T) {
 		result, err := Eval(tmpl, []config.File{{Source: "./testdata/a"}})
 
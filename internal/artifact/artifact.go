@@ -90,6 +90,25 @@ const (
 	CShared
 	// Metadata is an internal goreleaser metadata JSON file.
 	Metadata
+	// Completion is a shell completion script.
+	Completion
+	// ManPage is a man page.
+	ManPage
+	// Flatpak is a flatpak bundle.
+	Flatpak
+	// AppImage is an AppImage.
+	AppImage
+	// Installer is a Windows installer (MSI).
+	Installer
+	// ScanReport is a vulnerability scan report of a docker image.
+	ScanReport
+	// Plugin is a Go plugin, generated via a build with buildmode=plugin.
+	Plugin
+	// Release is a release published to a SCM, its URL is in ExtraURL.
+	Release
+	// BinarySignature is a signature (or certificate) of a binary, made
+	// before archiving it, so it can be added to its archives.
+	BinarySignature
 )
 
 func (t Type) String() string {
@@ -144,26 +163,65 @@ func (t Type) String() string {
 		return "Nixpkg"
 	case Metadata:
 		return "Metadata"
+	case Completion:
+		return "Completion"
+	case ManPage:
+		return "Man Page"
+	case Flatpak:
+		return "Flatpak"
+	case AppImage:
+		return "AppImage"
+	case Installer:
+		return "Installer"
+	case ScanReport:
+		return "Scan Report"
+	case Plugin:
+		return "Go Plugin"
+	case Release:
+		return "Release"
+	case BinarySignature:
+		return "Binary Signature"
 	default:
 		return "unknown"
 	}
 }
 
 const (
-	ExtraID         = "ID"
-	ExtraBinary     = "Binary"
-	ExtraExt        = "Ext"
-	ExtraFormat     = "Format"
-	ExtraWrappedIn  = "WrappedIn"
-	ExtraBinaries   = "Binaries"
-	ExtraRefresh    = "Refresh"
-	ExtraReplaces   = "Replaces"
-	ExtraDigest     = "Digest"
-	ExtraSize       = "Size"
-	ExtraChecksum   = "Checksum"
-	ExtraChecksumOf = "ChecksumOf"
+	ExtraID          = "ID"
+	ExtraBinary      = "Binary"
+	ExtraExt         = "Ext"
+	ExtraFormat      = "Format"
+	ExtraWrappedIn   = "WrappedIn"
+	ExtraBinaries    = "Binaries"
+	ExtraDocs        = "Docs"
+	ExtraRefresh     = "Refresh"
+	ExtraReplaces    = "Replaces"
+	ExtraDigest      = "Digest"
+	ExtraSize        = "Size"
+	ExtraChecksum    = "Checksum"
+	ExtraChecksumOf  = "ChecksumOf"
+	ExtraSignatureOf = "SignatureOf"
+	ExtraShell       = "Shell"
+	ExtraSection     = "Section"
+	ExtraURL         = "URL"
+	ExtraPullRequest = "PullRequest"
 )
 
+// CompletionName returns the name the given shell expects the completion file
+// of the given binary to have.
+func CompletionName(shell, binary string) (string, error) {
+	switch shell {
+	case "bash":
+		return binary, nil
+	case "zsh":
+		return "_" + binary, nil
+	case "fish":
+		return binary + ".fish", nil
+	default:
+		return "", fmt.Errorf("invalid shell: %q", shell)
+	}
+}
+
 // Extras represents the extra fields in an artifact.
 type Extras map[string]any
 
@@ -294,6 +352,20 @@ func (a Artifact) Checksum(algorithm string) (string, error) {
 	return check, nil
 }
 
+// Checksums calculates the checksums of the given artifacts, and returns them
+// in the same format as the checksums file, one artifact per line.
+func Checksums(algorithm string, artifacts []*Artifact) (string, error) {
+	lines := make([]string, 0, len(artifacts))
+	for _, a := range artifacts {
+		sum, err := a.Checksum(algorithm)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s", sum, a.Name))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
 var noRefresh = func() error { return nil }
 
 // Refresh executes a Refresh extra function on artifacts, if it exists.
@@ -507,6 +579,22 @@ func ByIDs(ids ...string) Filter {
 	return Or(filters...)
 }
 
+// ByDocsOf filters the shell completions and man pages of the given binaries:
+// the ones generated by their builds, and the ones declared for all of them,
+// which have no ID.
+func ByDocsOf(binaries []*Artifact) Filter {
+	ids := map[string]bool{}
+	for _, bin := range binaries {
+		ids[bin.ID()] = true
+	}
+	return And(
+		Or(ByType(Completion), ByType(ManPage)),
+		func(a *Artifact) bool {
+			return a.ID() == "" || ids[a.ID()]
+		},
+	)
+}
+
 // ByExt filter artifact by their 'Ext' extra field.
 func ByExt(exts ...string) Filter {
 	filters := make([]Filter, 0, len(exts))
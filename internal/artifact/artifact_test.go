@@ -355,6 +355,27 @@ func TestChecksum(t *testing.T) {
 	}
 }
 
+func TestChecksums(t *testing.T) {
+	folder := t.TempDir()
+	var artifacts []*Artifact
+	for _, name := range []string{"foo", "bar"} {
+		file := filepath.Join(folder, name)
+		require.NoError(t, os.WriteFile(file, []byte("lorem ipsum"), 0o644))
+		artifacts = append(artifacts, &Artifact{
+			Name: name,
+			Path: file,
+		})
+	}
+
+	sums, err := Checksums("sha256", artifacts)
+	require.NoError(t, err)
+	require.Equal(t, "5e2bf57d3f40c4b6df69daf1936cb766f832374b4fc0259a7cbff06e2f70f269  foo\n5e2bf57d3f40c4b6df69daf1936cb766f832374b4fc0259a7cbff06e2f70f269  bar", sums)
+
+	artifacts = append(artifacts, &Artifact{Path: filepath.Join(folder, "nope")})
+	_, err = Checksums("sha256", artifacts)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func TestChecksumFileDoesntExist(t *testing.T) {
 	file := filepath.Join(t.TempDir(), "nope")
 	artifact := Artifact{
@@ -386,7 +407,7 @@ func TestExtra(t *testing.T) {
 				Use: "docker",
 			},
 			"fail-plz": config.Homebrew{
-				Service: "aaaa",
+				Service: config.HomebrewService{Block: "aaaa"},
 			},
 			"unsupported": func() {},
 			"binaries":    []string{"foo", "bar"},
@@ -472,6 +493,23 @@ func TestByIDs(t *testing.T) {
 	require.Len(t, artifacts.Filter(ByIDs("foo", "bar")).items, 4)
 }
 
+func TestByDocsOf(t *testing.T) {
+	artifacts := New()
+	for _, id := range []string{"foo", "bar", "foo", ""} {
+		artifacts.Add(&Artifact{
+			Name:  id,
+			Type:  Completion,
+			Extra: map[string]interface{}{ExtraID: id},
+		})
+	}
+	artifacts.Add(&Artifact{Name: "foo.1", Type: ManPage, Extra: map[string]interface{}{ExtraID: "foo"}})
+	artifacts.Add(&Artifact{Name: "foo", Type: Binary, Extra: map[string]interface{}{ExtraID: "foo"}})
+
+	bin := &Artifact{Extra: map[string]interface{}{ExtraID: "foo"}}
+	require.Len(t, artifacts.Filter(ByDocsOf([]*Artifact{bin})).items, 4)
+	require.Len(t, artifacts.Filter(ByDocsOf(nil)).items, 1)
+}
+
 func TestByExts(t *testing.T) {
 	data := []*Artifact{
 		{
@@ -939,7 +977,7 @@ func TestArtifactStringer(t *testing.T) {
 }
 
 func TestArtifactTypeStringer(t *testing.T) {
-	for i := 1; i <= 30; i++ {
+	for i := 1; i <= 39; i++ {
 		t.Run(fmt.Sprintf("type-%d-%s", i, Type(i).String()), func(t *testing.T) {
 			require.NotEqual(t, "unknown", Type(i).String())
 		})
@@ -949,3 +987,22 @@ func TestArtifactTypeStringer(t *testing.T) {
 		require.Equal(t, "unknown", Type(99999).String())
 	})
 }
+
+func TestCompletionName(t *testing.T) {
+	for shell, expected := range map[string]string{
+		"bash": "foo",
+		"zsh":  "_foo",
+		"fish": "foo.fish",
+	} {
+		t.Run(shell, func(t *testing.T) {
+			name, err := CompletionName(shell, "foo")
+			require.NoError(t, err)
+			require.Equal(t, expected, name)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := CompletionName("tcsh", "foo")
+		require.EqualError(t, err, `invalid shell: "tcsh"`)
+	})
+}
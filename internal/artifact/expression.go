@@ -0,0 +1,254 @@
+package artifact
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// expressionFields are the artifact fields that can be used in a filter
+// expression.
+//
+//nolint:gochecknoglobals
+var expressionFields = map[string]func(a *Artifact) string{
+	"name":    func(a *Artifact) string { return a.Name },
+	"path":    func(a *Artifact) string { return a.Path },
+	"type":    func(a *Artifact) string { return a.Type.String() },
+	"goos":    func(a *Artifact) string { return a.Goos },
+	"goarch":  func(a *Artifact) string { return a.Goarch },
+	"goarm":   func(a *Artifact) string { return a.Goarm },
+	"gomips":  func(a *Artifact) string { return a.Gomips },
+	"goamd64": func(a *Artifact) string { return a.Goamd64 },
+	"id":      func(a *Artifact) string { return a.ID() },
+	"ext":     func(a *Artifact) string { return ExtraOr(*a, ExtraExt, "") },
+	"format":  func(a *Artifact) string { return ExtraOr(*a, ExtraFormat, "") },
+}
+
+// ByExpression parses the given filter expression into a Filter.
+//
+// An expression is made of comparisons between an artifact field and a
+// double quoted string, e.g. `goos == "linux"`.
+//
+// The supported fields are: name, path, type, goos, goarch, goarm, gomips,
+// goamd64, id, ext and format.
+//
+// The supported comparison operators are `==`, `!=` and `=~`, the latter
+// matching the field against a regular expression.
+// Comparisons can be combined with `&&`, `||`, `!` and parenthesis, with the
+// usual precedence.
+//
+// Example:
+//
+//	type == "Archive" && (goos == "linux" || name =~ "^foo_")
+func ByExpression(expr string) (Filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", expr, err)
+	}
+	p := &exprParser{tokens: tokens}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", expr, err)
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("invalid filter %q: unexpected %s", expr, tok)
+	}
+	return filter, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.value)
+	default:
+		return "'" + t.value + "'"
+	}
+}
+
+var operators = []string{"==", "!=", "=~", "&&", "||", "!"}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := rune(expr[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")"})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(expr) && expr[end] != '"'; end++ {
+				if expr[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			s, err := strconv.Unquote(expr[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %w", i, err)
+			}
+			tokens = append(tokens, token{tokenString, s})
+			i = end + 1
+		case unicode.IsLetter(c):
+			end := i
+			for end < len(expr) && (unicode.IsLetter(rune(expr[end])) || unicode.IsDigit(rune(expr[end]))) {
+				end++
+			}
+			tokens = append(tokens, token{tokenIdent, expr[i:end]})
+			i = end
+		default:
+			var op string
+			for _, o := range operators {
+				if strings.HasPrefix(expr[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+			tokens = append(tokens, token{tokenOperator, op})
+			i += len(op)
+		}
+	}
+	return tokens, nil
+}
+
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *exprParser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{kind: tokenEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() token {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *exprParser) isOperator(op string) bool {
+	tok := p.peek()
+	return tok.kind == tokenOperator && tok.value == op
+}
+
+func (p *exprParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = Or(left, right)
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("&&") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = And(left, right)
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (Filter, error) {
+	if p.isOperator("!") {
+		p.next()
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(a *Artifact) bool { return !filter(a) }, nil
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, fmt.Errorf("expected ')', got %s", tok)
+		}
+		return filter, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (Filter, error) {
+	tok := p.next()
+	if tok.kind != tokenIdent {
+		return nil, fmt.Errorf("expected a field name, got %s", tok)
+	}
+	field, ok := expressionFields[tok.value]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", tok.value)
+	}
+
+	op := p.next()
+	if op.kind != tokenOperator || (op.value != "==" && op.value != "!=" && op.value != "=~") {
+		return nil, fmt.Errorf("expected '==', '!=' or '=~' after %q, got %s", tok.value, op)
+	}
+
+	value := p.next()
+	if value.kind != tokenString {
+		return nil, fmt.Errorf("expected a string after %s, got %s", op, value)
+	}
+
+	switch op.value {
+	case "==":
+		return func(a *Artifact) bool { return field(a) == value.value }, nil
+	case "!=":
+		return func(a *Artifact) bool { return field(a) != value.value }, nil
+	default:
+		re, err := regexp.Compile(value.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %w", value.value, err)
+		}
+		return func(a *Artifact) bool { return re.MatchString(field(a)) }, nil
+	}
+}
@@ -0,0 +1,102 @@
+package artifact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestByExpression(t *testing.T) {
+	linuxArchive := &Artifact{
+		Name:   "foo_linux_amd64.tar.gz",
+		Path:   "dist/foo_linux_amd64.tar.gz",
+		Goos:   "linux",
+		Goarch: "amd64",
+		Type:   UploadableArchive,
+		Extra: map[string]any{
+			ExtraID:     "foo",
+			ExtraFormat: "tar.gz",
+		},
+	}
+	darwinArchive := &Artifact{
+		Name:   "foo_darwin_arm64.zip",
+		Goos:   "darwin",
+		Goarch: "arm64",
+		Type:   UploadableArchive,
+		Extra: map[string]any{
+			ExtraID:     "foo",
+			ExtraFormat: "zip",
+		},
+	}
+	linuxPackage := &Artifact{
+		Name:    "bar_linux_amd64v3.deb",
+		Goos:    "linux",
+		Goarch:  "amd64",
+		Goamd64: "v3",
+		Type:    LinuxPackage,
+		Extra: map[string]any{
+			ExtraID:  "bar",
+			ExtraExt: ".deb",
+		},
+	}
+	checksum := &Artifact{
+		Name: "checksums.txt",
+		Type: Checksum,
+	}
+	all := []*Artifact{linuxArchive, darwinArchive, linuxPackage, checksum}
+
+	for expr, expected := range map[string][]*Artifact{
+		`type == "Archive"`:                                      {linuxArchive, darwinArchive},
+		`type == "Archive" && goos == "linux"`:                   {linuxArchive},
+		`type=="Archive"&&goos=="linux"`:                         {linuxArchive},
+		`goos == "linux" || type == "Checksum"`:                  {linuxArchive, linuxPackage, checksum},
+		`goos != "linux"`:                                        {darwinArchive, checksum},
+		`!(goos == "linux")`:                                     {darwinArchive, checksum},
+		`!goos == "linux"`:                                       {darwinArchive, checksum},
+		`type == "Archive" && (goos == "darwin" || id == "bar")`: {darwinArchive},
+		`goos == "darwin" || goos == "linux" && id == "bar"`:     {darwinArchive, linuxPackage},
+		`name =~ "\\.(zip|deb)$"`:                                {darwinArchive, linuxPackage},
+		`path =~ "^dist/"`:                                       {linuxArchive},
+		`goamd64 == "v3"`:                                        {linuxPackage},
+		`ext == ".deb" || format == "zip"`:                       {darwinArchive, linuxPackage},
+		`goarch == "amd64" && goarm == "" && gomips == ""`:       {linuxArchive, linuxPackage},
+		`name == "foo \"quoted\""`:                               nil,
+	} {
+		t.Run(expr, func(t *testing.T) {
+			filter, err := ByExpression(expr)
+			require.NoError(t, err)
+			var result []*Artifact
+			for _, a := range all {
+				if filter(a) {
+					result = append(result, a)
+				}
+			}
+			require.Equal(t, expected, result)
+		})
+	}
+}
+
+func TestByExpressionErrors(t *testing.T) {
+	for expr, expected := range map[string]string{
+		``:                         "expected a field name, got end of expression",
+		`goos`:                     `expected '==', '!=' or '=~' after "goos", got end of expression`,
+		`goos ==`:                  "expected a string after '==', got end of expression",
+		`goos == linux`:            "expected a string after '==', got 'linux'",
+		`os == "linux"`:            `unknown field "os"`,
+		`goos = "linux"`:           `unexpected character '=' at position 5`,
+		`goos == "linux`:           "unterminated string at position 8",
+		`(goos == "linux"`:         "expected ')', got end of expression",
+		`goos == "linux")`:         "unexpected ')'",
+		`goos == "linux" goarch`:   "unexpected 'goarch'",
+		`goos == "linux" && `:      "expected a field name, got end of expression",
+		`name =~ "["`:              `invalid regular expression "["`,
+		`"linux" == goos`:          `expected a field name, got "linux"`,
+		`goos == "linux" & goarch`: "unexpected character '&' at position 16",
+		`goos == "linux" || || id`: "expected a field name, got '||'",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ByExpression(expr)
+			require.ErrorContains(t, err, expected)
+		})
+	}
+}
@@ -50,10 +50,22 @@ func (*Builder) WithDefaults(build config.Build) (config.Build, error) {
 	if build.Main == "" {
 		build.Main = "."
 	}
-	if len(build.Ldflags) == 0 {
+	if len(build.Ldflags) == 0 && build.LdflagsFile == "" {
 		build.Ldflags = []string{"-s -w -X main.version={{.Version}} -X main.commit={{.Commit}} -X main.date={{.Date}} -X main.builtBy=goreleaser"}
 	}
 
+	if err := checkBuildmode(build.Buildmode); err != nil {
+		return build, err
+	}
+	for _, o := range build.BuildDetailsOverrides {
+		if err := checkBuildmode(o.Buildmode); err != nil {
+			return build, err
+		}
+		if !slices.Contains([]string{"", ldflagsModeReplace, ldflagsModeAppend}, o.LdflagsMode) {
+			return build, fmt.Errorf("invalid ldflags_mode %q, valid options are %q and %q", o.LdflagsMode, ldflagsModeReplace, ldflagsModeAppend)
+		}
+	}
+
 	_ = warnIfTargetsAndOtherOptionTogether(build)
 	if len(build.Targets) == 0 {
 		if len(build.Goos) == 0 {
@@ -144,6 +156,11 @@ const (
 	goStableFirstClassTargetsName = "go_first_class"
 )
 
+const (
+	ldflagsModeReplace = "replace"
+	ldflagsModeAppend  = "append"
+)
+
 // go tool dist list -json | jq -r '.[] | select(.FirstClass) | [.GOOS, .GOARCH] | @tsv'
 var go118FirstClassTargets = []string{
 	"darwin_amd64_v1",
@@ -178,18 +195,24 @@ func (*Builder) Build(ctx *context.Context, build config.Build, options api.Opti
 		},
 	}
 
-	if build.Buildmode == "c-archive" {
+	details, err := withOverrides(ctx, build, options)
+	if err != nil {
+		return err
+	}
+	if err := checkBuildmodeSupported(details.Buildmode, options.Goos, options.Goarch); err != nil {
+		return err
+	}
+
+	if details.Buildmode == "c-archive" {
 		a.Type = artifact.CArchive
 		ctx.Artifacts.Add(getHeaderArtifactForLibrary(build, options))
 	}
-	if build.Buildmode == "c-shared" {
+	if details.Buildmode == "c-shared" {
 		a.Type = artifact.CShared
 		ctx.Artifacts.Add(getHeaderArtifactForLibrary(build, options))
 	}
-
-	details, err := withOverrides(ctx, build, options)
-	if err != nil {
-		return err
+	if details.Buildmode == "plugin" {
+		a.Type = artifact.Plugin
 	}
 
 	env := []string{}
@@ -232,8 +255,8 @@ func (*Builder) Build(ctx *context.Context, build config.Build, options api.Opti
 		return err
 	}
 
-	if err := run(ctx, cmd, env, build.Dir); err != nil {
-		return fmt.Errorf("failed to build for %s: %w", options.Target, err)
+	if err := buildWithCache(ctx, build, details, options, cmd, env); err != nil {
+		return err
 	}
 
 	modTimestamp, err := tmpl.New(ctx).WithEnvS(env).WithArtifact(a).Apply(build.ModTimestamp)
@@ -248,34 +271,160 @@ func (*Builder) Build(ctx *context.Context, build config.Build, options api.Opti
 	return nil
 }
 
-func withOverrides(ctx *context.Context, build config.Build, options api.Options) (config.BuildDetails, error) {
-	optsTarget := options.Goos + options.Goarch + options.Goarm + options.Gomips + options.Goamd64
-	for _, o := range build.BuildDetailsOverrides {
-		overrideTarget, err := tmpl.New(ctx).Apply(o.Goos + o.Goarch + o.Gomips + o.Goarm + o.Goamd64)
+// buildWithCache runs the given build command, unless the build is cached
+// and the binary built with the same inputs is in the cache, in which case
+// it is copied from there instead.
+//
+// Failing to read or write the cache never fails the build.
+func buildWithCache(ctx *context.Context, build config.Build, details config.BuildDetails, options api.Options, cmd, env []string) error {
+	var key string
+	dir := cacheDirFor(ctx, build, options)
+	if build.Cache && !slices.Contains([]string{"c-archive", "c-shared"}, details.Buildmode) {
+		var err error
+		key, err = cacheKey(ctx, build, cmd, env)
 		if err != nil {
-			return build.BuildDetails, err
+			log.WithError(err).Warn("could not compute the build cache key, building without the cache")
+		}
+	}
+	if key != "" {
+		hit, err := restoreFromCache(dir, key, options)
+		if err != nil {
+			log.WithError(err).Warn("could not restore the build from the cache, building it")
+		}
+		if hit {
+			log.WithField("binary", options.Name).Info("using cached build")
+			return nil
+		}
+	}
+
+	if err := run(ctx, cmd, env, build.Dir); err != nil {
+		return fmt.Errorf("failed to build for %s: %w", options.Target, err)
+	}
+
+	if key != "" {
+		if err := saveToCache(dir, key, options); err != nil {
+			log.WithError(err).Warn("could not save the build to the cache")
 		}
+	}
+	return nil
+}
+
+// Buildmode returns the build mode of the given build for the given target,
+// taking its overrides into account.
+func Buildmode(ctx *context.Context, build config.Build, options api.Options) (string, error) {
+	o, err := matchOverride(ctx, build.BuildDetailsOverrides, options)
+	if err != nil {
+		return "", err
+	}
+	if o != nil && o.Buildmode != "" {
+		return o.Buildmode, nil
+	}
+	return build.Buildmode, nil
+}
+
+// withOverrides returns the build details of the given build, with the most
+// specific override matching the target applied on top of them.
+//
+// Empty override fields match any value, the override with the most fields
+// set wins, and ties are won by the first one declared.
+func withOverrides(ctx *context.Context, build config.Build, options api.Options) (config.BuildDetails, error) {
+	base, err := withLdflagsFile(ctx, build.BuildDetails)
+	if err != nil {
+		return build.BuildDetails, err
+	}
+
+	o, err := matchOverride(ctx, build.BuildDetailsOverrides, options)
+	if err != nil || o == nil {
+		return base, err
+	}
+
+	override, err := withLdflagsFile(ctx, o.BuildDetails)
+	if err != nil {
+		return build.BuildDetails, err
+	}
+
+	dets := config.BuildDetails{
+		Buildmode: base.Buildmode,
+		Ldflags:   base.Ldflags,
+		Tags:      base.Tags,
+		Flags:     base.Flags,
+		Asmflags:  base.Asmflags,
+		Gcflags:   base.Gcflags,
+	}
+	if err := mergo.Merge(&dets, override, mergo.WithOverride); err != nil {
+		return build.BuildDetails, err
+	}
+	if o.LdflagsMode == ldflagsModeAppend {
+		dets.Ldflags = append(slices.Clone(base.Ldflags), override.Ldflags...)
+	}
+
+	dets.Env = context.ToEnv(append(build.Env, o.BuildDetails.Env...)).Strings()
+	log.WithField("details", dets).Infof("overridden build details for %s", options.Target)
+	return dets, nil
+}
 
-		if optsTarget == overrideTarget {
-			dets := config.BuildDetails{
-				Buildmode: build.BuildDetails.Buildmode,
-				Ldflags:   build.BuildDetails.Ldflags,
-				Tags:      build.BuildDetails.Tags,
-				Flags:     build.BuildDetails.Flags,
-				Asmflags:  build.BuildDetails.Asmflags,
-				Gcflags:   build.BuildDetails.Gcflags,
+func matchOverride(ctx *context.Context, overrides []config.BuildDetailsOverride, options api.Options) (*config.BuildDetailsOverride, error) {
+	var match *config.BuildDetailsOverride
+	best := 0
+	for i, o := range overrides {
+		score := 0
+		matches := true
+		for _, field := range [][2]string{
+			{o.Goos, options.Goos},
+			{o.Goarch, options.Goarch},
+			{o.Goarm, options.Goarm},
+			{o.Gomips, options.Gomips},
+			{o.Goamd64, options.Goamd64},
+		} {
+			value, err := tmpl.New(ctx).Apply(field[0])
+			if err != nil {
+				return nil, err
 			}
-			if err := mergo.Merge(&dets, o.BuildDetails, mergo.WithOverride); err != nil {
-				return build.BuildDetails, err
+			if value == "" {
+				continue
 			}
-
-			dets.Env = context.ToEnv(append(build.Env, o.BuildDetails.Env...)).Strings()
-			log.WithField("details", dets).Infof("overridden build details for %s", optsTarget)
-			return dets, nil
+			if value != field[1] {
+				matches = false
+				break
+			}
+			score++
+		}
+		if matches && score > best {
+			match = &overrides[i]
+			best = score
 		}
 	}
+	return match, nil
+}
 
-	return build.BuildDetails, nil
+// withLdflagsFile returns the given details with the ldflags read from its
+// ldflags file, if any, appended to its ldflags.
+//
+// The file should have one flag per line, empty lines and lines starting with
+// '#' are ignored.
+func withLdflagsFile(ctx *context.Context, details config.BuildDetails) (config.BuildDetails, error) {
+	if details.LdflagsFile == "" {
+		return details, nil
+	}
+	path, err := tmpl.New(ctx).Apply(details.LdflagsFile)
+	if err != nil {
+		return details, err
+	}
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return details, fmt.Errorf("failed to read ldflags file: %w", err)
+	}
+	ldflags := slices.Clone(details.Ldflags)
+	for _, line := range strings.Split(string(bts), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ldflags = append(ldflags, line)
+	}
+	details.Ldflags = ldflags
+	details.LdflagsFile = ""
+	return details, nil
 }
 
 func buildGoBuildLine(
@@ -262,6 +262,24 @@ func TestDefaults(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, "test", build.Command)
 	})
+	t.Run("ldflags file set", func(t *testing.T) {
+		build, err := Default.WithDefaults(config.Build{
+			BuildDetails: config.BuildDetails{
+				LdflagsFile: "ldflags.txt",
+			},
+		})
+		require.NoError(t, err)
+		require.Empty(t, build.Ldflags)
+	})
+	t.Run("invalid ldflags mode", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			BuildDetailsOverrides: []config.BuildDetailsOverride{{
+				Goos:        "linux",
+				LdflagsMode: "prepend",
+			}},
+		})
+		require.EqualError(t, err, `invalid ldflags_mode "prepend", valid options are "replace" and "append"`)
+	})
 }
 
 // createFakeGoBinaryWithVersion creates a temporary executable with the
@@ -1383,6 +1401,168 @@ func TestOverrides(t *testing.T) {
 	})
 }
 
+func TestOverridesPrecedence(t *testing.T) {
+	build := config.Build{
+		BuildDetails: config.BuildDetails{
+			Ldflags: []string{"original"},
+		},
+		BuildDetailsOverrides: []config.BuildDetailsOverride{
+			{
+				Goos: "linux",
+				BuildDetails: config.BuildDetails{
+					Ldflags: []string{"linux"},
+				},
+			},
+			{
+				Goos:    "linux",
+				Goarch:  "amd64",
+				Goamd64: "v3",
+				BuildDetails: config.BuildDetails{
+					Ldflags: []string{"linux_amd64_v3"},
+				},
+			},
+			{
+				Goos:   "linux",
+				Goarch: "amd64",
+				BuildDetails: config.BuildDetails{
+					Ldflags: []string{"linux_amd64"},
+				},
+			},
+			{
+				Goarch: "amd64",
+				Goos:   "{{ .Runtime.Goos }}",
+				BuildDetails: config.BuildDetails{
+					Ldflags: []string{"linux_amd64_again"},
+				},
+			},
+			{
+				Goarch: "arm64",
+				BuildDetails: config.BuildDetails{
+					Ldflags: []string{"arm64"},
+				},
+			},
+		},
+	}
+
+	for target, expected := range map[string]string{
+		"linux_amd64_v1":   "linux_amd64",
+		"linux_amd64_v3":   "linux_amd64_v3",
+		"linux_386":        "linux",
+		"linux_arm64":      "linux",
+		"darwin_arm64":     "arm64",
+		"windows_amd64_v1": "original",
+	} {
+		t.Run(target, func(t *testing.T) {
+			parts := strings.Split(target+"_", "_")
+			dets, err := withOverrides(testctx.New(), build, api.Options{
+				Target:  target,
+				Goos:    parts[0],
+				Goarch:  parts[1],
+				Goamd64: parts[2],
+			})
+			require.NoError(t, err)
+			require.Equal(t, []string{expected}, []string(dets.Ldflags))
+		})
+	}
+}
+
+func TestOverridesLdflags(t *testing.T) {
+	testlib.Mktmp(t)
+	require.NoError(t, os.WriteFile("ldflags_linux.txt", []byte("-X main.os=linux\n\n# comment\n  -X main.libc={{ .Env.LIBC }}\n"), 0o644))
+	require.NoError(t, os.WriteFile("ldflags.txt", []byte("-X main.version={{ .Version }}\n"), 0o644))
+	options := api.Options{Goos: "linux", Goarch: "amd64"}
+	newBuild := func(mode string) config.Build {
+		return config.Build{
+			BuildDetails: config.BuildDetails{
+				Ldflags:     []string{"-s -w"},
+				LdflagsFile: "ldflags.txt",
+			},
+			BuildDetailsOverrides: []config.BuildDetailsOverride{{
+				Goos:        "linux",
+				LdflagsMode: mode,
+				BuildDetails: config.BuildDetails{
+					LdflagsFile: "ldflags_{{ .Runtime.Goos }}.txt",
+				},
+			}},
+		}
+	}
+
+	t.Run("no overrides", func(t *testing.T) {
+		dets, err := withOverrides(testctx.New(), newBuild(""), api.Options{Goos: "darwin", Goarch: "arm64"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"-s -w", "-X main.version={{ .Version }}"}, []string(dets.Ldflags))
+		require.Empty(t, dets.LdflagsFile)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		dets, err := withOverrides(testctx.New(), newBuild("replace"), options)
+		require.NoError(t, err)
+		require.Equal(t, []string{"-X main.os=linux", "-X main.libc={{ .Env.LIBC }}"}, []string(dets.Ldflags))
+	})
+
+	t.Run("default is replace", func(t *testing.T) {
+		dets, err := withOverrides(testctx.New(), newBuild(""), options)
+		require.NoError(t, err)
+		require.Equal(t, []string{"-X main.os=linux", "-X main.libc={{ .Env.LIBC }}"}, []string(dets.Ldflags))
+	})
+
+	t.Run("append", func(t *testing.T) {
+		dets, err := withOverrides(testctx.New(), newBuild("append"), options)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"-s -w",
+			"-X main.version={{ .Version }}",
+			"-X main.os=linux",
+			"-X main.libc={{ .Env.LIBC }}",
+		}, []string(dets.Ldflags))
+	})
+
+	t.Run("replace without ldflags", func(t *testing.T) {
+		build := newBuild("replace")
+		build.BuildDetailsOverrides[0].LdflagsFile = ""
+		build.BuildDetailsOverrides[0].Tags = []string{"foo"}
+		dets, err := withOverrides(testctx.New(), build, options)
+		require.NoError(t, err)
+		require.Equal(t, []string{"-s -w", "-X main.version={{ .Version }}"}, []string(dets.Ldflags))
+		require.Equal(t, []string{"foo"}, []string(dets.Tags))
+	})
+
+	t.Run("build line", func(t *testing.T) {
+		ctx := testctx.New(
+			testctx.WithVersion("1.2.3"),
+			testctx.WithEnv(map[string]string{"LIBC": "musl"}),
+		)
+		build := newBuild("append")
+		build.GoBinary = "go"
+		build.Command = "build"
+		build.Main = "."
+		dets, err := withOverrides(ctx, build, options)
+		require.NoError(t, err)
+		line, err := buildGoBuildLine(ctx, build, dets, api.Options{Path: "foo"}, &artifact.Artifact{}, ctx.Env.Strings())
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"go", "build",
+			"-ldflags=-s -w -X main.version=1.2.3 -X main.os=linux -X main.libc=musl",
+			"-o", "foo", ".",
+		}, line)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		build := newBuild("")
+		build.LdflagsFile = "nope.txt"
+		_, err := withOverrides(testctx.New(), build, options)
+		require.ErrorIs(t, err, os.ErrNotExist)
+		require.ErrorContains(t, err, "failed to read ldflags file")
+	})
+
+	t.Run("invalid file template", func(t *testing.T) {
+		build := newBuild("")
+		build.BuildDetailsOverrides[0].LdflagsFile = "{{ .Nope }"
+		_, err := withOverrides(testctx.New(), build, options)
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
 func TestWarnIfTargetsAndOtherOptionsTogether(t *testing.T) {
 	nonEmpty := []string{"foo", "bar"}
 	for name, fn := range map[string]func(*config.Build){
@@ -1411,6 +1591,214 @@ func TestWarnIfTargetsAndOtherOptionsTogether(t *testing.T) {
 	}
 }
 
+func TestBuildmode(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for _, mode := range []string{"", "default", "exe", "pie", "c-archive", "c-shared", "plugin"} {
+			require.NoError(t, checkBuildmode(mode), mode)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.EqualError(t, checkBuildmode("shared"), `invalid buildmode "shared", valid options are ["default" "exe" "pie" "c-archive" "c-shared" "plugin"]`)
+	})
+
+	t.Run("invalid in defaults", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			BuildDetails: config.BuildDetails{Buildmode: "archive"},
+		})
+		require.ErrorContains(t, err, `invalid buildmode "archive"`)
+	})
+
+	t.Run("invalid in overrides", func(t *testing.T) {
+		_, err := Default.WithDefaults(config.Build{
+			BuildDetailsOverrides: []config.BuildDetailsOverride{{
+				Goos:         "linux",
+				BuildDetails: config.BuildDetails{Buildmode: "archive"},
+			}},
+		})
+		require.ErrorContains(t, err, `invalid buildmode "archive"`)
+	})
+
+	t.Run("supported", func(t *testing.T) {
+		for mode, targets := range map[string][]string{
+			"":          {"windows/arm", "js/wasm"},
+			"exe":       {"linux/amd64"},
+			"pie":       {"linux/amd64", "darwin/arm64", "windows/amd64"},
+			"c-archive": {"linux/amd64", "darwin/arm64", "windows/arm", "ios/arm64"},
+			"c-shared":  {"linux/arm64", "darwin/amd64", "windows/386", "android/arm"},
+			"plugin":    {"linux/amd64", "darwin/arm64", "freebsd/amd64"},
+		} {
+			for _, target := range targets {
+				goos, goarch, _ := strings.Cut(target, "/")
+				require.NoError(t, checkBuildmodeSupported(mode, goos, goarch), mode+" "+target)
+			}
+		}
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		for mode, targets := range map[string][]string{
+			"pie":       {"linux/mips", "js/wasm"},
+			"c-archive": {"js/wasm", "linux/mips64", "android/arm64"},
+			"c-shared":  {"windows/arm", "ios/arm64", "linux/mips"},
+			"plugin":    {"windows/amd64", "linux/riscv64", "android/arm"},
+		} {
+			for _, target := range targets {
+				goos, goarch, _ := strings.Cut(target, "/")
+				require.EqualError(
+					t,
+					checkBuildmodeSupported(mode, goos, goarch),
+					fmt.Sprintf("buildmode %s is not supported on %s", mode, target),
+				)
+			}
+		}
+	})
+}
+
+func TestBuildUnsupportedBuildmode(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	writeGoodMain(t, folder)
+	ctx := testctx.NewWithCfg(config.Project{
+		Builds: []config.Build{{
+			ID:       "foo",
+			Binary:   "foo",
+			GoBinary: "go",
+			Command:  "build",
+			BuildDetails: config.BuildDetails{
+				Buildmode: "plugin",
+			},
+			BuildDetailsOverrides: []config.BuildDetailsOverride{{
+				Goos: "windows",
+				BuildDetails: config.BuildDetails{
+					Buildmode: "c-shared",
+				},
+			}},
+		}},
+	})
+	build := ctx.Config.Builds[0]
+
+	require.EqualError(t, Default.Build(ctx, build, api.Options{
+		Target: "windows_arm",
+		Goos:   "windows",
+		Goarch: "arm",
+		Name:   "foo.dll",
+		Path:   filepath.Join(folder, "dist", "foo.dll"),
+	}), "buildmode c-shared is not supported on windows/arm")
+
+	require.EqualError(t, Default.Build(ctx, build, api.Options{
+		Target: "js_wasm",
+		Goos:   "js",
+		Goarch: "wasm",
+		Name:   "foo.so",
+		Path:   filepath.Join(folder, "dist", "foo.so"),
+	}), "buildmode plugin is not supported on js/wasm")
+}
+
+func TestBuildPlugin(t *testing.T) {
+	if runtime.GOOS != "linux" || runtime.GOARCH != "amd64" {
+		t.Skip("plugins are only tested on linux/amd64")
+	}
+	testlib.CheckPath(t, "gcc")
+	folder := testlib.Mktmp(t)
+	writeGoodMain(t, folder)
+	ctx := testctx.NewWithCfg(config.Project{
+		Builds: []config.Build{{
+			ID:       "foo",
+			Binary:   "foo",
+			GoBinary: "go",
+			Command:  "build",
+			BuildDetails: config.BuildDetails{
+				Buildmode: "plugin",
+				Env:       []string{"CGO_ENABLED=1", "GO111MODULE=off"},
+			},
+		}},
+	})
+	require.NoError(t, Default.Build(ctx, ctx.Config.Builds[0], api.Options{
+		Target:  "linux_amd64_v1",
+		Goos:    "linux",
+		Goarch:  "amd64",
+		Goamd64: "v1",
+		Name:    "foo.so",
+		Path:    filepath.Join(folder, "dist", "foo.so"),
+		Ext:     ".so",
+	}))
+	plugins := ctx.Artifacts.Filter(artifact.ByType(artifact.Plugin)).List()
+	require.Len(t, plugins, 1)
+	require.Equal(t, "foo.so", plugins[0].Name)
+	require.Equal(t, "foo", artifact.ExtraOr(*plugins[0], artifact.ExtraBinary, ""))
+	require.FileExists(t, plugins[0].Path)
+}
+
+func TestBuildPluginOverride(t *testing.T) {
+	if runtime.GOOS != "linux" || runtime.GOARCH != "amd64" {
+		t.Skip("plugins are only tested on linux/amd64")
+	}
+	testlib.CheckPath(t, "gcc")
+	folder := testlib.Mktmp(t)
+	writeGoodMain(t, folder)
+	ctx := testctx.NewWithCfg(config.Project{
+		Builds: []config.Build{{
+			ID:       "foo",
+			Binary:   "foo",
+			GoBinary: "go",
+			Command:  "build",
+			BuildDetails: config.BuildDetails{
+				Env: []string{"CGO_ENABLED=1", "GO111MODULE=off"},
+			},
+			BuildDetailsOverrides: []config.BuildDetailsOverride{{
+				Goos: "linux",
+				BuildDetails: config.BuildDetails{
+					Buildmode: "plugin",
+				},
+			}},
+		}},
+	})
+	require.NoError(t, Default.Build(ctx, ctx.Config.Builds[0], api.Options{
+		Target:  "linux_amd64_v1",
+		Goos:    "linux",
+		Goarch:  "amd64",
+		Goamd64: "v1",
+		Name:    "foo.so",
+		Path:    filepath.Join(folder, "dist", "foo.so"),
+		Ext:     ".so",
+	}))
+	require.Len(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Plugin)).List(), 1)
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Binary)).List())
+}
+
+func TestBuildmodeWithOverrides(t *testing.T) {
+	ctx := testctx.New()
+	build := config.Build{
+		BuildDetails: config.BuildDetails{
+			Buildmode: "c-archive",
+		},
+		BuildDetailsOverrides: []config.BuildDetailsOverride{
+			{
+				Goos: "windows",
+				BuildDetails: config.BuildDetails{
+					Buildmode: "c-shared",
+				},
+			},
+			{
+				Goos: "darwin",
+				BuildDetails: config.BuildDetails{
+					Ldflags: []string{"-s"},
+				},
+			},
+		},
+	}
+	for goos, expected := range map[string]string{
+		"windows": "c-shared",
+		"darwin":  "c-archive",
+		"linux":   "c-archive",
+	} {
+		t.Run(goos, func(t *testing.T) {
+			mode, err := Buildmode(ctx, build, api.Options{Goos: goos, Goarch: "amd64"})
+			require.NoError(t, err)
+			require.Equal(t, expected, mode)
+		})
+	}
+}
+
 func TestInvalidGoBinaryTpl(t *testing.T) {
 	folder := testlib.Mktmp(t)
 	require.NoError(t, os.Mkdir(filepath.Join(folder, ".go"), 0o755))
@@ -0,0 +1,66 @@
+package golang
+
+import (
+	"fmt"
+	"slices"
+)
+
+//nolint:gochecknoglobals
+var (
+	// validBuildmodes are the buildmodes that can be used in a build.
+	// The other ones Go supports only make sense for non-main packages.
+	validBuildmodes = []string{"", "default", "exe", "pie", "c-archive", "c-shared", "plugin"}
+
+	// buildmodePlatforms are the platforms each buildmode is supported on,
+	// the ones not listed here are supported everywhere.
+	//
+	// From https://github.com/golang/go/blob/go1.22.0/src/internal/platform/supported.go
+	buildmodePlatforms = map[string][]string{
+		"c-archive": {
+			"aix/ppc64",
+			"darwin/amd64", "darwin/arm64",
+			"freebsd/amd64",
+			"ios/amd64", "ios/arm64",
+			"linux/386", "linux/amd64", "linux/arm", "linux/armbe", "linux/arm64", "linux/arm64be", "linux/loong64", "linux/ppc64le", "linux/riscv64", "linux/s390x",
+			"windows/386", "windows/amd64", "windows/arm", "windows/arm64",
+		},
+		"c-shared": {
+			"android/386", "android/amd64", "android/arm", "android/arm64",
+			"darwin/amd64", "darwin/arm64",
+			"freebsd/amd64",
+			"linux/386", "linux/amd64", "linux/arm", "linux/arm64", "linux/loong64", "linux/ppc64le", "linux/riscv64", "linux/s390x",
+			"windows/386", "windows/amd64", "windows/arm64",
+		},
+		"pie": {
+			"aix/ppc64",
+			"android/386", "android/amd64", "android/arm", "android/arm64",
+			"darwin/amd64", "darwin/arm64",
+			"freebsd/amd64",
+			"ios/amd64", "ios/arm64",
+			"linux/386", "linux/amd64", "linux/arm", "linux/arm64", "linux/loong64", "linux/ppc64le", "linux/riscv64", "linux/s390x",
+			"openbsd/arm64",
+			"windows/386", "windows/amd64", "windows/arm", "windows/arm64",
+		},
+		"plugin": {
+			"android/386", "android/amd64",
+			"darwin/amd64", "darwin/arm64",
+			"freebsd/amd64",
+			"linux/386", "linux/amd64", "linux/arm", "linux/arm64", "linux/loong64", "linux/ppc64le", "linux/s390x",
+		},
+	}
+)
+
+func checkBuildmode(buildmode string) error {
+	if !slices.Contains(validBuildmodes, buildmode) {
+		return fmt.Errorf("invalid buildmode %q, valid options are %q", buildmode, validBuildmodes[1:])
+	}
+	return nil
+}
+
+func checkBuildmodeSupported(buildmode, goos, goarch string) error {
+	platforms, ok := buildmodePlatforms[buildmode]
+	if !ok || slices.Contains(platforms, goos+"/"+goarch) {
+		return nil
+	}
+	return fmt.Errorf("buildmode %s is not supported on %s/%s", buildmode, goos, goarch)
+}
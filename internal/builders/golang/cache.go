@@ -0,0 +1,307 @@
+package golang
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	api "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	// cacheDirName is the directory, within dist, where the builds are
+	// cached. The dist pipe keeps it when cleaning.
+	cacheDirName = ".cache"
+
+	// cacheVersion is part of every cache key, bump it to invalidate all the
+	// existing caches when the way keys are computed changes.
+	cacheVersion = "2"
+
+	cacheManifestName = "manifest.json"
+)
+
+type cacheManifest struct {
+	Hash string `json:"hash"`
+}
+
+// cacheDirFor returns the directory the given target of the given build is
+// cached in.
+func cacheDirFor(ctx *context.Context, build config.Build, options api.Options) string {
+	return filepath.Join(ctx.Config.Dist, cacheDirName, build.ID+"_"+options.Target)
+}
+
+// cacheKey returns the hash of everything that might change the binary built
+// with the given command and env:
+//
+//   - the output of `go version`;
+//   - the command line, with its flags already templated;
+//   - the GO*, CGO_*, CC, CXX and AR environment variables;
+//   - the path and contents of the files each package the main package
+//     depends on is built from, embedded files included, except for the
+//     standard library and the modules in the module cache, see sourceFiles;
+//   - the path and version of the modules in the module cache;
+//   - the go.mod and go.sum of the other modules.
+func cacheKey(ctx *context.Context, build config.Build, command, env []string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintln(h, "cache", cacheVersion)
+
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, command[0], "version")
+	cmd.Env = env
+	cmd.Dir = build.Dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("could not get the go version: %w", err)
+	}
+	fmt.Fprintln(h, "go", strings.TrimSpace(string(out)))
+
+	for _, arg := range command {
+		fmt.Fprintln(h, "arg", arg)
+	}
+	for _, e := range cacheEnv(env) {
+		fmt.Fprintln(h, "env", e)
+	}
+
+	files, modules, err := sourceFiles(ctx, build, command, env)
+	if err != nil {
+		return "", err
+	}
+	for _, module := range modules {
+		fmt.Fprintln(h, "module", module)
+	}
+	for _, file := range files {
+		sum, err := fileSum(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintln(h, "file", cachePath(file), sum)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// goPackage is the part of the `go list -json` output of a package the cache
+// key uses.
+type goPackage struct {
+	Dir          string
+	Standard     bool
+	Module       *goModule
+	GoFiles      []string
+	CgoFiles     []string
+	CFiles       []string
+	CXXFiles     []string
+	MFiles       []string
+	HFiles       []string
+	FFiles       []string
+	SFiles       []string
+	SwigFiles    []string
+	SwigCXXFiles []string
+	SysoFiles    []string
+	EmbedFiles   []string
+}
+
+type goModule struct {
+	Path    string
+	Version string
+	GoMod   string
+	Main    bool
+	Replace *goModule
+}
+
+// sourceFiles returns the files the main package of the given build and its
+// dependencies are built from, according to `go list -deps`, so generated or
+// git ignored files are taken into account too.
+//
+// The standard library is covered by the go version, and the modules in the
+// module cache, returned as path@version, can't change without their version
+// changing, so their files are not returned.
+// The go.mod and go.sum of the main module and of the modules replaced by
+// local directories are.
+func sourceFiles(ctx *context.Context, build config.Build, command, env []string) ([]string, []string, error) {
+	main := build.Main
+	if main == "" {
+		main = "."
+	}
+	args := append([]string{"list", "-deps", "-json"}, listFlags(command)...)
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, command[0], append(args, main)...)
+	cmd.Env = env
+	cmd.Dir = build.Dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not list the build dependencies: %w", err)
+	}
+
+	var files, modules []string
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var pkg goPackage
+		if err := dec.Decode(&pkg); err != nil {
+			return nil, nil, fmt.Errorf("could not list the build dependencies: %w", err)
+		}
+		if pkg.Standard {
+			continue
+		}
+		if mod := pkg.Module; mod != nil {
+			if !mod.Main && (mod.Replace == nil || mod.Replace.Version != "") {
+				if mod.Replace != nil {
+					mod = mod.Replace
+				}
+				modules = append(modules, mod.Path+"@"+mod.Version)
+				continue
+			}
+			if mod.Replace != nil {
+				mod = mod.Replace
+			}
+			if mod.GoMod != "" {
+				files = append(files, mod.GoMod, filepath.Join(filepath.Dir(mod.GoMod), "go.sum"))
+			}
+		}
+		for _, names := range [][]string{
+			pkg.GoFiles, pkg.CgoFiles, pkg.CFiles, pkg.CXXFiles, pkg.MFiles,
+			pkg.HFiles, pkg.FFiles, pkg.SFiles, pkg.SwigFiles, pkg.SwigCXXFiles,
+			pkg.SysoFiles, pkg.EmbedFiles,
+		} {
+			for _, name := range names {
+				files = append(files, filepath.Join(pkg.Dir, name))
+			}
+		}
+	}
+	slices.Sort(files)
+	slices.Sort(modules)
+	return slices.Compact(files), slices.Compact(modules), nil
+}
+
+// listFlags returns the flags of the given build command that change which
+// files are built, so `go list` picks the same ones.
+func listFlags(command []string) []string {
+	var flags []string
+	for i, arg := range command {
+		if strings.HasPrefix(arg, "-tags=") || strings.HasPrefix(arg, "--tags=") {
+			flags = append(flags, arg)
+		}
+		if (arg == "-tags" || arg == "--tags") && i+1 < len(command) {
+			flags = append(flags, arg, command[i+1])
+		}
+	}
+	return flags
+}
+
+// cachePath returns the given path relative to the current directory if
+// possible, so moving the project around does not change the cache keys.
+func cachePath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, abs); err == nil {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+// cacheEnv returns the variables of the given env that can change the build
+// output, sorted, and with only the last value of each.
+func cacheEnv(env []string) []string {
+	values := map[string]string{}
+	for _, e := range env {
+		k, v, _ := strings.Cut(e, "=")
+		if (strings.HasPrefix(k, "GO") && !strings.HasPrefix(k, "GORELEASER_")) ||
+			strings.HasPrefix(k, "CGO_") ||
+			k == "CC" || k == "CXX" || k == "AR" {
+			values[k] = v
+		}
+	}
+	result := make([]string, 0, len(values))
+	for k, v := range values {
+		result = append(result, k+"="+v)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// fileSum returns the sha256 of the given file contents, or a marker if it
+// was deleted or is a directory, e.g. a submodule.
+func fileSum(path string) (string, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "deleted", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "dir", nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// restoreFromCache copies the binary cached in the given dir to the options
+// path if it was built with the given key, returning whether it did.
+//
+// Missing or invalid manifests are cache misses.
+func restoreFromCache(dir, key string, options api.Options) (bool, error) {
+	bts, err := os.ReadFile(filepath.Join(dir, cacheManifestName))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var manifest cacheManifest
+	if err := json.Unmarshal(bts, &manifest); err != nil || manifest.Hash != key {
+		return false, nil
+	}
+	cached := filepath.Join(dir, options.Name)
+	if _, err := os.Stat(cached); err != nil {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(options.Path), 0o755); err != nil {
+		return false, err
+	}
+	if err := gio.Copy(cached, options.Path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// saveToCache copies the binary at the options path to the given dir, along
+// with the manifest of the given key, replacing whatever was cached before.
+func saveToCache(dir, key string, options api.Options) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	cached := filepath.Join(dir, options.Name)
+	if err := os.MkdirAll(filepath.Dir(cached), 0o755); err != nil {
+		return err
+	}
+	if err := gio.Copy(options.Path, cached); err != nil {
+		return err
+	}
+	bts, err := json.Marshal(cacheManifest{Hash: key})
+	if err != nil {
+		return err
+	}
+	// the manifest is written last, so a partially written cache is a miss.
+	return os.WriteFile(filepath.Join(dir, cacheManifestName), bts, 0o644)
+}
@@ -0,0 +1,196 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	api "github.com/goreleaser/goreleaser/v2/pkg/build"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCache(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	testlib.GitInit(t)
+	writeGoodMain(t, folder)
+	require.NoError(t, os.WriteFile(".gitignore", []byte("ignored.txt\n"), 0o644))
+
+	newCtx := func() *context.Context {
+		return testctx.NewWithCfg(config.Project{
+			Dist: "dist",
+			Builds: []config.Build{{
+				ID:     "foo",
+				Binary: "foo",
+				Cache:  true,
+				BuildDetails: config.BuildDetails{
+					Env: []string{"GO111MODULE=off"},
+				},
+				GoBinary: "go",
+				Command:  "build",
+			}},
+		}, testctx.WithVersion("1.0.0"))
+	}
+	options := api.Options{
+		Target: runtimeTarget,
+		Name:   "foo",
+		Path:   filepath.Join(folder, "dist", "foo"),
+	}
+	cached := filepath.Join("dist", ".cache", "foo_"+runtimeTarget, "foo")
+	build := func(tb testing.TB) []byte {
+		tb.Helper()
+		ctx := newCtx()
+		require.NoError(tb, Default.Build(ctx, ctx.Config.Builds[0], options))
+		require.Len(tb, ctx.Artifacts.List(), 1)
+		bts, err := os.ReadFile(options.Path)
+		require.NoError(tb, err)
+		require.NoError(tb, os.Remove(options.Path))
+		return bts
+	}
+
+	build(t)
+	require.FileExists(t, cached)
+	require.FileExists(t, filepath.Join("dist", ".cache", "foo_"+runtimeTarget, "manifest.json"))
+
+	// replace the cached binary, so we can tell whether it was used.
+	require.NoError(t, os.WriteFile(cached, []byte("cached"), 0o755))
+
+	t.Run("hit", func(t *testing.T) {
+		require.Equal(t, "cached", string(build(t)))
+	})
+
+	t.Run("ignored file changed", func(t *testing.T) {
+		require.NoError(t, os.WriteFile("ignored.txt", []byte("foo"), 0o644))
+		require.Equal(t, "cached", string(build(t)))
+	})
+
+	t.Run("source changed", func(t *testing.T) {
+		require.NoError(t, os.WriteFile("main.go", []byte("package main\nvar a = 2\nfunc main() {println(0)}"), 0o644))
+		require.NotEqual(t, "cached", string(build(t)))
+		require.NoError(t, os.WriteFile(cached, []byte("cached"), 0o755))
+		require.Equal(t, "cached", string(build(t)))
+	})
+
+	t.Run("new file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile("other.go", []byte("package main\nvar b = 2"), 0o644))
+		require.NotEqual(t, "cached", string(build(t)))
+		require.NoError(t, os.WriteFile(cached, []byte("cached"), 0o755))
+	})
+
+	t.Run("env changed", func(t *testing.T) {
+		t.Setenv("GOFLAGS", "-trimpath")
+		require.NotEqual(t, "cached", string(build(t)))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(cached, []byte("cached"), 0o755))
+		ctx := newCtx()
+		ctx.Config.Builds[0].Cache = false
+		require.NoError(t, Default.Build(ctx, ctx.Config.Builds[0], options))
+		bts, err := os.ReadFile(options.Path)
+		require.NoError(t, err)
+		require.NotEqual(t, "cached", string(bts))
+	})
+}
+
+func TestBuildCacheNoGit(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	writeGoodMain(t, folder)
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist: "dist",
+		Builds: []config.Build{{
+			ID:     "foo",
+			Binary: "foo",
+			Cache:  true,
+			BuildDetails: config.BuildDetails{
+				Env: []string{"GO111MODULE=off"},
+			},
+			GoBinary: "go",
+			Command:  "build",
+		}},
+	})
+	require.NoError(t, Default.Build(ctx, ctx.Config.Builds[0], api.Options{
+		Target: runtimeTarget,
+		Name:   "foo",
+		Path:   filepath.Join(folder, "dist", "foo"),
+	}))
+	require.FileExists(t, filepath.Join("dist", "foo"))
+	require.FileExists(t, filepath.Join("dist", ".cache", "foo_"+runtimeTarget, "foo"))
+}
+
+func TestBuildCacheModule(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	testlib.GitInit(t)
+	for path, content := range map[string]string{
+		"app/go.mod":                  "module example.com/app\n\ngo 1.21\n\nrequire example.com/x v0.0.0\n\nreplace example.com/x => ../x\n",
+		"app/cmd/foo/main.go":         "package main\n\nimport (\n\t_ \"embed\"\n\n\t\"example.com/app/internal/bar\"\n\t\"example.com/x\"\n)\n\n//go:embed assets/data.txt\nvar data string\n\nfunc main() { println(bar.Bar, x.X, data) }\n",
+		"app/cmd/foo/assets/data.txt": "generated\n",
+		".gitignore":                  "assets/\n",
+		"app/internal/bar/bar.go":     "package bar\n\nconst Bar = 1\n",
+		"x/go.mod":                    "module example.com/x\n\ngo 1.21\n",
+		"x/x.go":                      "package x\n\nconst X = 1\n",
+		"other/other.txt":             "not a dependency\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	options := api.Options{
+		Target: runtimeTarget,
+		Name:   "foo",
+		Path:   filepath.Join(folder, "dist", "foo"),
+	}
+	cached := filepath.Join("dist", ".cache", "foo_"+runtimeTarget, "foo")
+	build := func(tb testing.TB) []byte {
+		tb.Helper()
+		ctx := testctx.NewWithCfg(config.Project{
+			Dist: "dist",
+			Builds: []config.Build{{
+				ID:     "foo",
+				Binary: "foo",
+				Dir:    filepath.Join("app", "cmd", "foo"),
+				Main:   ".",
+				Cache:  true,
+				BuildDetails: config.BuildDetails{
+					Env: []string{"GOTOOLCHAIN=local", "GOFLAGS=-mod=mod"},
+				},
+				GoBinary: "go",
+				Command:  "build",
+			}},
+		}, testctx.WithVersion("1.0.0"))
+		require.NoError(tb, Default.Build(ctx, ctx.Config.Builds[0], options))
+		bts, err := os.ReadFile(options.Path)
+		require.NoError(tb, err)
+		require.NoError(tb, os.Remove(options.Path))
+		return bts
+	}
+	refresh := func(tb testing.TB) {
+		tb.Helper()
+		build(tb)
+		require.NoError(tb, os.WriteFile(cached, []byte("cached"), 0o755))
+		require.Equal(tb, "cached", string(build(tb)))
+	}
+
+	refresh(t)
+
+	for name, change := range map[string][2]string{
+		"sibling package": {"app/internal/bar/bar.go", "package bar\n\nconst Bar = 2\n"},
+		"go.mod":          {"app/go.mod", "module example.com/app\n\ngo 1.21\n\nrequire example.com/x v0.0.0\n\nreplace example.com/x => ../x\n\n// changed\n"},
+		"replaced module": {"x/x.go", "package x\n\nconst X = 2\n"},
+		"ignored embed":   {"app/cmd/foo/assets/data.txt", "regenerated\n"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(change[0], []byte(change[1]), 0o644))
+			require.NotEqual(t, "cached", string(build(t)))
+			refresh(t)
+		})
+	}
+
+	t.Run("unrelated file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile("other/other.txt", []byte("changed\n"), 0o644))
+		require.Equal(t, "cached", string(build(t)))
+	})
+}
@@ -4,6 +4,8 @@ package client
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
@@ -61,13 +63,35 @@ type Client interface {
 	FileCreator
 }
 
-// ChangelogItem represents a changelog item, basically, a commit and its author.
+// ChangelogItem represents a changelog item, basically, a commit or a pull
+// request, and its author.
 type ChangelogItem struct {
 	SHA            string
 	Message        string
 	AuthorName     string
 	AuthorEmail    string
 	AuthorUsername string
+	// Date the commit was authored, or the pull request merged.
+	Date time.Time
+	// Number and URL of the pull request, if any.
+	Number int
+	URL    string
+	Labels []string
+}
+
+// PullRequestLister can list the pull requests merged between two refs.
+//
+// The SHA of each item is the merge commit of its pull request, and the
+// Message its title.
+type PullRequestLister interface {
+	MergedPullRequests(ctx *context.Context, repo Repo, prev, current string) ([]ChangelogItem, error)
+}
+
+// CommitFilesLister can list the files changed by a commit.
+//
+// Renamed files are listed with both their old and new paths.
+type CommitFilesLister interface {
+	CommitFiles(ctx *context.Context, repo Repo, sha string) ([]string, error)
 }
 
 // ReleaseURLTemplater provides the release URL as a template, containing the
@@ -105,8 +129,25 @@ type ForkSyncer interface {
 }
 
 // PullRequestOpener can open pull requests.
+//
+// The changes are expected to be already pushed to the head branch, usually
+// with CreateFile.
+// Base defaults to the head repository and its default branch: if base has
+// the same owner and name as head, it's a same-repository pull request,
+// otherwise head is treated as a fork of base, and the pull request is opened
+// in base (a merge request in the base project, on GitLab).
+// If there's already an open pull request from head into base, it is reused.
+// The first line of title is used as the pull request title, the remaining
+// lines, if any, are used as its body.
+// The URL of the pull request is returned, or an empty string if none could
+// be opened.
 type PullRequestOpener interface {
-	OpenPullRequest(ctx *context.Context, base, head Repo, title string, draft bool) error
+	OpenPullRequest(ctx *context.Context, base, head Repo, title string, draft, autoMerge bool) (string, error)
+}
+
+// DeploymentStatusCreator can create deployment statuses.
+type DeploymentStatusCreator interface {
+	CreateDeploymentStatus(ctx *context.Context, repo Repo, id int64, state, url string) error
 }
 
 // New creates a new client depending on the token type.
@@ -161,6 +202,16 @@ func NewIfToken(ctx *context.Context, cli Client, token string) (Client, error)
 	return newWithToken(ctx, token)
 }
 
+// targetCommitish returns the evaluated release.target_commitish, which might
+// be empty.
+func targetCommitish(ctx *context.Context) (string, error) {
+	target, err := tmpl.New(ctx).Apply(ctx.Config.Release.TargetCommitish)
+	if err != nil {
+		return "", fmt.Errorf("could not template target_commitish: %w", err)
+	}
+	return strings.TrimSpace(target), nil
+}
+
 func truncateReleaseBody(body string) string {
 	if len(body) > maxReleaseBodyLength {
 		body = body[1:(maxReleaseBodyLength-len(ellipsis))] + ellipsis
@@ -1,9 +1,27 @@
 package client
 
-import "github.com/goreleaser/goreleaser/v2/pkg/config"
+import (
+	"strings"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+)
 
 const prFooter = "###### Automated with [GoReleaser](https://goreleaser.com)"
 
+// prTitleAndBody uses the first line of the given commit message as the pull
+// request title, and the remaining lines, plus the given extra parts and the
+// footer, as its body.
+func prTitleAndBody(msg string, extra ...string) (string, string) {
+	title, rest, _ := strings.Cut(msg, "\n")
+	parts := []string{}
+	for _, part := range append([]string{rest}, extra...) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.TrimSpace(title), strings.Join(append(parts, prFooter), "\n")
+}
+
 // RepoFromRef converts a config.RepoRef into a Repo.
 func RepoFromRef(ref config.RepoRef) Repo {
 	return Repo{
@@ -96,3 +96,17 @@ func TestTemplateRef(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestPRTitleAndBody(t *testing.T) {
+	t.Run("single line", func(t *testing.T) {
+		title, body := prTitleAndBody("update foo to v1.2.3")
+		require.Equal(t, "update foo to v1.2.3", title)
+		require.Equal(t, prFooter, body)
+	})
+
+	t.Run("multiple lines", func(t *testing.T) {
+		title, body := prTitleAndBody("update foo to v1.2.3\n\n## Changelog\n* fix bar\n", "template")
+		require.Equal(t, "update foo to v1.2.3", title)
+		require.Equal(t, "## Changelog\n* fix bar\ntemplate\n"+prFooter, body)
+	})
+}
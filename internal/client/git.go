@@ -129,6 +129,23 @@ func (g *gitClient) CreateFiles(
 
 	if err := runGitCmds(ctx, cwd, env, [][]string{
 		{"add", "-A", "."},
+	}); err != nil {
+		return fmt.Errorf("git: failed to push %q (%q): %w", repo.Name, url, err)
+	}
+
+	status, err := git.Clean(git.RunWithEnv(ctx, env, "-C", cwd, "status", "--porcelain"))
+	if err != nil {
+		return fmt.Errorf("git: failed to push %q (%q): %w", repo.Name, url, err)
+	}
+	if status == "" {
+		log.
+			WithField("repository", url).
+			WithField("name", repo.Name).
+			Info("files are up to date, nothing to push")
+		return nil
+	}
+
+	if err := runGitCmds(ctx, cwd, env, [][]string{
 		{"commit", "-m", message},
 		{"push", "origin", "HEAD"},
 	}); err != nil {
@@ -2,6 +2,7 @@ package client
 
 import (
 	"os"
+	"os/exec"
 	"strings"
 	"testing"
 
@@ -128,6 +129,31 @@ func TestGitClient(t *testing.T) {
 		))
 		require.Equal(t, "fake content 2", string(testlib.CatFileFromBareRepository(t, url, "fake.txt")))
 	})
+	t.Run("up to date", func(t *testing.T) {
+		url := testlib.GitMakeBareRepository(t)
+		ctx := testctx.NewWithCfg(config.Project{
+			Dist: t.TempDir(),
+		})
+		repo := Repo{
+			GitURL:     url,
+			PrivateKey: testlib.MakeNewSSHKey(t, ""),
+			Name:       "test1",
+		}
+		cli := NewGitUploadClient(repo.Branch)
+		for _, msg := range []string{"hey test", "hey test again"} {
+			require.NoError(t, cli.CreateFile(
+				ctx,
+				author,
+				repo,
+				[]byte("fake content"),
+				"fake.txt",
+				msg,
+			))
+		}
+		out, err := exec.Command("git", "-C", url, "log", "--format=%s", "master").CombinedOutput()
+		require.NoError(t, err)
+		require.Equal(t, "hey test", strings.TrimSpace(string(out)))
+	})
 	t.Run("bad url", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Dist: t.TempDir(),
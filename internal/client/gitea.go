@@ -1,7 +1,7 @@
 package client
 
 import (
-	"crypto/tls"
+	"bytes"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -9,10 +9,13 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/caarlos0/log"
+	"github.com/charmbracelet/x/exp/ordered"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -22,7 +25,10 @@ type giteaClient struct {
 	client *gitea.Client
 }
 
-var _ Client = &giteaClient{}
+var (
+	_ Client            = &giteaClient{}
+	_ PullRequestOpener = &giteaClient{}
+)
 
 func getInstanceURL(ctx *context.Context) (string, error) {
 	apiURL, err := tmpl.New(ctx).Apply(ctx.Config.GiteaURLs.API)
@@ -42,20 +48,37 @@ func getInstanceURL(ctx *context.Context) (string, error) {
 	return rawurl, nil
 }
 
+// GiteaHTTPOptions returns the HTTP options of the Gitea instance.
+func GiteaHTTPOptions(ctx *context.Context) httpclient.Options {
+	return httpclient.Options{
+		TLS: httpclient.TLSOptions{
+			SkipVerify: ctx.Config.GiteaURLs.SkipTLSVerify,
+			CACert:     ctx.Config.GiteaURLs.CACert,
+			ClientCert: ctx.Config.GiteaURLs.ClientCert,
+			ClientKey:  ctx.Config.GiteaURLs.ClientKey,
+		},
+		Proxy: httpclient.ProxyOptions{
+			URL:     ctx.Config.GiteaURLs.Proxy,
+			NoProxy: ctx.Config.GiteaURLs.NoProxy,
+		},
+	}
+}
+
 // newGitea returns a gitea client implementation.
 func newGitea(ctx *context.Context, token string) (*giteaClient, error) {
 	instanceURL, err := getInstanceURL(ctx)
 	if err != nil {
 		return nil, err
 	}
-	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		TLSClientConfig: &tls.Config{
-			//nolint:gosec
-			InsecureSkipVerify: ctx.Config.GiteaURLs.SkipTLSVerify,
-		},
+	transport, err := httpclient.Transport(ctx, GiteaHTTPOptions(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("gitea_urls: %w", err)
+	}
+	ua, err := httpclient.UserAgent(ctx, ctx.Config.GiteaURLs.UserAgent)
+	if err != nil {
+		return nil, fmt.Errorf("templating Gitea user agent: %w", err)
 	}
-	httpClient := &http.Client{Transport: transport}
+	httpClient := &http.Client{Transport: httpclient.WithUserAgent(transport, ua)}
 	options := []gitea.ClientOption{
 		gitea.SetHTTPClient(httpClient),
 	}
@@ -89,11 +112,38 @@ func (c *giteaClient) Changelog(_ *context.Context, repo Repo, prev, current str
 			AuthorName:     commit.Author.FullName,
 			AuthorEmail:    commit.Author.Email,
 			AuthorUsername: commit.Author.UserName,
+			Date:           giteaCommitDate(commit),
 		})
 	}
 	return log, nil
 }
 
+// CommitFiles lists the files changed by the given commit.
+func (c *giteaClient) CommitFiles(_ *context.Context, repo Repo, sha string) ([]string, error) {
+	commit, _, err := c.client.GetSingleCommit(repo.Owner, repo.Name, sha)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(commit.Files))
+	for _, file := range commit.Files {
+		files = append(files, file.Filename)
+	}
+	return files, nil
+}
+
+// giteaCommitDate returns the author date of the given commit, or the zero
+// time if it is not available.
+func giteaCommitDate(commit *gitea.Commit) time.Time {
+	if commit.RepoCommit == nil || commit.RepoCommit.Author == nil {
+		return time.Time{}
+	}
+	date, err := time.Parse(time.RFC3339, commit.RepoCommit.Author.Date)
+	if err != nil {
+		return time.Time{}
+	}
+	return date.UTC()
+}
+
 // CloseMilestone closes a given milestone.
 func (c *giteaClient) CloseMilestone(_ *context.Context, repo Repo, title string) error {
 	closedState := gitea.StateClosed
@@ -124,6 +174,17 @@ func (c *giteaClient) getDefaultBranch(_ *context.Context, repo Repo) (string, e
 	return p.DefaultBranch, nil
 }
 
+func (c *giteaClient) checkBranchExists(repo Repo, branch string) (bool, error) {
+	_, res, err := c.client.GetRepoBranch(repo.Owner, repo.Name, branch)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not check if branch exists: %w", err)
+	}
+	return true, nil
+}
+
 // CreateFile creates a file in the repository at a given path
 // or updates the file if it exists.
 func (c *giteaClient) CreateFile(
@@ -135,10 +196,25 @@ func (c *giteaClient) CreateFile(
 	message string,
 ) error {
 	// use default branch
-	var branch string
+	var branch, newBranch string
 	var err error
 	if repo.Branch != "" {
 		branch = repo.Branch
+		exists, err := c.checkBranchExists(repo, branch)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			// create the branch from the default one
+			newBranch = branch
+			branch, err = c.getDefaultBranch(ctx, repo)
+			if err != nil {
+				return err
+			}
+			log.WithField("projectID", repo.String()).
+				WithField("branch", newBranch).
+				Debug("branch doesn't exist, creating it")
+		}
 	} else {
 		branch, err = c.getDefaultBranch(ctx, repo)
 		if err != nil {
@@ -153,8 +229,9 @@ func (c *giteaClient) CreateFile(
 	}
 
 	fileOptions := gitea.FileOptions{
-		Message:    message,
-		BranchName: branch,
+		Message:       message,
+		BranchName:    branch,
+		NewBranchName: newBranch,
 		Author: gitea.Identity{
 			Name:  commitAuthor.Name,
 			Email: commitAuthor.Email,
@@ -184,6 +261,17 @@ func (c *giteaClient) CreateFile(
 		return err
 	}
 
+	if newBranch == "" && currentFile.Content != nil {
+		if current, err := base64.StdEncoding.DecodeString(*currentFile.Content); err == nil && bytes.Equal(current, content) {
+			log.
+				WithField("repository", repo.String()).
+				WithField("branch", branch).
+				WithField("file", path).
+				Info("file is up to date, not committing")
+			return nil
+		}
+	}
+
 	// update file
 	_, _, err = c.client.UpdateFile(repo.Owner, repo.Name, path, gitea.UpdateFileOptions{
 		FileOptions: fileOptions,
@@ -193,15 +281,105 @@ func (c *giteaClient) CreateFile(
 	return err
 }
 
+// OpenPullRequest opens a pull request from the head branch into the base one.
+func (c *giteaClient) OpenPullRequest(
+	ctx *context.Context,
+	base, head Repo,
+	title string,
+	draft, autoMerge bool,
+) (string, error) {
+	base.Owner = ordered.First(base.Owner, head.Owner)
+	base.Name = ordered.First(base.Name, head.Name)
+	if base.Branch == "" {
+		def, err := c.getDefaultBranch(ctx, base)
+		if err != nil {
+			return "", err
+		}
+		base.Branch = def
+	}
+
+	title, body := prTitleAndBody(title)
+	// gitea uses the WIP prefix to mark pull requests as drafts
+	if draft {
+		title = fmt.Sprintf("WIP: %s", title)
+	}
+
+	headRef := head.Branch
+	if head.Owner != "" && head.Owner != base.Owner {
+		headRef = head.Owner + ":" + head.Branch
+	}
+
+	log := log.
+		WithField("base", headString(base, Repo{})).
+		WithField("head", headString(base, head)).
+		WithField("draft", draft)
+	log.Info("opening pull request")
+	pr, res, err := c.client.CreatePullRequest(base.Owner, base.Name, gitea.CreatePullRequestOption{
+		Head:  headRef,
+		Base:  base.Branch,
+		Title: title,
+		Body:  body,
+	})
+	if err != nil {
+		if res == nil || res.StatusCode != http.StatusConflict {
+			return "", fmt.Errorf("could not create pull request: %w", err)
+		}
+		// there's already an open pull request for this head branch,
+		// the new commits were pushed to it, so we just reuse it.
+		pr, err = c.getOpenPullRequest(base, head.Branch)
+		if err != nil {
+			return "", fmt.Errorf("could not create pull request: %w", err)
+		}
+		log.WithField("url", pr.HTMLURL).Info("pull request already exists")
+	} else {
+		log.WithField("url", pr.HTMLURL).Info("pull request created")
+	}
+
+	if !autoMerge {
+		return pr.HTMLURL, nil
+	}
+
+	if _, _, err := c.client.MergePullRequest(base.Owner, base.Name, pr.Index, gitea.MergePullRequestOption{
+		Style:                  gitea.MergeStyleMerge,
+		MergeWhenChecksSucceed: true,
+	}); err != nil {
+		return "", fmt.Errorf("could not set pull request to auto merge: %w", err)
+	}
+	log.WithField("url", pr.HTMLURL).Info("pull request set to merge when checks succeed")
+	return pr.HTMLURL, nil
+}
+
+// getOpenPullRequest gets the open pull request from the given head branch
+// into the base branch.
+func (c *giteaClient) getOpenPullRequest(base Repo, head string) (*gitea.PullRequest, error) {
+	prs, _, err := c.client.ListRepoPullRequests(base.Owner, base.Name, gitea.ListPullRequestsOptions{
+		State: gitea.StateOpen,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range prs {
+		if pr.Head != nil && pr.Head.Ref == head &&
+			pr.Base != nil && pr.Base.Ref == base.Branch {
+			return pr, nil
+		}
+	}
+	return nil, fmt.Errorf("no open pull request found from %s into %s", head, base.Branch)
+}
+
 func (c *giteaClient) createRelease(ctx *context.Context, title, body string) (*gitea.Release, error) {
 	releaseConfig := ctx.Config.Release
 	owner := releaseConfig.Gitea.Owner
 	repoName := releaseConfig.Gitea.Name
 	tag := ctx.Git.CurrentTag
+	target, err := c.releaseTarget(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	opts := gitea.CreateReleaseOption{
 		TagName:      tag,
-		Target:       ctx.Git.Commit,
+		Target:       target,
 		Title:        title,
 		Note:         body,
 		IsDraft:      releaseConfig.Draft,
@@ -216,6 +394,27 @@ func (c *giteaClient) createRelease(ctx *context.Context, title, body string) (*
 	return release, nil
 }
 
+// releaseTarget returns the commitish the release should point to: the
+// release.target_commitish, if set and found in the repository, or the
+// current commit otherwise.
+func (c *giteaClient) releaseTarget(ctx *context.Context) (string, error) {
+	target, err := targetCommitish(ctx)
+	if err != nil {
+		return "", err
+	}
+	if target == "" {
+		return ctx.Git.Commit, nil
+	}
+	if _, _, err := c.client.GetSingleCommit(
+		ctx.Config.Release.Gitea.Owner,
+		ctx.Config.Release.Gitea.Name,
+		target,
+	); err != nil {
+		return "", fmt.Errorf("could not find target_commitish %q: %w", target, err)
+	}
+	return target, nil
+}
+
 func (c *giteaClient) getExistingRelease(owner, repoName, tagName string) (*gitea.Release, error) {
 	releases, _, err := c.client.ListReleases(owner, repoName, gitea.ListReleasesOptions{})
 	if err != nil {
@@ -236,10 +435,14 @@ func (c *giteaClient) updateRelease(ctx *context.Context, title, body string, id
 	owner := releaseConfig.Gitea.Owner
 	repoName := releaseConfig.Gitea.Name
 	tag := ctx.Git.CurrentTag
+	target, err := c.releaseTarget(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	opts := gitea.EditReleaseOption{
 		TagName:      tag,
-		Target:       ctx.Git.Commit,
+		Target:       target,
 		Title:        title,
 		Note:         body,
 		IsDraft:      &releaseConfig.Draft,
@@ -305,7 +508,7 @@ func (c *giteaClient) ReleaseURLTemplate(ctx *context.Context) (string, error) {
 	}
 
 	return fmt.Sprintf(
-		"%s/%s/%s/releases/download/{{ .Tag }}/{{ .ArtifactName }}",
+		"%s/%s/%s/releases/download/{{ .PrefixedTag }}/{{ .ArtifactName }}",
 		downloadURL,
 		ctx.Config.Release.Gitea.Owner,
 		ctx.Config.Release.Gitea.Name,
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,10 +10,12 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -266,6 +270,40 @@ func (s *GiteacreateReleaseSuite) TestError() {
 	require.Nil(t, release)
 }
 
+func (s *GiteacreateReleaseSuite) TestTargetCommitish() {
+	t := s.T()
+	s.ctx.Config.Release.TargetCommitish = "stable"
+	httpmock.RegisterResponder(
+		"GET",
+		fmt.Sprintf("%s/api/v1/repos/%s/%s/git/commits/stable", s.url, s.owner, s.repoName),
+		httpmock.NewStringResponder(200, "{}"),
+	)
+	httpmock.RegisterResponder("POST", s.releasesURL, func(r *http.Request) (*http.Response, error) {
+		var opts gitea.CreateReleaseOption
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&opts))
+		require.Equal(t, "stable", opts.Target)
+		return httpmock.NewJsonResponse(200, gitea.Release{Target: opts.Target})
+	})
+
+	release, err := s.client.createRelease(s.ctx, s.title, s.description)
+	require.NoError(t, err)
+	require.Equal(t, "stable", release.Target)
+}
+
+func (s *GiteacreateReleaseSuite) TestTargetCommitishNotFound() {
+	t := s.T()
+	s.ctx.Config.Release.TargetCommitish = "stable"
+	httpmock.RegisterResponder(
+		"GET",
+		fmt.Sprintf("%s/api/v1/repos/%s/%s/git/commits/stable", s.url, s.owner, s.repoName),
+		httpmock.NewStringResponder(404, ""),
+	)
+
+	release, err := s.client.createRelease(s.ctx, s.title, s.description)
+	require.ErrorContains(t, err, `could not find target_commitish "stable"`)
+	require.Nil(t, release)
+}
+
 func TestGiteacreateReleaseSuite(t *testing.T) {
 	suite.Run(t, new(GiteacreateReleaseSuite))
 }
@@ -485,12 +523,12 @@ func TestGiteaReleaseURLTemplate(t *testing.T) {
 		{
 			name:            "string_url",
 			downloadURL:     "https://gitea.com",
-			wantDownloadURL: "https://gitea.com/owner/name/releases/download/{{ .Tag }}/{{ .ArtifactName }}",
+			wantDownloadURL: "https://gitea.com/owner/name/releases/download/{{ .PrefixedTag }}/{{ .ArtifactName }}",
 		},
 		{
 			name:            "download_url_template",
 			downloadURL:     "{{ .Env.GORELEASER_TEST_GITEA_URLS_DOWNLOAD }}",
-			wantDownloadURL: "https://gitea.mycompany.com/owner/name/releases/download/{{ .Tag }}/{{ .ArtifactName }}",
+			wantDownloadURL: "https://gitea.mycompany.com/owner/name/releases/download/{{ .PrefixedTag }}/{{ .ArtifactName }}",
 		},
 		{
 			name:        "download_url_template_invalid_value",
@@ -622,6 +660,9 @@ func TestGiteaChangelog(t *testing.T) {
 						},
 						RepoCommit: &gitea.RepoCommit{
 							Message: "feat: impl something\n\nnsome other lines",
+							Author: &gitea.CommitUser{
+								Date: "2024-02-03T10:00:00+01:00",
+							},
 						},
 					},
 				},
@@ -655,6 +696,7 @@ func TestGiteaChangelog(t *testing.T) {
 			AuthorUsername: "johndoe",
 			AuthorName:     "John Doe",
 			AuthorEmail:    "nope@nope.nope",
+			Date:           time.Date(2024, 2, 3, 9, 0, 0, 0, time.UTC),
 		},
 	}, result)
 }
@@ -669,3 +711,294 @@ func TestGiteatGetInstanceURL(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "http://our.internal.gitea.media", url)
 }
+
+func TestGiteaCreateFileNewBranch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "api/v1/version"):
+			fmt.Fprint(w, "{\"version\":\"1.22.0\"}")
+		case r.URL.Path == "/api/v1/repos/someone/something/branches/foo":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "{}")
+		case r.URL.Path == "/api/v1/repos/someone/something":
+			fmt.Fprint(w, "{\"default_branch\":\"main\"}")
+		case r.URL.Path == "/api/v1/repos/someone/something/contents/file.txt" && r.Method == http.MethodGet:
+			require.Equal(t, "main", r.URL.Query().Get("ref"))
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "{}")
+		case r.URL.Path == "/api/v1/repos/someone/something/contents/file.txt" && r.Method == http.MethodPost:
+			var opts gitea.CreateFileOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&opts))
+			require.Equal(t, "main", opts.BranchName)
+			require.Equal(t, "foo", opts.NewBranchName)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, "{}")
+		default:
+			t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GiteaURLs: config.GiteaURLs{
+			API: srv.URL,
+		},
+	})
+	client, err := newGitea(ctx, "test-token")
+	require.NoError(t, err)
+	repo := Repo{
+		Owner:  "someone",
+		Name:   "something",
+		Branch: "foo",
+	}
+
+	require.NoError(t, client.CreateFile(ctx, config.CommitAuthor{Name: "someone"}, repo, []byte("hello"), "file.txt", "add hello"))
+}
+
+func TestGiteaOpenPullRequest(t *testing.T) {
+	var merged bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "api/v1/version"):
+			fmt.Fprint(w, "{\"version\":\"1.22.0\"}")
+		case r.URL.Path == "/api/v1/repos/someone/something":
+			fmt.Fprint(w, "{\"default_branch\":\"main\"}")
+		case r.URL.Path == "/api/v1/repos/someone/something/pulls":
+			var opts gitea.CreatePullRequestOption
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&opts))
+			require.Equal(t, "someoneelse:foo", opts.Head)
+			require.Equal(t, "main", opts.Base)
+			require.Equal(t, "WIP: some title", opts.Title)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, "{\"number\":3,\"html_url\":\"https://gitea.com/someone/something/pulls/3\"}")
+		case r.URL.Path == "/api/v1/repos/someone/something/pulls/3/merge":
+			var opts gitea.MergePullRequestOption
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&opts))
+			require.True(t, opts.MergeWhenChecksSucceed)
+			merged = true
+		default:
+			t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GiteaURLs: config.GiteaURLs{
+			API: srv.URL,
+		},
+	})
+	client, err := newGitea(ctx, "test-token")
+	require.NoError(t, err)
+	base := Repo{
+		Owner: "someone",
+		Name:  "something",
+	}
+	head := Repo{
+		Owner:  "someoneelse",
+		Name:   "something",
+		Branch: "foo",
+	}
+
+	url, err := client.OpenPullRequest(ctx, base, head, "some title", true, true)
+	require.NoError(t, err)
+	require.Equal(t, "https://gitea.com/someone/something/pulls/3", url)
+	require.True(t, merged)
+}
+
+func TestGiteaOpenPullRequestAlreadyExists(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "api/v1/version"):
+			fmt.Fprint(w, "{\"version\":\"1.22.0\"}")
+		case r.URL.Path == "/api/v1/repos/someone/something/pulls" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, "{\"message\":\"pull request already exists for these targets\"}")
+		case r.URL.Path == "/api/v1/repos/someone/something/pulls" && r.Method == http.MethodGet:
+			require.Equal(t, "open", r.URL.Query().Get("state"))
+			fmt.Fprint(w, `[
+				{"number":1,"head":{"ref":"bar"},"base":{"ref":"main"}},
+				{"number":2,"head":{"ref":"foo"},"base":{"ref":"main"},"html_url":"https://gitea.com/someone/something/pulls/2"}
+			]`)
+		default:
+			t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GiteaURLs: config.GiteaURLs{
+			API: srv.URL,
+		},
+	})
+	client, err := newGitea(ctx, "test-token")
+	require.NoError(t, err)
+	repo := Repo{
+		Owner:  "someone",
+		Name:   "something",
+		Branch: "foo",
+	}
+
+	_, err = client.OpenPullRequest(ctx, Repo{Branch: "main"}, repo, "some title", false, false)
+	require.NoError(t, err)
+}
+
+func TestGiteaCreateFileUpToDate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "api/v1/version"):
+			fmt.Fprint(w, "{\"version\":\"1.22.0\"}")
+		case r.URL.Path == "/api/v1/repos/someone/something/branches/foo":
+			fmt.Fprint(w, "{}")
+		case r.URL.Path == "/api/v1/repos/someone/something/contents/file.txt" && r.Method == http.MethodGet:
+			fmt.Fprint(w, "{\"sha\":\"fake\",\"encoding\":\"base64\",\"content\":\"aGVsbG8=\"}")
+		default:
+			t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GiteaURLs: config.GiteaURLs{
+			API: srv.URL,
+		},
+	})
+	client, err := newGitea(ctx, "test-token")
+	require.NoError(t, err)
+	repo := Repo{
+		Owner:  "someone",
+		Name:   "something",
+		Branch: "foo",
+	}
+
+	require.NoError(t, client.CreateFile(ctx, config.CommitAuthor{Name: "someone"}, repo, []byte("hello"), "file.txt", "add hello"))
+}
+
+func TestGiteaUserAgent(t *testing.T) {
+	for name, tt := range map[string]struct {
+		userAgent string
+		expected  string
+	}{
+		"default":  {"", "goreleaser/dev (+proj)"},
+		"override": {"my-agent/{{ .ProjectName }}", "my-agent/proj"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var agents []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				agents = append(agents, r.Header.Get("User-Agent"))
+				if r.URL.Path == "/api/v1/version" {
+					fmt.Fprint(w, `{"version":"1.20.0"}`)
+					return
+				}
+				fmt.Fprint(w, `[]`)
+			}))
+			t.Cleanup(srv.Close)
+
+			ctx := testctx.NewWithCfg(config.Project{
+				ProjectName: "proj",
+				GiteaURLs: config.GiteaURLs{
+					API:       srv.URL,
+					UserAgent: tt.userAgent,
+				},
+			})
+			client, err := newGitea(ctx, "test-token")
+			require.NoError(t, err)
+			_, err = client.getExistingRelease("foo", "bar", "v1.0.0")
+			require.NoError(t, err)
+
+			require.NotEmpty(t, agents)
+			for _, agent := range agents {
+				require.Equal(t, tt.expected, agent)
+			}
+		})
+	}
+}
+
+func TestGiteaUserAgentBadTemplate(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		GiteaURLs: config.GiteaURLs{
+			API:       "https://gitea.example.com/api/v1",
+			UserAgent: "{{ .Nope }}",
+		},
+	})
+	_, err := newGitea(ctx, "test-token")
+	require.ErrorContains(t, err, "templating Gitea user agent")
+}
+
+func TestGiteaMutualTLS(t *testing.T) {
+	cert, key := testlib.GenerateCert(t)
+	pair, err := tls.LoadX509KeyPair(cert, key)
+	require.NoError(t, err)
+	bts, err := os.ReadFile(cert)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(bts))
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.URL.Path == "/api/v1/version" {
+			fmt.Fprint(w, `{"version":"1.20.0"}`)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	t.Run("with client certificate", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			GiteaURLs: config.GiteaURLs{
+				API:        srv.URL,
+				CACert:     cert,
+				ClientCert: cert,
+				ClientKey:  key,
+			},
+		})
+		client, err := newGitea(ctx, "test-token")
+		require.NoError(t, err)
+		_, err = client.getExistingRelease("foo", "bar", "v1.0.0")
+		require.NoError(t, err)
+	})
+
+	t.Run("without client certificate", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			GiteaURLs: config.GiteaURLs{
+				API:    srv.URL,
+				CACert: cert,
+			},
+		})
+		_, err := newGitea(ctx, "test-token")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid client certificate", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			GiteaURLs: config.GiteaURLs{
+				API:        srv.URL,
+				ClientCert: cert,
+			},
+		})
+		_, err := newGitea(ctx, "test-token")
+		require.EqualError(t, err, "gitea_urls: client_cert and client_key must be set together")
+	})
+}
+
+func TestGiteaInvalidProxy(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		GiteaURLs: config.GiteaURLs{
+			API:   "https://gitea.com/api/v1",
+			Proxy: "::nope",
+		},
+	})
+	_, err := newGitea(ctx, "test-token")
+	require.EqualError(t, err, `gitea_urls: invalid proxy url: "::nope"`)
+}
@@ -1,13 +1,12 @@
 package client
 
 import (
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
-	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +15,7 @@ import (
 	"github.com/charmbracelet/x/exp/ordered"
 	"github.com/google/go-github/v62/github"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -25,16 +25,39 @@ import (
 const DefaultGitHubDownloadURL = "https://github.com"
 
 var (
-	_ Client                = &githubClient{}
-	_ ReleaseNotesGenerator = &githubClient{}
-	_ PullRequestOpener     = &githubClient{}
-	_ ForkSyncer            = &githubClient{}
+	_ Client                  = &githubClient{}
+	_ ReleaseNotesGenerator   = &githubClient{}
+	_ PullRequestOpener       = &githubClient{}
+	_ ForkSyncer              = &githubClient{}
+	_ DeploymentStatusCreator = &githubClient{}
+	_ PullRequestLister       = &githubClient{}
 )
 
 type githubClient struct {
 	client *github.Client
 }
 
+//nolint:gochecknoinits
+func init() {
+	// the latestRelease template function uses the GitHub API too.
+	tmpl.HTTPClient = func(ctx *context.Context) (*http.Client, error) {
+		return httpclient.ClientWith(ctx, gitHubHTTPOptions(ctx), ctx.Config.GitHubURLs.UserAgent)
+	}
+}
+
+// gitHubHTTPOptions returns the HTTP options of the GitHub API.
+func gitHubHTTPOptions(ctx *context.Context) httpclient.Options {
+	return httpclient.Options{
+		TLS: httpclient.TLSOptions{
+			SkipVerify: ctx.Config.GitHubURLs.SkipTLSVerify,
+		},
+		Proxy: httpclient.ProxyOptions{
+			URL:     ctx.Config.GitHubURLs.Proxy,
+			NoProxy: ctx.Config.GitHubURLs.NoProxy,
+		},
+	}
+}
+
 // NewGitHubReleaseNotesGenerator returns a GitHub client that can generate
 // changelogs.
 func NewGitHubReleaseNotesGenerator(ctx *context.Context, token string) (ReleaseNotesGenerator, error) {
@@ -47,21 +70,19 @@ func newGitHub(ctx *context.Context, token string) (*githubClient, error) {
 		&oauth2.Token{AccessToken: token},
 	)
 
-	httpClient := oauth2.NewClient(ctx, ts)
-	base := httpClient.Transport.(*oauth2.Transport).Base
-	if base == nil || reflect.ValueOf(base).IsNil() {
-		base = http.DefaultTransport
+	transport, err := httpclient.Transport(ctx, gitHubHTTPOptions(ctx))
+	if err != nil {
+		return &githubClient{}, fmt.Errorf("github_urls: %w", err)
 	}
-	//nolint:gosec
-	base.(*http.Transport).TLSClientConfig = &tls.Config{
-		InsecureSkipVerify: ctx.Config.GitHubURLs.SkipTLSVerify,
+	ua, err := httpclient.UserAgent(ctx, ctx.Config.GitHubURLs.UserAgent)
+	if err != nil {
+		return &githubClient{}, fmt.Errorf("templating GitHub user agent: %w", err)
 	}
-	base.(*http.Transport).Proxy = http.ProxyFromEnvironment
-	httpClient.Transport.(*oauth2.Transport).Base = base
+	httpClient := oauth2.NewClient(ctx, ts)
+	httpClient.Transport.(*oauth2.Transport).Base = httpclient.WithUserAgent(transport, ua)
 
 	client := github.NewClient(httpClient)
-	err := overrideGitHubClientAPI(ctx, client)
-	if err != nil {
+	if err := overrideGitHubClientAPI(ctx, client); err != nil {
 		return &githubClient{}, err
 	}
 
@@ -118,6 +139,7 @@ func (c *githubClient) Changelog(ctx *context.Context, repo Repo, prev, current
 				AuthorName:     commit.GetAuthor().GetName(),
 				AuthorEmail:    commit.GetAuthor().GetEmail(),
 				AuthorUsername: commit.GetAuthor().GetLogin(),
+				Date:           commit.GetCommit().GetAuthor().GetDate().Time,
 			})
 		}
 		if resp.NextPage == 0 {
@@ -129,6 +151,107 @@ func (c *githubClient) Changelog(ctx *context.Context, repo Repo, prev, current
 	return log, nil
 }
 
+// CommitFiles lists the files changed by the given commit.
+func (c *githubClient) CommitFiles(ctx *context.Context, repo Repo, sha string) ([]string, error) {
+	c.checkRateLimit(ctx)
+	var files []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		commit, resp, err := c.client.Repositories.GetCommit(ctx, repo.Owner, repo.Name, sha, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range commit.Files {
+			files = append(files, file.GetFilename())
+			if prev := file.GetPreviousFilename(); prev != "" {
+				files = append(files, prev)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return files, nil
+}
+
+// MergedPullRequests lists the pull requests whose merge commits are between
+// prev and current, in the order they were merged.
+func (c *githubClient) MergedPullRequests(ctx *context.Context, repo Repo, prev, current string) ([]ChangelogItem, error) {
+	c.checkRateLimit(ctx)
+	commits := map[string]int{}
+	var since time.Time
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		result, resp, err := c.client.Repositories.CompareCommits(ctx, repo.Owner, repo.Name, prev, current, opts)
+		if err != nil {
+			return nil, err
+		}
+		if since.IsZero() {
+			since = result.GetBaseCommit().GetCommit().GetCommitter().GetDate().Time
+		}
+		for _, commit := range result.Commits {
+			commits[commit.GetSHA()] = len(commits)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	var prs []ChangelogItem
+	listOpts := &github.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		c.checkRateLimit(ctx)
+		page, resp, err := c.client.PullRequests.List(ctx, repo.Owner, repo.Name, listOpts)
+		if err != nil {
+			return nil, err
+		}
+		done := false
+		for _, pr := range page {
+			// pull requests merged after prev were necessarily updated
+			// after it too.
+			if pr.GetUpdatedAt().Before(since) {
+				done = true
+				break
+			}
+			if pr.MergedAt == nil {
+				continue
+			}
+			if _, ok := commits[pr.GetMergeCommitSHA()]; !ok {
+				continue
+			}
+			var labels []string
+			for _, label := range pr.Labels {
+				labels = append(labels, label.GetName())
+			}
+			prs = append(prs, ChangelogItem{
+				SHA:            pr.GetMergeCommitSHA(),
+				Message:        pr.GetTitle(),
+				AuthorUsername: pr.GetUser().GetLogin(),
+				Date:           pr.GetMergedAt().Time,
+				Number:         pr.GetNumber(),
+				URL:            pr.GetHTMLURL(),
+				Labels:         labels,
+			})
+		}
+		if done || resp.NextPage == 0 {
+			break
+		}
+		listOpts.Page = resp.NextPage
+	}
+
+	sort.SliceStable(prs, func(i, j int) bool {
+		return commits[prs[i].SHA] < commits[prs[j].SHA]
+	})
+	return prs, nil
+}
+
 // getDefaultBranch returns the default branch of a github repo
 func (c *githubClient) getDefaultBranch(ctx *context.Context, repo Repo) (string, error) {
 	c.checkRateLimit(ctx)
@@ -172,6 +295,21 @@ func (c *githubClient) CloseMilestone(ctx *context.Context, repo Repo, title str
 	return err
 }
 
+func (c *githubClient) CreateDeploymentStatus(ctx *context.Context, repo Repo, id int64, state, url string) error {
+	c.checkRateLimit(ctx)
+	_, _, err := c.client.Repositories.CreateDeploymentStatus(
+		ctx,
+		repo.Owner,
+		repo.Name,
+		id,
+		&github.DeploymentStatusRequest{
+			State:  github.String(state),
+			LogURL: github.String(url),
+		},
+	)
+	return err
+}
+
 func headString(base, head Repo) string {
 	return strings.Join([]string{
 		ordered.First(head.Owner, base.Owner),
@@ -198,15 +336,15 @@ func (c *githubClient) OpenPullRequest(
 	ctx *context.Context,
 	base, head Repo,
 	title string,
-	draft bool,
-) error {
+	draft, autoMerge bool,
+) (string, error) {
 	c.checkRateLimit(ctx)
 	base.Owner = ordered.First(base.Owner, head.Owner)
 	base.Name = ordered.First(base.Name, head.Name)
 	if base.Branch == "" {
 		def, err := c.getDefaultBranch(ctx, base)
 		if err != nil {
-			return err
+			return "", err
 		}
 		base.Branch = def
 	}
@@ -218,6 +356,8 @@ func (c *githubClient) OpenPullRequest(
 		log.Info("got a pr template")
 	}
 
+	title, body := prTitleAndBody(title, tpl)
+
 	log := log.
 		WithField("base", headString(base, Repo{})).
 		WithField("head", headString(base, head)).
@@ -231,19 +371,22 @@ func (c *githubClient) OpenPullRequest(
 			Title: github.String(title),
 			Base:  github.String(base.Branch),
 			Head:  github.String(headString(base, head)),
-			Body:  github.String(strings.Join([]string{tpl, prFooter}, "\n")),
+			Body:  github.String(body),
 			Draft: github.Bool(draft),
 		},
 	)
 	if err != nil {
 		if res.StatusCode == http.StatusUnprocessableEntity {
 			log.WithError(err).Warn("pull request validation failed")
-			return nil
+			return "", nil
 		}
-		return fmt.Errorf("could not create pull request: %w", err)
+		return "", fmt.Errorf("could not create pull request: %w", err)
 	}
 	log.WithField("url", pr.GetHTMLURL()).Info("pull request created")
-	return nil
+	if autoMerge {
+		log.Warn("auto merge is not supported on GitHub, ignoring")
+	}
+	return pr.GetHTMLURL(), nil
 }
 
 func (c *githubClient) SyncFork(ctx *context.Context, head, base Repo) error {
@@ -350,6 +493,15 @@ func (c *githubClient) CreateFile(
 		return fmt.Errorf("could not get %q: %w", path, err)
 	}
 
+	if isUpToDate(file, content) {
+		log.
+			WithField("repository", repo.String()).
+			WithField("branch", branch).
+			WithField("file", path).
+			Info("file is up to date, not committing")
+		return nil
+	}
+
 	options.SHA = github.String(file.GetSHA())
 	if _, _, err := c.client.Repositories.UpdateFile(
 		ctx,
@@ -363,6 +515,15 @@ func (c *githubClient) CreateFile(
 	return nil
 }
 
+// isUpToDate checks whether the existing file already has the given content.
+func isUpToDate(file *github.RepositoryContent, content []byte) bool {
+	if file == nil {
+		return false
+	}
+	current, err := file.GetContent()
+	return err == nil && current == string(content)
+}
+
 func (c *githubClient) CreateRelease(ctx *context.Context, body string) (string, error) {
 	c.checkRateLimit(ctx)
 	title, err := tmpl.New(ctx).Apply(ctx.Config.Release.NameTemplate)
@@ -376,6 +537,12 @@ func (c *githubClient) CreateRelease(ctx *context.Context, body string) (string,
 		}
 	}
 
+	if ctx.Nightly {
+		if err := c.deleteExistingNightlyRelease(ctx, ctx.Git.CurrentTag); err != nil {
+			return "", err
+		}
+	}
+
 	// Truncate the release notes if it's too long (github doesn't allow more than 125000 characters)
 	body = truncateReleaseBody(body)
 
@@ -389,14 +556,26 @@ func (c *githubClient) CreateRelease(ctx *context.Context, body string) (string,
 		Prerelease: github.Bool(ctx.PreRelease),
 	}
 
-	if target := ctx.Config.Release.TargetCommitish; target != "" {
-		target, err := tmpl.New(ctx).Apply(target)
-		if err != nil {
-			return "", err
-		}
-		if target != "" {
-			data.TargetCommitish = github.String(target)
+	if ctx.Nightly {
+		// the nightly tag is (re)created on the current commit.
+		data.TargetCommitish = github.String(ctx.Git.FullCommit)
+	}
+
+	target, err := targetCommitish(ctx)
+	if err != nil {
+		return "", err
+	}
+	if target != "" {
+		if _, _, err := c.client.Repositories.GetCommitSHA1(
+			ctx,
+			ctx.Config.Release.GitHub.Owner,
+			ctx.Config.Release.GitHub.Name,
+			target,
+			"",
+		); err != nil {
+			return "", fmt.Errorf("could not find target_commitish %q: %w", target, err)
 		}
+		data.TargetCommitish = github.String(target)
 	}
 
 	release, err := c.createOrUpdateRelease(ctx, data, body)
@@ -420,7 +599,11 @@ func (c *githubClient) PublishRelease(ctx *context.Context, releaseID string) er
 	data := &github.RepositoryRelease{
 		Draft: github.Bool(draft),
 	}
-	if latest := strings.TrimSpace(ctx.Config.Release.MakeLatest); latest != "" {
+	latest, err := tmpl.New(ctx).Apply(ctx.Config.Release.MakeLatest)
+	if err != nil {
+		return fmt.Errorf("could not template make_latest: %w", err)
+	}
+	if latest := strings.TrimSpace(latest); latest != "" {
 		data.MakeLatest = github.String(latest)
 	}
 	if ctx.Config.Release.DiscussionCategoryName != "" {
@@ -484,7 +667,7 @@ func (c *githubClient) ReleaseURLTemplate(ctx *context.Context) (string, error)
 	}
 
 	return fmt.Sprintf(
-		"%s/%s/%s/releases/download/{{ .Tag }}/{{ .ArtifactName }}",
+		"%s/%s/%s/releases/download/{{ .PrefixedTag }}/{{ .ArtifactName }}",
 		downloadURL,
 		ctx.Config.Release.GitHub.Owner,
 		ctx.Config.Release.GitHub.Name,
@@ -684,6 +867,46 @@ func (c *githubClient) deleteExistingDraftRelease(ctx *context.Context, name str
 	}
 }
 
+// deleteExistingNightlyRelease deletes the release and the tag of the previous
+// nightly, so only the latest nightly exists.
+func (c *githubClient) deleteExistingNightlyRelease(ctx *context.Context, tag string) error {
+	c.checkRateLimit(ctx)
+	release, resp, err := c.client.Repositories.GetReleaseByTag(
+		ctx,
+		ctx.Config.Release.GitHub.Owner,
+		ctx.Config.Release.GitHub.Name,
+		tag,
+	)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return fmt.Errorf("could not get previous nightly release: %w", err)
+	}
+	if err == nil {
+		if _, err := c.client.Repositories.DeleteRelease(
+			ctx,
+			ctx.Config.Release.GitHub.Owner,
+			ctx.Config.Release.GitHub.Name,
+			release.GetID(),
+		); err != nil {
+			return fmt.Errorf("could not delete previous nightly release: %w", err)
+		}
+		log.WithField("commit", release.GetTargetCommitish()).
+			WithField("tag", tag).
+			Info("deleted previous nightly release")
+	}
+
+	resp, err = c.client.Git.DeleteRef(
+		ctx,
+		ctx.Config.Release.GitHub.Owner,
+		ctx.Config.Release.GitHub.Name,
+		"tags/"+tag,
+	)
+	// github answers with 422 when the reference does not exist.
+	if err != nil && (resp == nil || (resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusUnprocessableEntity)) {
+		return fmt.Errorf("could not delete previous nightly tag: %w", err)
+	}
+	return nil
+}
+
 func githubErrLogger(resp *github.Response, err error) *log.Entry {
 	requestID := ""
 	if resp != nil {
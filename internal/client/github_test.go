@@ -17,6 +17,7 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/stretchr/testify/require"
 )
@@ -115,6 +116,28 @@ func TestNewGitHubClient(t *testing.T) {
 	})
 }
 
+func TestGitHubLatestReleaseHTTPClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/repos/owner/name/releases/latest", r.URL.Path)
+		require.Equal(t, "my-agent", r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`{"tag_name":"v1.2.3"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitHubURLs: config.GitHubURLs{
+			API:       srv.URL + "/api/",
+			UserAgent: "my-agent",
+		},
+		HTTP: config.HTTP{
+			CABundle: testlib.ServerCert(t, srv),
+		},
+	})
+	out, err := tmpl.New(ctx).Apply(`{{ latestRelease "owner/name" }}`)
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.3", out)
+}
+
 func TestGitHubUploadReleaseIDNotInt(t *testing.T) {
 	ctx := testctx.New()
 	client, err := newGitHub(ctx, ctx.Token)
@@ -137,12 +160,12 @@ func TestGitHubReleaseURLTemplate(t *testing.T) {
 		{
 			name:            "default_download_url",
 			downloadURL:     DefaultGitHubDownloadURL,
-			wantDownloadURL: "https://github.com/owner/name/releases/download/{{ .Tag }}/{{ .ArtifactName }}",
+			wantDownloadURL: "https://github.com/owner/name/releases/download/{{ .PrefixedTag }}/{{ .ArtifactName }}",
 		},
 		{
 			name:            "download_url_template",
 			downloadURL:     "{{ .Env.GORELEASER_TEST_GITHUB_URLS_DOWNLOAD }}",
-			wantDownloadURL: "https://github.mycompany.com/owner/name/releases/download/{{ .Tag }}/{{ .ArtifactName }}",
+			wantDownloadURL: "https://github.mycompany.com/owner/name/releases/download/{{ .PrefixedTag }}/{{ .ArtifactName }}",
 		},
 		{
 			name:        "download_url_template_invalid_value",
@@ -307,10 +330,118 @@ func TestGitHubChangelog(t *testing.T) {
 			AuthorName:     "Octocat",
 			AuthorEmail:    "octo@cat",
 			AuthorUsername: "octocat",
+			Date:           time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC),
 		},
 	}, log)
 }
 
+func TestGitHubCommitFiles(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		if r.URL.Path == "/repos/someone/something/commits/6dcb09b5" {
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprint(w, `{"files": [{"filename": "docs/index.md"}]}`)
+				return
+			}
+			w.Header().Set("Link", `<`+"http://"+r.Host+r.URL.Path+`?page=2>; rel="next"`)
+			fmt.Fprint(w, `{"files": [{"filename": "main.go"}, {"filename": "new.go", "previous_filename": "old.go"}]}`)
+			return
+		}
+		if r.URL.Path == "/rate_limit" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"resources":{"core":{"remaining":120}}}`)
+			return
+		}
+		t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitHubURLs: config.GitHubURLs{
+			API: srv.URL + "/",
+		},
+	})
+	client, err := newGitHub(ctx, "test-token")
+	require.NoError(t, err)
+	repo := Repo{
+		Owner: "someone",
+		Name:  "something",
+	}
+
+	files, err := client.CommitFiles(ctx, repo, "6dcb09b5")
+	require.NoError(t, err)
+	require.Equal(t, []string{"main.go", "new.go", "old.go", "docs/index.md"}, files)
+}
+
+func TestGitHubMergedPullRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		if r.URL.Path == "/repos/someone/something/compare/v1.0.0...v1.1.0" {
+			fmt.Fprint(w, `{
+				"base_commit": {"sha": "aaa", "commit": {"committer": {"date": "2024-01-10T00:00:00Z"}}},
+				"commits": [{"sha": "bbb"}, {"sha": "ccc"}]
+			}`)
+			return
+		}
+		if r.URL.Path == "/repos/someone/something/pulls" {
+			require.Equal(t, "closed", r.URL.Query().Get("state"))
+			require.Equal(t, "updated", r.URL.Query().Get("sort"))
+			require.Equal(t, "desc", r.URL.Query().Get("direction"))
+			fmt.Fprint(w, `[
+				{"number": 3, "title": "feat: foo", "html_url": "https://github.com/someone/something/pull/3", "merge_commit_sha": "ccc", "merged_at": "2024-01-12T00:00:00Z", "updated_at": "2024-01-12T00:00:00Z", "user": {"login": "octocat"}, "labels": [{"name": "enhancement"}]},
+				{"number": 4, "title": "closed without merging", "merge_commit_sha": "ddd", "updated_at": "2024-01-12T00:00:00Z"},
+				{"number": 5, "title": "merged elsewhere", "merge_commit_sha": "eee", "merged_at": "2024-01-11T00:00:00Z", "updated_at": "2024-01-11T00:00:00Z"},
+				{"number": 2, "title": "fix: bar", "html_url": "https://github.com/someone/something/pull/2", "merge_commit_sha": "bbb", "merged_at": "2024-01-11T00:00:00Z", "updated_at": "2024-01-11T00:00:00Z", "user": {"login": "octodog"}},
+				{"number": 1, "title": "too old", "merge_commit_sha": "aaa", "merged_at": "2024-01-09T00:00:00Z", "updated_at": "2024-01-09T00:00:00Z"}
+			]`)
+			return
+		}
+		if r.URL.Path == "/rate_limit" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"resources":{"core":{"remaining":120}}}`)
+			return
+		}
+		t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitHubURLs: config.GitHubURLs{
+			API: srv.URL + "/",
+		},
+	})
+	client, err := newGitHub(ctx, "test-token")
+	require.NoError(t, err)
+	repo := Repo{
+		Owner: "someone",
+		Name:  "something",
+	}
+
+	prs, err := client.MergedPullRequests(ctx, repo, "v1.0.0", "v1.1.0")
+	require.NoError(t, err)
+	require.Equal(t, []ChangelogItem{
+		{
+			SHA:            "bbb",
+			Message:        "fix: bar",
+			AuthorUsername: "octodog",
+			Date:           time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+			Number:         2,
+			URL:            "https://github.com/someone/something/pull/2",
+		},
+		{
+			SHA:            "ccc",
+			Message:        "feat: foo",
+			AuthorUsername: "octocat",
+			Date:           time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC),
+			Number:         3,
+			URL:            "https://github.com/someone/something/pull/3",
+			Labels:         []string{"enhancement"},
+		},
+	}, prs)
+}
+
 func TestGitHubReleaseNotes(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer r.Body.Close()
@@ -416,6 +547,324 @@ func TestGitHubCloseMilestone(t *testing.T) {
 	require.NoError(t, client.CloseMilestone(ctx, repo, "v1.13.0"))
 }
 
+func TestGitHubCreateDeploymentStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		if r.URL.Path == "/repos/someone/something/deployments/123/statuses" {
+			require.Equal(t, http.MethodPost, r.Method)
+			var req github.DeploymentStatusRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "success", req.GetState())
+			require.Equal(t, "https://example.com/releases/v1.0.0", req.GetLogURL())
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":1,"state":"success"}`)
+			return
+		}
+
+		if r.URL.Path == "/rate_limit" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"resources":{"core":{"remaining":120}}}`)
+			return
+		}
+
+		t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitHubURLs: config.GitHubURLs{
+			API: srv.URL + "/",
+		},
+	})
+	client, err := newGitHub(ctx, "test-token")
+	require.NoError(t, err)
+	repo := Repo{
+		Owner: "someone",
+		Name:  "something",
+	}
+
+	require.NoError(t, client.CreateDeploymentStatus(ctx, repo, 123, "success", "https://example.com/releases/v1.0.0"))
+}
+
+func TestGitHubPublishReleaseMakeLatest(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		makeLatest string
+		prerelease string
+		expected   string
+	}{
+		{"stable", "{{ if .Prerelease }}false{{ else }}true{{ end }}", "", "true"},
+		{"prerelease", "{{ if .Prerelease }}false{{ else }}true{{ end }}", "rc1", "false"},
+		{"legacy", "legacy", "", "legacy"},
+		{"unset", "", "", ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+
+				if r.URL.Path == "/repos/someone/something/releases/123" {
+					require.Equal(t, http.MethodPatch, r.Method)
+					var req github.RepositoryRelease
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+					require.Equal(t, tt.expected, req.GetMakeLatest())
+					w.WriteHeader(http.StatusOK)
+					fmt.Fprint(w, `{"id":123}`)
+					return
+				}
+
+				if r.URL.Path == "/rate_limit" {
+					w.WriteHeader(http.StatusOK)
+					fmt.Fprint(w, `{"resources":{"core":{"remaining":120}}}`)
+					return
+				}
+
+				t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+			}))
+			defer srv.Close()
+
+			ctx := testctx.NewWithCfg(config.Project{
+				GitHubURLs: config.GitHubURLs{
+					API: srv.URL + "/",
+				},
+				Release: config.Release{
+					GitHub: config.Repo{
+						Owner: "someone",
+						Name:  "something",
+					},
+					MakeLatest: tt.makeLatest,
+				},
+			}, testctx.WithSemver(1, 0, 0, tt.prerelease))
+			client, err := newGitHub(ctx, "test-token")
+			require.NoError(t, err)
+			require.NoError(t, client.PublishRelease(ctx, "123"))
+		})
+	}
+}
+
+func TestGitHubCreateReleaseNightly(t *testing.T) {
+	var deletedRelease, deletedTag bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		if r.URL.Path == "/repos/someone/something/releases/tags/nightly" {
+			require.Equal(t, http.MethodGet, r.Method)
+			if deletedRelease {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"id":1,"tag_name":"nightly","target_commitish":"old"}`)
+			return
+		}
+
+		if r.URL.Path == "/repos/someone/something/releases/1" {
+			require.Equal(t, http.MethodDelete, r.Method)
+			deletedRelease = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if r.URL.Path == "/repos/someone/something/git/refs/tags/nightly" {
+			require.Equal(t, http.MethodDelete, r.Method)
+			deletedTag = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if r.URL.Path == "/repos/someone/something/releases" {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.True(t, deletedRelease, "should delete the previous release first")
+			require.True(t, deletedTag, "should delete the previous tag first")
+			var req github.RepositoryRelease
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "nightly", req.GetTagName())
+			require.Equal(t, "abc123", req.GetTargetCommitish())
+			require.True(t, req.GetPrerelease())
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":2}`)
+			return
+		}
+
+		if r.URL.Path == "/rate_limit" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"resources":{"core":{"remaining":120}}}`)
+			return
+		}
+
+		t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitHubURLs: config.GitHubURLs{
+			API: srv.URL + "/",
+		},
+		Release: config.Release{
+			GitHub: config.Repo{
+				Owner: "someone",
+				Name:  "something",
+			},
+		},
+	},
+		testctx.Nightly,
+		testctx.WithCurrentTag("nightly"),
+		testctx.WithCommit("abc123"),
+	)
+	ctx.PreRelease = true
+	client, err := newGitHub(ctx, "test-token")
+	require.NoError(t, err)
+	id, err := client.CreateRelease(ctx, "body")
+	require.NoError(t, err)
+	require.Equal(t, "2", id)
+}
+
+func TestGitHubCreateReleaseNightlyFirstRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		if r.URL.Path == "/repos/someone/something/releases/tags/nightly" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Path == "/repos/someone/something/git/refs/tags/nightly" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message":"Reference does not exist"}`)
+			return
+		}
+
+		if r.URL.Path == "/repos/someone/something/releases" {
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":1}`)
+			return
+		}
+
+		if r.URL.Path == "/rate_limit" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"resources":{"core":{"remaining":120}}}`)
+			return
+		}
+
+		t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitHubURLs: config.GitHubURLs{
+			API: srv.URL + "/",
+		},
+		Release: config.Release{
+			GitHub: config.Repo{
+				Owner: "someone",
+				Name:  "something",
+			},
+		},
+	}, testctx.Nightly, testctx.WithCurrentTag("nightly"))
+	client, err := newGitHub(ctx, "test-token")
+	require.NoError(t, err)
+	id, err := client.CreateRelease(ctx, "body")
+	require.NoError(t, err)
+	require.Equal(t, "1", id)
+}
+
+func TestGitHubCreateReleaseTargetCommitish(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		status int
+		err    string
+	}{
+		{"found", http.StatusOK, ""},
+		{"not found", http.StatusUnprocessableEntity, `could not find target_commitish "release/v1"`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var created bool
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+
+				if r.URL.Path == "/repos/someone/something/commits/release/v1" {
+					require.Equal(t, http.MethodGet, r.Method)
+					w.WriteHeader(tt.status)
+					fmt.Fprint(w, "deadbeef")
+					return
+				}
+
+				if r.URL.Path == "/repos/someone/something/releases/tags/v1.0.0" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				if r.URL.Path == "/repos/someone/something/releases" {
+					require.Equal(t, http.MethodPost, r.Method)
+					var req github.RepositoryRelease
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+					require.Equal(t, "release/v1", req.GetTargetCommitish())
+					created = true
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"id":1}`)
+					return
+				}
+
+				if r.URL.Path == "/rate_limit" {
+					w.WriteHeader(http.StatusOK)
+					fmt.Fprint(w, `{"resources":{"core":{"remaining":120}}}`)
+					return
+				}
+
+				t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+			}))
+			defer srv.Close()
+
+			ctx := testctx.NewWithCfg(config.Project{
+				GitHubURLs: config.GitHubURLs{
+					API: srv.URL + "/",
+				},
+				Env: []string{"TARGET_BRANCH=release/v1"},
+				Release: config.Release{
+					GitHub: config.Repo{
+						Owner: "someone",
+						Name:  "something",
+					},
+					TargetCommitish: "{{ .Env.TARGET_BRANCH }}",
+				},
+			}, testctx.WithCurrentTag("v1.0.0"))
+			client, err := newGitHub(ctx, "test-token")
+			require.NoError(t, err)
+			_, err = client.CreateRelease(ctx, "body")
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				require.False(t, created)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, created)
+		})
+	}
+}
+
+func TestGitHubCreateReleaseTargetCommitishBadTemplate(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Release: config.Release{
+			TargetCommitish: "{{ .Nope }",
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	client, err := newGitHub(ctx, "test-token")
+	require.NoError(t, err)
+	_, err = client.CreateRelease(ctx, "body")
+	require.ErrorContains(t, err, "could not template target_commitish")
+}
+
+func TestGitHubPublishReleaseMakeLatestBadTemplate(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Release: config.Release{
+			MakeLatest: "{{ .Nope }",
+		},
+	})
+	client, err := newGitHub(ctx, "test-token")
+	require.NoError(t, err)
+	testlib.RequireTemplateError(t, client.PublishRelease(ctx, "123"))
+}
+
 const testPRTemplate = "fake template\n- [ ] mark this\n---"
 
 func TestGitHubOpenPullRequestCrossRepo(t *testing.T) {
@@ -474,7 +923,8 @@ func TestGitHubOpenPullRequestCrossRepo(t *testing.T) {
 		Name:   "something",
 		Branch: "foo",
 	}
-	require.NoError(t, client.OpenPullRequest(ctx, base, head, "some title", false))
+	_, err = client.OpenPullRequest(ctx, base, head, "some title", false, false)
+	require.NoError(t, err)
 }
 
 func TestGitHubOpenPullRequestHappyPath(t *testing.T) {
@@ -522,7 +972,9 @@ func TestGitHubOpenPullRequestHappyPath(t *testing.T) {
 		Branch: "main",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, repo, Repo{}, "some title", false))
+	url, err := client.OpenPullRequest(ctx, repo, Repo{}, "some title", false, false)
+	require.NoError(t, err)
+	require.Equal(t, "https://github.com/caarlos0/svu/pull/1", url)
 }
 
 func TestGitHubOpenPullRequestNoBaseBranchDraft(t *testing.T) {
@@ -578,9 +1030,10 @@ func TestGitHubOpenPullRequestNoBaseBranchDraft(t *testing.T) {
 		Name:  "something",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, repo, Repo{
+	_, err = client.OpenPullRequest(ctx, repo, Repo{
 		Branch: "foo",
-	}, "some title", true))
+	}, "some title", true, false)
+	require.NoError(t, err)
 }
 
 func TestGitHubOpenPullRequestPRExists(t *testing.T) {
@@ -624,7 +1077,8 @@ func TestGitHubOpenPullRequestPRExists(t *testing.T) {
 		Branch: "main",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, repo, Repo{}, "some title", false))
+	_, err = client.OpenPullRequest(ctx, repo, Repo{}, "some title", false, false)
+	require.NoError(t, err)
 }
 
 func TestGitHubOpenPullRequestBaseEmpty(t *testing.T) {
@@ -673,7 +1127,8 @@ func TestGitHubOpenPullRequestBaseEmpty(t *testing.T) {
 		Branch: "foo",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, Repo{}, repo, "some title", false))
+	_, err = client.OpenPullRequest(ctx, Repo{}, repo, "some title", false, false)
+	require.NoError(t, err)
 }
 
 func TestGitHubOpenPullRequestHeadEmpty(t *testing.T) {
@@ -722,7 +1177,8 @@ func TestGitHubOpenPullRequestHeadEmpty(t *testing.T) {
 		Branch: "main",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, repo, Repo{}, "some title", false))
+	_, err = client.OpenPullRequest(ctx, repo, Repo{}, "some title", false, false)
+	require.NoError(t, err)
 }
 
 func TestGitHubCreateFileHappyPathCreate(t *testing.T) {
@@ -816,6 +1272,47 @@ func TestGitHubCreateFileHappyPathUpdate(t *testing.T) {
 	require.NoError(t, client.CreateFile(ctx, config.CommitAuthor{}, repo, []byte("content"), "file.txt", "message"))
 }
 
+func TestGitHubCreateFileUpToDate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		if r.URL.Path == "/repos/someone/something" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"default_branch": "main"}`)
+			return
+		}
+
+		if r.URL.Path == "/repos/someone/something/contents/file.txt" && r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"sha": "fake", "encoding": "base64", "content": "Y29udGVudA=="}`)
+			return
+		}
+
+		if r.URL.Path == "/rate_limit" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"resources":{"core":{"remaining":120}}}`)
+			return
+		}
+
+		t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitHubURLs: config.GitHubURLs{
+			API: srv.URL + "/",
+		},
+	})
+	client, err := newGitHub(ctx, "test-token")
+	require.NoError(t, err)
+	repo := Repo{
+		Owner: "someone",
+		Name:  "something",
+	}
+
+	require.NoError(t, client.CreateFile(ctx, config.CommitAuthor{}, repo, []byte("content"), "file.txt", "message"))
+}
+
 func TestGitHubCreateFileFeatureBranchAlreadyExists(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer r.Body.Close()
@@ -1,16 +1,19 @@
 package client
 
 import (
-	"crypto/tls"
+	"bytes"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/caarlos0/log"
 	"github.com/charmbracelet/x/exp/ordered"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -31,16 +34,25 @@ type gitlabClient struct {
 
 // newGitLab returns a gitlab client implementation.
 func newGitLab(ctx *context.Context, token string) (*gitlabClient, error) {
-	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		TLSClientConfig: &tls.Config{
-			//nolint:gosec
-			InsecureSkipVerify: ctx.Config.GitLabURLs.SkipTLSVerify,
+	transport, err := httpclient.Transport(ctx, httpclient.Options{
+		TLS: httpclient.TLSOptions{
+			SkipVerify: ctx.Config.GitLabURLs.SkipTLSVerify,
 		},
+		Proxy: httpclient.ProxyOptions{
+			URL:     ctx.Config.GitLabURLs.Proxy,
+			NoProxy: ctx.Config.GitLabURLs.NoProxy,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gitlab_urls: %w", err)
+	}
+	ua, err := httpclient.UserAgent(ctx, ctx.Config.GitLabURLs.UserAgent)
+	if err != nil {
+		return nil, fmt.Errorf("templating GitLab user agent: %w", err)
 	}
 	options := []gitlab.ClientOptionFunc{
 		gitlab.WithHTTPClient(&http.Client{
-			Transport: transport,
+			Transport: httpclient.WithUserAgent(transport, ua),
 		}),
 	}
 	if ctx.Config.GitLabURLs.API != "" {
@@ -54,7 +66,6 @@ func newGitLab(ctx *context.Context, token string) (*gitlabClient, error) {
 
 	var client *gitlab.Client
 	var authType gitlab.AuthType
-	var err error
 	if checkUseJobToken(*ctx, token) {
 		client, err = gitlab.NewJobClient(token, options...)
 		authType = gitlab.JobToken
@@ -93,16 +104,49 @@ func (c *gitlabClient) Changelog(_ *context.Context, repo Repo, prev, current st
 	}
 
 	for _, commit := range result.Commits {
+		var date time.Time
+		if commit.AuthoredDate != nil {
+			date = commit.AuthoredDate.UTC()
+		}
 		log = append(log, ChangelogItem{
 			SHA:         commit.ID,
 			Message:     strings.Split(commit.Message, "\n")[0],
 			AuthorName:  commit.AuthorName,
 			AuthorEmail: commit.AuthorEmail,
+			Date:        date,
 		})
 	}
 	return log, nil
 }
 
+// CommitFiles lists the files changed by the given commit.
+func (c *gitlabClient) CommitFiles(_ *context.Context, repo Repo, sha string) ([]string, error) {
+	if err := c.checkIsPrivateToken(); err != nil {
+		return nil, fmt.Errorf("commit files: %w", err)
+	}
+	var files []string
+	opts := &gitlab.GetCommitDiffOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 100},
+	}
+	for {
+		diffs, resp, err := c.client.Commits.GetCommitDiff(repo.String(), sha, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, diff := range diffs {
+			files = append(files, diff.NewPath)
+			if diff.OldPath != diff.NewPath {
+				files = append(files, diff.OldPath)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return files, nil
+}
+
 // getDefaultBranch get the default branch
 func (c *gitlabClient) getDefaultBranch(_ *context.Context, repo Repo) (string, error) {
 	if branch := os.Getenv("CI_DEFAULT_BRANCH"); branch != "" {
@@ -244,7 +288,7 @@ func (c *gitlabClient) CreateFile(
 	}
 
 	// Check if the file already exists
-	_, res, err := c.client.RepositoryFiles.GetFile(projectID, fileName, opts)
+	file, res, err := c.client.RepositoryFiles.GetFile(projectID, fileName, opts)
 	if err != nil && (res == nil || res.StatusCode != 404) {
 		log := log.
 			WithField("fileName", fileName).
@@ -258,6 +302,19 @@ func (c *gitlabClient) CreateFile(
 		return err
 	}
 
+	// only skip when committing to an existing branch, otherwise the branch
+	// still needs to be created.
+	if branchExists && file != nil {
+		if current, err := base64.StdEncoding.DecodeString(file.Content); err == nil && bytes.Equal(current, content) {
+			log.
+				WithField("projectID", projectID).
+				WithField("branch", branch).
+				WithField("fileName", fileName).
+				Info("file is up to date, not committing")
+			return nil
+		}
+	}
+
 	log.
 		WithField("projectID", projectID).
 		WithField("branch", branch).
@@ -391,6 +448,18 @@ func (c *gitlabClient) CreateRelease(ctx *context.Context, body string) (release
 		ref := ctx.Git.Commit
 		gitURL := ctx.Git.URL
 
+		var target string
+		target, err = targetCommitish(ctx)
+		if err != nil {
+			return "", err
+		}
+		if target != "" {
+			if _, _, err = c.client.Commits.GetCommit(projectID, target, nil); err != nil {
+				return "", fmt.Errorf("could not find target_commitish %q: %w", target, err)
+			}
+			ref = target
+		}
+
 		log.
 			WithField("name", name).
 			WithField("description", description).
@@ -447,14 +516,14 @@ func (c *gitlabClient) ReleaseURLTemplate(ctx *context.Context) (string, error)
 
 	if ctx.Config.Release.GitLab.Owner != "" {
 		urlTemplate = fmt.Sprintf(
-			"%s/%s/%s/-/releases/{{ .Tag }}/downloads/{{ .ArtifactName }}",
+			"%s/%s/%s/-/releases/{{ .PrefixedTag }}/downloads/{{ .ArtifactName }}",
 			downloadURL,
 			ctx.Config.Release.GitLab.Owner,
 			gitlabName,
 		)
 	} else {
 		urlTemplate = fmt.Sprintf(
-			"%s/%s/-/releases/{{ .Tag }}/downloads/{{ .ArtifactName }}",
+			"%s/%s/-/releases/{{ .PrefixedTag }}/downloads/{{ .ArtifactName }}",
 			downloadURL,
 			gitlabName,
 		)
@@ -615,10 +684,10 @@ func (c *gitlabClient) OpenPullRequest(
 	ctx *context.Context,
 	base, head Repo,
 	title string,
-	draft bool,
-) error {
+	draft, autoMerge bool,
+) (string, error) {
 	if err := c.checkIsPrivateToken(); err != nil {
-		return fmt.Errorf("open merge request: %w", err)
+		return "", fmt.Errorf("open merge request: %w", err)
 	}
 	var targetProjectID int
 	if base.Owner != "" {
@@ -631,7 +700,7 @@ func (c *gitlabClient) OpenPullRequest(
 				log = log.WithField("statusCode", res.StatusCode)
 			}
 			log.WithError(err).Warn("error getting base project id")
-			return err
+			return "", err
 		}
 		targetProjectID = p.ID
 	}
@@ -642,11 +711,12 @@ func (c *gitlabClient) OpenPullRequest(
 	if base.Branch == "" {
 		def, err := c.getDefaultBranch(ctx, base)
 		if err != nil {
-			return err
+			return "", err
 		}
 		base.Branch = def
 	}
 
+	title, body := prTitleAndBody(title)
 	if draft {
 		title = fmt.Sprintf("Draft: %s", title)
 	}
@@ -660,16 +730,60 @@ func (c *gitlabClient) OpenPullRequest(
 		SourceBranch: &head.Branch,
 		TargetBranch: &base.Branch,
 		Title:        &title,
+		Description:  &body,
 	}
 
 	if targetProjectID != 0 {
 		mrOptions.TargetProjectID = &targetProjectID
 	}
 
-	pr, _, err := c.client.MergeRequests.CreateMergeRequest(fmt.Sprintf("%s/%s", head.Owner, head.Name), mrOptions)
+	headProject := fmt.Sprintf("%s/%s", head.Owner, head.Name)
+	pr, res, err := c.client.MergeRequests.CreateMergeRequest(headProject, mrOptions)
 	if err != nil {
-		return fmt.Errorf("could not create pull request: %w", err)
+		if res == nil || res.StatusCode != http.StatusConflict {
+			return "", fmt.Errorf("could not create pull request: %w", err)
+		}
+		// there's already an open merge request for this source branch,
+		// the new commits were pushed to it, so we just reuse it.
+		var pid interface{} = headProject
+		if targetProjectID != 0 {
+			pid = targetProjectID
+		}
+		pr, err = c.getOpenMergeRequest(pid, head.Branch, base.Branch)
+		if err != nil {
+			return "", fmt.Errorf("could not create pull request: %w", err)
+		}
+		log.WithField("url", pr.WebURL).Info("pull request already exists")
+	} else {
+		log.WithField("url", pr.WebURL).Info("pull request created")
 	}
-	log.WithField("url", pr.WebURL).Info("pull request created")
-	return nil
+
+	if !autoMerge {
+		return pr.WebURL, nil
+	}
+
+	if _, _, err := c.client.MergeRequests.AcceptMergeRequest(pr.ProjectID, pr.IID, &gitlab.AcceptMergeRequestOptions{
+		MergeWhenPipelineSucceeds: gitlab.Ptr(true),
+	}); err != nil {
+		return "", fmt.Errorf("could not set pull request to auto merge: %w", err)
+	}
+	log.WithField("url", pr.WebURL).Info("pull request set to merge when pipeline succeeds")
+	return pr.WebURL, nil
+}
+
+// getOpenMergeRequest gets the open merge request from the given source
+// branch into the given target branch.
+func (c *gitlabClient) getOpenMergeRequest(pid interface{}, source, target string) (*gitlab.MergeRequest, error) {
+	mrs, _, err := c.client.MergeRequests.ListProjectMergeRequests(pid, &gitlab.ListProjectMergeRequestsOptions{
+		State:        gitlab.Ptr("opened"),
+		SourceBranch: &source,
+		TargetBranch: &target,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(mrs) == 0 {
+		return nil, fmt.Errorf("no open merge request found from %s into %s", source, target)
+	}
+	return mrs[0], nil
 }
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 	"text/template"
+	"time"
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
@@ -35,19 +36,19 @@ func TestGitLabReleaseURLTemplate(t *testing.T) {
 			name:            "default_download_url",
 			downloadURL:     DefaultGitLabDownloadURL,
 			repo:            repo,
-			wantDownloadURL: "https://gitlab.com/owner/name/-/releases/{{ .Tag }}/downloads/{{ .ArtifactName }}",
+			wantDownloadURL: "https://gitlab.com/owner/name/-/releases/{{ .PrefixedTag }}/downloads/{{ .ArtifactName }}",
 		},
 		{
 			name:            "default_download_url_no_owner",
 			downloadURL:     DefaultGitLabDownloadURL,
 			repo:            config.Repo{Name: "name"},
-			wantDownloadURL: "https://gitlab.com/name/-/releases/{{ .Tag }}/downloads/{{ .ArtifactName }}",
+			wantDownloadURL: "https://gitlab.com/name/-/releases/{{ .PrefixedTag }}/downloads/{{ .ArtifactName }}",
 		},
 		{
 			name:            "download_url_template",
 			repo:            repo,
 			downloadURL:     "{{ .Env.GORELEASER_TEST_GITLAB_URLS_DOWNLOAD }}",
-			wantDownloadURL: "https://gitlab.mycompany.com/owner/name/-/releases/{{ .Tag }}/downloads/{{ .ArtifactName }}",
+			wantDownloadURL: "https://gitlab.mycompany.com/owner/name/-/releases/{{ .PrefixedTag }}/downloads/{{ .ArtifactName }}",
 		},
 		{
 			name:        "download_url_template_invalid_value",
@@ -321,6 +322,72 @@ func TestGitLabCreateReleaseReleaseNotExists(t *testing.T) {
 	}
 }
 
+func TestGitLabCreateReleaseTargetCommitish(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		status int
+		err    string
+	}{
+		{"found", http.StatusOK, ""},
+		{"not found", http.StatusNotFound, `could not find target_commitish "release/v1"`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var ref string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+
+				if strings.HasSuffix(r.URL.RawPath, "/repository/commits/release%2Fv1") {
+					w.WriteHeader(tt.status)
+					fmt.Fprint(w, "{}")
+					return
+				}
+
+				if r.Method == http.MethodGet && strings.Contains(r.URL.Path, "releases") {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, "{}")
+					return
+				}
+
+				if r.Method == http.MethodPost && strings.Contains(r.URL.Path, "releases") {
+					var req map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+					ref = req["ref"].(string)
+					w.WriteHeader(http.StatusOK)
+					fmt.Fprint(w, "{}")
+					return
+				}
+
+				require.FailNow(t, "should not reach here: "+r.Method+" "+r.URL.String())
+			}))
+			defer srv.Close()
+
+			ctx := testctx.NewWithCfg(config.Project{
+				GitLabURLs: config.GitLabURLs{
+					API: srv.URL,
+				},
+				Release: config.Release{
+					GitLab: config.Repo{
+						Owner: "someone",
+						Name:  "something",
+					},
+					TargetCommitish: "release/v1",
+				},
+			}, testctx.WithCommit("abc123"), testctx.WithCurrentTag("v1.0.0"))
+			client, err := newGitLab(ctx, "test-token")
+			require.NoError(t, err)
+
+			_, err = client.CreateRelease(ctx, "body")
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				require.Empty(t, ref)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "release/v1", ref)
+		})
+	}
+}
+
 func TestGitLabCreateReleaseReleaseExists(t *testing.T) {
 	totalRequests := 0
 	createdRelease := false
@@ -516,10 +583,78 @@ func TestGitLabChangelog(t *testing.T) {
 			AuthorName:     "Joey User",
 			AuthorEmail:    "joey@user.edu",
 			AuthorUsername: "",
+			Date:           time.Date(2021, 10, 1, 0, 5, 21, 0, time.UTC),
 		},
 	}, log)
 }
 
+func TestGitLabCommitFiles(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if strings.HasSuffix(r.URL.Path, "projects/someone/something/repository/commits/6dcb09b5/diff") {
+			fmt.Fprint(w, `[
+				{"old_path": "main.go", "new_path": "main.go"},
+				{"old_path": "old.go", "new_path": "new.go"}
+			]`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitLabURLs: config.GitLabURLs{
+			API: srv.URL,
+		},
+	})
+	client, err := newGitLab(ctx, "test-token")
+	require.NoError(t, err)
+	repo := Repo{
+		Owner: "someone",
+		Name:  "something",
+	}
+
+	files, err := client.CommitFiles(ctx, repo, "6dcb09b5")
+	require.NoError(t, err)
+	require.Equal(t, []string{"main.go", "new.go", "old.go"}, files)
+}
+
+func TestGitLabCreateFileUpToDate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		if r.URL.Path == "/api/v4/projects/someone/something/repository/branches/somebranch" {
+			fmt.Fprint(w, "{}")
+			return
+		}
+
+		if r.URL.Path == "/api/v4/projects/someone/something/repository/files/file.txt" && r.Method == http.MethodGet {
+			fmt.Fprint(w, `{"file_path": "file.txt", "encoding": "base64", "content": "Y29udGVudA=="}`)
+			return
+		}
+
+		t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitLabURLs: config.GitLabURLs{
+			API: srv.URL,
+		},
+	})
+
+	client, err := newGitLab(ctx, "test-token")
+	require.NoError(t, err)
+
+	repo := Repo{
+		Owner:  "someone",
+		Name:   "something",
+		Branch: "somebranch",
+	}
+
+	require.NoError(t, client.CreateFile(ctx, config.CommitAuthor{}, repo, []byte("content"), "file.txt", "message"))
+}
+
 func TestGitLabCreateFile(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Handle the test where we know the branch and it exists
@@ -791,7 +926,9 @@ func TestGitLabOpenPullRequestCrossRepo(t *testing.T) {
 		Name:   "something",
 		Branch: "foo",
 	}
-	require.NoError(t, client.OpenPullRequest(ctx, base, head, "some title", false))
+	url, err := client.OpenPullRequest(ctx, base, head, "some title", false, false)
+	require.NoError(t, err)
+	require.Equal(t, "https://gitlab.com/someoneelse/something/merge_requests/1", url)
 }
 
 func TestGitLabOpenPullRequestBaseEmpty(t *testing.T) {
@@ -838,7 +975,8 @@ func TestGitLabOpenPullRequestBaseEmpty(t *testing.T) {
 		Branch: "foo",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, Repo{}, repo, "some title", false))
+	_, err = client.OpenPullRequest(ctx, Repo{}, repo, "some title", false, false)
+	require.NoError(t, err)
 }
 
 func TestGitLabOpenPullRequestDraft(t *testing.T) {
@@ -859,6 +997,7 @@ func TestGitLabOpenPullRequestDraft(t *testing.T) {
 			require.Equal(t, "main", pr.TargetBranch)
 			require.Equal(t, "main", pr.SourceBranch)
 			require.Equal(t, "Draft: some title", pr.Title)
+			require.Equal(t, prFooter, pr.Description)
 			require.Equal(t, 0, pr.TargetProjectID)
 
 			_, err = io.Copy(w, strings.NewReader(`{"web_url": "https://gitlab.com/someoneelse/something/merge_requests/1"}`))
@@ -885,7 +1024,8 @@ func TestGitLabOpenPullRequestDraft(t *testing.T) {
 		Branch: "main",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, Repo{}, repo, "some title", true))
+	_, err = client.OpenPullRequest(ctx, Repo{}, repo, "some title", true, false)
+	require.NoError(t, err)
 }
 
 func TestGitLabOpenPullBaseBranchGiven(t *testing.T) {
@@ -926,5 +1066,107 @@ func TestGitLabOpenPullBaseBranchGiven(t *testing.T) {
 		Branch: "foo",
 	}
 
-	require.NoError(t, client.OpenPullRequest(ctx, Repo{Branch: "main"}, repo, "some title", false))
+	_, err = client.OpenPullRequest(ctx, Repo{Branch: "main"}, repo, "some title", false, false)
+	require.NoError(t, err)
+}
+
+func TestGitLabOpenPullRequestAutoMerge(t *testing.T) {
+	var merged bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		if r.URL.Path == "/api/v4/projects/someone/something/merge_requests" {
+			_, err := io.Copy(w, strings.NewReader(`{"iid": 2, "project_id": 10, "web_url": "https://gitlab.com/someone/something/merge_requests/2"}`))
+			require.NoError(t, err)
+			return
+		}
+
+		if r.URL.Path == "/api/v4/projects/10/merge_requests/2/merge" {
+			require.Equal(t, http.MethodPut, r.Method)
+			got, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var opts gitlab.AcceptMergeRequestOptions
+			require.NoError(t, json.Unmarshal(got, &opts))
+			require.True(t, *opts.MergeWhenPipelineSucceeds)
+			merged = true
+
+			_, err = io.Copy(w, strings.NewReader(`{}`))
+			require.NoError(t, err)
+			return
+		}
+
+		t.Error("unhandled request: " + r.URL.Path)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitLabURLs: config.GitLabURLs{
+			API: srv.URL,
+		},
+	})
+
+	client, err := newGitLab(ctx, "test-token")
+	require.NoError(t, err)
+
+	repo := Repo{
+		Owner:  "someone",
+		Name:   "something",
+		Branch: "foo",
+	}
+
+	_, err = client.OpenPullRequest(ctx, Repo{Branch: "main"}, repo, "some title", false, true)
+	require.NoError(t, err)
+	require.True(t, merged)
+}
+
+func TestGitLabOpenPullRequestAlreadyExists(t *testing.T) {
+	var merged bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		if r.URL.Path == "/api/v4/projects/someone/something/merge_requests" && r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusConflict)
+			_, err := io.Copy(w, strings.NewReader(`{"message": ["Another open merge request already exists for this source branch: !1"]}`))
+			require.NoError(t, err)
+			return
+		}
+
+		if r.URL.Path == "/api/v4/projects/someone/something/merge_requests" && r.Method == http.MethodGet {
+			require.Equal(t, "opened", r.URL.Query().Get("state"))
+			require.Equal(t, "foo", r.URL.Query().Get("source_branch"))
+			require.Equal(t, "main", r.URL.Query().Get("target_branch"))
+			_, err := io.Copy(w, strings.NewReader(`[{"iid": 1, "project_id": 10, "web_url": "https://gitlab.com/someone/something/merge_requests/1"}]`))
+			require.NoError(t, err)
+			return
+		}
+
+		if r.URL.Path == "/api/v4/projects/10/merge_requests/1/merge" {
+			merged = true
+			_, err := io.Copy(w, strings.NewReader(`{}`))
+			require.NoError(t, err)
+			return
+		}
+
+		t.Error("unhandled request: " + r.Method + " " + r.URL.Path)
+	}))
+	defer srv.Close()
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitLabURLs: config.GitLabURLs{
+			API: srv.URL,
+		},
+	})
+
+	client, err := newGitLab(ctx, "test-token")
+	require.NoError(t, err)
+
+	repo := Repo{
+		Owner:  "someone",
+		Name:   "something",
+		Branch: "foo",
+	}
+
+	_, err = client.OpenPullRequest(ctx, Repo{Branch: "main"}, repo, "some title", false, true)
+	require.NoError(t, err)
+	require.True(t, merged)
 }
@@ -13,10 +13,12 @@ import (
 )
 
 var (
-	_ Client                = &Mock{}
-	_ ReleaseNotesGenerator = &Mock{}
-	_ PullRequestOpener     = &Mock{}
-	_ ForkSyncer            = &Mock{}
+	_ Client                  = &Mock{}
+	_ ReleaseNotesGenerator   = &Mock{}
+	_ PullRequestOpener       = &Mock{}
+	_ ForkSyncer              = &Mock{}
+	_ DeploymentStatusCreator = &Mock{}
+	_ PullRequestLister       = &Mock{}
 )
 
 func NewMock() *Mock {
@@ -40,10 +42,23 @@ type Mock struct {
 	ClosedMilestone      string
 	FailToCloseMilestone bool
 	Changes              []ChangelogItem
+	PullRequests         []ChangelogItem
+	Files                map[string][]string
 	ReleaseNotes         string
 	ReleaseNotesParams   []string
 	OpenedPullRequest    bool
+	PullRequestURL       string
 	SyncedFork           bool
+	DeploymentID         int64
+	DeploymentState      string
+	DeploymentURL        string
+}
+
+func (c *Mock) CreateDeploymentStatus(_ *context.Context, _ Repo, id int64, state, url string) error {
+	c.DeploymentID = id
+	c.DeploymentState = state
+	c.DeploymentURL = url
+	return nil
 }
 
 func (c *Mock) SyncFork(_ *context.Context, _ Repo, _ Repo) error {
@@ -51,9 +66,9 @@ func (c *Mock) SyncFork(_ *context.Context, _ Repo, _ Repo) error {
 	return nil
 }
 
-func (c *Mock) OpenPullRequest(_ *context.Context, _, _ Repo, _ string, _ bool) error {
+func (c *Mock) OpenPullRequest(_ *context.Context, _, _ Repo, _ string, _, _ bool) (string, error) {
 	c.OpenedPullRequest = true
-	return nil
+	return c.PullRequestURL, nil
 }
 
 func (c *Mock) Changelog(_ *context.Context, _ Repo, _, _ string) ([]ChangelogItem, error) {
@@ -63,6 +78,20 @@ func (c *Mock) Changelog(_ *context.Context, _ Repo, _, _ string) ([]ChangelogIt
 	return nil, ErrNotImplemented
 }
 
+func (c *Mock) MergedPullRequests(_ *context.Context, _ Repo, _, _ string) ([]ChangelogItem, error) {
+	if len(c.PullRequests) > 0 {
+		return c.PullRequests, nil
+	}
+	return nil, ErrNotImplemented
+}
+
+func (c *Mock) CommitFiles(_ *context.Context, _ Repo, sha string) ([]string, error) {
+	if files, ok := c.Files[sha]; ok {
+		return files, nil
+	}
+	return nil, ErrNotImplemented
+}
+
 func (c *Mock) GenerateReleaseNotes(_ *context.Context, _ Repo, prev, current string) (string, error) {
 	if c.ReleaseNotes != "" {
 		c.ReleaseNotesParams = []string{prev, current}
@@ -95,7 +124,7 @@ func (c *Mock) PublishRelease(_ *context.Context, _ string /* releaseID */) (err
 }
 
 func (c *Mock) ReleaseURLTemplate(_ *context.Context) (string, error) {
-	return "https://dummyhost/download/{{ .Tag }}/{{ .ArtifactName }}", nil
+	return "https://dummyhost/download/{{ .PrefixedTag }}/{{ .ArtifactName }}", nil
 }
 
 func (c *Mock) CreateFile(_ *context.Context, _ config.CommitAuthor, _ Repo, content []byte, path, msg string) error {
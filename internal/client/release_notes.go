@@ -1,6 +1,10 @@
 package client
 
-import "github.com/goreleaser/goreleaser/v2/pkg/config"
+import (
+	"strings"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+)
 
 func getReleaseNotes(existing, current string, mode config.ReleaseNotesMode) string {
 	switch mode {
@@ -10,6 +14,24 @@ func getReleaseNotes(existing, current string, mode config.ReleaseNotesMode) str
 		return current
 	case config.ReleaseNotesModePrepend:
 		return current + "\n\n" + existing
+	case config.ReleaseNotesModeAppendDedupe:
+		current = dedupeReleaseNotes(existing, current)
+		if current == "" {
+			return existing
+		}
+		if existing == "" {
+			return current
+		}
+		return existing + "\n\n" + current
+	case config.ReleaseNotesModePrependDedupe:
+		current = dedupeReleaseNotes(existing, current)
+		if current == "" {
+			return existing
+		}
+		if existing == "" {
+			return current
+		}
+		return current + "\n\n" + existing
 	default:
 		if existing != "" {
 			return existing
@@ -17,3 +39,20 @@ func getReleaseNotes(existing, current string, mode config.ReleaseNotesMode) str
 		return current
 	}
 }
+
+// dedupeReleaseNotes removes from current all the non-blank lines that
+// exactly match a line in existing.
+func dedupeReleaseNotes(existing, current string) string {
+	seen := map[string]bool{}
+	for _, line := range strings.Split(existing, "\n") {
+		seen[line] = true
+	}
+	var lines []string
+	for _, line := range strings.Split(current, "\n") {
+		if strings.TrimSpace(line) != "" && seen[line] {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
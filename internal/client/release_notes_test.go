@@ -31,7 +31,57 @@ func TestGetReleaseNotes(t *testing.T) {
 		require.Equal(t, "current rel notes\n\nexisting rel notes", getReleaseNotes(existing, current, config.ReleaseNotesModePrepend))
 	})
 
+	t.Run("append dedupe", func(t *testing.T) {
+		require.Equal(t, "existing rel notes\n\ncurrent rel notes", getReleaseNotes(existing, current, config.ReleaseNotesModeAppendDedupe))
+	})
+
+	t.Run("prepend dedupe", func(t *testing.T) {
+		require.Equal(t, "current rel notes\n\nexisting rel notes", getReleaseNotes(existing, current, config.ReleaseNotesModePrependDedupe))
+	})
+
+	t.Run("dedupe existing empty", func(t *testing.T) {
+		require.Equal(t, current, getReleaseNotes("", current, config.ReleaseNotesModeAppendDedupe))
+		require.Equal(t, current, getReleaseNotes("", current, config.ReleaseNotesModePrependDedupe))
+	})
+
 	t.Run("invalid", func(t *testing.T) {
 		require.Equal(t, existing, getReleaseNotes(existing, current, config.ReleaseNotesMode("invalid")))
 	})
 }
+
+func TestGetReleaseNotesDedupe(t *testing.T) {
+	const existing = "## Changelog\n\n* abc123 feat: foo\n* def456 fix: bar"
+	const current = "## Changelog\n\n* abc123 feat: foo\n* def456 fix: bar\n* 789abc feat: new"
+
+	t.Run("append", func(t *testing.T) {
+		result := getReleaseNotes(existing, current, config.ReleaseNotesModeAppendDedupe)
+		require.Equal(t, existing+"\n\n* 789abc feat: new", result)
+	})
+
+	t.Run("prepend", func(t *testing.T) {
+		result := getReleaseNotes(existing, current, config.ReleaseNotesModePrependDedupe)
+		require.Equal(t, "* 789abc feat: new\n\n"+existing, result)
+	})
+
+	t.Run("only exact lines", func(t *testing.T) {
+		result := getReleaseNotes(existing, "* ABC123 feat: foo\n* abc123 feat: foo", config.ReleaseNotesModeAppendDedupe)
+		require.Equal(t, existing+"\n\n* ABC123 feat: foo", result)
+	})
+
+	for _, mode := range []config.ReleaseNotesMode{
+		config.ReleaseNotesModeAppendDedupe,
+		config.ReleaseNotesModePrependDedupe,
+	} {
+		t.Run(string(mode)+" idempotent", func(t *testing.T) {
+			first := getReleaseNotes(existing, current, mode)
+			second := getReleaseNotes(first, current, mode)
+			require.Equal(t, first, second)
+			third := getReleaseNotes(second, current, mode)
+			require.Equal(t, first, third)
+		})
+
+		t.Run(string(mode)+" nothing new", func(t *testing.T) {
+			require.Equal(t, existing, getReleaseNotes(existing, existing, mode))
+		})
+	}
+}
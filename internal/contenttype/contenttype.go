@@ -0,0 +1,79 @@
+// Package contenttype provides content type detection for uploaded files.
+package contenttype
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// knownExts maps the extensions of common release files to their content
+// types.
+// Longer extensions must come first, so `.tar.gz` wins over `.gz`.
+var knownExts = []struct {
+	ext, typ string
+}{
+	{".tar.gz", "application/gzip"},
+	{".tar.xz", "application/x-xz"},
+	{".tar.zst", "application/zstd"},
+	{".tgz", "application/gzip"},
+	{".txz", "application/x-xz"},
+	{".gz", "application/gzip"},
+	{".xz", "application/x-xz"},
+	{".zst", "application/zstd"},
+	{".tar", "application/x-tar"},
+	{".zip", "application/zip"},
+	{".json", "application/json"},
+	{".yaml", "application/yaml"},
+	{".yml", "application/yaml"},
+	{".txt", "text/plain; charset=utf-8"},
+	{".sig", "application/pgp-signature"},
+	{".asc", "application/pgp-signature"},
+	{".pem", "application/x-pem-file"},
+	{".deb", "application/vnd.debian.binary-package"},
+	{".rpm", "application/x-rpm"},
+	{".dmg", "application/x-apple-diskimage"},
+	{".msi", "application/x-msi"},
+	{".exe", "application/vnd.microsoft.portable-executable"},
+}
+
+// Detect returns the content type of the file with the given name.
+//
+// The content type is, in order:
+//   - the value of the first glob in overrides (sorted alphabetically) that
+//     matches the file name;
+//   - inferred from the file extension;
+//   - sniffed from head, which should be the first 512 bytes of the file.
+//
+// If nothing matches, application/octet-stream is returned.
+func Detect(name string, head []byte, overrides map[string]string) (string, error) {
+	name = filepath.Base(name)
+	globs := make([]string, 0, len(overrides))
+	for glob := range overrides {
+		globs = append(globs, glob)
+	}
+	slices.Sort(globs)
+	for _, glob := range globs {
+		ok, err := filepath.Match(glob, name)
+		if err != nil {
+			return "", fmt.Errorf("invalid content type glob %q: %w", glob, err)
+		}
+		if ok {
+			return overrides[glob], nil
+		}
+	}
+
+	lower := strings.ToLower(name)
+	for _, known := range knownExts {
+		if strings.HasSuffix(lower, known.ext) {
+			return known.typ, nil
+		}
+	}
+	if typ := mime.TypeByExtension(filepath.Ext(lower)); typ != "" {
+		return typ, nil
+	}
+	return http.DetectContentType(head), nil
+}
@@ -0,0 +1,70 @@
+package contenttype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	elf := []byte("\x7fELF\x02\x01\x01")
+	for name, expected := range map[string]string{
+		"foo_1.0.0_linux_amd64.tar.gz":       "application/gzip",
+		"foo_1.0.0_linux_amd64.TGZ":          "application/gzip",
+		"foo_1.0.0_linux_amd64.tar.xz":       "application/x-xz",
+		"foo_1.0.0_windows_amd64.zip":        "application/zip",
+		"foo_1.0.0_checksums.txt":            "text/plain; charset=utf-8",
+		"foo_1.0.0_checksums.txt.sig":        "application/pgp-signature",
+		"metadata.json":                      "application/json",
+		"foo_1.0.0_linux_amd64.deb":          "application/vnd.debian.binary-package",
+		"dist/foo_1.0.0_linux_amd64.rpm":     "application/x-rpm",
+		"foo_1.0.0_linux_amd64.sbom.json":    "application/json",
+		"foo_windows_amd64.exe":              "application/vnd.microsoft.portable-executable",
+		"foo_linux_amd64":                    "application/octet-stream",
+		"foo.html":                           "text/html; charset=utf-8",
+		"some/path/foo_windows_arm64.tar.gz": "application/gzip",
+	} {
+		t.Run(name, func(t *testing.T) {
+			typ, err := Detect(name, elf, nil)
+			require.NoError(t, err)
+			require.Equal(t, expected, typ)
+		})
+	}
+}
+
+func TestDetectSniff(t *testing.T) {
+	typ, err := Detect("README", []byte("hello world"), nil)
+	require.NoError(t, err)
+	require.Equal(t, "text/plain; charset=utf-8", typ)
+
+	typ, err = Detect("foo", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "text/plain; charset=utf-8", typ)
+}
+
+func TestDetectOverrides(t *testing.T) {
+	overrides := map[string]string{
+		"*.tar.gz":  "application/x-gtar",
+		"*_linux_*": "application/x-executable",
+		"*.json":    "application/vnd.foo+json",
+	}
+
+	for name, expected := range map[string]string{
+		"foo_1.0.0_darwin_arm64.tar.gz": "application/x-gtar",
+		"foo_1.0.0_linux_amd64.tar.gz":  "application/x-gtar",
+		"foo_linux_amd64":               "application/x-executable",
+		"dist/metadata.json":            "application/vnd.foo+json",
+		"foo_1.0.0_windows_amd64.zip":   "application/zip",
+	} {
+		t.Run(name, func(t *testing.T) {
+			typ, err := Detect(name, nil, overrides)
+			require.NoError(t, err)
+			require.Equal(t, expected, typ)
+		})
+	}
+
+	t.Run("invalid glob", func(t *testing.T) {
+		_, err := Detect("foo.tar.gz", nil, map[string]string{"[": "foo"})
+		require.ErrorContains(t, err, `invalid content type glob "["`)
+	})
+}
@@ -14,9 +14,24 @@ import (
 
 const baseURL = "https://goreleaser.com/deprecations#"
 
+// removalVersion is the version in which currently deprecated properties will
+// be removed, as they are only removed on major versions.
+const removalVersion = "v3"
+
 // Notice warns the user about the deprecation of the given property.
 func Notice(ctx *context.Context, property string) {
-	NoticeCustom(ctx, property, "{{ .Property }} should not be used anymore, check {{ .URL }} for more info")
+	NoticeCustom(ctx, property, "{{ .Property }} should not be used anymore, it will be removed in {{ .Removal }}, check {{ .URL }} for more info")
+}
+
+// NoticeReplaced warns the user about the deprecation of the given property,
+// pointing to its replacement.
+func NoticeReplaced(ctx *context.Context, property, replacement string) {
+	notice(
+		ctx,
+		property,
+		replacement,
+		"{{ .Property }} should not be used anymore, use {{ .Replacement }} instead, it will be removed in {{ .Removal }}, check {{ .URL }} for more info",
+	)
 }
 
 var urlPropertyReplacer = strings.NewReplacer(
@@ -28,14 +43,20 @@ var urlPropertyReplacer = strings.NewReplacer(
 
 // NoticeCustom warns the user about the deprecation of the given property.
 func NoticeCustom(ctx *context.Context, property, tmpl string) {
+	notice(ctx, property, "", tmpl)
+}
+
+func notice(ctx *context.Context, property, replacement, tmpl string) {
 	// replaces . and _ with -
 	url := baseURL + urlPropertyReplacer.Replace(property)
 	var out bytes.Buffer
 	if err := template.
 		Must(template.New("deprecation").Parse("DEPRECATED: "+tmpl)).
 		Execute(&out, templateData{
-			URL:      logext.URL(url),
-			Property: logext.Keyword(property),
+			URL:         logext.URL(url),
+			Property:    logext.Keyword(property),
+			Replacement: logext.Keyword(replacement),
+			Removal:     removalVersion,
 		}); err != nil {
 		panic(err) // this should never happen
 	}
@@ -45,6 +66,8 @@ func NoticeCustom(ctx *context.Context, property, tmpl string) {
 }
 
 type templateData struct {
-	URL      string
-	Property string
+	URL         string
+	Property    string
+	Replacement string
+	Removal     string
 }
@@ -41,3 +41,18 @@ func TestNoticeCustom(t *testing.T) {
 
 	golden.RequireEqualTxt(t, w.Bytes())
 }
+
+func TestNoticeReplaced(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.Ascii)
+
+	var w bytes.Buffer
+	log.Log = log.New(&w)
+
+	log.Info("first")
+	ctx := testctx.New()
+	NoticeReplaced(ctx, "foo.old_name", "foo.new_name")
+	log.Info("last")
+	require.True(t, ctx.Deprecated)
+
+	golden.RequireEqualTxt(t, w.Bytes())
+}
@@ -27,9 +27,10 @@ var passthroughEnvVars = []string{"HOME", "USER", "USERPROFILE", "TMPDIR", "TMP"
 // Execute the given publisher
 func Execute(ctx *context.Context, publishers []config.Publisher) error {
 	skips := pipe.SkipMemento{}
+	conts := pipe.ContinueMemento{}
 	for _, p := range publishers {
 		log.WithField("name", p.Name).Debug("executing custom publisher")
-		err := executePublisher(ctx, p)
+		err := conts.Remember(p.ContinueOnError, executePublisher(ctx, p))
 		if err != nil && pipe.IsSkip(err) {
 			skips.Remember(err)
 			continue
@@ -38,6 +39,9 @@ func Execute(ctx *context.Context, publishers []config.Publisher) error {
 			return err
 		}
 	}
+	if err := conts.Evaluate(); err != nil {
+		return err
+	}
 	return skips.Evaluate()
 }
 
@@ -68,7 +72,7 @@ func executePublisher(ctx *context.Context, publisher config.Publisher) error {
 
 	log.Debugf("will execute custom publisher with %d artifacts", len(artifacts))
 
-	g := semerrgroup.New(ctx.Parallelism)
+	g := semerrgroup.NewShared(ctx)
 	for _, artifact := range artifacts {
 		g.Go(func() error {
 			c, err := resolveCommand(ctx, publisher, artifact)
@@ -104,8 +108,8 @@ func executeCommand(c *command, artifact *artifact.Artifact) error {
 
 	var b bytes.Buffer
 	w := gio.Safe(&b)
-	cmd.Stderr = io.MultiWriter(logext.NewWriter(), w)
-	cmd.Stdout = io.MultiWriter(logext.NewWriter(), w)
+	cmd.Stderr = io.MultiWriter(logext.NewWriter(logext.WithMaxLineLength(logext.DefaultMaxLineLength)), w)
+	cmd.Stdout = io.MultiWriter(logext.NewWriter(logext.WithMaxLineLength(logext.DefaultMaxLineLength)), w)
 
 	log := log.WithField("cmd", c.Args[0]).
 		WithField("artifact", artifact.Name)
@@ -414,6 +414,43 @@ func TestExecute(t *testing.T) {
 			fmt.Errorf(`publishing: %s failed: exit status 1: test error`, MockCmd),
 			nil,
 		},
+		{
+			"continue on error",
+			[]config.Publisher{
+				{
+					Name:            "test",
+					IDs:             []string{"debpkg"},
+					Cmd:             MockCmd + " {{.ArtifactName}}",
+					ContinueOnError: true,
+					Env: []string{
+						MarshalMockEnv(&MockData{
+							AnyOf: []MockCall{
+								{
+									ExpectedArgs: []string{"a.deb"},
+									ExpectedEnv:  osEnv(),
+									Stderr:       "test error",
+									ExitCode:     1,
+								},
+							},
+						}),
+					},
+				},
+				{
+					Name: "test",
+					IDs:  []string{"debpkg"},
+					Cmd:  MockCmd,
+					Env: []string{
+						MarshalMockEnv(&MockData{
+							AnyOf: []MockCall{
+								{ExpectedEnv: osEnv(), ExitCode: 0},
+							},
+						}),
+					},
+				},
+			},
+			nil,
+			&pipe.ErrContinueOnError{},
+		},
 	}
 
 	for i, tc := range testCases {
@@ -2,18 +2,22 @@
 package http
 
 import (
+	"bufio"
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"io"
 	h "net/http"
 	"os"
-	"runtime"
 	"strings"
 
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/contenttype"
 	"github.com/goreleaser/goreleaser/v2/internal/extrafiles"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/ifempty"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe"
 	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
@@ -98,6 +102,10 @@ func CheckConfig(ctx *context.Context, upload *config.Upload, kind string) error
 		return misconfigured(kind, upload, "mode must be 'binary' or 'archive'")
 	}
 
+	if err := ifempty.Validate(upload.IfEmpty); err != nil {
+		return misconfigured(kind, upload, err.Error())
+	}
+
 	username := getUsername(ctx, upload, kind)
 	password := getPassword(ctx, upload, kind)
 	passwordEnv := fmt.Sprintf("%s_%s_SECRET", strings.ToUpper(kind), strings.ToUpper(upload.Name))
@@ -156,54 +164,91 @@ type ResponseChecker func(*h.Response) error
 
 // Upload does the actual uploading work.
 func Upload(ctx *context.Context, uploads []config.Upload, kind string, check ResponseChecker) error {
+	return UploadWithChecksums(ctx, uploads, kind, check, nil)
+}
+
+// UploadWithChecksums is like Upload, but the checksum header reuses the given
+// sha256 checksums, keyed by artifact path, instead of reading the files again.
+func UploadWithChecksums(ctx *context.Context, uploads []config.Upload, kind string, check ResponseChecker, sums map[string]string) error {
+	conts := pipe.ContinueMemento{}
+	skips := pipe.SkipMemento{}
 	// Handle every configured upload
 	for _, upload := range uploads {
-		filters := []artifact.Filter{}
-		if upload.Checksum {
-			filters = append(filters, artifact.ByType(artifact.Checksum))
-		}
-		if upload.Meta {
-			filters = append(filters, artifact.ByType(artifact.Metadata))
-		}
-		if upload.Signature {
-			filters = append(filters, artifact.ByType(artifact.Signature), artifact.ByType(artifact.Certificate))
-		}
-		// We support two different modes
-		//	- "archive": Upload all artifacts
-		//	- "binary": Upload only the raw binaries
-		switch v := strings.ToLower(upload.Mode); v {
-		case ModeArchive:
-			filters = append(filters,
-				artifact.ByType(artifact.UploadableArchive),
-				artifact.ByType(artifact.UploadableSourceArchive),
-				artifact.ByType(artifact.LinuxPackage),
-			)
-		case ModeBinary:
-			filters = append(filters, artifact.ByType(artifact.UploadableBinary))
-		default:
-			return fmt.Errorf("%s: %s: mode \"%s\" not supported", upload.Name, kind, v)
-		}
-
-		filter := artifact.Or(filters...)
-		if len(upload.IDs) > 0 {
-			filter = artifact.And(filter, artifact.ByIDs(upload.IDs...))
+		artifacts, err := Artifacts(ctx, &upload, kind)
+		if err != nil {
+			return err
 		}
-		if len(upload.Exts) > 0 {
-			filter = artifact.And(filter, artifact.ByExt(upload.Exts...))
+		if len(artifacts) == 0 {
+			if err := ifempty.Handle(upload.IfEmpty, kind, upload.Name); err != nil {
+				if !pipe.IsSkip(err) {
+					return err
+				}
+				skips.Remember(err)
+			}
+			continue
 		}
-		if err := uploadWithFilter(ctx, &upload, filter, kind, check); err != nil {
+		if err := conts.Remember(
+			upload.ContinueOnError,
+			uploadArtifacts(ctx, &upload, artifacts, kind, check, sums),
+		); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	if err := conts.Evaluate(); err != nil {
+		return err
+	}
+	return skips.Evaluate()
 }
 
-func uploadWithFilter(ctx *context.Context, upload *config.Upload, filter artifact.Filter, kind string, check ResponseChecker) error {
+// Artifacts returns the artifacts the given upload configuration would upload,
+// including its extra files.
+func Artifacts(ctx *context.Context, upload *config.Upload, kind string) ([]*artifact.Artifact, error) {
+	filters := []artifact.Filter{}
+	if upload.Checksum {
+		filters = append(filters, artifact.ByType(artifact.Checksum))
+	}
+	if upload.Meta {
+		filters = append(filters, artifact.ByType(artifact.Metadata))
+	}
+	if upload.Signature {
+		filters = append(filters, artifact.ByType(artifact.Signature), artifact.ByType(artifact.Certificate))
+	}
+	// We support two different modes
+	//	- "archive": Upload all artifacts
+	//	- "binary": Upload only the raw binaries
+	switch v := strings.ToLower(upload.Mode); v {
+	case ModeArchive:
+		filters = append(filters,
+			artifact.ByType(artifact.UploadableArchive),
+			artifact.ByType(artifact.UploadableSourceArchive),
+			artifact.ByType(artifact.LinuxPackage),
+		)
+	case ModeBinary:
+		filters = append(filters, artifact.ByType(artifact.UploadableBinary))
+	default:
+		return nil, fmt.Errorf("%s: %s: mode \"%s\" not supported", upload.Name, kind, v)
+	}
+
+	filter := artifact.Or(filters...)
+	if len(upload.IDs) > 0 {
+		filter = artifact.And(filter, artifact.ByIDs(upload.IDs...))
+	}
+	if len(upload.Exts) > 0 {
+		filter = artifact.And(filter, artifact.ByExt(upload.Exts...))
+	}
+	if upload.Filter != "" {
+		expr, err := artifact.ByExpression(upload.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", upload.Name, kind, err)
+		}
+		filter = artifact.And(filter, expr)
+	}
+
 	var artifacts []*artifact.Artifact
 	extraFiles, err := extrafiles.Find(ctx, upload.ExtraFiles)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for name, path := range extraFiles {
@@ -217,22 +262,43 @@ func uploadWithFilter(ctx *context.Context, upload *config.Upload, filter artifa
 	if !upload.ExtraFilesOnly {
 		artifacts = append(artifacts, ctx.Artifacts.Filter(filter).List()...)
 	}
+	return artifacts, nil
+}
 
-	if len(artifacts) == 0 {
-		log.Info("no artifacts found")
-	}
+func uploadArtifacts(ctx *context.Context, upload *config.Upload, artifacts []*artifact.Artifact, kind string, check ResponseChecker, sums map[string]string) error {
 	log.Debugf("will upload %d artifacts", len(artifacts))
-	g := semerrgroup.New(ctx.Parallelism)
+	g := semerrgroup.NewShared(ctx)
 	for _, artifact := range artifacts {
 		g.Go(func() error {
-			return uploadAsset(ctx, upload, artifact, kind, check)
+			return uploadAsset(ctx, upload, artifact, kind, check, sums)
 		})
 	}
 	return g.Wait()
 }
 
+// Send sends the given body to the target, using the same credentials and
+// certificates as the given upload configuration.
+func Send(ctx *context.Context, upload *config.Upload, kind, method, target string, headers map[string]string, body []byte, check ResponseChecker) error {
+	a := &asset{
+		ReadCloser: io.NopCloser(bytes.NewReader(body)),
+		Size:       int64(len(body)),
+	}
+	req, err := newUploadRequest(ctx, method, target, getUsername(ctx, upload, kind), getPassword(ctx, upload, kind), headers, a)
+	if err != nil {
+		return err
+	}
+	res, err := executeHTTPRequest(ctx, upload, req, check)
+	if err != nil {
+		return err
+	}
+	if err := res.Body.Close(); err != nil {
+		log.WithError(err).Warn("failed to close response body")
+	}
+	return nil
+}
+
 // uploadAsset uploads file to target and logs all actions.
-func uploadAsset(ctx *context.Context, upload *config.Upload, artifact *artifact.Artifact, kind string, check ResponseChecker) error {
+func uploadAsset(ctx *context.Context, upload *config.Upload, artifact *artifact.Artifact, kind string, check ResponseChecker, sums map[string]string) error {
 	// username and secret are optional since the server may not support/need
 	// basic authentication always
 	username := getUsername(ctx, upload, kind)
@@ -270,12 +336,30 @@ func uploadAsset(ctx *context.Context, upload *config.Upload, artifact *artifact
 		headers[name] = resolvedValue
 	}
 	if upload.ChecksumHeader != "" {
-		sum, err := artifact.Checksum("sha256")
-		if err != nil {
-			return err
+		sum, ok := sums[artifact.Path]
+		if !ok {
+			sum, err = artifact.Checksum("sha256")
+			if err != nil {
+				return err
+			}
 		}
 		headers[upload.ChecksumHeader] = sum
 	}
+	if !hasHeader(headers, "Content-Type") {
+		// peek the first bytes of the asset, so the content type can be
+		// sniffed if needed.
+		r := bufio.NewReader(asset.ReadCloser)
+		head, err := r.Peek(512)
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("%s: %s: failed to read asset: %w", upload.Name, kind, err)
+		}
+		typ, err := contenttype.Detect(artifact.Name, head, upload.ContentTypes)
+		if err != nil {
+			return fmt.Errorf("%s: %s: %w", upload.Name, kind, err)
+		}
+		headers["Content-Type"] = typ
+		asset.ReadCloser = readCloser{r, asset.ReadCloser}
+	}
 
 	res, err := uploadAssetToServer(ctx, upload, targetURL, username, secret, headers, asset, check)
 	if err != nil {
@@ -292,6 +376,20 @@ func uploadAsset(ctx *context.Context, upload *config.Upload, artifact *artifact
 	return nil
 }
 
+func hasHeader(headers map[string]string, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
 // uploadAssetToServer uploads the asset file to target.
 func uploadAssetToServer(ctx *context.Context, upload *config.Upload, target, username, secret string, headers map[string]string, a *asset, check ResponseChecker) (*h.Response, error) {
 	req, err := newUploadRequest(ctx, upload.Method, target, username, secret, headers, a)
@@ -314,6 +412,14 @@ func newUploadRequest(ctx *context.Context, method, target, username, secret str
 		req.SetBasicAuth(username, secret)
 	}
 
+	if !hasHeader(headers, "User-Agent") {
+		ua, err := httpclient.UserAgent(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", ua)
+	}
+
 	for k, v := range headers {
 		req.Header.Add(k, v)
 	}
@@ -321,40 +427,27 @@ func newUploadRequest(ctx *context.Context, method, target, username, secret str
 	return req, err
 }
 
-func getHTTPClient(upload *config.Upload) (*h.Client, error) {
-	if upload.TrustedCerts == "" && upload.ClientX509Cert == "" && upload.ClientX509Key == "" {
-		return h.DefaultClient, nil
-	}
-	transport := &h.Transport{
-		Proxy:           h.ProxyFromEnvironment,
-		TLSClientConfig: &tls.Config{},
-	}
-	if upload.TrustedCerts != "" {
-		pool, err := x509.SystemCertPool()
-		if err != nil {
-			if runtime.GOOS == "windows" {
-				// on windows ignore errors until golang issues #16736 & #18609 get fixed
-				pool = x509.NewCertPool()
-			} else {
-				return nil, err
-			}
-		}
-		pool.AppendCertsFromPEM([]byte(upload.TrustedCerts)) // already validated certs checked by CheckConfig
-		transport.TLSClientConfig.RootCAs = pool
-	}
-	if upload.ClientX509Cert != "" && upload.ClientX509Key != "" {
-		cert, err := tls.LoadX509KeyPair(upload.ClientX509Cert, upload.ClientX509Key)
-		if err != nil {
-			return nil, err
-		}
-		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+func getHTTPClient(ctx *context.Context, upload *config.Upload) (*h.Client, error) {
+	transport, err := httpclient.Transport(ctx, httpclient.Options{
+		TLS: httpclient.TLSOptions{
+			TrustedCerts: upload.TrustedCerts,
+			ClientCert:   upload.ClientX509Cert,
+			ClientKey:    upload.ClientX509Key,
+		},
+		Proxy: httpclient.ProxyOptions{
+			URL:     upload.Proxy,
+			NoProxy: upload.NoProxy,
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
 	return &h.Client{Transport: transport}, nil
 }
 
 // executeHTTPRequest processes the http call with respect of context ctx.
 func executeHTTPRequest(ctx *context.Context, upload *config.Upload, req *h.Request, check ResponseChecker) (*h.Response, error) {
-	client, err := getHTTPClient(upload)
+	client, err := getHTTPClient(ctx, upload)
 	if err != nil {
 		return nil, err
 	}
@@ -7,16 +7,20 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	h "net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/stretchr/testify/require"
@@ -105,6 +109,7 @@ func TestCheckConfig(t *testing.T) {
 		{"mode missing", args{ctx, &config.Upload{Name: "a", Target: "http://blabla", Username: "pepe"}, "test"}, true},
 		{"mode invalid", args{ctx, &config.Upload{Name: "a", Target: "http://blabla", Username: "pepe", Mode: "blabla"}, "test"}, true},
 		{"cert invalid", args{ctx, &config.Upload{Name: "a", Target: "http://blabla", Username: "pepe", Mode: ModeBinary, TrustedCerts: "bad cert!"}, "test"}, true},
+		{"if_empty invalid", args{ctx, &config.Upload{Name: "a", Target: "http://blabla", Username: "pepe", Mode: ModeBinary, IfEmpty: "nope"}, "test"}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -558,6 +563,101 @@ func TestUpload(t *testing.T) {
 			},
 			checks(check{"/blah/2.1.0/a.ubi", "u2", "x", content, map[string]string{"x-project-name": "blah"}}),
 		},
+		{
+			"filter", true, true, false, false,
+			func(s *httptest.Server) (*context.Context, config.Upload) {
+				return ctx, config.Upload{
+					Mode:         ModeArchive,
+					Name:         "a",
+					Target:       s.URL + "/{{.ProjectName}}/{{.Version}}/",
+					Username:     "u3",
+					TrustedCerts: cert(s),
+					Filter:       `name =~ "\\.tar" && type != "Source"`,
+				}
+			},
+			checks(
+				check{"/blah/2.1.0/a.tar", "u3", "x", content, map[string]string{}},
+			),
+		},
+		{
+			"invalid-filter", true, true, true, true,
+			func(s *httptest.Server) (*context.Context, config.Upload) {
+				return ctx, config.Upload{
+					Mode:         ModeArchive,
+					Name:         "a",
+					Target:       s.URL + "/{{.ProjectName}}/{{.Version}}/",
+					Username:     "u3",
+					TrustedCerts: cert(s),
+					Filter:       `name =~`,
+				}
+			},
+			checks(),
+		},
+		{
+			"content-type", true, true, false, false,
+			func(s *httptest.Server) (*context.Context, config.Upload) {
+				return ctx, config.Upload{
+					Mode:         ModeArchive,
+					Name:         "a",
+					Target:       s.URL + "/{{.ProjectName}}/{{.Version}}/",
+					Username:     "u2",
+					TrustedCerts: cert(s),
+					ContentTypes: map[string]string{
+						"*.deb": "application/x-deb",
+					},
+				}
+			},
+			checks(
+				check{"/blah/2.1.0/a.deb", "u2", "x", content, map[string]string{"Content-Type": "application/x-deb"}},
+				check{"/blah/2.1.0/a.tar", "u2", "x", content, map[string]string{"Content-Type": "application/x-tar"}},
+				check{"/blah/2.1.0/a.tar.gz", "u2", "x", content, map[string]string{"Content-Type": "application/gzip"}},
+			),
+		},
+		{
+			"content-type-custom-header", true, true, false, false,
+			func(s *httptest.Server) (*context.Context, config.Upload) {
+				return ctx, config.Upload{
+					Mode:     ModeBinary,
+					Name:     "a",
+					Target:   s.URL + "/{{.ProjectName}}/{{.Version}}/",
+					Username: "u2",
+					CustomHeaders: map[string]string{
+						"content-type": "application/x-custom",
+					},
+					TrustedCerts: cert(s),
+				}
+			},
+			checks(check{"/blah/2.1.0/a.ubi", "u2", "x", content, map[string]string{"Content-Type": "application/x-custom"}}),
+		},
+		{
+			"content-type-sniffed", true, true, false, false,
+			func(s *httptest.Server) (*context.Context, config.Upload) {
+				return ctx, config.Upload{
+					Mode:         ModeBinary,
+					Name:         "a",
+					Target:       s.URL + "/{{.ProjectName}}/{{.Version}}/",
+					Username:     "u2",
+					TrustedCerts: cert(s),
+				}
+			},
+			checks(check{"/blah/2.1.0/a.ubi", "u2", "x", content, map[string]string{"Content-Type": "text/plain; charset=utf-8"}}),
+		},
+		{
+			"content-type-invalid-glob", true, true, true, true,
+			func(s *httptest.Server) (*context.Context, config.Upload) {
+				return ctx, config.Upload{
+					Mode:         ModeBinary,
+					Name:         "a",
+					Target:       s.URL + "/{{.ProjectName}}/{{.Version}}/",
+					Username:     "u2",
+					TrustedCerts: cert(s),
+					ContentTypes: map[string]string{
+						"[": "application/x-custom",
+					},
+				}
+			},
+			checks(),
+		},
 		{
 			"invalid-template-in-custom-headers", true, true, true, true,
 			func(s *httptest.Server) (*context.Context, config.Upload) {
@@ -701,3 +801,294 @@ func cert(srv *httptest.Server) string {
 	}
 	return string(pem.EncodeToMemory(block))
 }
+
+func TestNewUploadRequestUserAgent(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{ProjectName: "blah"})
+	newAsset := func() *asset {
+		return &asset{ReadCloser: io.NopCloser(strings.NewReader("")), Size: 0}
+	}
+
+	req, err := newUploadRequest(ctx, h.MethodPut, "https://example.com", "", "", nil, newAsset())
+	require.NoError(t, err)
+	require.Equal(t, "goreleaser/dev (+blah)", req.Header.Get("User-Agent"))
+
+	req, err = newUploadRequest(ctx, h.MethodPut, "https://example.com", "", "", map[string]string{
+		"user-agent": "custom",
+	}, newAsset())
+	require.NoError(t, err)
+	require.Equal(t, []string{"custom"}, req.Header.Values("User-Agent"))
+}
+
+func TestGetHTTPClientCABundle(t *testing.T) {
+	cert, _ := testlib.GenerateCert(t)
+
+	t.Run("no bundle", func(t *testing.T) {
+		client, err := getHTTPClient(testctx.New(), &config.Upload{})
+		require.NoError(t, err)
+		require.Nil(t, client.Transport.(*h.Transport).TLSClientConfig.RootCAs)
+	})
+
+	t.Run("bundle", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			HTTP: config.HTTP{CABundle: cert},
+		})
+		client, err := getHTTPClient(ctx, &config.Upload{})
+		require.NoError(t, err)
+		require.NotNil(t, client.Transport.(*h.Transport).TLSClientConfig.RootCAs)
+	})
+
+	t.Run("bundle and trusted certs", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			HTTP: config.HTTP{CABundle: cert},
+		})
+		bts, err := os.ReadFile(cert)
+		require.NoError(t, err)
+		client, err := getHTTPClient(ctx, &config.Upload{TrustedCerts: string(bts)})
+		require.NoError(t, err)
+		require.NotNil(t, client.Transport.(*h.Transport).TLSClientConfig.RootCAs)
+	})
+
+	t.Run("invalid bundle", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			HTTP: config.HTTP{CABundle: "nope.pem"},
+		})
+		_, err := getHTTPClient(ctx, &config.Upload{})
+		require.ErrorContains(t, err, "ca_bundle")
+	})
+}
+
+func TestGetHTTPClientProxy(t *testing.T) {
+	ctx := testctx.New()
+	client, err := getHTTPClient(ctx, &config.Upload{
+		Proxy:   "http://proxy.example.com:3128",
+		NoProxy: "internal.example.com",
+	})
+	require.NoError(t, err)
+	proxy := client.Transport.(*h.Transport).Proxy
+
+	req, err := h.NewRequest(h.MethodPut, "https://example.com/foo", nil)
+	require.NoError(t, err)
+	u, err := proxy(req)
+	require.NoError(t, err)
+	require.Equal(t, "http://proxy.example.com:3128", u.String())
+
+	req, err = h.NewRequest(h.MethodPut, "https://internal.example.com/foo", nil)
+	require.NoError(t, err)
+	u, err = proxy(req)
+	require.NoError(t, err)
+	require.Nil(t, u)
+
+	_, err = getHTTPClient(ctx, &config.Upload{Proxy: "{{ .Nope }}"})
+	require.Error(t, err)
+}
+
+func TestGetHTTPClientSharesTransport(t *testing.T) {
+	ctx := testctx.New()
+	upload := &config.Upload{Name: "a"}
+	client1, err := getHTTPClient(ctx, upload)
+	require.NoError(t, err)
+	client2, err := getHTTPClient(ctx, upload)
+	require.NoError(t, err)
+	require.Same(t, client1.Transport, client2.Transport)
+
+	client3, err := getHTTPClient(ctx, &config.Upload{Name: "b", Proxy: "http://proxy.example.com"})
+	require.NoError(t, err)
+	require.NotSame(t, client1.Transport, client3.Transport)
+}
+
+func TestUploadIfEmpty(t *testing.T) {
+	var requests atomic.Int64
+	srv := httptest.NewServer(h.HandlerFunc(func(w h.ResponseWriter, _ *h.Request) {
+		requests.Add(1)
+		w.WriteHeader(h.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := testctx.NewWithCfg(config.Project{ProjectName: "blah"})
+	folder := t.TempDir()
+	path := filepath.Join(folder, "a.tar.gz")
+	require.NoError(t, os.WriteFile(path, []byte("a"), 0o644))
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "a.tar.gz",
+		Path: path,
+		Type: artifact.UploadableArchive,
+		Extra: map[string]any{
+			artifact.ExtraID: "foo",
+		},
+	})
+	upload := func(name, ids, ifEmpty string) config.Upload {
+		return config.Upload{
+			Name:    name,
+			Mode:    ModeArchive,
+			Target:  srv.URL + "/{{ .ArtifactName }}",
+			IDs:     []string{ids},
+			IfEmpty: ifEmpty,
+		}
+	}
+	ok := func(*h.Response) error { return nil }
+
+	t.Run("warn", func(t *testing.T) {
+		requests.Store(0)
+		require.NoError(t, Upload(ctx, []config.Upload{
+			upload("empty", "nope", ""),
+			upload("full", "foo", ""),
+		}, "test", ok))
+		require.Equal(t, int64(1), requests.Load())
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		requests.Store(0)
+		err := Upload(ctx, []config.Upload{
+			upload("empty", "nope", "skip"),
+			upload("full", "foo", ""),
+		}, "test", ok)
+		require.True(t, pipe.IsSkip(err))
+		require.EqualError(t, err, "test empty: no artifacts matched the given filters")
+		require.Equal(t, int64(1), requests.Load())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		requests.Store(0)
+		err := Upload(ctx, []config.Upload{
+			upload("empty", "nope", "error"),
+			upload("full", "foo", ""),
+		}, "test", ok)
+		require.False(t, pipe.IsSkip(err))
+		require.EqualError(t, err, "test empty: no artifacts matched the given filters")
+		require.Equal(t, int64(0), requests.Load())
+	})
+}
+
+func TestUploadWithChecksums(t *testing.T) {
+	var sums []string
+	var lock sync.Mutex
+	srv := httptest.NewServer(h.HandlerFunc(func(w h.ResponseWriter, r *h.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		sums = append(sums, r.Header.Get("X-Checksum-SHA256"))
+		w.WriteHeader(h.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := testctx.NewWithCfg(config.Project{ProjectName: "blah"})
+	folder := t.TempDir()
+	for _, name := range []string{"a.tar.gz", "b.tar.gz"} {
+		path := filepath.Join(folder, name)
+		require.NoError(t, os.WriteFile(path, []byte("a"), 0o644))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: name,
+			Path: path,
+			Type: artifact.UploadableArchive,
+		})
+	}
+	uploads := []config.Upload{{
+		Name:           "a",
+		Mode:           ModeArchive,
+		Target:         srv.URL + "/{{ .ArtifactName }}",
+		ChecksumHeader: "X-Checksum-SHA256",
+	}}
+	require.NoError(t, UploadWithChecksums(ctx, uploads, "test", func(*h.Response) error { return nil }, map[string]string{
+		filepath.Join(folder, "a.tar.gz"): "precomputed",
+	}))
+	require.ElementsMatch(t, []string{
+		"precomputed",
+		"ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb",
+	}, sums)
+}
+
+func BenchmarkUpload(b *testing.B) {
+	var conns atomic.Int64
+	srv := httptest.NewUnstartedServer(h.HandlerFunc(func(w h.ResponseWriter, r *h.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(h.StatusCreated)
+	}))
+	srv.EnableHTTP2 = true
+	srv.Config.ConnState = func(_ net.Conn, state h.ConnState) {
+		if state == h.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.StartTLS()
+	b.Cleanup(srv.Close)
+
+	ctx := testctx.NewWithCfg(config.Project{ProjectName: "blah"})
+	ctx.Parallelism = 4
+	folder := b.TempDir()
+	content := bytes.Repeat([]byte("a"), 256*1024)
+	for i := range 50 {
+		name := fmt.Sprintf("a%d.tar.gz", i)
+		path := filepath.Join(folder, name)
+		require.NoError(b, os.WriteFile(path, content, 0o644))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: name,
+			Path: path,
+			Type: artifact.UploadableArchive,
+		})
+	}
+	uploads := []config.Upload{{
+		Name:         "bench",
+		Mode:         ModeArchive,
+		Target:       srv.URL + "/{{ .ArtifactName }}",
+		TrustedCerts: cert(srv),
+	}}
+	check := func(r *h.Response) error {
+		if r.StatusCode != h.StatusCreated {
+			return fmt.Errorf("unexpected http status code: %v", r.StatusCode)
+		}
+		return nil
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(50 * len(content)))
+	b.ResetTimer()
+	for range b.N {
+		require.NoError(b, Upload(ctx, uploads, "test", check))
+	}
+	b.ReportMetric(float64(conns.Load())/float64(b.N), "conns/op")
+}
+
+func TestArtifactsIDs(t *testing.T) {
+	ctx := testctx.New()
+	for _, a := range []struct {
+		name, id string
+		typ      artifact.Type
+	}{
+		{"cli.tar.gz", "cli", artifact.UploadableArchive},
+		{"cli.deb", "cli", artifact.LinuxPackage},
+		{"daemon.tar.gz", "daemon", artifact.UploadableArchive},
+		{"daemon.deb", "daemon", artifact.LinuxPackage},
+		{"source.tar.gz", "", artifact.UploadableSourceArchive},
+		{"checksums.txt", "", artifact.Checksum},
+		{"metadata.json", "", artifact.Metadata},
+	} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: a.typ,
+			Name: a.name,
+			Extra: map[string]any{
+				artifact.ExtraID: a.id,
+			},
+		})
+	}
+
+	artifacts, err := Artifacts(ctx, &config.Upload{
+		Name:       "production",
+		Mode:       ModeArchive,
+		IDs:        []string{"cli"},
+		Checksum:   true,
+		Meta:       true,
+		ExtraFiles: []config.ExtraFile{{Glob: "testdata/*.txt"}},
+	}, "test")
+	require.NoError(t, err)
+	var names []string
+	for _, a := range artifacts {
+		names = append(names, a.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"cli.tar.gz",
+		"cli.deb",
+		"source.tar.gz",
+		"checksums.txt",
+		"metadata.json",
+		"foo.txt",
+	}, names)
+}
@@ -0,0 +1,65 @@
+// Package httpclient contains the setup shared by the HTTP clients GoReleaser
+// uses to talk to external services.
+package httpclient
+
+import (
+	"net/http"
+
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// UserAgentEnv is the environment variable that can be used to set the user
+// agent when it's not set in the configuration.
+const UserAgentEnv = "GORELEASER_USER_AGENT"
+
+// Version is the GoReleaser version used in the default user agent.
+//
+//nolint:gochecknoglobals
+var Version = "dev"
+
+// UserAgent returns the user agent to use in the requests.
+//
+// In order of precedence, it is the given override (e.g. from the target
+// being published to), the http.user_agent configuration, the
+// GORELEASER_USER_AGENT environment variable, and
+// goreleaser/<version> (+<project name>).
+func UserAgent(ctx *context.Context, override string) (string, error) {
+	for _, ua := range []string{
+		override,
+		ctx.Config.HTTP.UserAgent,
+		ctx.Env[UserAgentEnv],
+	} {
+		if ua == "" {
+			continue
+		}
+		return tmpl.New(ctx).Apply(ua)
+	}
+	if ctx.Config.ProjectName == "" {
+		return "goreleaser/" + Version, nil
+	}
+	return "goreleaser/" + Version + " (+" + ctx.Config.ProjectName + ")", nil
+}
+
+// WithUserAgent wraps the given transport so every request sent through it
+// has the given user agent.
+//
+// If base is nil, http.DefaultTransport is used.
+func WithUserAgent(base http.RoundTripper, ua string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &userAgentTransport{base: base, ua: ua}
+}
+
+type userAgentTransport struct {
+	base http.RoundTripper
+	ua   string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrip must not modify the request.
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.ua)
+	return t.base.RoundTrip(req)
+}
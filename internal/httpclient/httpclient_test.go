@@ -0,0 +1,70 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAgent(t *testing.T) {
+	for name, tt := range map[string]struct {
+		config   string
+		env      string
+		override string
+		expected string
+	}{
+		"default":  {expected: "goreleaser/dev (+proj)"},
+		"env":      {env: "env-agent", expected: "env-agent"},
+		"config":   {env: "env-agent", config: "config-agent", expected: "config-agent"},
+		"override": {env: "env-agent", config: "config-agent", override: "override-agent", expected: "override-agent"},
+		"template": {config: "ci/{{ .ProjectName }}", expected: "ci/proj"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.NewWithCfg(config.Project{
+				ProjectName: "proj",
+				HTTP: config.HTTP{
+					UserAgent: tt.config,
+				},
+			}, testctx.WithEnv(map[string]string{UserAgentEnv: tt.env}))
+			ua, err := UserAgent(ctx, tt.override)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, ua)
+		})
+	}
+
+	t.Run("no project name", func(t *testing.T) {
+		ua, err := UserAgent(testctx.New(), "")
+		require.NoError(t, err)
+		require.Equal(t, "goreleaser/dev", ua)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := UserAgent(testctx.New(), "{{ .Nope }}")
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
+func TestWithUserAgent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "my-agent", r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: WithUserAgent(nil, "my-agent")}
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "something-else")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the original request is left untouched
+	require.Equal(t, "something-else", req.Header.Get("User-Agent"))
+}
@@ -0,0 +1,55 @@
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyOptions are the proxy settings of a client.
+//
+// Both fields are templated.
+type ProxyOptions struct {
+	// URL is the proxy used for all requests.
+	URL string
+	// NoProxy is a comma-separated list of hosts that should not be proxied,
+	// in the same format as the NO_PROXY environment variable.
+	NoProxy string
+}
+
+// Proxy returns the proxy function for the given options.
+//
+// If no proxy URL is set, http.ProxyFromEnvironment is returned.
+func Proxy(ctx *context.Context, opts ProxyOptions) (func(*http.Request) (*url.URL, error), error) {
+	if opts.URL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	if err := tmpl.New(ctx).ApplyAll(&opts.URL, &opts.NoProxy); err != nil {
+		return nil, err
+	}
+	return proxyFunc(opts)
+}
+
+// proxyFunc returns the proxy function for the given, already templated,
+// options.
+func proxyFunc(opts ProxyOptions) (func(*http.Request) (*url.URL, error), error) {
+	if opts.URL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	u, err := url.Parse(opts.URL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy url: %q", opts.URL)
+	}
+	proxy := (&httpproxy.Config{
+		HTTPProxy:  opts.URL,
+		HTTPSProxy: opts.URL,
+		NoProxy:    opts.NoProxy,
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}, nil
+}
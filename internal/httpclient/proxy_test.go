@@ -0,0 +1,82 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxy(t *testing.T) {
+	t.Run("from environment", func(t *testing.T) {
+		proxy, err := Proxy(testctx.New(), ProxyOptions{})
+		require.NoError(t, err)
+		require.Equal(t,
+			reflect.ValueOf(http.ProxyFromEnvironment).Pointer(),
+			reflect.ValueOf(proxy).Pointer(),
+		)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		ctx := testctx.New(testctx.WithEnv(map[string]string{
+			"PROXY": "http://proxy.example.com:3128",
+		}))
+		proxy, err := Proxy(ctx, ProxyOptions{
+			URL:     "{{ .Env.PROXY }}",
+			NoProxy: "internal.example.com,.corp.example.com",
+		})
+		require.NoError(t, err)
+
+		for url, expected := range map[string]string{
+			"https://api.github.com/repos":      "http://proxy.example.com:3128",
+			"http://example.com/foo":            "http://proxy.example.com:3128",
+			"https://internal.example.com/foo":  "",
+			"https://gitea.corp.example.com/v1": "",
+		} {
+			t.Run(url, func(t *testing.T) {
+				req, err := http.NewRequest(http.MethodGet, url, nil)
+				require.NoError(t, err)
+				u, err := proxy(req)
+				require.NoError(t, err)
+				if expected == "" {
+					require.Nil(t, u)
+					return
+				}
+				require.Equal(t, expected, u.String())
+			})
+		}
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := Proxy(testctx.New(), ProxyOptions{URL: "::nope"})
+		require.EqualError(t, err, `invalid proxy url: "::nope"`)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := Proxy(testctx.New(), ProxyOptions{URL: "{{ .Nope }}"})
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
+func TestTransportProxy(t *testing.T) {
+	var requested string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	transport, err := Transport(testctx.New(), Options{
+		Proxy: ProxyOptions{URL: srv.URL},
+	})
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Get("http://gitea.example.com/api/v1/version")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, "http://gitea.example.com/api/v1/version", requested)
+}
@@ -0,0 +1,146 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// CABundleEnv is the environment variable that can be used to set the CA
+// bundle when it's not set in the configuration.
+const CABundleEnv = "GORELEASER_CA_BUNDLE"
+
+// CertPool returns the system certificate pool with the CAs of the
+// http.ca_bundle configuration, or of the GORELEASER_CA_BUNDLE environment
+// variable, added to it.
+//
+// It returns a nil pool if no bundle is set, in which case the system pool
+// should be used.
+func CertPool(ctx *context.Context) (*x509.CertPool, error) {
+	bundle, err := caBundle(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return certPool(bundle)
+}
+
+// certPool returns the system certificate pool with the CAs of the given,
+// already templated, bundle added to it, or nil if there's no bundle.
+func certPool(bundle string) (*x509.CertPool, error) {
+	if bundle == "" {
+		return nil, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, fmt.Errorf("could not load system certificates: %w", err)
+	}
+	if err := appendCerts(pool, bundle); err != nil {
+		return nil, fmt.Errorf("ca_bundle: %w", err)
+	}
+	return pool, nil
+}
+
+func caBundle(ctx *context.Context) (string, error) {
+	bundle := ctx.Config.HTTP.CABundle
+	if bundle == "" {
+		bundle = ctx.Env[CABundleEnv]
+	}
+	return tmpl.New(ctx).Apply(bundle)
+}
+
+// TLSOptions are the TLS settings of a client.
+//
+// The paths are templated.
+type TLSOptions struct {
+	// SkipVerify disables the verification of the server certificate.
+	SkipVerify bool
+	// CACert is the path of a PEM file with additional CAs to trust.
+	CACert string
+	// TrustedCerts are PEM encoded additional CAs to trust.
+	TrustedCerts string
+	// ClientCert and ClientKey are the paths of the PEM encoded client
+	// certificate and key, used for mutual TLS.
+	ClientCert string
+	ClientKey  string
+}
+
+// TLSConfig builds the TLS configuration for the given options.
+//
+// The CA bundle, if any, is always trusted.
+func TLSConfig(ctx *context.Context, opts TLSOptions) (*tls.Config, error) {
+	if err := applyTLS(ctx, &opts); err != nil {
+		return nil, err
+	}
+	bundle, err := caBundle(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tlsConfig(opts, bundle)
+}
+
+// applyTLS templates the paths of the given options.
+func applyTLS(ctx *context.Context, opts *TLSOptions) error {
+	return tmpl.New(ctx).ApplyAll(
+		&opts.CACert,
+		&opts.ClientCert,
+		&opts.ClientKey,
+	)
+}
+
+// tlsConfig builds the TLS configuration for the given options and CA
+// bundle, both already templated.
+func tlsConfig(opts TLSOptions, bundle string) (*tls.Config, error) {
+	//nolint:gosec
+	cfg := &tls.Config{
+		InsecureSkipVerify: opts.SkipVerify,
+	}
+
+	pool, err := certPool(bundle)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil && (opts.CACert != "" || opts.TrustedCerts != "") {
+		pool, err = x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("could not load system certificates: %w", err)
+		}
+	}
+	if opts.CACert != "" {
+		if err := appendCerts(pool, opts.CACert); err != nil {
+			return nil, fmt.Errorf("ca_cert: %w", err)
+		}
+	}
+	if opts.TrustedCerts != "" && !pool.AppendCertsFromPEM([]byte(opts.TrustedCerts)) {
+		return nil, errors.New("no certificates found in trusted_certificates")
+	}
+	cfg.RootCAs = pool
+
+	if opts.ClientCert != "" || opts.ClientKey != "" {
+		if opts.ClientCert == "" || opts.ClientKey == "" {
+			return nil, errors.New("client_cert and client_key must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.ClientCert, opts.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+func appendCerts(pool *x509.CertPool, path string) error {
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read certificates: %w", err)
+	}
+	if !pool.AppendCertsFromPEM(bts) {
+		return fmt.Errorf("no certificates found in %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSConfig(t *testing.T) {
+	cert, key := testlib.GenerateCert(t)
+
+	t.Run("empty", func(t *testing.T) {
+		cfg, err := TLSConfig(testctx.New(), TLSOptions{})
+		require.NoError(t, err)
+		require.False(t, cfg.InsecureSkipVerify)
+		require.Nil(t, cfg.RootCAs)
+		require.Empty(t, cfg.Certificates)
+	})
+
+	t.Run("skip verify", func(t *testing.T) {
+		cfg, err := TLSConfig(testctx.New(), TLSOptions{SkipVerify: true})
+		require.NoError(t, err)
+		require.True(t, cfg.InsecureSkipVerify)
+	})
+
+	t.Run("all set", func(t *testing.T) {
+		ctx := testctx.New(testctx.WithEnv(map[string]string{
+			"CERT": cert,
+			"KEY":  key,
+		}))
+		cfg, err := TLSConfig(ctx, TLSOptions{
+			CACert:     cert,
+			ClientCert: "{{ .Env.CERT }}",
+			ClientKey:  "{{ .Env.KEY }}",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cfg.RootCAs)
+		require.Len(t, cfg.Certificates, 1)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := TLSConfig(testctx.New(), TLSOptions{ClientCert: cert})
+		require.EqualError(t, err, "client_cert and client_key must be set together")
+	})
+
+	t.Run("invalid key pair", func(t *testing.T) {
+		_, otherKey := testlib.GenerateCert(t)
+		_, err := TLSConfig(testctx.New(), TLSOptions{ClientCert: cert, ClientKey: otherKey})
+		require.ErrorContains(t, err, "could not load client certificate")
+	})
+
+	t.Run("missing ca", func(t *testing.T) {
+		_, err := TLSConfig(testctx.New(), TLSOptions{CACert: filepath.Join(t.TempDir(), "nope.pem")})
+		require.ErrorContains(t, err, "ca_cert: could not read certificates")
+	})
+
+	t.Run("trusted certs", func(t *testing.T) {
+		bts, err := os.ReadFile(cert)
+		require.NoError(t, err)
+		cfg, err := TLSConfig(testctx.New(), TLSOptions{TrustedCerts: string(bts)})
+		require.NoError(t, err)
+		require.NotNil(t, cfg.RootCAs)
+
+		_, err = TLSConfig(testctx.New(), TLSOptions{TrustedCerts: "not a cert"})
+		require.EqualError(t, err, "no certificates found in trusted_certificates")
+	})
+
+	t.Run("invalid ca", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(path, []byte("not a cert"), 0o644))
+		_, err := TLSConfig(testctx.New(), TLSOptions{CACert: path})
+		require.ErrorContains(t, err, "no certificates found in")
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := TLSConfig(testctx.New(), TLSOptions{CACert: "{{ .Nope }}"})
+		testlib.RequireTemplateError(t, err)
+	})
+
+	t.Run("ca bundle", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			HTTP: config.HTTP{CABundle: cert},
+		})
+		cfg, err := TLSConfig(ctx, TLSOptions{})
+		require.NoError(t, err)
+		require.NotNil(t, cfg.RootCAs)
+	})
+}
+
+func TestCertPool(t *testing.T) {
+	cert, _ := testlib.GenerateCert(t)
+
+	t.Run("not set", func(t *testing.T) {
+		pool, err := CertPool(testctx.New())
+		require.NoError(t, err)
+		require.Nil(t, pool)
+	})
+
+	t.Run("from config", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			HTTP: config.HTTP{CABundle: "{{ .Env.BUNDLE }}"},
+		}, testctx.WithEnv(map[string]string{"BUNDLE": cert}))
+		pool, err := CertPool(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, pool)
+	})
+
+	t.Run("from env", func(t *testing.T) {
+		ctx := testctx.New(testctx.WithEnv(map[string]string{CABundleEnv: cert}))
+		pool, err := CertPool(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, pool)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		ctx := testctx.New(testctx.WithEnv(map[string]string{CABundleEnv: "nope.pem"}))
+		_, err := CertPool(ctx)
+		require.ErrorContains(t, err, "ca_bundle: could not read certificates")
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			HTTP: config.HTTP{CABundle: "{{ .Nope }}"},
+		})
+		_, err := CertPool(ctx)
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
+func TestTransport(t *testing.T) {
+	cert, key := testlib.GenerateCert(t)
+	pair, err := tls.LoadX509KeyPair(cert, key)
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{pair}}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	get := func(tb testing.TB, ctx *context.Context) error {
+		tb.Helper()
+		transport, err := Transport(ctx, Options{})
+		require.NoError(tb, err)
+		resp, err := (&http.Client{Transport: transport}).Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	t.Run("untrusted", func(t *testing.T) {
+		require.ErrorContains(t, get(t, testctx.New()), "certificate")
+	})
+
+	t.Run("trusted through the ca bundle", func(t *testing.T) {
+		ctx := testctx.New(testctx.WithEnv(map[string]string{CABundleEnv: cert}))
+		require.NoError(t, get(t, ctx))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx := testctx.New(testctx.WithEnv(map[string]string{CABundleEnv: "nope.pem"}))
+		_, err := Transport(ctx, Options{})
+		require.Error(t, err)
+	})
+}
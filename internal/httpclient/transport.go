@@ -0,0 +1,116 @@
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// Options are the settings of a transport.
+type Options struct {
+	TLS   TLSOptions
+	Proxy ProxyOptions
+}
+
+// transportKey identifies a transport by its resolved settings.
+type transportKey struct {
+	opts                Options
+	caBundle            string
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+}
+
+//nolint:gochecknoglobals
+var transports = struct {
+	sync.Mutex
+	m map[transportKey]*http.Transport
+}{
+	m: map[transportKey]*http.Transport{},
+}
+
+// Transport returns a transport using the TLS and proxy configuration for
+// the given options.
+//
+// Transports are based on http.DefaultTransport, so they keep connections
+// alive and use HTTP/2 when the server supports it.
+// They are shared by all the clients with the same settings, so connections
+// are reused across them, and the number of idle connections kept per host
+// can be set with http.max_idle_conns_per_host.
+func Transport(ctx *context.Context, opts Options) (*http.Transport, error) {
+	// the options are templated only once, here, as they are also the key
+	// of the shared transports.
+	if err := applyTLS(ctx, &opts.TLS); err != nil {
+		return nil, err
+	}
+	if err := tmpl.New(ctx).ApplyAll(&opts.Proxy.URL, &opts.Proxy.NoProxy); err != nil {
+		return nil, err
+	}
+	bundle, err := caBundle(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key := transportKey{
+		opts:                opts,
+		caBundle:            bundle,
+		maxIdleConnsPerHost: maxIdleConnsPerHost(ctx),
+		idleConnTimeout:     ctx.Config.HTTP.IdleConnTimeout,
+	}
+
+	transports.Lock()
+	defer transports.Unlock()
+	if transport, ok := transports.m[key]; ok {
+		return transport, nil
+	}
+
+	cfg, err := tlsConfig(opts.TLS, bundle)
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := proxyFunc(opts.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg
+	transport.Proxy = proxy
+	transport.ForceAttemptHTTP2 = true
+	transport.MaxIdleConnsPerHost = key.maxIdleConnsPerHost
+	if key.idleConnTimeout > 0 {
+		transport.IdleConnTimeout = key.idleConnTimeout
+	}
+	transports.m[key] = transport
+	return transport, nil
+}
+
+// Client returns an HTTP client using the shared transport with the default
+// options, and sending the User-Agent.
+func Client(ctx *context.Context) (*http.Client, error) {
+	return ClientWith(ctx, Options{}, "")
+}
+
+// ClientWith returns an HTTP client using the shared transport for the given
+// options, and sending the User-Agent, or the given override of it.
+func ClientWith(ctx *context.Context, opts Options, userAgent string) (*http.Client, error) {
+	transport, err := Transport(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	ua, err := UserAgent(ctx, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: WithUserAgent(transport, ua)}, nil
+}
+
+// maxIdleConnsPerHost returns the http.max_idle_conns_per_host
+// configuration, defaulting to the parallelism, so concurrent uploads to the
+// same host can all reuse their connections.
+func maxIdleConnsPerHost(ctx *context.Context) int {
+	if n := ctx.Config.HTTP.MaxIdleConnsPerHost; n > 0 {
+		return n
+	}
+	return max(ctx.Parallelism, http.DefaultMaxIdleConnsPerHost)
+}
@@ -0,0 +1,138 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportTuning(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.New()
+		ctx.Parallelism = 8
+		transport, err := Transport(ctx, Options{})
+		require.NoError(t, err)
+		require.True(t, transport.ForceAttemptHTTP2)
+		require.Equal(t, 8, transport.MaxIdleConnsPerHost)
+		require.Equal(t, http.DefaultTransport.(*http.Transport).IdleConnTimeout, transport.IdleConnTimeout)
+	})
+
+	t.Run("low parallelism", func(t *testing.T) {
+		ctx := testctx.New()
+		ctx.Parallelism = 1
+		transport, err := Transport(ctx, Options{})
+		require.NoError(t, err)
+		require.Equal(t, http.DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			HTTP: config.HTTP{
+				MaxIdleConnsPerHost: 32,
+				IdleConnTimeout:     time.Minute,
+			},
+		})
+		transport, err := Transport(ctx, Options{})
+		require.NoError(t, err)
+		require.Equal(t, 32, transport.MaxIdleConnsPerHost)
+		require.Equal(t, time.Minute, transport.IdleConnTimeout)
+	})
+}
+
+func TestTransportShared(t *testing.T) {
+	ctx := testctx.New(testctx.WithEnv(map[string]string{
+		"PROXY": "http://proxy.example.com",
+	}))
+	opts := Options{
+		TLS:   TLSOptions{SkipVerify: true},
+		Proxy: ProxyOptions{URL: "{{ .Env.PROXY }}"},
+	}
+
+	transport1, err := Transport(ctx, opts)
+	require.NoError(t, err)
+	transport2, err := Transport(ctx, opts)
+	require.NoError(t, err)
+	require.Same(t, transport1, transport2)
+
+	// same settings, once templated
+	transport3, err := Transport(ctx, Options{
+		TLS:   TLSOptions{SkipVerify: true},
+		Proxy: ProxyOptions{URL: "http://proxy.example.com"},
+	})
+	require.NoError(t, err)
+	require.Same(t, transport1, transport3)
+
+	transport4, err := Transport(ctx, Options{})
+	require.NoError(t, err)
+	require.NotSame(t, transport1, transport4)
+
+	client, err := Client(ctx)
+	require.NoError(t, err)
+	require.Same(t, transport4, client.Transport.(*userAgentTransport).base)
+}
+
+func TestTransportTemplatesOnce(t *testing.T) {
+	cert, _ := testlib.GenerateCert(t)
+	bts, err := os.ReadFile(cert)
+	require.NoError(t, err)
+	// templating the path again would fail.
+	path := filepath.Join(t.TempDir(), "{{ .Nope }}.pem")
+	require.NoError(t, os.WriteFile(path, bts, 0o644))
+
+	ctx := testctx.New(testctx.WithEnv(map[string]string{"CERT": path}))
+	transport, err := Transport(ctx, Options{
+		TLS: TLSOptions{CACert: "{{ .Env.CERT }}"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, transport.TLSClientConfig.RootCAs)
+}
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("User-Agent")))
+	}))
+	t.Cleanup(srv.Close)
+
+	get := func(tb testing.TB, client *http.Client) string {
+		tb.Helper()
+		resp, err := client.Get(srv.URL)
+		require.NoError(tb, err)
+		defer resp.Body.Close()
+		bts, err := io.ReadAll(resp.Body)
+		require.NoError(tb, err)
+		return string(bts)
+	}
+
+	ctx := testctx.NewWithCfg(config.Project{ProjectName: "proj"})
+	client, err := Client(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "goreleaser/dev (+proj)", get(t, client))
+
+	client, err = ClientWith(ctx, Options{}, "custom/{{ .ProjectName }}")
+	require.NoError(t, err)
+	require.Equal(t, "custom/proj", get(t, client))
+
+	_, err = ClientWith(ctx, Options{}, "{{ .Nope }}")
+	testlib.RequireTemplateError(t, err)
+}
+
+func TestTransportInvalidTemplate(t *testing.T) {
+	_, err := Transport(testctx.New(), Options{
+		Proxy: ProxyOptions{URL: "{{ .Nope }}"},
+	})
+	testlib.RequireTemplateError(t, err)
+
+	_, err = Client(testctx.NewWithCfg(config.Project{
+		HTTP: config.HTTP{CABundle: "{{ .Nope }}"},
+	}))
+	testlib.RequireTemplateError(t, err)
+}
@@ -0,0 +1,51 @@
+// Package ifempty handles the configurations whose filters match no
+// artifacts, the same way across the pipes that have them.
+package ifempty
+
+import (
+	"fmt"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+)
+
+// What to do when the filters of a configuration match no artifacts.
+const (
+	// Warn logs a warning and moves on, the default.
+	Warn = "warn"
+	// Skip skips the configuration, which is reported as such.
+	Skip = "skip"
+	// Error fails the pipe.
+	Error = "error"
+)
+
+// Validate errors if the given mode is not valid.
+func Validate(mode string) error {
+	switch mode {
+	case "", Warn, Skip, Error:
+		return nil
+	default:
+		return fmt.Errorf("invalid if_empty %q, valid options are %q", mode, []string{Warn, Skip, Error})
+	}
+}
+
+// Handle handles a configuration of the given pipe, with the given ID (or
+// name), whose filters matched no artifacts, according to the given mode.
+//
+// It returns a pipe.ErrSkip if the mode is Skip, so callers should remember it
+// and keep going with the other configurations.
+func Handle(mode, pipeName, id string) error {
+	switch mode {
+	case "", Warn:
+		log.WithField("pipe", pipeName).
+			WithField("id", id).
+			Warn("no artifacts matched the given filters, set 'if_empty' to 'skip' or 'error' to change this")
+		return nil
+	case Skip:
+		return pipe.Skipf("%s %s: no artifacts matched the given filters", pipeName, id)
+	case Error:
+		return fmt.Errorf("%s %s: no artifacts matched the given filters", pipeName, id)
+	default:
+		return Validate(mode)
+	}
+}
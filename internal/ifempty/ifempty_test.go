@@ -0,0 +1,41 @@
+package ifempty
+
+import (
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	for _, mode := range []string{"", Warn, Skip, Error} {
+		require.NoError(t, Validate(mode))
+	}
+	require.EqualError(t, Validate("nope"), `invalid if_empty "nope", valid options are ["warn" "skip" "error"]`)
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		require.NoError(t, Handle("", "signs", "default"))
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		require.NoError(t, Handle(Warn, "signs", "default"))
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		err := Handle(Skip, "signs", "default")
+		require.True(t, pipe.IsSkip(err))
+		require.EqualError(t, err, "signs default: no artifacts matched the given filters")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := Handle(Error, "signs", "default")
+		require.False(t, pipe.IsSkip(err))
+		require.EqualError(t, err, "signs default: no artifacts matched the given filters")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.EqualError(t, Handle("nope", "signs", "default"), `invalid if_empty "nope", valid options are ["warn" "skip" "error"]`)
+	})
+}
@@ -0,0 +1,129 @@
+package logext
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/caarlos0/log"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	pipeLock sync.Mutex
+	pipe     string
+)
+
+// SetPipe sets the name of the pipe currently running, which is used to tag
+// the JSON log lines, returning the previous one.
+func SetPipe(name string) string {
+	pipeLock.Lock()
+	defer pipeLock.Unlock()
+	previous := pipe
+	pipe = name
+	return previous
+}
+
+func currentPipe() string {
+	pipeLock.Lock()
+	defer pipeLock.Unlock()
+	return pipe
+}
+
+// NewJSONLogger creates a new logger that writes one JSON object per entry
+// to w, with its time, level, pipe, message and fields.
+//
+// The log package has no pluggable handlers, so this changes its global level
+// strings and styles to make the entries parseable, and should only be used to
+// replace the default logger.
+func NewJSONLogger(w io.Writer) *log.Logger {
+	for level := log.DebugLevel; level <= log.FatalLevel; level++ {
+		log.Strings[level] = level.String()
+		log.Styles[level] = lipgloss.NewStyle()
+	}
+	return log.New(&jsonWriter{out: w})
+}
+
+type jsonEntry struct {
+	Time    time.Time         `json:"time"`
+	Level   string            `json:"level"`
+	Pipe    string            `json:"pipe,omitempty"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// jsonWriter assembles the entries written by log.Logger, which writes the
+// level and message, each of the fields, and the final new line separately.
+type jsonWriter struct {
+	lock  sync.Mutex
+	out   io.Writer
+	entry *jsonEntry
+}
+
+func (w *jsonWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	s := string(p)
+	switch {
+	case w.entry == nil:
+		level, msg, _ := strings.Cut(strings.TrimLeft(s, " "), " ")
+		w.entry = &jsonEntry{
+			Time:    log.Now(),
+			Level:   level,
+			Pipe:    currentPipe(),
+			Message: strings.TrimRight(msg, " "),
+		}
+	case s == "\n":
+		entry := w.entry
+		w.entry = nil
+		if err := writeJSON(w.out, entry); err != nil {
+			return 0, err
+		}
+	default:
+		key, value, _ := strings.Cut(strings.TrimPrefix(s, " "), "=")
+		if w.entry.Fields == nil {
+			w.entry.Fields = map[string]string{}
+		}
+		w.entry.Fields[key] = value
+	}
+	return len(p), nil
+}
+
+// outputWriter writes each line of a subprocess output as a JSON entry,
+// tagged with the pipe running it.
+type outputWriter struct {
+	json  *jsonWriter
+	level log.Level
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	w.json.lock.Lock()
+	defer w.json.lock.Unlock()
+	for _, line := range strings.Split(string(p), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if err := writeJSON(w.json.out, &jsonEntry{
+			Time:    log.Now(),
+			Level:   w.level.String(),
+			Pipe:    currentPipe(),
+			Message: line,
+			Fields:  map[string]string{"source": "output"},
+		}); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func writeJSON(w io.Writer, entry *jsonEntry) error {
+	bts, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(bts, '\n'))
+	return err
+}
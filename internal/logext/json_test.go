@@ -0,0 +1,141 @@
+package logext
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caarlos0/log"
+	"github.com/stretchr/testify/require"
+)
+
+func setupJSON(tb testing.TB) *bytes.Buffer {
+	tb.Helper()
+	strs, styles, now := log.Strings, log.Styles, log.Now
+	tb.Cleanup(func() {
+		log.Log = log.New(os.Stderr)
+		log.Strings, log.Styles, log.Now = strs, styles, now
+		SetPipe("")
+	})
+	log.Now = func() time.Time {
+		return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+	var b bytes.Buffer
+	log.Log = NewJSONLogger(&b)
+	return &b
+}
+
+func decodeJSONLines(tb testing.TB, b *bytes.Buffer) []jsonEntry {
+	tb.Helper()
+	var entries []jsonEntry
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		var entry jsonEntry
+		require.NoError(tb, json.Unmarshal([]byte(line), &entry), line)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestJSONLogger(t *testing.T) {
+	b := setupJSON(t)
+	log.SetLevel(log.DebugLevel)
+
+	log.Info("starting")
+	previous := SetPipe("building binaries")
+	require.Empty(t, previous)
+	log.IncreasePadding()
+	log.WithField("binary", "dist/foo_linux_amd64/foo").
+		WithField("target", "linux_amd64").
+		Debug("building")
+	log.WithError(errors.New("oops: a=b")).Warn("something  happened")
+	require.Equal(t, "building binaries", SetPipe(""))
+	log.Error("failed")
+
+	require.Equal(t, []jsonEntry{
+		{
+			Time:    log.Now(),
+			Level:   "info",
+			Message: "starting",
+		},
+		{
+			Time:    log.Now(),
+			Level:   "debug",
+			Pipe:    "building binaries",
+			Message: "building",
+			Fields: map[string]string{
+				"binary": "dist/foo_linux_amd64/foo",
+				"target": "linux_amd64",
+			},
+		},
+		{
+			Time:    log.Now(),
+			Level:   "warn",
+			Pipe:    "building binaries",
+			Message: "something  happened",
+			Fields: map[string]string{
+				"error": "oops: a=b",
+			},
+		},
+		{
+			Time:    log.Now(),
+			Level:   "error",
+			Message: "failed",
+		},
+	}, decodeJSONLines(t, b))
+}
+
+func TestJSONWriter(t *testing.T) {
+	t.Run("info", func(t *testing.T) {
+		b := setupJSON(t)
+		SetPipe("docker images")
+		_, err := io.WriteString(NewWriter(), "foo\nbar\n")
+		require.NoError(t, err)
+		require.Empty(t, b.String())
+	})
+
+	t.Run("debug", func(t *testing.T) {
+		b := setupJSON(t)
+		log.SetLevel(log.DebugLevel)
+		SetPipe("docker images")
+		l, err := io.WriteString(NewWriter(), "foo\r\n\nbar\n")
+		require.NoError(t, err)
+		require.Equal(t, 10, l)
+		require.Equal(t, []jsonEntry{
+			{
+				Time:    log.Now(),
+				Level:   "debug",
+				Pipe:    "docker images",
+				Message: "foo",
+				Fields:  map[string]string{"source": "output"},
+			},
+			{
+				Time:    log.Now(),
+				Level:   "debug",
+				Pipe:    "docker images",
+				Message: "bar",
+				Fields:  map[string]string{"source": "output"},
+			},
+		}, decodeJSONLines(t, b))
+	})
+
+	t.Run("conditional", func(t *testing.T) {
+		b := setupJSON(t)
+		SetPipe("signing artifacts")
+		_, err := io.WriteString(NewConditionalWriter(true), "signed")
+		require.NoError(t, err)
+		require.Equal(t, []jsonEntry{
+			{
+				Time:    log.Now(),
+				Level:   "info",
+				Pipe:    "signing artifacts",
+				Message: "signed",
+				Fields:  map[string]string{"source": "output"},
+			},
+		}, decodeJSONLines(t, b))
+	})
+}
@@ -0,0 +1,78 @@
+package logext
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Redacted is what registered secrets are replaced with.
+const Redacted = "*****"
+
+var (
+	secretsLock sync.RWMutex
+	secrets     = map[string]struct{}{}
+	replacer    = strings.NewReplacer()
+)
+
+// AddSecrets registers sensitive strings, like tokens and keys, to be redacted
+// from the output written to the log writers.
+//
+// Empty and blank strings are ignored.
+func AddSecrets(values ...string) {
+	secretsLock.Lock()
+	defer secretsLock.Unlock()
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		secrets[v] = struct{}{}
+	}
+
+	// longest first, so a secret containing another is redacted entirely.
+	keys := make([]string, 0, len(secrets))
+	for k := range secrets {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	pairs := make([]string, 0, len(keys)*2)
+	for _, k := range keys {
+		pairs = append(pairs, k, Redacted)
+	}
+	replacer = strings.NewReplacer(pairs...)
+}
+
+// Redact replaces all the registered secrets in s.
+func Redact(s string) string {
+	secretsLock.RLock()
+	defer secretsLock.RUnlock()
+	return replacer.Replace(s)
+}
+
+func resetSecrets() {
+	secretsLock.Lock()
+	defer secretsLock.Unlock()
+	secrets = map[string]struct{}{}
+	replacer = strings.NewReplacer()
+}
+
+// redactWriter redacts the registered secrets from each write.
+//
+// Secrets split across writes are not redacted, which is fine for the line
+// buffered output of most commands.
+type redactWriter struct {
+	w io.Writer
+}
+
+func (w redactWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.w, Redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -1,27 +1,109 @@
 package logext
 
 import (
+	"bytes"
+	"fmt"
 	"io"
 	"os"
 
 	"github.com/caarlos0/log"
 )
 
+// DefaultMaxLineLength is the line length to truncate the output of tools
+// known to output huge lines to.
+const DefaultMaxLineLength = 4 * 1024
+
+// WriterOption customizes the log writers.
+type WriterOption func(*writerOptions)
+
+type writerOptions struct {
+	maxLineLength int
+}
+
+// WithMaxLineLength truncates the lines longer than the given length, which
+// is useful for tools that output huge lines, e.g. base64 blobs.
+//
+// The truncated lines end with an ellipsis and the number of bytes omitted.
+// Zero or less means no limit, which is the default.
+func WithMaxLineLength(n int) WriterOption {
+	return func(o *writerOptions) {
+		o.maxLineLength = n
+	}
+}
+
 // NewWriter creates a new log writer.
-func NewWriter() io.Writer {
-	return NewConditionalWriter(false)
+func NewWriter(opts ...WriterOption) io.Writer {
+	return NewConditionalWriter(false, opts...)
 }
 
 // NewConditionalWriter creates a new log writer that only writes when the given condition is met or debug is enabled.
-func NewConditionalWriter(condition bool) io.Writer {
-	if condition || isDebug() {
-		logger, ok := log.Log.(*log.Logger)
-		if !ok {
-			return os.Stderr
+//
+// Secrets registered with AddSecrets are redacted from its output.
+func NewConditionalWriter(condition bool, opts ...WriterOption) io.Writer {
+	if !condition && !isDebug() {
+		return io.Discard
+	}
+	var options writerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var w io.Writer = os.Stderr
+	if logger, ok := log.Log.(*log.Logger); ok {
+		w = logger.Writer
+		if jw, ok := logger.Writer.(*jsonWriter); ok {
+			level := log.DebugLevel
+			if condition {
+				level = log.InfoLevel
+			}
+			w = &outputWriter{json: jw, level: level}
+		}
+	}
+	if options.maxLineLength > 0 {
+		w = &truncateWriter{w: w, max: options.maxLineLength}
+	}
+	// redact before truncating, so no partial secrets are written.
+	return redactWriter{w}
+}
+
+// truncateWriter truncates the lines longer than max.
+//
+// The line being written is tracked across writes, so long lines written in
+// several chunks are truncated as well.
+type truncateWriter struct {
+	w    io.Writer
+	max  int
+	line int
+}
+
+func (t *truncateWriter) Write(p []byte) (int, error) {
+	var out bytes.Buffer
+	for rest := p; len(rest) > 0; {
+		chunk := rest
+		i := bytes.IndexByte(rest, '\n')
+		if i >= 0 {
+			chunk = rest[:i]
+		}
+		if remaining := t.max - t.line; remaining > 0 {
+			out.Write(chunk[:min(len(chunk), remaining)])
+		}
+		t.line += len(chunk)
+		if i < 0 {
+			break
+		}
+		if t.line > t.max {
+			fmt.Fprintf(&out, "… (%d bytes truncated)", t.line-t.max)
+		}
+		out.WriteByte('\n')
+		t.line = 0
+		rest = rest[i+1:]
+	}
+	if out.Len() > 0 {
+		if _, err := t.w.Write(out.Bytes()); err != nil {
+			return 0, err
 		}
-		return logger.Writer
 	}
-	return io.Discard
+	return len(p), nil
 }
 
 func isDebug() bool {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/caarlos0/log"
@@ -40,4 +41,84 @@ func TestWriter(t *testing.T) {
 		require.Equal(t, 8, l)
 		golden.RequireEqualTxt(t, b.Bytes())
 	})
+
+	t.Run("redacted", func(t *testing.T) {
+		t.Cleanup(func() {
+			log.Log = log.New(os.Stderr)
+			resetSecrets()
+		})
+		var b bytes.Buffer
+		log.Log = log.New(&b)
+		AddSecrets("ghp_s3cr3t", "", "  ")
+		l, err := io.WriteString(NewConditionalWriter(true), "Login Succeeded with ghp_s3cr3t\n")
+		require.NoError(t, err)
+		require.Equal(t, 32, l)
+		require.Equal(t, "Login Succeeded with *****\n", b.String())
+	})
+
+	t.Run("redacted json", func(t *testing.T) {
+		b := setupJSON(t)
+		t.Cleanup(resetSecrets)
+		AddSecrets("passphrase")
+		_, err := io.WriteString(NewConditionalWriter(true), "gpg: using passphrase\n")
+		require.NoError(t, err)
+		entries := decodeJSONLines(t, b)
+		require.Len(t, entries, 1)
+		require.Equal(t, "gpg: using *****", entries[0].Message)
+	})
+}
+
+func TestWriterMaxLineLength(t *testing.T) {
+	t.Cleanup(func() {
+		log.Log = log.New(os.Stderr)
+	})
+
+	t.Run("single write", func(t *testing.T) {
+		var b bytes.Buffer
+		log.Log = log.New(&b)
+		input := "short\n" + strings.Repeat("a", 100) + "\nexactly10!\n"
+		l, err := io.WriteString(NewConditionalWriter(true, WithMaxLineLength(10)), input)
+		require.NoError(t, err)
+		require.Equal(t, len(input), l)
+		require.Equal(t, "short\naaaaaaaaaa… (90 bytes truncated)\nexactly10!\n", b.String())
+	})
+
+	t.Run("multiple writes", func(t *testing.T) {
+		var b bytes.Buffer
+		log.Log = log.New(&b)
+		w := NewConditionalWriter(true, WithMaxLineLength(10))
+		for _, s := range []string{"aaaaaa", "aaaaaa", "aaaaaa\nb", "b\n"} {
+			l, err := io.WriteString(w, s)
+			require.NoError(t, err)
+			require.Equal(t, len(s), l)
+		}
+		require.Equal(t, "aaaaaaaaaa… (8 bytes truncated)\nbb\n", b.String())
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		var b bytes.Buffer
+		log.Log = log.New(&b)
+		input := strings.Repeat("a", 100) + "\n"
+		_, err := io.WriteString(NewConditionalWriter(true, WithMaxLineLength(0)), input)
+		require.NoError(t, err)
+		require.Equal(t, input, b.String())
+	})
+
+	t.Run("redacted before truncating", func(t *testing.T) {
+		t.Cleanup(resetSecrets)
+		var b bytes.Buffer
+		log.Log = log.New(&b)
+		AddSecrets("s3cr3t-t0k3n")
+		_, err := io.WriteString(NewConditionalWriter(true, WithMaxLineLength(10)), "token=s3cr3t-t0k3n\n")
+		require.NoError(t, err)
+		require.Equal(t, "token=****… (1 bytes truncated)\n", b.String())
+	})
+}
+
+func TestRedact(t *testing.T) {
+	t.Cleanup(resetSecrets)
+	require.Equal(t, "nothing registered", Redact("nothing registered"))
+	AddSecrets("abc", "abcdef")
+	AddSecrets("abc")
+	require.Equal(t, "token: *****, prefix: *****", Redact("token: abcdef, prefix: abc"))
 }
@@ -0,0 +1,268 @@
+// Package metrics exports the pipe timings recorded in the context, either as
+// a Prometheus textfile or to an OpenTelemetry collector using OTLP/HTTP.
+package metrics
+
+import (
+	"bytes"
+	stdctx "context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	promName = "goreleaser_pipe_duration_seconds"
+	otlpName = "goreleaser.pipe.duration"
+	timeout  = 10 * time.Second
+)
+
+// Options configures where the metrics are exported to.
+// Nothing is exported when all the options are empty.
+type Options struct {
+	// File is the path of a Prometheus textfile to write.
+	File string
+	// Endpoint is the OTLP/HTTP metrics endpoint to push to, e.g.
+	// http://localhost:4318/v1/metrics.
+	Endpoint string
+}
+
+// Export exports the timings recorded in the given context.
+func Export(ctx *context.Context, opts Options) error {
+	if opts.File == "" && opts.Endpoint == "" {
+		return nil
+	}
+	timings := merge(ctx.Timings.List())
+	if opts.File != "" {
+		if err := WriteTextfile(opts.File, ctx.Config.ProjectName, timings); err != nil {
+			return err
+		}
+	}
+	if opts.Endpoint != "" {
+		// the context might be already canceled, e.g. by a timeout, but we
+		// still want to report what happened.
+		pctx, cancel := stdctx.WithTimeout(stdctx.WithoutCancel(ctx), timeout)
+		defer cancel()
+		client, err := httpclient.Client(ctx)
+		if err != nil {
+			return fmt.Errorf("metrics: %w", err)
+		}
+		headers := parseHeaders(ctx.Env["OTEL_EXPORTER_OTLP_HEADERS"])
+		if err := PushOTLP(pctx, client, opts.Endpoint, headers, ctx.Config.ProjectName, timings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTextfile writes the given timings to path in the Prometheus text
+// exposition format, suitable for the node_exporter textfile collector.
+//
+// The file is written atomically, so it is never collected half-written.
+func WriteTextfile(path, project string, timings []context.Timing) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# HELP %s How long each goreleaser pipe took to run.\n", promName)
+	fmt.Fprintf(&b, "# TYPE %s gauge\n", promName)
+	for _, t := range timings {
+		fmt.Fprintf(
+			&b,
+			"%s{project=%s,pipe=%s,parent=%s,status=%s} %s\n",
+			promName,
+			quote(project),
+			quote(t.Name),
+			quote(t.Parent),
+			quote(status(t)),
+			strconv.FormatFloat(t.Duration.Seconds(), 'f', -1, 64),
+		)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b.Bytes()); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("metrics: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+	return nil
+}
+
+// PushOTLP pushes the given timings as a gauge to the given OTLP/HTTP
+// endpoint with the given client, using the JSON encoding.
+//
+// Docs: https://opentelemetry.io/docs/specs/otlp/#otlphttp
+func PushOTLP(ctx stdctx.Context, client *http.Client, endpoint string, headers map[string]string, project string, timings []context.Timing) error {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+	points := make([]otlpDataPoint, 0, len(timings))
+	for _, t := range timings {
+		points = append(points, otlpDataPoint{
+			AsDouble:     t.Duration.Seconds(),
+			TimeUnixNano: now,
+			Attributes: []otlpAttribute{
+				stringAttribute("pipe", t.Name),
+				stringAttribute("parent", t.Parent),
+				stringAttribute("status", status(t)),
+			},
+		})
+	}
+	bts, err := json.Marshal(otlpRequest{
+		ResourceMetrics: []otlpResourceMetrics{{
+			Resource: otlpResource{
+				Attributes: []otlpAttribute{
+					stringAttribute("service.name", "goreleaser"),
+					stringAttribute("project", project),
+				},
+			},
+			ScopeMetrics: []otlpScopeMetrics{{
+				Scope: otlpScope{Name: "goreleaser"},
+				Metrics: []otlpMetric{{
+					Name:        otlpName,
+					Description: "How long each goreleaser pipe took to run.",
+					Unit:        "s",
+					Gauge:       otlpGauge{DataPoints: points},
+				}},
+			}},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(bts))
+	if err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("metrics: %s: unexpected status %d: %s", endpoint, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// merge merges the timings that would be exported with the same labels, e.g.
+// the ones of each target of an announcer, adding up their durations, as a
+// series can't be repeated.
+func merge(timings []context.Timing) []context.Timing {
+	type key struct {
+		name, parent string
+		failed       bool
+	}
+	idx := map[key]int{}
+	result := make([]context.Timing, 0, len(timings))
+	for _, t := range timings {
+		k := key{t.Name, t.Parent, t.Failed}
+		if i, ok := idx[k]; ok {
+			result[i].Duration += t.Duration
+			continue
+		}
+		idx[k] = len(result)
+		result = append(result, t)
+	}
+	return result
+}
+
+func status(t context.Timing) string {
+	if t.Failed {
+		return "failure"
+	}
+	return "success"
+}
+
+// quote quotes a Prometheus label value.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// parseHeaders parses headers in the OTEL_EXPORTER_OTLP_HEADERS format, e.g.
+// "api-key=foo,other=bar".
+func parseHeaders(s string) map[string]string {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			continue
+		}
+		headers[k] = strings.TrimSpace(v)
+	}
+	return headers
+}
+
+type otlpRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpMetric struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Unit        string    `json:"unit"`
+	Gauge       otlpGauge `json:"gauge"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpDataPoint `json:"dataPoints"`
+}
+
+type otlpDataPoint struct {
+	AsDouble     float64         `json:"asDouble"`
+	TimeUnixNano string          `json:"timeUnixNano"`
+	Attributes   []otlpAttribute `json:"attributes"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+func stringAttribute(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{StringValue: value}}
+}
@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func newContext(tb testing.TB) *context.Context {
+	tb.Helper()
+	ctx := testctx.NewWithCfg(config.Project{ProjectName: "foo"})
+	ctx.Timings.Start("building binaries")
+	ctx.Timings.Add("default linux_amd64", 1500*time.Millisecond, nil)
+	ctx.Timings.Add("building binaries", 2*time.Second, nil)
+	ctx.Timings.Start("publishing")
+	ctx.Timings.Add(`my "blob"`, 250*time.Millisecond, errors.New("fake"))
+	ctx.Timings.Add("publishing", 300*time.Millisecond, errors.New("fake"))
+	return ctx
+}
+
+func TestExportDisabled(t *testing.T) {
+	require.NoError(t, Export(newContext(t), Options{}))
+}
+
+func TestWriteTextfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goreleaser.prom")
+	require.NoError(t, Export(newContext(t), Options{File: path}))
+
+	bts, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `# HELP goreleaser_pipe_duration_seconds How long each goreleaser pipe took to run.
+# TYPE goreleaser_pipe_duration_seconds gauge
+goreleaser_pipe_duration_seconds{project="foo",pipe="default linux_amd64",parent="building binaries",status="success"} 1.5
+goreleaser_pipe_duration_seconds{project="foo",pipe="building binaries",parent="",status="success"} 2
+goreleaser_pipe_duration_seconds{project="foo",pipe="my \"blob\"",parent="publishing",status="failure"} 0.25
+goreleaser_pipe_duration_seconds{project="foo",pipe="publishing",parent="",status="failure"} 0.3
+`, string(bts))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary file should have been removed")
+}
+
+func TestWriteTextfileMergesTargets(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{ProjectName: "foo"})
+	ctx.Timings.Start("announcing")
+	ctx.Timings.Add("slack", time.Second, nil)
+	ctx.Timings.Add("slack", 500*time.Millisecond, nil)
+	ctx.Timings.Add("slack", 250*time.Millisecond, errors.New("fake"))
+	ctx.Timings.Add("announcing", 2*time.Second, errors.New("fake"))
+
+	path := filepath.Join(t.TempDir(), "goreleaser.prom")
+	require.NoError(t, Export(ctx, Options{File: path}))
+
+	bts, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `# HELP goreleaser_pipe_duration_seconds How long each goreleaser pipe took to run.
+# TYPE goreleaser_pipe_duration_seconds gauge
+goreleaser_pipe_duration_seconds{project="foo",pipe="slack",parent="announcing",status="success"} 1.5
+goreleaser_pipe_duration_seconds{project="foo",pipe="slack",parent="announcing",status="failure"} 0.25
+goreleaser_pipe_duration_seconds{project="foo",pipe="announcing",parent="",status="failure"} 2
+`, string(bts))
+}
+
+func TestWriteTextfileInvalidDir(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nope", "goreleaser.prom")
+	require.ErrorIs(t, Export(newContext(t), Options{File: path}), os.ErrNotExist)
+}
+
+func TestPushOTLP(t *testing.T) {
+	var body otlpRequest
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		bts, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bts, &body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := newContext(t)
+	ctx.Env["OTEL_EXPORTER_OTLP_HEADERS"] = "api-key=secret, x-team = releng,invalid"
+	require.NoError(t, Export(ctx, Options{Endpoint: srv.URL + "/v1/metrics"}))
+
+	require.Equal(t, "application/json", header.Get("Content-Type"))
+	require.Equal(t, "goreleaser/dev (+foo)", header.Get("User-Agent"))
+	require.Equal(t, "secret", header.Get("api-key"))
+	require.Equal(t, "releng", header.Get("x-team"))
+
+	require.Len(t, body.ResourceMetrics, 1)
+	rm := body.ResourceMetrics[0]
+	require.Equal(t, []otlpAttribute{
+		stringAttribute("service.name", "goreleaser"),
+		stringAttribute("project", "foo"),
+	}, rm.Resource.Attributes)
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	metric := rm.ScopeMetrics[0].Metrics[0]
+	require.Equal(t, "goreleaser.pipe.duration", metric.Name)
+	require.Equal(t, "s", metric.Unit)
+	points := metric.Gauge.DataPoints
+	require.Len(t, points, 4)
+	require.InDelta(t, 0.25, points[2].AsDouble, 0.0001)
+	require.NotEmpty(t, points[2].TimeUnixNano)
+	require.Equal(t, []otlpAttribute{
+		stringAttribute("pipe", `my "blob"`),
+		stringAttribute("parent", "publishing"),
+		stringAttribute("status", "failure"),
+	}, points[2].Attributes)
+}
+
+func TestPushOTLPCanceledContext(t *testing.T) {
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := newContext(t)
+	wrapped, cancel := context.NewWithTimeout(ctx.Config, time.Hour)
+	wrapped.Timings = ctx.Timings
+	cancel()
+	require.NoError(t, Export(wrapped, Options{Endpoint: srv.URL}))
+	require.True(t, called)
+}
+
+func TestPushOTLPCABundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := newContext(t)
+	require.ErrorContains(t, Export(ctx, Options{Endpoint: srv.URL}), "certificate")
+
+	ctx.Env[httpclient.CABundleEnv] = testlib.ServerCert(t, srv)
+	require.NoError(t, Export(ctx, Options{Endpoint: srv.URL}))
+}
+
+func TestPushOTLPBadStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "nope", http.StatusUnauthorized)
+	}))
+	t.Cleanup(srv.Close)
+
+	err := Export(newContext(t), Options{Endpoint: srv.URL})
+	require.ErrorContains(t, err, "unexpected status 401: nope")
+}
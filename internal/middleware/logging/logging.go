@@ -6,6 +6,7 @@ import (
 
 	"github.com/caarlos0/log"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
@@ -15,15 +16,27 @@ var (
 	faint = lipgloss.NewStyle().Italic(true).Faint(true)
 )
 
-// Log pretty prints the given action and its title.
+// Log pretty prints the given action and its title, recording how long it
+// took in the context timings.
 func Log(title string, next middleware.Action) middleware.Action {
-	return func(ctx *context.Context) error {
+	return func(ctx *context.Context) (err error) {
+		if title != "" {
+			// tags the log entries with the pipe, restoring the previous
+			// one when done.
+			defer logext.SetPipe(logext.SetPipe(title))
+		}
 		start := time.Now()
 		defer func() {
 			logDuration(start)
 			log.ResetPadding()
+			if title != "" {
+				recordDuration(ctx, title, start, err)
+			}
 		}()
 		if title != "" {
+			if ctx != nil {
+				ctx.Timings.Start(title)
+			}
 			log.Infof(bold.Render(title))
 			log.IncreasePadding()
 		}
@@ -32,12 +45,17 @@ func Log(title string, next middleware.Action) middleware.Action {
 }
 
 // PadLog pretty prints the given action and its title with an increased padding.
+//
+// Its duration is recorded in the context timings as a child of the step
+// currently running.
 func PadLog(title string, next middleware.Action) middleware.Action {
-	return func(ctx *context.Context) error {
+	return func(ctx *context.Context) (err error) {
+		defer logext.SetPipe(logext.SetPipe(title))
 		start := time.Now()
 		defer func() {
 			logDuration(start)
 			log.ResetPadding()
+			recordDuration(ctx, title, start, err)
 		}()
 		log.ResetPadding()
 		log.IncreasePadding()
@@ -52,3 +70,10 @@ func logDuration(start time.Time) {
 		log.Info(faint.Render(fmt.Sprintf("took: %s", took)))
 	}
 }
+
+func recordDuration(ctx *context.Context, title string, start time.Time, err error) {
+	if ctx == nil {
+		return
+	}
+	ctx.Timings.Add(title, time.Since(start), err)
+}
@@ -1,9 +1,12 @@
 package logging
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/stretchr/testify/require"
 )
@@ -18,3 +21,44 @@ func TestLogging(t *testing.T) {
 		return nil
 	})(nil))
 }
+
+func TestLoggingTimings(t *testing.T) {
+	ctx := context.New(config.Project{})
+	require.NoError(t, Log("publishing", func(ctx *context.Context) error {
+		return PadLog("github", func(_ *context.Context) error {
+			return nil
+		})(ctx)
+	})(ctx))
+	require.Error(t, Log("failing", func(_ *context.Context) error {
+		return errors.New("fake")
+	})(ctx))
+	require.NoError(t, Log("", func(_ *context.Context) error {
+		return nil
+	})(ctx))
+
+	timings := ctx.Timings.List()
+	require.Len(t, timings, 3)
+	require.Equal(t, "github", timings[0].Name)
+	require.Equal(t, "publishing", timings[0].Parent)
+	require.Equal(t, "publishing", timings[1].Name)
+	require.Empty(t, timings[1].Parent)
+	require.False(t, timings[1].Failed)
+	require.Equal(t, "failing", timings[2].Name)
+	require.Empty(t, timings[2].Parent)
+	require.True(t, timings[2].Failed)
+}
+
+func TestLoggingPipe(t *testing.T) {
+	require.NoError(t, Log("publishing", func(ctx *context.Context) error {
+		return PadLog("github", func(_ *context.Context) error {
+			require.Equal(t, "github", logext.SetPipe("github"))
+			return nil
+		})(ctx)
+	})(nil))
+	require.Empty(t, logext.SetPipe(""))
+
+	require.NoError(t, Log("publishing", func(_ *context.Context) error {
+		require.Equal(t, "publishing", logext.SetPipe("publishing"))
+		return nil
+	})(nil))
+}
@@ -0,0 +1,38 @@
+// Package loglevel can raise the log level of an entire Action.
+package loglevel
+
+import (
+	"path"
+	"reflect"
+	"slices"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// Name returns the name of the given pipe, the name of its package, e.g.
+// "docker" for both docker.Pipe and docker.ManifestPipe.
+func Name(pipe interface{}) string {
+	t := reflect.TypeOf(pipe)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return path.Base(t.PkgPath())
+}
+
+// Debug returns an action that runs with the debug log level if the name of
+// the given pipe is in the context debug pipes, restoring the previous level
+// afterwards.
+func Debug(pipe interface{}, next middleware.Action) middleware.Action {
+	return func(ctx *context.Context) error {
+		logger, ok := log.Log.(*log.Logger)
+		if !ok || logger.Level == log.DebugLevel || !slices.Contains(ctx.DebugPipes, Name(pipe)) {
+			return next(ctx)
+		}
+		previous := logger.Level
+		logger.Level = log.DebugLevel
+		defer func() { logger.Level = previous }()
+		return next(ctx)
+	}
+}
@@ -0,0 +1,43 @@
+package loglevel
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/docker"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/sign"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestName(t *testing.T) {
+	require.Equal(t, "docker", Name(docker.Pipe{}))
+	require.Equal(t, "docker", Name(docker.ManifestPipe{}))
+	require.Equal(t, "docker", Name(&docker.Pipe{}))
+	require.Equal(t, "sign", Name(sign.DockerPipe{}))
+}
+
+func TestDebug(t *testing.T) {
+	t.Cleanup(func() {
+		log.Log = log.New(os.Stderr)
+	})
+	var b bytes.Buffer
+	log.Log = log.New(&b)
+
+	ctx := testctx.New()
+	ctx.DebugPipes = []string{"docker"}
+	action := func(ctx *context.Context) error {
+		log.Debug("debugging")
+		return nil
+	}
+
+	require.NoError(t, Debug(sign.Pipe{}, action)(ctx))
+	require.Empty(t, b.String())
+
+	require.NoError(t, Debug(docker.Pipe{}, action)(ctx))
+	require.Contains(t, b.String(), "debugging")
+	require.Equal(t, log.InfoLevel, log.Log.(*log.Logger).Level)
+}
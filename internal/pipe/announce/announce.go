@@ -6,6 +6,7 @@ import (
 
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/errhandler"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/logging"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/loglevel"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/skip"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/bluesky"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/discord"
@@ -22,13 +23,25 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/webhook"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
 // Announcer should be implemented by pipes that want to announce releases.
+//
+// Each announcer might have several targets configured, which are identified
+// by their index.
 type Announcer interface {
 	fmt.Stringer
-	Announce(ctx *context.Context) error
+	skips.Skipper
+	// Len returns the number of targets configured.
+	Len(ctx *context.Context) int
+	// Skip returns true if the target should be skipped.
+	Skip(ctx *context.Context, i int) bool
+	// Conditions returns the target's conditions, which are evaluated
+	// the same way for every announcer before announcing.
+	Conditions(ctx *context.Context, i int) config.AnnounceConditions
+	Announce(ctx *context.Context, i int) error
 }
 
 //nolint:gochecknoglobals
@@ -49,6 +62,9 @@ var announcers = []Announcer{
 	webhook.Pipe{},
 }
 
+// Announcers returns all the announcers, in order.
+func Announcers() []Announcer { return announcers }
+
 // Pipe that announces releases.
 type Pipe struct{}
 
@@ -65,13 +81,39 @@ func (Pipe) Skip(ctx *context.Context) (bool, error) {
 func (Pipe) Run(ctx *context.Context) error {
 	memo := errhandler.Memo{}
 	for _, announcer := range announcers {
-		_ = skip.Maybe(
-			announcer,
-			logging.PadLog(announcer.String(), memo.Wrap(announcer.Announce)),
-		)(ctx)
+		for i := range announcer.Len(ctx) {
+			target := target{announcer, i}
+			_ = loglevel.Debug(announcer, memo.Wrap(skip.Maybe(
+				target,
+				logging.PadLog(announcer.String(), target.Announce),
+			)))(ctx)
+		}
 	}
 	if memo.Error() != nil {
 		return fmt.Errorf("failed to announce release: %w", memo.Error())
 	}
 	return nil
 }
+
+// target is one of the configured targets of an announcer.
+type target struct {
+	announcer Announcer
+	i         int
+}
+
+func (t target) String() string { return t.announcer.String() }
+
+func (t target) Announce(ctx *context.Context) error { return t.announcer.Announce(ctx, t.i) }
+
+func (t target) Skip(ctx *context.Context) (bool, error) {
+	if ctx.Snapshot ||
+		skips.Any(ctx, skips.Announce.Of(t.announcer.Key())) ||
+		t.announcer.Skip(ctx, t.i) {
+		return true, nil
+	}
+	cond := t.announcer.Conditions(ctx, t.i)
+	if cond.SkipPrerelease && ctx.PreRelease {
+		return true, nil
+	}
+	return tmpl.New(ctx).Bool(cond.Skip)
+}
@@ -14,15 +14,70 @@ func TestDescription(t *testing.T) {
 	require.NotEmpty(t, Pipe{}.String())
 }
 
+func TestAnnouncerKeys(t *testing.T) {
+	seen := map[string]bool{}
+	for _, announcer := range Announcers() {
+		require.NotEmpty(t, announcer.Key(), announcer.String())
+		require.False(t, seen[announcer.Key()], announcer.Key())
+		seen[announcer.Key()] = true
+	}
+}
+
 func TestAnnounce(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Twitter: config.Twitter{
+			Twitter: []config.Twitter{{
 				Enabled: true,
-			},
-			Mastodon: config.Mastodon{
+			}},
+			Mastodon: []config.Mastodon{{
 				Enabled: true,
 				Server:  "https://localhost:1234/",
+			}},
+		},
+	})
+	err := Pipe{}.Run(ctx)
+	require.Error(t, err)
+	merr := &multierror.Error{}
+	require.ErrorAs(t, err, &merr, "must be a multierror")
+	require.Len(t, merr.Errors, 2)
+}
+
+func TestAnnounceSkipSingle(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			Twitter: []config.Twitter{{
+				Enabled: true,
+			}},
+			Mastodon: []config.Mastodon{{
+				Enabled: true,
+				Server:  "https://localhost:1234/",
+			}},
+		},
+	}, testctx.Skip(skips.Announce.Of("twitter")))
+	err := Pipe{}.Run(ctx)
+	require.Error(t, err)
+	merr := &multierror.Error{}
+	require.ErrorAs(t, err, &merr, "must be a multierror")
+	require.Len(t, merr.Errors, 1)
+	require.ErrorContains(t, err, "mastodon")
+}
+
+func TestAnnounceMultipleTargets(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			Mastodon: []config.Mastodon{
+				{
+					Enabled: true,
+					Server:  "https://localhost:1234/",
+				},
+				{
+					Enabled: false,
+					Server:  "https://localhost:1235/",
+				},
+				{
+					Enabled: true,
+					Server:  "https://localhost:1236/",
+				},
 			},
 		},
 	})
@@ -33,6 +88,67 @@ func TestAnnounce(t *testing.T) {
 	require.Len(t, merr.Errors, 2)
 }
 
+func TestAnnounceConditions(t *testing.T) {
+	cfg := func(twitter config.AnnounceConditions) config.Project {
+		return config.Project{
+			Announce: config.Announce{
+				Twitter: []config.Twitter{{
+					Enabled:            true,
+					AnnounceConditions: twitter,
+				}},
+				Mastodon: []config.Mastodon{{
+					Enabled: true,
+					Server:  "https://localhost:1234/",
+				}},
+			},
+		}
+	}
+
+	requireErrors := func(tb testing.TB, err error, n int) {
+		tb.Helper()
+		require.Error(tb, err)
+		merr := &multierror.Error{}
+		require.ErrorAs(tb, err, &merr, "must be a multierror")
+		require.Len(tb, merr.Errors, n)
+	}
+
+	t.Run("skip prerelease", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg(config.AnnounceConditions{
+			SkipPrerelease: true,
+		}))
+		ctx.PreRelease = true
+		requireErrors(t, Pipe{}.Run(ctx), 1)
+	})
+
+	t.Run("dont skip release", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg(config.AnnounceConditions{
+			SkipPrerelease: true,
+		}))
+		requireErrors(t, Pipe{}.Run(ctx), 2)
+	})
+
+	t.Run("skip template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg(config.AnnounceConditions{
+			Skip: "{{gt .Patch 0}}",
+		}), testctx.WithSemver(0, 0, 1, ""))
+		requireErrors(t, Pipe{}.Run(ctx), 1)
+	})
+
+	t.Run("invalid skip template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg(config.AnnounceConditions{
+			Skip: "{{if eq .Patch 123}",
+		}))
+		err := Pipe{}.Run(ctx)
+		requireErrors(t, err, 2)
+		require.ErrorContains(t, err, "skip twitter:")
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg(config.AnnounceConditions{}), testctx.Snapshot)
+		require.NoError(t, Pipe{}.Run(ctx))
+	})
+}
+
 func TestAnnounceAllDisabled(t *testing.T) {
 	ctx := testctx.New()
 	require.NoError(t, Pipe{}.Run(ctx))
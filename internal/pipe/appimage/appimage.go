@@ -0,0 +1,271 @@
+// Package appimage implements the Pipe interface providing AppImage
+// bindings.
+package appimage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultNameTemplate = `{{ .ProjectName }}`
+	extractAndRunEnv    = "APPIMAGE_EXTRACT_AND_RUN"
+	fuseHint            = "install FUSE (e.g. the fuse or fuse3 packages), or set " + extractAndRunEnv + "=1 to run appimagetool without it"
+)
+
+var errNoIcon = errors.New("appimage: icon is required")
+
+// cmd runs appimagetool.
+var cmd shell.Runner = shell.StdRunner{}
+
+// archs maps the supported platforms to their AppImage architectures.
+var archs = map[string]string{
+	"amd64": "x86_64",
+	"arm64": "aarch64",
+	"386":   "i686",
+	"arm":   "armhf",
+}
+
+// Pipe for AppImages.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "appimages" }
+func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.AppImages) == 0 }
+
+// Dependencies implements healthcheck.Healthchecker.
+// FUSE is not needed if appimagetool is told to extract itself and run.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	if ctx.Env[extractAndRunEnv] == "1" {
+		return []string{"appimagetool"}
+	}
+	return []string{"appimagetool", "fusermount"}
+}
+
+// Hints implements healthcheck.Hinter.
+func (Pipe) Hints(_ *context.Context) map[string]string {
+	return map[string]string{
+		"appimagetool": "download it from https://github.com/AppImage/appimagetool/releases and put it in your $PATH",
+		"fusermount":   fuseHint,
+	}
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("appimages")
+	for i := range ctx.Config.AppImages {
+		appimage := &ctx.Config.AppImages[i]
+		if appimage.ID == "" {
+			appimage.ID = "default"
+		}
+		if appimage.NameTemplate == "" {
+			appimage.NameTemplate = defaultNameTemplate
+		}
+		if appimage.Goamd64 == "" {
+			appimage.Goamd64 = "v1"
+		}
+		if appimage.Goarm == "" {
+			appimage.Goarm = "6"
+		}
+		if appimage.Name == "" {
+			appimage.Name = ctx.Config.ProjectName
+		}
+		if len(appimage.Categories) == 0 {
+			appimage.Categories = []string{"Utility"}
+		}
+		ids.Inc(appimage.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, appimage := range ctx.Config.AppImages {
+		if err := doRun(ctx, appimage); err != nil {
+			if pipe.IsSkip(err) {
+				skips.Remember(err)
+				continue
+			}
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doRun(ctx *context.Context, appimage config.AppImage) error {
+	tpl := tmpl.New(ctx)
+	if err := tpl.ApplyAll(
+		&appimage.Disable,
+		&appimage.Name,
+		&appimage.Comment,
+		&appimage.Icon,
+		&appimage.Command,
+	); err != nil {
+		return err
+	}
+	if appimage.Disable == "true" {
+		return pipe.Skip("configuration is disabled")
+	}
+	if appimage.Icon == "" {
+		return errNoIcon
+	}
+	if _, err := os.Stat(appimage.Icon); err != nil {
+		return fmt.Errorf("appimage: %w", err)
+	}
+	categories := make([]string, 0, len(appimage.Categories))
+	for _, category := range appimage.Categories {
+		category, err := tpl.Apply(category)
+		if err != nil {
+			return err
+		}
+		categories = append(categories, category)
+	}
+	appimage.Categories = categories
+
+	filters := []artifact.Filter{
+		artifact.ByGoos("linux"),
+		artifact.ByType(artifact.Binary),
+		artifact.Or(
+			artifact.And(
+				artifact.ByGoarch("amd64"),
+				artifact.ByGoamd64(appimage.Goamd64),
+			),
+			artifact.And(
+				artifact.ByGoarch("arm"),
+				artifact.ByGoarm(appimage.Goarm),
+			),
+			artifact.ByGoarch("arm64"),
+			artifact.ByGoarch("386"),
+		),
+	}
+	if len(appimage.IDs) > 0 {
+		filters = append(filters, artifact.ByIDs(appimage.IDs...))
+	}
+
+	g := semerrgroup.NewShared(ctx)
+	for platform, binaries := range ctx.Artifacts.Filter(artifact.And(filters...)).GroupByPlatform() {
+		arch, ok := archs[binaries[0].Goarch]
+		if !ok {
+			log.WithField("platform", platform).Warn("ignored unsupported arch")
+			continue
+		}
+		g.Go(func() error {
+			return create(ctx, appimage, arch, binaries)
+		})
+	}
+	return g.Wait()
+}
+
+func create(ctx *context.Context, appimage config.AppImage, arch string, binaries []*artifact.Artifact) error {
+	name, err := tmpl.New(ctx).WithArtifact(binaries[0]).Apply(appimage.NameTemplate)
+	if err != nil {
+		return err
+	}
+	name = name + "-" + arch + ".AppImage"
+
+	command := appimage.Command
+	if command == "" {
+		command = filepath.Base(binaries[0].Path)
+	}
+
+	appDir := filepath.Join(ctx.Config.Dist, "appimage", strings.TrimSuffix(name, ".AppImage"), "AppDir")
+	if err := os.RemoveAll(appDir); err != nil {
+		return err
+	}
+	bin := filepath.Join(appDir, "usr", "bin")
+	if err := os.MkdirAll(bin, 0o755); err != nil {
+		return err
+	}
+	for _, binary := range binaries {
+		if err := gio.CopyWithMode(binary.Path, filepath.Join(bin, filepath.Base(binary.Path)), 0o755); err != nil {
+			return err
+		}
+	}
+
+	if err := gio.Copy(appimage.Icon, filepath.Join(appDir, command+filepath.Ext(appimage.Icon))); err != nil {
+		return err
+	}
+	if err := os.WriteFile(
+		filepath.Join(appDir, command+".desktop"),
+		desktopEntry(appimage, command),
+		0o644,
+	); err != nil {
+		return err
+	}
+	if err := os.WriteFile(
+		filepath.Join(appDir, "AppRun"),
+		appRun(command),
+		0o755,
+	); err != nil {
+		return err
+	}
+
+	path := filepath.Join(ctx.Config.Dist, name)
+	log.WithField("arch", arch).WithField("appimage", path).Info("creating")
+	if out, err := cmd.Exec(ctx, shell.Cmd{
+		Name: "appimagetool",
+		Args: []string{appDir, path},
+		Env:  append(ctx.Env.Strings(), "ARCH="+arch),
+	}); err != nil {
+		if bytes.Contains(bytes.ToLower(out), []byte("fuse")) {
+			return fmt.Errorf("failed to create appimage: %w: %s: %s", err, string(out), fuseHint)
+		}
+		return fmt.Errorf("failed to create appimage: %w: %s", err, string(out))
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.AppImage,
+		Name:    name,
+		Path:    path,
+		Goos:    binaries[0].Goos,
+		Goarch:  binaries[0].Goarch,
+		Goarm:   binaries[0].Goarm,
+		Goamd64: binaries[0].Goamd64,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:     appimage.ID,
+			artifact.ExtraFormat: "appimage",
+		},
+	})
+	return nil
+}
+
+// desktopEntry returns the .desktop file of the AppImage.
+// See: https://specifications.freedesktop.org/desktop-entry-spec/latest/
+func desktopEntry(appimage config.AppImage, command string) []byte {
+	var b strings.Builder
+	b.WriteString("[Desktop Entry]\n")
+	b.WriteString("Type=Application\n")
+	fmt.Fprintf(&b, "Name=%s\n", appimage.Name)
+	if appimage.Comment != "" {
+		fmt.Fprintf(&b, "Comment=%s\n", appimage.Comment)
+	}
+	fmt.Fprintf(&b, "Exec=%s\n", command)
+	fmt.Fprintf(&b, "Icon=%s\n", command)
+	fmt.Fprintf(&b, "Categories=%s;\n", strings.Join(appimage.Categories, ";"))
+	fmt.Fprintf(&b, "Terminal=%t\n", appimage.Terminal)
+	return []byte(b.String())
+}
+
+// appRun returns the AppRun script, which runs the given command from
+// inside the mounted AppImage.
+func appRun(command string) []byte {
+	return []byte(`#!/bin/sh
+HERE="$(dirname "$(readlink -f "$0")")"
+exec "$HERE/usr/bin/` + command + `" "$@"
+`)
+}
@@ -0,0 +1,253 @@
+package appimage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencies(t *testing.T) {
+	t.Run("fuse", func(t *testing.T) {
+		require.Equal(t, []string{"appimagetool", "fusermount"}, Pipe{}.Dependencies(testctx.New()))
+	})
+
+	t.Run("extract and run", func(t *testing.T) {
+		ctx := testctx.New(testctx.WithEnv(map[string]string{extractAndRunEnv: "1"}))
+		require.Equal(t, []string{"appimagetool"}, Pipe{}.Dependencies(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		AppImages:   []config.AppImage{{}},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, config.AppImage{
+		ID:           "default",
+		NameTemplate: defaultNameTemplate,
+		Goamd64:      "v1",
+		Goarm:        "6",
+		Name:         "foo",
+		Categories:   []string{"Utility"},
+	}, ctx.Config.AppImages[0])
+}
+
+func TestDesktopEntry(t *testing.T) {
+	golden.RequireEqualExt(t, desktopEntry(config.AppImage{
+		Name:       "Foo",
+		Comment:    "The foo app",
+		Categories: []string{"Development", "Utility"},
+		Terminal:   true,
+	}, "foo"), ".desktop")
+}
+
+func TestRun(t *testing.T) {
+	mock, restore := shell.Mock(&cmd, nil)
+	t.Cleanup(restore)
+
+	folder := t.TempDir()
+	icon := filepath.Join(folder, "icon.png")
+	require.NoError(t, os.WriteFile(icon, []byte("fake png"), 0o644))
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Dist:        folder,
+		AppImages: []config.AppImage{
+			{
+				Icon:    icon,
+				Comment: "foo {{ .Version }}",
+			},
+		},
+	}, testctx.WithVersion("1.0.0"), testctx.WithEnv(map[string]string{"FOO": "bar"}))
+	addBinaries(t, ctx, folder)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	appimages := ctx.Artifacts.Filter(artifact.ByType(artifact.AppImage)).List()
+	require.Len(t, appimages, 3)
+	require.ElementsMatch(t, []string{
+		"foo-x86_64.AppImage",
+		"foo-aarch64.AppImage",
+		"foo-armhf.AppImage",
+	}, []string{appimages[0].Name, appimages[1].Name, appimages[2].Name})
+	for _, appimage := range appimages {
+		require.Equal(t, "default", artifact.ExtraOr(*appimage, artifact.ExtraID, ""))
+		require.Equal(t, "appimage", artifact.ExtraOr(*appimage, artifact.ExtraFormat, ""))
+	}
+
+	appDir := filepath.Join(folder, "appimage", "foo-x86_64", "AppDir")
+	for _, name := range []string{"AppRun", "usr/bin/foo"} {
+		stat, err := os.Stat(filepath.Join(appDir, name))
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o755), stat.Mode().Perm())
+	}
+	require.FileExists(t, filepath.Join(appDir, "foo.png"))
+	bts, err := os.ReadFile(filepath.Join(appDir, "foo.desktop"))
+	require.NoError(t, err)
+	require.Contains(t, string(bts), "Comment=foo 1.0.0\n")
+
+	var calls []string
+	for _, c := range mock.Cmds() {
+		// the environment of the context is kept, and ARCH is set for
+		// appimagetool.
+		require.Contains(t, c.Env, "FOO=bar")
+		arch := c.Env[slices.IndexFunc(c.Env, func(s string) bool {
+			return strings.HasPrefix(s, "ARCH=")
+		})]
+		calls = append(calls, arch+" "+c.String())
+	}
+	require.Len(t, calls, 3)
+	require.Contains(t, calls, "ARCH=x86_64 appimagetool "+appDir+" "+filepath.Join(folder, "foo-x86_64.AppImage"))
+}
+
+func TestRunVariants(t *testing.T) {
+	_, restore := shell.Mock(&cmd, nil)
+	t.Cleanup(restore)
+
+	folder := t.TempDir()
+	icon := filepath.Join(folder, "icon.png")
+	require.NoError(t, os.WriteFile(icon, []byte("fake png"), 0o644))
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Dist:        folder,
+		AppImages: []config.AppImage{
+			{
+				Icon:    icon,
+				Goamd64: "v3",
+				Goarm:   "7",
+			},
+		},
+	})
+	addBinaries(t, ctx, folder)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	amd64 := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.AppImage),
+		artifact.ByGoarch("amd64"),
+	)).List()
+	require.Len(t, amd64, 1)
+	require.Equal(t, "v3", amd64[0].Goamd64)
+
+	arm := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.AppImage),
+		artifact.ByGoarch("arm"),
+	)).List()
+	require.Len(t, arm, 1)
+	require.Equal(t, "7", arm[0].Goarm)
+}
+
+func TestRunErrors(t *testing.T) {
+	folder := t.TempDir()
+	icon := filepath.Join(folder, "icon.png")
+	require.NoError(t, os.WriteFile(icon, []byte("fake png"), 0o644))
+
+	for name, tt := range map[string]struct {
+		appimage config.AppImage
+		out      string
+		check    func(tb testing.TB, err error)
+	}{
+		"no icon": {
+			appimage: config.AppImage{},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.ErrorIs(tb, err, errNoIcon)
+			},
+		},
+		"missing icon": {
+			appimage: config.AppImage{Icon: filepath.Join(folder, "nope.png")},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.ErrorIs(tb, err, os.ErrNotExist)
+			},
+		},
+		"bad name": {
+			appimage: config.AppImage{Icon: icon, Name: "{{ .Nope }"},
+			check:    testlib.RequireTemplateError,
+		},
+		"bad categories": {
+			appimage: config.AppImage{Icon: icon, Categories: []string{"{{ .Nope }"}},
+			check:    testlib.RequireTemplateError,
+		},
+		"failed": {
+			appimage: config.AppImage{Icon: icon},
+			out:      "some output",
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.EqualError(tb, err, "failed to create appimage: fake error: some output")
+			},
+		},
+		"no fuse": {
+			appimage: config.AppImage{Icon: icon},
+			out:      "dlopen(): error loading libfuse.so.2",
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.ErrorContains(tb, err, extractAndRunEnv+"=1")
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, restore := shell.Mock(&cmd, func(shell.Cmd) ([]byte, error) {
+				return []byte(tt.out), errors.New("fake error")
+			})
+			t.Cleanup(restore)
+
+			dist := t.TempDir()
+			ctx := testctx.NewWithCfg(config.Project{
+				ProjectName: "foo",
+				Dist:        dist,
+				AppImages:   []config.AppImage{tt.appimage},
+			})
+			addBinaries(t, ctx, dist)
+			require.NoError(t, Pipe{}.Default(ctx))
+			tt.check(t, Pipe{}.Run(ctx))
+		})
+	}
+}
+
+func addBinaries(tb testing.TB, ctx *context.Context, dist string) {
+	tb.Helper()
+	for _, platform := range []struct{ goarch, goamd64, goarm string }{
+		{goarch: "amd64", goamd64: "v1"},
+		{goarch: "amd64", goamd64: "v3"},
+		{goarch: "arm", goarm: "6"},
+		{goarch: "arm", goarm: "7"},
+		{goarch: "arm64"},
+		{goarch: "riscv64"},
+	} {
+		path := filepath.Join(dist, "foo_linux_"+platform.goarch+platform.goamd64+platform.goarm, "foo")
+		require.NoError(tb, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(tb, os.WriteFile(path, []byte("fake"), 0o644))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo",
+			Path:    path,
+			Goos:    "linux",
+			Goarch:  platform.goarch,
+			Goamd64: platform.goamd64,
+			Goarm:   platform.goarm,
+			Type:    artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: "foo",
+			},
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "foo.exe",
+		Path:   filepath.Join(dist, "foo_windows_amd64", "foo.exe"),
+		Goos:   "windows",
+		Goarch: "amd64",
+		Type:   artifact.Binary,
+	})
+}
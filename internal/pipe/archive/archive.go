@@ -8,18 +8,23 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/archivefiles"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/ids"
 	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/archive"
+	"github.com/goreleaser/goreleaser/v2/pkg/archive/tar"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
@@ -28,6 +33,9 @@ const (
 	defaultNameTemplateSuffix = `{{ .Version }}_{{ .Os }}_{{ .Arch }}{{ with .Arm }}v{{ . }}{{ end }}{{ with .Mips }}_{{ . }}{{ end }}{{ if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}`
 	defaultNameTemplate       = "{{ .ProjectName }}_" + defaultNameTemplateSuffix
 	defaultBinaryNameTemplate = "{{ .Binary }}_" + defaultNameTemplateSuffix
+	defaultVersionFileName    = "version.txt"
+	dedupeHardlink            = "hardlink"
+	defaultVersionFileContent = "version: {{ .Version }}\ntag: {{ .Tag }}\ncommit: {{ .FullCommit }}\ndate: {{ .Date }}\n"
 )
 
 // ErrArchiveDifferentBinaryCount happens when an archive uses several builds which have different goos/goarch/etc sets,
@@ -79,6 +87,17 @@ func (Pipe) Default(ctx *context.Context) error {
 				archive.NameTemplate = defaultBinaryNameTemplate
 			}
 		}
+		if archive.Dedupe != "" && archive.Dedupe != dedupeHardlink {
+			return fmt.Errorf("invalid archive dedupe %q, valid options are %q", archive.Dedupe, dedupeHardlink)
+		}
+		if archive.IncludeVersionFile {
+			if archive.VersionFile.Name == "" {
+				archive.VersionFile.Name = defaultVersionFileName
+			}
+			if archive.VersionFile.Content == "" {
+				archive.VersionFile.Content = defaultVersionFileContent
+			}
+		}
 		ids.Inc(archive.ID)
 	}
 	return ids.Validate()
@@ -86,7 +105,7 @@ func (Pipe) Default(ctx *context.Context) error {
 
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
-	g := semerrgroup.New(ctx.Parallelism)
+	g := semerrgroup.NewShared(ctx)
 	for i, archive := range ctx.Config.Archives {
 		if archive.Meta {
 			g.Go(func() error {
@@ -101,10 +120,18 @@ func (Pipe) Run(ctx *context.Context) error {
 			artifact.ByType(artifact.Header),
 			artifact.ByType(artifact.CArchive),
 			artifact.ByType(artifact.CShared),
+			artifact.ByType(artifact.Plugin),
 		)}
 		if len(archive.Builds) > 0 {
 			filter = append(filter, artifact.ByIDs(archive.Builds...))
 		}
+		if archive.Filter != "" {
+			expr, err := artifact.ByExpression(archive.Filter)
+			if err != nil {
+				return fmt.Errorf("invalid archive: %d: %w", i, err)
+			}
+			filter = append(filter, expr)
+		}
 		artifacts := ctx.Artifacts.Filter(artifact.And(filter...)).GroupByPlatform()
 		if err := checkArtifacts(artifacts); err != nil && archive.Format != "binary" && !archive.AllowDifferentBinaryCount {
 			return fmt.Errorf("invalid archive: %d: %w", i, ErrArchiveDifferentBinaryCount)
@@ -179,7 +206,7 @@ func create(ctx *context.Context, arch config.Archive, binaries []*artifact.Arti
 	if err != nil {
 		return err
 	}
-	a, err := archive.New(archiveFile, format)
+	a, err := archive.New(archiveFile, format, archiveOptions(arch, format, log)...)
 	if err != nil {
 		return err
 	}
@@ -193,11 +220,27 @@ func create(ctx *context.Context, arch config.Archive, binaries []*artifact.Arti
 	if arch.Meta && len(files) == 0 {
 		return fmt.Errorf("no files found")
 	}
+	epoch, err := sourceDateEpoch(ctx)
+	if err != nil {
+		return err
+	}
+	buildsInfo := arch.BuildsInfo
+	if buildsInfo.ParsedMTime.IsZero() {
+		buildsInfo.ParsedMTime = epoch
+	}
 	for _, f := range files {
+		if f.Info.ParsedMTime.IsZero() {
+			f.Info.ParsedMTime = epoch
+		}
 		if err = a.Add(f); err != nil {
 			return fmt.Errorf("failed to add: '%s' -> '%s': %w", f.Source, f.Destination, err)
 		}
 	}
+	if arch.IncludeVersionFile {
+		if err := addVersionFile(template, a, arch.VersionFile, epoch); err != nil {
+			return err
+		}
+	}
 	bins := []string{}
 	for _, binary := range binaries {
 		dst := binary.Name
@@ -207,12 +250,22 @@ func create(ctx *context.Context, arch config.Archive, binaries []*artifact.Arti
 		if err := a.Add(config.File{
 			Source:      binary.Path,
 			Destination: dst,
-			Info:        arch.BuildsInfo,
+			Info:        buildsInfo,
 		}); err != nil {
 			return fmt.Errorf("failed to add: '%s' -> '%s': %w", binary.Path, dst, err)
 		}
+		if err := addBinarySignatures(ctx, a, binary, dst, buildsInfo.ParsedMTime); err != nil {
+			return err
+		}
 		bins = append(bins, binary.Name)
 	}
+	var docs []string
+	if len(binaries) > 0 {
+		docs, err = addDocs(ctx, a, binaries, buildsInfo.ParsedMTime)
+		if err != nil {
+			return err
+		}
+	}
 	art := &artifact.Artifact{
 		Type: artifact.UploadableArchive,
 		Name: folder + "." + format,
@@ -222,6 +275,7 @@ func create(ctx *context.Context, arch config.Archive, binaries []*artifact.Arti
 			artifact.ExtraFormat:    format,
 			artifact.ExtraWrappedIn: wrap,
 			artifact.ExtraBinaries:  bins,
+			artifact.ExtraDocs:      docs,
 		},
 	}
 	if len(binaries) > 0 {
@@ -233,10 +287,133 @@ func create(ctx *context.Context, arch config.Archive, binaries []*artifact.Arti
 		art.Extra[artifact.ExtraReplaces] = binaries[0].Extra[artifact.ExtraReplaces]
 	}
 
+	// the archive must be complete before the post hooks can look at it.
+	if err := a.Close(); err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+	if err := archiveFile.Close(); err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+	if err := shell.RunHooks(ctx, arch.Hooks.After, ctx.Env.Strings(), func() *tmpl.Template {
+		return tmpl.New(ctx).WithArtifact(art)
+	}); err != nil {
+		return fmt.Errorf("after hook failed: %w", err)
+	}
+
 	ctx.Artifacts.Add(art)
 	return nil
 }
 
+// addBinarySignatures adds the signatures made for the given binary before
+// archiving it (signs with `phase: build`) next to it in the archive.
+func addBinarySignatures(ctx *context.Context, a archive.Archive, binary *artifact.Artifact, dst string, mtime time.Time) error {
+	for _, sig := range ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.BinarySignature),
+		func(a *artifact.Artifact) bool {
+			return artifact.ExtraOr(*a, artifact.ExtraSignatureOf, "") == binary.Path
+		},
+	)).List() {
+		sigDst := path.Join(path.Dir(filepath.ToSlash(dst)), path.Base(filepath.ToSlash(sig.Name)))
+		if err := a.Add(config.File{
+			Source:      sig.Path,
+			Destination: sigDst,
+			Info:        config.FileInfo{ParsedMTime: mtime},
+		}); err != nil {
+			return fmt.Errorf("failed to add: '%s' -> '%s': %w", sig.Path, sigDst, err)
+		}
+	}
+	return nil
+}
+
+// addDocs adds the shell completions and man pages of the given binaries to
+// the archive, in the completions and manpages folders, returning their paths
+// in it.
+func addDocs(ctx *context.Context, a archive.Archive, binaries []*artifact.Artifact, mtime time.Time) ([]string, error) {
+	var docs []string
+	for _, art := range ctx.Artifacts.Filter(artifact.ByDocsOf(binaries)).List() {
+		dir := "completions"
+		if art.Type == artifact.ManPage {
+			dir = "manpages"
+		}
+		dst := path.Join(dir, art.Name)
+		if err := a.Add(config.File{
+			Source:      art.Path,
+			Destination: dst,
+			Info:        config.FileInfo{ParsedMTime: mtime},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to add: '%s' -> '%s': %w", art.Path, dst, err)
+		}
+		docs = append(docs, dst)
+	}
+	return docs, nil
+}
+
+// addVersionFile evaluates the version file name and content templates, and
+// adds the result to the archive.
+func addVersionFile(template *tmpl.Template, a archive.Archive, vf config.VersionFile, mtime time.Time) error {
+	name, err := template.Apply(vf.Name)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate version file name: %w", err)
+	}
+	content, err := template.Apply(vf.Content)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate version file content: %w", err)
+	}
+
+	// archives can only add files from disk, so we write it to a temporary
+	// directory that is removed once it has been added.
+	tmp, err := os.MkdirTemp("", "goreleaserversion")
+	if err != nil {
+		return fmt.Errorf("failed to create version file: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, filepath.Base(name))
+	if err := os.WriteFile(src, []byte(content), 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to create version file: %w", err)
+	}
+	if err := a.Add(config.File{
+		Source:      src,
+		Destination: name,
+		Info:        config.FileInfo{ParsedMTime: mtime},
+	}); err != nil {
+		return fmt.Errorf("failed to add: '%s': %w", name, err)
+	}
+	return nil
+}
+
+// sourceDateEpoch returns the time set in SOURCE_DATE_EPOCH, if any, which is
+// used as the modification time of files that don't have one set.
+//
+// See https://reproducible-builds.org/docs/source-date-epoch/
+func sourceDateEpoch(ctx *context.Context) (time.Time, error) {
+	epoch := ctx.Env["SOURCE_DATE_EPOCH"]
+	if epoch == "" {
+		return time.Time{}, nil
+	}
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH: %w", err)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+func archiveOptions(arch config.Archive, format string, log *log.Entry) []tar.Option {
+	var opts []tar.Option
+	if arch.PreserveXattrs {
+		opts = append(opts, tar.WithXattrs(func(path string, err error) {
+			log.WithField("file", path).WithError(err).Warn("could not preserve extended attributes")
+		}))
+	}
+	if arch.Dedupe == dedupeHardlink {
+		opts = append(opts, tar.WithHardlinkDedupe())
+	}
+	if len(opts) > 0 && (format == "zip" || format == "gz") {
+		log.Warnf("preserve_xattrs and dedupe are not supported by the %s format, ignoring", format)
+		return nil
+	}
+	return opts
+}
+
 func wrapFolder(a config.Archive) string {
 	switch a.WrapInDirectory {
 	case "true":
@@ -254,10 +431,24 @@ func skip(ctx *context.Context, archive config.Archive, binaries []*artifact.Art
 		if err != nil {
 			return err
 		}
-		finalName := name + artifact.ExtraOr(*binary, artifact.ExtraExt, "")
+		// the name template might already include the extension, e.g. via
+		// {{ .ArtifactExt }}, in which case we do not add it again.
+		ext := artifact.ExtraOr(*binary, artifact.ExtraExt, "")
+		finalName := name
+		if !strings.HasSuffix(finalName, ext) {
+			finalName += ext
+		}
 		log.WithField("binary", binary.Name).
 			WithField("name", finalName).
 			Info("skip archiving")
+		lock.Lock()
+		if len(ctx.Artifacts.Filter(artifact.And(
+			artifact.ByType(artifact.UploadableBinary),
+			func(a *artifact.Artifact) bool { return a.Name == finalName && a.Path != binary.Path },
+		)).List()) > 0 {
+			lock.Unlock()
+			return fmt.Errorf("binary named %s already exists. Check your archive name template", finalName)
+		}
 		ctx.Artifacts.Add(&artifact.Artifact{
 			Type:    artifact.UploadableBinary,
 			Name:    finalName,
@@ -271,9 +462,11 @@ func skip(ctx *context.Context, archive config.Archive, binaries []*artifact.Art
 				artifact.ExtraID:       archive.ID,
 				artifact.ExtraFormat:   archive.Format,
 				artifact.ExtraBinary:   binary.Name,
-				artifact.ExtraReplaces: binaries[0].Extra[artifact.ExtraReplaces],
+				artifact.ExtraExt:      ext,
+				artifact.ExtraReplaces: binary.Extra[artifact.ExtraReplaces],
 			},
 		})
+		lock.Unlock()
 	}
 	return nil
 }
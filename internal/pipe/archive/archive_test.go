@@ -2,20 +2,29 @@ package archive
 
 import (
 	"archive/tar"
+	"archive/zip"
 	"compress/gzip"
 	"fmt"
 	"io"
 	"io/fs"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/archive"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/stretchr/testify/require"
 )
 
@@ -508,6 +517,142 @@ func TestRunPipeBinary(t *testing.T) {
 	require.Equal(t, "myotherbin.exe", artifact.ExtraOr(*windows2, artifact.ExtraBinary, ""))
 }
 
+func TestRunPipeBinaryExtInNameTemplate(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	createFakeBinary(t, dist, "darwinall", "mybin")
+	createFakeBinary(t, dist, "windowsamd64", "mybin.exe")
+	ctx := testctx.NewWithCfg(
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					Format:       "binary",
+					NameTemplate: "{{ .Binary }}_{{ .Os }}_{{ .Arch }}{{ .ArtifactExt }}",
+				},
+			},
+		},
+		testctx.WithVersion("0.0.1"),
+		testctx.WithCurrentTag("v0.0.1"),
+	)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "darwin",
+		Goarch: "all",
+		Name:   "mybin",
+		Path:   filepath.Join(dist, "darwinall", "mybin"),
+		Type:   artifact.UniversalBinary,
+		Extra: map[string]interface{}{
+			artifact.ExtraBinary:   "mybin",
+			artifact.ExtraID:       "default",
+			artifact.ExtraReplaces: true,
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "windows",
+		Goarch: "amd64",
+		Name:   "mybin.exe",
+		Path:   filepath.Join(dist, "windowsamd64", "mybin.exe"),
+		Type:   artifact.Binary,
+		Extra: map[string]interface{}{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraExt:    ".exe",
+			artifact.ExtraID:     "default",
+		},
+	})
+
+	require.NoError(t, Pipe{}.Run(ctx))
+	binaries := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableBinary))
+	require.Len(t, binaries.List(), 2)
+
+	darwin := binaries.Filter(artifact.ByGoos("darwin")).List()[0]
+	require.Equal(t, "mybin_darwin_all", darwin.Name)
+	require.Equal(t, "mybin", artifact.ExtraOr(*darwin, artifact.ExtraBinary, ""))
+	require.Equal(t, "", artifact.ExtraOr(*darwin, artifact.ExtraExt, "nope"))
+	require.True(t, artifact.ExtraOr(*darwin, artifact.ExtraReplaces, false))
+
+	windows := binaries.Filter(artifact.ByGoos("windows")).List()[0]
+	require.Equal(t, "mybin_windows_amd64.exe", windows.Name)
+	require.Equal(t, "mybin.exe", artifact.ExtraOr(*windows, artifact.ExtraBinary, ""))
+	require.Equal(t, ".exe", artifact.ExtraOr(*windows, artifact.ExtraExt, ""))
+	require.False(t, artifact.ExtraOr(*windows, artifact.ExtraReplaces, false))
+}
+
+func TestRunPipeFilterMicroarch(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	ctx := testctx.NewWithCfg(
+		config.Project{
+			ProjectName: "foo",
+			Dist:        dist,
+			Archives: []config.Archive{
+				{
+					ID:           "default",
+					Format:       "tar.gz",
+					NameTemplate: defaultNameTemplate,
+					Filter:       `goamd64 != "v3"`,
+				},
+				{
+					ID:           "v3",
+					Format:       "tar.gz",
+					NameTemplate: defaultNameTemplate,
+					Filter:       `goamd64 == "v3"`,
+				},
+			},
+		},
+		testctx.WithVersion("0.0.1"),
+		testctx.WithCurrentTag("v0.0.1"),
+	)
+	for _, plat := range [][]string{
+		{"amd64", "v1"},
+		{"amd64", "v3"},
+		{"arm64", ""},
+	} {
+		path := filepath.Join(dist, plat[0]+plat[1], "mybin")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("foo"), 0o755))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:    "linux",
+			Goarch:  plat[0],
+			Goamd64: plat[1],
+			Name:    "mybin",
+			Path:    path,
+			Type:    artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraBinary: "mybin",
+				artifact.ExtraID:     "default",
+			},
+		})
+	}
+
+	require.NoError(t, Pipe{}.Run(ctx))
+	names := map[string][]string{}
+	for id, archives := range ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).GroupByID() {
+		for _, a := range archives {
+			names[id] = append(names[id], a.Name)
+		}
+		sort.Strings(names[id])
+	}
+	require.Equal(t, map[string][]string{
+		"default": {"foo_0.0.1_linux_amd64.tar.gz", "foo_0.0.1_linux_arm64.tar.gz"},
+		"v3":      {"foo_0.0.1_linux_amd64v3.tar.gz"},
+	}, names)
+
+	t.Run("invalid filter", func(t *testing.T) {
+		ctx.Config.Archives = []config.Archive{{Filter: "nope"}}
+		require.ErrorContains(t, Pipe{}.Run(ctx), "invalid filter")
+	})
+
+	t.Run("binary name collision", func(t *testing.T) {
+		ctx.Config.Archives = []config.Archive{{
+			ID:           "bins",
+			Format:       "binary",
+			NameTemplate: "{{ .Binary }}_{{ .Os }}_{{ .Arch }}",
+			Filter:       `goarch == "amd64"`,
+		}}
+		require.EqualError(t, Pipe{}.Run(ctx), "binary named mybin_linux_amd64 already exists. Check your archive name template")
+	})
+}
+
 func TestRunPipeDistRemoved(t *testing.T) {
 	ctx := testctx.NewWithCfg(
 		config.Project{
@@ -784,6 +929,291 @@ func TestRunPipeWrap(t *testing.T) {
 	)
 }
 
+func TestRunPipeDocs(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.Mkdir(dist, 0o755))
+	for _, name := range []string{"mybin", "mybin.bash", "mybin.1", "other.bash", "other.1"} {
+		f, err := os.Create(filepath.Join(dist, name))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+	ctx := testctx.NewWithCfg(
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					Builds:       []string{"default"},
+					NameTemplate: "foo",
+					Format:       "tar.gz",
+					Files:        []config.File{},
+				},
+			},
+		},
+		testctx.WithCurrentTag("v0.0.1"),
+	)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Goos:   "darwin",
+		Goarch: "amd64",
+		Name:   "mybin",
+		Path:   filepath.Join(dist, "mybin"),
+		Type:   artifact.Binary,
+		Extra: map[string]interface{}{
+			artifact.ExtraBinary: "mybin",
+			artifact.ExtraID:     "default",
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "mybin",
+		Path: filepath.Join(dist, "mybin.bash"),
+		Type: artifact.Completion,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:    "default",
+			artifact.ExtraShell: "bash",
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "mybin.1",
+		Path: filepath.Join(dist, "mybin.1"),
+		Type: artifact.ManPage,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:      "default",
+			artifact.ExtraSection: "1",
+		},
+	})
+	// docs of builds not in the archive are not added.
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "other",
+		Path: filepath.Join(dist, "other.bash"),
+		Type: artifact.Completion,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:    "other",
+			artifact.ExtraShell: "bash",
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "other.1",
+		Path: filepath.Join(dist, "other.1"),
+		Type: artifact.ManPage,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:      "other",
+			artifact.ExtraSection: "1",
+		},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	require.ElementsMatch(
+		t,
+		[]string{"mybin", "completions/mybin", "manpages/mybin.1"},
+		testlib.LsArchive(t, filepath.Join(dist, "foo.tar.gz"), "tar.gz"),
+	)
+	archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+	require.Len(t, archives, 1)
+	require.Equal(t, []string{"completions/mybin", "manpages/mybin.1"}, artifact.ExtraOr(*archives[0], artifact.ExtraDocs, []string{}))
+}
+
+func TestRunPipeSourceDateEpoch(t *testing.T) {
+	makeCtx := func(t *testing.T, epoch string) *context.Context {
+		t.Helper()
+		folder := testlib.Mktmp(t)
+		dist := filepath.Join(folder, "dist")
+		createFakeBinary(t, dist, "linuxamd64", "mybin")
+		require.NoError(t, os.WriteFile(filepath.Join(folder, "README.md"), []byte("readme"), 0o644))
+		ctx := testctx.NewWithCfg(
+			config.Project{
+				Dist: dist,
+				Env:  []string{"SOURCE_DATE_EPOCH=" + epoch},
+				Archives: []config.Archive{
+					{
+						NameTemplate: "foo",
+						Format:       "zip",
+						Files: []config.File{
+							{Source: "README.*"},
+						},
+					},
+				},
+			},
+			testctx.WithCurrentTag("v0.0.1"),
+		)
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   "linux",
+			Goarch: "amd64",
+			Name:   "mybin",
+			Path:   filepath.Join(dist, "linuxamd64", "mybin"),
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraBinary: "mybin",
+				artifact.ExtraID:     "default",
+			},
+		})
+		return ctx
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := makeCtx(t, "1704164645")
+		require.NoError(t, Pipe{}.Run(ctx))
+
+		r, err := zip.OpenReader(filepath.Join(ctx.Config.Dist, "foo.zip"))
+		require.NoError(t, err)
+		defer r.Close()
+		require.Len(t, r.File, 2)
+		for _, f := range r.File {
+			require.Equal(t, int64(1704164645), f.Modified.Unix(), f.Name)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx := makeCtx(t, "yesterday")
+		require.ErrorContains(t, Pipe{}.Run(ctx), "invalid SOURCE_DATE_EPOCH")
+	})
+}
+
+func TestRunPipeVersionFile(t *testing.T) {
+	for _, format := range []string{"tar.gz", "zip"} {
+		t.Run(format, func(t *testing.T) {
+			folder := t.TempDir()
+			dist := filepath.Join(folder, "dist")
+			createFakeBinary(t, dist, "linuxamd64", "mybin")
+			ctx := testctx.NewWithCfg(
+				config.Project{
+					Dist: dist,
+					Archives: []config.Archive{
+						{
+							NameTemplate:       "foo",
+							Format:             format,
+							WrapInDirectory:    "true",
+							IncludeVersionFile: true,
+						},
+					},
+				},
+				testctx.WithCurrentTag("v1.2.3"),
+				testctx.WithVersion("1.2.3"),
+				testctx.WithCommit("a1b2c3d4"),
+				testctx.WithDate(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+			)
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Goos:   "linux",
+				Goarch: "amd64",
+				Name:   "mybin",
+				Path:   filepath.Join(dist, "linuxamd64", "mybin"),
+				Type:   artifact.Binary,
+				Extra: map[string]interface{}{
+					artifact.ExtraBinary: "mybin",
+					artifact.ExtraID:     "default",
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			path := filepath.Join(dist, "foo."+format)
+			require.ElementsMatch(
+				t,
+				[]string{"foo/version.txt", "foo/mybin"},
+				testlib.LsArchive(t, path, format),
+			)
+			golden.RequireEqualTxt(t, testlib.GetFileFromArchive(t, path, format, "foo/version.txt"))
+		})
+	}
+
+	t.Run("custom", func(t *testing.T) {
+		folder := testlib.Mktmp(t)
+		dist := filepath.Join(folder, "dist")
+		createFakeBinary(t, dist, "darwinarm64", "mybin")
+		ctx := testctx.NewWithCfg(
+			config.Project{
+				ProjectName: "proj",
+				Dist:        dist,
+				Archives: []config.Archive{
+					{
+						NameTemplate:       "foo",
+						Format:             "tar.gz",
+						IncludeVersionFile: true,
+						VersionFile: config.VersionFile{
+							Name:    "{{ .ProjectName }}.version",
+							Content: "{{ .ProjectName }} {{ .Tag }} {{ .Os }}/{{ .Arch }}",
+						},
+					},
+				},
+			},
+			testctx.WithCurrentTag("v1.2.3"),
+		)
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   "darwin",
+			Goarch: "arm64",
+			Name:   "mybin",
+			Path:   filepath.Join(dist, "darwinarm64", "mybin"),
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraBinary: "mybin",
+				artifact.ExtraID:     "default",
+			},
+		})
+		require.NoError(t, Pipe{}.Run(ctx))
+
+		path := filepath.Join(dist, "foo.tar.gz")
+		require.ElementsMatch(
+			t,
+			[]string{"proj.version", "mybin"},
+			testlib.LsArchive(t, path, "tar.gz"),
+		)
+		require.Equal(
+			t,
+			"proj v1.2.3 darwin/arm64",
+			string(testlib.GetFileFromArchive(t, path, "tar.gz", "proj.version")),
+		)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		folder := testlib.Mktmp(t)
+		dist := filepath.Join(folder, "dist")
+		createFakeBinary(t, dist, "linuxamd64", "mybin")
+		ctx := testctx.NewWithCfg(
+			config.Project{
+				Dist: dist,
+				Archives: []config.Archive{
+					{
+						NameTemplate:       "foo",
+						Format:             "zip",
+						IncludeVersionFile: true,
+						VersionFile: config.VersionFile{
+							Name:    "version.txt",
+							Content: "{{ .Nope }}",
+						},
+					},
+				},
+			},
+			testctx.WithCurrentTag("v1.2.3"),
+		)
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   "linux",
+			Goarch: "amd64",
+			Name:   "mybin",
+			Path:   filepath.Join(dist, "linuxamd64", "mybin"),
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraBinary: "mybin",
+				artifact.ExtraID:     "default",
+			},
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
+
+func TestDefaultVersionFile(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Archives: []config.Archive{
+			{ID: "foo"},
+			{ID: "bar", IncludeVersionFile: true},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, config.VersionFile{}, ctx.Config.Archives[0].VersionFile)
+	require.Equal(t, config.VersionFile{
+		Name:    defaultVersionFileName,
+		Content: defaultVersionFileContent,
+	}, ctx.Config.Archives[1].VersionFile)
+}
+
 func TestDefault(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Archives: []config.Archive{},
@@ -1239,3 +1669,257 @@ func TestSkip(t *testing.T) {
 		require.False(t, Pipe{}.Skip(testctx.New()))
 	})
 }
+
+// BenchmarkRun compares creating the archives of many platforms serially
+// with creating them concurrently.
+func BenchmarkRun(b *testing.B) {
+	folder := testlib.Mktmp(b)
+	require.NoError(b, os.WriteFile("README.md", []byte("readme"), 0o644))
+	var binaries []*artifact.Artifact
+	for i := 0; i < 16; i++ {
+		goarch := fmt.Sprintf("arch%d", i)
+		path := filepath.Join(folder, "bins", goarch, "mybin")
+		require.NoError(b, os.MkdirAll(filepath.Dir(path), 0o755))
+		bts := make([]byte, 1<<20)
+		_, err := rand.New(rand.NewSource(int64(i))).Read(bts)
+		require.NoError(b, err)
+		require.NoError(b, os.WriteFile(path, bts, 0o755))
+		binaries = append(binaries, &artifact.Artifact{
+			Goos:   "linux",
+			Goarch: goarch,
+			Name:   "mybin",
+			Path:   path,
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraBinary: "mybin",
+				artifact.ExtraID:     "default",
+			},
+		})
+	}
+
+	var runs int
+	for name, parallelism := range map[string]int{
+		"serial":   1,
+		"parallel": runtime.GOMAXPROCS(0),
+	} {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				runs++
+				ctx := testctx.NewWithCfg(config.Project{
+					Dist:        filepath.Join(folder, fmt.Sprintf("dist%d", runs)),
+					ProjectName: "foobar",
+					Archives: []config.Archive{
+						{
+							Format: "tar.gz",
+							Files:  []config.File{{Source: "README.md"}},
+						},
+					},
+				})
+				ctx.Parallelism = parallelism
+				require.NoError(b, Pipe{}.Default(ctx))
+				for _, bin := range binaries {
+					ctx.Artifacts.Add(bin)
+				}
+				b.StartTimer()
+
+				require.NoError(b, Pipe{}.Run(ctx))
+			}
+		})
+	}
+}
+
+func TestArchiveOptions(t *testing.T) {
+	entry := log.WithField("archive", "test")
+	require.Empty(t, archiveOptions(config.Archive{}, "tar.gz", entry))
+	require.Len(t, archiveOptions(config.Archive{PreserveXattrs: true}, "tar.gz", entry), 1)
+	require.Len(t, archiveOptions(config.Archive{PreserveXattrs: true}, "tar", entry), 1)
+	require.Empty(t, archiveOptions(config.Archive{PreserveXattrs: true}, "zip", entry))
+	require.Empty(t, archiveOptions(config.Archive{PreserveXattrs: true}, "gz", entry))
+	require.Len(t, archiveOptions(config.Archive{Dedupe: "hardlink"}, "tar.zst", entry), 1)
+	require.Len(t, archiveOptions(config.Archive{Dedupe: "hardlink", PreserveXattrs: true}, "tgz", entry), 2)
+	require.Empty(t, archiveOptions(config.Archive{Dedupe: "hardlink"}, "zip", entry))
+}
+
+func TestDefaultInvalidDedupe(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Archives: []config.Archive{{Dedupe: "symlink"}},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), `invalid archive dedupe "symlink", valid options are "hardlink"`)
+}
+
+func TestRunPipeWrapInDirectoryForms(t *testing.T) {
+	for name, wrap := range map[string]string{
+		"boolean":  "true",
+		"template": "{{ .ProjectName }}-{{ .Version }}-{{ .Os }}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			folder := t.TempDir()
+			dist := filepath.Join(folder, "dist")
+			createFakeBinary(t, dist, "linuxamd64", "mybin")
+			ctx := testctx.NewWithCfg(
+				config.Project{
+					ProjectName: "foo",
+					Dist:        dist,
+					Archives: []config.Archive{
+						{
+							NameTemplate:    "{{ .ProjectName }}_{{ .Os }}",
+							Format:          "tar.gz",
+							WrapInDirectory: wrap,
+						},
+					},
+				},
+				testctx.WithCurrentTag("v1.2.3"),
+				testctx.WithVersion("1.2.3"),
+			)
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Goos:   "linux",
+				Goarch: "amd64",
+				Name:   "mybin",
+				Path:   filepath.Join(dist, "linuxamd64", "mybin"),
+				Type:   artifact.Binary,
+				Extra: map[string]interface{}{
+					artifact.ExtraBinary: "mybin",
+					artifact.ExtraID:     "default",
+				},
+			})
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			files := testlib.LsArchive(t, filepath.Join(dist, "foo_linux.tar.gz"), "tar.gz")
+			sort.Strings(files)
+			golden.RequireEqualTxt(t, []byte(strings.Join(files, "\n")+"\n"))
+		})
+	}
+}
+
+func TestRunPipeAfterHooks(t *testing.T) {
+	makeCtx := func(t *testing.T, hooks config.Hooks) (*context.Context, string) {
+		t.Helper()
+		folder := t.TempDir()
+		dist := filepath.Join(folder, "dist")
+		createFakeBinary(t, dist, "linuxamd64", "mybin")
+		ctx := testctx.NewWithCfg(
+			config.Project{
+				ProjectName: "foo",
+				Dist:        dist,
+				Archives: []config.Archive{
+					{
+						NameTemplate: "{{ .ProjectName }}_{{ .Os }}",
+						Format:       "tar.gz",
+						Hooks:        config.ArchiveHooks{After: hooks},
+					},
+				},
+			},
+			testctx.WithCurrentTag("v1.2.3"),
+		)
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   "linux",
+			Goarch: "amd64",
+			Name:   "mybin",
+			Path:   filepath.Join(dist, "linuxamd64", "mybin"),
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraBinary: "mybin",
+				artifact.ExtraID:     "default",
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		return ctx, dist
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctx, dist := makeCtx(t, config.Hooks{
+			{Cmd: "cp {{ .ArtifactPath }} {{ .ArtifactPath }}.copy"},
+			{
+				Cmd: "touch {{ .Env.SIG }}",
+				Dir: "{{ dir .ArtifactPath }}",
+				Env: []string{"SIG={{ .ArtifactName }}.sig"},
+			},
+		})
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Len(t, ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List(), 1)
+
+		// the archive was complete when the hook ran
+		require.Equal(
+			t,
+			[]string{"mybin"},
+			testlib.LsArchive(t, filepath.Join(dist, "foo_linux.tar.gz.copy"), "tar.gz"),
+		)
+		require.FileExists(t, filepath.Join(dist, "foo_linux.tar.gz.sig"))
+	})
+
+	t.Run("failing hook", func(t *testing.T) {
+		ctx, _ := makeCtx(t, config.Hooks{{Cmd: "false"}})
+		require.ErrorContains(t, Pipe{}.Run(ctx), "after hook failed: shell: 'false'")
+		require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List())
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		for _, hook := range []config.Hook{
+			{Cmd: "echo {{ .Nope }}"},
+			{Cmd: "echo", Dir: "{{ .Nope }}"},
+			{Cmd: "echo", Env: []string{"A={{ .Nope }}"}},
+		} {
+			ctx, _ := makeCtx(t, config.Hooks{hook})
+			testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+		}
+	})
+}
+
+func TestRunPipeBinarySignatures(t *testing.T) {
+	folder := t.TempDir()
+	dist := filepath.Join(folder, "dist")
+	createFakeBinary(t, dist, "linuxamd64", "bin/mybin")
+	createFakeBinary(t, dist, "linuxamd64", "bin/mybin.sig")
+	createFakeBinary(t, dist, "darwinamd64", "bin/mybin")
+	createFakeBinary(t, dist, "darwinamd64", "bin/mybin.sig")
+	ctx := testctx.NewWithCfg(
+		config.Project{
+			Dist: dist,
+			Archives: []config.Archive{
+				{
+					NameTemplate: "foo_{{ .Os }}",
+					Format:       "tar.gz",
+					Files:        []config.File{{Source: "none*"}},
+				},
+			},
+		},
+		testctx.WithCurrentTag("v1.2.3"),
+	)
+	for _, goos := range []string{"linux", "darwin"} {
+		binPath := filepath.Join(dist, goos+"amd64", "bin", "mybin")
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   goos,
+			Goarch: "amd64",
+			Name:   "bin/mybin",
+			Path:   binPath,
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraBinary: "mybin",
+				artifact.ExtraID:     "default",
+			},
+		})
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Goos:   goos,
+			Goarch: "amd64",
+			Name:   "bin/mybin.sig",
+			Path:   binPath + ".sig",
+			Type:   artifact.BinarySignature,
+			Extra: map[string]interface{}{
+				artifact.ExtraID:          "default",
+				artifact.ExtraSignatureOf: binPath,
+			},
+		})
+	}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	for _, goos := range []string{"linux", "darwin"} {
+		require.ElementsMatch(
+			t,
+			[]string{"bin/mybin", "bin/mybin.sig"},
+			testlib.LsArchive(t, filepath.Join(dist, "foo_"+goos+".tar.gz"), "tar.gz"),
+		)
+	}
+}
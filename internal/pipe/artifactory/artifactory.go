@@ -9,6 +9,7 @@ import (
 
 	"github.com/goreleaser/goreleaser/v2/internal/http"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -16,6 +17,7 @@ import (
 type Pipe struct{}
 
 func (Pipe) String() string                 { return "artifactory" }
+func (Pipe) Key() string                    { return "artifactory" }
 func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.Artifactories) == 0 }
 
 // Default sets the pipe defaults.
@@ -26,6 +28,11 @@ func (Pipe) Default(ctx *context.Context) error {
 		}
 		ctx.Config.Artifactories[i].Method = h.MethodPut
 	}
+	for i := range ctx.Config.ArtifactoryBuildInfos {
+		if ctx.Config.ArtifactoryBuildInfos[i].Name == "" {
+			ctx.Config.ArtifactoryBuildInfos[i].Name = "{{ .ProjectName }}"
+		}
+	}
 	return http.Defaults(ctx.Config.Artifactories)
 }
 
@@ -41,7 +48,61 @@ func (Pipe) Publish(ctx *context.Context) error {
 		}
 	}
 
-	return http.Upload(ctx, ctx.Config.Artifactories, "artifactory", checkResponse)
+	// Build infos are computed before uploading, so missing build
+	// identifiers are reported before anything is published, and their
+	// sha256 checksums are reused by the uploads.
+	infos := make([]buildInfo, 0, len(ctx.Config.ArtifactoryBuildInfos))
+	sums := map[string]hashes{}
+	for _, cfg := range ctx.Config.ArtifactoryBuildInfos {
+		instance, err := findInstance(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		artifacts, err := http.Artifacts(ctx, &instance, "artifactory")
+		if err != nil {
+			return err
+		}
+		if err := computeHashes(sums, artifacts); err != nil {
+			return err
+		}
+		info, err := newBuildInfo(ctx, cfg, artifacts, sums)
+		if err != nil {
+			return err
+		}
+		infos = append(infos, info)
+	}
+
+	sha256s := make(map[string]string, len(sums))
+	for path, sum := range sums {
+		sha256s[path] = sum.sha256
+	}
+
+	// instances skipped for having no artifacts don't stop the others.
+	uploadErr := http.UploadWithChecksums(ctx, ctx.Config.Artifactories, "artifactory", checkResponse, sha256s)
+	if uploadErr != nil && !pipe.IsSkip(uploadErr) {
+		return uploadErr
+	}
+
+	for i, cfg := range ctx.Config.ArtifactoryBuildInfos {
+		instance, err := findInstance(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		if err := publishBuildInfo(ctx, instance, cfg, infos[i]); err != nil {
+			return err
+		}
+	}
+	return uploadErr
+}
+
+// findInstance returns the artifactory the given build info is published to.
+func findInstance(ctx *context.Context, cfg config.ArtifactoryBuildInfo) (config.Upload, error) {
+	for _, instance := range ctx.Config.Artifactories {
+		if instance.Name == cfg.Artifactory {
+			return instance, nil
+		}
+	}
+	return config.Upload{}, fmt.Errorf("artifactory: build_info: no artifactory named %q", cfg.Artifactory)
 }
 
 // An ErrorResponse reports one or more errors caused by an API request.
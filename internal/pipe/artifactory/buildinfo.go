@@ -0,0 +1,229 @@
+package artifactory
+
+import (
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	h "net/http"
+	"os"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/http"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// buildInfo is the build info record, as documented in
+// https://jfrog.com/help/r/jfrog-rest-apis/build-upload
+type buildInfo struct {
+	Version string            `json:"version"`
+	Name    string            `json:"name"`
+	Number  string            `json:"number"`
+	Started string            `json:"started"`
+	URL     string            `json:"url,omitempty"`
+	Agent   buildInfoAgent    `json:"agent"`
+	VCS     []buildInfoVCS    `json:"vcs,omitempty"`
+	Modules []buildInfoModule `json:"modules"`
+}
+
+type buildInfoAgent struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type buildInfoVCS struct {
+	Revision string `json:"revision"`
+	URL      string `json:"url,omitempty"`
+}
+
+type buildInfoModule struct {
+	ID        string              `json:"id"`
+	Artifacts []buildInfoArtifact `json:"artifacts"`
+}
+
+type buildInfoArtifact struct {
+	Name   string `json:"name"`
+	Type   string `json:"type,omitempty"`
+	Size   int64  `json:"size"`
+	SHA1   string `json:"sha1"`
+	SHA256 string `json:"sha256"`
+	MD5    string `json:"md5"`
+}
+
+// hashes are the checksums of a file, as needed by the build info.
+type hashes struct {
+	size   int64
+	sha1   string
+	sha256 string
+	md5    string
+}
+
+// ciBuildNumbers are the environment variables holding the build number in
+// the supported CI systems, in order of precedence.
+var ciBuildNumbers = []string{
+	"BUILD_NUMBER",           // Jenkins, TeamCity
+	"GITHUB_RUN_NUMBER",      // GitHub Actions
+	"CI_PIPELINE_IID",        // GitLab CI
+	"CIRCLE_BUILD_NUM",       // CircleCI
+	"BUILDKITE_BUILD_NUMBER", // Buildkite
+}
+
+// ciBuildURLs are the environment variables holding the build URL in the
+// supported CI systems, in order of precedence.
+var ciBuildURLs = []string{
+	"BUILD_URL",           // Jenkins
+	"CI_PIPELINE_URL",     // GitLab CI
+	"CIRCLE_BUILD_URL",    // CircleCI
+	"BUILDKITE_BUILD_URL", // Buildkite
+}
+
+// computeHashes adds the hashes of the given artifacts to sums, reading each
+// file once.
+func computeHashes(sums map[string]hashes, artifacts []*artifact.Artifact) error {
+	for _, a := range artifacts {
+		if _, ok := sums[a.Path]; ok {
+			continue
+		}
+		sum, err := hashFile(a.Path)
+		if err != nil {
+			return err
+		}
+		sums[a.Path] = sum
+	}
+	return nil
+}
+
+//nolint:gosec
+func hashFile(path string) (hashes, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return hashes{}, fmt.Errorf("failed to checksum: %w", err)
+	}
+	defer f.Close()
+	s1, s256, m5 := sha1.New(), sha256.New(), md5.New()
+	size, err := io.Copy(io.MultiWriter(s1, s256, m5), f)
+	if err != nil {
+		return hashes{}, fmt.Errorf("failed to checksum: %w", err)
+	}
+	return hashes{
+		size:   size,
+		sha1:   hex.EncodeToString(s1.Sum(nil)),
+		sha256: hex.EncodeToString(s256.Sum(nil)),
+		md5:    hex.EncodeToString(m5.Sum(nil)),
+	}, nil
+}
+
+func newBuildInfo(ctx *context.Context, cfg config.ArtifactoryBuildInfo, artifacts []*artifact.Artifact, sums map[string]hashes) (buildInfo, error) {
+	var values []string
+	for _, s := range []string{cfg.Name, cfg.Number, cfg.URL} {
+		v, err := tmpl.New(ctx).Apply(s)
+		if err != nil {
+			return buildInfo{}, fmt.Errorf("artifactory: %s: build_info: %w", cfg.Artifactory, err)
+		}
+		values = append(values, strings.TrimSpace(v))
+	}
+	name, number, url := values[0], values[1], values[2]
+	if number == "" {
+		number = firstEnv(ctx, ciBuildNumbers)
+	}
+	if url == "" {
+		url = ciBuildURL(ctx)
+	}
+	if cfg.Target == "" {
+		return buildInfo{}, fmt.Errorf("artifactory: %s: build_info: missing target", cfg.Artifactory)
+	}
+	if name == "" {
+		return buildInfo{}, fmt.Errorf("artifactory: %s: build_info: missing build name", cfg.Artifactory)
+	}
+	if number == "" {
+		return buildInfo{}, fmt.Errorf("artifactory: %s: build_info: missing build number, set its number or run in a supported CI", cfg.Artifactory)
+	}
+
+	info := buildInfo{
+		Version: "1.0.1",
+		Name:    name,
+		Number:  number,
+		Started: ctx.Date.UTC().Format("2006-01-02T15:04:05.000-0700"),
+		URL:     url,
+		Agent: buildInfoAgent{
+			Name:    "goreleaser",
+			Version: ctx.Version,
+		},
+		Modules: []buildInfoModule{
+			{
+				ID:        ctx.Config.ProjectName + ":" + ctx.Version,
+				Artifacts: []buildInfoArtifact{},
+			},
+		},
+	}
+	if ctx.Git.FullCommit != "" {
+		info.VCS = []buildInfoVCS{{Revision: ctx.Git.FullCommit, URL: ctx.Git.URL}}
+	}
+	for _, a := range artifacts {
+		sum := sums[a.Path]
+		info.Modules[0].Artifacts = append(info.Modules[0].Artifacts, buildInfoArtifact{
+			Name:   a.Name,
+			Type:   strings.TrimPrefix(artifact.ExtraOr(*a, artifact.ExtraExt, ""), "."),
+			Size:   sum.size,
+			SHA1:   sum.sha1,
+			SHA256: sum.sha256,
+			MD5:    sum.md5,
+		})
+	}
+	return info, nil
+}
+
+// publishBuildInfo uploads the given build info.
+//
+// Docs: https://jfrog.com/help/r/jfrog-rest-apis/build-upload
+func publishBuildInfo(ctx *context.Context, instance config.Upload, cfg config.ArtifactoryBuildInfo, info buildInfo) error {
+	target, err := tmpl.New(ctx).Apply(cfg.Target)
+	if err != nil {
+		return fmt.Errorf("artifactory: %s: build_info: %w", instance.Name, err)
+	}
+	bts, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	url := strings.TrimSuffix(target, "/") + "/api/build"
+	if err := http.Send(ctx, &instance, "artifactory", h.MethodPut, url, map[string]string{
+		"Content-Type": "application/json",
+	}, bts, checkResponse); err != nil {
+		return fmt.Errorf("artifactory: %s: build_info: %w", instance.Name, err)
+	}
+	log.WithField("instance", instance.Name).
+		WithField("build", info.Name+"/"+info.Number).
+		Info("published build info")
+	return nil
+}
+
+func ciBuildURL(ctx *context.Context) string {
+	if url := firstEnv(ctx, ciBuildURLs); url != "" {
+		return url
+	}
+	if ctx.Env["GITHUB_RUN_ID"] != "" {
+		return fmt.Sprintf(
+			"%s/%s/actions/runs/%s",
+			ctx.Env["GITHUB_SERVER_URL"],
+			ctx.Env["GITHUB_REPOSITORY"],
+			ctx.Env["GITHUB_RUN_ID"],
+		)
+	}
+	return ""
+}
+
+func firstEnv(ctx *context.Context, keys []string) string {
+	for _, key := range keys {
+		if v := ctx.Env[key]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
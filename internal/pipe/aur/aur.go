@@ -35,6 +35,7 @@ var ErrNoArchivesFound = errors.New("no linux archives found")
 type Pipe struct{}
 
 func (Pipe) String() string        { return "arch user repositories" }
+func (Pipe) Key() string           { return "aur" }
 func (Pipe) ContinueOnError() bool { return true }
 func (Pipe) Skip(ctx *context.Context) bool {
 	return skips.Any(ctx, skips.AUR) || len(ctx.Config.AURs) == 0
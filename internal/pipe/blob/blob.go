@@ -4,6 +4,7 @@ package blob
 import (
 	"fmt"
 
+	"github.com/goreleaser/goreleaser/v2/internal/ifempty"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe"
 	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
@@ -15,6 +16,7 @@ type Pipe struct{}
 
 // String returns the description of the pipe.
 func (Pipe) String() string                 { return "blobs" }
+func (Pipe) Key() string                    { return "blob" }
 func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.Blobs) == 0 }
 
 // Default sets the pipe defaults.
@@ -34,14 +36,18 @@ func (Pipe) Default(ctx *context.Context) error {
 		case "-":
 			blob.ContentDisposition = ""
 		}
+		if err := ifempty.Validate(blob.IfEmpty); err != nil {
+			return fmt.Errorf("blobs: %w", err)
+		}
 	}
 	return nil
 }
 
 // Publish to specified blob bucket url.
 func (Pipe) Publish(ctx *context.Context) error {
-	g := semerrgroup.New(ctx.Parallelism)
+	g := semerrgroup.NewShared(ctx)
 	skips := pipe.SkipMemento{}
+	conts := pipe.ContinueMemento{}
 	for _, conf := range ctx.Config.Blobs {
 		g.Go(func() error {
 			b, err := tmpl.New(ctx).Bool(conf.Disable)
@@ -52,11 +58,19 @@ func (Pipe) Publish(ctx *context.Context) error {
 				skips.Remember(pipe.Skip("configuration is disabled"))
 				return nil
 			}
-			return doUpload(ctx, conf)
+			err = doUpload(ctx, conf)
+			if pipe.IsSkip(err) {
+				skips.Remember(err)
+				return nil
+			}
+			return conts.Remember(conf.ContinueOnError, err)
 		})
 	}
 	if err := g.Wait(); err != nil {
 		return err
 	}
+	if err := conts.Evaluate(); err != nil {
+		return err
+	}
 	return skips.Evaluate()
 }
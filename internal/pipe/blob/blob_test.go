@@ -4,10 +4,15 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/stretchr/testify/require"
+	_ "gocloud.dev/blob/memblob"
 )
 
 func TestDescription(t *testing.T) {
@@ -230,3 +235,142 @@ func TestSkip(t *testing.T) {
 		require.False(t, Pipe{}.Skip(ctx))
 	})
 }
+
+func TestUploadContentType(t *testing.T) {
+	ctx := testctx.New()
+	up := &productionUploader{
+		contentTypes: map[string]string{
+			"*.sbom": "application/spdx+json",
+		},
+	}
+	require.NoError(t, up.Open(ctx, "mem://"))
+	t.Cleanup(func() { require.NoError(t, up.Close()) })
+
+	for name, expected := range map[string]string{
+		"foo/bar_linux_amd64.tar.gz": "application/gzip",
+		"foo/metadata.json":          "application/json",
+		"foo/bar.sbom":               "application/spdx+json",
+		"foo/bar_linux_amd64":        "application/octet-stream",
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, up.Upload(ctx, name, []byte{0x7f, 'E', 'L', 'F', 0x00}))
+			attrs, err := up.bucket.Attributes(ctx, name)
+			require.NoError(t, err)
+			require.Equal(t, expected, attrs.ContentType)
+		})
+	}
+}
+
+func TestArtifactListByIDs(t *testing.T) {
+	ctx := testctx.New()
+	for _, a := range []struct {
+		name, id string
+		typ      artifact.Type
+	}{
+		{"cli.tar.gz", "cli", artifact.UploadableArchive},
+		{"cli.deb", "cli", artifact.LinuxPackage},
+		{"daemon.tar.gz", "daemon", artifact.UploadableArchive},
+		{"daemon.deb", "daemon", artifact.LinuxPackage},
+		{"source.tar.gz", "", artifact.UploadableSourceArchive},
+		{"checksums.txt", "", artifact.Checksum},
+	} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: a.typ,
+			Name: a.name,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: a.id,
+			},
+		})
+	}
+
+	names := func(conf config.Blob) []string {
+		var result []string
+		for _, a := range artifactList(ctx, conf) {
+			result = append(result, a.Name)
+		}
+		return result
+	}
+
+	require.ElementsMatch(t, []string{
+		"cli.tar.gz", "cli.deb", "source.tar.gz", "checksums.txt",
+	}, names(config.Blob{IDs: []string{"cli"}}))
+	require.ElementsMatch(t, []string{
+		"daemon.tar.gz", "daemon.deb", "source.tar.gz", "checksums.txt",
+	}, names(config.Blob{IDs: []string{"daemon"}}))
+	require.Len(t, names(config.Blob{}), 6)
+}
+
+func TestOpenBucketCABundle(t *testing.T) {
+	cert, _ := testlib.GenerateCert(t)
+
+	t.Run("s3", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			HTTP: config.HTTP{CABundle: cert},
+		})
+		bucket, err := openBucket(ctx, "s3://foo?region=us-east-1", httpclient.ProxyOptions{})
+		require.NoError(t, err)
+		require.NoError(t, bucket.Close())
+	})
+
+	t.Run("invalid bundle", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			HTTP: config.HTTP{CABundle: "nope.pem"},
+		})
+		_, err := openBucket(ctx, "s3://foo?region=us-east-1", httpclient.ProxyOptions{})
+		require.ErrorContains(t, err, "ca_bundle")
+	})
+}
+
+func TestDefaultsInvalidIfEmpty(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Blobs: []config.Blob{
+			{
+				Bucket:   "foo",
+				Provider: "s3",
+				IfEmpty:  "nope",
+			},
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), `blobs: invalid if_empty "nope", valid options are ["warn" "skip" "error"]`)
+}
+
+func TestPublishIfEmpty(t *testing.T) {
+	makeCtx := func(ifEmpty string) *context.Context {
+		ctx := testctx.NewWithCfg(config.Project{
+			Blobs: []config.Blob{
+				{
+					Bucket:   "foo",
+					Provider: "mem",
+					IDs:      []string{"nope"},
+					IfEmpty:  ifEmpty,
+				},
+			},
+		}, testctx.WithCurrentTag("v1.0.0"))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: artifact.UploadableArchive,
+			Name: "foo.tar.gz",
+			Path: "foo.tar.gz",
+			Extra: map[string]interface{}{
+				artifact.ExtraID: "foo",
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		return ctx
+	}
+
+	t.Run("warn", func(t *testing.T) {
+		require.NoError(t, Pipe{}.Publish(makeCtx("")))
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		err := Pipe{}.Publish(makeCtx("skip"))
+		require.True(t, pipe.IsSkip(err))
+		require.EqualError(t, err, "blobs mem://foo: no artifacts matched the given filters")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := Pipe{}.Publish(makeCtx("error"))
+		require.False(t, pipe.IsSkip(err))
+		require.ErrorContains(t, err, "blobs mem://foo: no artifacts matched the given filters")
+	})
+}
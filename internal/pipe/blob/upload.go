@@ -3,27 +3,32 @@ package blob
 import (
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/contenttype"
 	"github.com/goreleaser/goreleaser/v2/internal/extrafiles"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/ifempty"
 	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"gocloud.dev/blob"
+	"gocloud.dev/blob/s3blob"
 	"gocloud.dev/secrets"
 
 	// Import the blob packages we want to be able to open.
 	_ "gocloud.dev/blob/azureblob"
 	_ "gocloud.dev/blob/gcsblob"
-	_ "gocloud.dev/blob/s3blob"
 
 	// import the secrets packages we want to be able to be used.
 	_ "gocloud.dev/secrets/awskms"
@@ -99,6 +104,11 @@ func doUpload(ctx *context.Context, conf config.Blob) error {
 	up := &productionUploader{
 		cacheControl:       conf.CacheControl,
 		contentDisposition: conf.ContentDisposition,
+		contentTypes:       conf.ContentTypes,
+		proxy: httpclient.ProxyOptions{
+			URL:     conf.Proxy,
+			NoProxy: conf.NoProxy,
+		},
 	}
 	if conf.Provider == "s3" && conf.ACL != "" {
 		up.beforeWrite = func(asFunc func(interface{}) bool) error {
@@ -111,13 +121,23 @@ func doUpload(ctx *context.Context, conf config.Blob) error {
 		}
 	}
 
+	artifacts := artifactList(ctx, conf)
+	files, err := extrafiles.Find(ctx, conf.ExtraFiles)
+	if err != nil {
+		return err
+	}
+	if len(artifacts) == 0 && len(files) == 0 {
+		bucket, _, _ := strings.Cut(bucketURL, "?")
+		return ifempty.Handle(conf.IfEmpty, "blobs", bucket)
+	}
+
 	if err := up.Open(ctx, bucketURL); err != nil {
 		return handleError(err, bucketURL)
 	}
 	defer up.Close()
 
-	g := semerrgroup.New(ctx.Parallelism)
-	for _, artifact := range artifactList(ctx, conf) {
+	g := semerrgroup.NewShared(ctx)
+	for _, artifact := range artifacts {
 		g.Go(func() error {
 			// TODO: replace this with ?prefix=folder on the bucket url
 			dataFile := artifact.Path
@@ -127,10 +147,6 @@ func doUpload(ctx *context.Context, conf config.Blob) error {
 		})
 	}
 
-	files, err := extrafiles.Find(ctx, conf.ExtraFiles)
-	if err != nil {
-		return err
-	}
 	for name, fullpath := range files {
 		g.Go(func() error {
 			uploadFile := path.Join(dir, name)
@@ -153,6 +169,9 @@ func artifactList(ctx *context.Context, conf config.Blob) []*artifact.Artifact {
 		artifact.ByType(artifact.Signature),
 		artifact.ByType(artifact.Certificate),
 		artifact.ByType(artifact.LinuxPackage),
+		artifact.ByType(artifact.Flatpak),
+		artifact.ByType(artifact.AppImage),
+		artifact.ByType(artifact.Installer),
 		artifact.ByType(artifact.SBOM),
 	}
 	if conf.IncludeMeta {
@@ -242,6 +261,8 @@ type productionUploader struct {
 	beforeWrite        func(asFunc func(interface{}) bool) error
 	cacheControl       []string
 	contentDisposition string
+	contentTypes       map[string]string
+	proxy              httpclient.ProxyOptions
 }
 
 func (u *productionUploader) Close() error {
@@ -254,7 +275,7 @@ func (u *productionUploader) Close() error {
 func (u *productionUploader) Open(ctx *context.Context, bucket string) error {
 	log.WithField("bucket", bucket).Debug("uploading")
 
-	conn, err := blob.OpenBucket(ctx, bucket)
+	conn, err := openBucket(ctx, bucket, u.proxy)
 	if err != nil {
 		return err
 	}
@@ -262,6 +283,35 @@ func (u *productionUploader) Open(ctx *context.Context, bucket string) error {
 	return nil
 }
 
+// openBucket opens the given bucket URL.
+//
+// S3 buckets are opened with the shared HTTP transport, so they trust the CA
+// bundle, if any, use the given proxy, and reuse their connections.
+func openBucket(ctx *context.Context, bucketURL string, proxy httpclient.ProxyOptions) (*blob.Bucket, error) {
+	u, err := url.Parse(bucketURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != s3blob.Scheme {
+		return blob.OpenBucket(ctx, bucketURL)
+	}
+	transport, err := httpclient.Transport(ctx, httpclient.Options{Proxy: proxy})
+	if err != nil {
+		return nil, err
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config: aws.Config{
+			HTTPClient: &http.Client{Transport: transport},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	opener := &s3blob.URLOpener{ConfigProvider: sess}
+	return opener.OpenBucketURL(ctx, u)
+}
+
 func (u *productionUploader) Upload(ctx *context.Context, filepath string, data []byte) error {
 	log.WithField("path", filepath).Info("uploading")
 
@@ -272,7 +322,13 @@ func (u *productionUploader) Upload(ctx *context.Context, filepath string, data
 		return err
 	}
 
+	typ, err := contenttype.Detect(filepath, data, u.contentTypes)
+	if err != nil {
+		return err
+	}
+
 	opts := &blob.WriterOptions{
+		ContentType:        typ,
 		ContentDisposition: disp,
 		BeforeWrite:        u.beforeWrite,
 		CacheControl:       strings.Join(u.cacheControl, ", "),
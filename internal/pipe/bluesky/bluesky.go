@@ -11,8 +11,11 @@ import (
 	butil "github.com/bluesky-social/indigo/util"
 	"github.com/bluesky-social/indigo/xrpc"
 	"github.com/caarlos0/env/v11"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/hashicorp/go-retryablehttp"
 )
 
 const (
@@ -22,23 +25,35 @@ const (
 
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "bluesky" }
-func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Announce.Bluesky.Enabled }
+func (Pipe) String() string                        { return "bluesky" }
+func (Pipe) Key() string                           { return "bluesky" }
+func (Pipe) Len(ctx *context.Context) int          { return len(ctx.Config.Announce.Bluesky) }
+func (Pipe) Skip(ctx *context.Context, i int) bool { return !ctx.Config.Announce.Bluesky[i].Enabled }
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.Bluesky[i].AnnounceConditions
+}
 
 type Config struct {
 	Password string `env:"BLUESKY_APP_PASSWORD,notEmpty"`
 }
 
 func (Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.Bluesky.MessageTemplate == "" {
-		ctx.Config.Announce.Bluesky.MessageTemplate = defaultMessageTemplate
+	for i := range ctx.Config.Announce.Bluesky {
+		conf := &ctx.Config.Announce.Bluesky[i]
+		if conf.MessageTemplate == "" && conf.MessageTemplateFile == "" {
+			conf.MessageTemplate = defaultMessageTemplate
+		}
 	}
-
 	return nil
 }
 
-func (p Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Bluesky.MessageTemplate)
+func (p Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.Bluesky[i]
+	msg, err := tmpl.New(ctx).ApplyInlineOrFile(
+		conf.MessageTemplate,
+		conf.MessageTemplateFile,
+	)
 	if err != nil {
 		return fmt.Errorf("bluesky: %w", err)
 	}
@@ -73,17 +88,13 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		}
 	}
 
-	httpClient := butil.RobustHTTPClient()
-	userAgent := "goreleaser/v1"
-
-	xrpcClient := &xrpc.Client{
-		Client:    httpClient,
-		Host:      defaultPDSURL,
-		UserAgent: &userAgent,
+	xrpcClient, err := newClient(ctx, defaultPDSURL)
+	if err != nil {
+		return fmt.Errorf("bluesky: %w", err)
 	}
 
 	loginInput := &atproto.ServerCreateSession_Input{
-		Identifier: ctx.Config.Announce.Bluesky.Username,
+		Identifier: conf.Username,
 		Password:   cfg.Password,
 	}
 
@@ -109,3 +120,25 @@ func (p Pipe) Announce(ctx *context.Context) error {
 
 	return err
 }
+
+// newClient returns a XRPC client for the given host, retrying requests the
+// same way as indigo's RobustHTTPClient, on top of the shared HTTP client.
+func newClient(ctx *context.Context, host string) (*xrpc.Client, error) {
+	httpClient, err := httpclient.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient = httpClient
+	retryClient.RetryMax = 3
+	retryClient.RetryWaitMin = 1 * time.Second
+	retryClient.RetryWaitMax = 10 * time.Second
+	retryClient.Logger = nil
+	retryClient.CheckRetry = butil.XRPCRetryPolicy
+	client := retryClient.StandardClient()
+	client.Timeout = 30 * time.Second
+	return &xrpc.Client{
+		Client: client,
+		Host:   host,
+	}, nil
+}
@@ -1,74 +1,111 @@
-package bluesky_test
+package bluesky
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
-	"github.com/goreleaser/goreleaser/v2/internal/pipe/bluesky"
+	"github.com/bluesky-social/indigo/api/atproto"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestStringer(t *testing.T) {
-	require.Equal(t, "bluesky", bluesky.Pipe{}.String())
+	require.Equal(t, "bluesky", Pipe{}.String())
 }
 
 func TestDefault(t *testing.T) {
-	ctx := testctx.New()
-	require.NoError(t, bluesky.Pipe{}.Default(ctx))
-	require.Equal(t, `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`, ctx.Config.Announce.Bluesky.MessageTemplate)
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			Bluesky: []config.Bluesky{{}},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`, ctx.Config.Announce.Bluesky[0].MessageTemplate)
 }
 
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Bluesky: config.Bluesky{
+			Bluesky: []config.Bluesky{{
 				MessageTemplate: "{{ .Foo }",
-			},
+			}},
 		},
 	})
-	testlib.RequireTemplateError(t, bluesky.Pipe{}.Announce(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
 }
 
 func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Bluesky: config.Bluesky{},
+			Bluesky: []config.Bluesky{{}},
 		},
 	})
-	require.NoError(t, bluesky.Pipe{}.Default(ctx))
-	require.EqualError(t, bluesky.Pipe{}.Announce(ctx), `bluesky: env: environment variable "BLUESKY_APP_PASSWORD" should not be empty`)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `bluesky: env: environment variable "BLUESKY_APP_PASSWORD" should not be empty`)
 }
 
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
-		require.True(t, bluesky.Pipe{}.Skip(testctx.New()))
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Bluesky: []config.Bluesky{{}},
+			},
+		})
+		require.True(t, Pipe{}.Skip(ctx, 0))
 	})
 
 	t.Run("dont skip", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Bluesky: config.Bluesky{
+				Bluesky: []config.Bluesky{{
 					Enabled: true,
-				},
+				}},
 			},
 		})
-		require.False(t, bluesky.Pipe{}.Skip(ctx))
+		require.False(t, Pipe{}.Skip(ctx, 0))
 	})
 }
 
+func TestNewClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "goreleaser/dev (+foo)", r.Header.Get("User-Agent"))
+		assert.Equal(t, "/xrpc/com.atproto.server.createSession", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"accessJwt":"access","refreshJwt":"refresh","handle":"foo","did":"did:plc:foo"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		HTTP: config.HTTP{
+			CABundle: testlib.ServerCert(t, srv),
+		},
+	})
+	client, err := newClient(ctx, srv.URL)
+	require.NoError(t, err)
+	session, err := atproto.ServerCreateSession(ctx, client, &atproto.ServerCreateSession_Input{
+		Identifier: "foo",
+		Password:   "pass",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "did:plc:foo", session.Did)
+}
+
 func TestLive(t *testing.T) {
 	t.SkipNow()
 	t.Setenv("BLUESKY_APP_PASSWORD", "TODO")
 
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Bluesky: config.Bluesky{
+			Bluesky: []config.Bluesky{{
 				MessageTemplate: "This is a sample announcement from the forthcoming {{ .ProjectName }} Bluesky support. View the details at {{ .ReleaseURL }}",
 				Enabled:         true,
 				Username:        "caarlos0.dev",
-			},
+			}},
 		},
 	})
 
@@ -76,6 +113,6 @@ func TestLive(t *testing.T) {
 	ctx.ReleaseURL = "https://goreleaser.com/customization/announce/bluesky"
 	ctx.Version = "v1.26.0"
 
-	require.NoError(t, bluesky.Pipe{}.Default(ctx))
-	require.NoError(t, bluesky.Pipe{}.Announce(ctx))
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx, 0))
 }
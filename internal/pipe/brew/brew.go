@@ -8,11 +8,14 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"text/template"
 
 	"github.com/caarlos0/log"
+	"github.com/charmbracelet/x/exp/ordered"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/client"
 	"github.com/goreleaser/goreleaser/v2/internal/commitauthor"
@@ -46,6 +49,7 @@ func (e ErrNoArchivesFound) Error() string {
 type Pipe struct{}
 
 func (Pipe) String() string        { return "homebrew tap formula" }
+func (Pipe) Key() string           { return "brew" }
 func (Pipe) ContinueOnError() bool { return true }
 func (Pipe) Skip(ctx *context.Context) bool {
 	return skips.Any(ctx, skips.Homebrew) || len(ctx.Config.Brews) == 0
@@ -137,7 +141,14 @@ func doPublish(ctx *context.Context, formula *artifact.Artifact, cl client.Clien
 
 	gpath := buildFormulaPath(brew.Directory, formula.Name)
 
-	msg, err := tmpl.New(ctx).Apply(brew.CommitMessageTemplate)
+	sums, err := artifact.Checksums("sha256", ctx.Artifacts.Filter(archivesFilter(brew)).List())
+	if err != nil {
+		return err
+	}
+
+	msg, err := tmpl.New(ctx).WithExtraFields(tmpl.Fields{
+		"Checksums": sums,
+	}).Apply(brew.CommitMessageTemplate)
 	if err != nil {
 		return err
 	}
@@ -191,7 +202,14 @@ func doPublish(ctx *context.Context, formula *artifact.Artifact, cl client.Clien
 		return fmt.Errorf("client does not support pull requests")
 	}
 
-	return pcl.OpenPullRequest(ctx, base, repo, msg, brew.Repository.PullRequest.Draft)
+	url, err := pcl.OpenPullRequest(ctx, base, repo, msg, brew.Repository.PullRequest.Draft, brew.Repository.PullRequest.AutoMerge)
+	if err != nil {
+		return err
+	}
+	if url != "" {
+		formula.Extra[artifact.ExtraPullRequest] = url
+	}
+	return nil
 }
 
 func doRun(ctx *context.Context, brew config.Homebrew, cl client.ReleaseURLTemplater) error {
@@ -199,37 +217,7 @@ func doRun(ctx *context.Context, brew config.Homebrew, cl client.ReleaseURLTempl
 		return pipe.Skip("brew.repository.name is not set")
 	}
 
-	filters := []artifact.Filter{
-		artifact.Or(
-			artifact.ByGoos("darwin"),
-			artifact.ByGoos("linux"),
-		),
-		artifact.Or(
-			artifact.And(
-				artifact.ByGoarch("amd64"),
-				artifact.ByGoamd64(brew.Goamd64),
-			),
-			artifact.ByGoarch("arm64"),
-			artifact.ByGoarch("all"),
-			artifact.And(
-				artifact.ByGoarch("arm"),
-				artifact.ByGoarm(brew.Goarm),
-			),
-		),
-		artifact.Or(
-			artifact.And(
-				artifact.ByFormats("zip", "tar.gz", "tar.xz"),
-				artifact.ByType(artifact.UploadableArchive),
-			),
-			artifact.ByType(artifact.UploadableBinary),
-		),
-		artifact.OnlyReplacingUnibins,
-	}
-	if len(brew.IDs) > 0 {
-		filters = append(filters, artifact.ByIDs(brew.IDs...))
-	}
-
-	archives := ctx.Artifacts.Filter(artifact.And(filters...)).List()
+	archives := ctx.Artifacts.Filter(archivesFilter(brew)).List()
 	if len(archives) == 0 {
 		return ErrNoArchivesFound{
 			goamd64: brew.Goamd64,
@@ -284,6 +272,41 @@ func doRun(ctx *context.Context, brew config.Homebrew, cl client.ReleaseURLTempl
 	return nil
 }
 
+// archivesFilter filters the archives and binaries a formula installs.
+func archivesFilter(brew config.Homebrew) artifact.Filter {
+	filters := []artifact.Filter{
+		artifact.Or(
+			artifact.ByGoos("darwin"),
+			artifact.ByGoos("linux"),
+		),
+		artifact.Or(
+			artifact.And(
+				artifact.ByGoarch("amd64"),
+				artifact.ByGoamd64(brew.Goamd64),
+			),
+			artifact.ByGoarch("arm64"),
+			artifact.ByGoarch("all"),
+			artifact.And(
+				artifact.ByGoarch("arm"),
+				artifact.ByGoarm(brew.Goarm),
+			),
+		),
+		artifact.Or(
+			artifact.And(
+				artifact.ByFormats("zip", "tar.gz", "tar.xz"),
+				artifact.ByType(artifact.UploadableArchive),
+			),
+			artifact.ByType(artifact.UploadableBinary),
+		),
+		artifact.OnlyReplacingUnibins,
+	}
+	if len(brew.IDs) > 0 {
+		filters = append(filters, artifact.ByIDs(brew.IDs...))
+	}
+
+	return artifact.And(filters...)
+}
+
 func buildFormulaPath(folder, filename string) string {
 	return path.Join(folder, filename)
 }
@@ -366,6 +389,15 @@ func installs(ctx *context.Context, cfg config.Homebrew, art *artifact.Artifact)
 		return append(split(install), split(extraInstall)...), nil
 	}
 
+	if len(cfg.Binaries) > 0 {
+		result, err := binaryInstalls(cfg.Binaries, art)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, docInstalls(ctx, art)...)
+		return append(result, split(extraInstall)...), nil
+	}
+
 	installMap := map[string]bool{}
 	switch art.Type {
 	case artifact.UploadableBinary:
@@ -380,11 +412,67 @@ func installs(ctx *context.Context, cfg config.Homebrew, art *artifact.Artifact)
 
 	result := keys(installMap)
 	sort.Strings(result)
+	result = append(result, docInstalls(ctx, art)...)
 	log.WithField("install", result).Info("guessing install")
 
 	return append(result, split(extraInstall)...), nil
 }
 
+// docInstalls returns the install lines of the shell completions and man
+// pages the archive pipe added to the given archive.
+func docInstalls(ctx *context.Context, art *artifact.Artifact) []string {
+	if art.Type != artifact.UploadableArchive {
+		return nil
+	}
+	docs := artifact.ExtraOr(*art, artifact.ExtraDocs, []string{})
+	var result []string
+	for _, doc := range ctx.Artifacts.Filter(artifact.ByType(artifact.Completion)).List() {
+		if !slices.Contains(docs, "completions/"+doc.Name) {
+			continue
+		}
+		shell := artifact.ExtraOr(*doc, artifact.ExtraShell, "")
+		result = append(result, fmt.Sprintf("%s_completion.install %q", shell, "completions/"+doc.Name))
+	}
+	for _, doc := range ctx.Artifacts.Filter(artifact.ByType(artifact.ManPage)).List() {
+		if !slices.Contains(docs, "manpages/"+doc.Name) {
+			continue
+		}
+		section := artifact.ExtraOr(*doc, artifact.ExtraSection, "1")
+		result = append(result, fmt.Sprintf("man%s.install %q", section, "manpages/"+doc.Name))
+	}
+	return result
+}
+
+// binaryInstalls returns the install lines of the given binaries, making sure
+// they are available in the artifact.
+func binaryInstalls(binaries []config.HomebrewBinary, art *artifact.Artifact) ([]string, error) {
+	available := map[string]string{}
+	switch art.Type {
+	case artifact.UploadableBinary:
+		// binaries are uploaded with the artifact name, not the binary one.
+		available[artifact.ExtraOr(*art, artifact.ExtraBinary, art.Name)] = art.Name
+	case artifact.UploadableArchive:
+		for _, bin := range artifact.ExtraOr(*art, artifact.ExtraBinaries, []string{}) {
+			available[bin] = bin
+		}
+	}
+
+	result := make([]string, 0, len(binaries))
+	for _, bin := range binaries {
+		src, ok := available[bin.Name]
+		if !ok {
+			return nil, fmt.Errorf("brew: binary %q not found in %s", bin.Name, art.Name)
+		}
+		dst := ordered.First(bin.Rename, bin.Name)
+		if src == dst {
+			result = append(result, fmt.Sprintf("bin.install %q", src))
+			continue
+		}
+		result = append(result, fmt.Sprintf("bin.install %q => %q", src, dst))
+	}
+	return result, nil
+}
+
 func keys(m map[string]bool) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
@@ -393,10 +481,105 @@ func keys(m map[string]bool) []string {
 	return keys
 }
 
+// testsFor returns the lines of the formula test block.
+// If no test is configured, it defaults to running the first binary with
+// `--version`.
+func testsFor(cfg config.Homebrew, artifacts []*artifact.Artifact) []string {
+	if tests := split(cfg.Test); len(tests) > 0 {
+		return tests
+	}
+	if len(cfg.Binaries) > 0 {
+		bin := cfg.Binaries[0]
+		return []string{fmt.Sprintf(`system "#{bin}/%s", "--version"`, ordered.First(bin.Rename, bin.Name))}
+	}
+	for _, art := range artifacts {
+		var bins []string
+		switch art.Type {
+		case artifact.UploadableBinary:
+			bins = []string{artifact.ExtraOr(*art, artifact.ExtraBinary, art.Name)}
+		case artifact.UploadableArchive:
+			bins = artifact.ExtraOr(*art, artifact.ExtraBinaries, []string{})
+		}
+		if len(bins) > 0 {
+			return []string{fmt.Sprintf(`system "#{bin}/%s", "--version"`, bins[0])}
+		}
+	}
+	return []string{}
+}
+
+// serviceFor returns the lines of the formula service block.
+// The raw block is used as is, otherwise it's generated from the structured
+// fields.
+func serviceFor(service config.HomebrewService) ([]string, error) {
+	if service.Block != "" {
+		return split(service.Block), nil
+	}
+	if reflect.ValueOf(service).IsZero() {
+		return []string{}, nil
+	}
+	if len(service.Run) == 0 {
+		return nil, fmt.Errorf("brew.service.run is required")
+	}
+
+	args := []string{fmt.Sprintf(`opt_bin/"%s"`, service.Run[0])}
+	for _, arg := range service.Run[1:] {
+		args = append(args, fmt.Sprintf(`"%s"`, arg))
+	}
+	run := args[0]
+	if len(args) > 1 {
+		run = "[" + strings.Join(args, ", ") + "]"
+	}
+	lines := []string{"run " + run}
+
+	for _, path := range []struct{ name, value string }{
+		{"working_dir", service.WorkingDir},
+		{"log_path", service.LogPath},
+		{"error_log_path", service.ErrorLogPath},
+	} {
+		if path.value == "" {
+			continue
+		}
+		// relative paths are relative to homebrew's var directory.
+		if strings.HasPrefix(path.value, "/") {
+			lines = append(lines, fmt.Sprintf(`%s "%s"`, path.name, path.value))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf(`%s var/"%s"`, path.name, path.value))
+	}
+
+	if service.KeepAlive {
+		lines = append(lines, "keep_alive true")
+	}
+
+	switch service.RunType {
+	case "":
+	case "immediate":
+		lines = append(lines, "run_type :immediate")
+	case "interval":
+		if service.Interval <= 0 {
+			return nil, fmt.Errorf("brew.service.interval is required when run_type is interval")
+		}
+		lines = append(lines, "run_type :interval", fmt.Sprintf("interval %d", service.Interval))
+	case "cron":
+		if service.Cron == "" {
+			return nil, fmt.Errorf("brew.service.cron is required when run_type is cron")
+		}
+		lines = append(lines, "run_type :cron", fmt.Sprintf(`cron "%s"`, service.Cron))
+	default:
+		return nil, fmt.Errorf("invalid brew.service.run_type: %q", service.RunType)
+	}
+
+	return lines, nil
+}
+
 func dataFor(ctx *context.Context, cfg config.Homebrew, cl client.ReleaseURLTemplater, artifacts []*artifact.Artifact) (templateData, error) {
 	sort.Slice(cfg.Dependencies, func(i, j int) bool {
 		return cfg.Dependencies[i].Name < cfg.Dependencies[j].Name
 	})
+	service, err := serviceFor(cfg.Service)
+	if err != nil {
+		return templateData{}, err
+	}
 	result := templateData{
 		Name:          formulaNameFor(cfg.Name),
 		Desc:          cfg.Description,
@@ -406,9 +589,9 @@ func dataFor(ctx *context.Context, cfg config.Homebrew, cl client.ReleaseURLTemp
 		Caveats:       split(cfg.Caveats),
 		Dependencies:  cfg.Dependencies,
 		Conflicts:     cfg.Conflicts,
-		Service:       split(cfg.Service),
+		Service:       service,
 		PostInstall:   split(cfg.PostInstall),
-		Tests:         split(cfg.Test),
+		Tests:         testsFor(cfg, artifacts),
 		CustomRequire: cfg.CustomRequire,
 		CustomBlock:   split(cfg.CustomBlock),
 	}
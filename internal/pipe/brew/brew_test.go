@@ -337,7 +337,7 @@ func TestFullPipe(t *testing.T) {
 								{Name: "ash", Version: "1.0.0", OS: "linux"},
 							},
 							Conflicts:   []string{"gtk+", "qt"},
-							Service:     "run foo/bar\nkeep_alive true",
+							Service:     config.HomebrewService{Block: "run foo/bar\nkeep_alive true"},
 							PostInstall: "system \"echo\"\ntouch \"/tmp/hi\"",
 							Install:     `bin.install "{{ .ProjectName }}_{{.Os}}_{{.Arch}} => {{.ProjectName}}"`,
 							Goamd64:     "v1",
@@ -1076,12 +1076,14 @@ func TestRunPipePullRequest(t *testing.T) {
 							Enabled: true,
 						},
 					},
+					CommitMessageTemplate: "foo {{ .Tag }}\n\n{{ .ReleaseNotes }}\n\n{{ .Checksums }}",
 				},
 			},
 		},
 		testctx.WithVersion("1.2.1"),
 		testctx.WithCurrentTag("v1.2.1"),
 	)
+	ctx.ReleaseNotes = "## Changelog\n* fix bar"
 	path := filepath.Join(folder, "dist/foo_darwin_all/foo")
 	ctx.Artifacts.Add(&artifact.Artifact{
 		Name:   "foo_macos",
@@ -1102,12 +1104,190 @@ func TestRunPipePullRequest(t *testing.T) {
 	require.NoError(t, f.Close())
 
 	client := client.NewMock()
+	client.PullRequestURL = "https://github.com/foo/bar/pull/1"
 	require.NoError(t, runAll(ctx, client))
 	require.NoError(t, publishAll(ctx, client))
 	require.True(t, client.CreatedFile)
 	require.True(t, client.OpenedPullRequest)
 	require.True(t, client.SyncedFork)
 	golden.RequireEqualRb(t, []byte(client.Content))
+	require.Equal(t, []string{
+		"foo v1.2.1\n\n## Changelog\n* fix bar\n\ne3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  foo_macos",
+	}, client.Messages)
+
+	formulas := ctx.Artifacts.Filter(artifact.ByType(artifact.BrewTap)).List()
+	require.Len(t, formulas, 1)
+	require.Len(t, formulas[0].Extra, 2)
+	require.Contains(t, formulas[0].Extra, brewConfigExtra)
+	require.Equal(t, "https://github.com/foo/bar/pull/1", formulas[0].Extra[artifact.ExtraPullRequest])
+}
+
+func TestRunPipeService(t *testing.T) {
+	folder := t.TempDir()
+	ctx := testctx.NewWithCfg(
+		config.Project{
+			Dist:        folder,
+			ProjectName: "foo",
+			Brews: []config.Homebrew{
+				{
+					Name:        "foo",
+					Homepage:    "https://goreleaser.com",
+					Description: "Fake desc",
+					Repository: config.RepoRef{
+						Owner: "foo",
+						Name:  "bar",
+					},
+					Service: config.HomebrewService{
+						Run:          []string{"foo", "serve", "--port={{ .Env.PORT }}"},
+						WorkingDir:   "lib/foo",
+						LogPath:      "log/foo.log",
+						ErrorLogPath: "/tmp/foo.err.log",
+						KeepAlive:    true,
+						RunType:      "immediate",
+					},
+				},
+			},
+			Env: []string{"PORT=8080"},
+		},
+		testctx.WithVersion("1.2.1"),
+		testctx.WithCurrentTag("v1.2.1"),
+	)
+	path := filepath.Join(folder, "dist/foo_darwin_all/foo")
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "foo_macos",
+		Path:   path,
+		Goos:   "darwin",
+		Goarch: "all",
+		Type:   artifact.UploadableBinary,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:     "foo",
+			artifact.ExtraFormat: "binary",
+			artifact.ExtraBinary: "foo",
+		},
+	})
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	client := client.NewMock()
+	require.NoError(t, runAll(ctx, client))
+	require.NoError(t, publishAll(ctx, client))
+	require.True(t, client.CreatedFile)
+	golden.RequireEqualRb(t, []byte(client.Content))
+}
+
+func TestTestsFor(t *testing.T) {
+	archive := &artifact.Artifact{
+		Name: "foo.tar.gz",
+		Type: artifact.UploadableArchive,
+		Extra: map[string]interface{}{
+			artifact.ExtraBinaries: []string{"foo", "foo-helper"},
+		},
+	}
+
+	t.Run("configured", func(t *testing.T) {
+		require.Equal(t, []string{
+			`system "#{bin}/foo", "version"`,
+			`assert_match "1.2.3", shell_output("#{bin}/foo version")`,
+		}, testsFor(config.Homebrew{
+			Test: "system \"#{bin}/foo\", \"version\"\nassert_match \"1.2.3\", shell_output(\"#{bin}/foo version\")\n",
+		}, []*artifact.Artifact{archive}))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		require.Equal(t, []string{
+			`system "#{bin}/foo", "--version"`,
+		}, testsFor(config.Homebrew{}, []*artifact.Artifact{archive}))
+	})
+
+	t.Run("no binaries", func(t *testing.T) {
+		require.Empty(t, testsFor(config.Homebrew{}, []*artifact.Artifact{{
+			Name: "foo.tar.gz",
+			Type: artifact.UploadableArchive,
+		}}))
+	})
+}
+
+func TestServiceFor(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		lines, err := serviceFor(config.HomebrewService{})
+		require.NoError(t, err)
+		require.Empty(t, lines)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		lines, err := serviceFor(config.HomebrewService{
+			Block: "run foo/bar\nkeep_alive true",
+			Run:   []string{"ignored"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"run foo/bar", "keep_alive true"}, lines)
+	})
+
+	t.Run("single run arg", func(t *testing.T) {
+		lines, err := serviceFor(config.HomebrewService{
+			Run: []string{"foo"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{`run opt_bin/"foo"`}, lines)
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		lines, err := serviceFor(config.HomebrewService{
+			Run:      []string{"foo", "sync"},
+			RunType:  "interval",
+			Interval: 300,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			`run [opt_bin/"foo", "sync"]`,
+			"run_type :interval",
+			"interval 300",
+		}, lines)
+	})
+
+	t.Run("cron", func(t *testing.T) {
+		lines, err := serviceFor(config.HomebrewService{
+			Run:     []string{"foo"},
+			RunType: "cron",
+			Cron:    "0 * * * *",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			`run opt_bin/"foo"`,
+			"run_type :cron",
+			`cron "0 * * * *"`,
+		}, lines)
+	})
+
+	for name, tt := range map[string]struct {
+		service config.HomebrewService
+		err     string
+	}{
+		"no run": {
+			service: config.HomebrewService{KeepAlive: true},
+			err:     "brew.service.run is required",
+		},
+		"no interval": {
+			service: config.HomebrewService{Run: []string{"foo"}, RunType: "interval"},
+			err:     "brew.service.interval is required when run_type is interval",
+		},
+		"no cron": {
+			service: config.HomebrewService{Run: []string{"foo"}, RunType: "cron"},
+			err:     "brew.service.cron is required when run_type is cron",
+		},
+		"invalid run type": {
+			service: config.HomebrewService{Run: []string{"foo"}, RunType: "sometimes"},
+			err:     `invalid brew.service.run_type: "sometimes"`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := serviceFor(tt.service)
+			require.EqualError(t, err, tt.err)
+		})
+	}
 }
 
 func TestRunPipeNoUpload(t *testing.T) {
@@ -1347,6 +1527,174 @@ func TestInstalls(t *testing.T) {
 	})
 }
 
+func TestRunPipeMultipleBinaries(t *testing.T) {
+	for name, tt := range map[string]struct {
+		binaries []config.HomebrewBinary
+		err      string
+	}{
+		"valid": {
+			binaries: []config.HomebrewBinary{
+				{Name: "tool"},
+				{Name: "tool-helper", Rename: "helper"},
+			},
+		},
+		"missing": {
+			binaries: []config.HomebrewBinary{
+				{Name: "tool"},
+				{Name: "nope"},
+			},
+			err: `brew: binary "nope" not found in tool_darwin_amd64.tar.gz`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			folder := t.TempDir()
+			ctx := testctx.NewWithCfg(
+				config.Project{
+					Dist:        folder,
+					ProjectName: "tool",
+					Brews: []config.Homebrew{
+						{
+							Name:        "tool",
+							Homepage:    "https://goreleaser.com",
+							Description: "Fake desc",
+							Repository: config.RepoRef{
+								Owner: "foo",
+								Name:  "bar",
+							},
+							Binaries: tt.binaries,
+						},
+					},
+				},
+				testctx.WithCurrentTag("v1.0.1"),
+				testctx.WithVersion("1.0.1"),
+			)
+			path := filepath.Join(folder, "bin.tar.gz")
+			for _, goos := range []string{"darwin", "linux"} {
+				ctx.Artifacts.Add(&artifact.Artifact{
+					Name:    "tool_" + goos + "_amd64.tar.gz",
+					Path:    path,
+					Goos:    goos,
+					Goarch:  "amd64",
+					Goamd64: "v1",
+					Type:    artifact.UploadableArchive,
+					Extra: map[string]interface{}{
+						artifact.ExtraID:       "tool",
+						artifact.ExtraFormat:   "tar.gz",
+						artifact.ExtraBinaries: []string{"tool", "tool-helper"},
+					},
+				})
+			}
+
+			f, err := os.Create(path)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+			client := client.NewMock()
+
+			require.NoError(t, Pipe{}.Default(ctx))
+			err = runAll(ctx, client)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, publishAll(ctx, client))
+			golden.RequireEqualRb(t, []byte(client.Content))
+		})
+	}
+}
+
+func TestRunPipeDocs(t *testing.T) {
+	folder := t.TempDir()
+	ctx := testctx.NewWithCfg(
+		config.Project{
+			Dist:        folder,
+			ProjectName: "tool",
+			Brews: []config.Homebrew{
+				{
+					Name:        "tool",
+					Homepage:    "https://goreleaser.com",
+					Description: "Fake desc",
+					Repository: config.RepoRef{
+						Owner: "foo",
+						Name:  "bar",
+					},
+				},
+			},
+		},
+		testctx.WithCurrentTag("v1.0.1"),
+		testctx.WithVersion("1.0.1"),
+	)
+	path := filepath.Join(folder, "bin.tar.gz")
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:    "tool_darwin_amd64.tar.gz",
+		Path:    path,
+		Goos:    "darwin",
+		Goarch:  "amd64",
+		Goamd64: "v1",
+		Type:    artifact.UploadableArchive,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:       "tool",
+			artifact.ExtraFormat:   "tar.gz",
+			artifact.ExtraBinaries: []string{"tool"},
+			artifact.ExtraDocs: []string{
+				"completions/tool",
+				"completions/_tool",
+				"completions/tool.fish",
+				"manpages/tool.1",
+			},
+		},
+	})
+	for _, completion := range [][2]string{
+		{"bash", "tool"},
+		{"zsh", "_tool"},
+		{"fish", "tool.fish"},
+	} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: completion[1],
+			Path: filepath.Join(folder, "tool."+completion[0]),
+			Type: artifact.Completion,
+			Extra: map[string]interface{}{
+				artifact.ExtraShell: completion[0],
+			},
+		})
+	}
+	// docs not in the archive are not installed.
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "other",
+		Path: filepath.Join(folder, "other.bash"),
+		Type: artifact.Completion,
+		Extra: map[string]interface{}{
+			artifact.ExtraShell: "bash",
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "other.1",
+		Path: filepath.Join(folder, "other.1"),
+		Type: artifact.ManPage,
+		Extra: map[string]interface{}{
+			artifact.ExtraSection: "1",
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "tool.1",
+		Path: filepath.Join(folder, "tool.1"),
+		Type: artifact.ManPage,
+		Extra: map[string]interface{}{
+			artifact.ExtraSection: "1",
+		},
+	})
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	client := client.NewMock()
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, runAll(ctx, client))
+	require.NoError(t, publishAll(ctx, client))
+	golden.RequireEqualRb(t, []byte(client.Content))
+}
+
 func TestRunPipeUniversalBinary(t *testing.T) {
 	folder := t.TempDir()
 	ctx := testctx.NewWithCfg(
@@ -3,13 +3,16 @@
 package build
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
-	"github.com/caarlos0/go-shellwords"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/ids"
 	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
 	"github.com/goreleaser/goreleaser/v2/internal/shell"
@@ -20,7 +23,7 @@ import (
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 
 	// langs to init.
-	_ "github.com/goreleaser/goreleaser/v2/internal/builders/golang"
+	"github.com/goreleaser/goreleaser/v2/internal/builders/golang"
 )
 
 // Pipe for build.
@@ -32,7 +35,7 @@ func (Pipe) String() string {
 
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
-	g := semerrgroup.New(ctx.Parallelism)
+	g := semerrgroup.NewShared(ctx)
 	for _, build := range ctx.Config.Builds {
 		if build.Skip {
 			log.WithField("id", build.ID).Info("skip is set")
@@ -41,7 +44,25 @@ func (Pipe) Run(ctx *context.Context) error {
 		log.WithField("build", build).Debug("building")
 		runPipeOnBuild(ctx, g, build)
 	}
-	return g.Wait()
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	for _, build := range ctx.Config.Builds {
+		if build.Skip {
+			continue
+		}
+		if build.Completions.Cmd != "" {
+			if err := generateCompletions(ctx, build); err != nil {
+				return err
+			}
+		}
+		if build.ManPage.Cmd != "" {
+			if err := generateManPage(ctx, build); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // Default sets the pipe defaults.
@@ -75,6 +96,9 @@ func buildWithDefaults(ctx *context.Context, build config.Build) (config.Build,
 	if build.ID == "" {
 		build.ID = ctx.Config.ProjectName
 	}
+	if build.Completions.Cmd != "" && len(build.Completions.Shells) == 0 {
+		build.Completions.Shells = []string{"bash", "zsh", "fish"}
+	}
 	for k, v := range build.Env {
 		build.Env[k] = os.ExpandEnv(v)
 	}
@@ -94,11 +118,18 @@ func runPipeOnBuild(ctx *context.Context, g semerrgroup.Group, build config.Buil
 					return fmt.Errorf("pre hook failed: %w", err)
 				}
 			}
-			if err := doBuild(ctx, build, *opts); err != nil {
+			start := time.Now()
+			err = doBuild(ctx, build, *opts)
+			ctx.Timings.Add(build.ID+" "+target, time.Since(start), err)
+			if err != nil {
 				return err
 			}
 			if !skips.Any(ctx, skips.PostBuildHooks) {
-				if err := runHook(ctx, *opts, build.Env, build.Hooks.Post); err != nil {
+				extraFile := opts.Path + extraFileSuffix
+				if err := runHook(ctx, *opts, build.Env, build.Hooks.Post, extraFileEnv+"="+extraFile); err != nil {
+					return fmt.Errorf("post hook failed: %w", err)
+				}
+				if err := mergeHookExtras(ctx, opts.Path, extraFile); err != nil {
 					return fmt.Errorf("post hook failed: %w", err)
 				}
 			}
@@ -107,46 +138,78 @@ func runPipeOnBuild(ctx *context.Context, g semerrgroup.Group, build config.Buil
 	}
 }
 
-func runHook(ctx *context.Context, opts builders.Options, buildEnv []string, hooks config.Hooks) error {
+func runHook(ctx *context.Context, opts builders.Options, buildEnv []string, hooks config.Hooks, extraEnv ...string) error {
 	if len(hooks) == 0 {
 		return nil
 	}
 
-	for _, hook := range hooks {
-		var env []string
-
-		env = append(env, ctx.Env.Strings()...)
-		for _, rawEnv := range append(buildEnv, hook.Env...) {
-			e, err := tmpl.New(ctx).WithBuildOptions(opts).Apply(rawEnv)
-			if err != nil {
-				return err
-			}
-			env = append(env, e)
-		}
-
-		dir, err := tmpl.New(ctx).WithBuildOptions(opts).Apply(hook.Dir)
+	env := ctx.Env.Strings()
+	for _, rawEnv := range buildEnv {
+		e, err := tmpl.New(ctx).WithBuildOptions(opts).Apply(rawEnv)
 		if err != nil {
 			return err
 		}
+		env = append(env, e)
+	}
+	env = append(env, extraEnv...)
 
-		sh, err := tmpl.New(ctx).WithBuildOptions(opts).
-			WithEnvS(env).
-			Apply(hook.Cmd)
-		if err != nil {
-			return err
-		}
+	return shell.RunHooks(ctx, hooks, env, func() *tmpl.Template {
+		return tmpl.New(ctx).WithBuildOptions(opts)
+	})
+}
 
-		log.WithField("hook", sh).Info("running hook")
-		cmd, err := shellwords.Parse(sh)
-		if err != nil {
-			return err
-		}
+const (
+	// extraFileEnv is the environment variable that holds the path of the
+	// file post hooks can write extra artifact fields to.
+	extraFileEnv = "GORELEASER_ARTIFACT_EXTRA_FILE"
 
-		if err := shell.Run(ctx, dir, cmd, env, hook.Output); err != nil {
-			return err
-		}
+	extraFileSuffix = ".extra.json"
+)
+
+// mergeHookExtras reads the JSON object post hooks might have written to the
+// given file, and merges it into the extra fields of the artifacts built at
+// the given path.
+// Fields already set by GoReleaser are not overridden.
+// The file is removed afterwards.
+func mergeHookExtras(ctx *context.Context, path, file string) error {
+	bts, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", extraFileEnv, err)
+	}
+	if err := os.Remove(file); err != nil {
+		return fmt.Errorf("could not remove %s: %w", extraFileEnv, err)
 	}
 
+	var extras map[string]any
+	if err := json.Unmarshal(bts, &extras); err != nil {
+		return fmt.Errorf("invalid %s: expected a JSON object: %w", extraFileEnv, err)
+	}
+
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	for _, a := range ctx.Artifacts.Filter(func(a *artifact.Artifact) bool {
+		// artifact paths might have been made relative when added.
+		apath, err := filepath.Abs(filepath.FromSlash(a.Path))
+		return err == nil && apath == path
+	}).List() {
+		if a.Extra == nil {
+			a.Extra = artifact.Extras{}
+		}
+		for k, v := range extras {
+			if _, ok := a.Extra[k]; ok {
+				log.WithField("key", k).
+					WithField("artifact", a.Name).
+					Warn("ignoring extra field from post hook: already set")
+				continue
+			}
+			a.Extra[k] = v
+		}
+	}
 	return nil
 }
 
@@ -155,7 +218,6 @@ func doBuild(ctx *context.Context, build config.Build, opts builders.Options) er
 }
 
 func buildOptionsForTarget(ctx *context.Context, build config.Build, target string) (*builders.Options, error) {
-	ext := extFor(target, build.BuildDetails)
 	parts := strings.Split(target, "_")
 	if len(parts) < 2 {
 		return nil, fmt.Errorf("%s is not a valid build target", target)
@@ -179,7 +241,6 @@ func buildOptionsForTarget(ctx *context.Context, build config.Build, target stri
 
 	buildOpts := builders.Options{
 		Target:  target,
-		Ext:     ext,
 		Goos:    goos,
 		Goarch:  goarch,
 		Goarm:   goarm,
@@ -187,6 +248,17 @@ func buildOptionsForTarget(ctx *context.Context, build config.Build, target stri
 		Goamd64: goamd64,
 	}
 
+	// the extension depends on the build mode, which might be overridden for
+	// this target.
+	details := build.BuildDetails
+	buildmode, err := golang.Buildmode(ctx, build, buildOpts)
+	if err != nil {
+		return nil, err
+	}
+	details.Buildmode = buildmode
+	ext := extFor(target, details)
+	buildOpts.Ext = ext
+
 	bin, err := tmpl.New(ctx).WithBuildOptions(buildOpts).Apply(build.Binary)
 	if err != nil {
 		return nil, err
@@ -212,6 +284,7 @@ func buildOptionsForTarget(ctx *context.Context, build config.Build, target stri
 func extFor(target string, build config.BuildDetails) string {
 	// Configure the extensions for shared and static libraries - by default .so and .a respectively -
 	// with overrides for Windows (.dll for shared and .lib for static) and .dylib for macOS.
+	// Go plugins are always .so.
 	switch build.Buildmode {
 	case "c-shared":
 		if strings.Contains(target, "darwin") {
@@ -226,6 +299,8 @@ func extFor(target string, build config.BuildDetails) string {
 			return ".lib"
 		}
 		return ".a"
+	case "plugin":
+		return ".so"
 	}
 
 	if target == "js_wasm" {
@@ -27,6 +27,7 @@ var (
 type fakeBuilder struct {
 	fail        bool
 	failDefault bool
+	withPath    bool
 }
 
 func (f *fakeBuilder) WithDefaults(build config.Build) (config.Build, error) {
@@ -46,14 +47,25 @@ func (f *fakeBuilder) Build(ctx *context.Context, _ config.Build, options api.Op
 	if err := os.WriteFile(options.Path, []byte("foo"), 0o755); err != nil {
 		return err
 	}
-	ctx.Artifacts.Add(&artifact.Artifact{
+	a := &artifact.Artifact{
 		Name: options.Name,
-	})
+	}
+	if f.withPath {
+		a.Path = options.Path
+		a.Type = artifact.Binary
+		a.Extra = artifact.Extras{
+			artifact.ExtraBinary: options.Name,
+		}
+	}
+	ctx.Artifacts.Add(a)
 	return nil
 }
 
 func init() {
 	api.Register("fake", &fakeBuilder{})
+	api.Register("fakeWithPath", &fakeBuilder{
+		withPath: true,
+	})
 	api.Register("fakeFail", &fakeBuilder{
 		fail: true,
 	})
@@ -248,6 +260,69 @@ func TestRunPipeFailingHooks(t *testing.T) {
 	})
 }
 
+func TestRunPipeHookExtras(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	cfg := config.Project{
+		Dist: folder,
+		Builds: []config.Build{
+			{
+				ID:      "extras",
+				Builder: "fakeWithPath",
+				Binary:  "extras",
+				Targets: []string{"linux_amd64"},
+			},
+		},
+	}
+	writeExtras := func(content string) config.Hook {
+		return config.Hook{
+			Cmd: `sh -c 'echo "$EXTRAS" > "$` + extraFileEnv + `"'`,
+			Env: []string{"EXTRAS=" + content},
+		}
+	}
+
+	t.Run("merged", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg, testctx.WithCurrentTag("2.4.5"))
+		ctx.Config.Builds[0].Hooks.Post = []config.Hook{
+			writeExtras(`{"score":7.5,"Binary":"nope","tags":["a","b"]}`),
+		}
+		require.NoError(t, Pipe{}.Run(ctx))
+		bins := ctx.Artifacts.Filter(artifact.ByType(artifact.Binary)).List()
+		require.Len(t, bins, 1)
+		bin := bins[0]
+		require.Equal(t, artifact.Extras{
+			artifact.ExtraBinary: "extras",
+			"score":              7.5,
+			"tags":               []any{"a", "b"},
+		}, bin.Extra)
+		require.NoFileExists(t, bin.Path+extraFileSuffix)
+
+		out, err := tmpl.New(ctx).WithArtifact(bin).Apply("{{ .Artifact.Extra.score }}")
+		require.NoError(t, err)
+		require.Equal(t, "7.5", out)
+	})
+
+	t.Run("no file", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg, testctx.WithCurrentTag("2.4.5"))
+		ctx.Config.Builds[0].Hooks.Post = []config.Hook{{Cmd: "echo post"}}
+		require.NoError(t, Pipe{}.Run(ctx))
+		bins := ctx.Artifacts.Filter(artifact.ByType(artifact.Binary)).List()
+		require.Len(t, bins, 1)
+		require.Equal(t, artifact.Extras{
+			artifact.ExtraBinary: "extras",
+		}, bins[0].Extra)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg, testctx.WithCurrentTag("2.4.5"))
+		ctx.Config.Builds[0].Hooks.Post = []config.Hook{
+			writeExtras(`[1,2]`),
+		}
+		err := Pipe{}.Run(ctx)
+		require.ErrorContains(t, err, "post hook failed")
+		require.ErrorContains(t, err, "expected a JSON object")
+	})
+}
+
 func TestDefaultNoBuilds(t *testing.T) {
 	ctx := testctx.New()
 	require.NoError(t, Pipe{}.Default(ctx))
@@ -422,6 +497,8 @@ func TestExtDarwin(t *testing.T) {
 	require.Equal(t, ".dylib", extFor("darwin_arm64", config.BuildDetails{Buildmode: "c-shared"}))
 	require.Equal(t, ".a", extFor("darwin_amd64", config.BuildDetails{Buildmode: "c-archive"}))
 	require.Equal(t, ".a", extFor("darwin_arm64", config.BuildDetails{Buildmode: "c-archive"}))
+	require.Equal(t, ".so", extFor("darwin_arm64", config.BuildDetails{Buildmode: "plugin"}))
+	require.Equal(t, "", extFor("darwin_arm64", config.BuildDetails{Buildmode: "pie"}))
 }
 
 func TestExtLinux(t *testing.T) {
@@ -432,6 +509,7 @@ func TestExtLinux(t *testing.T) {
 	require.Equal(t, ".so", extFor("linux_386", config.BuildDetails{Buildmode: "c-shared"}))
 	require.Equal(t, ".a", extFor("linux_amd64", config.BuildDetails{Buildmode: "c-archive"}))
 	require.Equal(t, ".a", extFor("linux_386", config.BuildDetails{Buildmode: "c-archive"}))
+	require.Equal(t, ".so", extFor("linux_amd64", config.BuildDetails{Buildmode: "plugin"}))
 }
 
 func TestExtWindows(t *testing.T) {
@@ -442,6 +520,7 @@ func TestExtWindows(t *testing.T) {
 	require.Equal(t, ".dll", extFor("windows_386", config.BuildDetails{Buildmode: "c-shared"}))
 	require.Equal(t, ".lib", extFor("windows_amd64", config.BuildDetails{Buildmode: "c-archive"}))
 	require.Equal(t, ".lib", extFor("windows_386", config.BuildDetails{Buildmode: "c-archive"}))
+	require.Equal(t, ".exe", extFor("windows_amd64", config.BuildDetails{Buildmode: "pie"}))
 }
 
 func TestExtWasm(t *testing.T) {
@@ -679,6 +758,31 @@ func TestBuildOptionsForTarget(t *testing.T) {
 				Goamd64: "v3",
 			},
 		},
+		{
+			name: "buildmode in overrides",
+			build: config.Build{
+				ID:     "testid",
+				Binary: "testbinary",
+				Targets: []string{
+					"windows_amd64",
+				},
+				BuildDetailsOverrides: []config.BuildDetailsOverride{{
+					Goos: "windows",
+					BuildDetails: config.BuildDetails{
+						Buildmode: "c-shared",
+					},
+				}},
+			},
+			expectedOpts: &api.Options{
+				Name:    "testbinary.dll",
+				Path:    filepath.Join(tmpDir, "testid_windows_amd64_v1", "testbinary.dll"),
+				Target:  "windows_amd64_v1",
+				Ext:     ".dll",
+				Goos:    "windows",
+				Goarch:  "amd64",
+				Goamd64: "v1",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
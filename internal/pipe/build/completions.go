@@ -0,0 +1,119 @@
+package build
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/caarlos0/go-shellwords"
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// generateCompletions runs the binary built for the host platform to generate
+// the shell completions, and adds them as artifacts.
+//
+// Completions do not depend on the target platform, so a single host binary
+// generates them for all the targets of the build.
+func generateCompletions(ctx *context.Context, build config.Build) error {
+	bin := hostBinary(ctx, build)
+	if bin == nil {
+		log.WithField("id", build.ID).
+			WithField("target", runtime.GOOS+"_"+runtime.GOARCH).
+			Warn("no binary built for the host platform, skipping completions")
+		return nil
+	}
+
+	args, err := hostArgs(ctx, bin, build.Completions.Cmd)
+	if err != nil {
+		return err
+	}
+
+	binary := artifact.ExtraOr(*bin, artifact.ExtraBinary, bin.Name)
+	dir := filepath.Join(ctx.Config.Dist, "completions", build.ID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, shell := range build.Completions.Shells {
+		name, err := artifact.CompletionName(shell, binary)
+		if err != nil {
+			return fmt.Errorf("completions: %w", err)
+		}
+
+		log.WithField("binary", bin.Path).
+			WithField("shell", shell).
+			Info("generating completions")
+		out, err := runHost(ctx, bin, append(args, shell))
+		if err != nil {
+			return fmt.Errorf("completions: %s completion failed: %w", shell, err)
+		}
+
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, out, 0o644); err != nil {
+			return fmt.Errorf("completions: %w", err)
+		}
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: artifact.Completion,
+			Name: name,
+			Path: path,
+			Extra: map[string]any{
+				artifact.ExtraID:     build.ID,
+				artifact.ExtraShell:  shell,
+				artifact.ExtraBinary: binary,
+			},
+		})
+	}
+	return nil
+}
+
+// hostBinary returns the binary of the given build that can run on the host
+// platform, if any.
+//
+// On amd64, only the v1 binary is picked, as the host might not support the
+// instructions of the others.
+func hostBinary(ctx *context.Context, build config.Build) *artifact.Artifact {
+	filters := []artifact.Filter{
+		artifact.ByType(artifact.Binary),
+		artifact.ByIDs(build.ID),
+		artifact.ByGoos(runtime.GOOS),
+		artifact.ByGoarch(runtime.GOARCH),
+	}
+	if runtime.GOARCH == "amd64" {
+		filters = append(filters, artifact.ByGoamd64("v1"))
+	}
+	bins := ctx.Artifacts.Filter(artifact.And(filters...)).List()
+	if len(bins) == 0 {
+		return nil
+	}
+	return bins[0]
+}
+
+// hostArgs applies the templates in the given command and splits it into
+// arguments to the host binary.
+func hostArgs(ctx *context.Context, bin *artifact.Artifact, cmd string) ([]string, error) {
+	sh, err := tmpl.New(ctx).WithArtifact(bin).Apply(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return shellwords.Parse(sh)
+}
+
+// runHost runs the host binary with the given arguments, returning its
+// standard output.
+func runHost(ctx *context.Context, bin *artifact.Artifact, args []string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, bin.Path, args...)
+	cmd.Env = ctx.Env.Strings()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
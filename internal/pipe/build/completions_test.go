@@ -0,0 +1,172 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+// setupHostBinary creates a context with a shell script as the binary of the
+// given build, for the host platform and another one.
+func setupHostBinary(tb testing.TB, script string, build config.Build) *context.Context {
+	tb.Helper()
+	dist := tb.TempDir()
+	bin := filepath.Join(dist, "foo")
+	require.NoError(tb, os.WriteFile(bin, []byte("#!/bin/sh\n"+script+"\n"), 0o755))
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist:   dist,
+		Builds: []config.Build{build},
+	})
+	for _, target := range [][2]string{
+		{"plan9", "arm"},
+		{runtime.GOOS, runtime.GOARCH},
+	} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo",
+			Path:    bin,
+			Goos:    target[0],
+			Goarch:  target[1],
+			Goamd64: goamd64(target[1]),
+			Type:    artifact.Binary,
+			Extra: artifact.Extras{
+				artifact.ExtraID:     "foo",
+				artifact.ExtraBinary: "foo",
+			},
+		})
+	}
+	return ctx
+}
+
+// goamd64 returns the default goamd64 of the given goarch, as the go builder
+// sets it.
+func goamd64(goarch string) string {
+	if goarch == "amd64" {
+		return "v1"
+	}
+	return ""
+}
+
+func TestHostBinary(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("only amd64 has variants the host might not run")
+	}
+	ctx := testctx.New()
+	for _, variant := range []string{"v3", "v1", "v4"} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo_" + variant,
+			Goos:    runtime.GOOS,
+			Goarch:  runtime.GOARCH,
+			Goamd64: variant,
+			Type:    artifact.Binary,
+			Extra: artifact.Extras{
+				artifact.ExtraID: "foo",
+			},
+		})
+	}
+	bin := hostBinary(ctx, config.Build{ID: "foo"})
+	require.NotNil(t, bin)
+	require.Equal(t, "v1", bin.Goamd64)
+	require.Nil(t, hostBinary(ctx, config.Build{ID: "bar"}))
+}
+
+func TestGenerateCompletions(t *testing.T) {
+	t.Run("generate", func(t *testing.T) {
+		ctx := setupHostBinary(t, `echo "$@"`, config.Build{
+			ID: "foo",
+			Completions: config.BuildCompletion{
+				Cmd:    "completion --name {{ .Binary }}",
+				Shells: []string{"bash", "zsh", "fish"},
+			},
+		})
+		require.NoError(t, generateCompletions(ctx, ctx.Config.Builds[0]))
+
+		completions := ctx.Artifacts.Filter(artifact.ByType(artifact.Completion)).List()
+		require.Len(t, completions, 3)
+		for i, expected := range [][2]string{
+			{"bash", "foo"},
+			{"zsh", "_foo"},
+			{"fish", "foo.fish"},
+		} {
+			completion := completions[i]
+			require.Equal(t, expected[1], completion.Name)
+			require.Equal(t, expected[0], artifact.ExtraOr(*completion, artifact.ExtraShell, ""))
+			require.Equal(t, "foo", artifact.ExtraOr(*completion, artifact.ExtraBinary, ""))
+			require.Equal(t, "foo", completion.ID())
+			bts, err := os.ReadFile(completion.Path)
+			require.NoError(t, err)
+			require.Equal(t, "completion --name foo "+expected[0]+"\n", string(bts))
+		}
+	})
+
+	t.Run("no host binary", func(t *testing.T) {
+		ctx := setupHostBinary(t, `echo "$@"`, config.Build{
+			ID: "bar",
+			Completions: config.BuildCompletion{
+				Cmd:    "completion",
+				Shells: []string{"bash"},
+			},
+		})
+		require.NoError(t, generateCompletions(ctx, ctx.Config.Builds[0]))
+		require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Completion)).List())
+	})
+
+	t.Run("command fails", func(t *testing.T) {
+		ctx := setupHostBinary(t, `echo "unknown command" >&2; exit 1`, config.Build{
+			ID: "foo",
+			Completions: config.BuildCompletion{
+				Cmd:    "completion",
+				Shells: []string{"bash"},
+			},
+		})
+		require.ErrorContains(t, generateCompletions(ctx, ctx.Config.Builds[0]), "bash completion failed: exit status 1: unknown command")
+	})
+
+	t.Run("invalid shell", func(t *testing.T) {
+		ctx := setupHostBinary(t, `echo "$@"`, config.Build{
+			ID: "foo",
+			Completions: config.BuildCompletion{
+				Cmd:    "completion",
+				Shells: []string{"tcsh"},
+			},
+		})
+		require.EqualError(t, generateCompletions(ctx, ctx.Config.Builds[0]), `completions: invalid shell: "tcsh"`)
+	})
+
+	t.Run("bad template", func(t *testing.T) {
+		ctx := setupHostBinary(t, `echo "$@"`, config.Build{
+			ID: "foo",
+			Completions: config.BuildCompletion{
+				Cmd:    "{{ .Nope }",
+				Shells: []string{"bash"},
+			},
+		})
+		testlib.RequireTemplateError(t, generateCompletions(ctx, ctx.Config.Builds[0]))
+	})
+}
+
+func TestDefaultCompletionShells(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Builds: []config.Build{
+			{
+				ID:          "foo",
+				Builder:     "fake",
+				Completions: config.BuildCompletion{Cmd: "completion"},
+			},
+			{
+				ID:      "bar",
+				Builder: "fake",
+			},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, []string{"bash", "zsh", "fish"}, ctx.Config.Builds[0].Completions.Shells)
+	require.Empty(t, ctx.Config.Builds[1].Completions.Shells)
+}
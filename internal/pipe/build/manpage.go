@@ -0,0 +1,57 @@
+package build
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// generateManPage runs the binary built for the host platform to generate the
+// man page, and adds it as an artifact.
+func generateManPage(ctx *context.Context, build config.Build) error {
+	bin := hostBinary(ctx, build)
+	if bin == nil {
+		log.WithField("id", build.ID).
+			WithField("target", runtime.GOOS+"_"+runtime.GOARCH).
+			Warn("no binary built for the host platform, skipping man page")
+		return nil
+	}
+
+	args, err := hostArgs(ctx, bin, build.ManPage.Cmd)
+	if err != nil {
+		return err
+	}
+
+	log.WithField("binary", bin.Path).Info("generating man page")
+	out, err := runHost(ctx, bin, args)
+	if err != nil {
+		return fmt.Errorf("man page: %w", err)
+	}
+
+	binary := artifact.ExtraOr(*bin, artifact.ExtraBinary, bin.Name)
+	dir := filepath.Join(ctx.Config.Dist, "manpages", build.ID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	name := binary + ".1"
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, out, 0o644); err != nil {
+		return fmt.Errorf("man page: %w", err)
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.ManPage,
+		Name: name,
+		Path: path,
+		Extra: map[string]any{
+			artifact.ExtraID:      build.ID,
+			artifact.ExtraSection: "1",
+		},
+	})
+	return nil
+}
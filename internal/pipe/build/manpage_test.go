@@ -0,0 +1,56 @@
+package build
+
+import (
+	"os"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateManPage(t *testing.T) {
+	t.Run("generate", func(t *testing.T) {
+		ctx := setupHostBinary(t, `echo "$@"`, config.Build{
+			ID:      "foo",
+			ManPage: config.BuildManPage{Cmd: "man --name {{ .Binary }}"},
+		})
+		require.NoError(t, generateManPage(ctx, ctx.Config.Builds[0]))
+
+		manPages := ctx.Artifacts.Filter(artifact.ByType(artifact.ManPage)).List()
+		require.Len(t, manPages, 1)
+		manPage := manPages[0]
+		require.Equal(t, "foo.1", manPage.Name)
+		require.Equal(t, "foo", manPage.ID())
+		require.Equal(t, "1", artifact.ExtraOr(*manPage, artifact.ExtraSection, ""))
+		bts, err := os.ReadFile(manPage.Path)
+		require.NoError(t, err)
+		require.Equal(t, "man --name foo\n", string(bts))
+	})
+
+	t.Run("no host binary", func(t *testing.T) {
+		ctx := setupHostBinary(t, `echo "$@"`, config.Build{
+			ID:      "bar",
+			ManPage: config.BuildManPage{Cmd: "man"},
+		})
+		require.NoError(t, generateManPage(ctx, ctx.Config.Builds[0]))
+		require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.ManPage)).List())
+	})
+
+	t.Run("command fails", func(t *testing.T) {
+		ctx := setupHostBinary(t, `echo "unknown command" >&2; exit 1`, config.Build{
+			ID:      "foo",
+			ManPage: config.BuildManPage{Cmd: "man"},
+		})
+		require.EqualError(t, generateManPage(ctx, ctx.Config.Builds[0]), "man page: exit status 1: unknown command\n")
+	})
+
+	t.Run("bad template", func(t *testing.T) {
+		ctx := setupHostBinary(t, `echo "$@"`, config.Build{
+			ID:      "foo",
+			ManPage: config.BuildManPage{Cmd: "{{ .Nope }"},
+		})
+		testlib.RequireTemplateError(t, generateManPage(ctx, ctx.Config.Builds[0]))
+	})
+}
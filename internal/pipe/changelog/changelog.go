@@ -4,23 +4,35 @@ package changelog
 import (
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/client"
 	"github.com/goreleaser/goreleaser/v2/internal/git"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
 // ErrInvalidSortDirection happens when the sort order is invalid.
 var ErrInvalidSortDirection = errors.New("invalid sort direction")
 
-const li = "* "
+const (
+	li                = "* "
+	defaultGroupTitle = "Others"
+
+	// minShortSHALength is the minimum length of the abbreviated SHAs, same
+	// as git's default.
+	minShortSHALength = 7
+)
 
 type useChangelog string
 
@@ -34,6 +46,7 @@ const (
 	useGitea        = "gitea"
 	useGitLab       = "gitlab"
 	useGitHubNative = "github-native"
+	useGitHubPR     = "github-pr"
 )
 
 // Pipe for checksums.
@@ -50,6 +63,9 @@ func (Pipe) Skip(ctx *context.Context) (bool, error) {
 }
 
 func (Pipe) Default(ctx *context.Context) error {
+	if ctx.Config.Changelog.Format == "" && ctx.Config.Changelog.Use == useGitHubPR {
+		ctx.Config.Changelog.Format = "{{ .SHA }}: {{ .Message }} (#{{ .Number }}){{ with .AuthorUsername }} (@{{ . }}){{ end }}"
+	}
 	if ctx.Config.Changelog.Format == "" {
 		ctx.Config.Changelog.Format = "{{ .SHA }}: {{ .Message }} ({{ with .AuthorUsername }}@{{ . }}{{ else }}{{ .AuthorName }} <{{ .AuthorEmail }}>{{ end }})"
 	}
@@ -110,6 +126,22 @@ func (Pipe) Run(ctx *context.Context) error {
 	return os.WriteFile(path, []byte(ctx.ReleaseNotes), 0o644) //nolint: gosec
 }
 
+// changelogEntry is a line of the changelog, along with the item it was
+// formatted from, if known.
+type changelogEntry struct {
+	line string
+	item client.ChangelogItem
+}
+
+// linesOf returns the lines of the given entries.
+func linesOf(entries []changelogEntry) []string {
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, entry.line)
+	}
+	return result
+}
+
 type changelogGroup struct {
 	title   string
 	entries []string
@@ -150,17 +182,40 @@ func abbrevEntry(s string, abbr int) string {
 	}
 }
 
-func abbrev(entries []string, abbr int) []string {
-	result := make([]string, 0, len(entries))
+func abbrev(entries []changelogEntry, abbr int) []changelogEntry {
+	result := make([]changelogEntry, 0, len(entries))
 	for _, entry := range entries {
-		result = append(result, abbrevEntry(entry, abbr))
+		result = append(result, changelogEntry{
+			line: abbrevEntry(entry.line, abbr),
+			item: entry.item,
+		})
 	}
 	return result
 }
 
-func formatChangelog(ctx *context.Context, entries []string) (string, error) {
+// hasLabelGroups checks whether any of the given groups groups by labels.
+func hasLabelGroups(groups []config.ChangelogGroup) bool {
+	for _, group := range groups {
+		if len(group.Labels) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnyLabel checks whether the given entry has any of the given labels.
+func hasAnyLabel(entryLabels, labels []string) bool {
+	for _, label := range labels {
+		if slices.Contains(entryLabels, label) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatChangelog(ctx *context.Context, entries []changelogEntry) (string, error) {
 	if !useChangelog(ctx.Config.Changelog.Use).formatable() {
-		return strings.Join(entries, newLineFor(ctx)), nil
+		return strings.Join(linesOf(entries), newLineFor(ctx)), nil
 	}
 
 	entries = abbrev(entries, ctx.Config.Changelog.Abbrev)
@@ -178,24 +233,29 @@ func formatChangelog(ctx *context.Context, entries []string) (string, error) {
 			title: title(group.Title, 3),
 			order: group.Order,
 		}
-		if group.Regexp == "" {
+		if group.Regexp == "" && len(group.Labels) == 0 {
 			// If no regexp is provided, we purge all strikethrough entries and add remaining entries to the list
 			item.entries = filterAndPrefixItems(entries)
 			// clear array
 			entries = nil
 		} else {
-			re, err := regexp.Compile(group.Regexp)
-			if err != nil {
-				return "", fmt.Errorf("failed to group into %q: %w", group.Title, err)
+			var re *regexp.Regexp
+			if group.Regexp != "" {
+				var err error
+				re, err = regexp.Compile(group.Regexp)
+				if err != nil {
+					return "", fmt.Errorf("failed to group into %q: %w", group.Title, err)
+				}
 			}
 
 			log.Debugf("group: %#v", group)
 			i := 0
 			for _, entry := range entries {
-				match := re.MatchString(entry)
-				log.Debugf("entry: %s match: %b\n", entry, match)
+				match := (re != nil && re.MatchString(entry.line)) ||
+					hasAnyLabel(entry.item.Labels, group.Labels)
+				log.Debugf("entry: %s match: %b\n", entry.line, match)
 				if match {
-					item.entries = append(item.entries, li+entry)
+					item.entries = append(item.entries, li+entry.line)
 				} else {
 					// Keep unmatched entry.
 					entries[i] = entry
@@ -211,7 +271,16 @@ func formatChangelog(ctx *context.Context, entries []string) (string, error) {
 		}
 	}
 
-	sort.Slice(groups, groupSort(groups))
+	if len(entries) > 0 && hasLabelGroups(ctx.Config.Changelog.Groups) {
+		// entries without any of the configured labels go to a default group.
+		groups = append(groups, changelogGroup{
+			title:   title(defaultGroupTitle, 3),
+			entries: filterAndPrefixItems(entries),
+			order:   math.MaxInt,
+		})
+	}
+
+	sort.SliceStable(groups, groupSort(groups))
 	for _, group := range groups {
 		if len(group.entries) > 0 {
 			result = append(result, group.title)
@@ -227,11 +296,11 @@ func groupSort(groups []changelogGroup) func(i, j int) bool {
 	}
 }
 
-func filterAndPrefixItems(ss []string) []string {
+func filterAndPrefixItems(entries []changelogEntry) []string {
 	var r []string
-	for _, s := range ss {
-		if s != "" {
-			r = append(r, li+s)
+	for _, entry := range entries {
+		if entry.line != "" {
+			r = append(r, li+entry.line)
 		}
 	}
 	return r
@@ -248,25 +317,42 @@ func loadFromFile(file string) (string, error) {
 
 func checkSortDirection(mode string) error {
 	switch mode {
-	case "", "asc", "desc":
+	case "", "asc", "desc", "date":
 		return nil
 	default:
 		return ErrInvalidSortDirection
 	}
 }
 
-func buildChangelog(ctx *context.Context) ([]string, error) {
+func buildChangelog(ctx *context.Context) ([]changelogEntry, error) {
 	l, err := getChangeloger(ctx)
 	if err != nil {
 		return nil, err
 	}
-	log, err := l.Log(ctx)
+	out, err := l.Log(ctx)
 	if err != nil {
 		return nil, err
 	}
-	entries := strings.Split(log, "\n")
-	if lastLine := entries[len(entries)-1]; strings.TrimSpace(lastLine) == "" {
-		entries = entries[0 : len(entries)-1]
+	lines := strings.Split(out, "\n")
+	if lastLine := lines[len(lines)-1]; strings.TrimSpace(lastLine) == "" {
+		lines = lines[0 : len(lines)-1]
+	}
+	var items []client.ChangelogItem
+	if ic, ok := l.(itemsChangeloger); ok {
+		items = ic.Items()
+	}
+	if len(items) > 0 && len(items) != len(lines) {
+		// e.g. a format rendering multiple lines per item.
+		log.Debug("changelog lines don't match its items, ignoring them")
+		items = nil
+	}
+	entries := make([]changelogEntry, 0, len(lines))
+	for i, line := range lines {
+		entry := changelogEntry{line: line}
+		if items != nil {
+			entry.item = items[i]
+		}
+		entries = append(entries, entry)
 	}
 	if !useChangelog(ctx.Config.Changelog.Use).formatable() {
 		return entries, nil
@@ -278,10 +364,10 @@ func buildChangelog(ctx *context.Context) ([]string, error) {
 	return sortEntries(ctx, entries), nil
 }
 
-func filterEntries(ctx *context.Context, entries []string) ([]string, error) {
+func filterEntries(ctx *context.Context, entries []changelogEntry) ([]changelogEntry, error) {
 	filters := ctx.Config.Changelog.Filters
 	if len(filters.Include) > 0 {
-		var newEntries []string
+		var newEntries []changelogEntry
 		for _, filter := range filters.Include {
 			r, err := regexp.Compile(filter)
 			if err != nil {
@@ -301,16 +387,28 @@ func filterEntries(ctx *context.Context, entries []string) ([]string, error) {
 	return entries, nil
 }
 
-func sortEntries(ctx *context.Context, entries []string) []string {
+func sortEntries(ctx *context.Context, entries []changelogEntry) []changelogEntry {
 	direction := ctx.Config.Changelog.Sort
 	if direction == "" {
 		return entries
 	}
-	result := make([]string, len(entries))
+	result := make([]changelogEntry, len(entries))
 	copy(result, entries)
+	if direction == "date" {
+		sort.SliceStable(result, func(i, j int) bool {
+			idate := result[i].item.Date
+			jdate := result[j].item.Date
+			if !idate.Equal(jdate) {
+				return idate.Before(jdate)
+			}
+			// same date, sort by SHA so the result is always the same.
+			return strings.Compare(entrySHA(result[i]), entrySHA(result[j])) < 0
+		})
+		return result
+	}
 	sort.Slice(result, func(i, j int) bool {
-		imsg := extractCommitInfo(result[i])
-		jmsg := extractCommitInfo(result[j])
+		imsg := extractCommitInfo(result[i].line)
+		jmsg := extractCommitInfo(result[j].line)
 		if direction == "asc" {
 			return strings.Compare(imsg, jmsg) < 0
 		}
@@ -319,24 +417,33 @@ func sortEntries(ctx *context.Context, entries []string) []string {
 	return result
 }
 
-func keep(filter *regexp.Regexp, entries []string) (result []string) {
+func keep(filter *regexp.Regexp, entries []changelogEntry) (result []changelogEntry) {
 	for _, entry := range entries {
-		if filter.MatchString(extractCommitInfo(entry)) {
+		if filter.MatchString(extractCommitInfo(entry.line)) {
 			result = append(result, entry)
 		}
 	}
 	return result
 }
 
-func remove(filter *regexp.Regexp, entries []string) (result []string) {
+func remove(filter *regexp.Regexp, entries []changelogEntry) (result []changelogEntry) {
 	for _, entry := range entries {
-		if !filter.MatchString(extractCommitInfo(entry)) {
+		if !filter.MatchString(extractCommitInfo(entry.line)) {
 			result = append(result, entry)
 		}
 	}
 	return result
 }
 
+// entrySHA returns the SHA of the given entry.
+func entrySHA(entry changelogEntry) string {
+	if sha := entry.item.SHA; sha != "" {
+		return sha
+	}
+	sha, _, _ := strings.Cut(entry.line, " ")
+	return sha
+}
+
 func extractCommitInfo(line string) string {
 	return strings.Join(strings.Split(line, " ")[1:], " ")
 }
@@ -344,11 +451,13 @@ func extractCommitInfo(line string) string {
 func getChangeloger(ctx *context.Context) (changeloger, error) {
 	switch ctx.Config.Changelog.Use {
 	case useGit, "":
-		return gitChangeloger{}, nil
+		return &gitChangeloger{}, nil
 	case useGitLab, useGitea, useGitHub:
 		return newSCMChangeloger(ctx)
 	case useGitHubNative:
 		return newGithubChangeloger(ctx)
+	case useGitHubPR:
+		return newPullRequestChangeloger(ctx)
 	default:
 		return nil, fmt.Errorf("invalid changelog.use: %q", ctx.Config.Changelog.Use)
 	}
@@ -366,6 +475,9 @@ func newGithubChangeloger(ctx *context.Context) (changeloger, error) {
 	if err := repo.CheckSCM(); err != nil {
 		return nil, err
 	}
+	if ctx.Config.Monorepo.Dir != "" {
+		log.Warnf("changelog.use: %s does not support filtering by monorepo.dir, all changes will be included", useGitHubNative)
+	}
 	return &githubNativeChangeloger{
 		client: cli,
 		repo: client.Repo{
@@ -396,6 +508,26 @@ func newSCMChangeloger(ctx *context.Context) (changeloger, error) {
 	}, nil
 }
 
+func newPullRequestChangeloger(ctx *context.Context) (changeloger, error) {
+	scm, err := newSCMChangeloger(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c := scm.(*scmChangeloger)
+	lister, ok := c.client.(client.PullRequestLister)
+	if !ok {
+		log.WithField("token", ctx.TokenType).
+			Warnf("changelog.use: %s is not supported by this provider, using the commits instead", useGitHubPR)
+		return c, nil
+	}
+	files, _ := c.client.(client.CommitFilesLister)
+	return &pullRequestChangeloger{
+		client: lister,
+		files:  files,
+		repo:   c.repo,
+	}, nil
+}
+
 func loadContent(ctx *context.Context, fileName, tmplName string) (string, error) {
 	if tmplName != "" {
 		log.Debugf("loading template %q", tmplName)
@@ -426,24 +558,174 @@ type changeloger interface {
 	Log(ctx *context.Context) (string, error)
 }
 
-type gitChangeloger struct{}
+// itemsChangeloger is a changeloger that also knows the item behind each
+// line of its log, e.g. its labels and date, in the same order.
+type itemsChangeloger interface {
+	changeloger
+	Items() []client.ChangelogItem
+}
+
+type gitChangeloger struct {
+	items []client.ChangelogItem
+}
 
 var validSHA1 = regexp.MustCompile(`^[a-fA-F0-9]{40}$`)
 
-func (g gitChangeloger) Log(ctx *context.Context) (string, error) {
-	args := []string{"log", "--pretty=oneline", "--no-decorate", "--no-color"}
+func (g *gitChangeloger) Log(ctx *context.Context) (string, error) {
+	args := append([]string{"log", "--pretty=oneline", "--no-decorate", "--no-color"}, gitRange(ctx)...)
+	log, err := git.Run(ctx, append(args, gitPathspec(ctx)...)...)
+	if err != nil || ctx.Config.Changelog.Sort != "date" {
+		return log, err
+	}
+
+	dates := map[string]time.Time{}
+	args = append([]string{"log", "--pretty=format:%H %at", "--no-color"}, gitRange(ctx)...)
+	out, err := git.Run(ctx, append(args, gitPathspec(ctx)...)...)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(out, "\n") {
+		sha, timestamp, _ := strings.Cut(strings.TrimSpace(line), " ")
+		if sec, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+			dates[sha] = time.Unix(sec, 0).UTC()
+		}
+	}
+	g.items = nil
+	for _, line := range strings.Split(strings.TrimSuffix(log, "\n"), "\n") {
+		sha, _, _ := strings.Cut(line, " ")
+		g.items = append(g.items, client.ChangelogItem{
+			SHA:  sha,
+			Date: dates[sha],
+		})
+	}
+	return log, nil
+}
+
+func (g *gitChangeloger) Items() []client.ChangelogItem {
+	return g.items
+}
+
+// gitRange returns the git log arguments for the changelog range.
+func gitRange(ctx *context.Context) []string {
 	prev, current := comparePair(ctx)
 	if validSHA1.MatchString(prev) {
-		args = append(args, prev, current)
-	} else {
-		args = append(args, fmt.Sprintf("tags/%s..tags/%s", ctx.Git.PreviousTag, ctx.Git.CurrentTag))
+		return []string{prev, current}
 	}
-	return git.Run(ctx, args...)
+	if ctx.Nightly {
+		return []string{fmt.Sprintf("tags/%s..%s", prev, current)}
+	}
+	return []string{fmt.Sprintf("tags/%s..tags/%s", ctx.Git.PreviousTag, ctx.Git.CurrentTag)}
+}
+
+// gitPathspec returns the git log arguments that limit the changelog to the
+// monorepo directory, if any.
+func gitPathspec(ctx *context.Context) []string {
+	if dir := monorepoDir(ctx); dir != "" {
+		return []string{"--", dir}
+	}
+	return nil
+}
+
+// monorepoDir returns the cleaned monorepo.dir, or an empty string if the
+// changelog should not be filtered by it.
+func monorepoDir(ctx *context.Context) string {
+	dir := filepath.ToSlash(filepath.Clean(ctx.Config.Monorepo.Dir))
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// filterMonorepoItems keeps only the items that changed files within the
+// monorepo directory.
+//
+// The files of all the items between prev and current are listed at once
+// from the local repository. Only the items it doesn't have are listed one by
+// one, with the given lister, if any, or the local repository, and if that
+// fails as well, the item is kept.
+func filterMonorepoItems(ctx *context.Context, lister client.CommitFilesLister, repo client.Repo, prev, current string, items []client.ChangelogItem) []client.ChangelogItem {
+	dir := monorepoDir(ctx)
+	if dir == "" {
+		return items
+	}
+	local, err := rangeFiles(ctx, prev, current)
+	if err != nil {
+		log.WithError(err).
+			Debug("could not list the changed files from the local repository, listing them for each commit")
+	}
+	var result []client.ChangelogItem
+	for _, item := range items {
+		files, ok := local[item.SHA]
+		if !ok {
+			files, err = commitFiles(ctx, lister, repo, item.SHA)
+			if err != nil {
+				log.WithField("commit", item.SHA).
+					WithError(err).
+					Warnf("could not list the changed files, including it in the changelog anyway")
+				result = append(result, item)
+				continue
+			}
+		}
+		if slices.ContainsFunc(files, func(file string) bool {
+			return file == dir || strings.HasPrefix(file, dir+"/")
+		}) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// rangeFiles lists the files changed by each commit between prev and current
+// in the local repository, with a single git call.
+func rangeFiles(ctx *context.Context, prev, current string) (map[string][]string, error) {
+	ref := current
+	if prev != "" {
+		ref = prev + ".." + current
+	}
+	out, err := git.Run(
+		ctx, "log", "--format=%x00%H", "--name-only", "--no-renames",
+		"--diff-merges=first-parent", ref,
+	)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string][]string{}
+	for _, commit := range strings.Split(out, "\x00") {
+		lines := strings.Split(strings.TrimSpace(commit), "\n")
+		if lines[0] == "" {
+			continue
+		}
+		files := []string{}
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				files = append(files, line)
+			}
+		}
+		result[lines[0]] = files
+	}
+	return result, nil
+}
+
+func commitFiles(ctx *context.Context, lister client.CommitFilesLister, repo client.Repo, sha string) ([]string, error) {
+	if lister != nil {
+		files, err := lister.CommitFiles(ctx, repo, sha)
+		if err == nil {
+			return files, nil
+		}
+		log.WithField("commit", sha).
+			WithError(err).
+			Debug("could not list the changed files from the scm, trying the local repository")
+	}
+	return git.CleanAllLines(git.Run(
+		ctx, "diff-tree", "--no-commit-id", "--name-only", "-r",
+		"--diff-merges=first-parent", "--root", sha,
+	))
 }
 
 type scmChangeloger struct {
 	client client.Client
 	repo   client.Repo
+	items  []client.ChangelogItem
 }
 
 func (c *scmChangeloger) Log(ctx *context.Context) (string, error) {
@@ -452,14 +734,54 @@ func (c *scmChangeloger) Log(ctx *context.Context) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	lister, _ := c.client.(client.CommitFilesLister)
+	items = filterMonorepoItems(ctx, lister, c.repo, prev, current, items)
+	c.items = items
+	return formatItems(ctx, items)
+}
+
+func (c *scmChangeloger) Items() []client.ChangelogItem {
+	return c.items
+}
+
+type pullRequestChangeloger struct {
+	client client.PullRequestLister
+	files  client.CommitFilesLister
+	repo   client.Repo
+	items  []client.ChangelogItem
+}
+
+func (c *pullRequestChangeloger) Log(ctx *context.Context) (string, error) {
+	prev, current := comparePair(ctx)
+	items, err := c.client.MergedPullRequests(ctx, c.repo, prev, current)
+	if err != nil {
+		return "", err
+	}
+	items = filterMonorepoItems(ctx, c.files, c.repo, prev, current, items)
+	c.items = items
+	return formatItems(ctx, items)
+}
+
+func (c *pullRequestChangeloger) Items() []client.ChangelogItem {
+	return c.items
+}
+
+// formatItems formats the given items with the changelog format, one line
+// per item.
+func formatItems(ctx *context.Context, items []client.ChangelogItem) (string, error) {
+	length := shortSHALength(items, ctx.Config.Changelog.Abbrev)
 	var lines []string
 	for _, item := range items {
 		line, err := tmpl.New(ctx).WithExtraFields(tmpl.Fields{
 			"SHA":            item.SHA,
+			"ShortCommit":    item.SHA[:min(length, len(item.SHA))],
 			"Message":        item.Message,
 			"AuthorUsername": item.AuthorUsername,
 			"AuthorName":     item.AuthorName,
 			"AuthorEmail":    item.AuthorEmail,
+			"Number":         item.Number,
+			"URL":            item.URL,
+			"Labels":         item.Labels,
 		}).Apply(ctx.Config.Changelog.Format)
 		if err != nil {
 			return "", err
@@ -469,6 +791,39 @@ func (c *scmChangeloger) Log(ctx *context.Context) (string, error) {
 	return strings.Join(lines, "\n"), nil
 }
 
+// shortSHALength returns the length to abbreviate the SHAs of the given items
+// to.
+//
+// A positive abbrev is used as is, otherwise it is the shortest length that
+// keeps the SHAs unambiguous among the given items, but never shorter than
+// git's default of 7 characters.
+func shortSHALength(items []client.ChangelogItem, abbrev int) int {
+	if abbrev > 0 {
+		return abbrev
+	}
+	shas := make([]string, 0, len(items))
+	for _, item := range items {
+		shas = append(shas, item.SHA)
+	}
+	sort.Strings(shas)
+	length := minShortSHALength
+	for i := 1; i < len(shas); i++ {
+		if shas[i] == shas[i-1] {
+			continue
+		}
+		length = max(length, commonPrefixLength(shas[i-1], shas[i])+1)
+	}
+	return length
+}
+
+func commonPrefixLength(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
 type githubNativeChangeloger struct {
 	client client.ReleaseNotesGenerator
 	repo   client.Repo
@@ -484,5 +839,10 @@ func comparePair(ctx *context.Context) (prev string, current string) {
 	if prev == "" {
 		prev = ctx.Git.FirstCommit
 	}
+	if ctx.Nightly {
+		// the nightly tag does not exist yet, or points to the previous
+		// nightly.
+		current = ctx.Git.FullCommit
+	}
 	return
 }
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -281,8 +282,8 @@ func TestChangelogSort(t *testing.T) {
 			require.NoError(t, err)
 			require.Len(t, entries, len(cfg.Entries))
 			var changes []string
-			for _, line := range entries {
-				changes = append(changes, extractCommitInfo(line))
+			for _, entry := range entries {
+				changes = append(changes, extractCommitInfo(entry.line))
 			}
 			require.EqualValues(t, cfg.Entries, changes)
 		})
@@ -541,6 +542,409 @@ func TestGetChangelogGitHub(t *testing.T) {
 	require.Equal(t, expected, log)
 }
 
+func TestGetChangelogGitHubPR(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Changelog: config.Changelog{
+			Use: useGitHubPR,
+		},
+	}, testctx.WithCurrentTag("v0.180.2"), testctx.WithPreviousTag("v0.180.1"))
+	require.NoError(t, Pipe{}.Default(ctx))
+
+	mock := client.NewMock()
+	mock.PullRequests = []client.ChangelogItem{
+		{
+			SHA:            "c90f1085f255d0af0b055160bfff5ee40f47af79",
+			Message:        "fix: do not skip any defaults",
+			AuthorUsername: "caarlos0",
+			Number:         2521,
+			Labels:         []string{"bug"},
+		},
+		{
+			SHA:     "a90f1085f255d0af0b055160bfff5ee40f47af79",
+			Message: "feat: something new",
+			Number:  2522,
+		},
+	}
+	l := &pullRequestChangeloger{
+		client: mock,
+		repo: client.Repo{
+			Owner: "goreleaser",
+			Name:  "goreleaser",
+		},
+	}
+
+	log, err := l.Log(ctx)
+	require.NoError(t, err)
+	require.Equal(t, `c90f1085f255d0af0b055160bfff5ee40f47af79: fix: do not skip any defaults (#2521) (@caarlos0)
+a90f1085f255d0af0b055160bfff5ee40f47af79: feat: something new (#2522)`, log)
+	require.Len(t, l.Items(), 2)
+	require.Equal(t, []string{"bug"}, l.Items()[0].Labels)
+	require.Equal(t, 2522, l.Items()[1].Number)
+}
+
+func TestChangelogGroupByLabels(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Changelog: config.Changelog{
+			Use:    useGitHubPR,
+			Abbrev: -1,
+			Groups: []config.ChangelogGroup{
+				{Title: "Features", Labels: []string{"enhancement", "feature"}, Order: 0},
+				{Title: "Bug fixes", Labels: []string{"bug"}, Order: 1},
+				{Title: "Docs", Regexp: "docs", Order: 2},
+				{Title: "Others", Order: 999},
+			},
+		},
+	})
+	out, err := formatChangelog(
+		ctx,
+		[]changelogEntry{
+			{line: "aea123 add foo (#1)", item: client.ChangelogItem{Labels: []string{"feature"}}},
+			{line: "aef653 fix bar (#2)", item: client.ChangelogItem{Labels: []string{"bug", "cli"}}},
+			{line: "bcd123 update docs (#3)"},
+			{line: "cde123 chore (#4)"},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, `## Changelog
+### Features
+* add foo (#1)
+### Bug fixes
+* fix bar (#2)
+### Docs
+* update docs (#3)
+### Others
+* chore (#4)`, out)
+}
+
+func TestChangelogGroupByLabelsDefaultGroup(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Changelog: config.Changelog{
+			Use: useGitHubPR,
+			Groups: []config.ChangelogGroup{
+				{Title: "Bug fixes", Labels: []string{"bug"}, Order: 1},
+				{Title: "Features", Labels: []string{"enhancement"}, Order: 0},
+			},
+		},
+	})
+	out, err := formatChangelog(
+		ctx,
+		[]changelogEntry{
+			{line: "aea123 add foo (#1)", item: client.ChangelogItem{Labels: []string{"enhancement"}}},
+			{line: "aef653 fix bar (#2)", item: client.ChangelogItem{Labels: []string{"bug"}}},
+			{line: "bcd123 update docs (#3)"},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, `## Changelog
+### Features
+* aea123 add foo (#1)
+### Bug fixes
+* aef653 fix bar (#2)
+### Others
+* bcd123 update docs (#3)`, out)
+}
+
+func TestChangelogGroupByLabelsSameLine(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Changelog: config.Changelog{
+			Use: useGitHubPR,
+			Groups: []config.ChangelogGroup{
+				{Title: "Features", Labels: []string{"enhancement"}, Order: 0},
+				{Title: "Bug fixes", Labels: []string{"bug"}, Order: 1},
+			},
+		},
+	})
+	out, err := formatChangelog(
+		ctx,
+		[]changelogEntry{
+			{line: "update deps", item: client.ChangelogItem{Number: 1, Labels: []string{"enhancement"}}},
+			{line: "update deps", item: client.ChangelogItem{Number: 2, Labels: []string{"bug"}}},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, `## Changelog
+### Features
+* update deps
+### Bug fixes
+* update deps`, out)
+}
+
+func TestChangelogShortCommit(t *testing.T) {
+	items := []client.ChangelogItem{
+		{SHA: "c90f1085f255d0af0b055160bfff5ee40f47af79", Message: "foo"},
+		{SHA: "c90f1084a255d0af0b055160bfff5ee40f47af79", Message: "bar"},
+		{SHA: "a90f1085f255d0af0b055160bfff5ee40f47af79", Message: "baz"},
+	}
+	for name, tt := range map[string]struct {
+		abbrev   int
+		expected string
+	}{
+		"unambiguous": {
+			expected: "c90f1085 foo\nc90f1084 bar\na90f1085 baz",
+		},
+		"remove": {
+			abbrev:   -1,
+			expected: "c90f1085 foo\nc90f1084 bar\na90f1085 baz",
+		},
+		"fixed": {
+			abbrev:   5,
+			expected: "c90f1 foo\nc90f1 bar\na90f1 baz",
+		},
+		"longer than sha": {
+			abbrev:   50,
+			expected: "c90f1085f255d0af0b055160bfff5ee40f47af79 foo\nc90f1084a255d0af0b055160bfff5ee40f47af79 bar\na90f1085f255d0af0b055160bfff5ee40f47af79 baz",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.NewWithCfg(config.Project{
+				Changelog: config.Changelog{
+					Use:    useGitHub,
+					Format: "{{ .ShortCommit }} {{ .Message }}",
+					Abbrev: tt.abbrev,
+				},
+			})
+			out, err := formatItems(ctx, items)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, out)
+			// the SHAs are not abbreviated in place.
+			for _, item := range items {
+				require.Len(t, item.SHA, 40)
+			}
+		})
+	}
+}
+
+func TestShortSHALength(t *testing.T) {
+	require.Equal(t, 7, shortSHALength(nil, 0))
+	require.Equal(t, 7, shortSHALength([]client.ChangelogItem{
+		{SHA: "aaaaaaaaaa"},
+		{SHA: "aaaaaaaaaa"},
+	}, 0))
+	require.Equal(t, 10, shortSHALength([]client.ChangelogItem{
+		{SHA: "aaaaaaaaaaaa"},
+		{SHA: "aaaaaaaaabaa"},
+	}, 0))
+	require.Equal(t, 3, shortSHALength([]client.ChangelogItem{
+		{SHA: "aaaaaaaaaaaa"},
+	}, 3))
+}
+
+func TestSortEntriesByDate(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Changelog: config.Changelog{
+			Sort: "date",
+		},
+	})
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	entries := []changelogEntry{
+		{line: "ccc third", item: client.ChangelogItem{SHA: "ccc", Date: day(3)}},
+		{line: "bbb second, same date", item: client.ChangelogItem{SHA: "bbb", Date: day(2)}},
+		{line: "ddd no date"},
+		{line: "aaa second", item: client.ChangelogItem{SHA: "aaa", Date: day(2)}},
+		{line: "eee first", item: client.ChangelogItem{SHA: "eee", Date: day(1)}},
+	}
+	require.Equal(t, []string{
+		"ddd no date",
+		"eee first",
+		"aaa second",
+		"bbb second, same date",
+		"ccc third",
+	}, linesOf(sortEntries(ctx, entries)))
+}
+
+func TestSortEntriesByDateSameLine(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Changelog: config.Changelog{
+			Sort: "date",
+		},
+	})
+	entries := []changelogEntry{
+		{line: "update deps", item: client.ChangelogItem{SHA: "bbb", Date: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)}},
+		{line: "fix foo", item: client.ChangelogItem{SHA: "ccc", Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}},
+		{line: "update deps", item: client.ChangelogItem{SHA: "aaa", Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+	sorted := sortEntries(ctx, entries)
+	require.Equal(t, []string{"update deps", "fix foo", "update deps"}, linesOf(sorted))
+	require.Equal(t, "aaa", sorted[0].item.SHA)
+	require.Equal(t, "bbb", sorted[2].item.SHA)
+}
+
+func TestChangelogSortByDateGit(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitCommit(t, "first")
+	testlib.GitTag(t, "v0.0.1")
+	testlib.GitCommitWithDate(t, "b: newer", time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	testlib.GitCommitWithDate(t, "a: older", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	testlib.GitTag(t, "v0.0.2")
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist: folder,
+		Changelog: config.Changelog{
+			Sort:   "date",
+			Abbrev: -1,
+		},
+	}, testctx.WithCurrentTag("v0.0.2"), testctx.WithPreviousTag("v0.0.1"))
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, "## Changelog\n* a: older\n* b: newer\n", ctx.ReleaseNotes)
+}
+
+func TestChangelogMonorepo(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitCommit(t, "first")
+	testlib.GitTag(t, "service-a/v1.0.0")
+	testlib.GitCommit(t, "a: feature")
+	testlib.GitTag(t, "service-b/v2.0.0")
+	testlib.GitCommit(t, "a: fix")
+	testlib.GitTag(t, "service-a/v1.1.0")
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist:     folder,
+		Monorepo: config.Monorepo{TagPrefix: "service-a/"},
+		Changelog: config.Changelog{
+			Abbrev: -1,
+		},
+	}, testctx.WithCurrentTag("service-a/v1.1.0"), testctx.WithPreviousTag("service-a/v1.0.0"))
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, "## Changelog\n* a: fix\n* a: feature\n", ctx.ReleaseNotes)
+}
+
+func TestChangelogMonorepoDir(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitCommit(t, "first")
+	testlib.GitTag(t, "service-a/v1.0.0")
+	require.NoError(t, os.MkdirAll("service-a", 0o755))
+	require.NoError(t, os.WriteFile("service-a/main.go", []byte("package main"), 0o644))
+	testlib.GitAdd(t)
+	testlib.GitCommit(t, "a: feature")
+	require.NoError(t, os.MkdirAll("service-b", 0o755))
+	require.NoError(t, os.WriteFile("service-b/main.go", []byte("package main"), 0o644))
+	testlib.GitAdd(t)
+	testlib.GitCommit(t, "b: feature")
+	testlib.GitCommit(t, "empty")
+	require.NoError(t, os.WriteFile("service-a/main.go", []byte("package main\n"), 0o644))
+	testlib.GitAdd(t)
+	testlib.GitCommit(t, "a: fix")
+	testlib.GitTag(t, "service-a/v1.1.0")
+
+	for _, sort := range []string{"", "date"} {
+		t.Run("sort "+sort, func(t *testing.T) {
+			ctx := testctx.NewWithCfg(config.Project{
+				Dist: folder,
+				Monorepo: config.Monorepo{
+					TagPrefix: "service-a/",
+					Dir:       "./service-a/",
+				},
+				Changelog: config.Changelog{
+					Abbrev: -1,
+					Sort:   sort,
+				},
+			}, testctx.WithCurrentTag("service-a/v1.1.0"), testctx.WithPreviousTag("service-a/v1.0.0"))
+			require.NoError(t, Pipe{}.Run(ctx))
+			require.Equal(t, "## Changelog\n* a: fix\n* a: feature\n", ctx.ReleaseNotes)
+		})
+	}
+}
+
+func TestChangelogMonorepoDirSCM(t *testing.T) {
+	testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitCommit(t, "first")
+	require.NoError(t, os.MkdirAll("service-a", 0o755))
+	require.NoError(t, os.WriteFile("service-a/main.go", []byte("package main"), 0o644))
+	testlib.GitAdd(t)
+	testlib.GitCommit(t, "a: local")
+	local, err := git.Clean(git.Run(testctx.New(), "rev-parse", "HEAD"))
+	require.NoError(t, err)
+
+	ctx := testctx.NewWithCfg(config.Project{
+		Monorepo: config.Monorepo{Dir: "service-a"},
+		Changelog: config.Changelog{
+			Use: useGitHub,
+		},
+	}, testctx.WithCurrentTag("v0.2.0"), testctx.WithPreviousTag("v0.1.0"))
+	require.NoError(t, Pipe{}.Default(ctx))
+
+	mock := client.NewMock()
+	mock.Changes = []client.ChangelogItem{
+		{SHA: "a90f1085f255d0af0b055160bfff5ee40f47af79", Message: "a: remote", AuthorUsername: "caarlos0"},
+		{SHA: "b90f1085f255d0af0b055160bfff5ee40f47af79", Message: "b: remote", AuthorUsername: "caarlos0"},
+		{SHA: "c90f1085f255d0af0b055160bfff5ee40f47af79", Message: "a: prefix", AuthorUsername: "caarlos0"},
+		{SHA: local, Message: "a: local", AuthorUsername: "caarlos0"},
+		{SHA: "d90f1085f255d0af0b055160bfff5ee40f47af79", Message: "unknown", AuthorUsername: "caarlos0"},
+	}
+	mock.Files = map[string][]string{
+		"a90f1085f255d0af0b055160bfff5ee40f47af79": {"README.md", "service-a/main.go"},
+		"b90f1085f255d0af0b055160bfff5ee40f47af79": {"service-b/main.go"},
+		"c90f1085f255d0af0b055160bfff5ee40f47af79": {"service-abc/main.go"},
+	}
+	l := scmChangeloger{
+		client: mock,
+		repo: client.Repo{
+			Owner: "goreleaser",
+			Name:  "goreleaser",
+		},
+	}
+
+	log, err := l.Log(ctx)
+	require.NoError(t, err)
+	require.Equal(t, `a90f1085f255d0af0b055160bfff5ee40f47af79: a: remote (@caarlos0)
+`+local+`: a: local (@caarlos0)
+d90f1085f255d0af0b055160bfff5ee40f47af79: unknown (@caarlos0)`, log)
+}
+
+func TestChangelogMonorepoDirSCMLocalRange(t *testing.T) {
+	testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitCommit(t, "first")
+	testlib.GitTag(t, "v0.1.0")
+	require.NoError(t, os.MkdirAll("service-a", 0o755))
+	require.NoError(t, os.WriteFile("service-a/main.go", []byte("package main"), 0o644))
+	testlib.GitAdd(t)
+	testlib.GitCommit(t, "a: feature")
+	a, err := git.Clean(git.Run(testctx.New(), "rev-parse", "HEAD"))
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll("service-b", 0o755))
+	require.NoError(t, os.WriteFile("service-b/main.go", []byte("package main"), 0o644))
+	testlib.GitAdd(t)
+	testlib.GitCommit(t, "b: feature")
+	b, err := git.Clean(git.Run(testctx.New(), "rev-parse", "HEAD"))
+	require.NoError(t, err)
+	testlib.GitTag(t, "v0.2.0")
+
+	ctx := testctx.NewWithCfg(config.Project{
+		Monorepo: config.Monorepo{Dir: "service-a"},
+		Changelog: config.Changelog{
+			Use: useGitHub,
+		},
+	}, testctx.WithCurrentTag("v0.2.0"), testctx.WithPreviousTag("v0.1.0"))
+	require.NoError(t, Pipe{}.Default(ctx))
+
+	mock := client.NewMock()
+	mock.Changes = []client.ChangelogItem{
+		{SHA: b, Message: "b: feature", AuthorUsername: "caarlos0"},
+		{SHA: a, Message: "a: feature", AuthorUsername: "caarlos0"},
+	}
+	// the commits in the local range are never listed from the scm.
+	mock.Files = map[string][]string{
+		a: {"service-b/main.go"},
+		b: {"service-a/main.go"},
+	}
+	l := scmChangeloger{
+		client: mock,
+		repo: client.Repo{
+			Owner: "goreleaser",
+			Name:  "goreleaser",
+		},
+	}
+
+	log, err := l.Log(ctx)
+	require.NoError(t, err)
+	require.Equal(t, a+": a: feature (@caarlos0)", log)
+}
+
 func TestGetChangelogGitHubNative(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Changelog: config.Changelog{
@@ -601,7 +1005,7 @@ func TestGetChangeloger(t *testing.T) {
 	t.Run("default", func(t *testing.T) {
 		c, err := getChangeloger(testctx.New())
 		require.NoError(t, err)
-		require.IsType(t, gitChangeloger{}, c)
+		require.IsType(t, &gitChangeloger{}, c)
 	})
 
 	t.Run(useGit, func(t *testing.T) {
@@ -611,7 +1015,7 @@ func TestGetChangeloger(t *testing.T) {
 			},
 		}))
 		require.NoError(t, err)
-		require.IsType(t, gitChangeloger{}, c)
+		require.IsType(t, &gitChangeloger{}, c)
 	})
 
 	t.Run(useGitHub, func(t *testing.T) {
@@ -636,6 +1040,28 @@ func TestGetChangeloger(t *testing.T) {
 		require.IsType(t, &githubNativeChangeloger{}, c)
 	})
 
+	t.Run(useGitHubPR, func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Changelog: config.Changelog{
+				Use: useGitHubPR,
+			},
+		}, testctx.GitHubTokenType)
+		c, err := getChangeloger(ctx)
+		require.NoError(t, err)
+		require.IsType(t, &pullRequestChangeloger{}, c)
+	})
+
+	t.Run(useGitHubPR+"-unsupported", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Changelog: config.Changelog{
+				Use: useGitHubPR,
+			},
+		}, testctx.GitLabTokenType)
+		c, err := getChangeloger(ctx)
+		require.NoError(t, err)
+		require.IsType(t, &scmChangeloger{}, c)
+	})
+
 	t.Run(useGitHubNative+"-invalid-repo", func(t *testing.T) {
 		testlib.Mktmp(t)
 		testlib.GitInit(t)
@@ -852,10 +1278,10 @@ func TestChangelogFormat(t *testing.T) {
 			t.Run(use, func(t *testing.T) {
 				out, err := formatChangelog(
 					testctx.NewWithCfg(makeConf(use)),
-					[]string{
+					entriesOf(
 						"aea123 foo",
 						"aef653 bar",
-					},
+					),
 				)
 				require.NoError(t, err)
 				require.Equal(t, `## Changelog
@@ -867,11 +1293,11 @@ func TestChangelogFormat(t *testing.T) {
 		t.Run(useGitHubNative, func(t *testing.T) {
 			out, err := formatChangelog(
 				testctx.NewWithCfg(makeConf(useGitHubNative)),
-				[]string{
+				entriesOf(
 					"# What's changed",
 					"* aea123 foo",
 					"* aef653 bar",
-				},
+				),
 			)
 			require.NoError(t, err)
 			require.Equal(t, `# What's changed
@@ -895,11 +1321,11 @@ func TestChangelogFormat(t *testing.T) {
 		t.Run(useGitHubNative, func(t *testing.T) {
 			out, err := formatChangelog(
 				testctx.NewWithCfg(makeConf(useGitHubNative)),
-				[]string{
+				entriesOf(
 					"# What's changed",
 					"* aea123 foo",
 					"* aef653 bar",
-				},
+				),
 			)
 			require.NoError(t, err)
 			require.Equal(t, `# What's changed
@@ -910,10 +1336,10 @@ func TestChangelogFormat(t *testing.T) {
 			t.Run(use, func(t *testing.T) {
 				out, err := formatChangelog(
 					testctx.NewWithCfg(makeConf(use)),
-					[]string{
+					entriesOf(
 						"aea123 foo",
 						"aef653 bar",
-					},
+					),
 				)
 				require.NoError(t, err)
 				require.Equal(t, `## Changelog
@@ -1016,3 +1442,11 @@ func withFirstCommit(tb testing.TB) testctx.Opt {
 		ctx.Git.FirstCommit = s
 	}
 }
+
+func entriesOf(lines ...string) []changelogEntry {
+	entries := make([]changelogEntry, 0, len(lines))
+	for _, line := range lines {
+		entries = append(entries, changelogEntry{line: line})
+	}
+	return entries
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -21,11 +22,18 @@ import (
 
 const (
 	artifactChecksumExtra = "Checksum"
+	splitByID             = "by-id"
 )
 
 var (
 	errNoArtifacts = errors.New("there are no artifacts to sign")
 	lock           sync.Mutex
+
+	// checksumOf calculates the checksum of an artifact, streaming it from
+	// disk.
+	checksumOf = func(a *artifact.Artifact, algorithm string) (string, error) {
+		return a.Checksum(algorithm)
+	}
 )
 
 // Pipe for checksums.
@@ -40,10 +48,16 @@ func (Pipe) Default(ctx *context.Context) error {
 	if cs.Algorithm == "" {
 		cs.Algorithm = "sha256"
 	}
+	if !slices.Contains([]string{"", "true", "false", splitByID}, cs.Split) {
+		return fmt.Errorf("invalid checksum split %q, valid options are true, false and %q", cs.Split, splitByID)
+	}
 	if cs.NameTemplate == "" {
-		if cs.Split {
+		switch cs.Split {
+		case "true":
 			cs.NameTemplate = "{{ .ArtifactName }}.{{ .Algorithm }}"
-		} else {
+		case splitByID:
+			cs.NameTemplate = "{{ .ID }}_checksums.txt"
+		default:
 			cs.NameTemplate = "{{ .ProjectName }}_{{ .Version }}_checksums.txt"
 		}
 	}
@@ -52,8 +66,11 @@ func (Pipe) Default(ctx *context.Context) error {
 
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
-	if ctx.Config.Checksum.Split {
+	switch ctx.Config.Checksum.Split {
+	case "true":
 		return splitChecksum(ctx)
+	case splitByID:
+		return idChecksums(ctx)
 	}
 
 	return singleChecksum(ctx)
@@ -65,7 +82,8 @@ func splitChecksum(ctx *context.Context) error {
 		return err
 	}
 
-	for _, art := range artifactList {
+	filenames := make([]string, len(artifactList))
+	for i, art := range artifactList {
 		filename, err := tmpl.New(ctx).
 			WithArtifact(art).
 			WithExtraFields(tmpl.Fields{
@@ -75,19 +93,36 @@ func splitChecksum(ctx *context.Context) error {
 		if err != nil {
 			return fmt.Errorf("checksum: name template: %w", err)
 		}
-		filepath := filepath.Join(ctx.Config.Dist, filename)
-		if err := refreshOne(ctx, *art, filepath); err != nil {
-			return fmt.Errorf("checksum: %s: %w", art.Path, err)
-		}
+		filenames[i] = filename
+	}
+
+	g := semerrgroup.NewShared(ctx)
+	for i, art := range artifactList {
+		g.Go(func() error {
+			if err := refreshOne(ctx, *art, filepath.Join(ctx.Config.Dist, filenames[i])); err != nil {
+				return fmt.Errorf("checksum: %s: %w", art.Path, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	// artifacts are added after all checksums are done, so their order
+	// doesn't depend on which one finished first.
+	for i, art := range artifactList {
+		filename := filenames[i]
+		path := filepath.Join(ctx.Config.Dist, filename)
 		ctx.Artifacts.Add(&artifact.Artifact{
 			Type: artifact.Checksum,
-			Path: filepath,
+			Path: path,
 			Name: filename,
 			Extra: map[string]interface{}{
 				artifact.ExtraChecksumOf: art.Path,
 				artifact.ExtraRefresh: func() error {
 					log.WithField("file", filename).Debug("refreshing checksums")
-					return refreshOne(ctx, *art, filepath)
+					return refreshOne(ctx, *art, path)
 				},
 			},
 		})
@@ -121,8 +156,66 @@ func singleChecksum(ctx *context.Context) error {
 	return nil
 }
 
+// idChecksums creates one checksums file for each artifact ID.
+// Artifacts without an ID, such as the source archive and extra files, are
+// grouped under the project name.
+func idChecksums(ctx *context.Context) error {
+	artifactList, err := buildArtifactList(ctx)
+	if err != nil {
+		if errors.Is(err, errNoArtifacts) {
+			return nil
+		}
+		return err
+	}
+
+	groups := groupByID(ctx, artifactList)
+	ids := make([]string, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		filename, err := tmpl.New(ctx).
+			WithExtraFields(tmpl.Fields{
+				"ID":        id,
+				"Algorithm": ctx.Config.Checksum.Algorithm,
+			}).
+			Apply(ctx.Config.Checksum.NameTemplate)
+		if err != nil {
+			return fmt.Errorf("checksum: name template: %w", err)
+		}
+		filepath := filepath.Join(ctx.Config.Dist, filename)
+		if err := refreshID(ctx, id, filepath); err != nil {
+			return err
+		}
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: artifact.Checksum,
+			Path: filepath,
+			Name: filename,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: id,
+				artifact.ExtraRefresh: func() error {
+					log.WithField("file", filename).Debug("refreshing checksums")
+					return refreshID(ctx, id, filepath)
+				},
+			},
+		})
+	}
+	return nil
+}
+
+func groupByID(ctx *context.Context, artifacts []*artifact.Artifact) map[string][]*artifact.Artifact {
+	result := map[string][]*artifact.Artifact{}
+	for _, a := range artifacts {
+		id := artifact.ExtraOr(*a, artifact.ExtraID, ctx.Config.ProjectName)
+		result[id] = append(result[id], a)
+	}
+	return result
+}
+
 func refreshOne(ctx *context.Context, art artifact.Artifact, path string) error {
-	check, err := art.Checksum(ctx.Config.Checksum.Algorithm)
+	check, err := checksumOf(&art, ctx.Config.Checksum.Algorithm)
 	if err != nil {
 		return err
 	}
@@ -137,8 +230,22 @@ func refreshAll(ctx *context.Context, filepath string) error {
 	if err != nil {
 		return err
 	}
+	return writeChecksums(ctx, artifactList, filepath)
+}
+
+func refreshID(ctx *context.Context, id, filepath string) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	artifactList, err := buildArtifactList(ctx)
+	if err != nil {
+		return err
+	}
+	return writeChecksums(ctx, groupByID(ctx, artifactList)[id], filepath)
+}
 
-	g := semerrgroup.New(ctx.Parallelism)
+func writeChecksums(ctx *context.Context, artifactList []*artifact.Artifact, filepath string) error {
+	g := semerrgroup.NewShared(ctx)
 	sumLines := make([]string, len(artifactList))
 	for i, artifact := range artifactList {
 		g.Go(func() error {
@@ -151,8 +258,7 @@ func refreshAll(ctx *context.Context, filepath string) error {
 		})
 	}
 
-	err = g.Wait()
-	if err != nil {
+	if err := g.Wait(); err != nil {
 		return err
 	}
 
@@ -178,6 +284,9 @@ func buildArtifactList(ctx *context.Context) ([]*artifact.Artifact, error) {
 		artifact.ByType(artifact.UploadableBinary),
 		artifact.ByType(artifact.UploadableSourceArchive),
 		artifact.ByType(artifact.LinuxPackage),
+		artifact.ByType(artifact.Flatpak),
+		artifact.ByType(artifact.AppImage),
+		artifact.ByType(artifact.Installer),
 		artifact.ByType(artifact.SBOM),
 	)
 	if len(ctx.Config.Checksum.IDs) > 0 {
@@ -186,7 +295,13 @@ func buildArtifactList(ctx *context.Context) ([]*artifact.Artifact, error) {
 
 	artifactList := ctx.Artifacts.Filter(filter).List()
 
-	extraFiles, err := extrafiles.Find(ctx, ctx.Config.Checksum.ExtraFiles)
+	files := ctx.Config.Checksum.ExtraFiles
+	if ctx.Config.Release.IncludeExtraFilesInChecksums {
+		// release extra files are only added as artifacts when publishing,
+		// after the checksums are calculated, so they are found here instead.
+		files = append(slices.Clone(files), ctx.Config.Release.ExtraFiles...)
+	}
+	extraFiles, err := extrafiles.Find(ctx, files)
 	if err != nil {
 		return nil, err
 	}
@@ -202,12 +317,32 @@ func buildArtifactList(ctx *context.Context) ([]*artifact.Artifact, error) {
 	if len(artifactList) == 0 {
 		return nil, errNoArtifacts
 	}
+	if err := checkDuplicateNames(artifactList); err != nil {
+		return nil, err
+	}
 	return artifactList, nil
 }
 
+// checkDuplicateNames makes sure no two different files would end up with the
+// same name in the checksums, which usually happens when name templates do not
+// take the microarchitecture (e.g. {{ .Amd64 }}) into account.
+func checkDuplicateNames(artifacts []*artifact.Artifact) error {
+	paths := map[string]string{}
+	for _, a := range artifacts {
+		if path, ok := paths[a.Name]; ok && path != a.Path {
+			return fmt.Errorf(
+				"multiple artifacts named %s (%s and %s), check your name templates include the microarchitecture, e.g. {{ .Amd64 }}",
+				a.Name, path, a.Path,
+			)
+		}
+		paths[a.Name] = a.Path
+	}
+	return nil
+}
+
 func checksums(algorithm string, a *artifact.Artifact) (string, error) {
 	log.WithField("file", a.Name).Debug("checksumming")
-	sha, err := a.Checksum(algorithm)
+	sha, err := checksumOf(a, algorithm)
 	if err != nil {
 		return "", err
 	}
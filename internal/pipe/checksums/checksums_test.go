@@ -1,17 +1,21 @@
 package checksums
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/stretchr/testify/require"
 )
 
@@ -118,7 +122,7 @@ func TestPipeSplit(t *testing.T) {
 			Dist:        folder,
 			ProjectName: "foo",
 			Checksum: config.Checksum{
-				Split: true,
+				Split: "true",
 			},
 		},
 	)
@@ -164,6 +168,109 @@ func TestPipeSplit(t *testing.T) {
 	}
 }
 
+func TestPipeSplitByID(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	for name, content := range map[string]string{
+		"foo.tar.gz": "foo archive",
+		"foo.rpm":    "foo package",
+		"bar.tar.gz": "bar archive",
+		"extra.txt":  "extra file",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(folder, name), []byte(content), 0o644))
+	}
+	ctx := testctx.NewWithCfg(
+		config.Project{
+			Dist:        folder,
+			ProjectName: "proj",
+			Checksum: config.Checksum{
+				Split: "by-id",
+				ExtraFiles: []config.ExtraFile{
+					{Glob: "extra.txt"},
+				},
+			},
+		},
+		testctx.WithVersion("1.0.0"),
+	)
+	for _, a := range []struct{ name, id string }{
+		{"foo.tar.gz", "foo"},
+		{"foo.rpm", "foo"},
+		{"bar.tar.gz", "bar"},
+	} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: a.name,
+			Path: filepath.Join(folder, a.name),
+			Type: artifact.UploadableArchive,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: a.id,
+			},
+		})
+	}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	checks := ctx.Artifacts.Filter(artifact.ByType(artifact.Checksum)).List()
+	require.Len(t, checks, 3)
+
+	expected := map[string]string{
+		"bar_checksums.txt": "ef0815a6cb6fbfbdc21ec1fa1eb22eecdb6bc24fcc622fabd822abaa0d1bf2e9  bar.tar.gz\n",
+		"foo_checksums.txt": "d3796e0f77a1dfbdb2a90329e38585bf6eef2143156643bcae8f2319a62eab41  foo.rpm\n" +
+			"d2857738476acb012a7bb289376ec3e4d5dce0e5180dd952aa0d9ecf7ed562b8  foo.tar.gz\n",
+		"proj_checksums.txt": "c31312ed7409d713d9ed6493d52c99c434f2143098eb56fb9755c266b76fb1d4  extra.txt\n",
+	}
+	for _, check := range checks {
+		_, ok := expected[check.Name]
+		require.True(t, ok, check.Name)
+		bts, err := os.ReadFile(check.Path)
+		require.NoError(t, err)
+		require.Equal(t, expected[check.Name], string(bts), check.Name)
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "bar.tar.gz"), []byte("bar archive v2"), 0o644))
+	require.NoError(t, ctx.Artifacts.Visit(func(a *artifact.Artifact) error {
+		return a.Refresh()
+	}))
+	bts, err := os.ReadFile(filepath.Join(folder, "bar_checksums.txt"))
+	require.NoError(t, err)
+	require.NotEqual(t, expected["bar_checksums.txt"], string(bts))
+	require.Contains(t, string(bts), "  bar.tar.gz\n")
+}
+
+func TestPipeSplitByIDNameTemplate(t *testing.T) {
+	folder := t.TempDir()
+	file := filepath.Join(folder, "binary")
+	require.NoError(t, os.WriteFile(file, []byte("some string"), 0o644))
+	ctx := testctx.NewWithCfg(
+		config.Project{
+			Dist:        folder,
+			ProjectName: "proj",
+			Checksum: config.Checksum{
+				Split:        "by-id",
+				NameTemplate: "{{ .ProjectName }}_{{ .Version }}_{{ .ID }}.{{ .Algorithm }}",
+			},
+		},
+		testctx.WithVersion("1.0.0"),
+	)
+	for _, id := range []string{"foo", "bar"} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: id + ".bin",
+			Path: file,
+			Type: artifact.UploadableBinary,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: id,
+			},
+		})
+	}
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	var names []string
+	for _, check := range ctx.Artifacts.Filter(artifact.ByType(artifact.Checksum)).List() {
+		names = append(names, check.Name)
+		require.FileExists(t, check.Path)
+	}
+	require.ElementsMatch(t, []string{"proj_1.0.0_foo.sha256", "proj_1.0.0_bar.sha256"}, names)
+}
+
 func TestRefreshModifying(t *testing.T) {
 	const binary = "binary"
 	folder := t.TempDir()
@@ -204,7 +311,7 @@ func TestRefreshModifyingSplit(t *testing.T) {
 		Dist:        folder,
 		ProjectName: binary,
 		Checksum: config.Checksum{
-			Split: true,
+			Split: "true",
 		},
 		Env: []string{"FOO=bar"},
 	}, testctx.WithCurrentTag("1.2.3"))
@@ -245,6 +352,40 @@ func TestPipeFileNotExist(t *testing.T) {
 	require.ErrorIs(t, Pipe{}.Run(ctx), os.ErrNotExist)
 }
 
+func TestPipeDuplicateNames(t *testing.T) {
+	folder := t.TempDir()
+	for _, amd64 := range []string{"v1", "v3"} {
+		path := filepath.Join(folder, amd64, "bin.tar.gz")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(amd64), 0o644))
+	}
+	for _, split := range []string{"false", "true", "by-id"} {
+		t.Run("split "+split, func(t *testing.T) {
+			ctx := testctx.NewWithCfg(
+				config.Project{
+					Dist: folder,
+					Checksum: config.Checksum{
+						Split: split,
+					},
+				},
+				testctx.WithCurrentTag("1.2.3"),
+			)
+			require.NoError(t, Pipe{}.Default(ctx))
+			for _, amd64 := range []string{"v1", "v3"} {
+				ctx.Artifacts.Add(&artifact.Artifact{
+					Name:    "bin.tar.gz",
+					Path:    filepath.Join(folder, amd64, "bin.tar.gz"),
+					Goos:    "linux",
+					Goarch:  "amd64",
+					Goamd64: amd64,
+					Type:    artifact.UploadableArchive,
+				})
+			}
+			require.ErrorContains(t, Pipe{}.Run(ctx), "multiple artifacts named bin.tar.gz")
+		})
+	}
+}
+
 func TestPipeInvalidNameTemplate(t *testing.T) {
 	binFile, err := os.CreateTemp(t.TempDir(), "goreleasertest-bin")
 	require.NoError(t, err)
@@ -256,8 +397,8 @@ func TestPipeInvalidNameTemplate(t *testing.T) {
 		"{{ .Pro }_checksums.txt",
 		"{{.Env.NOPE}}",
 	} {
-		for _, split := range []bool{true, false} {
-			t.Run(fmt.Sprintf("split_%v_%s", split, template), func(t *testing.T) {
+		for _, split := range []string{"true", "false", "by-id"} {
+			t.Run(fmt.Sprintf("split_%s_%s", split, template), func(t *testing.T) {
 				folder := t.TempDir()
 				ctx := testctx.NewWithCfg(
 					config.Project{
@@ -334,7 +475,7 @@ func TestDefault(t *testing.T) {
 func TestDefaultSPlit(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Checksum: config.Checksum{
-			Split: true,
+			Split: "true",
 		},
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
@@ -346,6 +487,25 @@ func TestDefaultSPlit(t *testing.T) {
 	require.Equal(t, "sha256", ctx.Config.Checksum.Algorithm)
 }
 
+func TestDefaultSplitByID(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Checksum: config.Checksum{
+			Split: "by-id",
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "{{ .ID }}_checksums.txt", ctx.Config.Checksum.NameTemplate)
+}
+
+func TestDefaultInvalidSplit(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Checksum: config.Checksum{
+			Split: "by-os",
+		},
+	})
+	require.EqualError(t, Pipe{}.Default(ctx), `invalid checksum split "by-os", valid options are true, false and "by-id"`)
+}
+
 func TestDefaultSet(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Checksum: config.Checksum{
@@ -509,6 +669,51 @@ func TestPipeCheckSumsWithExtraFiles(t *testing.T) {
 	}
 }
 
+func TestPipeCheckSumsWithReleaseExtraFiles(t *testing.T) {
+	for name, include := range map[string]bool{
+		"included":     true,
+		"not included": false,
+	} {
+		t.Run(name, func(t *testing.T) {
+			folder := t.TempDir()
+			file := filepath.Join(folder, "binary")
+			require.NoError(t, os.WriteFile(file, []byte("some string"), 0o644))
+			ctx := testctx.NewWithCfg(
+				config.Project{
+					Dist:        folder,
+					ProjectName: "binary",
+					Checksum: config.Checksum{
+						Algorithm:    "sha256",
+						NameTemplate: "checksums.txt",
+					},
+					Release: config.Release{
+						ExtraFiles: []config.ExtraFile{
+							{Glob: "./testdata/foo.txt", NameTemplate: "docs.txt"},
+						},
+						IncludeExtraFilesInChecksums: include,
+					},
+				},
+			)
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Name: "binary",
+				Path: file,
+				Type: artifact.UploadableBinary,
+			})
+
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			bts, err := os.ReadFile(filepath.Join(folder, "checksums.txt"))
+			require.NoError(t, err)
+			require.Contains(t, string(bts), "61d034473102d7dac305902770471fd50f4c5b26f6831a56dd90b5184b3c30fc  binary")
+			if include {
+				require.Contains(t, string(bts), "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  docs.txt")
+			} else {
+				require.NotContains(t, string(bts), "docs.txt")
+			}
+		})
+	}
+}
+
 func TestExtraFilesNoMatch(t *testing.T) {
 	dir := t.TempDir()
 	ctx := testctx.NewWithCfg(
@@ -549,3 +754,106 @@ func TestSkip(t *testing.T) {
 }
 
 // TODO: add tests for LinuxPackage and UploadableSourceArchive
+
+func TestPipeDeterministicOrder(t *testing.T) {
+	names := []string{"a.tar.gz", "b.tar.gz", "c.tar.gz", "d.tar.gz", "e.tar.gz"}
+
+	// the first artifacts take the longest, so they finish last.
+	previous := checksumOf
+	checksumOf = func(a *artifact.Artifact, algorithm string) (string, error) {
+		idx := slices.Index(names, a.Name)
+		time.Sleep(time.Duration(len(names)-idx) * 10 * time.Millisecond)
+		return previous(a, algorithm)
+	}
+	t.Cleanup(func() { checksumOf = previous })
+
+	setup := func(tb testing.TB, split string) *context.Context {
+		tb.Helper()
+		folder := tb.TempDir()
+		ctx := testctx.NewWithCfg(config.Project{
+			Dist:        folder,
+			ProjectName: "foo",
+			Checksum: config.Checksum{
+				NameTemplate: "checksums.txt",
+				Split:        split,
+			},
+		}, testctx.WithParallelism(len(names)))
+		// added in reverse order, so the list isn't sorted already.
+		for i := len(names) - 1; i >= 0; i-- {
+			path := filepath.Join(folder, names[i])
+			require.NoError(tb, os.WriteFile(path, []byte(names[i]), 0o644))
+			ctx.Artifacts.Add(&artifact.Artifact{
+				Name: names[i],
+				Path: path,
+				Type: artifact.UploadableArchive,
+			})
+		}
+		if split == "true" {
+			ctx.Config.Checksum.NameTemplate = ""
+		}
+		require.NoError(tb, Pipe{}.Default(ctx))
+		return ctx
+	}
+
+	t.Run("single", func(t *testing.T) {
+		ctx := setup(t, "")
+		require.NoError(t, Pipe{}.Run(ctx))
+
+		bts, err := os.ReadFile(filepath.Join(ctx.Config.Dist, "checksums.txt"))
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(bts)), "\n")
+		require.Len(t, lines, len(names))
+		for i, name := range names {
+			require.True(t, strings.HasSuffix(lines[i], "  "+name), lines[i])
+		}
+	})
+
+	t.Run("split", func(t *testing.T) {
+		ctx := setup(t, "true")
+		require.NoError(t, Pipe{}.Run(ctx))
+
+		checks := ctx.Artifacts.Filter(artifact.ByType(artifact.Checksum)).List()
+		require.Len(t, checks, len(names))
+		for i, check := range checks {
+			// same order as the artifacts they are the checksum of.
+			require.Equal(t, names[len(names)-1-i]+".sha256", check.Name)
+		}
+	})
+}
+
+func BenchmarkPipe(b *testing.B) {
+	folder := b.TempDir()
+	content := bytes.Repeat([]byte("a"), 8*1024*1024)
+	var paths []string
+	for i := range 16 {
+		path := filepath.Join(folder, fmt.Sprintf("file%d.tar.gz", i))
+		require.NoError(b, os.WriteFile(path, content, 0o644))
+		paths = append(paths, path)
+	}
+
+	for _, split := range []string{"false", "true"} {
+		for _, parallelism := range []int{1, 4} {
+			b.Run(fmt.Sprintf("split=%s/parallelism=%d", split, parallelism), func(b *testing.B) {
+				b.SetBytes(int64(len(paths) * len(content)))
+				for range b.N {
+					b.StopTimer()
+					ctx := testctx.NewWithCfg(config.Project{
+						Dist:        b.TempDir(),
+						ProjectName: "foo",
+						Checksum:    config.Checksum{Split: split},
+					}, testctx.WithParallelism(parallelism))
+					for _, path := range paths {
+						ctx.Artifacts.Add(&artifact.Artifact{
+							Name: filepath.Base(path),
+							Path: path,
+							Type: artifact.UploadableArchive,
+						})
+					}
+					require.NoError(b, Pipe{}.Default(ctx))
+					b.StartTimer()
+					require.NoError(b, Pipe{}.Run(ctx))
+				}
+			})
+		}
+	}
+}
@@ -5,13 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"text/template"
 
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
@@ -27,12 +27,13 @@ const nupkgFormat = "nupkg"
 const chocoConfigExtra = "ChocolateyConfig"
 
 // cmd represents a command executor.
-var cmd cmder = stdCmd{}
+var cmd shell.Runner = shell.StdRunner{}
 
 // Pipe for chocolatey packaging.
 type Pipe struct{}
 
 func (Pipe) String() string        { return "chocolatey packages" }
+func (Pipe) Key() string           { return "chocolatey" }
 func (Pipe) ContinueOnError() bool { return true }
 func (Pipe) Skip(ctx *context.Context) bool {
 	return skips.Any(ctx, skips.Chocolatey) || len(ctx.Config.Chocolateys) == 0
@@ -155,7 +156,10 @@ func doRun(ctx *context.Context, cl client.ReleaseURLTemplater, choco config.Cho
 	}
 
 	log.WithField("nuspec", nuspecFile).Info("packing")
-	out, err := cmd.Exec(ctx, "choco", "pack", nuspecFile, "--out", ctx.Config.Dist)
+	out, err := cmd.Exec(ctx, shell.Cmd{
+		Name: "choco",
+		Args: []string{"pack", nuspecFile, "--out", ctx.Config.Dist},
+	})
 	if err != nil {
 		return fmt.Errorf("failed to generate chocolatey package: %w: %s", err, string(out))
 	}
@@ -207,7 +211,7 @@ func doPush(ctx *context.Context, art *artifact.Artifact) error {
 		filepath.Clean(art.Path),
 	}
 
-	if out, err := cmd.Exec(ctx, "choco", args...); err != nil {
+	if out, err := cmd.Exec(ctx, shell.Cmd{Name: "choco", Args: args}); err != nil {
 		return fmt.Errorf("failed to push chocolatey package: %w: %s", err, string(out))
 	}
 
@@ -317,24 +321,3 @@ func dataFor(ctx *context.Context, cl client.ReleaseURLTemplater, choco config.C
 
 	return result, nil
 }
-
-// cmder is a special interface to execute external commands.
-//
-// The intention is to be used to wrap the standard exec and provide the
-// ability to create a fake one for testing.
-type cmder interface {
-	// Exec executes a command.
-	Exec(*context.Context, string, ...string) ([]byte, error)
-}
-
-// stdCmd uses the standard golang exec.
-type stdCmd struct{}
-
-var _ cmder = &stdCmd{}
-
-func (stdCmd) Exec(ctx *context.Context, name string, args ...string) ([]byte, error) {
-	log.WithField("cmd", name).
-		WithField("args", args).
-		Debug("running")
-	return exec.CommandContext(ctx, name, args...).CombinedOutput()
-}
@@ -9,6 +9,7 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/client"
 	"github.com/goreleaser/goreleaser/v2/internal/golden"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
@@ -126,7 +127,7 @@ func Test_doRun(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			cmd = fakeCmd{execFn: tt.exec}
 			t.Cleanup(func() {
-				cmd = stdCmd{}
+				cmd = shell.StdRunner{}
 			})
 
 			ctx := testctx.NewWithCfg(
@@ -306,7 +307,7 @@ func TestPublish(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			cmd = fakeCmd{execFn: tt.exec}
 			t.Cleanup(func() {
-				cmd = stdCmd{}
+				cmd = shell.StdRunner{}
 			})
 
 			ctx := testctx.New()
@@ -335,10 +336,10 @@ type fakeCmd struct {
 	execFn func(cmd string, args ...string) ([]byte, error)
 }
 
-var _ cmder = fakeCmd{}
+var _ shell.Runner = fakeCmd{}
 
-func (f fakeCmd) Exec(_ *context.Context, cmd string, args ...string) ([]byte, error) {
-	return f.execFn(cmd, args...)
+func (f fakeCmd) Exec(_ *context.Context, cmd shell.Cmd) ([]byte, error) {
+	return f.execFn(cmd.Name, cmd.Args...)
 }
 
 func checkPushCmd(tb testing.TB, cmd string, args ...string) {
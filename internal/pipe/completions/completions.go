@@ -0,0 +1,113 @@
+// Package completions provides a Pipe that registers shell completions and
+// man pages as artifacts, so packagers can install them.
+package completions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// Pipe for shell completions and man pages.
+type Pipe struct{}
+
+func (Pipe) String() string { return "shell completions and man pages" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return len(ctx.Config.Completions) == 0 && len(ctx.Config.ManPages) == 0
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	for i := range ctx.Config.Completions {
+		completion := &ctx.Config.Completions[i]
+		if completion.Name == "" {
+			completion.Name = ctx.Config.ProjectName
+		}
+	}
+	return nil
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	for _, completion := range ctx.Config.Completions {
+		if err := addCompletion(ctx, completion); err != nil {
+			return err
+		}
+	}
+	for _, man := range ctx.Config.ManPages {
+		if err := addManPage(ctx, man); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addCompletion(ctx *context.Context, completion config.Completion) error {
+	t := tmpl.New(ctx)
+	src, err := t.Apply(completion.Src)
+	if err != nil {
+		return err
+	}
+	binary, err := t.Apply(completion.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("completion: %w", err)
+	}
+
+	name, err := artifact.CompletionName(completion.Shell, binary)
+	if err != nil {
+		return fmt.Errorf("completion %s: %w", src, err)
+	}
+
+	log.WithField("shell", completion.Shell).
+		WithField("src", src).
+		Debug("adding completion")
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.Completion,
+		Name: name,
+		Path: src,
+		Extra: map[string]any{
+			artifact.ExtraShell:  completion.Shell,
+			artifact.ExtraBinary: binary,
+		},
+	})
+	return nil
+}
+
+func addManPage(ctx *context.Context, man config.ManPage) error {
+	src, err := tmpl.New(ctx).Apply(man.Src)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("man page: %w", err)
+	}
+
+	name := filepath.Base(src)
+	section := strings.TrimPrefix(filepath.Ext(strings.TrimSuffix(name, ".gz")), ".")
+	if section == "" {
+		return fmt.Errorf("man page %s: missing section extension, e.g. %s.1", src, name)
+	}
+
+	log.WithField("section", section).
+		WithField("src", src).
+		Debug("adding man page")
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.ManPage,
+		Name: name,
+		Path: src,
+		Extra: map[string]any{
+			artifact.ExtraSection: section,
+		},
+	})
+	return nil
+}
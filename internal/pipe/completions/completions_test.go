@@ -0,0 +1,162 @@
+package completions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.New()))
+	})
+
+	t.Run("completions", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Completions: []config.Completion{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("man pages", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			ManPages: []config.ManPage{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Completions: []config.Completion{
+			{Shell: "bash"},
+			{Shell: "zsh", Name: "bar"},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, "foo", ctx.Config.Completions[0].Name)
+	require.Equal(t, "bar", ctx.Config.Completions[1].Name)
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"foo.bash", "foo.zsh", "foo.fish", "foo.1", "foo.5.gz"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644))
+	}
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Completions: []config.Completion{
+			{Shell: "bash", Src: filepath.Join(dir, "{{ .ProjectName }}.bash")},
+			{Shell: "zsh", Src: filepath.Join(dir, "foo.zsh")},
+			{Shell: "fish", Src: filepath.Join(dir, "foo.fish"), Name: "{{ .ProjectName }}ctl"},
+		},
+		ManPages: []config.ManPage{
+			{Src: filepath.Join(dir, "foo.1")},
+			{Src: filepath.Join(dir, "foo.5.gz")},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	completions := ctx.Artifacts.Filter(artifact.ByType(artifact.Completion)).List()
+	require.Len(t, completions, 3)
+	for i, expected := range []struct {
+		name, shell, binary string
+	}{
+		{"foo", "bash", "foo"},
+		{"_foo", "zsh", "foo"},
+		{"fooctl.fish", "fish", "fooctl"},
+	} {
+		require.Equal(t, expected.name, completions[i].Name)
+		require.Equal(t, expected.shell, artifact.ExtraOr(*completions[i], artifact.ExtraShell, ""))
+		require.Equal(t, expected.binary, artifact.ExtraOr(*completions[i], artifact.ExtraBinary, ""))
+	}
+
+	manPages := ctx.Artifacts.Filter(artifact.ByType(artifact.ManPage)).List()
+	require.Len(t, manPages, 2)
+	require.Equal(t, "foo.1", manPages[0].Name)
+	require.Equal(t, "1", artifact.ExtraOr(*manPages[0], artifact.ExtraSection, ""))
+	require.Equal(t, "foo.5.gz", manPages[1].Name)
+	require.Equal(t, "5", artifact.ExtraOr(*manPages[1], artifact.ExtraSection, ""))
+}
+
+func TestRunErrors(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"foo.bash", "foo"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644))
+	}
+
+	t.Run("invalid shell", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Completions: []config.Completion{
+				{Shell: "tcsh", Src: filepath.Join(dir, "foo.bash")},
+			},
+		})
+		require.ErrorContains(t, Pipe{}.Run(ctx), `invalid shell: "tcsh"`)
+	})
+
+	t.Run("missing completion", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Completions: []config.Completion{
+				{Shell: "bash", Src: filepath.Join(dir, "nope.bash")},
+			},
+		})
+		require.ErrorIs(t, Pipe{}.Run(ctx), os.ErrNotExist)
+	})
+
+	t.Run("missing man page", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			ManPages: []config.ManPage{
+				{Src: filepath.Join(dir, "nope.1")},
+			},
+		})
+		require.ErrorIs(t, Pipe{}.Run(ctx), os.ErrNotExist)
+	})
+
+	t.Run("missing section", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			ManPages: []config.ManPage{
+				{Src: filepath.Join(dir, "foo")},
+			},
+		})
+		require.ErrorContains(t, Pipe{}.Run(ctx), "missing section extension")
+	})
+
+	t.Run("bad completion template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Completions: []config.Completion{
+				{Shell: "bash", Src: "{{ .Nope }"},
+			},
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+
+	t.Run("bad name template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Completions: []config.Completion{
+				{Shell: "bash", Src: filepath.Join(dir, "foo.bash"), Name: "{{ .Nope }"},
+			},
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+
+	t.Run("bad man page template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			ManPages: []config.ManPage{
+				{Src: "{{ .Nope }"},
+			},
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
@@ -10,5 +10,6 @@ import (
 type Pipe struct{}
 
 func (Pipe) String() string                     { return "custom publisher" }
+func (Pipe) Key() string                        { return "custompublishers" }
 func (Pipe) Skip(ctx *context.Context) bool     { return len(ctx.Config.Publishers) == 0 }
 func (Pipe) Publish(ctx *context.Context) error { return exec.Execute(ctx, ctx.Config.Publishers) }
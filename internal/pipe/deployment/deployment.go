@@ -0,0 +1,94 @@
+// Package deployment implements updating the status of a GitHub deployment
+// after a release.
+package deployment
+
+import (
+	stdctx "context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// EnvDeploymentID is the environment variable holding the ID of the
+// deployment to update.
+const EnvDeploymentID = "GITHUB_DEPLOYMENT_ID"
+
+const (
+	stateSuccess = "success"
+	stateFailure = "failure"
+)
+
+// reportTimeout is how long updating the deployment status can take.
+const reportTimeout = 30 * time.Second
+
+// Pipe for GitHub deployment statuses.
+type Pipe struct{}
+
+func (Pipe) String() string { return "github deployment status" }
+
+func (Pipe) Skip(ctx *context.Context) bool {
+	return ctx.Env[EnvDeploymentID] == "" ||
+		ctx.TokenType != context.TokenTypeGitHub ||
+		ctx.Snapshot ||
+		skips.Any(ctx, skips.Publish)
+}
+
+// Report creates a deployment status for the deployment in
+// $GITHUB_DEPLOYMENT_ID, which is successful if the given result is nil,
+// and failed otherwise.
+func (p Pipe) Report(ctx *context.Context, result error) error {
+	if p.Skip(ctx) {
+		log.Debugf("skipped %s", p.String())
+		return nil
+	}
+	rctx, cancel := reportContext(ctx)
+	defer cancel()
+	c, err := client.New(rctx)
+	if err != nil {
+		return err
+	}
+	return doReport(rctx, c, result)
+}
+
+// reportContext returns a copy of the context that is not canceled with it,
+// as the release might have failed precisely because it was canceled, e.g.,
+// by --timeout, and the deployment still needs to know about it.
+func reportContext(ctx *context.Context) (*context.Context, stdctx.CancelFunc) {
+	rctx := *ctx
+	var cancel stdctx.CancelFunc
+	rctx.Context, cancel = stdctx.WithTimeout(stdctx.WithoutCancel(ctx), reportTimeout)
+	return &rctx, cancel
+}
+
+func doReport(ctx *context.Context, cli client.Client, result error) error {
+	creator, ok := cli.(client.DeploymentStatusCreator)
+	if !ok {
+		return client.ErrNotImplemented
+	}
+
+	id, err := strconv.ParseInt(ctx.Env[EnvDeploymentID], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", EnvDeploymentID, err)
+	}
+
+	state := stateSuccess
+	if result != nil {
+		state = stateFailure
+	}
+
+	repo := client.Repo{
+		Owner: ctx.Config.Release.GitHub.Owner,
+		Name:  ctx.Config.Release.GitHub.Name,
+	}
+
+	log.WithField("deployment", id).
+		WithField("repo", repo.String()).
+		WithField("state", state).
+		Info("updating deployment status")
+	return creator.CreateDeploymentStatus(ctx, repo, id, state, ctx.ReleaseURL)
+}
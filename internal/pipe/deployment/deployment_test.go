@@ -0,0 +1,109 @@
+package deployment
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	withID := func(ctx *context.Context) {
+		ctx.Env[EnvDeploymentID] = "123"
+	}
+
+	t.Run("no deployment id", func(t *testing.T) {
+		ctx := testctx.New(testctx.GitHubTokenType)
+		require.True(t, Pipe{}.Skip(ctx))
+		require.NoError(t, Pipe{}.Report(ctx, nil))
+	})
+
+	t.Run("not github", func(t *testing.T) {
+		ctx := testctx.New(testctx.GitLabTokenType, withID)
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		ctx := testctx.New(testctx.GitHubTokenType, testctx.Snapshot, withID)
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("skip publish", func(t *testing.T) {
+		ctx := testctx.New(testctx.GitHubTokenType, testctx.Skip(skips.Publish), withID)
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.New(testctx.GitHubTokenType, withID)
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestReport(t *testing.T) {
+	newCtx := func(id string) *context.Context {
+		ctx := testctx.NewWithCfg(config.Project{
+			Release: config.Release{
+				GitHub: config.Repo{
+					Owner: "goreleaser",
+					Name:  "goreleaser",
+				},
+			},
+		}, testctx.GitHubTokenType)
+		ctx.Env[EnvDeploymentID] = id
+		ctx.ReleaseURL = "https://github.com/goreleaser/goreleaser/releases/tag/v1.0.0"
+		return ctx
+	}
+
+	t.Run("success", func(t *testing.T) {
+		cli := client.NewMock()
+		require.NoError(t, doReport(newCtx("123"), cli, nil))
+		require.Equal(t, int64(123), cli.DeploymentID)
+		require.Equal(t, "success", cli.DeploymentState)
+		require.Equal(t, "https://github.com/goreleaser/goreleaser/releases/tag/v1.0.0", cli.DeploymentURL)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		cli := client.NewMock()
+		require.NoError(t, doReport(newCtx("123"), cli, errors.New("fake")))
+		require.Equal(t, int64(123), cli.DeploymentID)
+		require.Equal(t, "failure", cli.DeploymentState)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		cli := client.NewMock()
+		require.ErrorContains(t, doReport(newCtx("nope"), cli, nil), "invalid GITHUB_DEPLOYMENT_ID")
+		require.Empty(t, cli.DeploymentState)
+	})
+
+	t.Run("not implemented", func(t *testing.T) {
+		require.ErrorIs(t, doReport(newCtx("123"), notImplementedClient{}, nil), client.ErrNotImplemented)
+	})
+}
+
+func TestReportContext(t *testing.T) {
+	ctx, cancel := context.NewWithTimeout(config.Project{}, time.Hour)
+	cancel()
+	require.Error(t, ctx.Err())
+
+	rctx, rcancel := reportContext(ctx)
+	defer rcancel()
+	require.NoError(t, rctx.Err())
+	deadline, ok := rctx.Deadline()
+	require.True(t, ok)
+	require.WithinDuration(t, time.Now().Add(reportTimeout), deadline, time.Minute)
+	require.Equal(t, ctx.Config, rctx.Config)
+}
+
+type notImplementedClient struct {
+	client.Client
+}
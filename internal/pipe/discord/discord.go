@@ -7,10 +7,13 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"unicode/utf8"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -21,10 +24,26 @@ const (
 	defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}`
 )
 
+// https://discord.com/developers/docs/resources/message#embed-object-embed-limits
+const (
+	embedTitleLimit       = 256
+	embedDescriptionLimit = 4096
+	embedFieldNameLimit   = 256
+	embedFieldValueLimit  = 1024
+	embedTotalLimit       = 6000
+	truncatedNotice       = "... (truncated)"
+)
+
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "discord" }
-func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Announce.Discord.Enabled }
+func (Pipe) String() string                        { return "discord" }
+func (Pipe) Key() string                           { return "discord" }
+func (Pipe) Len(ctx *context.Context) int          { return len(ctx.Config.Announce.Discord) }
+func (Pipe) Skip(ctx *context.Context, i int) bool { return !ctx.Config.Announce.Discord[i].Enabled }
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.Discord[i].AnnounceConditions
+}
 
 type Config struct {
 	WebhookID    string `env:"DISCORD_WEBHOOK_ID,notEmpty"`
@@ -32,23 +51,26 @@ type Config struct {
 }
 
 func (p Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.Discord.MessageTemplate == "" {
-		ctx.Config.Announce.Discord.MessageTemplate = defaultMessageTemplate
-	}
-	if ctx.Config.Announce.Discord.IconURL == "" {
-		ctx.Config.Announce.Discord.IconURL = defaultIcon
-	}
-	if ctx.Config.Announce.Discord.Author == "" {
-		ctx.Config.Announce.Discord.Author = defaultAuthor
-	}
-	if ctx.Config.Announce.Discord.Color == "" {
-		ctx.Config.Announce.Discord.Color = defaultColor
+	for i := range ctx.Config.Announce.Discord {
+		conf := &ctx.Config.Announce.Discord[i]
+		if conf.MessageTemplate == "" && conf.MessageTemplateFile == "" {
+			conf.MessageTemplate = defaultMessageTemplate
+		}
+		if conf.IconURL == "" {
+			conf.IconURL = defaultIcon
+		}
+		if conf.Author == "" {
+			conf.Author = defaultAuthor
+		}
+		if conf.Color == "" {
+			conf.Color = defaultColor
+		}
 	}
 	return nil
 }
 
-func (p Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Discord.MessageTemplate)
+func (p Pipe) Announce(ctx *context.Context, i int) error {
+	embed, err := buildEmbed(ctx, ctx.Config.Announce.Discord[i])
 	if err != nil {
 		return fmt.Errorf("discord: %w", err)
 	}
@@ -58,12 +80,7 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		return fmt.Errorf("discord: %w", err)
 	}
 
-	log.Infof("posting: '%s'", msg)
-
-	color, err := strconv.Atoi(ctx.Config.Announce.Discord.Color)
-	if err != nil {
-		return fmt.Errorf("discord: %w", err)
-	}
+	log.Infof("posting: '%s'", embed.Title+embed.Description)
 
 	u, err := url.Parse("https://discord.com/api")
 	if err != nil {
@@ -72,22 +89,23 @@ func (p Pipe) Announce(ctx *context.Context) error {
 	u = u.JoinPath("webhooks", cfg.WebhookID, cfg.WebhookToken)
 
 	bts, err := json.Marshal(WebhookMessageCreate{
-		Embeds: []Embed{
-			{
-				Author: &EmbedAuthor{
-					Name:    ctx.Config.Announce.Discord.Author,
-					IconURL: ctx.Config.Announce.Discord.IconURL,
-				},
-				Description: msg,
-				Color:       color,
-			},
-		},
+		Embeds: []Embed{embed},
 	})
 	if err != nil {
 		return fmt.Errorf("discord: %w", err)
 	}
 
-	resp, err := http.Post(u.String(), "application/json", bytes.NewReader(bts))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(bts))
+	if err != nil {
+		return fmt.Errorf("discord: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client, err := httpclient.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("discord: %w", err)
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("discord: %w", err)
 	}
@@ -100,14 +118,114 @@ func (p Pipe) Announce(ctx *context.Context) error {
 	return nil
 }
 
+// buildEmbed builds the embed to post, either from the embed configuration,
+// or with the message as its description if it isn't set.
+func buildEmbed(ctx *context.Context, conf config.Discord) (Embed, error) {
+	embed := Embed{
+		Author: &EmbedAuthor{
+			Name:    conf.Author,
+			IconURL: conf.IconURL,
+		},
+	}
+
+	t := tmpl.New(ctx)
+	var description string
+	if isEmbedSet(conf.Embed) {
+		t = t.WithExtraFields(tmpl.Fields{
+			"ArtifactCount": len(ctx.Artifacts.List()),
+		})
+		title, err := t.Apply(conf.Embed.Title)
+		if err != nil {
+			return embed, err
+		}
+		embed.Title = truncate(title, embedTitleLimit)
+		description, err = t.Apply(conf.Embed.Description)
+		if err != nil {
+			return embed, err
+		}
+		for _, field := range conf.Embed.Fields {
+			name, err := t.Apply(field.Name)
+			if err != nil {
+				return embed, err
+			}
+			value, err := t.Apply(field.Value)
+			if err != nil {
+				return embed, err
+			}
+			embed.Fields = append(embed.Fields, EmbedField{
+				Name:   truncate(name, embedFieldNameLimit),
+				Value:  truncate(value, embedFieldValueLimit),
+				Inline: field.Inline,
+			})
+		}
+	} else {
+		msg, err := t.ApplyInlineOrFile(conf.MessageTemplate, conf.MessageTemplateFile)
+		if err != nil {
+			return embed, err
+		}
+		description = msg
+	}
+
+	// the description gets whatever is left of the total limit.
+	embed.Description = truncate(description, max(0, min(
+		embedDescriptionLimit,
+		embedTotalLimit-embed.size(),
+	)))
+
+	color, err := strconv.Atoi(conf.Color)
+	if err != nil {
+		return embed, err
+	}
+	embed.Color = color
+	return embed, nil
+}
+
+func isEmbedSet(embed config.DiscordEmbed) bool {
+	return embed.Title != "" || embed.Description != "" || len(embed.Fields) > 0
+}
+
+// truncate s to limit characters, ending it with a notice if needed.
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	notice := []rune(truncatedNotice)
+	if limit < len(notice) {
+		return string(runes[:limit])
+	}
+	return string(runes[:limit-len(notice)]) + truncatedNotice
+}
+
 type WebhookMessageCreate struct {
 	Embeds []Embed `json:"embeds,omitempty"`
 }
 
 type Embed struct {
+	Title       string       `json:"title,omitempty"`
 	Description string       `json:"description,omitempty"`
 	Color       int          `json:"color,omitempty"`
 	Author      *EmbedAuthor `json:"author,omitempty"`
+	Fields      []EmbedField `json:"fields,omitempty"`
+}
+
+// size is the number of characters Discord counts against the total embed
+// limit.
+func (e Embed) size() int {
+	size := utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+	if e.Author != nil {
+		size += utf8.RuneCountInString(e.Author.Name)
+	}
+	for _, field := range e.Fields {
+		size += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	return size
+}
+
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
 }
 
 type EmbedAuthor struct {
@@ -1,10 +1,16 @@
 package discord
 
 import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/stretchr/testify/require"
 )
@@ -14,47 +20,274 @@ func TestStringer(t *testing.T) {
 }
 
 func TestDefault(t *testing.T) {
-	ctx := testctx.New()
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			Discord: []config.Discord{{}},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Discord[0].MessageTemplate)
+}
+
+func TestDefaultTemplateFile(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			Discord: []config.Discord{{
+				MessageTemplateFile: "announce.tmpl",
+			}},
+		},
+	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Discord.MessageTemplate)
+	require.Empty(t, ctx.Config.Announce.Discord[0].MessageTemplate)
 }
 
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Discord: config.Discord{
+			Discord: []config.Discord{{
 				MessageTemplate: "{{ .Foo }",
-			},
+			}},
+		},
+	})
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
+}
+
+func TestAnnounceInvalidTemplateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "announce.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte("{{ .Foo }"), 0o644))
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			Discord: []config.Discord{{
+				MessageTemplateFile: path,
+			}},
 		},
 	})
-	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
+}
+
+func TestAnnounceTemplateAndTemplateFile(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			Discord: []config.Discord{{
+				MessageTemplate:     "foo",
+				MessageTemplateFile: "announce.tmpl",
+			}},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.ErrorIs(t, Pipe{}.Announce(ctx, 0), tmpl.ErrTemplateAndFile)
 }
 
 func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Discord: config.Discord{},
+			Discord: []config.Discord{{}},
 		},
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.EqualError(t, Pipe{}.Announce(ctx), `discord: env: environment variable "DISCORD_WEBHOOK_ID" should not be empty; environment variable "DISCORD_WEBHOOK_TOKEN" should not be empty`)
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `discord: env: environment variable "DISCORD_WEBHOOK_ID" should not be empty; environment variable "DISCORD_WEBHOOK_TOKEN" should not be empty`)
 }
 
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
-		require.True(t, Pipe{}.Skip(testctx.New()))
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Discord: []config.Discord{{}},
+			},
+		})
+		require.True(t, Pipe{}.Skip(ctx, 0))
 	})
 
 	t.Run("dont skip", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Discord: config.Discord{
+				Discord: []config.Discord{{
 					Enabled: true,
+				}},
+			},
+		})
+		require.False(t, Pipe{}.Skip(ctx, 0))
+	})
+}
+
+func TestBuildEmbed(t *testing.T) {
+	t.Run("message", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			ProjectName: "foo",
+			Announce: config.Announce{
+				Discord: []config.Discord{{}},
+			},
+		}, testctx.WithCurrentTag("v1.0.0"))
+		require.NoError(t, Pipe{}.Default(ctx))
+		ctx.ReleaseURL = "https://example.com"
+		embed, err := buildEmbed(ctx, ctx.Config.Announce.Discord[0])
+		require.NoError(t, err)
+		require.Equal(t, Embed{
+			Description: "foo v1.0.0 is out! Check it out at https://example.com",
+			Color:       3888754,
+			Author: &EmbedAuthor{
+				Name:    defaultAuthor,
+				IconURL: defaultIcon,
+			},
+		}, embed)
+	})
+
+	t.Run("message template file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "announce.tmpl")
+		require.NoError(t, os.WriteFile(path, []byte("{{ .ProjectName }} {{ .Tag }} from a file"), 0o644))
+		ctx := testctx.NewWithCfg(config.Project{
+			ProjectName: "foo",
+			Env:         []string{"TEMPLATE_FILE=" + path},
+			Announce: config.Announce{
+				Discord: []config.Discord{{
+					MessageTemplateFile: "{{ .Env.TEMPLATE_FILE }}",
+				}},
+			},
+		}, testctx.WithCurrentTag("v1.0.0"))
+		require.NoError(t, Pipe{}.Default(ctx))
+		embed, err := buildEmbed(ctx, ctx.Config.Announce.Discord[0])
+		require.NoError(t, err)
+		require.Equal(t, "foo v1.0.0 from a file", embed.Description)
+	})
+
+	t.Run("missing message template file", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Discord: []config.Discord{{
+					MessageTemplateFile: filepath.Join(t.TempDir(), "nope.tmpl"),
+				}},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		_, err := buildEmbed(ctx, ctx.Config.Announce.Discord[0])
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("embed", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			ProjectName: "foo",
+			Announce: config.Announce{
+				Discord: []config.Discord{{
+					Embed: config.DiscordEmbed{
+						Title:       "{{ .ProjectName }} {{ .Tag }}",
+						Description: "{{ .ReleaseNotes }}",
+						Fields: []config.DiscordEmbedField{
+							{Name: "Version", Value: "{{ .Version }}", Inline: true},
+							{Name: "Commit", Value: "{{ .Commit }}", Inline: true},
+							{Name: "Artifacts", Value: "{{ .ArtifactCount }}"},
+						},
+					}},
+				},
+			},
+		},
+			testctx.WithCurrentTag("v1.0.0"),
+			testctx.WithVersion("1.0.0"),
+			testctx.WithCommit("a1b2c3d4"),
+		)
+		ctx.ReleaseNotes = "## Changelog\n* foo"
+		ctx.Artifacts.Add(&artifact.Artifact{Name: "foo.tar.gz"})
+		ctx.Artifacts.Add(&artifact.Artifact{Name: "checksums.txt"})
+		require.NoError(t, Pipe{}.Default(ctx))
+		embed, err := buildEmbed(ctx, ctx.Config.Announce.Discord[0])
+		require.NoError(t, err)
+		require.Equal(t, "foo v1.0.0", embed.Title)
+		require.Equal(t, "## Changelog\n* foo", embed.Description)
+		require.Equal(t, []EmbedField{
+			{Name: "Version", Value: "1.0.0", Inline: true},
+			{Name: "Commit", Value: "a1b2c3d4", Inline: true},
+			{Name: "Artifacts", Value: "2"},
+		}, embed.Fields)
+	})
+
+	t.Run("description limit", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Discord: []config.Discord{{
+					Embed: config.DiscordEmbed{
+						Description: "{{ .ReleaseNotes }}",
+					}},
+				},
+			},
+		})
+		ctx.ReleaseNotes = strings.Repeat("a", 5000)
+		require.NoError(t, Pipe{}.Default(ctx))
+		embed, err := buildEmbed(ctx, ctx.Config.Announce.Discord[0])
+		require.NoError(t, err)
+		require.Len(t, embed.Description, embedDescriptionLimit)
+		require.True(t, strings.HasSuffix(embed.Description, truncatedNotice))
+	})
+
+	t.Run("total limit", func(t *testing.T) {
+		var fields []config.DiscordEmbedField
+		for range 3 {
+			fields = append(fields, config.DiscordEmbedField{
+				Name:  "name",
+				Value: strings.Repeat("b", 2000),
+			})
+		}
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Discord: []config.Discord{{
+					Embed: config.DiscordEmbed{
+						Title:       "title",
+						Description: "{{ .ReleaseNotes }}",
+						Fields:      fields,
+					}},
 				},
 			},
 		})
-		require.False(t, Pipe{}.Skip(ctx))
+		ctx.ReleaseNotes = strings.Repeat("a", 4000)
+		require.NoError(t, Pipe{}.Default(ctx))
+		embed, err := buildEmbed(ctx, ctx.Config.Announce.Discord[0])
+		require.NoError(t, err)
+		for _, field := range embed.Fields {
+			require.Len(t, field.Value, embedFieldValueLimit)
+			require.True(t, strings.HasSuffix(field.Value, truncatedNotice))
+		}
+		require.True(t, strings.HasSuffix(embed.Description, truncatedNotice))
+		require.Equal(t, embedTotalLimit, embed.size())
+	})
+
+	t.Run("invalid templates", func(t *testing.T) {
+		for name, embed := range map[string]config.DiscordEmbed{
+			"title":       {Title: "{{ .Foo }"},
+			"description": {Description: "{{ .Foo }"},
+			"field name":  {Fields: []config.DiscordEmbedField{{Name: "{{ .Foo }"}}},
+			"field value": {Fields: []config.DiscordEmbedField{{Name: "foo", Value: "{{ .Foo }"}}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctx := testctx.NewWithCfg(config.Project{
+					Announce: config.Announce{
+						Discord: []config.Discord{{
+							Embed: embed,
+						}},
+					},
+				})
+				require.NoError(t, Pipe{}.Default(ctx))
+				_, err := buildEmbed(ctx, ctx.Config.Announce.Discord[0])
+				testlib.RequireTemplateError(t, err)
+			})
+		}
 	})
+
+	t.Run("invalid color", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Discord: []config.Discord{{
+					Color: "red",
+				}},
+			},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		_, err := buildEmbed(ctx, ctx.Config.Announce.Discord[0])
+		require.ErrorIs(t, err, strconv.ErrSyntax)
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	require.Equal(t, "foo", truncate("foo", 3))
+	require.Equal(t, "fo", truncate("foo", 2))
+	require.Equal(t, "ã"+truncatedNotice, truncate(strings.Repeat("ã", 20), len(truncatedNotice)+1))
 }
 
 func TestLive(t *testing.T) {
@@ -64,13 +297,13 @@ func TestLive(t *testing.T) {
 
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Discord: config.Discord{
+			Discord: []config.Discord{{
 				MessageTemplate: "test",
 				Enabled:         true,
-			},
+			}},
 		},
 	})
 
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.NoError(t, Pipe{}.Announce(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx, 0))
 }
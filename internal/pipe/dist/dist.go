@@ -5,11 +5,20 @@ package dist
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
+// cacheDir is the directory, within dist, where the go builder caches the
+// builds with cache enabled.
+const cacheDir = ".cache"
+
 // Pipe for dist.
 type Pipe struct{}
 
@@ -19,13 +28,20 @@ func (Pipe) String() string {
 
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) (err error) {
+	if err := applyTemplate(ctx); err != nil {
+		return err
+	}
 	_, err = os.Stat(ctx.Config.Dist)
 	if os.IsNotExist(err) {
 		log.Debugf("%s doesn't exist, creating empty directory", ctx.Config.Dist)
 		return mkdir(ctx)
 	}
+	keepCache := hasCachedBuilds(ctx)
 	if ctx.Clean {
 		log.Infof("cleaning %s", ctx.Config.Dist)
+		if keepCache {
+			return cleanKeepingCache(ctx)
+		}
 		err = os.RemoveAll(ctx.Config.Dist)
 		if err == nil {
 			err = mkdir(ctx)
@@ -36,6 +52,9 @@ func (Pipe) Run(ctx *context.Context) (err error) {
 	if err != nil {
 		return
 	}
+	if keepCache {
+		files = slices.DeleteFunc(files, isCacheDir)
+	}
 	if len(files) != 0 {
 		log.Debugf("there are %d files on %s", len(files), ctx.Config.Dist)
 		return fmt.Errorf(
@@ -47,6 +66,54 @@ func (Pipe) Run(ctx *context.Context) (err error) {
 	return mkdir(ctx)
 }
 
+// applyTemplate resolves the dist template once, so all the following pipes
+// can use ctx.Config.Dist directly.
+func applyTemplate(ctx *context.Context) error {
+	dist, err := tmpl.New(ctx).Apply(ctx.Config.Dist)
+	if err != nil {
+		return fmt.Errorf("invalid dist: %w", err)
+	}
+	dist = strings.TrimSpace(dist)
+	switch filepath.Clean(dist) {
+	case ".", "..", string(filepath.Separator):
+		return fmt.Errorf("invalid dist: %q resolves to %q", ctx.Config.Dist, dist)
+	}
+	if dist != ctx.Config.Dist {
+		log.Debugf("dist resolved to %s", dist)
+	}
+	ctx.Config.Dist = dist
+	return nil
+}
+
+// hasCachedBuilds returns true if any of the builds has the cache enabled.
+func hasCachedBuilds(ctx *context.Context) bool {
+	return slices.ContainsFunc(ctx.Config.Builds, func(build config.Build) bool {
+		return build.Cache
+	})
+}
+
+func isCacheDir(entry os.DirEntry) bool {
+	return entry.Name() == cacheDir && entry.IsDir()
+}
+
+// cleanKeepingCache removes everything within dist but the build cache.
+func cleanKeepingCache(ctx *context.Context) error {
+	entries, err := os.ReadDir(ctx.Config.Dist)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if isCacheDir(entry) {
+			log.Debugf("keeping the build cache in %s", filepath.Join(ctx.Config.Dist, cacheDir))
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(ctx.Config.Dist, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func mkdir(ctx *context.Context) error {
 	// #nosec
 	return os.MkdirAll(ctx.Config.Dist, 0o755)
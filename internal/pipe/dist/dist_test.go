@@ -7,6 +7,7 @@ import (
 
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/stretchr/testify/require"
 )
 
@@ -41,6 +42,86 @@ func TestEmptyDistExists(t *testing.T) {
 	require.False(t, os.IsNotExist(err))
 }
 
+func TestDistBuildCache(t *testing.T) {
+	folder := t.TempDir()
+	dist := filepath.Join(folder, "dist")
+	cache := filepath.Join(dist, ".cache", "foo_linux_amd64")
+	require.NoError(t, os.MkdirAll(cache, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(cache, "foo"), []byte("foo"), 0o755))
+	newCtx := func() *context.Context {
+		return testctx.NewWithCfg(config.Project{
+			Dist:   dist,
+			Builds: []config.Build{{Cache: true}},
+		})
+	}
+
+	t.Run("only the cache", func(t *testing.T) {
+		require.NoError(t, Pipe{}.Run(newCtx()))
+	})
+
+	t.Run("clean", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dist, "mybin"), []byte("foo"), 0o644))
+		ctx := newCtx()
+		require.Error(t, Pipe{}.Run(ctx))
+		ctx.Clean = true
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.NoFileExists(t, filepath.Join(dist, "mybin"))
+		require.FileExists(t, filepath.Join(cache, "foo"))
+	})
+
+	t.Run("cache disabled", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{Dist: dist})
+		require.Error(t, Pipe{}.Run(ctx))
+		ctx.Clean = true
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.NoDirExists(t, filepath.Join(dist, ".cache"))
+	})
+}
+
+func TestTemplatedDist(t *testing.T) {
+	folder := t.TempDir()
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Dist:        filepath.Join(folder, "dist", "{{ .ProjectName }}"),
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	dist := filepath.Join(folder, "dist", "foo")
+	require.Equal(t, dist, ctx.Config.Dist)
+	require.DirExists(t, dist)
+
+	t.Run("clean", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dist, "mybin"), []byte("foo"), 0o644))
+		other := filepath.Join(folder, "dist", "other")
+		require.NoError(t, os.Mkdir(other, 0o755))
+		ctx := testctx.NewWithCfg(config.Project{
+			ProjectName: "foo",
+			Dist:        filepath.Join(folder, "dist", "{{ .ProjectName }}"),
+		})
+		ctx.Clean = true
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.NoFileExists(t, filepath.Join(dist, "mybin"))
+		require.DirExists(t, dist)
+		require.DirExists(t, other)
+	})
+}
+
+func TestInvalidDist(t *testing.T) {
+	for name, dist := range map[string]string{
+		"template": "{{ .Nope }}",
+		"empty":    "{{ .Env.NOPE }}",
+		"dot":      "./{{ .Env.EMPTY }}",
+		"parent":   "..",
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := testctx.NewWithCfg(
+				config.Project{Dist: dist},
+				testctx.WithEnv(map[string]string{"EMPTY": "", "NOPE": ""}),
+			)
+			require.ErrorContains(t, Pipe{}.Run(ctx), "invalid dist")
+		})
+	}
+}
+
 func TestDescription(t *testing.T) {
 	require.NotEmpty(t, Pipe{}.String())
 }
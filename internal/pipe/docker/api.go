@@ -47,12 +47,12 @@ func runCommand(ctx *context.Context, dir, binary string, args ...string) error
 	/* #nosec */
 	cmd := exec.CommandContext(ctx, binary, args...)
 	cmd.Dir = dir
-	cmd.Env = append(ctx.Env.Strings(), cmd.Environ()...)
+	cmd.Env = append(cmd.Environ(), ctx.Env.Strings()...)
 
 	var b bytes.Buffer
 	w := gio.Safe(&b)
-	cmd.Stderr = io.MultiWriter(logext.NewWriter(), w)
-	cmd.Stdout = io.MultiWriter(logext.NewWriter(), w)
+	cmd.Stderr = io.MultiWriter(logext.NewWriter(logext.WithMaxLineLength(logext.DefaultMaxLineLength)), w)
+	cmd.Stdout = io.MultiWriter(logext.NewWriter(logext.WithMaxLineLength(logext.DefaultMaxLineLength)), w)
 
 	log.
 		WithField("cmd", append([]string{binary}, args[0])).
@@ -68,11 +68,11 @@ func runCommandWithOutput(ctx *context.Context, dir, binary string, args ...stri
 	/* #nosec */
 	cmd := exec.CommandContext(ctx, binary, args...)
 	cmd.Dir = dir
-	cmd.Env = append(ctx.Env.Strings(), cmd.Environ()...)
+	cmd.Env = append(cmd.Environ(), ctx.Env.Strings()...)
 
 	var b bytes.Buffer
 	w := gio.Safe(&b)
-	cmd.Stderr = io.MultiWriter(logext.NewWriter(), w)
+	cmd.Stderr = io.MultiWriter(logext.NewWriter(logext.WithMaxLineLength(logext.DefaultMaxLineLength)), w)
 
 	log.
 		WithField("cmd", append([]string{binary}, args[0])).
@@ -82,7 +82,7 @@ func runCommandWithOutput(ctx *context.Context, dir, binary string, args ...stri
 	out, err := cmd.Output()
 	if out != nil {
 		// regardless of command success, always print stdout for backward-compatibility with runCommand()
-		_, _ = io.MultiWriter(logext.NewWriter(), w).Write(out)
+		_, _ = io.MultiWriter(logext.NewWriter(logext.WithMaxLineLength(logext.DefaultMaxLineLength)), w).Write(out)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", err, b.String())
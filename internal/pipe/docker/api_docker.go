@@ -14,6 +14,7 @@ func init() {
 	registerImager(useBuildx, dockerImager{
 		buildx: true,
 	})
+	registerImager(useBake, bakeImager{})
 }
 
 type dockerManifester struct{}
@@ -38,29 +39,40 @@ func (m dockerManifester) Push(ctx *context.Context, manifest string, flags []st
 	if err != nil {
 		return "", fmt.Errorf("failed to push %s: %w", manifest, err)
 	}
-	digest := dockerDigestPattern.FindString(string(bts))
-	if digest == "" {
-		return "", fmt.Errorf("failed to find docker digest in docker push output: %s", string(bts))
-	}
-	return digest, nil
+	return parseDigest(bts)
 }
 
 type dockerImager struct {
 	buildx bool
 }
 
-var dockerDigestPattern = regexp.MustCompile("sha256:[a-z0-9]{64}")
+var (
+	dockerDigestPattern     = regexp.MustCompile("sha256:[a-z0-9]{64}")
+	dockerPushDigestPattern = regexp.MustCompile("digest: (sha256:[a-z0-9]{64})")
+)
+
+// parseDigest gets the digest of the pushed image or manifest from the
+// output of `docker push` and `docker manifest push`.
+//
+// `docker push` prints a `<tag>: digest: sha256:... size: N` line at the
+// end, which is preferred over any other digest-looking string in the
+// output. `docker manifest push` prints only the digest.
+func parseDigest(bts []byte) (string, error) {
+	if match := dockerPushDigestPattern.FindSubmatch(bts); match != nil {
+		return string(match[1]), nil
+	}
+	if digest := dockerDigestPattern.Find(bts); digest != nil {
+		return string(digest), nil
+	}
+	return "", fmt.Errorf("failed to find docker digest in docker push output: %s", string(bts))
+}
 
 func (i dockerImager) Push(ctx *context.Context, image string, _ []string) (string, error) {
 	bts, err := runCommandWithOutput(ctx, ".", "docker", "push", image)
 	if err != nil {
 		return "", fmt.Errorf("failed to push %s: %w", image, err)
 	}
-	digest := dockerDigestPattern.FindString(string(bts))
-	if digest == "" {
-		return "", fmt.Errorf("failed to find docker digest in docker push output: %s", string(bts))
-	}
-	return digest, nil
+	return parseDigest(bts)
 }
 
 func (i dockerImager) Build(ctx *context.Context, root string, images, flags []string) error {
@@ -81,3 +93,35 @@ func (i dockerImager) buildCommand(images, flags []string) []string {
 	base = append(base, flags...)
 	return base
 }
+
+// bakeImager builds the images with `docker buildx bake`, and pushes them the
+// same way as dockerImager.
+//
+// The bake file, target, and tags are given in the flags, see bakeFlags.
+type bakeImager struct {
+	dockerImager
+}
+
+func (i bakeImager) Build(ctx *context.Context, root string, images, flags []string) error {
+	if err := runCommand(ctx, root, "docker", i.buildCommand(flags)...); err != nil {
+		return fmt.Errorf("failed to build %s: %w", images[0], err)
+	}
+	return nil
+}
+
+func (i bakeImager) buildCommand(flags []string) []string {
+	return append([]string{"buildx", "bake", "--load"}, flags...)
+}
+
+// bakeFlags returns the flags to build the given target of the given bake
+// file, tagged with the given images.
+//
+// A single target is built, as the images can't be told apart otherwise.
+func bakeFlags(file, target string, images, flags []string) []string {
+	result := []string{"-f", file}
+	for _, image := range images {
+		result = append(result, "--set="+target+".tags="+image)
+	}
+	result = append(result, flags...)
+	return append(result, target)
+}
@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/gio"
 	"github.com/goreleaser/goreleaser/v2/internal/ids"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/referrers"
 	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
@@ -27,12 +29,14 @@ const (
 
 	useBuildx = "buildx"
 	useDocker = "docker"
+	useBake   = "bake"
 )
 
 // Pipe for docker.
 type Pipe struct{}
 
 func (Pipe) String() string { return "docker images" }
+func (Pipe) Key() string    { return "docker" }
 
 func (Pipe) Skip(ctx *context.Context) bool {
 	return len(ctx.Config.Dockers) == 0 || skips.Any(ctx, skips.Docker)
@@ -42,10 +46,11 @@ func (Pipe) Dependencies(ctx *context.Context) []string {
 	var cmds []string
 	for _, s := range ctx.Config.Dockers {
 		switch s.Use {
-		case useDocker, useBuildx:
+		case useDocker, useBuildx, useBake:
 			cmds = append(cmds, "docker")
 			// TODO: how to check if buildx is installed
 		}
+		cmds = append(cmds, referrers.Dependencies(s.AttachSBOMs)...)
 	}
 	return cmds
 }
@@ -77,9 +82,18 @@ func (Pipe) Default(ctx *context.Context) error {
 		if docker.Use == "" {
 			docker.Use = useDocker
 		}
+		if docker.Use == useBake && docker.Bake.File == "" {
+			docker.Bake.File = "docker-bake.hcl"
+		}
+		if docker.Use == useBake && docker.Bake.Target == "" {
+			docker.Bake.Target = "default"
+		}
 		if err := validateImager(docker.Use); err != nil {
 			return err
 		}
+		if err := referrers.Default(&docker.AttachSBOMs); err != nil {
+			return err
+		}
 	}
 	return ids.Validate()
 }
@@ -101,9 +115,15 @@ func validateImager(use string) error {
 // Publish the docker images.
 func (Pipe) Publish(ctx *context.Context) error {
 	skips := pipe.SkipMemento{}
+	conts := pipe.ContinueMemento{}
 	images := ctx.Artifacts.Filter(artifact.ByType(artifact.PublishableDockerImage)).List()
+	attacher := referrers.New()
 	for _, image := range images {
-		if err := dockerPush(ctx, image); err != nil {
+		docker, err := artifact.Extra[config.Docker](*image, dockerConfigExtra)
+		if err != nil {
+			return err
+		}
+		if err := conts.Remember(docker.ContinueOnError, dockerPush(ctx, attacher, image, docker)); err != nil {
 			if pipe.IsSkip(err) {
 				skips.Remember(err)
 				continue
@@ -111,12 +131,21 @@ func (Pipe) Publish(ctx *context.Context) error {
 			return err
 		}
 	}
+	if err := conts.Evaluate(); err != nil {
+		return err
+	}
 	return skips.Evaluate()
 }
 
+// Cleanup removes the temporary docker config the registries were logged in
+// to, once all the publishers ran.
+func (Pipe) Cleanup(ctx *context.Context) error {
+	return cleanupLogins(ctx)
+}
+
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
-	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	g := semerrgroup.NewSkipAware(semerrgroup.NewShared(ctx))
 	for i, docker := range ctx.Config.Dockers {
 		g.Go(func() error {
 			log := log.WithField("index", i)
@@ -180,10 +209,23 @@ func process(ctx *context.Context, docker config.Docker, artifacts []*artifact.A
 
 	if err := tmpl.New(ctx).ApplyAll(
 		&docker.Dockerfile,
+		&docker.Context,
+		&docker.Bake.File,
+		&docker.Bake.Target,
 	); err != nil {
 		return err
 	}
-	if err := gio.Copy(
+	if docker.Context != "" {
+		if err := copyContext(ctx, docker.Context, tmp); err != nil {
+			return err
+		}
+	}
+	if docker.Use == useBake {
+		// the Dockerfile is referenced by the bake file instead.
+		if err := copyBakeFile(docker.Bake.File, tmp); err != nil {
+			return err
+		}
+	} else if err := gio.Copy(
 		docker.Dockerfile,
 		filepath.Join(tmp, "Dockerfile"),
 	); err != nil {
@@ -209,10 +251,19 @@ func process(ctx *context.Context, docker config.Docker, artifacts []*artifact.A
 		}
 	}
 
+	if docker.Context != "" {
+		if err := includeInContext(tmp, docker, artifacts); err != nil {
+			return err
+		}
+	}
+
 	buildFlags, err := processBuildFlagTemplates(ctx, docker)
 	if err != nil {
 		return err
 	}
+	if docker.Use == useBake {
+		buildFlags = bakeFlags(filepath.Base(docker.Bake.File), docker.Bake.Target, images, buildFlags)
+	}
 
 	log.Info("building docker image")
 	if err := imagers[docker.Use].Build(ctx, tmp, images, buildFlags); err != nil {
@@ -243,7 +294,7 @@ Previous error:
 	}
 
 	for _, img := range images {
-		ctx.Artifacts.Add(&artifact.Artifact{
+		art := &artifact.Artifact{
 			Type:   artifact.PublishableDockerImage,
 			Name:   img,
 			Path:   img,
@@ -253,7 +304,127 @@ Previous error:
 			Extra: map[string]interface{}{
 				dockerConfigExtra: docker,
 			},
-		})
+		}
+		if docker.ID != "" {
+			art.Extra[artifact.ExtraID] = docker.ID
+		}
+		ctx.Artifacts.Add(art)
+	}
+	return nil
+}
+
+// copyContext copies the given build context directory into the temporary
+// build dir, so the binaries and extra files can be added to it without
+// changing the original directory.
+//
+// The dist and .git directories are never copied, and neither is anything
+// excluded by the .dockerignore of the context.
+func copyContext(ctx *context.Context, dir, tmp string) error {
+	dir = filepath.Clean(dir)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("failed to find docker build context: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("docker build context %q is not a directory", dir)
+	}
+	dist, err := filepath.Abs(ctx.Config.Dist)
+	if err != nil {
+		return err
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if abs == dist || strings.HasPrefix(abs, dist+string(filepath.Separator)) {
+		return fmt.Errorf("docker build context %q can't be within the dist directory", dir)
+	}
+	ignore, err := readDockerignore(dir)
+	if err != nil {
+		return err
+	}
+	if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		// the .dockerignore itself is always needed by the build.
+		excluded := rel != "." && rel != ".dockerignore" && ignore.ignored(filepath.ToSlash(rel))
+		if d.IsDir() {
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+			if abs == dist || (rel != "." && d.Name() == ".git") || (excluded && !ignore.negates()) {
+				log.WithField("dir", path).Debug("skipping from docker build context")
+				return filepath.SkipDir
+			}
+			if excluded {
+				// still walk it, as its files might be re-included.
+				return nil
+			}
+			return os.MkdirAll(filepath.Join(tmp, rel), 0o755)
+		}
+		if excluded {
+			return nil
+		}
+		target := filepath.Join(tmp, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		return gio.Copy(path, target)
+	}); err != nil {
+		return fmt.Errorf("failed to copy docker build context: %w", err)
+	}
+	return nil
+}
+
+// copyBakeFile copies the given bake file into the root of the temporary
+// build dir, so the targets can use it as their context.
+func copyBakeFile(file, tmp string) error {
+	if _, err := os.Stat(file); err != nil {
+		return fmt.Errorf("failed to find docker bake file: %w", err)
+	}
+	if err := gio.Copy(file, filepath.Join(tmp, filepath.Base(file))); err != nil {
+		return fmt.Errorf("failed to copy docker bake file: %w", err)
+	}
+	return nil
+}
+
+// includeInContext makes sure the Dockerfile, extra files and artifacts added
+// by GoReleaser are not excluded by the .dockerignore of the build context.
+//
+// Docker uses the last matching pattern, so negating each one of them at the
+// end of the file is enough.
+func includeInContext(tmp string, docker config.Docker, artifacts []*artifact.Artifact) error {
+	path := filepath.Join(tmp, ".dockerignore")
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read .dockerignore: %w", err)
+	}
+
+	names := append([]string{"Dockerfile"}, docker.Files...)
+	for _, art := range artifacts {
+		names = append(names, art.Name)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open .dockerignore: %w", err)
+	}
+	defer f.Close()
+	var sb strings.Builder
+	sb.WriteString("\n# added by goreleaser\n")
+	for _, name := range names {
+		sb.WriteString("!" + filepath.ToSlash(name) + "\n")
+	}
+	if _, err := f.WriteString(sb.String()); err != nil {
+		return fmt.Errorf("failed to write .dockerignore: %w", err)
 	}
 	return nil
 }
@@ -278,7 +449,9 @@ func processImageTemplates(ctx *context.Context, docker config.Docker) ([]string
 		if err != nil {
 			return nil, fmt.Errorf("failed to execute image template '%s': %w", imageTemplate, err)
 		}
-		if image == "" {
+		image = strings.TrimSpace(image)
+		if isEmptyImage(image) {
+			log.WithField("template", imageTemplate).Debug("image template rendered an empty image or tag, ignoring")
 			continue
 		}
 
@@ -288,6 +461,13 @@ func processImageTemplates(ctx *context.Context, docker config.Docker) ([]string
 	return images, nil
 }
 
+// isEmptyImage tells whether the given rendered image template is empty, or
+// has an empty tag, e.g. when using a template like
+// `foo/bar:{{ if not .Prerelease }}latest{{ end }}`.
+func isEmptyImage(image string) bool {
+	return image == "" || strings.HasSuffix(image, ":")
+}
+
 func processBuildFlagTemplates(ctx *context.Context, docker config.Docker) ([]string, error) {
 	//nolint:prealloc
 	var buildFlags []string
@@ -301,14 +481,9 @@ func processBuildFlagTemplates(ctx *context.Context, docker config.Docker) ([]st
 	return buildFlags, nil
 }
 
-func dockerPush(ctx *context.Context, image *artifact.Artifact) error {
+func dockerPush(ctx *context.Context, attacher *referrers.Attacher, image *artifact.Artifact, docker config.Docker) error {
 	log.WithField("image", image.Name).Info("pushing")
 
-	docker, err := artifact.Extra[config.Docker](*image, dockerConfigExtra)
-	if err != nil {
-		return err
-	}
-
 	skip, err := tmpl.New(ctx).Apply(docker.SkipPush)
 	if err != nil {
 		return err
@@ -320,6 +495,10 @@ func dockerPush(ctx *context.Context, image *artifact.Artifact) error {
 		return pipe.Skip("prerelease detected with 'auto' push, skipping docker publish: " + image.Name)
 	}
 
+	if err := login(ctx, docker.RegistryAuth); err != nil {
+		return err
+	}
+
 	digest, err := doPush(ctx, imagers[docker.Use], image.Name, docker.PushFlags)
 	if err != nil {
 		return err
@@ -340,7 +519,7 @@ func dockerPush(ctx *context.Context, image *artifact.Artifact) error {
 	art.Extra[artifact.ExtraDigest] = digest
 
 	ctx.Artifacts.Add(art)
-	return nil
+	return attacher.Attach(ctx, docker.AttachSBOMs, image.Name, digest)
 }
 
 func doPush(ctx *context.Context, img imager, name string, flags []string) (string, error) {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/referrers"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
@@ -1334,6 +1335,87 @@ func Test_processImageTemplates(t *testing.T) {
 	}, images)
 }
 
+func Test_processImageTemplatesStableOnly(t *testing.T) {
+	docker := config.Docker{
+		ImageTemplates: []string{
+			"user/image:{{.Tag}}",
+			"user/image:{{ if not .Prerelease }}latest{{ end }}",
+			"{{ if not .Prerelease }}user/image:v{{ .Major }}{{ end }}",
+			"  ",
+		},
+	}
+
+	t.Run("stable", func(t *testing.T) {
+		ctx := testctx.New(
+			testctx.WithCurrentTag("v1.0.0"),
+			testctx.WithSemver(1, 0, 0, ""),
+		)
+		images, err := processImageTemplates(ctx, docker)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"user/image:v1.0.0",
+			"user/image:latest",
+			"user/image:v1",
+		}, images)
+	})
+
+	t.Run("prerelease", func(t *testing.T) {
+		ctx := testctx.New(
+			testctx.WithCurrentTag("v1.0.0-rc1"),
+			testctx.WithSemver(1, 0, 0, "rc1"),
+		)
+		images, err := processImageTemplates(ctx, docker)
+		require.NoError(t, err)
+		require.Equal(t, []string{"user/image:v1.0.0-rc1"}, images)
+	})
+}
+
+func TestManifestStableOnly(t *testing.T) {
+	manifest := config.DockerManifest{
+		NameTemplate: "user/image:{{ if not .Prerelease }}latest{{ end }}",
+		ImageTemplates: []string{
+			"user/image:{{ .Tag }}-amd64",
+			"user/image:{{ if not .Prerelease }}{{ .Tag }}-arm64{{ end }}",
+		},
+	}
+
+	t.Run("stable", func(t *testing.T) {
+		ctx := testctx.New(
+			testctx.WithCurrentTag("v1.0.0"),
+			testctx.WithSemver(1, 0, 0, ""),
+		)
+		name, err := manifestName(ctx, manifest)
+		require.NoError(t, err)
+		require.Equal(t, "user/image:latest", name)
+		images, err := manifestImages(ctx, manifest)
+		require.NoError(t, err)
+		require.Equal(t, []string{"user/image:v1.0.0-amd64", "user/image:v1.0.0-arm64"}, images)
+	})
+
+	t.Run("prerelease", func(t *testing.T) {
+		ctx := testctx.New(
+			testctx.WithCurrentTag("v1.0.0-rc1"),
+			testctx.WithSemver(1, 0, 0, "rc1"),
+		)
+		_, err := manifestName(ctx, manifest)
+		testlib.AssertSkipped(t, err)
+		images, err := manifestImages(ctx, manifest)
+		require.NoError(t, err)
+		require.Equal(t, []string{"user/image:v1.0.0-rc1-amd64"}, images)
+	})
+
+	t.Run("no images", func(t *testing.T) {
+		ctx := testctx.New(
+			testctx.WithCurrentTag("v1.0.0-rc1"),
+			testctx.WithSemver(1, 0, 0, "rc1"),
+		)
+		_, err := manifestImages(ctx, config.DockerManifest{
+			ImageTemplates: []string{"{{ if not .Prerelease }}user/image:latest{{ end }}"},
+		})
+		testlib.AssertSkipped(t, err)
+	})
+}
+
 func TestSkip(t *testing.T) {
 	t.Run("image", func(t *testing.T) {
 		t.Run("skip", func(t *testing.T) {
@@ -1418,7 +1500,8 @@ func TestDependencies(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Dockers: []config.Docker{
 			{Use: useBuildx},
-			{Use: useDocker},
+			{Use: useDocker, AttachSBOMs: config.SBOMAttach{IDs: []string{"foo"}, Tool: "oras"}},
+			{Use: useBake},
 			{Use: "nope"},
 		},
 		DockerManifests: []config.DockerManifest{
@@ -1427,7 +1510,7 @@ func TestDependencies(t *testing.T) {
 			{Use: "nope"},
 		},
 	})
-	require.Equal(t, []string{"docker", "docker"}, Pipe{}.Dependencies(ctx))
+	require.Equal(t, []string{"docker", "docker", "oras", "docker"}, Pipe{}.Dependencies(ctx))
 	require.Equal(t, []string{"docker", "docker"}, ManifestPipe{}.Dependencies(ctx))
 }
 
@@ -1441,3 +1524,565 @@ func TestIsFileNotFoundError(t *testing.T) {
 		require.True(t, isFileNotFoundError(`./foo: not found: not found`))
 	})
 }
+
+type fakeImager struct {
+	output string
+	pushed []string
+}
+
+func (i *fakeImager) Build(*context.Context, string, []string, []string) error { return nil }
+
+func (i *fakeImager) Push(_ *context.Context, image string, _ []string) (string, error) {
+	i.pushed = append(i.pushed, image)
+	return parseDigest([]byte(i.output))
+}
+
+func TestPushDigest(t *testing.T) {
+	const digest = "sha256:b3ebf0fda4d6a2a3e8282e202b4e5b86f7e4bdc3a8bf6a517f0d7b8a1a5c0f17"
+	imager := &fakeImager{
+		output: strings.Join([]string{
+			"The push refers to repository [localhost:5050/owner/img]",
+			"5f70bf18a086: Layer already exists",
+			"sha256:0000000000000000000000000000000000000000000000000000000000000000: Pushed",
+			"v1.0.0: digest: " + digest + " size: 528",
+		}, "\n"),
+	}
+	registerImager("fake", imager)
+	t.Cleanup(func() {
+		lock.Lock()
+		defer lock.Unlock()
+		delete(imagers, "fake")
+	})
+
+	ctx := testctx.New()
+	docker := config.Docker{ID: "img", Use: "fake"}
+	require.NoError(t, dockerPush(ctx, referrers.New(), &artifact.Artifact{
+		Type:   artifact.PublishableDockerImage,
+		Name:   "localhost:5050/owner/img:v1.0.0",
+		Path:   "localhost:5050/owner/img:v1.0.0",
+		Goos:   "linux",
+		Goarch: "amd64",
+		Extra: map[string]interface{}{
+			dockerConfigExtra: docker,
+		},
+	}, docker))
+	require.Equal(t, []string{"localhost:5050/owner/img:v1.0.0"}, imager.pushed)
+
+	images := ctx.Artifacts.Filter(artifact.ByType(artifact.DockerImage)).List()
+	require.Len(t, images, 1)
+	require.Equal(t, digest, artifact.ExtraOr(*images[0], artifact.ExtraDigest, ""))
+	require.Equal(t, "img", images[0].ID())
+	require.Equal(t, "localhost:5050/owner/img:v1.0.0@"+digest, withDigest("fake", "localhost:5050/owner/img:v1.0.0", images))
+}
+
+func TestParseDigest(t *testing.T) {
+	const digest = "sha256:b3ebf0fda4d6a2a3e8282e202b4e5b86f7e4bdc3a8bf6a517f0d7b8a1a5c0f17"
+
+	t.Run("docker push", func(t *testing.T) {
+		got, err := parseDigest([]byte("5f70bf18a086: Pushed\nlatest: digest: " + digest + " size: 528\n"))
+		require.NoError(t, err)
+		require.Equal(t, digest, got)
+	})
+
+	t.Run("docker manifest push", func(t *testing.T) {
+		got, err := parseDigest([]byte(digest + "\n"))
+		require.NoError(t, err)
+		require.Equal(t, digest, got)
+	})
+
+	t.Run("no digest", func(t *testing.T) {
+		_, err := parseDigest([]byte("5f70bf18a086: Pushed\n"))
+		require.ErrorContains(t, err, "failed to find docker digest")
+	})
+}
+
+func TestRegistryLogin(t *testing.T) {
+	auths := []config.DockerRegistryAuth{
+		{
+			Registry: "ghcr.io",
+			Username: "{{ .Env.GHCR_USER }}",
+			Token:    "{{ .Env.GHCR_TOKEN }}",
+		},
+		{
+			Username: "hubuser",
+			Password: "{{ .Env.HUB_PASSWORD }}",
+		},
+	}
+	newCtx := func() *context.Context {
+		return testctx.New(testctx.WithEnv(map[string]string{
+			"GHCR_USER":    "ghuser",
+			"GHCR_TOKEN":   "ghtoken",
+			"HUB_PASSWORD": "hubpassword",
+		}))
+	}
+
+	t.Run("login and cleanup", func(t *testing.T) {
+		home := t.TempDir()
+		userConfig := `{"auths":{"quay.io":{"auth":"Zm9vOmJhcg=="}},"credsStore":"desktop","credHelpers":{"gcr.io":"gcloud"}}`
+		require.NoError(t, os.WriteFile(filepath.Join(home, "config.json"), []byte(userConfig), 0o600))
+		ctx := newCtx()
+		ctx.Env[dockerConfigEnv] = home
+		require.NoError(t, login(ctx, auths))
+		require.NoError(t, login(ctx, auths))
+
+		dir := ctx.Env[dockerConfigEnv]
+		require.NotEqual(t, home, dir)
+		bts, err := os.ReadFile(filepath.Join(dir, "config.json"))
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"auths": {
+				"quay.io": {"auth": "Zm9vOmJhcg=="},
+				"ghcr.io": {"auth": "Z2h1c2VyOmdodG9rZW4="},
+				"https://index.docker.io/v1/": {"auth": "aHVidXNlcjpodWJwYXNzd29yZA=="}
+			},
+			"credsStore": "desktop",
+			"credHelpers": {"gcr.io": "gcloud", "ghcr.io": "", "index.docker.io": ""}
+		}`, string(bts))
+
+		require.NoError(t, cleanupLogins(ctx))
+		require.NoDirExists(t, dir)
+		require.Equal(t, home, ctx.Env[dockerConfigEnv])
+
+		bts, err = os.ReadFile(filepath.Join(home, "config.json"))
+		require.NoError(t, err)
+		require.Equal(t, userConfig, string(bts), "user config should be untouched")
+	})
+
+	t.Run("no user config", func(t *testing.T) {
+		ctx := newCtx()
+		delete(ctx.Env, dockerConfigEnv)
+		t.Setenv("HOME", t.TempDir())
+		require.NoError(t, login(ctx, auths))
+		dir := ctx.Env[dockerConfigEnv]
+		require.FileExists(t, filepath.Join(dir, "config.json"))
+		require.NoError(t, cleanupLogins(ctx))
+		require.NoDirExists(t, dir)
+		require.NotContains(t, ctx.Env, dockerConfigEnv)
+	})
+
+	t.Run("invalid user config", func(t *testing.T) {
+		home := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(home, "config.json"), []byte("nope"), 0o600))
+		ctx := newCtx()
+		ctx.Env[dockerConfigEnv] = home
+		require.ErrorContains(t, login(ctx, auths), "failed to parse docker config")
+		require.NoError(t, cleanupLogins(ctx))
+		require.Equal(t, home, ctx.Env[dockerConfigEnv])
+	})
+
+	t.Run("cleanup without login", func(t *testing.T) {
+		home := t.TempDir()
+		ctx := newCtx()
+		ctx.Env[dockerConfigEnv] = home
+		require.NoError(t, cleanupLogins(ctx))
+		require.DirExists(t, home)
+		require.Equal(t, home, ctx.Env[dockerConfigEnv])
+	})
+
+	t.Run("no username", func(t *testing.T) {
+		err := login(newCtx(), []config.DockerRegistryAuth{{Token: "foo"}})
+		require.EqualError(t, err, `docker: registry_auth for "docker.io" requires a username`)
+	})
+
+	t.Run("no secret", func(t *testing.T) {
+		err := login(newCtx(), []config.DockerRegistryAuth{{Username: "foo"}})
+		require.ErrorIs(t, err, errNoRegistrySecret)
+	})
+
+	t.Run("bad template", func(t *testing.T) {
+		err := login(newCtx(), []config.DockerRegistryAuth{{Username: "{{ .Nope }"}})
+		testlib.RequireTemplateError(t, err)
+	})
+}
+
+func TestRegistryHost(t *testing.T) {
+	for registry, host := range map[string]string{
+		"":                         "index.docker.io",
+		"docker.io":                "index.docker.io",
+		"ghcr.io":                  "ghcr.io",
+		"https://localhost:5000/":  "localhost:5000",
+		"registry.example.com/foo": "registry.example.com",
+	} {
+		require.Equal(t, host, registryHost(registry), registry)
+	}
+}
+
+func TestPublishRegistryAuth(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	imager := &fakeImager{
+		output: "v1.0.0: digest: sha256:b3ebf0fda4d6a2a3e8282e202b4e5b86f7e4bdc3a8bf6a517f0d7b8a1a5c0f17 size: 528",
+	}
+	registerImager("fake", imager)
+	t.Cleanup(func() {
+		lock.Lock()
+		defer lock.Unlock()
+		delete(imagers, "fake")
+	})
+
+	docker := config.Docker{
+		Use: "fake",
+		RegistryAuth: []config.DockerRegistryAuth{
+			{Registry: "ghcr.io", Username: "user", Token: "token"},
+		},
+	}
+	ctx := testctx.New()
+	for _, tag := range []string{"v1.0.0", "latest"} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: artifact.PublishableDockerImage,
+			Name: "ghcr.io/owner/img:" + tag,
+			Path: "ghcr.io/owner/img:" + tag,
+			Extra: map[string]interface{}{
+				dockerConfigExtra: docker,
+			},
+		})
+	}
+	require.NoError(t, Pipe{}.Publish(ctx))
+	require.Equal(t, []string{"ghcr.io/owner/img:v1.0.0", "ghcr.io/owner/img:latest"}, imager.pushed)
+
+	// the credentials are kept for the publishers after it, e.g. cosign
+	dir := ctx.Env[dockerConfigEnv]
+	bts, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"auths":{"ghcr.io":{"auth":"dXNlcjp0b2tlbg=="}},"credHelpers":{"ghcr.io":""}}`, string(bts))
+	require.NoError(t, ManifestPipe{}.Publish(ctx))
+	require.Equal(t, dir, ctx.Env[dockerConfigEnv])
+
+	require.NoError(t, Pipe{}.Cleanup(ctx))
+	require.NoDirExists(t, dir)
+	require.NoError(t, Pipe{}.Cleanup(ctx))
+}
+
+// contextImager records the files in the build context and the flags
+// instead of building.
+type contextImager struct {
+	files        []string
+	flags        []string
+	dockerignore string
+}
+
+func (i *contextImager) Build(_ *context.Context, root string, _, flags []string) error {
+	i.flags = flags
+	i.files = nil
+	bts, err := os.ReadFile(filepath.Join(root, ".dockerignore"))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	i.dockerignore = string(bts)
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		i.files = append(i.files, filepath.ToSlash(rel))
+		return err
+	})
+}
+
+func (*contextImager) Push(*context.Context, string, []string) (string, error) {
+	return "", nil
+}
+
+func TestRunPipeContext(t *testing.T) {
+	imager := &contextImager{}
+	registerImager("context-test", imager)
+	t.Cleanup(func() {
+		lock.Lock()
+		defer lock.Unlock()
+		delete(imagers, "context-test")
+	})
+
+	folder := testlib.Mktmp(t)
+	dockerfile := filepath.Join(folder, "Dockerfile")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM scratch\n"), 0o644))
+	binary := filepath.Join(folder, "mybin")
+	require.NoError(t, os.WriteFile(binary, []byte("bin"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "my.file"), []byte("file"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(folder, ".git"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, ".git", "HEAD"), []byte("ref"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(folder, "dist"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "dist", "artifacts.json"), []byte("[]"), 0o644))
+
+	dir := filepath.Join(folder, "images", "foo")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "etc"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "etc", "app.conf"), []byte("conf"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM nope\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".dockerignore"), []byte("*\n!etc\n"), 0o644))
+
+	makeCtx := func(context string) *context.Context {
+		ctx := testctx.NewWithCfg(config.Project{
+			ProjectName: "foo",
+			Dist:        "dist",
+			Env:         []string{"CONTEXT=" + context},
+			Dockers: []config.Docker{
+				{
+					ImageTemplates: []string{"foo:latest"},
+					Dockerfile:     dockerfile,
+					Context:        "{{ .Env.CONTEXT }}",
+					Use:            "context-test",
+				},
+			},
+		})
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "mybin",
+			Path:    binary,
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.Binary,
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		return ctx
+	}
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, Pipe{}.Run(makeCtx(dir)))
+		require.ElementsMatch(t, []string{".dockerignore", "Dockerfile", "etc/app.conf", "mybin"}, imager.files)
+		require.Equal(t, "*\n!etc\n\n# added by goreleaser\n!Dockerfile\n!mybin\n", imager.dockerignore)
+
+		bts, err := os.ReadFile(filepath.Join(dir, ".dockerignore"))
+		require.NoError(t, err)
+		require.Equal(t, "*\n!etc\n", string(bts), "original .dockerignore should be untouched")
+		require.NoFileExists(t, filepath.Join(dir, "mybin"))
+	})
+
+	t.Run("relative", func(t *testing.T) {
+		require.NoError(t, Pipe{}.Run(makeCtx("./images/foo/")))
+		require.ElementsMatch(t, []string{".dockerignore", "Dockerfile", "etc/app.conf", "mybin"}, imager.files)
+		require.Equal(t, "*\n!etc\n\n# added by goreleaser\n!Dockerfile\n!mybin\n", imager.dockerignore)
+	})
+
+	t.Run("project root", func(t *testing.T) {
+		require.NoError(t, Pipe{}.Run(makeCtx(".")))
+		require.ElementsMatch(t, []string{
+			"Dockerfile",
+			"my.file",
+			"mybin",
+			"images/foo/.dockerignore",
+			"images/foo/Dockerfile",
+			"images/foo/etc/app.conf",
+		}, imager.files)
+	})
+
+	t.Run("dockerignore", func(t *testing.T) {
+		other := t.TempDir()
+		for name, content := range map[string]string{
+			".dockerignore":           "node_modules\n**/*.log\n!keep.log\nvendor\n!vendor/keep\n",
+			"main.go":                 "package main",
+			"keep.log":                "keep",
+			"sub/debug.log":           "debug",
+			"node_modules/pkg/a.js":   "js",
+			"vendor/nope/nope.go":     "nope",
+			"vendor/keep/keep.go":     "keep",
+			"docs/node_modules/b.txt": "nested",
+		} {
+			path := filepath.Join(other, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		}
+		require.NoError(t, Pipe{}.Run(makeCtx(other)))
+		require.ElementsMatch(t, []string{
+			".dockerignore",
+			"Dockerfile",
+			"docs/node_modules/b.txt",
+			"keep.log",
+			"main.go",
+			"mybin",
+			"vendor/keep/keep.go",
+		}, imager.files)
+	})
+
+	t.Run("within dist", func(t *testing.T) {
+		require.ErrorContains(t, Pipe{}.Run(makeCtx("./dist")), "can't be within the dist directory")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		require.ErrorContains(t, Pipe{}.Run(makeCtx(filepath.Join(folder, "nope"))), "failed to find docker build context")
+	})
+
+	t.Run("not a directory", func(t *testing.T) {
+		require.ErrorContains(t, Pipe{}.Run(makeCtx(binary)), "is not a directory")
+	})
+
+	t.Run("template error", func(t *testing.T) {
+		ctx := makeCtx(dir)
+		ctx.Config.Dockers[0].Context = "{{ .Nope }}"
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
+
+func TestDockerignore(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".dockerignore"), []byte(`# comment
+/dist
+*.md
+!README.md
+**/testdata
+cmd/*/main_test.go
+[ab].txt
+`), 0o644))
+	ignore, err := readDockerignore(dir)
+	require.NoError(t, err)
+	require.True(t, ignore.negates())
+
+	for path, ignored := range map[string]bool{
+		"dist":                 true,
+		"dist/foo":             true,
+		"CHANGELOG.md":         true,
+		"README.md":            false,
+		"docs/index.md":        false,
+		"testdata/foo":         true,
+		"pkg/foo/testdata/bar": true,
+		"cmd/foo/main_test.go": true,
+		"cmd/foo/main.go":      false,
+		"a.txt":                true,
+		"c.txt":                false,
+		"main.go":              false,
+	} {
+		require.Equal(t, ignored, ignore.ignored(path), path)
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		ignore, err := readDockerignore(t.TempDir())
+		require.NoError(t, err)
+		require.False(t, ignore.ignored("foo"))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".dockerignore"), []byte("[a\n"), 0o644))
+		_, err := readDockerignore(dir)
+		require.ErrorContains(t, err, "invalid .dockerignore pattern")
+	})
+}
+
+func TestDefaultBake(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Dockers: []config.Docker{
+			{Use: useBake},
+			{Use: useBake, Bake: config.DockerBake{File: "images.hcl"}},
+			{Use: useBuildx},
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, config.DockerBake{File: "docker-bake.hcl", Target: "default"}, ctx.Config.Dockers[0].Bake)
+	require.Equal(t, config.DockerBake{File: "images.hcl", Target: "default"}, ctx.Config.Dockers[1].Bake)
+	require.Empty(t, ctx.Config.Dockers[2].Bake)
+}
+
+func TestBakeBuildCommand(t *testing.T) {
+	images := []string{"goreleaser/test_build_flag", "goreleaser/test_multiple_tags"}
+	tests := []struct {
+		name   string
+		target string
+		flags  []string
+		expect []string
+	}{
+		{
+			name:   "default target",
+			target: "default",
+			expect: []string{
+				"buildx", "bake", "--load", "-f", "docker-bake.hcl",
+				"--set=default.tags=" + images[0], "--set=default.tags=" + images[1],
+				"default",
+			},
+		},
+		{
+			name:   "target and flags",
+			target: "app",
+			flags:  []string{"--set=*.args.VERSION=1.0.0"},
+			expect: []string{
+				"buildx", "bake", "--load", "-f", "docker-bake.hcl",
+				"--set=app.tags=" + images[0], "--set=app.tags=" + images[1],
+				"--set=*.args.VERSION=1.0.0",
+				"app",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := bakeFlags("docker-bake.hcl", tt.target, images, tt.flags)
+			require.Equal(t, tt.expect, bakeImager{}.buildCommand(flags))
+		})
+	}
+}
+
+func TestRunPipeBake(t *testing.T) {
+	imager := &contextImager{}
+	lock.Lock()
+	original := imagers[useBake]
+	imagers[useBake] = imager
+	lock.Unlock()
+	t.Cleanup(func() {
+		registerImager(useBake, original)
+	})
+
+	folder := t.TempDir()
+	bakefile := filepath.Join(folder, "build", "images.hcl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(bakefile), 0o755))
+	require.NoError(t, os.WriteFile(bakefile, []byte(`target "app" {}`), 0o644))
+	dockerfile := filepath.Join(folder, "build", "app.Dockerfile")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM scratch\n"), 0o644))
+	binary := filepath.Join(folder, "mybin")
+	require.NoError(t, os.WriteFile(binary, []byte("bin"), 0o755))
+
+	makeCtx := func(file string) *context.Context {
+		ctx := testctx.NewWithCfg(config.Project{
+			ProjectName: "foo",
+			Env:         []string{"BAKE_FILE=" + file},
+			Dockers: []config.Docker{
+				{
+					ImageTemplates:     []string{"foo:{{ .Version }}", "foo:latest"},
+					BuildFlagTemplates: []string{"--set=*.args.VERSION={{ .Version }}"},
+					Files:              []string{"app.Dockerfile"},
+					Use:                useBake,
+					Bake: config.DockerBake{
+						File:   "{{ .Env.BAKE_FILE }}",
+						Target: "{{ .ProjectName }}",
+					},
+				},
+			},
+		}, testctx.WithVersion("1.0.0"))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "mybin",
+			Path:    binary,
+			Goos:    "linux",
+			Goarch:  "amd64",
+			Goamd64: "v1",
+			Type:    artifact.Binary,
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		return ctx
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Join(folder, "build")))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(wd)) })
+
+	t.Run("success", func(t *testing.T) {
+		ctx := makeCtx(bakefile)
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.ElementsMatch(t, []string{"images.hcl", "app.Dockerfile", "mybin"}, imager.files)
+		require.Equal(t, []string{
+			"-f", "images.hcl",
+			"--set=foo.tags=foo:1.0.0", "--set=foo.tags=foo:latest",
+			"--set=*.args.VERSION=1.0.0",
+			"foo",
+		}, imager.flags)
+
+		images := ctx.Artifacts.Filter(artifact.ByType(artifact.PublishableDockerImage)).List()
+		require.Len(t, images, 2)
+		require.Equal(t, "foo:1.0.0", images[0].Name)
+		require.Equal(t, "foo:latest", images[1].Name)
+	})
+
+	t.Run("missing bake file", func(t *testing.T) {
+		require.ErrorContains(t, Pipe{}.Run(makeCtx(filepath.Join(folder, "nope.hcl"))), "failed to find docker bake file")
+	})
+
+	t.Run("target template error", func(t *testing.T) {
+		ctx := makeCtx(bakefile)
+		ctx.Config.Dockers[0].Bake.Target = "{{ .Nope }}"
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+}
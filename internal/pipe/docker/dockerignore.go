@@ -0,0 +1,136 @@
+package docker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// dockerignore is the list of patterns of a .dockerignore file.
+type dockerignore []ignorePattern
+
+type ignorePattern struct {
+	re     *regexp.Regexp
+	negate bool
+}
+
+// readDockerignore reads the .dockerignore file in the given directory, if
+// any.
+func readDockerignore(dir string) (dockerignore, error) {
+	f, err := os.Open(filepath.Join(dir, ".dockerignore"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read .dockerignore: %w", err)
+	}
+	defer f.Close()
+
+	var patterns dockerignore
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		negate := strings.HasPrefix(line, "!")
+		line = strings.TrimPrefix(line, "!")
+		line = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(line)), "/")
+		re, err := ignoreRegexp(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid .dockerignore pattern %q: %w", line, err)
+		}
+		patterns = append(patterns, ignorePattern{re: re, negate: negate})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read .dockerignore: %w", err)
+	}
+	return patterns, nil
+}
+
+// ignoreRegexp converts a .dockerignore pattern into a regular expression:
+// '**' matches any number of directories, '*' and '?' never match a '/'.
+func ignoreRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**/") {
+				sb.WriteString("(.*/)?")
+				i += 2
+			} else if strings.HasPrefix(pattern[i:], "**") {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, errors.New("unterminated character class")
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// ignored tells whether the given slash separated path, relative to the
+// build context, is excluded.
+//
+// As in Docker, a pattern matching a parent directory also matches the
+// path, and the last matching pattern wins.
+func (d dockerignore) ignored(path string) bool {
+	ignored := false
+	for _, p := range d {
+		if p.matches(path) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
+
+// negates tells whether any pattern re-includes paths, in which case the
+// excluded directories still need to be walked.
+func (d dockerignore) negates() bool {
+	for _, p := range d {
+		if p.negate {
+			return true
+		}
+	}
+	return false
+}
+
+func (p ignorePattern) matches(path string) bool {
+	for {
+		if p.re.MatchString(path) {
+			return true
+		}
+		idx := strings.LastIndexByte(path, '/')
+		if idx < 0 {
+			return false
+		}
+		path = path[:idx]
+	}
+}
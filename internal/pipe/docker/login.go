@@ -0,0 +1,262 @@
+package docker
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+var errNoRegistrySecret = errors.New("docker: registry_auth requires either a password or a token")
+
+// dockerConfigEnv is the environment variable docker, cosign, etc use to find
+// the docker config directory.
+const dockerConfigEnv = "DOCKER_CONFIG"
+
+// dockerHub is the key docker uses for Docker Hub in the auths of its config.
+const dockerHub = "https://index.docker.io/v1/"
+
+// previousConfigFile is written into the temporary docker config, and holds
+// the DOCKER_CONFIG it replaced, so it can be restored on cleanup.
+const previousConfigFile = "goreleaser-previous.json"
+
+type previousConfig struct {
+	Dir string `json:"dir"`
+	Set bool   `json:"set"`
+}
+
+// loginsLock guards the temporary docker config, which both the docker and
+// docker manifest pipes write to.
+var loginsLock sync.Mutex
+
+// login adds the credentials of the given registries to a temporary docker
+// config, set as DOCKER_CONFIG in the context environment, so the user's
+// config is never changed, and the credentials are available to all the
+// publishers (e.g. cosign).
+// It is removed by Pipe.Cleanup, after all publishers ran.
+func login(ctx *context.Context, auths []config.DockerRegistryAuth) error {
+	loginsLock.Lock()
+	defer loginsLock.Unlock()
+	for _, auth := range auths {
+		if err := tmpl.New(ctx).ApplyAll(
+			&auth.Registry,
+			&auth.Username,
+			&auth.Password,
+			&auth.Token,
+		); err != nil {
+			return err
+		}
+		secret := auth.Password
+		if secret == "" {
+			secret = auth.Token
+		}
+		logext.AddSecrets(secret)
+
+		if auth.Username == "" {
+			return fmt.Errorf("docker: registry_auth for %q requires a username", registryName(auth.Registry))
+		}
+		if secret == "" {
+			return errNoRegistrySecret
+		}
+		dir, err := setupDockerConfig(ctx)
+		if err != nil {
+			return err
+		}
+		cfg, err := readDockerConfig(dir)
+		if err != nil {
+			return err
+		}
+		if !cfg.setAuth(auth.Registry, auth.Username, secret) {
+			continue
+		}
+		log.WithField("registry", registryName(auth.Registry)).
+			WithField("username", auth.Username).
+			Info("logging in")
+		if err := cfg.write(dir); err != nil {
+			return fmt.Errorf("failed to login to %s: %w", registryName(auth.Registry), err)
+		}
+	}
+	return nil
+}
+
+// setupDockerConfig creates the temporary docker config, if not created yet,
+// sets it as DOCKER_CONFIG, and returns it.
+//
+// The user's config.json is copied into it, so the registries they are
+// already logged in to keep working, including the ones in their `credsStore`.
+func setupDockerConfig(ctx *context.Context) (string, error) {
+	if dir := ctx.Env[dockerConfigEnv]; isTemporaryDockerConfig(dir) {
+		return dir, nil
+	}
+	dir, err := os.MkdirTemp("", "goreleaser-docker-config")
+	if err != nil {
+		return "", fmt.Errorf("failed to create docker config dir: %w", err)
+	}
+	previous, set := ctx.Env[dockerConfigEnv]
+	bts, err := json.Marshal(previousConfig{Dir: previous, Set: set})
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, previousConfigFile), bts, 0o600)
+	}
+	if err == nil {
+		err = copyDockerConfig(userDockerConfig(ctx), dir)
+	}
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+	ctx.Env[dockerConfigEnv] = dir
+	return dir, nil
+}
+
+func isTemporaryDockerConfig(dir string) bool {
+	if dir == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(dir, previousConfigFile))
+	return err == nil
+}
+
+// cleanupLogins removes the temporary docker config of the given context, if
+// any, and restores DOCKER_CONFIG.
+func cleanupLogins(ctx *context.Context) error {
+	loginsLock.Lock()
+	defer loginsLock.Unlock()
+	dir := ctx.Env[dockerConfigEnv]
+	if !isTemporaryDockerConfig(dir) {
+		return nil
+	}
+	bts, err := os.ReadFile(filepath.Join(dir, previousConfigFile))
+	if err != nil {
+		return fmt.Errorf("failed to read docker config dir: %w", err)
+	}
+	var previous previousConfig
+	if err := json.Unmarshal(bts, &previous); err != nil {
+		return fmt.Errorf("failed to read docker config dir: %w", err)
+	}
+	if previous.Set {
+		ctx.Env[dockerConfigEnv] = previous.Dir
+	} else {
+		delete(ctx.Env, dockerConfigEnv)
+	}
+	log.Debug("removing temporary docker config")
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove docker config dir: %w", err)
+	}
+	return nil
+}
+
+// userDockerConfig returns the user's docker config dir.
+func userDockerConfig(ctx *context.Context) string {
+	if dir := ctx.Env[dockerConfigEnv]; dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker")
+}
+
+// copyDockerConfig copies the config.json from the src to the dst dir, if it
+// exists.
+func copyDockerConfig(src, dst string) error {
+	if src == "" {
+		return nil
+	}
+	bts, err := os.ReadFile(filepath.Join(src, "config.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read docker config: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dst, "config.json"), bts, 0o600); err != nil {
+		return fmt.Errorf("failed to write docker config: %w", err)
+	}
+	return nil
+}
+
+// dockerConfig is a docker config.json, keeping the fields we don't use as
+// they are.
+type dockerConfig map[string]json.RawMessage
+
+func readDockerConfig(dir string) (dockerConfig, error) {
+	cfg := dockerConfig{}
+	bts, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docker config: %w", err)
+	}
+	if err := json.Unmarshal(bts, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config: %w", err)
+	}
+	return cfg, nil
+}
+
+// setAuth sets the credentials of the given registry, returning false if they
+// were already set.
+//
+// The registry is also added to the `credHelpers` with no helper, so docker
+// reads its credentials from the auths instead of the `credsStore`, which is
+// still used for all the other registries.
+func (c dockerConfig) setAuth(registry, username, secret string) bool {
+	key, host := registry, registryHost(registry)
+	if registry == "" || registry == "docker.io" {
+		key = dockerHub
+	}
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + secret))
+
+	auths := map[string]json.RawMessage{}
+	helpers := map[string]string{}
+	_ = json.Unmarshal(c["auths"], &auths)
+	_ = json.Unmarshal(c["credHelpers"], &helpers)
+	entry, _ := json.Marshal(map[string]string{"auth": auth})
+	if helper, ok := helpers[host]; ok && helper == "" && string(auths[key]) == string(entry) {
+		return false
+	}
+	auths[key] = entry
+	helpers[host] = ""
+	c["auths"], _ = json.Marshal(auths)
+	c["credHelpers"], _ = json.Marshal(helpers)
+	return true
+}
+
+func (c dockerConfig) write(dir string) error {
+	bts, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "config.json"), bts, 0o600)
+}
+
+// registryHost returns the host docker uses to pick the credential helper of
+// the given registry.
+func registryHost(registry string) string {
+	if registry == "" || registry == "docker.io" {
+		return "index.docker.io"
+	}
+	registry = strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+	host, _, _ := strings.Cut(registry, "/")
+	return host
+}
+
+// registryName returns the registry name as docker would show it.
+func registryName(registry string) string {
+	if registry == "" {
+		return "docker.io"
+	}
+	return registry
+}
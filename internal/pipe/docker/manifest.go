@@ -21,6 +21,7 @@ import (
 type ManifestPipe struct{}
 
 func (ManifestPipe) String() string { return "docker manifests" }
+func (ManifestPipe) Key() string    { return "docker-manifest" }
 
 func (ManifestPipe) Skip(ctx *context.Context) bool {
 	return len(ctx.Config.DockerManifests) == 0 || skips.Any(ctx, skips.Docker)
@@ -59,57 +60,74 @@ func (ManifestPipe) Default(ctx *context.Context) error {
 // Publish the docker manifests.
 func (ManifestPipe) Publish(ctx *context.Context) error {
 	g := semerrgroup.NewSkipAware(semerrgroup.New(1))
+	conts := pipe.ContinueMemento{}
 	for _, manifest := range ctx.Config.DockerManifests {
 		g.Go(func() error {
-			skip, err := tmpl.New(ctx).Apply(manifest.SkipPush)
-			if err != nil {
-				return err
-			}
-			if strings.TrimSpace(skip) == "true" {
-				return pipe.Skip("docker_manifest.skip_push is set")
-			}
+			return conts.Remember(manifest.ContinueOnError, publishManifest(ctx, manifest))
+		})
+	}
+	err := g.Wait()
+	if cerr := conts.Evaluate(); cerr != nil && (err == nil || pipe.IsSkip(err)) {
+		return cerr
+	}
+	return err
+}
 
-			if strings.TrimSpace(skip) == "auto" && ctx.Semver.Prerelease != "" {
-				return pipe.Skip("prerelease detected with 'auto' push, skipping docker manifest")
-			}
+func publishManifest(ctx *context.Context, manifest config.DockerManifest) error {
+	skip, err := tmpl.New(ctx).Apply(manifest.SkipPush)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(skip) == "true" {
+		return pipe.Skip("docker_manifest.skip_push is set")
+	}
 
-			name, err := manifestName(ctx, manifest)
-			if err != nil {
-				return err
-			}
+	if strings.TrimSpace(skip) == "auto" && ctx.Semver.Prerelease != "" {
+		return pipe.Skip("prerelease detected with 'auto' push, skipping docker manifest")
+	}
 
-			images, err := manifestImages(ctx, manifest)
-			if err != nil {
-				return err
-			}
+	name, err := manifestName(ctx, manifest)
+	if err != nil {
+		return err
+	}
 
-			manifester := manifesters[manifest.Use]
+	images, err := manifestImages(ctx, manifest)
+	if err != nil {
+		return err
+	}
 
-			log.WithField("manifest", name).WithField("images", images).Info("creating")
-			if err := manifester.Create(ctx, name, images, manifest.CreateFlags); err != nil {
-				return err
-			}
-			art := &artifact.Artifact{
-				Type:  artifact.DockerManifest,
-				Name:  name,
-				Path:  name,
-				Extra: map[string]interface{}{},
-			}
-			if manifest.ID != "" {
-				art.Extra[artifact.ExtraID] = manifest.ID
-			}
+	// manifests are made of the images pushed by the dockers, so we log in
+	// to their registries as well.
+	for _, docker := range ctx.Config.Dockers {
+		if err := login(ctx, docker.RegistryAuth); err != nil {
+			return err
+		}
+	}
 
-			log.WithField("manifest", name).Info("pushing")
-			digest, err := manifester.Push(ctx, name, manifest.PushFlags)
-			if err != nil {
-				return err
-			}
-			art.Extra[artifact.ExtraDigest] = digest
-			ctx.Artifacts.Add(art)
-			return nil
-		})
+	manifester := manifesters[manifest.Use]
+
+	log.WithField("manifest", name).WithField("images", images).Info("creating")
+	if err := manifester.Create(ctx, name, images, manifest.CreateFlags); err != nil {
+		return err
+	}
+	art := &artifact.Artifact{
+		Type:  artifact.DockerManifest,
+		Name:  name,
+		Path:  name,
+		Extra: map[string]interface{}{},
+	}
+	if manifest.ID != "" {
+		art.Extra[artifact.ExtraID] = manifest.ID
 	}
-	return g.Wait()
+
+	log.WithField("manifest", name).Info("pushing")
+	digest, err := manifester.Push(ctx, name, manifest.PushFlags)
+	if err != nil {
+		return err
+	}
+	art.Extra[artifact.ExtraDigest] = digest
+	ctx.Artifacts.Add(art)
+	return nil
 }
 
 func validateManifester(use string) error {
@@ -131,7 +149,8 @@ func manifestName(ctx *context.Context, manifest config.DockerManifest) (string,
 	if err != nil {
 		return name, err
 	}
-	if strings.TrimSpace(name) == "" {
+	name = strings.TrimSpace(name)
+	if isEmptyImage(name) {
 		return name, pipe.Skip("manifest name is empty")
 	}
 	return name, nil
@@ -145,9 +164,13 @@ func manifestImages(ctx *context.Context, manifest config.DockerManifest) ([]str
 		if err != nil {
 			return []string{}, err
 		}
+		str = strings.TrimSpace(str)
+		if isEmptyImage(str) {
+			continue
+		}
 		imgs = append(imgs, withDigest(manifest.Use, str, artifacts))
 	}
-	if strings.TrimSpace(strings.Join(manifest.ImageTemplates, "")) == "" {
+	if len(imgs) == 0 {
 		return imgs, pipe.Skip("manifest has no images")
 	}
 	return imgs, nil
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/caarlos0/log"
@@ -108,6 +110,8 @@ func (Pipe) Run(ctx *context.Context) error {
 		return err
 	}
 
+	logext.AddSecrets(githubToken, gitlabToken, giteaToken)
+
 	if gitlabToken != "" {
 		log.Debug("token type: gitlab")
 		ctx.TokenType = context.TokenTypeGitLab
@@ -129,9 +133,90 @@ func (Pipe) Run(ctx *context.Context) error {
 		ctx.TokenType = context.TokenTypeGitHub
 	}
 
+	if ctx.Config.CheckEnv {
+		declared := map[string]bool{
+			"GITHUB_TOKEN": githubToken != "",
+			"GITLAB_TOKEN": gitlabToken != "",
+			"GITEA_TOKEN":  giteaToken != "",
+		}
+		if err := checkReferencedEnv(ctx, declared); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// ErrMissingEnv happens when the configuration references environment
+// variables that are not set.
+type ErrMissingEnv struct {
+	vars []string
+}
+
+func (e ErrMissingEnv) Error() string {
+	return fmt.Sprintf("missing environment variables referenced in the configuration: %s", strings.Join(e.vars, ", "))
+}
+
+// checkReferencedEnv scans all the templated strings in the configuration,
+// and checks that all the environment variables they reference are either
+// set or declared.
+func checkReferencedEnv(ctx *context.Context, declared map[string]bool) error {
+	refs := map[string]struct{}{}
+	var err error
+	walkStrings(reflect.ValueOf(ctx.Config), func(s string) {
+		if err != nil || !strings.Contains(s, "{{") {
+			return
+		}
+		var names []string
+		names, err = tmpl.ReferencedEnv(s)
+		for _, name := range names {
+			refs[name] = struct{}{}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for name := range refs {
+		if _, ok := ctx.Env[name]; ok || declared[name] {
+			continue
+		}
+		missing = append(missing, name)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return ErrMissingEnv{missing}
+}
+
+func walkStrings(v reflect.Value, fn func(s string)) {
+	switch v.Kind() {
+	case reflect.String:
+		fn(v.String())
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walkStrings(v.Elem(), fn)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				walkStrings(v.Field(i), fn)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkStrings(v.Index(i), fn)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walkStrings(iter.Value(), fn)
+		}
+	}
+}
+
 func checkErrors(ctx *context.Context, noTokens, noTokenErrs bool, gitlabTokenErr, githubTokenErr, giteaTokenErr error) error {
 	if ctx.SkipTokenCheck || skips.Any(ctx, skips.Publish) {
 		return nil
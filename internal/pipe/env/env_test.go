@@ -6,6 +6,7 @@ import (
 	"syscall"
 	"testing"
 
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
@@ -118,6 +119,7 @@ func TestValidGithubEnv(t *testing.T) {
 	require.NoError(t, Pipe{}.Run(ctx))
 	require.Equal(t, "asdf", ctx.Token)
 	require.Equal(t, context.TokenTypeGitHub, ctx.TokenType)
+	require.Equal(t, "token: *****", logext.Redact("token: asdf"))
 }
 
 func TestValidGitlabEnv(t *testing.T) {
@@ -305,3 +307,70 @@ func TestLoadEnv(t *testing.T) {
 		require.Equal(t, "", v)
 	})
 }
+
+func TestCheckEnv(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "asdf")
+	t.Setenv("SET_VAR", "set")
+	cfg := config.Project{
+		CheckEnv: true,
+		Env: []string{
+			"DECLARED_VAR=foo",
+		},
+		Builds: []config.Build{
+			{
+				BuildDetails: config.BuildDetails{
+					Ldflags: []string{"-X main.foo={{ .Env.SET_VAR }} -X main.bar={{ .Env.DECLARED_VAR }}"},
+				},
+			},
+		},
+		Release: config.Release{
+			Footer: "{{ .Env.GITHUB_TOKEN }}",
+		},
+	}
+
+	t.Run("all set", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg)
+		require.NoError(t, Pipe{}.Run(ctx))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg)
+		ctx.Config.Signs = []config.Sign{{
+			Args: []string{"--key={{ .Env.GIHTUB_KEY }}"},
+		}}
+		ctx.Config.Announce.Slack = []config.Slack{{
+			MessageTemplate: `{{ .Env.MISSING }}{{ if isEnvSet "OPTIONAL" }}{{ .Env.OPTIONAL }}{{ end }}`,
+		}}
+		require.EqualError(t, Pipe{}.Run(ctx), "missing environment variables referenced in the configuration: GIHTUB_KEY, MISSING")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg)
+		ctx.Config.CheckEnv = false
+		ctx.Config.Release.Footer = "{{ .Env.MISSING }}"
+		require.NoError(t, Pipe{}.Run(ctx))
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg)
+		ctx.Config.Release.Footer = "{{ .Env.MISSING "
+		require.Error(t, Pipe{}.Run(ctx))
+	})
+}
+
+func TestCheckEnvTokenFromFile(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "token")
+	require.NoError(t, err)
+	fmt.Fprint(f, "12345")
+	require.NoError(t, f.Close())
+	ctx := testctx.NewWithCfg(config.Project{
+		CheckEnv: true,
+		EnvFiles: config.EnvFiles{
+			GitHubToken: f.Name(),
+		},
+		Release: config.Release{
+			Footer: "{{ .Env.GITHUB_TOKEN }}",
+		},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+}
@@ -0,0 +1,274 @@
+// Package flatpak implements the Pipe interface providing flatpak bundles
+// bindings.
+package flatpak
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultNameTemplate = `{{ .ProjectName }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}`
+	defaultRuntime      = "org.freedesktop.Platform"
+	defaultSDK          = "org.freedesktop.Sdk"
+	bundleFormat        = "flatpak"
+)
+
+var (
+	errNoAppID          = errors.New("flatpak: app_id is required")
+	errNoRuntimeVersion = errors.New("flatpak: runtime_version is required")
+)
+
+// cmd runs flatpak-builder and flatpak.
+var cmd shell.Runner = shell.StdRunner{}
+
+// archs maps the supported GOARCHs to their flatpak architectures.
+var archs = map[string]string{
+	"amd64": "x86_64",
+	"arm64": "aarch64",
+}
+
+// Pipe for flatpak bundles.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "flatpak bundles" }
+func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.Flatpaks) == 0 }
+func (Pipe) Dependencies(_ *context.Context) []string {
+	return []string{"flatpak-builder", "flatpak"}
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("flatpaks")
+	for i := range ctx.Config.Flatpaks {
+		flatpak := &ctx.Config.Flatpaks[i]
+		if flatpak.ID == "" {
+			flatpak.ID = "default"
+		}
+		if flatpak.NameTemplate == "" {
+			flatpak.NameTemplate = defaultNameTemplate
+		}
+		if flatpak.Goamd64 == "" {
+			flatpak.Goamd64 = "v1"
+		}
+		if flatpak.Runtime == "" {
+			flatpak.Runtime = defaultRuntime
+		}
+		if flatpak.SDK == "" {
+			flatpak.SDK = defaultSDK
+		}
+		ids.Inc(flatpak.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, flatpak := range ctx.Config.Flatpaks {
+		if err := doRun(ctx, flatpak); err != nil {
+			if pipe.IsSkip(err) {
+				skips.Remember(err)
+				continue
+			}
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doRun(ctx *context.Context, flatpak config.Flatpak) error {
+	tpl := tmpl.New(ctx)
+	if err := tpl.ApplyAll(
+		&flatpak.Disable,
+		&flatpak.AppID,
+		&flatpak.Runtime,
+		&flatpak.RuntimeVersion,
+		&flatpak.SDK,
+		&flatpak.Command,
+	); err != nil {
+		return err
+	}
+	if flatpak.Disable == "true" {
+		return pipe.Skip("configuration is disabled")
+	}
+	if flatpak.AppID == "" {
+		return errNoAppID
+	}
+	if flatpak.RuntimeVersion == "" {
+		return errNoRuntimeVersion
+	}
+	finishArgs := make([]string, 0, len(flatpak.FinishArgs))
+	for _, arg := range flatpak.FinishArgs {
+		arg, err := tpl.Apply(arg)
+		if err != nil {
+			return err
+		}
+		finishArgs = append(finishArgs, arg)
+	}
+	flatpak.FinishArgs = finishArgs
+
+	filters := []artifact.Filter{
+		artifact.ByGoos("linux"),
+		artifact.ByType(artifact.Binary),
+		artifact.Or(
+			artifact.And(
+				artifact.ByGoarch("amd64"),
+				artifact.ByGoamd64(flatpak.Goamd64),
+			),
+			artifact.ByGoarch("arm64"),
+		),
+	}
+	if len(flatpak.IDs) > 0 {
+		filters = append(filters, artifact.ByIDs(flatpak.IDs...))
+	}
+
+	g := semerrgroup.NewShared(ctx)
+	for platform, binaries := range ctx.Artifacts.Filter(artifact.And(filters...)).GroupByPlatform() {
+		arch, ok := archs[binaries[0].Goarch]
+		if !ok {
+			log.WithField("platform", platform).Warn("ignored unsupported arch")
+			continue
+		}
+		g.Go(func() error {
+			return create(ctx, flatpak, arch, binaries)
+		})
+	}
+	return g.Wait()
+}
+
+func create(ctx *context.Context, flatpak config.Flatpak, arch string, binaries []*artifact.Artifact) error {
+	name, err := tmpl.New(ctx).WithArtifact(binaries[0]).Apply(flatpak.NameTemplate)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(ctx.Config.Dist, "flatpak", name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	bts, err := manifestFor(flatpak, binaries)
+	if err != nil {
+		return err
+	}
+	manifestPath := filepath.Join(dir, flatpak.AppID+".json")
+	log := log.WithField("arch", arch)
+	log.WithField("file", manifestPath).Debug("creating manifest")
+	if err := os.WriteFile(manifestPath, bts, 0o644); err != nil {
+		return err
+	}
+
+	repo := filepath.Join(dir, "repo")
+	if out, err := cmd.Exec(ctx, shell.Cmd{
+		Name: "flatpak-builder",
+		Args: []string{
+			"--arch=" + arch,
+			"--force-clean",
+			"--repo=" + repo,
+			filepath.Join(dir, "build"),
+			manifestPath,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to build flatpak: %w: %s", err, string(out))
+	}
+
+	bundle := filepath.Join(ctx.Config.Dist, name+"."+bundleFormat)
+	log.WithField("bundle", bundle).Info("creating")
+	if out, err := cmd.Exec(ctx, shell.Cmd{
+		Name: "flatpak",
+		Args: []string{"build-bundle", "--arch=" + arch, repo, bundle, flatpak.AppID},
+	}); err != nil {
+		return fmt.Errorf("failed to create flatpak bundle: %w: %s", err, string(out))
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.Flatpak,
+		Name:    name + "." + bundleFormat,
+		Path:    bundle,
+		Goos:    binaries[0].Goos,
+		Goarch:  binaries[0].Goarch,
+		Goarm:   binaries[0].Goarm,
+		Goamd64: binaries[0].Goamd64,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:     flatpak.ID,
+			artifact.ExtraFormat: bundleFormat,
+		},
+	})
+	return nil
+}
+
+// Manifest is the flatpak-builder manifest.
+// See: https://docs.flatpak.org/en/latest/manifests.html
+type Manifest struct {
+	ID             string   `json:"id"`
+	Runtime        string   `json:"runtime"`
+	RuntimeVersion string   `json:"runtime-version"`
+	SDK            string   `json:"sdk"`
+	Command        string   `json:"command"`
+	FinishArgs     []string `json:"finish-args,omitempty"`
+	Modules        []Module `json:"modules"`
+}
+
+// Module is a module in the flatpak-builder manifest.
+type Module struct {
+	Name          string   `json:"name"`
+	Buildsystem   string   `json:"buildsystem"`
+	BuildCommands []string `json:"build-commands"`
+	Sources       []Source `json:"sources"`
+}
+
+// Source is a source in a flatpak-builder module.
+type Source struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+// manifestFor returns the manifest installing the given binaries in the
+// flatpak, the first one being the command if none is set.
+func manifestFor(flatpak config.Flatpak, binaries []*artifact.Artifact) ([]byte, error) {
+	module := Module{
+		Name:        flatpak.AppID,
+		Buildsystem: "simple",
+	}
+	for _, binary := range binaries {
+		path, err := filepath.Abs(binary.Path)
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.Base(binary.Path)
+		module.BuildCommands = append(module.BuildCommands, fmt.Sprintf("install -Dm755 %s /app/bin/%s", name, name))
+		module.Sources = append(module.Sources, Source{
+			Type: "file",
+			Path: path,
+		})
+	}
+
+	command := flatpak.Command
+	if command == "" {
+		command = filepath.Base(binaries[0].Path)
+	}
+
+	return json.MarshalIndent(Manifest{
+		ID:             flatpak.AppID,
+		Runtime:        flatpak.Runtime,
+		RuntimeVersion: flatpak.RuntimeVersion,
+		SDK:            flatpak.SDK,
+		Command:        command,
+		FinishArgs:     flatpak.FinishArgs,
+		Modules:        []Module{module},
+	}, "", "  ")
+}
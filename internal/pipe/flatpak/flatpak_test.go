@@ -0,0 +1,223 @@
+package flatpak
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Flatpaks: []config.Flatpak{{}},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, config.Flatpak{
+		ID:           "default",
+		NameTemplate: defaultNameTemplate,
+		Goamd64:      "v1",
+		Runtime:      defaultRuntime,
+		SDK:          defaultSDK,
+	}, ctx.Config.Flatpaks[0])
+}
+
+func TestManifest(t *testing.T) {
+	out, err := manifestFor(config.Flatpak{
+		AppID:          "org.goreleaser.Foo",
+		Runtime:        defaultRuntime,
+		RuntimeVersion: "23.08",
+		SDK:            defaultSDK,
+		FinishArgs:     []string{"--share=network"},
+	}, []*artifact.Artifact{
+		{Name: "foo", Path: "/dist/foo_linux_amd64_v1/foo"},
+		{Name: "bar", Path: "/dist/bar_linux_amd64_v1/bar"},
+	})
+	require.NoError(t, err)
+	golden.RequireEqualJSON(t, out)
+}
+
+func TestRun(t *testing.T) {
+	mock, restore := shell.Mock(&cmd, nil)
+	t.Cleanup(restore)
+
+	folder := t.TempDir()
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Dist:        folder,
+		Flatpaks: []config.Flatpak{
+			{
+				AppID:          "org.goreleaser.{{ .ProjectName }}",
+				RuntimeVersion: "23.08",
+				FinishArgs:     []string{"--env=VERSION={{ .Version }}"},
+			},
+		},
+	}, testctx.WithVersion("1.0.0"))
+	addBinaries(t, ctx, folder)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	flatpaks := ctx.Artifacts.Filter(artifact.ByType(artifact.Flatpak)).List()
+	require.Len(t, flatpaks, 2)
+	names := []string{flatpaks[0].Name, flatpaks[1].Name}
+	require.ElementsMatch(t, []string{
+		"foo_1.0.0_linux_amd64.flatpak",
+		"foo_1.0.0_linux_arm64.flatpak",
+	}, names)
+	for _, flatpak := range flatpaks {
+		require.Equal(t, "default", artifact.ExtraOr(*flatpak, artifact.ExtraID, ""))
+		require.Equal(t, "flatpak", artifact.ExtraOr(*flatpak, artifact.ExtraFormat, ""))
+	}
+
+	dir := filepath.Join(folder, "flatpak", "foo_1.0.0_linux_amd64")
+	manifest := filepath.Join(dir, "org.goreleaser.foo.json")
+	bts, err := os.ReadFile(manifest)
+	require.NoError(t, err)
+	require.Contains(t, string(bts), `"--env=VERSION=1.0.0"`)
+	require.Contains(t, string(bts), `"command": "foo"`)
+
+	calls := mock.Lines()
+	require.Len(t, calls, 4)
+	require.Contains(t, calls, "flatpak-builder --arch=x86_64 --force-clean --repo="+
+		filepath.Join(dir, "repo")+" "+filepath.Join(dir, "build")+" "+manifest)
+	require.Contains(t, calls, "flatpak build-bundle --arch=x86_64 "+
+		filepath.Join(dir, "repo")+" "+filepath.Join(folder, "foo_1.0.0_linux_amd64.flatpak")+" org.goreleaser.foo")
+}
+
+func TestRunGoamd64(t *testing.T) {
+	_, restore := shell.Mock(&cmd, nil)
+	t.Cleanup(restore)
+
+	folder := t.TempDir()
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Dist:        folder,
+		Flatpaks: []config.Flatpak{
+			{
+				AppID:          "org.goreleaser.foo",
+				RuntimeVersion: "23.08",
+				Goamd64:        "v3",
+			},
+		},
+	}, testctx.WithVersion("1.0.0"))
+	addBinaries(t, ctx, folder)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	amd64 := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.Flatpak),
+		artifact.ByGoarch("amd64"),
+	)).List()
+	require.Len(t, amd64, 1)
+	require.Equal(t, "v3", amd64[0].Goamd64)
+}
+
+func TestRunErrors(t *testing.T) {
+	for name, tt := range map[string]struct {
+		flatpak config.Flatpak
+		fail    string
+		check   func(tb testing.TB, err error)
+	}{
+		"no app id": {
+			flatpak: config.Flatpak{RuntimeVersion: "23.08"},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.ErrorIs(tb, err, errNoAppID)
+			},
+		},
+		"no runtime version": {
+			flatpak: config.Flatpak{AppID: "org.goreleaser.foo"},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.ErrorIs(tb, err, errNoRuntimeVersion)
+			},
+		},
+		"bad app id": {
+			flatpak: config.Flatpak{AppID: "{{ .Nope }", RuntimeVersion: "23.08"},
+			check:   testlib.RequireTemplateError,
+		},
+		"bad finish args": {
+			flatpak: config.Flatpak{
+				AppID:          "org.goreleaser.foo",
+				RuntimeVersion: "23.08",
+				FinishArgs:     []string{"{{ .Nope }"},
+			},
+			check: testlib.RequireTemplateError,
+		},
+		"failed to build": {
+			flatpak: config.Flatpak{AppID: "org.goreleaser.foo", RuntimeVersion: "23.08"},
+			fail:    "flatpak-builder",
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.EqualError(tb, err, "failed to build flatpak: fake error: some output")
+			},
+		},
+		"failed to bundle": {
+			flatpak: config.Flatpak{AppID: "org.goreleaser.foo", RuntimeVersion: "23.08"},
+			fail:    "flatpak",
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.EqualError(tb, err, "failed to create flatpak bundle: fake error: some output")
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, restore := shell.Mock(&cmd, func(c shell.Cmd) ([]byte, error) {
+				if c.Name == tt.fail {
+					return []byte("some output"), errors.New("fake error")
+				}
+				return nil, nil
+			})
+			t.Cleanup(restore)
+			folder := t.TempDir()
+			ctx := testctx.NewWithCfg(config.Project{
+				ProjectName: "foo",
+				Dist:        folder,
+				Flatpaks:    []config.Flatpak{tt.flatpak},
+			})
+			addBinaries(t, ctx, folder)
+			require.NoError(t, Pipe{}.Default(ctx))
+			tt.check(t, Pipe{}.Run(ctx))
+		})
+	}
+}
+
+func addBinaries(tb testing.TB, ctx *context.Context, dist string) {
+	tb.Helper()
+	for _, platform := range []struct{ goarch, goamd64 string }{
+		{goarch: "amd64", goamd64: "v1"},
+		{goarch: "amd64", goamd64: "v3"},
+		{goarch: "arm64"},
+		{goarch: "386"},
+	} {
+		path := filepath.Join(dist, "foo_linux_"+platform.goarch+platform.goamd64, "foo")
+		require.NoError(tb, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(tb, os.WriteFile(path, []byte("fake"), 0o755))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo",
+			Path:    path,
+			Goos:    "linux",
+			Goarch:  platform.goarch,
+			Goamd64: platform.goamd64,
+			Type:    artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: "foo",
+			},
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "foo.exe",
+		Path:   filepath.Join(dist, "foo_windows_amd64", "foo.exe"),
+		Goos:   "windows",
+		Goarch: "amd64",
+		Type:   artifact.Binary,
+	})
+}
@@ -33,3 +33,13 @@ var ErrNotRepository = errors.New("current folder is not a git repository")
 
 // ErrNoGit happens when git is not present in PATH.
 var ErrNoGit = errors.New("git not present in PATH")
+
+// ErrTagExists happens when the tag being created already exists, pointing to
+// another commit.
+type ErrTagExists struct {
+	commit, tag string
+}
+
+func (e ErrTagExists) Error() string {
+	return fmt.Sprintf("git tag %v already exists and was not made against commit %v", e.tag, e.commit)
+}
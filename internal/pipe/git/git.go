@@ -1,6 +1,7 @@
 package git
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"github.com/charmbracelet/x/exp/ordered"
 	"github.com/goreleaser/goreleaser/v2/internal/git"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/nightly"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -31,6 +33,12 @@ func setDefaults(ctx *context.Context) {
 	if ctx.Config.Git.TagSort == "" {
 		ctx.Config.Git.TagSort = "-version:refname"
 	}
+	if ctx.Config.Git.Tag.Message == "" {
+		ctx.Config.Git.Tag.Message = "{{ .Tag }}"
+	}
+	if ctx.Config.Git.Tag.Remote == "" {
+		ctx.Config.Git.Tag.Remote = "origin"
+	}
 }
 
 // Run the pipe.
@@ -50,7 +58,11 @@ func (Pipe) Run(ctx *context.Context) error {
 		WithField("previous_tag", ordered.First(info.PreviousTag, "<unknown>")).
 		WithField("dirty", info.Dirty).
 		Info("git state")
-	ctx.Version = strings.TrimPrefix(ctx.Git.CurrentTag, "v")
+	ctx.Version = strings.TrimPrefix(strings.TrimPrefix(ctx.Git.CurrentTag, ctx.Config.Monorepo.TagPrefix), "v")
+	if ctx.CreateTag {
+		// the tag is created and validated by TagPipe.
+		return nil
+	}
 	return validate(ctx)
 }
 
@@ -73,6 +85,19 @@ func getInfo(ctx *context.Context) (context.GitInfo, error) {
 		return context.GitInfo{}, ErrNotRepository
 	}
 	info, err := getGitInfo(ctx)
+	if ctx.CreateTag && errors.Is(err, ErrNoTag) {
+		log.Warn("no tags found, using v0.0.0 as the base version of the tag")
+		return info, nil
+	}
+	if ctx.Nightly {
+		if errors.Is(err, ErrNoTag) {
+			log.Warn("no tags found, using v0.0.0 as the base version of the nightly")
+			return info, nil
+		}
+		// the changelog of a nightly goes from its base tag to the current
+		// commit.
+		info.PreviousTag = info.CurrentTag
+	}
 	if err != nil && ctx.Snapshot {
 		log.WithError(err).Warn("ignoring errors because this is a snapshot")
 		if info.Commit == "" {
@@ -131,6 +156,13 @@ func getGitInfo(ctx *context.Context) (context.GitInfo, error) {
 		}
 		excluding = append(excluding, tag)
 	}
+	if ctx.Nightly || ctx.DistinctNightly {
+		tag, err := nightly.TagName(ctx)
+		if err != nil {
+			return context.GitInfo{}, err
+		}
+		excluding = append(excluding, tag)
+	}
 
 	tag, err := getTag(ctx, excluding)
 	if err != nil {
@@ -199,6 +231,15 @@ func validate(ctx *context.Context) error {
 	if err := CheckDirty(ctx); err != nil {
 		return err
 	}
+	if ctx.Nightly {
+		// nightlies are built from any commit, not from a tagged one.
+		return nil
+	}
+	return validateTag(ctx)
+}
+
+// validateTag checks that the current tag points to the current commit.
+func validateTag(ctx *context.Context) error {
 	_, err := git.Clean(git.Run(ctx, "describe", "--exact-match", "--tags", "--match", ctx.Git.CurrentTag))
 	if err != nil {
 		return ErrWrongRef{
@@ -323,6 +364,9 @@ func gitTagsPointingAt(ctx *context.Context, ref string) ([]string, error) {
 		"--sort",
 		ctx.Config.Git.TagSort,
 	)
+	if prefix := ctx.Config.Monorepo.TagPrefix; prefix != "" {
+		args = append(args, "--list", prefix+"*")
+	}
 	return git.CleanAllLines(git.Run(ctx, args...))
 }
 
@@ -333,6 +377,9 @@ func gitDescribe(ctx *context.Context, ref string, excluding []string) (string,
 		"--abbrev=0",
 		ref,
 	}
+	if prefix := ctx.Config.Monorepo.TagPrefix; prefix != "" {
+		args = append(args, "--match="+prefix+"*")
+	}
 	for _, exclude := range excluding {
 		args = append(args, "--exclude="+exclude)
 	}
@@ -239,6 +239,131 @@ func TestValidState(t *testing.T) {
 	require.False(t, ctx.Git.Dirty)
 }
 
+func TestNightly(t *testing.T) {
+	testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitRemoteAdd(t, "git@github.com:foo/bar.git")
+	testlib.GitCommit(t, "commit1")
+	testlib.GitTag(t, "v0.0.1")
+	testlib.GitCommit(t, "commit2")
+	testlib.GitTag(t, "nightly")
+	testlib.GitCommit(t, "commit3")
+	ctx := testctx.New(testctx.Nightly)
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, "v0.0.1", ctx.Git.CurrentTag)
+	require.Equal(t, "v0.0.1", ctx.Git.PreviousTag)
+	require.Equal(t, "0.0.1", ctx.Version)
+}
+
+func TestDistinctNightly(t *testing.T) {
+	setup := func(t *testing.T, nightly string) {
+		t.Helper()
+		testlib.Mktmp(t)
+		testlib.GitInit(t)
+		testlib.GitRemoteAdd(t, "git@github.com:foo/bar.git")
+		testlib.GitCommit(t, "commit1")
+		testlib.GitTag(t, "v0.0.1")
+		testlib.GitCommit(t, "commit2")
+		testlib.GitTag(t, nightly)
+		testlib.GitCommit(t, "commit3")
+		testlib.GitTag(t, "v0.0.2")
+	}
+
+	t.Run("not distinct", func(t *testing.T) {
+		setup(t, "nightly")
+		ctx := testctx.New()
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "v0.0.2", ctx.Git.CurrentTag)
+		require.Equal(t, "nightly", ctx.Git.PreviousTag)
+	})
+
+	t.Run("distinct", func(t *testing.T) {
+		setup(t, "nightly")
+		ctx := testctx.New()
+		ctx.DistinctNightly = true
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "v0.0.2", ctx.Git.CurrentTag)
+		require.Equal(t, "v0.0.1", ctx.Git.PreviousTag)
+	})
+
+	t.Run("distinct custom tag", func(t *testing.T) {
+		setup(t, "devel")
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{TagName: "devel"},
+		})
+		ctx.DistinctNightly = true
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "v0.0.2", ctx.Git.CurrentTag)
+		require.Equal(t, "v0.0.1", ctx.Git.PreviousTag)
+	})
+
+	t.Run("distinct templated tag", func(t *testing.T) {
+		setup(t, "devel")
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{TagName: "{{ .Env.TAG }}"},
+		}, testctx.WithEnv(map[string]string{"TAG": "devel"}))
+		ctx.DistinctNightly = true
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "v0.0.2", ctx.Git.CurrentTag)
+		require.Equal(t, "v0.0.1", ctx.Git.PreviousTag)
+	})
+}
+
+func TestNightlyNoTags(t *testing.T) {
+	testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitRemoteAdd(t, "git@github.com:foo/bar.git")
+	testlib.GitCommit(t, "commit1")
+	ctx := testctx.New(testctx.Nightly)
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, "v0.0.0", ctx.Git.CurrentTag)
+	require.Empty(t, ctx.Git.PreviousTag)
+	require.NotEmpty(t, ctx.Git.FirstCommit)
+}
+
+func TestNightlyDirty(t *testing.T) {
+	folder := testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitRemoteAdd(t, "git@github.com:foo/bar.git")
+	testlib.GitCommit(t, "commit1")
+	testlib.GitTag(t, "v0.0.1")
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "foo"), []byte("foobar"), 0o644))
+	ctx := testctx.New(testctx.Nightly)
+	require.ErrorContains(t, Pipe{}.Run(ctx), "git is in a dirty state")
+}
+
+func TestMonorepo(t *testing.T) {
+	testlib.Mktmp(t)
+	testlib.GitInit(t)
+	testlib.GitRemoteAdd(t, "git@github.com:foo/bar.git")
+	testlib.GitCommit(t, "commit1")
+	testlib.GitTag(t, "service-a/v1.0.0")
+	testlib.GitCommit(t, "commit2")
+	testlib.GitTag(t, "service-b/v2.0.0")
+	testlib.GitTag(t, "v3.0.0")
+	testlib.GitCommit(t, "commit3")
+	testlib.GitTag(t, "service-a/v1.1.0")
+	testlib.GitTag(t, "service-b/v2.1.0")
+	ctx := testctx.NewWithCfg(config.Project{
+		Monorepo: config.Monorepo{TagPrefix: "service-a/"},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, "service-a/v1.1.0", ctx.Git.CurrentTag)
+	require.Equal(t, "service-a/v1.0.0", ctx.Git.PreviousTag)
+	require.Equal(t, "1.1.0", ctx.Version)
+
+	t.Run("untagged commit", func(t *testing.T) {
+		testlib.GitCommit(t, "commit4")
+		testlib.GitTag(t, "service-b/v2.2.0")
+		ctx := testctx.NewWithCfg(config.Project{
+			Monorepo: config.Monorepo{TagPrefix: "service-a/"},
+		}, testctx.Skip(skips.Validate))
+		testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+		require.Equal(t, "service-a/v1.1.0", ctx.Git.CurrentTag)
+		require.Equal(t, "service-a/v1.0.0", ctx.Git.PreviousTag)
+	})
+}
+
 func TestSnapshotNoTags(t *testing.T) {
 	testlib.Mktmp(t)
 	testlib.GitInit(t)
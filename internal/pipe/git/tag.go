@@ -0,0 +1,114 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/git"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/semver"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// TagPipe creates the tag from git.tag on the current commit, locally, when
+// releasing with --create-tag.
+//
+// It runs after the semver pipe, so the tag name is evaluated with the state
+// of the latest tag, e.g., `v{{ incpatch .Version }}` gives the next patch
+// version, and `.Major`, `.Minor`, etc are set.
+// The tag is only pushed by PushTagPipe, when publishing, so a failed build
+// never leaves a published tag behind.
+type TagPipe struct{}
+
+func (TagPipe) String() string { return "creating tag" }
+
+func (TagPipe) Skip(ctx *context.Context) bool { return !ctx.CreateTag }
+
+// Run the pipe.
+func (TagPipe) Run(ctx *context.Context) error {
+	cfg := ctx.Config.Git.Tag
+	if cfg.NameTemplate == "" {
+		return errors.New("git.tag.name_template is required to create a tag")
+	}
+	if !skips.Any(ctx, skips.Validate) {
+		if err := CheckDirty(ctx); err != nil {
+			return err
+		}
+	}
+
+	tag, err := tmpl.New(ctx).Apply(cfg.NameTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse tag name: %w", err)
+	}
+	if tag == "" {
+		return errors.New("empty tag name")
+	}
+
+	// prints the commit of the tag, followed by the tag object itself if it is
+	// annotated, or nothing if it does not exist.
+	commit, err := git.Clean(git.Run(ctx, "tag", "--list", "--format=%(*objectname)%(objectname)", tag))
+	if err != nil {
+		return fmt.Errorf("couldn't check if tag %s exists: %w", tag, err)
+	}
+	switch {
+	case commit == "":
+		ctx.Git.PreviousTag = ctx.Git.CurrentTag
+		ctx.Git.CurrentTag = tag
+		ctx.Version = strings.TrimPrefix(strings.TrimPrefix(tag, ctx.Config.Monorepo.TagPrefix), "v")
+		message, err := tmpl.New(ctx).Apply(cfg.Message)
+		if err != nil {
+			return fmt.Errorf("failed to parse tag message: %w", err)
+		}
+		flag := "--annotate"
+		if cfg.Sign {
+			flag = "--sign"
+		}
+		log.WithField("tag", tag).Info("creating tag")
+		if _, err := git.Clean(git.Run(ctx, "tag", flag, "--message", message, tag)); err != nil {
+			return fmt.Errorf("failed to create tag %s: %w", tag, err)
+		}
+	case strings.HasPrefix(commit, ctx.Git.FullCommit):
+		log.WithField("tag", tag).Warn("tag already exists on the current commit, not creating it")
+	default:
+		return ErrTagExists{commit: ctx.Git.FullCommit, tag: tag}
+	}
+
+	info, err := getInfo(ctx)
+	if err != nil {
+		return err
+	}
+	ctx.Git = info
+	ctx.Version = strings.TrimPrefix(strings.TrimPrefix(ctx.Git.CurrentTag, ctx.Config.Monorepo.TagPrefix), "v")
+	if err := (semver.Pipe{}).Run(ctx); err != nil {
+		return err
+	}
+	return validateTag(ctx)
+}
+
+// PushTagPipe pushes the tag created by TagPipe to git.tag.remote.
+//
+// It is the first publisher, as the release and the publishers after it
+// expect the tag to exist in the remote.
+type PushTagPipe struct{}
+
+func (PushTagPipe) String() string { return "pushing tag" }
+func (PushTagPipe) Key() string    { return "tag" }
+
+func (PushTagPipe) Skip(ctx *context.Context) bool { return !ctx.CreateTag }
+
+// Unrecoverable implements publish.Unrecoverable.
+func (PushTagPipe) Unrecoverable() bool { return true }
+
+// Publish pushes the tag.
+func (PushTagPipe) Publish(ctx *context.Context) error {
+	tag := ctx.Git.CurrentTag
+	remote := ctx.Config.Git.Tag.Remote
+	log.WithField("tag", tag).WithField("remote", remote).Info("pushing tag")
+	if _, err := git.Clean(git.Run(ctx, "push", remote, "refs/tags/"+tag)); err != nil {
+		return fmt.Errorf("failed to push tag %s: %w", tag, err)
+	}
+	return nil
+}
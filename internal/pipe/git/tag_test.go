@@ -0,0 +1,211 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/git"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/semver"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	gcontext "github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTag(t *testing.T) {
+	remote := setupTagRepo(t)
+	testlib.GitTag(t, "v0.1.0")
+	testlib.GitCommit(t, "commit2")
+	ctx := testctx.NewWithCfg(config.Project{
+		Git: config.Git{
+			Tag: config.GitTag{
+				NameTemplate: "v{{ incpatch .Version }}",
+				Message:      "release {{ .Tag }}",
+			},
+		},
+	})
+	ctx.CreateTag = true
+	require.NoError(t, createTag(ctx))
+	require.Equal(t, "v0.1.1", ctx.Git.CurrentTag)
+	require.Equal(t, "v0.1.0", ctx.Git.PreviousTag)
+	require.Equal(t, "release v0.1.1", ctx.Git.TagSubject)
+	require.Equal(t, "0.1.1", ctx.Version)
+	require.Equal(t, uint64(1), ctx.Semver.Patch)
+	require.Empty(t, remoteTags(t, remote), "tag should only be pushed when publishing")
+
+	require.NoError(t, PushTagPipe{}.Publish(ctx))
+	require.Equal(t, "v0.1.1", remoteTags(t, remote))
+
+	t.Run("already exists", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Git: config.Git{
+				Tag: config.GitTag{
+					NameTemplate: "v0.1.1",
+				},
+			},
+		})
+		ctx.CreateTag = true
+		require.NoError(t, createTag(ctx))
+		require.Equal(t, "v0.1.1", ctx.Git.CurrentTag)
+		require.Equal(t, "release v0.1.1", ctx.Git.TagSubject)
+	})
+
+	t.Run("exists on another commit", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Git: config.Git{
+				Tag: config.GitTag{
+					NameTemplate: "v0.1.0",
+				},
+			},
+		})
+		ctx.CreateTag = true
+		require.ErrorAs(t, createTag(ctx), &ErrTagExists{})
+	})
+}
+
+func TestCreateTagNoTags(t *testing.T) {
+	remote := setupTagRepo(t)
+	ctx := testctx.NewWithCfg(config.Project{
+		Git: config.Git{
+			Tag: config.GitTag{
+				NameTemplate: "v{{ incminor .Version }}",
+			},
+		},
+	})
+	ctx.CreateTag = true
+	require.NoError(t, createTag(ctx))
+	require.Equal(t, "v0.1.0", ctx.Git.CurrentTag)
+	require.Empty(t, ctx.Git.PreviousTag)
+	require.Equal(t, "v0.1.0", ctx.Git.TagSubject)
+	require.NoError(t, PushTagPipe{}.Publish(ctx))
+	require.Equal(t, "v0.1.0", remoteTags(t, remote))
+}
+
+func TestCreateTagSemver(t *testing.T) {
+	setupTagRepo(t)
+	testlib.GitTag(t, "v1.2.3")
+	testlib.GitCommit(t, "commit2")
+	ctx := testctx.NewWithCfg(config.Project{
+		Git: config.Git{
+			Tag: config.GitTag{
+				NameTemplate: "v{{ .Major }}.{{ .Minor }}.99",
+			},
+		},
+	})
+	ctx.CreateTag = true
+	require.NoError(t, createTag(ctx))
+	require.Equal(t, "v1.2.99", ctx.Git.CurrentTag)
+	require.Equal(t, "v1.2.3", ctx.Git.PreviousTag)
+	require.Equal(t, uint64(99), ctx.Semver.Patch)
+}
+
+func TestCreateTagSkip(t *testing.T) {
+	require.True(t, TagPipe{}.Skip(testctx.New()))
+	require.True(t, PushTagPipe{}.Skip(testctx.New()))
+	ctx := testctx.New()
+	ctx.CreateTag = true
+	require.False(t, TagPipe{}.Skip(ctx))
+	require.False(t, PushTagPipe{}.Skip(ctx))
+}
+
+func TestCreateTagErrors(t *testing.T) {
+	t.Run("no name template", func(t *testing.T) {
+		setupTagRepo(t)
+		ctx := testctx.New()
+		ctx.CreateTag = true
+		require.EqualError(t, createTag(ctx), "git.tag.name_template is required to create a tag")
+	})
+
+	t.Run("invalid name template", func(t *testing.T) {
+		setupTagRepo(t)
+		ctx := testctx.NewWithCfg(config.Project{
+			Git: config.Git{
+				Tag: config.GitTag{
+					NameTemplate: "{{ .Version }",
+				},
+			},
+		})
+		ctx.CreateTag = true
+		testlib.RequireTemplateError(t, createTag(ctx))
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		setupTagRepo(t)
+		ctx := testctx.NewWithCfg(config.Project{
+			Git: config.Git{
+				Tag: config.GitTag{
+					NameTemplate: "v1.0.0",
+					Message:      "{{ .Tag }",
+				},
+			},
+		})
+		ctx.CreateTag = true
+		testlib.RequireTemplateError(t, createTag(ctx))
+	})
+
+	t.Run("dirty", func(t *testing.T) {
+		setupTagRepo(t)
+		require.NoError(t, os.WriteFile("foo", []byte("foobar"), 0o644))
+		ctx := testctx.NewWithCfg(config.Project{
+			Git: config.Git{
+				Tag: config.GitTag{
+					NameTemplate: "v1.0.0",
+				},
+			},
+		})
+		ctx.CreateTag = true
+		require.ErrorContains(t, createTag(ctx), "git is in a dirty state")
+	})
+
+	t.Run("push fails", func(t *testing.T) {
+		setupTagRepo(t)
+		testlib.GitRemoteAddWithName(t, "nope", filepath.Join(t.TempDir(), "nope"))
+		ctx := testctx.NewWithCfg(config.Project{
+			Git: config.Git{
+				Tag: config.GitTag{
+					NameTemplate: "v1.0.0",
+					Remote:       "nope",
+				},
+			},
+		})
+		ctx.CreateTag = true
+		require.NoError(t, createTag(ctx))
+		require.ErrorContains(t, PushTagPipe{}.Publish(ctx), "failed to push tag v1.0.0")
+	})
+}
+
+// createTag runs the pipes involved in creating a tag, in the order of the
+// build pipeline.
+func createTag(ctx *gcontext.Context) error {
+	if err := (Pipe{}).Run(ctx); err != nil {
+		return err
+	}
+	if err := (semver.Pipe{}).Run(ctx); err != nil {
+		return err
+	}
+	return TagPipe{}.Run(ctx)
+}
+
+// setupTagRepo creates a repository with a single commit and a bare remote,
+// returning the remote path.
+func setupTagRepo(tb testing.TB) string {
+	tb.Helper()
+	tb.Setenv("GIT_COMMITTER_NAME", "GoReleaser")
+	tb.Setenv("GIT_COMMITTER_EMAIL", "test@goreleaser.github.com")
+	testlib.Mktmp(tb)
+	testlib.GitInit(tb)
+	remote := testlib.GitMakeBareRepository(tb)
+	testlib.GitRemoteAdd(tb, remote)
+	testlib.GitCommit(tb, "commit1")
+	return remote
+}
+
+func remoteTags(tb testing.TB, remote string) string {
+	tb.Helper()
+	out, err := git.Clean(git.Run(context.Background(), "-C", remote, "tag", "--list"))
+	require.NoError(tb, err)
+	return out
+}
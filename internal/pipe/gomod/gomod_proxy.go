@@ -74,7 +74,7 @@ type ProxyPipe struct{}
 func (ProxyPipe) String() string { return "proxying go module" }
 
 func (ProxyPipe) Skip(ctx *context.Context) bool {
-	return ctx.ModulePath == "" || !ctx.Config.GoMod.Proxy || ctx.Snapshot
+	return ctx.ModulePath == "" || !ctx.Config.GoMod.Proxy || ctx.Snapshot || ctx.Nightly
 }
 
 // Run the ProxyPipe.
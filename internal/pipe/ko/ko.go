@@ -9,6 +9,7 @@ import (
 	"io"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -20,13 +21,18 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn/github"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/google"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/google/ko/pkg/build"
 	"github.com/google/ko/pkg/commands/options"
 	"github.com/google/ko/pkg/publish"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/referrers"
 	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
@@ -57,10 +63,20 @@ var (
 type Pipe struct{}
 
 func (Pipe) String() string { return "ko" }
+func (Pipe) Key() string    { return "ko" }
 func (Pipe) Skip(ctx *context.Context) bool {
 	return skips.Any(ctx, skips.Ko) || len(ctx.Config.Kos) == 0
 }
 
+// Dependencies implements healthcheck.Healthchecker.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	var cmds []string
+	for _, ko := range ctx.Config.Kos {
+		cmds = append(cmds, referrers.Dependencies(ko.AttachSBOMs)...)
+	}
+	return cmds
+}
+
 // Default sets the Pipes defaults.
 func (Pipe) Default(ctx *context.Context) error {
 	ids := ids.New("kos")
@@ -111,6 +127,10 @@ func (Pipe) Default(ctx *context.Context) error {
 			ko.Platforms = []string{"linux/amd64"}
 		}
 
+		if err := validatePlatformBaseImages(*ko); err != nil {
+			return err
+		}
+
 		if len(ko.Tags) == 0 {
 			ko.Tags = []string{"latest"}
 		}
@@ -127,6 +147,10 @@ func (Pipe) Default(ctx *context.Context) error {
 			return errNoRepository
 		}
 
+		if err := referrers.Default(&ko.AttachSBOMs); err != nil {
+			return err
+		}
+
 		ids.Inc(ko.ID)
 	}
 	return ids.Validate()
@@ -134,11 +158,19 @@ func (Pipe) Default(ctx *context.Context) error {
 
 // Publish executes the Pipe.
 func (Pipe) Publish(ctx *context.Context) error {
-	g := semerrgroup.New(ctx.Parallelism)
+	g := semerrgroup.NewShared(ctx)
+	conts := pipe.ContinueMemento{}
+	attacher := referrers.New()
 	for _, ko := range ctx.Config.Kos {
-		g.Go(doBuild(ctx, ko))
+		build := doBuild(ctx, ko, attacher)
+		g.Go(func() error {
+			return conts.Remember(ko.ContinueOnError, build())
+		})
 	}
-	return g.Wait()
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return conts.Evaluate()
 }
 
 type buildOptions struct {
@@ -150,6 +182,7 @@ type buildOptions struct {
 	workingDir          string
 	platforms           []string
 	baseImage           string
+	platformBaseImages  map[string]string
 	labels              map[string]string
 	tags                []string
 	creationTime        *v1.Time
@@ -177,29 +210,12 @@ func (o *buildOptions) makeBuilder(ctx *context.Context) (*build.Caching, error)
 			if err != nil {
 				return nil, nil, err
 			}
-
-			if cached, found := baseImages.Load(o.baseImage); found {
-				return ref, cached.(build.Result), nil
-			}
-
-			desc, err := remote.Get(
-				ref,
-				remote.WithAuthFromKeychain(keychain),
-			)
-			if err != nil {
-				return nil, nil, err
-			}
-			if desc.MediaType.IsImage() {
-				img, err := desc.Image()
-				baseImages.Store(o.baseImage, img)
-				return ref, img, err
-			}
-			if desc.MediaType.IsIndex() {
-				idx, err := desc.ImageIndex()
-				baseImages.Store(o.baseImage, idx)
-				return ref, idx, err
+			if len(o.platformBaseImages) == 0 {
+				res, err := fetchBaseImage(ref)
+				return ref, res, err
 			}
-			return nil, nil, fmt.Errorf("unexpected base image media type: %s", desc.MediaType)
+			idx, err := o.platformBaseIndex()
+			return ref, idx, err
 		}),
 	}
 	if o.creationTime != nil {
@@ -211,27 +227,130 @@ func (o *buildOptions) makeBuilder(ctx *context.Context) (*build.Caching, error)
 	for k, v := range o.labels {
 		buildOptions = append(buildOptions, build.WithLabel(k, v))
 	}
-	switch o.sbom {
+	sbom, err := sbomOption(o.sbom)
+	if err != nil {
+		return nil, err
+	}
+	buildOptions = append(buildOptions, sbom)
+
+	b, err := build.NewGo(ctx, o.workingDir, buildOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("newGo: %w", err)
+	}
+	return build.NewCaching(b)
+}
+
+// sbomOption returns the ko build option for the given SBOM format.
+// With "none", ko neither generates nor publishes a SBOM.
+func sbomOption(sbom string) (build.Option, error) {
+	switch sbom {
 	case "spdx":
-		buildOptions = append(buildOptions, build.WithSPDX("devel"))
+		return build.WithSPDX("devel"), nil
 	case "cyclonedx":
-		buildOptions = append(buildOptions, build.WithCycloneDX())
+		return build.WithCycloneDX(), nil
 	case "go.version-m":
-		buildOptions = append(buildOptions, build.WithGoVersionSBOM())
+		return build.WithGoVersionSBOM(), nil
 	case "none":
-		buildOptions = append(buildOptions, build.WithDisabledSBOM())
+		return build.WithDisabledSBOM(), nil
 	default:
-		return nil, fmt.Errorf("unknown sbom type: %q", o.sbom)
+		return nil, fmt.Errorf("unknown sbom type: %q", sbom)
 	}
+}
 
-	b, err := build.NewGo(ctx, o.workingDir, buildOptions...)
+// fetchBaseImage gets the given base image, which might be either an image or
+// an index, caching it for subsequent builds.
+func fetchBaseImage(ref name.Reference) (build.Result, error) {
+	if cached, found := baseImages.Load(ref.String()); found {
+		return cached.(build.Result), nil
+	}
+
+	desc, err := remote.Get(
+		ref,
+		remote.WithAuthFromKeychain(keychain),
+	)
 	if err != nil {
-		return nil, fmt.Errorf("newGo: %w", err)
+		return nil, err
 	}
-	return build.NewCaching(b)
+	if desc.MediaType.IsImage() {
+		img, err := desc.Image()
+		baseImages.Store(ref.String(), img)
+		return img, err
+	}
+	if desc.MediaType.IsIndex() {
+		idx, err := desc.ImageIndex()
+		baseImages.Store(ref.String(), idx)
+		return idx, err
+	}
+	return nil, fmt.Errorf("unexpected base image media type: %s", desc.MediaType)
 }
 
-func doBuild(ctx *context.Context, ko config.Ko) func() error {
+// platformBaseIndex builds an index containing, for each platform, the image
+// of that platform's base image, so ko uses a different base per platform.
+func (o *buildOptions) platformBaseIndex() (v1.ImageIndex, error) {
+	return baseIndex(o.platforms, func(platform string) (build.Result, error) {
+		base := o.baseImage
+		if img, ok := o.platformBaseImages[platform]; ok {
+			base = img
+		}
+		ref, err := name.ParseReference(base)
+		if err != nil {
+			return nil, err
+		}
+		return fetchBaseImage(ref)
+	})
+}
+
+func baseIndex(platforms []string, baseFor func(platform string) (build.Result, error)) (v1.ImageIndex, error) {
+	idx := mutate.IndexMediaType(empty.Index, types.OCIImageIndex)
+	for _, platform := range platforms {
+		p, err := v1.ParsePlatform(platform)
+		if err != nil {
+			return nil, err
+		}
+		base, err := baseFor(platform)
+		if err != nil {
+			return nil, err
+		}
+		img, err := imageForPlatform(base, *p)
+		if err != nil {
+			return nil, fmt.Errorf("base image for %s: %w", platform, err)
+		}
+		mt, err := img.MediaType()
+		if err != nil {
+			return nil, err
+		}
+		idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+			Add: img,
+			Descriptor: v1.Descriptor{
+				MediaType: mt,
+				Platform:  p,
+			},
+		})
+	}
+	return idx, nil
+}
+
+func imageForPlatform(base build.Result, platform v1.Platform) (v1.Image, error) {
+	switch base := base.(type) {
+	case v1.Image:
+		return base, nil
+	case v1.ImageIndex:
+		manifest, err := base.IndexManifest()
+		if err != nil {
+			return nil, err
+		}
+		for _, desc := range manifest.Manifests {
+			if desc.Platform != nil && desc.Platform.Satisfies(platform) {
+				return base.Image(desc.Digest)
+			}
+		}
+		return nil, fmt.Errorf("no image for platform %s", platform.String())
+	default:
+		return nil, fmt.Errorf("unexpected base image type: %T", base)
+	}
+}
+
+func doBuild(ctx *context.Context, ko config.Ko, attacher *referrers.Attacher) func() error {
 	return func() error {
 		opts, err := buildBuildOptions(ctx, ko)
 		if err != nil {
@@ -277,11 +396,12 @@ func doBuild(ctx *context.Context, ko config.Ko) func() error {
 		if ko.ID != "" {
 			art.Extra[artifact.ExtraID] = ko.ID
 		}
-		if digest := ref.Context().Digest(ref.Identifier()).DigestStr(); digest != "" {
+		digest := ref.Context().Digest(ref.Identifier()).DigestStr()
+		if digest != "" {
 			art.Extra[artifact.ExtraDigest] = digest
 		}
 		ctx.Artifacts.Add(art)
-		return nil
+		return attacher.Attach(ctx, ko.AttachSBOMs, ref.Name(), digest)
 	}
 }
 
@@ -327,6 +447,7 @@ func buildBuildOptions(ctx *context.Context, cfg config.Ko) (*buildOptions, erro
 		preserveImportPaths: cfg.PreserveImportPaths,
 		baseImportPaths:     cfg.BaseImportPaths,
 		baseImage:           cfg.BaseImage,
+		platformBaseImages:  cfg.PlatformBaseImages,
 		platforms:           cfg.Platforms,
 		sbom:                cfg.SBOM,
 		imageRepo:           cfg.Repository,
@@ -427,6 +548,24 @@ func getTimeFromTemplate(ctx *context.Context, t string) (*v1.Time, error) {
 	return &v1.Time{Time: time.Unix(seconds, 0)}, nil
 }
 
+func validatePlatformBaseImages(ko config.Ko) error {
+	if len(ko.PlatformBaseImages) > 0 && slices.Contains(ko.Platforms, "all") {
+		return fmt.Errorf("ko: %s: platform_base_images can't be used with the 'all' platform", ko.ID)
+	}
+	for platform, base := range ko.PlatformBaseImages {
+		if base == "" {
+			return fmt.Errorf("ko: %s: platform_base_images: empty base image for %s", ko.ID, platform)
+		}
+		if _, err := v1.ParsePlatform(platform); err != nil {
+			return fmt.Errorf("ko: %s: platform_base_images: %w", ko.ID, err)
+		}
+		if !slices.Contains(ko.Platforms, platform) {
+			return fmt.Errorf("ko: %s: platform_base_images: %s is not in platforms", ko.ID, platform)
+		}
+	}
+	return nil
+}
+
 func validateMainPath(path string) error {
 	// if the path is empty, it's probably fine as ko will use the default value
 	if path == "" {
@@ -1,7 +1,9 @@
 package ko
 
 import (
+	stdctx "context"
 	"fmt"
+	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
@@ -10,7 +12,14 @@ import (
 	_ "github.com/distribution/distribution/v3/registry/auth/htpasswd"
 	_ "github.com/distribution/distribution/v3/registry/storage/driver/inmemory"
 	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/ko/pkg/build"
+	"github.com/google/ko/pkg/publish"
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
@@ -81,6 +90,183 @@ func TestDefaultNoImage(t *testing.T) {
 	require.ErrorIs(t, Pipe{}.Default(ctx), errNoRepository)
 }
 
+func TestDefaultPlatformBaseImages(t *testing.T) {
+	makeCtx := func(ko config.Ko) *context.Context {
+		ko.Repository = registry
+		return testctx.NewWithCfg(config.Project{
+			ProjectName: "test",
+			Builds: []config.Build{
+				{
+					ID: "test",
+				},
+			},
+			Kos: []config.Ko{ko},
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := makeCtx(config.Ko{
+			Platforms:          []string{"linux/amd64", "linux/arm64"},
+			PlatformBaseImages: map[string]string{"linux/arm64": "gcr.io/distroless/static:nonroot-arm64"},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, chainguardStatic, ctx.Config.Kos[0].BaseImage)
+	})
+
+	t.Run("platform not built", func(t *testing.T) {
+		ctx := makeCtx(config.Ko{
+			PlatformBaseImages: map[string]string{"linux/arm64": "gcr.io/distroless/static:nonroot-arm64"},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "ko: test: platform_base_images: linux/arm64 is not in platforms")
+	})
+
+	t.Run("empty base", func(t *testing.T) {
+		ctx := makeCtx(config.Ko{
+			PlatformBaseImages: map[string]string{"linux/amd64": ""},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "ko: test: platform_base_images: empty base image for linux/amd64")
+	})
+
+	t.Run("all platforms", func(t *testing.T) {
+		ctx := makeCtx(config.Ko{
+			Platforms:          []string{"all"},
+			PlatformBaseImages: map[string]string{"linux/arm64": "gcr.io/distroless/static:nonroot-arm64"},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "ko: test: platform_base_images can't be used with the 'all' platform")
+	})
+}
+
+func TestBaseIndex(t *testing.T) {
+	amd64, err := random.Image(1024, 1)
+	require.NoError(t, err)
+	arm64, err := random.Image(1024, 1)
+	require.NoError(t, err)
+	distroless, err := random.Image(1024, 1)
+	require.NoError(t, err)
+
+	defaultBase := mutate.AppendManifests(
+		empty.Index,
+		mutate.IndexAddendum{
+			Add:        amd64,
+			Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}},
+		},
+		mutate.IndexAddendum{
+			Add:        arm64,
+			Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}},
+		},
+	)
+
+	imageDigest := func(tb testing.TB, img v1.Image) v1.Hash {
+		tb.Helper()
+		h, err := img.Digest()
+		require.NoError(tb, err)
+		return h
+	}
+
+	t.Run("per platform", func(t *testing.T) {
+		idx, err := baseIndex([]string{"linux/amd64", "linux/arm64"}, func(platform string) (build.Result, error) {
+			if platform == "linux/arm64" {
+				return distroless, nil
+			}
+			return defaultBase, nil
+		})
+		require.NoError(t, err)
+
+		manifest, err := idx.IndexManifest()
+		require.NoError(t, err)
+		require.Len(t, manifest.Manifests, 2)
+		require.Equal(t, "linux/amd64", manifest.Manifests[0].Platform.String())
+		require.Equal(t, imageDigest(t, amd64), manifest.Manifests[0].Digest)
+		require.Equal(t, "linux/arm64", manifest.Manifests[1].Platform.String())
+		require.Equal(t, imageDigest(t, distroless), manifest.Manifests[1].Digest)
+	})
+
+	t.Run("platform missing from base", func(t *testing.T) {
+		_, err := baseIndex([]string{"linux/s390x"}, func(string) (build.Result, error) {
+			return defaultBase, nil
+		})
+		require.EqualError(t, err, "base image for linux/s390x: no image for platform linux/s390x")
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		_, err := baseIndex([]string{"linux/amd64"}, func(string) (build.Result, error) {
+			return nil, fmt.Errorf("fake")
+		})
+		require.EqualError(t, err, "fake")
+	})
+}
+
+func TestSBOMOption(t *testing.T) {
+	base, err := random.Image(1024, 1)
+	require.NoError(t, err)
+	base, err = mutate.ConfigFile(base, &v1.ConfigFile{OS: "linux", Architecture: "amd64"})
+	require.NoError(t, err)
+	getBase := func(stdctx.Context, string) (name.Reference, build.Result, error) {
+		return name.MustParseReference("example.com/base"), base, nil
+	}
+	srv := httptest.NewServer(ggcrregistry.New())
+	t.Cleanup(srv.Close)
+
+	for sbom, mediaType := range map[string]string{
+		"spdx":         "text/spdx+json",
+		"cyclonedx":    "application/vnd.cyclonedx+json",
+		"go.version-m": "application/vnd.go.version-m",
+		"none":         "",
+	} {
+		t.Run(sbom, func(t *testing.T) {
+			opt, err := sbomOption(sbom)
+			require.NoError(t, err)
+
+			b, err := build.NewGo(
+				stdctx.Background(),
+				"./testdata/app/",
+				build.WithBaseImages(getBase),
+				build.WithPlatforms("linux/amd64"),
+				opt,
+			)
+			require.NoError(t, err)
+			result, err := b.Build(stdctx.Background(), "testapp")
+			require.NoError(t, err)
+
+			// ko only writes the sbom attachment when publishing, next to
+			// the image, so push it to an in-memory registry.
+			repo := strings.TrimPrefix(srv.URL, "http://") + "/" + sbom
+			publisher, err := publish.NewDefault(repo, publish.Insecure(true))
+			require.NoError(t, err)
+			_, err = publisher.Publish(stdctx.Background(), result, "testapp")
+			require.NoError(t, err)
+
+			digest, err := result.Digest()
+			require.NoError(t, err)
+			sbomRef, err := name.ParseReference(
+				fmt.Sprintf("%s:%s.sbom", repo, strings.Replace(digest.String(), ":", "-", 1)),
+				name.Insecure,
+			)
+			require.NoError(t, err)
+			doc, err := remote.Image(sbomRef)
+			if mediaType == "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			layers, err := doc.Layers()
+			require.NoError(t, err)
+			require.Len(t, layers, 1)
+			mt, err := layers[0].MediaType()
+			require.NoError(t, err)
+			require.Equal(t, mediaType, string(mt))
+			size, err := layers[0].Size()
+			require.NoError(t, err)
+			require.NotZero(t, size)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := sbomOption("nope")
+		require.EqualError(t, err, `unknown sbom type: "nope"`)
+	})
+}
+
 func TestDescription(t *testing.T) {
 	require.NotEmpty(t, Pipe{}.String())
 }
@@ -104,6 +290,16 @@ func TestSkip(t *testing.T) {
 	})
 }
 
+func TestDependencies(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Kos: []config.Ko{
+			{},
+			{AttachSBOMs: config.SBOMAttach{IDs: []string{"foo"}, Tool: "cosign"}},
+		},
+	})
+	require.Equal(t, []string{"cosign"}, Pipe{}.Dependencies(ctx))
+}
+
 func TestPublishPipeNoMatchingBuild(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Builds: []config.Build{
@@ -236,6 +432,9 @@ func TestPublishPipeSuccess(t *testing.T) {
 			require.Equal(t, manifests[0].Name, manifests[0].Path)
 			require.NotEmpty(t, manifests[0].Extra[artifact.ExtraDigest])
 			require.Equal(t, "default", manifests[0].Extra[artifact.ExtraID])
+			// the SBOM is pushed alongside the image, never registered as an
+			// artifact.
+			require.Len(t, ctx.Artifacts.List(), 1)
 
 			tags, err := applyTemplate(ctx, table.Tags)
 			require.NoError(t, err)
@@ -37,8 +37,9 @@ var ErrNoArchivesFound = errors.New("no archives found")
 type Pipe struct{}
 
 func (Pipe) String() string                 { return "krew plugin manifest" }
+func (Pipe) Key() string                    { return "krew" }
 func (Pipe) ContinueOnError() bool          { return true }
-func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.Krews) == 0 }
+func (Pipe) Skip(ctx *context.Context) bool { return ctx.Nightly || len(ctx.Config.Krews) == 0 }
 
 func (Pipe) Default(ctx *context.Context) error {
 	for i := range ctx.Config.Krews {
@@ -358,7 +359,14 @@ func doPublish(ctx *context.Context, manifest *artifact.Artifact, cl client.Clie
 		return fmt.Errorf("client does not support pull requests")
 	}
 
-	return pcl.OpenPullRequest(ctx, base, repo, msg, cfg.Repository.PullRequest.Draft)
+	url, err := pcl.OpenPullRequest(ctx, base, repo, msg, cfg.Repository.PullRequest.Draft, cfg.Repository.PullRequest.AutoMerge)
+	if err != nil {
+		return err
+	}
+	if url != "" {
+		manifest.Extra[artifact.ExtraPullRequest] = url
+	}
+	return nil
 }
 
 func buildManifestPath(folder, filename string) string {
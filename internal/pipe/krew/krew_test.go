@@ -400,10 +400,12 @@ func TestRunPipePullRequest(t *testing.T) {
 	require.NoError(t, f.Close())
 
 	client := client.NewMock()
+	client.PullRequestURL = "https://github.com/foo/bar/pull/1"
 	require.NoError(t, runAll(ctx, client))
 	require.NoError(t, publishAll(ctx, client))
 	require.True(t, client.CreatedFile)
 	require.True(t, client.OpenedPullRequest)
+	require.Equal(t, "https://github.com/foo/bar/pull/1", artifact.ExtraOr(*ctx.Artifacts.Filter(artifact.ByType(artifact.KrewPluginManifest)).List()[0], artifact.ExtraPullRequest, ""))
 	require.True(t, client.SyncedFork)
 	golden.RequireEqualYaml(t, []byte(client.Content))
 }
@@ -2,6 +2,7 @@ package linkedin
 
 import (
 	"bytes"
+	stdctx "context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"golang.org/x/oauth2"
 )
@@ -43,9 +45,14 @@ func createLinkedInClient(cfg oauthClientConfig) (client, error) {
 		return client{}, fmt.Errorf("empty access token")
 	}
 
+	hc, err := httpclient.Client(cfg.Context)
+	if err != nil {
+		return client{}, err
+	}
+
 	config := oauth2.Config{}
 
-	c := config.Client(cfg.Context, &oauth2.Token{
+	c := config.Client(stdctx.WithValue(cfg.Context, oauth2.HTTPClient, hc), &oauth2.Token{
 		AccessToken: cfg.AccessToken,
 	})
 
@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -84,6 +87,32 @@ func TestClient_Share(t *testing.T) {
 	require.Equal(t, wantLink, link)
 }
 
+func TestClient_HTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "goreleaser/dev (+foo)", req.Header.Get("User-Agent"))
+		assert.Equal(t, "Bearer foo", req.Header.Get("Authorization"))
+		_, _ = io.WriteString(rw, `{"sub": "foo", "activity": "123456789"}`)
+	}))
+	defer server.Close()
+
+	c, err := createLinkedInClient(oauthClientConfig{
+		Context: testctx.NewWithCfg(config.Project{
+			ProjectName: "foo",
+			HTTP: config.HTTP{
+				CABundle: testlib.ServerCert(t, server),
+			},
+		}),
+		AccessToken: "foo",
+	})
+	require.NoError(t, err)
+
+	c.baseURL = server.URL
+
+	link, err := c.Share("test")
+	require.NoError(t, err)
+	require.Equal(t, "https://www.linkedin.com/feed/update/123456789", link)
+}
+
 func TestClientLegacyProfile_Share(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.Path == "/v2/userinfo" {
@@ -6,6 +6,7 @@ import (
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -13,23 +14,35 @@ const defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it o
 
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "linkedin" }
-func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Announce.LinkedIn.Enabled }
+func (Pipe) String() string                        { return "linkedin" }
+func (Pipe) Key() string                           { return "linkedin" }
+func (Pipe) Len(ctx *context.Context) int          { return len(ctx.Config.Announce.LinkedIn) }
+func (Pipe) Skip(ctx *context.Context, i int) bool { return !ctx.Config.Announce.LinkedIn[i].Enabled }
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.LinkedIn[i].AnnounceConditions
+}
 
 type Config struct {
 	AccessToken string `env:"LINKEDIN_ACCESS_TOKEN,notEmpty"`
 }
 
 func (Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.LinkedIn.MessageTemplate == "" {
-		ctx.Config.Announce.LinkedIn.MessageTemplate = defaultMessageTemplate
+	for i := range ctx.Config.Announce.LinkedIn {
+		conf := &ctx.Config.Announce.LinkedIn[i]
+		if conf.MessageTemplate == "" && conf.MessageTemplateFile == "" {
+			conf.MessageTemplate = defaultMessageTemplate
+		}
 	}
-
 	return nil
 }
 
-func (Pipe) Announce(ctx *context.Context) error {
-	message, err := tmpl.New(ctx).Apply(ctx.Config.Announce.LinkedIn.MessageTemplate)
+func (Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.LinkedIn[i]
+	message, err := tmpl.New(ctx).ApplyInlineOrFile(
+		conf.MessageTemplate,
+		conf.MessageTemplateFile,
+	)
 	if err != nil {
 		return fmt.Errorf("linkedin: %w", err)
 	}
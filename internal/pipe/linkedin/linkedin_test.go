@@ -14,54 +14,67 @@ func TestStringer(t *testing.T) {
 }
 
 func TestDefault(t *testing.T) {
-	ctx := testctx.New()
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			LinkedIn: []config.LinkedIn{{}},
+		},
+	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.LinkedIn.MessageTemplate)
+	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.LinkedIn[0].MessageTemplate)
 }
 
 func TestAnnounceDisabled(t *testing.T) {
-	ctx := testctx.New()
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			LinkedIn: []config.LinkedIn{{}},
+		},
+	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.EqualError(t, Pipe{}.Announce(ctx), `linkedin: env: environment variable "LINKEDIN_ACCESS_TOKEN" should not be empty`)
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `linkedin: env: environment variable "LINKEDIN_ACCESS_TOKEN" should not be empty`)
 }
 
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			LinkedIn: config.LinkedIn{
+			LinkedIn: []config.LinkedIn{{
 				Enabled:         true,
 				MessageTemplate: "{{ .Foo }",
-			},
+			}},
 		},
 	})
-	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
 }
 
 func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			LinkedIn: config.LinkedIn{
+			LinkedIn: []config.LinkedIn{{
 				Enabled: true,
-			},
+			}},
 		},
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.EqualError(t, Pipe{}.Announce(ctx), `linkedin: env: environment variable "LINKEDIN_ACCESS_TOKEN" should not be empty`)
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `linkedin: env: environment variable "LINKEDIN_ACCESS_TOKEN" should not be empty`)
 }
 
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
-		require.True(t, Pipe{}.Skip(testctx.New()))
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				LinkedIn: []config.LinkedIn{{}},
+			},
+		})
+		require.True(t, Pipe{}.Skip(ctx, 0))
 	})
 
 	t.Run("dont skip", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				LinkedIn: config.LinkedIn{
+				LinkedIn: []config.LinkedIn{{
 					Enabled: true,
-				},
+				}},
 			},
 		})
-		require.False(t, Pipe{}.Skip(ctx))
+		require.False(t, Pipe{}.Skip(ctx, 0))
 	})
 }
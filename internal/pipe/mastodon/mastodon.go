@@ -5,7 +5,9 @@ import (
 
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/mattn/go-mastodon"
 )
@@ -15,9 +17,16 @@ const defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it o
 type Pipe struct{}
 
 func (Pipe) String() string { return "mastodon" }
+func (Pipe) Key() string    { return "mastodon" }
 
-func (Pipe) Skip(ctx *context.Context) bool {
-	return !ctx.Config.Announce.Mastodon.Enabled || ctx.Config.Announce.Mastodon.Server == ""
+func (Pipe) Len(ctx *context.Context) int { return len(ctx.Config.Announce.Mastodon) }
+
+func (Pipe) Skip(ctx *context.Context, i int) bool {
+	return !ctx.Config.Announce.Mastodon[i].Enabled || ctx.Config.Announce.Mastodon[i].Server == ""
+}
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.Mastodon[i].AnnounceConditions
 }
 
 type Config struct {
@@ -27,14 +36,21 @@ type Config struct {
 }
 
 func (Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.Mastodon.MessageTemplate == "" {
-		ctx.Config.Announce.Mastodon.MessageTemplate = defaultMessageTemplate
+	for i := range ctx.Config.Announce.Mastodon {
+		conf := &ctx.Config.Announce.Mastodon[i]
+		if conf.MessageTemplate == "" && conf.MessageTemplateFile == "" {
+			conf.MessageTemplate = defaultMessageTemplate
+		}
 	}
 	return nil
 }
 
-func (Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Mastodon.MessageTemplate)
+func (Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.Mastodon[i]
+	msg, err := tmpl.New(ctx).ApplyInlineOrFile(
+		conf.MessageTemplate,
+		conf.MessageTemplateFile,
+	)
 	if err != nil {
 		return fmt.Errorf("mastodon: %w", err)
 	}
@@ -44,12 +60,15 @@ func (Pipe) Announce(ctx *context.Context) error {
 		return fmt.Errorf("mastodon: %w", err)
 	}
 
-	client := mastodon.NewClient(&mastodon.Config{
-		Server:       ctx.Config.Announce.Mastodon.Server,
+	client, err := newClient(ctx, &mastodon.Config{
+		Server:       conf.Server,
 		ClientID:     cfg.ClientID,
 		ClientSecret: cfg.ClientSecret,
 		AccessToken:  cfg.AccessToken,
 	})
+	if err != nil {
+		return fmt.Errorf("mastodon: %w", err)
+	}
 
 	log.Infof("posting: '%s'", msg)
 	if _, err := client.PostStatus(ctx, &mastodon.Toot{
@@ -59,3 +78,14 @@ func (Pipe) Announce(ctx *context.Context) error {
 	}
 	return nil
 }
+
+// newClient returns a mastodon client using the shared HTTP client.
+func newClient(ctx *context.Context, cfg *mastodon.Config) (*mastodon.Client, error) {
+	hc, err := httpclient.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := mastodon.NewClient(cfg)
+	client.Client = *hc
+	return client, nil
+}
@@ -1,11 +1,15 @@
 package mastodon
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/mattn/go-mastodon"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,56 +18,90 @@ func TestStringer(t *testing.T) {
 }
 
 func TestDefault(t *testing.T) {
-	ctx := testctx.New()
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			Mastodon: []config.Mastodon{{}},
+		},
+	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Mastodon.MessageTemplate)
+	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Mastodon[0].MessageTemplate)
 }
 
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Mastodon: config.Mastodon{
+			Mastodon: []config.Mastodon{{
 				MessageTemplate: "{{ .Foo }",
-			},
+			}},
 		},
 	})
-	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
 }
 
 func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Mastodon: config.Mastodon{},
+			Mastodon: []config.Mastodon{{}},
 		},
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.EqualError(t, Pipe{}.Announce(ctx), `mastodon: env: environment variable "MASTODON_CLIENT_ID" should not be empty; environment variable "MASTODON_CLIENT_SECRET" should not be empty; environment variable "MASTODON_ACCESS_TOKEN" should not be empty`)
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `mastodon: env: environment variable "MASTODON_CLIENT_ID" should not be empty; environment variable "MASTODON_CLIENT_SECRET" should not be empty; environment variable "MASTODON_ACCESS_TOKEN" should not be empty`)
+}
+
+func TestNewClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "goreleaser/dev (+foo)", r.Header.Get("User-Agent"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "/api/v1/statuses", r.URL.Path)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		HTTP: config.HTTP{
+			CABundle: testlib.ServerCert(t, srv),
+		},
+	})
+	client, err := newClient(ctx, &mastodon.Config{
+		Server:      srv.URL,
+		AccessToken: "token",
+	})
+	require.NoError(t, err)
+	status, err := client.PostStatus(ctx, &mastodon.Toot{Status: "foo"})
+	require.NoError(t, err)
+	require.Equal(t, mastodon.ID("1"), status.ID)
 }
 
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
-		require.True(t, Pipe{}.Skip(testctx.New()))
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Mastodon: []config.Mastodon{{}},
+			},
+		})
+		require.True(t, Pipe{}.Skip(ctx, 0))
 	})
 
 	t.Run("skip empty server", func(t *testing.T) {
 		require.True(t, Pipe{}.Skip(testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Mastodon: config.Mastodon{
+				Mastodon: []config.Mastodon{{
 					Enabled: true,
 					Server:  "", // empty
-				},
+				}},
 			},
-		})))
+		}), 0))
 	})
 
 	t.Run("dont skip", func(t *testing.T) {
 		require.False(t, Pipe{}.Skip(testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Mastodon: config.Mastodon{
+				Mastodon: []config.Mastodon{{
 					Enabled: true,
 					Server:  "https://mastodon.social",
-				},
+				}},
 			},
-		})))
+		}), 0))
 	})
 }
@@ -10,7 +10,9 @@ import (
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
 
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -23,38 +25,50 @@ const (
 
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "mattermost" }
-func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Announce.Mattermost.Enabled }
+func (Pipe) String() string                        { return "mattermost" }
+func (Pipe) Key() string                           { return "mattermost" }
+func (Pipe) Len(ctx *context.Context) int          { return len(ctx.Config.Announce.Mattermost) }
+func (Pipe) Skip(ctx *context.Context, i int) bool { return !ctx.Config.Announce.Mattermost[i].Enabled }
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.Mattermost[i].AnnounceConditions
+}
 
 type Config struct {
 	Webhook string `env:"MATTERMOST_WEBHOOK,notEmpty"`
 }
 
 func (Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.Mattermost.MessageTemplate == "" {
-		ctx.Config.Announce.Mattermost.MessageTemplate = defaultMessageTemplate
-	}
-
-	if ctx.Config.Announce.Mattermost.TitleTemplate == "" {
-		ctx.Config.Announce.Mattermost.TitleTemplate = defaultMessageTitle
-	}
-	if ctx.Config.Announce.Mattermost.Username == "" {
-		ctx.Config.Announce.Mattermost.Username = defaultUsername
+	for i := range ctx.Config.Announce.Mattermost {
+		conf := &ctx.Config.Announce.Mattermost[i]
+		if conf.MessageTemplate == "" && conf.MessageTemplateFile == "" {
+			conf.MessageTemplate = defaultMessageTemplate
+		}
+
+		if conf.TitleTemplate == "" {
+			conf.TitleTemplate = defaultMessageTitle
+		}
+		if conf.Username == "" {
+			conf.Username = defaultUsername
+		}
+		if conf.Color == "" {
+			conf.Color = defaultColor
+		}
 	}
-	if ctx.Config.Announce.Teams.Color == "" {
-		ctx.Config.Announce.Teams.Color = defaultColor
-	}
-
 	return nil
 }
 
-func (Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Mattermost.MessageTemplate)
+func (Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.Mattermost[i]
+	msg, err := tmpl.New(ctx).ApplyInlineOrFile(
+		conf.MessageTemplate,
+		conf.MessageTemplateFile,
+	)
 	if err != nil {
 		return fmt.Errorf("mattermost: %w", err)
 	}
 
-	title, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Mattermost.TitleTemplate)
+	title, err := tmpl.New(ctx).Apply(conf.TitleTemplate)
 	if err != nil {
 		return fmt.Errorf("teams: %w", err)
 	}
@@ -67,15 +81,15 @@ func (Pipe) Announce(ctx *context.Context) error {
 	log.Infof("posting: %q", msg)
 
 	wm := &incomingWebhookRequest{
-		Username:    ctx.Config.Announce.Mattermost.Username,
-		IconEmoji:   ctx.Config.Announce.Mattermost.IconEmoji,
-		IconURL:     ctx.Config.Announce.Mattermost.IconURL,
-		ChannelName: ctx.Config.Announce.Mattermost.Channel,
+		Username:    conf.Username,
+		IconEmoji:   conf.IconEmoji,
+		IconURL:     conf.IconURL,
+		ChannelName: conf.Channel,
 		Attachments: []*mattermostAttachment{
 			{
 				Title: title,
 				Text:  msg,
-				Color: ctx.Config.Announce.Teams.Color,
+				Color: conf.Color,
 			},
 		},
 	}
@@ -100,7 +114,11 @@ func postWebhook(ctx *context.Context, url string, msg *incomingWebhookRequest)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	r, err := http.DefaultClient.Do(req)
+	client, err := httpclient.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("mattermost: %w", err)
+	}
+	r, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("mattermost: %w", err)
 	}
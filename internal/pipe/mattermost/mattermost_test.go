@@ -19,46 +19,55 @@ func TestStringer(t *testing.T) {
 }
 
 func TestDefault(t *testing.T) {
-	ctx := testctx.New()
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			Mattermost: []config.Mattermost{{}},
+		},
+	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Mattermost.MessageTemplate)
+	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Mattermost[0].MessageTemplate)
 }
 
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Mattermost: config.Mattermost{
+			Mattermost: []config.Mattermost{{
 				MessageTemplate: "{{ .Foo }",
-			},
+			}},
 		},
 	})
-	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
 }
 
 func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Mattermost: config.Mattermost{},
+			Mattermost: []config.Mattermost{{}},
 		},
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.EqualError(t, Pipe{}.Announce(ctx), `mattermost: env: environment variable "MATTERMOST_WEBHOOK" should not be empty`)
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `mattermost: env: environment variable "MATTERMOST_WEBHOOK" should not be empty`)
 }
 
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
-		require.True(t, Pipe{}.Skip(testctx.New()))
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Mattermost: []config.Mattermost{{}},
+			},
+		})
+		require.True(t, Pipe{}.Skip(ctx, 0))
 	})
 
 	t.Run("dont skip", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Mattermost: config.Mattermost{
+				Mattermost: []config.Mattermost{{
 					Enabled: true,
-				},
+				}},
 			},
 		})
-		require.False(t, Pipe{}.Skip(ctx))
+		require.False(t, Pipe{}.Skip(ctx, 0))
 	})
 }
 
@@ -82,9 +91,9 @@ func TestPostWebhook(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		ProjectName: "Honk",
 		Announce: config.Announce{
-			Mattermost: config.Mattermost{
+			Mattermost: []config.Mattermost{{
 				Enabled: true,
-			},
+			}},
 		},
 	})
 
@@ -95,5 +104,5 @@ func TestPostWebhook(t *testing.T) {
 	t.Setenv("MATTERMOST_WEBHOOK", ts.URL)
 
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.NoError(t, Pipe{}.Announce(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx, 0))
 }
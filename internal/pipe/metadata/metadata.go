@@ -59,7 +59,9 @@ func writeMetadata(ctx *context.Context) error {
 func writeArtifacts(ctx *context.Context) error {
 	_ = ctx.Artifacts.Visit(func(a *artifact.Artifact) error {
 		a.TypeS = a.Type.String()
-		a.Path = filepath.ToSlash(filepath.Clean(a.Path))
+		if a.Path != "" {
+			a.Path = filepath.ToSlash(filepath.Clean(a.Path))
+		}
 		return nil
 	})
 	_, err := writeJSON(ctx, ctx.Artifacts.List(), "artifacts.json")
@@ -16,8 +16,9 @@ const defaultNameTemplate = "{{ .Tag }}"
 type Pipe struct{}
 
 func (Pipe) String() string                 { return "milestones" }
+func (Pipe) Key() string                    { return "milestone" }
 func (Pipe) ContinueOnError() bool          { return true }
-func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.Milestones) == 0 }
+func (Pipe) Skip(ctx *context.Context) bool { return ctx.Nightly || len(ctx.Config.Milestones) == 0 }
 
 // Default sets the pipe defaults.
 func (Pipe) Default(ctx *context.Context) error {
@@ -0,0 +1,344 @@
+// Package msi implements the Pipe interface providing Windows installers
+// (MSI) bindings, using msitools or the WiX toolset.
+package msi
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/google/uuid"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultNameTemplate = `{{ .ProjectName }}_{{ .MsiArch }}`
+	defaultVersion      = `{{ .Major }}.{{ .Minor }}.{{ .Patch }}`
+)
+
+var errNoUpgradeCode = errors.New("msi: upgrade_code is required when no wxs is provided")
+
+// cmd runs wixl, or candle and light.
+var cmd shell.Runner = shell.StdRunner{}
+
+// goos is the OS goreleaser is running on, which defines whether the WiX
+// toolset or msitools is used.
+var goos = runtime.GOOS
+
+// archs maps the supported GOARCHs to their MSI architectures.
+var archs = map[string]string{
+	"amd64": "x64",
+	"386":   "x86",
+}
+
+// Pipe for MSI installers.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "windows installers" }
+func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.MSIs) == 0 }
+
+// Dependencies implements healthcheck.Healthchecker.
+func (Pipe) Dependencies(_ *context.Context) []string {
+	if goos == "windows" {
+		return []string{"candle", "light"}
+	}
+	return []string{"wixl"}
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("msi")
+	for i := range ctx.Config.MSIs {
+		msi := &ctx.Config.MSIs[i]
+		if msi.ID == "" {
+			msi.ID = ctx.Config.ProjectName
+		}
+		if msi.Name == "" {
+			msi.Name = defaultNameTemplate
+		}
+		if msi.Goamd64 == "" {
+			msi.Goamd64 = "v1"
+		}
+		if msi.ProductName == "" {
+			msi.ProductName = ctx.Config.ProjectName
+		}
+		if msi.Manufacturer == "" {
+			msi.Manufacturer = msi.ProductName
+		}
+		if msi.InstallDir == "" {
+			msi.InstallDir = ctx.Config.ProjectName
+		}
+		if msi.Version == "" {
+			msi.Version = defaultVersion
+		}
+		version, err := tmpl.New(ctx).Apply(msi.Version)
+		if err != nil {
+			return err
+		}
+		if err := validateVersion(version); err != nil {
+			return err
+		}
+		ids.Inc(msi.ID)
+	}
+	return ids.Validate()
+}
+
+// validateVersion checks that the given version is a valid MSI
+// ProductVersion, i.e., major.minor.build, with major and minor up to 255 and
+// build up to 65535.
+func validateVersion(version string) error {
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("msi: invalid version %q: must be in the major.minor.build format", version)
+	}
+	for i, max := range []uint64{255, 255, 65535} {
+		n, err := strconv.ParseUint(parts[i], 10, 64)
+		if err != nil || n > max {
+			return fmt.Errorf("msi: invalid version %q: %q must be a number up to %d", version, parts[i], max)
+		}
+	}
+	return nil
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, msi := range ctx.Config.MSIs {
+		if err := doRun(ctx, msi); err != nil {
+			if pipe.IsSkip(err) {
+				skips.Remember(err)
+				continue
+			}
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doRun(ctx *context.Context, msi config.MSI) error {
+	tpl := tmpl.New(ctx)
+	if err := tpl.ApplyAll(
+		&msi.Disable,
+		&msi.WXS,
+		&msi.ProductName,
+		&msi.Manufacturer,
+		&msi.UpgradeCode,
+		&msi.InstallDir,
+		&msi.Version,
+	); err != nil {
+		return err
+	}
+	if msi.Disable == "true" {
+		return pipe.Skip("configuration is disabled")
+	}
+	if msi.WXS == "" && msi.UpgradeCode == "" {
+		return errNoUpgradeCode
+	}
+	if msi.UpgradeCode != "" {
+		if _, err := uuid.Parse(msi.UpgradeCode); err != nil {
+			return fmt.Errorf("msi: invalid upgrade_code: %q", msi.UpgradeCode)
+		}
+	}
+
+	filters := []artifact.Filter{
+		artifact.ByGoos("windows"),
+		artifact.Or(
+			artifact.ByType(artifact.UploadableArchive),
+			artifact.ByType(artifact.UploadableBinary),
+		),
+		artifact.Or(
+			artifact.And(
+				artifact.ByGoarch("amd64"),
+				artifact.ByGoamd64(msi.Goamd64),
+			),
+			artifact.ByGoarch("386"),
+		),
+	}
+	if len(msi.IDs) > 0 {
+		filters = append(filters, artifact.ByIDs(msi.IDs...))
+	}
+
+	filter := artifact.And(filters...)
+	platforms := ctx.Artifacts.Filter(filter).GroupByPlatform()
+	for platform, archives := range platforms {
+		if len(archives) > 1 {
+			return fmt.Errorf("msi: found %d archives for %s, use ids to pick one", len(archives), platform)
+		}
+	}
+
+	g := semerrgroup.NewShared(ctx)
+	for _, archives := range platforms {
+		binary, err := binaryOf(ctx, archives[0])
+		if err != nil {
+			return err
+		}
+		g.Go(func() error {
+			return create(ctx, msi, binary)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if msi.Replace {
+		return ctx.Artifacts.Remove(filter)
+	}
+	return nil
+}
+
+// binaryOf returns the binary in the given archive, which must have only one.
+func binaryOf(ctx *context.Context, archive *artifact.Artifact) (*artifact.Artifact, error) {
+	if archive.Type == artifact.UploadableBinary {
+		return archive, nil
+	}
+	bins := artifact.ExtraOr(*archive, artifact.ExtraBinaries, []string{})
+	if len(bins) != 1 {
+		return nil, fmt.Errorf("msi: archive %s must have a single binary, found %d", archive.Name, len(bins))
+	}
+	filters := []artifact.Filter{
+		artifact.ByType(artifact.Binary),
+		artifact.ByGoos(archive.Goos),
+		artifact.ByGoarch(archive.Goarch),
+		artifact.ByGoamd64(archive.Goamd64),
+		func(a *artifact.Artifact) bool { return a.Name == bins[0] },
+	}
+	for _, cfg := range ctx.Config.Archives {
+		if cfg.ID == archive.ID() && len(cfg.Builds) > 0 {
+			filters = append(filters, artifact.ByIDs(cfg.Builds...))
+		}
+	}
+	binaries := ctx.Artifacts.Filter(artifact.And(filters...)).List()
+	if len(binaries) == 0 {
+		return nil, fmt.Errorf("msi: binary %s of archive %s not found", bins[0], archive.Name)
+	}
+	return binaries[0], nil
+}
+
+func create(ctx *context.Context, msi config.MSI, binary *artifact.Artifact) error {
+	arch := archs[binary.Goarch]
+	tpl := tmpl.New(ctx).WithArtifact(binary).WithExtraFields(tmpl.Fields{
+		"MsiArch":         arch,
+		"MsiVersion":      escape(msi.Version),
+		"MsiProductName":  escape(msi.ProductName),
+		"MsiManufacturer": escape(msi.Manufacturer),
+		"MsiUpgradeCode":  escape(msi.UpgradeCode),
+		"MsiInstallDir":   escape(msi.InstallDir),
+	})
+	name, err := tpl.Apply(msi.Name)
+	if err != nil {
+		return err
+	}
+
+	wxs := wxsTemplate
+	if msi.WXS != "" {
+		bts, err := os.ReadFile(msi.WXS)
+		if err != nil {
+			return fmt.Errorf("msi: %w", err)
+		}
+		wxs = string(bts)
+	}
+	wxs, err = tpl.Apply(wxs)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(ctx.Config.Dist, "msi", name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := gio.Copy(binary.Path, filepath.Join(dir, filepath.Base(binary.Path))); err != nil {
+		return err
+	}
+	for _, extra := range msi.ExtraFiles {
+		if err := gio.Copy(extra, filepath.Join(dir, filepath.Base(extra))); err != nil {
+			return err
+		}
+	}
+	wxsName := name + ".wxs"
+	if err := os.WriteFile(filepath.Join(dir, wxsName), []byte(wxs), 0o644); err != nil {
+		return err
+	}
+
+	path, err := filepath.Abs(filepath.Join(ctx.Config.Dist, name+".msi"))
+	if err != nil {
+		return err
+	}
+	log.WithField("arch", arch).WithField("msi", path).Info("creating")
+	if err := build(ctx, dir, arch, wxsName, path); err != nil {
+		return err
+	}
+
+	modTimestamp, err := tmpl.New(ctx).Apply(msi.ModTimestamp)
+	if err != nil {
+		return err
+	}
+	if err := gio.Chtimes(path, modTimestamp); err != nil {
+		return err
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.Installer,
+		Name:    name + ".msi",
+		Path:    path,
+		Goos:    binary.Goos,
+		Goarch:  binary.Goarch,
+		Goamd64: binary.Goamd64,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:     msi.ID,
+			artifact.ExtraFormat: "msi",
+			artifact.ExtraExt:    ".msi",
+		},
+	})
+	return nil
+}
+
+// build runs the WiX toolset on windows, and msitools' wixl elsewhere.
+func build(ctx *context.Context, dir, arch, wxs, out string) error {
+	if goos != "windows" {
+		if output, err := cmd.Exec(ctx, shell.Cmd{
+			Name: "wixl",
+			Args: []string{"-a", arch, "-o", out, wxs},
+			Dir:  dir,
+		}); err != nil {
+			return fmt.Errorf("failed to create msi: %w: %s", err, string(output))
+		}
+		return nil
+	}
+
+	obj := strings.TrimSuffix(wxs, ".wxs") + ".wixobj"
+	if output, err := cmd.Exec(ctx, shell.Cmd{
+		Name: "candle",
+		Args: []string{"-nologo", "-arch", arch, "-out", obj, wxs},
+		Dir:  dir,
+	}); err != nil {
+		return fmt.Errorf("failed to compile wxs: %w: %s", err, string(output))
+	}
+	if output, err := cmd.Exec(ctx, shell.Cmd{
+		Name: "light",
+		Args: []string{"-nologo", "-out", out, obj},
+		Dir:  dir,
+	}); err != nil {
+		return fmt.Errorf("failed to create msi: %w: %s", err, string(output))
+	}
+	return nil
+}
+
+// escape escapes s so it can be used in an XML attribute.
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
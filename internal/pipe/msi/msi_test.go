@@ -0,0 +1,412 @@
+package msi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+const upgradeCode = "ABCDDCBA-7349-453F-94F6-BCB5110BA8FD"
+
+func TestDependencies(t *testing.T) {
+	t.Run("msitools", func(t *testing.T) {
+		setGoos(t, "linux")
+		require.Equal(t, []string{"wixl"}, Pipe{}.Dependencies(nil))
+	})
+
+	t.Run("wix", func(t *testing.T) {
+		setGoos(t, "windows")
+		require.Equal(t, []string{"candle", "light"}, Pipe{}.Dependencies(nil))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		MSIs:        []config.MSI{{}},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, config.MSI{
+		ID:           "foo",
+		Name:         defaultNameTemplate,
+		Goamd64:      "v1",
+		ProductName:  "foo",
+		Manufacturer: "foo",
+		InstallDir:   "foo",
+		Version:      defaultVersion,
+	}, ctx.Config.MSIs[0])
+}
+
+func TestDefaultInvalidVersion(t *testing.T) {
+	for version, expected := range map[string]string{
+		"1.2":            `msi: invalid version "1.2": must be in the major.minor.build format`,
+		"1.2.3-beta":     `msi: invalid version "1.2.3-beta": "3-beta" must be a number up to 65535`,
+		"256.0.0":        `msi: invalid version "256.0.0": "256" must be a number up to 255`,
+		"1.2.65536":      `msi: invalid version "1.2.65536": "65536" must be a number up to 65535`,
+		"{{ .Version }}": `msi: invalid version "1.2.3.4": must be in the major.minor.build format`,
+	} {
+		t.Run(version, func(t *testing.T) {
+			ctx := testctx.NewWithCfg(config.Project{
+				MSIs: []config.MSI{{Version: version}},
+			}, testctx.WithVersion("1.2.3.4"))
+			require.EqualError(t, Pipe{}.Default(ctx), expected)
+		})
+	}
+
+	t.Run("bad template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			MSIs: []config.MSI{{Version: "{{ .Nope }"}},
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Default(ctx))
+	})
+}
+
+func TestRun(t *testing.T) {
+	for goos, expected := range map[string][]string{
+		"linux": {
+			"wixl -a x64 -o {{ .Dist }}/foo_x64.msi foo_x64.wxs",
+			"wixl -a x86 -o {{ .Dist }}/foo_x86.msi foo_x86.wxs",
+		},
+		"windows": {
+			"candle -nologo -arch x64 -out foo_x64.wixobj foo_x64.wxs",
+			"light -nologo -out {{ .Dist }}/foo_x64.msi foo_x64.wixobj",
+			"candle -nologo -arch x86 -out foo_x86.wixobj foo_x86.wxs",
+			"light -nologo -out {{ .Dist }}/foo_x86.msi foo_x86.wixobj",
+		},
+	} {
+		t.Run(goos, func(t *testing.T) {
+			setGoos(t, goos)
+			calls := fakeCommands(t, nil)
+
+			folder := t.TempDir()
+			ctx := testctx.NewWithCfg(config.Project{
+				ProjectName: "foo",
+				Dist:        folder,
+				MSIs: []config.MSI{
+					{
+						UpgradeCode:  upgradeCode,
+						ProductName:  "Foo & Bar",
+						ModTimestamp: "{{ .CommitTimestamp }}",
+					},
+				},
+			}, testctx.WithVersion("1.2.3"), testctx.WithSemver(1, 2, 3, ""))
+			addArtifacts(t, ctx, folder)
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			msis := ctx.Artifacts.Filter(artifact.ByType(artifact.Installer)).List()
+			require.Len(t, msis, 2)
+			require.ElementsMatch(t, []string{"foo_x64.msi", "foo_x86.msi"}, []string{msis[0].Name, msis[1].Name})
+			for _, msi := range msis {
+				require.Equal(t, "foo", artifact.ExtraOr(*msi, artifact.ExtraID, ""))
+				require.Equal(t, "msi", artifact.ExtraOr(*msi, artifact.ExtraFormat, ""))
+			}
+
+			dir := filepath.Join(folder, "msi", "foo_x64")
+			require.FileExists(t, filepath.Join(dir, "foo.exe"))
+			wxs, err := os.ReadFile(filepath.Join(dir, "foo_x64.wxs"))
+			require.NoError(t, err)
+			golden.RequireEqualExt(t, wxs, ".wxs")
+
+			var got []string
+			for _, c := range calls.Cmds() {
+				require.Contains(t, []string{dir, filepath.Join(folder, "msi", "foo_x86")}, c.Dir)
+				got = append(got, strings.ReplaceAll(c.String(), folder, "{{ .Dist }}"))
+			}
+			require.ElementsMatch(t, expected, got)
+		})
+	}
+}
+
+func TestRunCustomWXS(t *testing.T) {
+	setGoos(t, "linux")
+	fakeCommands(t, nil)
+
+	folder := t.TempDir()
+	wxs := filepath.Join(folder, "app.wxs")
+	require.NoError(t, os.WriteFile(wxs, []byte("{{ .ProjectName }} {{ .MsiArch }} {{ .MsiVersion }} {{ .Binary }}"), 0o644))
+	extra := filepath.Join(folder, "logo.ico")
+	require.NoError(t, os.WriteFile(extra, []byte("fake ico"), 0o644))
+
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Dist:        folder,
+		MSIs: []config.MSI{
+			{
+				WXS:        wxs,
+				IDs:        []string{"foo"},
+				ExtraFiles: []string{extra},
+				Version:    "{{ .Major }}.{{ .Minor }}.42",
+			},
+		},
+	}, testctx.WithSemver(1, 2, 3, ""))
+	addArtifacts(t, ctx, folder)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	dir := filepath.Join(folder, "msi", "foo_x64")
+	require.FileExists(t, filepath.Join(dir, "logo.ico"))
+	bts, err := os.ReadFile(filepath.Join(dir, "foo_x64.wxs"))
+	require.NoError(t, err)
+	require.Equal(t, "foo x64 1.2.42 foo", string(bts))
+}
+
+func TestRunArchiveIDs(t *testing.T) {
+	setGoos(t, "linux")
+	fakeCommands(t, nil)
+
+	folder := t.TempDir()
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Dist:        folder,
+		Archives: []config.Archive{
+			{ID: "foo", Builds: []string{"default"}},
+		},
+		MSIs: []config.MSI{
+			{UpgradeCode: upgradeCode, IDs: []string{"nope"}},
+			{ID: "bar", UpgradeCode: upgradeCode, IDs: []string{"foo"}},
+		},
+	}, testctx.WithSemver(1, 2, 3, ""))
+	addArtifacts(t, ctx, folder)
+	// a binary with the same name, but from another build.
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:    "foo.exe",
+		Path:    filepath.Join(folder, "other", "foo.exe"),
+		Goos:    "windows",
+		Goarch:  "amd64",
+		Goamd64: "v1",
+		Type:    artifact.Binary,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "other",
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	msis := ctx.Artifacts.Filter(artifact.ByType(artifact.Installer)).List()
+	require.Len(t, msis, 2)
+	for _, msi := range msis {
+		require.Equal(t, "bar", artifact.ExtraOr(*msi, artifact.ExtraID, ""))
+	}
+	bts, err := os.ReadFile(filepath.Join(folder, "msi", "foo_x64", "foo.exe"))
+	require.NoError(t, err)
+	require.Equal(t, "fake", string(bts))
+}
+
+func TestRunReplace(t *testing.T) {
+	setGoos(t, "linux")
+	fakeCommands(t, nil)
+
+	folder := t.TempDir()
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Dist:        folder,
+		MSIs: []config.MSI{
+			{UpgradeCode: upgradeCode, Replace: true},
+		},
+	}, testctx.WithSemver(1, 2, 3, ""))
+	addArtifacts(t, ctx, folder)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	require.Len(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Installer)).List(), 2)
+	archives := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableArchive)).List()
+	require.Len(t, archives, 2)
+	for _, archive := range archives {
+		require.Contains(t, []string{"v3", ""}, archive.Goamd64)
+		require.NotEqual(t, "386", archive.Goarch)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	setGoos(t, "linux")
+
+	for name, tt := range map[string]struct {
+		msi     config.MSI
+		archive *artifact.Artifact
+		err     error
+		check   func(tb testing.TB, err error)
+	}{
+		"no upgrade code": {
+			msi: config.MSI{},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.ErrorIs(tb, err, errNoUpgradeCode)
+			},
+		},
+		"invalid upgrade code": {
+			msi: config.MSI{UpgradeCode: "nope"},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.EqualError(tb, err, `msi: invalid upgrade_code: "nope"`)
+			},
+		},
+		"missing wxs": {
+			msi: config.MSI{WXS: "nope.wxs"},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.ErrorIs(tb, err, os.ErrNotExist)
+			},
+		},
+		"multiple archives": {
+			msi: config.MSI{UpgradeCode: upgradeCode},
+			archive: &artifact.Artifact{
+				Name:    "bar.zip",
+				Goos:    "windows",
+				Goarch:  "amd64",
+				Goamd64: "v1",
+				Type:    artifact.UploadableArchive,
+				Extra: map[string]interface{}{
+					artifact.ExtraID:       "bar",
+					artifact.ExtraBinaries: []string{"foo.exe"},
+				},
+			},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.ErrorContains(tb, err, "msi: found 2 archives for")
+			},
+		},
+		"archive with multiple binaries": {
+			msi: config.MSI{UpgradeCode: upgradeCode, IDs: []string{"bar"}},
+			archive: &artifact.Artifact{
+				Name:    "bar.zip",
+				Goos:    "windows",
+				Goarch:  "amd64",
+				Goamd64: "v1",
+				Type:    artifact.UploadableArchive,
+				Extra: map[string]interface{}{
+					artifact.ExtraID:       "bar",
+					artifact.ExtraBinaries: []string{"foo.exe", "bar.exe"},
+				},
+			},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.EqualError(tb, err, "msi: archive bar.zip must have a single binary, found 2")
+			},
+		},
+		"binary not found": {
+			msi: config.MSI{UpgradeCode: upgradeCode, IDs: []string{"bar"}},
+			archive: &artifact.Artifact{
+				Name:    "bar.zip",
+				Goos:    "windows",
+				Goarch:  "amd64",
+				Goamd64: "v1",
+				Type:    artifact.UploadableArchive,
+				Extra: map[string]interface{}{
+					artifact.ExtraID:       "bar",
+					artifact.ExtraBinaries: []string{"bar.exe"},
+				},
+			},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.EqualError(tb, err, "msi: binary bar.exe of archive bar.zip not found")
+			},
+		},
+		"bad upgrade code": {
+			msi:   config.MSI{UpgradeCode: "{{ .Nope }"},
+			check: testlib.RequireTemplateError,
+		},
+		"failed": {
+			msi: config.MSI{UpgradeCode: upgradeCode},
+			err: errors.New("fake error"),
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.EqualError(tb, err, "failed to create msi: fake error: some output")
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fakeCommands(t, tt.err)
+
+			folder := t.TempDir()
+			ctx := testctx.NewWithCfg(config.Project{
+				ProjectName: "foo",
+				Dist:        folder,
+				MSIs:        []config.MSI{tt.msi},
+			})
+			addArtifacts(t, ctx, folder)
+			if tt.archive != nil {
+				ctx.Artifacts.Add(tt.archive)
+			}
+			require.NoError(t, Pipe{}.Default(ctx))
+			tt.check(t, Pipe{}.Run(ctx))
+		})
+	}
+}
+
+func setGoos(tb testing.TB, s string) {
+	tb.Helper()
+	previous := goos
+	goos = s
+	tb.Cleanup(func() {
+		goos = previous
+	})
+}
+
+func fakeCommands(tb testing.TB, err error) *shell.MockRunner {
+	tb.Helper()
+	mock, restore := shell.Mock(&cmd, func(c shell.Cmd) ([]byte, error) {
+		if err != nil {
+			return []byte("some output"), err
+		}
+		// only the final msi path is absolute.
+		if out := c.Args[len(c.Args)-2]; filepath.IsAbs(out) {
+			return nil, os.WriteFile(out, []byte("fake msi"), 0o644)
+		}
+		return nil, nil
+	})
+	tb.Cleanup(restore)
+	return mock
+}
+
+func addArtifacts(tb testing.TB, ctx *context.Context, dist string) {
+	tb.Helper()
+	for _, platform := range [][2]string{{"amd64", "v1"}, {"amd64", "v3"}, {"386", ""}, {"arm64", ""}} {
+		path := filepath.Join(dist, "foo_windows_"+platform[0]+platform[1], "foo.exe")
+		require.NoError(tb, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(tb, os.WriteFile(path, []byte("fake"), 0o755))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo.exe",
+			Path:    path,
+			Goos:    "windows",
+			Goarch:  platform[0],
+			Goamd64: platform[1],
+			Type:    artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraID:     "default",
+				artifact.ExtraBinary: "foo",
+			},
+		})
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo_windows_" + platform[0] + platform[1] + ".zip",
+			Path:    filepath.Join(dist, "foo_windows_"+platform[0]+platform[1]+".zip"),
+			Goos:    "windows",
+			Goarch:  platform[0],
+			Goamd64: platform[1],
+			Type:    artifact.UploadableArchive,
+			Extra: map[string]interface{}{
+				artifact.ExtraID:       "foo",
+				artifact.ExtraFormat:   "zip",
+				artifact.ExtraBinaries: []string{"foo.exe"},
+			},
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "foo",
+		Path:   filepath.Join(dist, "foo_linux_amd64", "foo"),
+		Goos:   "linux",
+		Goarch: "amd64",
+		Type:   artifact.Binary,
+	})
+}
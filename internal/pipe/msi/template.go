@@ -0,0 +1,56 @@
+package msi
+
+// wxsTemplate is the WiX source used when no wxs file is provided.
+// It goes through the template engine, with the extra MSI fields.
+const wxsTemplate = `<?xml version='1.0' encoding='windows-1252'?>
+<!-- This file was generated by GoReleaser. DO NOT EDIT. -->
+<Wix xmlns='http://schemas.microsoft.com/wix/2006/wi'>
+	<Product
+		Name='{{ .MsiProductName }}'
+		Id='*'
+		UpgradeCode='{{ .MsiUpgradeCode }}'
+		Language='1033'
+		Codepage='1252'
+		Version='{{ .MsiVersion }}'
+		Manufacturer='{{ .MsiManufacturer }}'>
+
+		<Package
+			Id='*'
+			Keywords='Installer'
+			Description='{{ .MsiProductName }} installer'
+			Manufacturer='{{ .MsiManufacturer }}'
+			InstallerVersion='200'
+			Languages='1033'
+			Compressed='yes'
+			SummaryCodepage='1252'
+			{{- if eq .MsiArch "x64" }}
+			Platform='x64'
+			{{- end }}
+		/>
+
+		<MajorUpgrade DowngradeErrorMessage='A newer version of {{ .MsiProductName }} is already installed.' />
+
+		<Media Id='1' Cabinet='product.cab' EmbedCab='yes' />
+
+		<Directory Id='TARGETDIR' Name='SourceDir'>
+			<Directory Id='ProgramFiles{{ if eq .MsiArch "x64" }}64{{ end }}Folder' Name='PFiles'>
+				<Directory Id='INSTALLDIR' Name='{{ .MsiInstallDir }}'>
+					<Component Id='MainExecutable' Guid='*'{{ if eq .MsiArch "x64" }} Win64='yes'{{ end }}>
+						<File
+							Id='MainExecutableFile'
+							Name='{{ .Binary }}.exe'
+							DiskId='1'
+							Source='{{ .Binary }}.exe'
+							KeyPath='yes'
+						/>
+					</Component>
+				</Directory>
+			</Directory>
+		</Directory>
+
+		<Feature Id='Complete' Level='1'>
+			<ComponentRef Id='MainExecutable' />
+		</Feature>
+	</Product>
+</Wix>
+`
@@ -2,9 +2,11 @@
 package nfpm
 
 import (
+	"compress/gzip"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -62,6 +64,9 @@ func (Pipe) Default(ctx *context.Context) error {
 		if fpm.Libdirs.CArchive == "" {
 			fpm.Libdirs.CArchive = "/usr/lib"
 		}
+		if fpm.Libdirs.Plugin == "" {
+			fpm.Libdirs.Plugin = "/usr/lib"
+		}
 		if fpm.PackageName == "" {
 			fpm.PackageName = ctx.Config.ProjectName
 		}
@@ -99,6 +104,7 @@ func doRun(ctx *context.Context, fpm config.NFPM) error {
 			artifact.ByType(artifact.Header),
 			artifact.ByType(artifact.CArchive),
 			artifact.ByType(artifact.CShared),
+			artifact.ByType(artifact.Plugin),
 		),
 		artifact.Or(
 			artifact.ByGoos("linux"),
@@ -115,7 +121,7 @@ func doRun(ctx *context.Context, fpm config.NFPM) error {
 	if len(linuxBinaries) == 0 {
 		return fmt.Errorf("no linux binaries found for builds %v", fpm.Builds)
 	}
-	g := semerrgroup.New(ctx.Parallelism)
+	g := semerrgroup.NewShared(ctx)
 	for _, format := range fpm.Formats {
 		for _, artifacts := range linuxBinaries {
 			g.Go(func() error {
@@ -209,6 +215,7 @@ func create(ctx *context.Context, fpm config.NFPM, format string, artifacts []*a
 		fpm.Libdirs.Header = termuxPrefixedDir(fpm.Libdirs.Header)
 		fpm.Libdirs.CArchive = termuxPrefixedDir(fpm.Libdirs.CArchive)
 		fpm.Libdirs.CShared = termuxPrefixedDir(fpm.Libdirs.CShared)
+		fpm.Libdirs.Plugin = termuxPrefixedDir(fpm.Libdirs.Plugin)
 	}
 
 	if artifacts[0].Goos == "android" && format != termuxFormat {
@@ -288,6 +295,10 @@ func create(ctx *context.Context, fpm config.NFPM, format string, artifacts []*a
 	if err != nil {
 		return err
 	}
+	libdirs.Plugin, err = t.Apply(fpm.Libdirs.Plugin)
+	if err != nil {
+		return err
+	}
 
 	contents := files.Contents{}
 	for _, content := range overridden.Contents {
@@ -335,6 +346,11 @@ func create(ctx *context.Context, fpm config.NFPM, format string, artifacts []*a
 				},
 			})
 		}
+		docContents, err := docs(ctx, packageName, format, arch, artifacts)
+		if err != nil {
+			return err
+		}
+		contents = append(contents, docContents...)
 	}
 
 	log.WithField("files", destinations(contents)).Debug("all archive files")
@@ -503,6 +519,96 @@ func create(ctx *context.Context, fpm config.NFPM, format string, artifacts []*a
 	return nil
 }
 
+// completionDirs are the directories each shell looks for completions in.
+var completionDirs = map[string]string{
+	"bash": "/usr/share/bash-completion/completions",
+	"zsh":  "/usr/share/zsh/site-functions",
+	"fish": "/usr/share/fish/vendor_completions.d",
+}
+
+// docs returns the shell completions and man pages of the given binaries to
+// add to the package.
+// Man pages are gzipped for formats that expect them to be.
+func docs(ctx *context.Context, packageName, format, arch string, binaries []*artifact.Artifact) (files.Contents, error) {
+	prefix := func(dir string) string { return dir }
+	if format == termuxFormat {
+		prefix = termuxPrefixedDir
+	}
+	var contents files.Contents
+	for _, art := range ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.Completion),
+		artifact.ByDocsOf(binaries),
+	)).List() {
+		shell := artifact.ExtraOr(*art, artifact.ExtraShell, "")
+		contents = append(contents, &files.Content{
+			Source:      filepath.ToSlash(art.Path),
+			Destination: path.Join(prefix(completionDirs[shell]), art.Name),
+			FileInfo: &files.ContentFileInfo{
+				Mode: 0o644,
+			},
+		})
+	}
+	for _, art := range ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.ManPage),
+		artifact.ByDocsOf(binaries),
+	)).List() {
+		section := artifact.ExtraOr(*art, artifact.ExtraSection, "1")
+		src, name := art.Path, art.Name
+		if compressManPages(format) && !strings.HasSuffix(name, ".gz") {
+			name += ".gz"
+			src = filepath.Join(ctx.Config.Dist, format, packageName+"_"+arch, "manpages", name)
+			if err := gzipFile(art.Path, src); err != nil {
+				return nil, fmt.Errorf("failed to compress man page: %w", err)
+			}
+		}
+		contents = append(contents, &files.Content{
+			Source:      filepath.ToSlash(src),
+			Destination: path.Join(prefix("/usr/share/man"), "man"+section, name),
+			FileInfo: &files.ContentFileInfo{
+				Mode: 0o644,
+			},
+		})
+	}
+	return contents, nil
+}
+
+func compressManPages(format string) bool {
+	switch format {
+	case "deb", termuxFormat, "rpm":
+		return true
+	default:
+		return false
+	}
+}
+
+// gzipFile writes the gzipped contents of src to dst.
+// The gzip header is left empty so the output is reproducible.
+func gzipFile(src, dst string) error {
+	bts, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w, err := gzip.NewWriterLevel(f, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(bts); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
 func setupLintian(ctx *context.Context, fpm config.NFPM, packageName, format, arch string) (*files.Content, error) {
 	lines := make([]string, 0, len(fpm.Deb.Lintian))
 	for _, ov := range fpm.Deb.Lintian {
@@ -567,6 +673,8 @@ func artifactPackageDir(bindir string, libdirs config.Libdirs, art *artifact.Art
 		return libdirs.CShared
 	case artifact.CArchive:
 		return libdirs.CArchive
+	case artifact.Plugin:
+		return libdirs.Plugin
 	default:
 		// should never happen
 		return ""
@@ -1,8 +1,11 @@
 package nfpm
 
 import (
+	"compress/gzip"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
@@ -881,6 +884,32 @@ func TestDefault(t *testing.T) {
 	require.Empty(t, ctx.Config.NFPMs[0].Builds)
 	require.Equal(t, defaultNameTemplate, ctx.Config.NFPMs[0].FileNameTemplate)
 	require.Equal(t, ctx.Config.ProjectName, ctx.Config.NFPMs[0].PackageName)
+	require.Equal(t, config.Libdirs{
+		Header:   "/usr/include",
+		CShared:  "/usr/lib",
+		CArchive: "/usr/lib",
+		Plugin:   "/usr/lib",
+	}, ctx.Config.NFPMs[0].Libdirs)
+}
+
+func TestArtifactPackageDir(t *testing.T) {
+	libdirs := config.Libdirs{
+		Header:   "/usr/include",
+		CShared:  "/usr/lib/shared",
+		CArchive: "/usr/lib/static",
+		Plugin:   "/usr/lib/plugins",
+	}
+	for typ, dir := range map[artifact.Type]string{
+		artifact.Binary:   "/usr/bin",
+		artifact.Header:   "/usr/include",
+		artifact.CShared:  "/usr/lib/shared",
+		artifact.CArchive: "/usr/lib/static",
+		artifact.Plugin:   "/usr/lib/plugins",
+	} {
+		t.Run(typ.String(), func(t *testing.T) {
+			require.Equal(t, dir, artifactPackageDir("/usr/bin", libdirs, &artifact.Artifact{Type: typ}))
+		})
+	}
 }
 
 func TestDefaultSet(t *testing.T) {
@@ -1583,6 +1612,115 @@ func TestBinDirTemplating(t *testing.T) {
 	}
 }
 
+func TestDocs(t *testing.T) {
+	folder := t.TempDir()
+	dist := filepath.Join(folder, "dist")
+	require.NoError(t, os.Mkdir(dist, 0o755))
+	for _, name := range []string{"mybin", "mybin.bash", "mybin.zsh", "mybin.fish", "mybin.1", "other.1"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dist, name), []byte(name), 0o755))
+	}
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "mybin",
+		Dist:        dist,
+		NFPMs: []config.NFPM{
+			{
+				ID:          "someid",
+				Bindir:      "/usr/bin",
+				Builds:      []string{"default"},
+				Formats:     []string{"deb", "termux.deb", "apk"},
+				Description: "Some description",
+				License:     "MIT",
+				Maintainer:  "me@me",
+				NFPMOverridables: config.NFPMOverridables{
+					PackageName: "foo",
+				},
+			},
+		},
+	}, testctx.WithVersion("1.0.0"), testctx.WithCurrentTag("v1.0.0"))
+	for _, goos := range []string{"linux", "android"} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:   "mybin",
+			Path:   filepath.Join(dist, "mybin"),
+			Goarch: "arm64",
+			Goos:   goos,
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: "default",
+			},
+		})
+	}
+	for shell, name := range map[string]string{
+		"bash": "mybin",
+		"zsh":  "_mybin",
+		"fish": "mybin.fish",
+	} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: name,
+			Path: filepath.Join(dist, "mybin."+shell),
+			Type: artifact.Completion,
+			Extra: map[string]interface{}{
+				artifact.ExtraID:    "default",
+				artifact.ExtraShell: shell,
+			},
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "mybin.1",
+		Path: filepath.Join(dist, "mybin.1"),
+		Type: artifact.ManPage,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:      "default",
+			artifact.ExtraSection: "1",
+		},
+	})
+	// docs of builds not in the package are not added.
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "other.1",
+		Path: filepath.Join(dist, "other.1"),
+		Type: artifact.ManPage,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:      "other",
+			artifact.ExtraSection: "1",
+		},
+	})
+	require.NoError(t, Pipe{}.Run(ctx))
+	packages := ctx.Artifacts.Filter(artifact.ByType(artifact.LinuxPackage)).List()
+	require.Len(t, packages, 3)
+
+	for _, pkg := range packages {
+		prefix := ""
+		if pkg.Format() == termuxFormat {
+			prefix = "/data/data/com.termux/files"
+		}
+		man := "/usr/share/man/man1/mybin.1.gz"
+		if pkg.Format() == "apk" {
+			man = "/usr/share/man/man1/mybin.1"
+		}
+		contents := artifact.ExtraOr(*pkg, extraFiles, files.Contents{})
+		require.ElementsMatch(t, []string{
+			prefix + "/usr/bin/mybin",
+			prefix + "/usr/share/bash-completion/completions/mybin",
+			prefix + "/usr/share/zsh/site-functions/_mybin",
+			prefix + "/usr/share/fish/vendor_completions.d/mybin.fish",
+			prefix + man,
+		}, destinations(contents))
+
+		for _, content := range contents {
+			if !strings.HasSuffix(content.Destination, ".gz") {
+				continue
+			}
+			f, err := os.Open(content.Source)
+			require.NoError(t, err)
+			defer f.Close()
+			r, err := gzip.NewReader(f)
+			require.NoError(t, err)
+			bts, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, "mybin.1", string(bts))
+		}
+	}
+}
+
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
 		require.True(t, Pipe{}.Skip(testctx.New()))
@@ -0,0 +1,75 @@
+// Package nightly provides the nightly builds functionality to goreleaser.
+package nightly
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	defaultNameTemplate = `{{ .Version }}-nightly-{{ .Now.Format "20060102" }}`
+	defaultTagName      = "nightly"
+)
+
+var errPublishReleaseNotGitHub = errors.New("nightly.publish_release is only supported on GitHub")
+
+// Pipe for setting up the nightly builds.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "nightly" }
+func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Nightly }
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	if ctx.Config.Nightly.NameTemplate == "" {
+		ctx.Config.Nightly.NameTemplate = defaultNameTemplate
+	}
+	tag, err := TagName(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to parse nightly tag name: %w", err)
+	}
+	ctx.Config.Nightly.TagName = tag
+	if ctx.Nightly &&
+		ctx.Config.Nightly.PublishRelease &&
+		ctx.TokenType != "" &&
+		ctx.TokenType != context.TokenTypeGitHub {
+		return errPublishReleaseNotGitHub
+	}
+	if ctx.Nightly && ctx.DistinctNightly {
+		if _, err := semver.NewVersion(ctx.Config.Nightly.TagName); err == nil {
+			return fmt.Errorf("nightly tag %q looks like a stable release tag, use a distinct tag name, e.g. %q", ctx.Config.Nightly.TagName, defaultTagName)
+		}
+	}
+	return nil
+}
+
+// Run sets the nightly version, tag and marks the release as a pre-release.
+func (Pipe) Run(ctx *context.Context) error {
+	name, err := tmpl.New(ctx).Apply(ctx.Config.Nightly.NameTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse nightly name: %w", err)
+	}
+	if name == "" {
+		return fmt.Errorf("empty nightly name")
+	}
+	ctx.Version = name
+	ctx.Git.CurrentTag = ctx.Config.Nightly.TagName
+	ctx.PreRelease = true
+	log.WithField("version", ctx.Version).
+		WithField("tag", ctx.Git.CurrentTag).
+		Infof("building nightly...")
+	return nil
+}
+
+// TagName returns the evaluated tag name used by the nightly releases.
+func TagName(ctx *context.Context) (string, error) {
+	if tag := ctx.Config.Nightly.TagName; tag != "" {
+		return tmpl.New(ctx).Apply(tag)
+	}
+	return defaultTagName, nil
+}
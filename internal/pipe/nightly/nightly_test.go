@@ -0,0 +1,168 @@
+package nightly
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.New()))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		require.False(t, Pipe{}.Skip(testctx.New(testctx.Nightly)))
+	})
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.New(testctx.Nightly)
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, config.Nightly{
+			NameTemplate: defaultNameTemplate,
+			TagName:      defaultTagName,
+		}, ctx.Config.Nightly)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				NameTemplate: "{{ .Version }}-devel",
+				TagName:      "devel",
+			},
+		}, testctx.Nightly)
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, "{{ .Version }}-devel", ctx.Config.Nightly.NameTemplate)
+		require.Equal(t, "devel", ctx.Config.Nightly.TagName)
+	})
+
+	t.Run("distinct semver tag", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				TagName: "v1.0.0",
+			},
+		}, testctx.Nightly)
+		ctx.DistinctNightly = true
+		require.EqualError(t, Pipe{}.Default(ctx), `nightly tag "v1.0.0" looks like a stable release tag, use a distinct tag name, e.g. "nightly"`)
+	})
+
+	t.Run("distinct templated semver tag", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				TagName: "{{ .Env.TAG }}",
+			},
+		}, testctx.Nightly, testctx.WithEnv(map[string]string{"TAG": "v1.0.0"}))
+		ctx.DistinctNightly = true
+		require.EqualError(t, Pipe{}.Default(ctx), `nightly tag "v1.0.0" looks like a stable release tag, use a distinct tag name, e.g. "nightly"`)
+	})
+
+	t.Run("templated tag", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				TagName: "{{ .Env.TAG }}",
+			},
+		}, testctx.Nightly, testctx.WithEnv(map[string]string{"TAG": "devel"}))
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, "devel", ctx.Config.Nightly.TagName)
+	})
+
+	t.Run("invalid tag template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				TagName: "{{ .Foo }",
+			},
+		}, testctx.Nightly)
+		testlib.RequireTemplateError(t, Pipe{}.Default(ctx))
+	})
+
+	t.Run("distinct tag", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				TagName: "devel",
+			},
+		}, testctx.Nightly)
+		ctx.DistinctNightly = true
+		require.NoError(t, Pipe{}.Default(ctx))
+	})
+
+	t.Run("publish release on gitlab", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				PublishRelease: true,
+			},
+		}, testctx.Nightly, testctx.GitLabTokenType)
+		require.ErrorIs(t, Pipe{}.Default(ctx), errPublishReleaseNotGitHub)
+	})
+
+	t.Run("publish release on gitlab not nightly", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				PublishRelease: true,
+			},
+		}, testctx.GitLabTokenType)
+		require.NoError(t, Pipe{}.Default(ctx))
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		ctx := testctx.New(
+			testctx.Nightly,
+			testctx.WithVersion("1.2.3"),
+			testctx.WithCurrentTag("v1.2.3"),
+			testctx.WithDate(time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC)),
+		)
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "1.2.3-nightly-20240203", ctx.Version)
+		require.Equal(t, "nightly", ctx.Git.CurrentTag)
+		require.True(t, ctx.PreRelease)
+	})
+
+	t.Run("custom tag", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				NameTemplate: "{{ .Version }}-{{ .FullCommit }}",
+				TagName:      "devel",
+			},
+		},
+			testctx.Nightly,
+			testctx.WithVersion("1.2.3"),
+			testctx.WithCommit("abcdef"),
+		)
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "1.2.3-abcdef", ctx.Version)
+		require.Equal(t, "devel", ctx.Git.CurrentTag)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				NameTemplate: "{{ .ShortCommit }{{ .Timestamp }}",
+			},
+		}, testctx.Nightly)
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				NameTemplate: "{{ .Env.NOPE }}",
+			},
+		}, testctx.Nightly, testctx.WithEnv(map[string]string{"NOPE": ""}))
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Run(ctx), "empty nightly name")
+	})
+}
@@ -65,6 +65,7 @@ type Pipe struct {
 }
 
 func (Pipe) String() string                           { return "nixpkgs" }
+func (Pipe) Key() string                              { return "nix" }
 func (Pipe) ContinueOnError() bool                    { return true }
 func (Pipe) Dependencies(_ *context.Context) []string { return []string{"nix-prefetch-url"} }
 func (p Pipe) Skip(ctx *context.Context) bool {
@@ -419,7 +420,14 @@ func doPublish(ctx *context.Context, prefetcher shaPrefetcher, cl client.Client,
 		return fmt.Errorf("client does not support pull requests")
 	}
 
-	return pcl.OpenPullRequest(ctx, base, repo, msg, nix.Repository.PullRequest.Draft)
+	url, err := pcl.OpenPullRequest(ctx, base, repo, msg, nix.Repository.PullRequest.Draft, nix.Repository.PullRequest.AutoMerge)
+	if err != nil {
+		return err
+	}
+	if url != "" {
+		pkg.Extra[artifact.ExtraPullRequest] = url
+	}
+	return nil
 }
 
 func doBuildPkg(ctx *context.Context, data templateData) (string, error) {
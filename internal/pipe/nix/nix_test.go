@@ -519,6 +519,7 @@ func TestRunPipe(t *testing.T) {
 			}
 
 			client := client.NewMock()
+			client.PullRequestURL = "https://github.com/foo/bar/pull/1"
 			bpipe := NewBuild()
 			ppipe := Pipe{
 				fakeNixShaPrefetcher{
@@ -575,6 +576,7 @@ func TestRunPipe(t *testing.T) {
 			if tt.nix.Repository.PullRequest.Enabled {
 				require.True(t, client.OpenedPullRequest)
 				require.True(t, client.SyncedFork)
+				require.Equal(t, "https://github.com/foo/bar/pull/1", artifact.ExtraOr(*ctx.Artifacts.Filter(artifact.ByType(artifact.Nixpkg)).List()[0], artifact.ExtraPullRequest, ""))
 			}
 			if tt.nix.Path != "" {
 				require.Equal(t, tt.nix.Path, client.Path)
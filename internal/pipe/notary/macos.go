@@ -40,7 +40,7 @@ func (MacOS) Default(ctx *context.Context) error {
 }
 
 func (MacOS) Run(ctx *context.Context) error {
-	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	g := semerrgroup.NewSkipAware(semerrgroup.NewShared(ctx))
 	for _, cfg := range ctx.Config.Notarize.MacOS {
 		g.Go(func() error {
 			return signAndNotarize(ctx, cfg)
@@ -0,0 +1,219 @@
+// Package nsis implements the Pipe interface providing Windows setup
+// executables bindings, using NSIS.
+package nsis
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const defaultNameTemplate = `{{ .ProjectName }}_{{ .Arch }}_setup`
+
+// cmd runs makensis.
+var cmd shell.Runner = shell.StdRunner{}
+
+// Pipe for NSIS installers.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "nsis installers" }
+func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.NSIS) == 0 }
+func (Pipe) Dependencies(_ *context.Context) []string {
+	return []string{"makensis"}
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("nsis")
+	for i := range ctx.Config.NSIS {
+		nsis := &ctx.Config.NSIS[i]
+		if nsis.ID == "" {
+			nsis.ID = ctx.Config.ProjectName
+		}
+		if nsis.Name == "" {
+			nsis.Name = defaultNameTemplate
+		}
+		if nsis.Goamd64 == "" {
+			nsis.Goamd64 = "v1"
+		}
+		if nsis.ProductName == "" {
+			nsis.ProductName = ctx.Config.ProjectName
+		}
+		ids.Inc(nsis.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	for _, nsis := range ctx.Config.NSIS {
+		if err := doRun(ctx, nsis); err != nil {
+			if pipe.IsSkip(err) {
+				skips.Remember(err)
+				continue
+			}
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doRun(ctx *context.Context, nsis config.NSIS) error {
+	if err := tmpl.New(ctx).ApplyAll(
+		&nsis.Disable,
+		&nsis.Script,
+		&nsis.ProductName,
+		&nsis.License,
+	); err != nil {
+		return err
+	}
+	if nsis.Disable == "true" {
+		return pipe.Skip("configuration is disabled")
+	}
+
+	filters := []artifact.Filter{
+		artifact.ByGoos("windows"),
+		artifact.ByType(artifact.Binary),
+		artifact.Or(
+			artifact.And(
+				artifact.ByGoarch("amd64"),
+				artifact.ByGoamd64(nsis.Goamd64),
+			),
+			artifact.ByGoarch("386"),
+			artifact.ByGoarch("arm64"),
+		),
+	}
+	if len(nsis.IDs) > 0 {
+		filters = append(filters, artifact.ByIDs(nsis.IDs...))
+	}
+
+	platforms := ctx.Artifacts.Filter(artifact.And(filters...)).GroupByPlatform()
+	for platform, binaries := range platforms {
+		if len(binaries) > 1 {
+			return fmt.Errorf("nsis: found %d binaries for %s, use ids to pick one", len(binaries), platform)
+		}
+	}
+
+	g := semerrgroup.NewShared(ctx)
+	for _, binaries := range platforms {
+		g.Go(func() error {
+			return create(ctx, nsis, binaries[0])
+		})
+	}
+	return g.Wait()
+}
+
+func create(ctx *context.Context, nsis config.NSIS, binary *artifact.Artifact) error {
+	tpl := tmpl.New(ctx).WithArtifact(binary)
+	name, err := tpl.Apply(nsis.Name)
+	if err != nil {
+		return err
+	}
+	path, err := filepath.Abs(filepath.Join(ctx.Config.Dist, name+".exe"))
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(ctx.Config.Dist, "nsis", name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := gio.Copy(binary.Path, filepath.Join(dir, filepath.Base(binary.Path))); err != nil {
+		return err
+	}
+	for _, extra := range nsis.ExtraFiles {
+		if err := gio.Copy(extra, filepath.Join(dir, filepath.Base(extra))); err != nil {
+			return err
+		}
+	}
+	var license string
+	if nsis.License != "" {
+		license = filepath.Base(nsis.License)
+		if err := gio.Copy(nsis.License, filepath.Join(dir, license)); err != nil {
+			return err
+		}
+	}
+
+	script, err := scriptFor(nsis, tpl.WithExtraFields(tmpl.Fields{
+		"NsisName":     escape(nsis.ProductName),
+		"NsisVersion":  fmt.Sprintf("%d.%d.%d.0", ctx.Semver.Major, ctx.Semver.Minor, ctx.Semver.Patch),
+		"NsisOutFile":  escape(path),
+		"NsisLicense":  escape(license),
+		"NsisShortcut": nsis.Shortcut,
+	}))
+	if err != nil {
+		return err
+	}
+	scriptName := name + ".nsi"
+	if err := os.WriteFile(filepath.Join(dir, scriptName), script, 0o644); err != nil {
+		return err
+	}
+
+	log.WithField("arch", binary.Goarch).WithField("installer", path).Info("creating")
+	if out, err := cmd.Exec(ctx, shell.Cmd{
+		Name: "makensis",
+		Args: []string{"-V2", scriptName},
+		Dir:  dir,
+	}); err != nil {
+		return fmt.Errorf("failed to create nsis installer: %w: %s", err, string(out))
+	}
+
+	modTimestamp, err := tmpl.New(ctx).Apply(nsis.ModTimestamp)
+	if err != nil {
+		return err
+	}
+	if err := gio.Chtimes(path, modTimestamp); err != nil {
+		return err
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.Installer,
+		Name:    name + ".exe",
+		Path:    path,
+		Goos:    binary.Goos,
+		Goarch:  binary.Goarch,
+		Goamd64: binary.Goamd64,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:     nsis.ID,
+			artifact.ExtraFormat: "nsis",
+			artifact.ExtraExt:    ".exe",
+		},
+	})
+	return nil
+}
+
+// scriptFor returns the NSIS script, either the default or the given one,
+// applied against the given template.
+func scriptFor(nsis config.NSIS, tpl *tmpl.Template) ([]byte, error) {
+	script := nsiTemplate
+	if nsis.Script != "" {
+		bts, err := os.ReadFile(nsis.Script)
+		if err != nil {
+			return nil, fmt.Errorf("nsis: %w", err)
+		}
+		script = string(bts)
+	}
+	out, err := tpl.Apply(script)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+// escape escapes s so it can be used inside a double quoted NSIS string.
+func escape(s string) string {
+	return strings.NewReplacer(`$`, `$$`, `"`, `$\"`).Replace(s)
+}
@@ -0,0 +1,256 @@
+package nsis
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		NSIS:        []config.NSIS{{}},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, config.NSIS{
+		ID:          "foo",
+		Name:        defaultNameTemplate,
+		Goamd64:     "v1",
+		ProductName: "foo",
+	}, ctx.Config.NSIS[0])
+}
+
+func TestScript(t *testing.T) {
+	ctx := testctx.New(
+		testctx.WithVersion("1.2.3-rc1"),
+		testctx.WithSemver(1, 2, 3, "rc1"),
+	)
+	for name, tt := range map[string]struct {
+		nsis    config.NSIS
+		license string
+	}{
+		"simple": {nsis: config.NSIS{ProductName: "Foo"}},
+		"full": {
+			nsis: config.NSIS{
+				ProductName: `Foo "Bar"`,
+				Shortcut:    true,
+			},
+			license: "LICENSE.txt",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tpl := tmpl.New(ctx).WithArtifact(&artifact.Artifact{
+				Goos:   "windows",
+				Goarch: "amd64",
+				Extra: map[string]interface{}{
+					artifact.ExtraBinary: "foo",
+				},
+			}).WithExtraFields(tmpl.Fields{
+				"NsisName":     escape(tt.nsis.ProductName),
+				"NsisVersion":  "1.2.3.0",
+				"NsisOutFile":  `C:\dist\foo_amd64_setup.exe`,
+				"NsisLicense":  tt.license,
+				"NsisShortcut": tt.nsis.Shortcut,
+			})
+			out, err := scriptFor(tt.nsis, tpl)
+			require.NoError(t, err)
+			require.Contains(t, string(out), "!define VERSION \"1.2.3-rc1\"\n")
+			require.Contains(t, string(out), "!define VIVERSION \"1.2.3.0\"\n")
+			golden.RequireEqualExt(t, out, ".nsi")
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	calls := fakeCommands(t, nil)
+
+	folder := t.TempDir()
+	license := filepath.Join(folder, "LICENSE.txt")
+	require.NoError(t, os.WriteFile(license, []byte("MIT"), 0o644))
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Dist:        folder,
+		NSIS: []config.NSIS{
+			{
+				License:      license,
+				Shortcut:     true,
+				ModTimestamp: "{{ .CommitTimestamp }}",
+			},
+		},
+	}, testctx.WithVersion("1.2.3"), testctx.WithSemver(1, 2, 3, ""))
+	addBinaries(t, ctx, folder)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	installers := ctx.Artifacts.Filter(artifact.ByType(artifact.Installer)).List()
+	require.Len(t, installers, 3)
+	var names []string
+	for _, installer := range installers {
+		names = append(names, installer.Name)
+		require.Equal(t, "foo", artifact.ExtraOr(*installer, artifact.ExtraID, ""))
+		require.Equal(t, "nsis", artifact.ExtraOr(*installer, artifact.ExtraFormat, ""))
+	}
+	require.ElementsMatch(t, []string{
+		"foo_amd64_setup.exe",
+		"foo_386_setup.exe",
+		"foo_arm64_setup.exe",
+	}, names)
+
+	dir := filepath.Join(folder, "nsis", "foo_amd64_setup")
+	require.FileExists(t, filepath.Join(dir, "foo.exe"))
+	require.FileExists(t, filepath.Join(dir, "LICENSE.txt"))
+	bts, err := os.ReadFile(filepath.Join(dir, "foo_amd64_setup.nsi"))
+	require.NoError(t, err)
+	require.Contains(t, string(bts), `OutFile "`+filepath.Join(folder, "foo_amd64_setup.exe")+`"`)
+	require.Contains(t, string(bts), `LicenseData "LICENSE.txt"`)
+	require.Contains(t, string(bts), `$PROGRAMFILES64\${NAME}`)
+
+	require.Len(t, calls.Cmds(), 3)
+	require.Contains(t, calls.Cmds(), shell.Cmd{
+		Name: "makensis",
+		Args: []string{"-V2", "foo_amd64_setup.nsi"},
+		Dir:  dir,
+	})
+}
+
+func TestRunCustomScript(t *testing.T) {
+	fakeCommands(t, nil)
+
+	folder := t.TempDir()
+	script := filepath.Join(folder, "app.nsi")
+	require.NoError(t, os.WriteFile(script, []byte(`!define VERSION "{{ .Version }}"`), 0o644))
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Dist:        folder,
+		NSIS: []config.NSIS{
+			{
+				Script: script,
+				IDs:    []string{"foo"},
+				Name:   "{{ .ProjectName }}_{{ .Version }}_{{ .Arch }}",
+			},
+		},
+	}, testctx.WithVersion("1.2.3"))
+	addBinaries(t, ctx, folder)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	bts, err := os.ReadFile(filepath.Join(folder, "nsis", "foo_1.2.3_386", "foo_1.2.3_386.nsi"))
+	require.NoError(t, err)
+	require.Equal(t, `!define VERSION "1.2.3"`, string(bts))
+}
+
+func TestRunErrors(t *testing.T) {
+	for name, tt := range map[string]struct {
+		nsis   config.NSIS
+		binary bool
+		err    error
+		check  func(tb testing.TB, err error)
+	}{
+		"missing script": {
+			nsis: config.NSIS{Script: "nope.nsi"},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.ErrorIs(tb, err, os.ErrNotExist)
+			},
+		},
+		"missing license": {
+			nsis: config.NSIS{License: "nope.txt"},
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.ErrorIs(tb, err, os.ErrNotExist)
+			},
+		},
+		"multiple binaries": {
+			binary: true,
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.ErrorContains(tb, err, "nsis: found 2 binaries for")
+			},
+		},
+		"bad product name": {
+			nsis:  config.NSIS{ProductName: "{{ .Nope }"},
+			check: testlib.RequireTemplateError,
+		},
+		"failed": {
+			err: errors.New("fake error"),
+			check: func(tb testing.TB, err error) {
+				tb.Helper()
+				require.EqualError(tb, err, "failed to create nsis installer: fake error: some output")
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fakeCommands(t, tt.err)
+
+			folder := t.TempDir()
+			ctx := testctx.NewWithCfg(config.Project{
+				ProjectName: "foo",
+				Dist:        folder,
+				NSIS:        []config.NSIS{tt.nsis},
+			})
+			addBinaries(t, ctx, folder)
+			if tt.binary {
+				ctx.Artifacts.Add(&artifact.Artifact{
+					Name:   "bar.exe",
+					Path:   filepath.Join(folder, "bar.exe"),
+					Goos:   "windows",
+					Goarch: "386",
+					Type:   artifact.Binary,
+				})
+			}
+			require.NoError(t, Pipe{}.Default(ctx))
+			tt.check(t, Pipe{}.Run(ctx))
+		})
+	}
+}
+
+func fakeCommands(tb testing.TB, err error) *shell.MockRunner {
+	tb.Helper()
+	mock, restore := shell.Mock(&cmd, func(c shell.Cmd) ([]byte, error) {
+		if err != nil {
+			return []byte("some output"), err
+		}
+		return nil, os.WriteFile(filepath.Join(filepath.Dir(c.Dir), "..", filepath.Base(c.Dir)+".exe"), []byte("fake exe"), 0o644)
+	})
+	tb.Cleanup(restore)
+	return mock
+}
+
+func addBinaries(tb testing.TB, ctx *context.Context, dist string) {
+	tb.Helper()
+	for _, platform := range [][2]string{{"amd64", "v1"}, {"amd64", "v3"}, {"386", ""}, {"arm64", ""}, {"arm", ""}} {
+		path := filepath.Join(dist, "foo_windows_"+platform[0]+platform[1], "foo.exe")
+		require.NoError(tb, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(tb, os.WriteFile(path, []byte("fake"), 0o755))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo.exe",
+			Path:    path,
+			Goos:    "windows",
+			Goarch:  platform[0],
+			Goamd64: platform[1],
+			Type:    artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraID:     "foo",
+				artifact.ExtraBinary: "foo",
+			},
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "foo",
+		Path:   filepath.Join(dist, "foo_linux_amd64", "foo"),
+		Goos:   "linux",
+		Goarch: "amd64",
+		Type:   artifact.Binary,
+	})
+}
@@ -0,0 +1,60 @@
+package nsis
+
+// nsiTemplate is the NSIS script used when no script is provided.
+// It goes through the template engine, with the extra NSIS fields.
+const nsiTemplate = `; This file was generated by GoReleaser. DO NOT EDIT.
+Unicode true
+
+!define NAME "{{ .NsisName }}"
+!define VERSION "{{ .Version }}"
+!define VIVERSION "{{ .NsisVersion }}"
+!define EXE "{{ .Binary }}.exe"
+
+Name "${NAME}"
+OutFile "{{ .NsisOutFile }}"
+InstallDir "{{ if eq .Arch "386" }}$PROGRAMFILES{{ else }}$PROGRAMFILES64{{ end }}\${NAME}"
+RequestExecutionLevel admin
+SetCompressor /SOLID lzma
+
+VIProductVersion "${VIVERSION}"
+VIAddVersionKey "ProductName" "${NAME}"
+VIAddVersionKey "ProductVersion" "${VERSION}"
+VIAddVersionKey "FileVersion" "${VERSION}"
+VIAddVersionKey "FileDescription" "${NAME} installer"
+
+{{- if .NsisLicense }}
+
+Page license
+LicenseData "{{ .NsisLicense }}"
+{{- end }}
+Page directory
+Page instfiles
+UninstPage uninstConfirm
+UninstPage instfiles
+
+Section "Install"
+	SetOutPath "$INSTDIR"
+	File "${EXE}"
+	WriteUninstaller "$INSTDIR\uninstall.exe"
+	{{- if .NsisShortcut }}
+	CreateDirectory "$SMPROGRAMS\${NAME}"
+	CreateShortcut "$SMPROGRAMS\${NAME}\${NAME}.lnk" "$INSTDIR\${EXE}"
+	CreateShortcut "$SMPROGRAMS\${NAME}\Uninstall ${NAME}.lnk" "$INSTDIR\uninstall.exe"
+	{{- end }}
+	WriteRegStr HKLM "Software\Microsoft\Windows\CurrentVersion\Uninstall\${NAME}" "DisplayName" "${NAME}"
+	WriteRegStr HKLM "Software\Microsoft\Windows\CurrentVersion\Uninstall\${NAME}" "DisplayVersion" "${VERSION}"
+	WriteRegStr HKLM "Software\Microsoft\Windows\CurrentVersion\Uninstall\${NAME}" "UninstallString" '"$INSTDIR\uninstall.exe"'
+SectionEnd
+
+Section "Uninstall"
+	Delete "$INSTDIR\${EXE}"
+	Delete "$INSTDIR\uninstall.exe"
+	RMDir "$INSTDIR"
+	{{- if .NsisShortcut }}
+	Delete "$SMPROGRAMS\${NAME}\${NAME}.lnk"
+	Delete "$SMPROGRAMS\${NAME}\Uninstall ${NAME}.lnk"
+	RMDir "$SMPROGRAMS\${NAME}"
+	{{- end }}
+	DeleteRegKey HKLM "Software\Microsoft\Windows\CurrentVersion\Uninstall\${NAME}"
+SectionEnd
+`
@@ -8,7 +8,9 @@ import (
 
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -21,9 +23,16 @@ const (
 type Pipe struct{}
 
 func (Pipe) String() string { return "opencollective" }
+func (Pipe) Key() string    { return "opencollective" }
 
-func (Pipe) Skip(ctx *context.Context) bool {
-	return !ctx.Config.Announce.OpenCollective.Enabled || ctx.Config.Announce.OpenCollective.Slug == ""
+func (Pipe) Len(ctx *context.Context) int { return len(ctx.Config.Announce.OpenCollective) }
+
+func (Pipe) Skip(ctx *context.Context, i int) bool {
+	return !ctx.Config.Announce.OpenCollective[i].Enabled || ctx.Config.Announce.OpenCollective[i].Slug == ""
+}
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.OpenCollective[i].AnnounceConditions
 }
 
 type Config struct {
@@ -31,21 +40,28 @@ type Config struct {
 }
 
 func (Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.OpenCollective.TitleTemplate == "" {
-		ctx.Config.Announce.OpenCollective.TitleTemplate = defaultTitleTemplate
-	}
-	if ctx.Config.Announce.OpenCollective.MessageTemplate == "" {
-		ctx.Config.Announce.OpenCollective.MessageTemplate = defaultMessageTemplate
+	for i := range ctx.Config.Announce.OpenCollective {
+		conf := &ctx.Config.Announce.OpenCollective[i]
+		if conf.TitleTemplate == "" {
+			conf.TitleTemplate = defaultTitleTemplate
+		}
+		if conf.MessageTemplate == "" && conf.MessageTemplateFile == "" {
+			conf.MessageTemplate = defaultMessageTemplate
+		}
 	}
 	return nil
 }
 
-func (Pipe) Announce(ctx *context.Context) error {
-	title, err := tmpl.New(ctx).Apply(ctx.Config.Announce.OpenCollective.TitleTemplate)
+func (Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.OpenCollective[i]
+	title, err := tmpl.New(ctx).Apply(conf.TitleTemplate)
 	if err != nil {
 		return fmt.Errorf("opencollective: %w", err)
 	}
-	html, err := tmpl.New(ctx).Apply(ctx.Config.Announce.OpenCollective.MessageTemplate)
+	html, err := tmpl.New(ctx).ApplyInlineOrFile(
+		conf.MessageTemplate,
+		conf.MessageTemplateFile,
+	)
 	if err != nil {
 		return fmt.Errorf("opencollective: %w", err)
 	}
@@ -57,7 +73,7 @@ func (Pipe) Announce(ctx *context.Context) error {
 
 	log.Infof("posting: %q | %q", title, html)
 
-	id, err := createUpdate(ctx, title, html, ctx.Config.Announce.OpenCollective.Slug, cfg.Token)
+	id, err := createUpdate(ctx, title, html, conf.Slug, cfg.Token)
 	if err != nil {
 		return fmt.Errorf("opencollective: %w", err)
 	}
@@ -155,7 +171,11 @@ func doMutation(ctx *context.Context, payload payload, token string) (*http.Resp
 	req.Header.Set("Personal-Token", token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	client, err := httpclient.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("could not send request to opencollective: %w", err)
 	}
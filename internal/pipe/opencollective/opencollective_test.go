@@ -14,57 +14,66 @@ func TestStringer(t *testing.T) {
 }
 
 func TestDefault(t *testing.T) {
-	ctx := testctx.New()
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			OpenCollective: []config.OpenCollective{{}},
+		},
+	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.Equal(t, defaultTitleTemplate, ctx.Config.Announce.OpenCollective.TitleTemplate)
-	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.OpenCollective.MessageTemplate)
+	require.Equal(t, defaultTitleTemplate, ctx.Config.Announce.OpenCollective[0].TitleTemplate)
+	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.OpenCollective[0].MessageTemplate)
 }
 
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			OpenCollective: config.OpenCollective{
+			OpenCollective: []config.OpenCollective{{
 				MessageTemplate: "{{ .Foo }",
-			},
+			}},
 		},
 	})
-	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
 }
 
 func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			OpenCollective: config.OpenCollective{},
+			OpenCollective: []config.OpenCollective{{}},
 		},
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.EqualError(t, Pipe{}.Announce(ctx), `opencollective: env: environment variable "OPENCOLLECTIVE_TOKEN" should not be empty`)
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `opencollective: env: environment variable "OPENCOLLECTIVE_TOKEN" should not be empty`)
 }
 
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
-		require.True(t, Pipe{}.Skip(testctx.New()))
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				OpenCollective: []config.OpenCollective{{}},
+			},
+		})
+		require.True(t, Pipe{}.Skip(ctx, 0))
 	})
 
 	t.Run("skip empty slug", func(t *testing.T) {
 		require.True(t, Pipe{}.Skip(testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				OpenCollective: config.OpenCollective{
+				OpenCollective: []config.OpenCollective{{
 					Enabled: true,
 					Slug:    "", // empty
-				},
+				}},
 			},
-		})))
+		}), 0))
 	})
 
 	t.Run("dont skip", func(t *testing.T) {
 		require.False(t, Pipe{}.Skip(testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				OpenCollective: config.OpenCollective{
+				OpenCollective: []config.OpenCollective{{
 					Enabled: true,
 					Slug:    "goreleaser",
-				},
+				}},
 			},
-		})))
+		}), 0))
 	})
 }
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
 )
 
 // ErrSnapshotEnabled happens when goreleaser is running in snapshot mode.
@@ -52,12 +55,16 @@ func Skipf(format string, a ...any) ErrSkip {
 }
 
 // SkipMemento remembers previous skip errors so you can return them all at once later.
+// It is safe for concurrent use.
 type SkipMemento struct {
+	lock  sync.Mutex
 	skips []string
 }
 
 // Remember a skip.
 func (e *SkipMemento) Remember(err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
 	for _, skip := range e.skips {
 		if skip == err.Error() {
 			return
@@ -68,8 +75,68 @@ func (e *SkipMemento) Remember(err error) {
 
 // Evaluate return a skip error with all previous skips, or nil if none happened.
 func (e *SkipMemento) Evaluate() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
 	if len(e.skips) == 0 {
 		return nil
 	}
 	return Skip(strings.Join(e.skips, ", "))
 }
+
+// IsContinueOnError returns true if the error is an ErrContinueOnError.
+func IsContinueOnError(err error) bool {
+	return errors.As(err, &ErrContinueOnError{})
+}
+
+// ErrContinueOnError occurs when a configuration with continue_on_error
+// fails, and should not prevent the other publishers from running.
+type ErrContinueOnError struct {
+	err error
+}
+
+// Error implements the error interface.
+func (e ErrContinueOnError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e ErrContinueOnError) Unwrap() error {
+	return e.err
+}
+
+// ContinueOnError marks the given error as one that should not prevent the
+// other publishers from running.
+func ContinueOnError(err error) ErrContinueOnError {
+	return ErrContinueOnError{err: err}
+}
+
+// ContinueMemento remembers the errors of configurations with continue_on_error
+// set, so they only get returned after all the other ones succeeded.
+// It is safe for concurrent use.
+type ContinueMemento struct {
+	lock sync.Mutex
+	err  error
+}
+
+// Remember the given error if cont is true and it isn't a skip, returning
+// nil. Otherwise, err is returned as is.
+func (e *ContinueMemento) Remember(cont bool, err error) error {
+	if err == nil || !cont || IsSkip(err) {
+		return err
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.err = multierror.Append(e.err, err)
+	return nil
+}
+
+// Evaluate returns an ErrContinueOnError with all previous errors, or nil if
+// none happened.
+func (e *ContinueMemento) Evaluate() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.err == nil {
+		return nil
+	}
+	return ContinueOnError(e.err)
+}
@@ -2,6 +2,7 @@ package pipe
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -32,3 +33,30 @@ func TestSkipMemento(t *testing.T) {
 func TestSkipMementoNoErrors(t *testing.T) {
 	require.NoError(t, (&SkipMemento{}).Evaluate())
 }
+
+func TestIsContinueOnError(t *testing.T) {
+	err := ContinueOnError(errors.New("fake"))
+	require.EqualError(t, err, "fake")
+	require.True(t, IsContinueOnError(err))
+	require.True(t, IsContinueOnError(fmt.Errorf("wrapped: %w", err)))
+	require.False(t, IsContinueOnError(errors.New("nope")))
+}
+
+func TestContinueMemento(t *testing.T) {
+	m := ContinueMemento{}
+	require.NoError(t, m.Remember(true, nil))
+	require.NoError(t, m.Evaluate())
+
+	hard := errors.New("hard")
+	require.ErrorIs(t, m.Remember(false, hard), hard)
+	skip := Skip("skipped")
+	require.ErrorIs(t, m.Remember(true, skip), skip)
+	require.NoError(t, m.Evaluate())
+
+	require.NoError(t, m.Remember(true, errors.New("foo")))
+	require.NoError(t, m.Remember(true, errors.New("bar")))
+	err := m.Evaluate()
+	require.True(t, IsContinueOnError(err))
+	require.ErrorContains(t, err, "foo")
+	require.ErrorContains(t, err, "bar")
+}
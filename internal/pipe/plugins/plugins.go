@@ -0,0 +1,249 @@
+// Package plugins provides a Pipe that runs plugin publishers.
+package plugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/goreleaser/goreleaser/v2/pkg/plugin"
+	"github.com/goreleaser/goreleaser/v2/pkg/plugin/proto"
+	"github.com/hashicorp/go-hclog"
+	goplugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// passthroughEnvVars are the only variables of the current environment plugins
+// get, everything else, secrets included, must be set in the plugin's env.
+var passthroughEnvVars = []string{"HOME", "USER", "USERPROFILE", "TMPDIR", "TMP", "TEMP", "PATH"}
+
+// Pipe for plugin publishers.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "plugin publishers" }
+func (Pipe) Key() string                    { return "plugins" }
+func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.Plugins) == 0 }
+
+// Publish runs each of the plugins.
+func (Pipe) Publish(ctx *context.Context) error {
+	skips := pipe.SkipMemento{}
+	conts := pipe.ContinueMemento{}
+	for _, conf := range ctx.Config.Plugins {
+		log.WithField("name", conf.Name).Debug("running plugin")
+		err := conts.Remember(conf.ContinueOnError, publish(ctx, conf))
+		if err != nil && pipe.IsSkip(err) {
+			skips.Remember(err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := conts.Evaluate(); err != nil {
+		return err
+	}
+	return skips.Evaluate()
+}
+
+func publish(ctx *context.Context, conf config.Plugin) error {
+	t := tmpl.New(ctx)
+	disabled, err := t.Bool(conf.Disable)
+	if err != nil {
+		return err
+	}
+	if disabled {
+		return pipe.Skip("plugin is disabled")
+	}
+	if conf.Cmd == "" {
+		return fmt.Errorf("plugin %s: cmd is required", conf.Name)
+	}
+
+	cmd, err := command(ctx, conf)
+	if err != nil {
+		return fmt.Errorf("plugin %s: %w", conf.Name, err)
+	}
+	req, err := request(ctx, conf)
+	if err != nil {
+		return fmt.Errorf("plugin %s: %w", conf.Name, err)
+	}
+
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig:  plugin.Handshake,
+		Plugins:          plugin.Plugins(nil),
+		Cmd:              cmd,
+		SkipHostEnv:      true,
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		SyncStdout:       logext.NewWriter(),
+		SyncStderr:       logext.NewWriter(),
+		Logger: hclog.New(&hclog.LoggerOptions{
+			Name:   conf.Name,
+			Output: logext.NewWriter(),
+			Level:  hclog.Debug,
+		}),
+	})
+	defer client.Kill()
+
+	rpc, err := client.Client()
+	if err != nil {
+		return fmt.Errorf("plugin %s: %w", conf.Name, err)
+	}
+	raw, err := rpc.Dispense(plugin.Name)
+	if err != nil {
+		return fmt.Errorf("plugin %s: %w", conf.Name, err)
+	}
+	log.WithField("name", conf.Name).
+		WithField("artifacts", len(req.GetArtifacts())).
+		Info("publishing")
+	if err := raw.(plugin.Publisher).Publish(ctx, req); err != nil {
+		return fmt.Errorf("plugin %s: %w", conf.Name, err)
+	}
+	return nil
+}
+
+func command(ctx *context.Context, conf config.Plugin) (*exec.Cmd, error) {
+	t := tmpl.New(ctx)
+	name, err := t.Apply(conf.Cmd)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := t.Apply(conf.Dir)
+	if err != nil {
+		return nil, err
+	}
+	args, err := applyAll(t, conf.Args)
+	if err != nil {
+		return nil, err
+	}
+	env, err := applyAll(t, conf.Env)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Env = []string{}
+	for _, key := range passthroughEnvVars {
+		if value := os.Getenv(key); value != "" {
+			cmd.Env = append(cmd.Env, key+"="+value)
+		}
+	}
+	cmd.Env = append(cmd.Env, env...)
+	return cmd, nil
+}
+
+func applyAll(t *tmpl.Template, in []string) ([]string, error) {
+	out := make([]string, 0, len(in))
+	for _, s := range in {
+		s, err := t.Apply(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+func request(ctx *context.Context, conf config.Plugin) (*proto.PublishRequest, error) {
+	t := tmpl.New(ctx)
+	cfg, err := templateValue(t, conf.Config)
+	if err != nil {
+		return nil, err
+	}
+	cfgStruct, err := structpb.NewStruct(cfg.(map[string]any))
+	if err != nil {
+		return nil, err
+	}
+
+	var filter artifact.Filter = func(*artifact.Artifact) bool { return true }
+	if len(conf.IDs) > 0 {
+		filter = artifact.ByIDs(conf.IDs...)
+	}
+	var artifacts []*proto.Artifact
+	for _, a := range ctx.Artifacts.Filter(filter).List() {
+		extra, err := extraStruct(a.Extra)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", a.Name, err)
+		}
+		artifacts = append(artifacts, &proto.Artifact{
+			Name:    a.Name,
+			Path:    a.Path,
+			Goos:    a.Goos,
+			Goarch:  a.Goarch,
+			Goarm:   a.Goarm,
+			Gomips:  a.Gomips,
+			Goamd64: a.Goamd64,
+			Type:    a.Type.String(),
+			Extra:   extra,
+		})
+	}
+
+	return &proto.PublishRequest{
+		Project: &proto.Project{
+			Name:        ctx.Config.ProjectName,
+			Version:     ctx.Version,
+			Tag:         ctx.Git.CurrentTag,
+			PreviousTag: ctx.Git.PreviousTag,
+			Commit:      ctx.Git.FullCommit,
+			ShortCommit: ctx.Git.ShortCommit,
+			Branch:      ctx.Git.Branch,
+			GitUrl:      ctx.Git.URL,
+			ReleaseUrl:  ctx.ReleaseURL,
+			Snapshot:    ctx.Snapshot,
+			Dist:        ctx.Config.Dist,
+		},
+		Artifacts: artifacts,
+		Config:    cfgStruct,
+	}, nil
+}
+
+// templateValue applies the templates in all the strings of the given
+// configuration value.
+func templateValue(t *tmpl.Template, v any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return t.Apply(v)
+	case []any:
+		result := make([]any, 0, len(v))
+		for _, item := range v {
+			item, err := templateValue(t, item)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, item)
+		}
+		return result, nil
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			item, err := templateValue(t, item)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			result[k] = item
+		}
+		return result, nil
+	default:
+		return v, nil
+	}
+}
+
+// extraStruct converts the artifact extras, which might contain structs, to
+// a protobuf struct using their JSON representation.
+func extraStruct(extra artifact.Extras) (*structpb.Struct, error) {
+	bts, err := json.Marshal(extra)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(bts, &m); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(m)
+}
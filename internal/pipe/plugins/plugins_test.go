@@ -0,0 +1,236 @@
+package plugins
+
+import (
+	stdctx "context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/plugin"
+	"github.com/goreleaser/goreleaser/v2/pkg/plugin/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestMain serves a fake plugin publisher when the test binary is launched
+// as a plugin.
+func TestMain(m *testing.M) {
+	if os.Getenv(plugin.Handshake.MagicCookieKey) == plugin.Handshake.MagicCookieValue {
+		plugin.Serve(fakePublisher{})
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+type fakePublisher struct{}
+
+func (fakePublisher) Publish(_ stdctx.Context, req *proto.PublishRequest) error {
+	cfg := req.GetConfig().AsMap()
+	if fail, _ := cfg["fail"].(bool); fail {
+		return errors.New("fake publish failed")
+	}
+	if env, ok := cfg["env"].(string); ok {
+		if err := os.WriteFile(env, []byte(strings.Join(os.Environ(), "\n")), 0o644); err != nil {
+			return err
+		}
+	}
+	bts, err := protojson.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cfg["output"].(string), bts, 0o644)
+}
+
+func TestDescription(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.New()))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Plugins: []config.Plugin{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestPublish(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "request.json")
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Dist:        "dist",
+		Plugins: []config.Plugin{{
+			Name: "fake",
+			Cmd:  os.Args[0],
+			IDs:  []string{"foo"},
+			Config: map[string]any{
+				"output": output,
+				"nested": map[string]any{
+					"list": []any{"{{ .ProjectName }}", 1},
+				},
+			},
+		}},
+	}, testctx.WithVersion("1.2.3"), testctx.WithCurrentTag("v1.2.3"))
+	ctx.ReleaseURL = "https://example.com/releases/v1.2.3"
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "foo.tar.gz",
+		Path:   "dist/foo.tar.gz",
+		Goos:   "linux",
+		Goarch: "amd64",
+		Type:   artifact.UploadableArchive,
+		Extra: map[string]any{
+			artifact.ExtraID:      "foo",
+			artifact.ExtraFormat:  "tar.gz",
+			artifact.ExtraRefresh: func() error { return nil },
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "bar.tar.gz",
+		Path: "dist/bar.tar.gz",
+		Type: artifact.UploadableArchive,
+		Extra: map[string]any{
+			artifact.ExtraID: "bar",
+		},
+	})
+
+	require.NoError(t, Pipe{}.Publish(ctx))
+
+	bts, err := os.ReadFile(output)
+	require.NoError(t, err)
+	var req proto.PublishRequest
+	require.NoError(t, protojson.Unmarshal(bts, &req))
+
+	require.Equal(t, "foo", req.GetProject().GetName())
+	require.Equal(t, "1.2.3", req.GetProject().GetVersion())
+	require.Equal(t, "v1.2.3", req.GetProject().GetTag())
+	require.Equal(t, "https://example.com/releases/v1.2.3", req.GetProject().GetReleaseUrl())
+	require.Equal(t, "dist", req.GetProject().GetDist())
+
+	require.Len(t, req.GetArtifacts(), 1)
+	art := req.GetArtifacts()[0]
+	require.Equal(t, "foo.tar.gz", art.GetName())
+	require.Equal(t, "Archive", art.GetType())
+	require.Equal(t, map[string]any{
+		"ID":     "foo",
+		"Format": "tar.gz",
+	}, art.GetExtra().AsMap())
+
+	require.Equal(t, map[string]any{
+		"output": output,
+		"nested": map[string]any{
+			"list": []any{"foo", float64(1)},
+		},
+	}, req.GetConfig().AsMap())
+}
+
+func TestPublishEnv(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "os-secret")
+	output := filepath.Join(t.TempDir(), "env")
+	ctx := testctx.NewWithCfg(config.Project{
+		Plugins: []config.Plugin{{
+			Name: "fake",
+			Cmd:  os.Args[0],
+			Env:  []string{"FOO={{ .Env.FOO }}"},
+			Config: map[string]any{
+				"output": filepath.Join(t.TempDir(), "request.json"),
+				"env":    output,
+			},
+		}},
+	}, testctx.WithEnv(map[string]string{
+		"FOO":          "bar",
+		"GITHUB_TOKEN": "ctx-secret",
+	}))
+
+	require.NoError(t, Pipe{}.Publish(ctx))
+
+	bts, err := os.ReadFile(output)
+	require.NoError(t, err)
+	env := strings.Split(string(bts), "\n")
+	require.Contains(t, env, "FOO=bar")
+	require.Contains(t, env, "PATH="+os.Getenv("PATH"))
+	for _, e := range env {
+		require.False(t, strings.HasPrefix(e, "GITHUB_TOKEN="), e)
+	}
+}
+
+func TestPublishError(t *testing.T) {
+	fail := config.Plugin{
+		Name:   "fake",
+		Cmd:    os.Args[0],
+		Config: map[string]any{"fail": true},
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Plugins: []config.Plugin{fail},
+		})
+		require.EqualError(t, Pipe{}.Publish(ctx), "plugin fake: fake publish failed")
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		cont := fail
+		cont.ContinueOnError = true
+		output := filepath.Join(t.TempDir(), "request.json")
+		ctx := testctx.NewWithCfg(config.Project{
+			Plugins: []config.Plugin{
+				cont,
+				{
+					Name:   "other",
+					Cmd:    os.Args[0],
+					Config: map[string]any{"output": output},
+				},
+			},
+		})
+		err := Pipe{}.Publish(ctx)
+		require.ErrorContains(t, err, "plugin fake: fake publish failed")
+		require.True(t, pipe.IsContinueOnError(err))
+		require.FileExists(t, output)
+	})
+
+	t.Run("no cmd", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Plugins: []config.Plugin{{Name: "fake"}},
+		})
+		require.EqualError(t, Pipe{}.Publish(ctx), "plugin fake: cmd is required")
+	})
+
+	t.Run("not a plugin", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Plugins: []config.Plugin{{Name: "fake", Cmd: "true"}},
+		})
+		require.ErrorContains(t, Pipe{}.Publish(ctx), "plugin fake: ")
+	})
+
+	t.Run("bad template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Plugins: []config.Plugin{{
+				Name:   "fake",
+				Cmd:    os.Args[0],
+				Config: map[string]any{"foo": "{{ .Nope }"},
+			}},
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Publish(ctx))
+	})
+}
+
+func TestPublishDisabled(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Plugins: []config.Plugin{{
+			Name:    "fake",
+			Cmd:     os.Args[0],
+			Disable: "true",
+		}},
+	})
+	testlib.AssertSkipped(t, Pipe{}.Publish(ctx))
+}
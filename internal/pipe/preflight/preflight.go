@@ -0,0 +1,40 @@
+// Package preflight provides a pipe that checks, before doing anything else,
+// that the external tools needed by the enabled pipes are installed.
+package preflight
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/goreleaser/goreleaser/v2/pkg/healthcheck"
+)
+
+// Pipe for preflight checks.
+type Pipe struct{}
+
+func (Pipe) String() string                 { return "checking needed tools" }
+func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Healthcheck }
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	results, err := healthcheck.Run(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+			continue
+		}
+		log.WithField("tool", result.Tool).
+			WithField("version", result.Version).
+			Debug("found")
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("one or more needed tools are not present: %w", errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package preflight
+
+import (
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.New()))
+	})
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.New()
+		ctx.Healthcheck = true
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := testctx.New()
+		require.NoError(t, Pipe{}.Run(ctx))
+	})
+	t.Run("missing tool", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Signs: []config.Sign{{Cmd: "this-tool-does-not-exist"}},
+		})
+		require.ErrorContains(t, Pipe{}.Run(ctx), "this-tool-does-not-exist: not present in path")
+	})
+}
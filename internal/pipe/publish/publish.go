@@ -4,9 +4,12 @@ package publish
 import (
 	"fmt"
 
+	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/errhandler"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/logging"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/loglevel"
 	"github.com/goreleaser/goreleaser/v2/internal/middleware/skip"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/artifactory"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/aur"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/blob"
@@ -14,11 +17,14 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/chocolatey"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/custompublishers"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/docker"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/git"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/ko"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/krew"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/milestone"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nix"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/plugins"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/release"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/scan"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/scoop"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sign"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/snapcraft"
@@ -31,6 +37,7 @@ import (
 // Publisher should be implemented by pipes that want to publish artifacts.
 type Publisher interface {
 	fmt.Stringer
+	skips.Skipper
 
 	// Default sets the configuration defaults
 	Publish(ctx *context.Context) error
@@ -40,17 +47,23 @@ type Publisher interface {
 func New() Pipe {
 	return Pipe{
 		pipeline: []Publisher{
+			// the tag is pushed before anything else is published
+			git.PushTagPipe{},
 			blob.Pipe{},
 			upload.Pipe{},
 			artifactory.Pipe{},
 			custompublishers.Pipe{},
+			plugins.Pipe{},
 			docker.Pipe{},
 			docker.ManifestPipe{},
 			ko.Pipe{},
+			// ko images can only be scanned once pushed
+			scan.KoPipe{},
 			sign.DockerPipe{},
 			snapcraft.Pipe{},
 			// This should be one of the last steps
 			release.Pipe{},
+			release.MirrorsPipe{},
 			// brew et al use the release URL, so, they should be last
 			nix.NewPublish(),
 			winget.Pipe{},
@@ -72,17 +85,25 @@ type Pipe struct {
 func (Pipe) String() string                 { return "publishing" }
 func (Pipe) Skip(ctx *context.Context) bool { return skips.Any(ctx, skips.Publish) }
 
+// Publishers returns the publishers run by this pipe, in order.
+func (p Pipe) Publishers() []Publisher { return p.pipeline }
+
 func (p Pipe) Run(ctx *context.Context) error {
+	defer p.cleanup(ctx)
 	memo := errhandler.Memo{}
 	for _, publisher := range p.pipeline {
-		if err := skip.Maybe(
+		if skips.Any(ctx, skips.Publish.Of(publisher.Key())) {
+			log.Debugf("skipped %s", publisher.String())
+			continue
+		}
+		if err := loglevel.Debug(publisher, skip.Maybe(
 			publisher,
 			logging.PadLog(
 				publisher.String(),
 				errhandler.Handle(publisher.Publish),
 			),
-		)(ctx); err != nil {
-			if ig, ok := publisher.(Continuable); ok && ig.ContinueOnError() && !ctx.FailFast {
+		))(ctx); err != nil {
+			if canContinue(ctx, publisher, err) {
 				memo.Memorize(fmt.Errorf("%s: %w", publisher.String(), err))
 				continue
 			}
@@ -92,6 +113,52 @@ func (p Pipe) Run(ctx *context.Context) error {
 	return memo.Error()
 }
 
+// cleanup cleans up after the publishers implementing Cleaner, regardless of
+// whether they ran or failed.
+func (p Pipe) cleanup(ctx *context.Context) {
+	for _, publisher := range p.pipeline {
+		if c, ok := publisher.(Cleaner); ok {
+			if err := c.Cleanup(ctx); err != nil {
+				log.WithError(err).Warnf("%s: failed to clean up", publisher.String())
+			}
+		}
+	}
+}
+
+// Cleaner is implemented by publishers that leave state around for the ones
+// after them, e.g. registry credentials, which should be removed once all the
+// publishers ran.
+type Cleaner interface {
+	Cleanup(ctx *context.Context) error
+}
+
 type Continuable interface {
 	ContinueOnError() bool
 }
+
+// Unrecoverable is implemented by publishers the ones after them depend on,
+// so their errors abort the publishing even with --collect-errors.
+type Unrecoverable interface {
+	Unrecoverable() bool
+}
+
+// canContinue returns true if the publishing should continue after the given
+// publisher failed with err.
+func canContinue(ctx *context.Context, publisher Publisher, err error) bool {
+	if ctx.FailFast {
+		return false
+	}
+	if ur, ok := publisher.(Unrecoverable); ok && ur.Unrecoverable() {
+		return false
+	}
+	return ctx.CollectErrors || isContinuable(publisher, err)
+}
+
+// isContinuable returns true if the publisher always continues on error, or
+// if all the configurations that failed have continue_on_error set.
+func isContinuable(publisher Publisher, err error) bool {
+	if ig, ok := publisher.(Continuable); ok && ig.ContinueOnError() {
+		return true
+	}
+	return pipe.IsContinueOnError(err)
+}
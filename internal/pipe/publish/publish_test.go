@@ -17,6 +17,15 @@ func TestDescription(t *testing.T) {
 	require.NotEmpty(t, Pipe{}.String())
 }
 
+func TestPublisherKeys(t *testing.T) {
+	seen := map[string]bool{}
+	for _, publisher := range New().Publishers() {
+		require.NotEmpty(t, publisher.Key(), publisher.String())
+		require.False(t, seen[publisher.Key()], publisher.Key())
+		seen[publisher.Key()] = true
+	}
+}
+
 func TestPublish(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Release: config.Release{Disable: "true"},
@@ -48,6 +57,15 @@ func TestPublishSuccess(t *testing.T) {
 	require.True(t, lastStep.ran)
 }
 
+func TestPublishSkipSingle(t *testing.T) {
+	ctx := testctx.New(testctx.Skip(skips.Publish.Of("test")))
+	step := &testPublisher{shouldErr: true}
+	require.NoError(t, Pipe{
+		pipeline: []Publisher{step},
+	}.Run(ctx))
+	require.False(t, step.ran)
+}
+
 func TestPublishError(t *testing.T) {
 	ctx := testctx.New()
 	lastStep := &testPublisher{}
@@ -71,6 +89,69 @@ func TestPublishError(t *testing.T) {
 	require.False(t, lastStep.ran)
 }
 
+func TestPublishContinueOnError(t *testing.T) {
+	t.Run("continue", func(t *testing.T) {
+		ctx := testctx.New()
+		lastStep := &testPublisher{}
+		err := Pipe{
+			pipeline: []Publisher{
+				&testPublisher{shouldErr: true, continueOnError: true},
+				lastStep,
+			},
+		}.Run(ctx)
+		require.EqualError(t, err, "1 error occurred:\n\t* test: errored\n\n")
+		require.True(t, lastStep.ran)
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		ctx := testctx.New()
+		ctx.FailFast = true
+		lastStep := &testPublisher{}
+		err := Pipe{
+			pipeline: []Publisher{
+				&testPublisher{shouldErr: true, continueOnError: true},
+				lastStep,
+			},
+		}.Run(ctx)
+		require.EqualError(t, err, "test: failed to publish artifacts: errored")
+		require.False(t, lastStep.ran)
+	})
+}
+
+func TestPublishCollectErrors(t *testing.T) {
+	t.Run("collect", func(t *testing.T) {
+		ctx := testctx.New()
+		ctx.CollectErrors = true
+		lastStep := &testPublisher{}
+		err := Pipe{
+			pipeline: []Publisher{
+				&testPublisher{shouldErr: true},
+				&testPublisher{shouldErr: true, continuable: true},
+				lastStep,
+			},
+		}.Run(ctx)
+		merr := &multierror.Error{}
+		require.ErrorAs(t, err, &merr)
+		require.Equal(t, 2, merr.Len())
+		require.True(t, lastStep.ran)
+	})
+
+	t.Run("unrecoverable", func(t *testing.T) {
+		ctx := testctx.New()
+		ctx.CollectErrors = true
+		lastStep := &testPublisher{}
+		err := Pipe{
+			pipeline: []Publisher{
+				&testPublisher{shouldErr: true},
+				&testPublisher{shouldErr: true, unrecoverable: true},
+				lastStep,
+			},
+		}.Run(ctx)
+		require.EqualError(t, err, "test: failed to publish artifacts: errored")
+		require.False(t, lastStep.ran)
+	})
+}
+
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
 		ctx := testctx.New(testctx.Skip(skips.Publish))
@@ -83,18 +164,25 @@ func TestSkip(t *testing.T) {
 }
 
 type testPublisher struct {
-	shouldErr   bool
-	shouldSkip  bool
-	continuable bool
-	ran         bool
+	shouldErr       bool
+	shouldSkip      bool
+	continuable     bool
+	continueOnError bool
+	unrecoverable   bool
+	ran             bool
 }
 
 func (t *testPublisher) ContinueOnError() bool { return t.continuable }
+func (t *testPublisher) Unrecoverable() bool   { return t.unrecoverable }
 func (t *testPublisher) String() string        { return "test" }
+func (t *testPublisher) Key() string           { return "test" }
 func (t *testPublisher) Publish(_ *context.Context) error {
 	if t.shouldSkip {
 		return pipe.Skip("skipped")
 	}
+	if t.shouldErr && t.continueOnError {
+		return pipe.ContinueOnError(fmt.Errorf("errored"))
+	}
 	if t.shouldErr {
 		return fmt.Errorf("errored")
 	}
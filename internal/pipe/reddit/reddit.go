@@ -6,7 +6,9 @@ import (
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/go-reddit/v3/reddit"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -17,8 +19,14 @@ const (
 
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "reddit" }
-func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Announce.Reddit.Enabled }
+func (Pipe) String() string                        { return "reddit" }
+func (Pipe) Key() string                           { return "reddit" }
+func (Pipe) Len(ctx *context.Context) int          { return len(ctx.Config.Announce.Reddit) }
+func (Pipe) Skip(ctx *context.Context, i int) bool { return !ctx.Config.Announce.Reddit[i].Enabled }
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.Reddit[i].AnnounceConditions
+}
 
 type Config struct {
 	Secret   string `env:"REDDIT_SECRET,notEmpty"`
@@ -26,30 +34,33 @@ type Config struct {
 }
 
 func (Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.Reddit.TitleTemplate == "" {
-		ctx.Config.Announce.Reddit.TitleTemplate = defaultTitleTemplate
+	for i := range ctx.Config.Announce.Reddit {
+		conf := &ctx.Config.Announce.Reddit[i]
+		if conf.TitleTemplate == "" {
+			conf.TitleTemplate = defaultTitleTemplate
+		}
+
+		if conf.URLTemplate == "" {
+			conf.URLTemplate = defaultURLTemplate
+		}
 	}
-
-	if ctx.Config.Announce.Reddit.URLTemplate == "" {
-		ctx.Config.Announce.Reddit.URLTemplate = defaultURLTemplate
-	}
-
 	return nil
 }
 
-func (Pipe) Announce(ctx *context.Context) error {
-	title, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Reddit.TitleTemplate)
+func (Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.Reddit[i]
+	title, err := tmpl.New(ctx).Apply(conf.TitleTemplate)
 	if err != nil {
 		return fmt.Errorf("reddit: %w", err)
 	}
 
-	url, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Reddit.URLTemplate)
+	url, err := tmpl.New(ctx).Apply(conf.URLTemplate)
 	if err != nil {
 		return fmt.Errorf("reddit: %w", err)
 	}
 
 	linkRequest := reddit.SubmitLinkRequest{
-		Subreddit: ctx.Config.Announce.Reddit.Sub,
+		Subreddit: conf.Sub,
 		Title:     title,
 		URL:       url,
 	}
@@ -59,8 +70,8 @@ func (Pipe) Announce(ctx *context.Context) error {
 		return fmt.Errorf("reddit: %w", err)
 	}
 
-	credentials := reddit.Credentials{ID: ctx.Config.Announce.Reddit.ApplicationID, Secret: cfg.Secret, Username: ctx.Config.Announce.Reddit.Username, Password: cfg.Password}
-	client, err := reddit.NewClient(credentials)
+	credentials := reddit.Credentials{ID: conf.ApplicationID, Secret: cfg.Secret, Username: conf.Username, Password: cfg.Password}
+	client, err := newClient(ctx, credentials)
 	if err != nil {
 		return fmt.Errorf("reddit: %w", err)
 	}
@@ -74,3 +85,12 @@ func (Pipe) Announce(ctx *context.Context) error {
 
 	return nil
 }
+
+// newClient returns a reddit client using the shared HTTP client.
+func newClient(ctx *context.Context, credentials reddit.Credentials, opts ...reddit.Opt) (*reddit.Client, error) {
+	client, err := httpclient.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return reddit.NewClient(credentials, append(opts, reddit.WithHTTPClient(client))...)
+}
@@ -1,11 +1,15 @@
 package reddit
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/caarlos0/go-reddit/v3/reddit"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,56 +18,99 @@ func TestStringer(t *testing.T) {
 }
 
 func TestDefault(t *testing.T) {
-	ctx := testctx.New()
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			Reddit: []config.Reddit{{}},
+		},
+	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.Equal(t, defaultTitleTemplate, ctx.Config.Announce.Reddit.TitleTemplate)
+	require.Equal(t, defaultTitleTemplate, ctx.Config.Announce.Reddit[0].TitleTemplate)
 }
 
 func TestAnnounceInvalidURLTemplate(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Reddit: config.Reddit{
+			Reddit: []config.Reddit{{
 				URLTemplate: "{{ .Foo }",
-			},
+			}},
 		},
 	})
-	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
 }
 
 func TestAnnounceInvalidTitleTemplate(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Reddit: config.Reddit{
+			Reddit: []config.Reddit{{
 				TitleTemplate: "{{ .Foo }",
-			},
+			}},
 		},
 	})
-	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
 }
 
 func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Reddit: config.Reddit{},
+			Reddit: []config.Reddit{{}},
 		},
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.EqualError(t, Pipe{}.Announce(ctx), `reddit: env: environment variable "REDDIT_SECRET" should not be empty; environment variable "REDDIT_PASSWORD" should not be empty`)
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `reddit: env: environment variable "REDDIT_SECRET" should not be empty; environment variable "REDDIT_PASSWORD" should not be empty`)
+}
+
+func TestNewClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "goreleaser/dev (+foo)", r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/access_token":
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"bearer","expires_in":3600}`))
+		case "/api/submit":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"json":{"data":{"url":"https://reddit.com/r/foo/1"}}}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		HTTP: config.HTTP{
+			CABundle: testlib.ServerCert(t, srv),
+		},
+	})
+	client, err := newClient(
+		ctx,
+		reddit.Credentials{ID: "id", Secret: "secret", Username: "user", Password: "pass"},
+		reddit.WithBaseURL(srv.URL),
+		reddit.WithTokenURL(srv.URL+"/api/v1/access_token"),
+	)
+	require.NoError(t, err)
+	post, _, err := client.Post.SubmitLink(ctx, reddit.SubmitLinkRequest{Subreddit: "foo", Title: "foo", URL: "https://goreleaser.com"})
+	require.NoError(t, err)
+	require.Equal(t, "https://reddit.com/r/foo/1", post.URL)
 }
 
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
-		require.True(t, Pipe{}.Skip(testctx.New()))
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Reddit: []config.Reddit{{}},
+			},
+		})
+		require.True(t, Pipe{}.Skip(ctx, 0))
 	})
 
 	t.Run("dont skip", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Reddit: config.Reddit{
+				Reddit: []config.Reddit{{
 					Enabled: true,
-				},
+				}},
 			},
 		})
-		require.False(t, Pipe{}.Skip(ctx))
+		require.False(t, Pipe{}.Skip(ctx, 0))
 	})
 }
@@ -15,6 +15,12 @@ const bodyTemplateText = `{{ with .Header }}{{ . }}{{ "\n" }}{{ end }}
 {{- with .Footer }}{{ "\n" }}{{ . }}{{ end }}
 `
 
+// Body returns the release body, as it is sent to the SCM.
+func Body(ctx *context.Context) (string, error) {
+	body, err := describeBody(ctx)
+	return body.String(), err
+}
+
 func describeBody(ctx *context.Context) (bytes.Buffer, error) {
 	var out bytes.Buffer
 	fields := tmpl.Fields{}
@@ -0,0 +1,141 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// MirrorsPipe publishes the release to the configured GitHub mirrors.
+//
+// It runs after Pipe, and unlike it, its errors don't abort the publishing.
+type MirrorsPipe struct{}
+
+func (MirrorsPipe) String() string { return "scm release mirrors" }
+func (MirrorsPipe) Key() string    { return "release-mirrors" }
+
+func (MirrorsPipe) Skip(ctx *context.Context) (bool, error) {
+	if len(ctx.Config.Release.Mirrors) == 0 {
+		return true, nil
+	}
+	return Pipe{}.Skip(ctx)
+}
+
+// ContinueOnError implements publish.Continuable.
+func (MirrorsPipe) ContinueOnError() bool { return true }
+
+// Publish the release to each mirror.
+func (MirrorsPipe) Publish(ctx *context.Context) error {
+	var errs []error
+	for _, mirror := range ctx.Config.Release.Mirrors {
+		mctx, err := mirrorContext(ctx, mirror)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		repo := mctx.Config.Release.GitHub.String()
+		cli, err := client.New(mctx)
+		if err == nil {
+			cli, err = client.NewIfToken(mctx, cli, mirror.Token)
+		}
+		if err == nil {
+			err = publishMirror(mctx, cli)
+		}
+		if err != nil && !pipe.IsSkip(err) {
+			errs = append(errs, fmt.Errorf("%s: %w", repo, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkMirrors validates the mirrors configuration.
+func checkMirrors(ctx *context.Context) error {
+	if len(ctx.Config.Release.Mirrors) == 0 {
+		return nil
+	}
+	if ctx.TokenType == context.TokenTypeGitLab || ctx.TokenType == context.TokenTypeGitea {
+		return errors.New("release mirrors are only supported on GitHub")
+	}
+	for i, mirror := range ctx.Config.Release.Mirrors {
+		if mirror.Owner == "" || mirror.Name == "" {
+			return fmt.Errorf("release mirror %d: owner and name are required", i)
+		}
+	}
+	return nil
+}
+
+// mirrorContext returns a copy of the context releasing to the given mirror.
+func mirrorContext(ctx *context.Context, mirror config.Mirror) (*context.Context, error) {
+	mctx := *ctx
+	mctx.Config.Release.GitHub = config.Repo{
+		Owner: mirror.Owner,
+		Name:  mirror.Name,
+	}
+	if err := setupGitHub(&mctx); err != nil {
+		return nil, fmt.Errorf("release mirror %s/%s: %w", mirror.Owner, mirror.Name, err)
+	}
+	return &mctx, nil
+}
+
+// publishMirror creates the release in the mirror, and uploads the same
+// artifacts uploaded to the main release.
+func publishMirror(ctx *context.Context, cli client.Client) error {
+	log.WithField("tag", ctx.Git.CurrentTag).
+		WithField("repo", ctx.Config.Release.GitHub.String()).
+		Info("releasing to mirror")
+	body, err := describeBody(ctx)
+	if err != nil {
+		return err
+	}
+	releaseID, err := cli.CreateRelease(ctx, body.String())
+	if err != nil {
+		return err
+	}
+
+	skipUpload, err := tmpl.New(ctx).Bool(ctx.Config.Release.SkipUpload)
+	if err != nil {
+		return err
+	}
+	if skipUpload {
+		err = cli.PublishRelease(ctx, releaseID)
+	} else {
+		err = uploadAndPublish(ctx, cli, releaseID)
+	}
+	if err != nil {
+		return err
+	}
+	addReleaseArtifact(ctx, ctx.Config.Release.GitHub)
+	log.WithField("url", ctx.ReleaseURL).Info("mirror release published")
+	return nil
+}
+
+// releaseRepo returns the repository being released to.
+func releaseRepo(ctx *context.Context) config.Repo {
+	switch ctx.TokenType {
+	case context.TokenTypeGitLab:
+		return ctx.Config.Release.GitLab
+	case context.TokenTypeGitea:
+		return ctx.Config.Release.Gitea
+	default:
+		return ctx.Config.Release.GitHub
+	}
+}
+
+// addReleaseArtifact records the release in the given repository, so it is
+// listed in the artifacts metadata.
+func addReleaseArtifact(ctx *context.Context, repo config.Repo) {
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.Release,
+		Name: repo.String(),
+		Extra: map[string]interface{}{
+			artifact.ExtraURL: ctx.ReleaseURL,
+		},
+	})
+}
@@ -0,0 +1,165 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirrorsPipeDescription(t *testing.T) {
+	require.NotEmpty(t, MirrorsPipe{}.String())
+	require.True(t, MirrorsPipe{}.ContinueOnError())
+}
+
+func TestMirrorsPipeSkip(t *testing.T) {
+	t.Run("no mirrors", func(t *testing.T) {
+		skip, err := MirrorsPipe{}.Skip(testctx.New())
+		require.NoError(t, err)
+		require.True(t, skip)
+	})
+
+	t.Run("release disabled", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Release: config.Release{
+				Disable: "true",
+				Mirrors: []config.Mirror{{Owner: "foo", Name: "bar"}},
+			},
+		})
+		skip, err := MirrorsPipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.True(t, skip)
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Release: config.Release{
+				Mirrors: []config.Mirror{{Owner: "foo", Name: "bar"}},
+			},
+		})
+		skip, err := MirrorsPipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.False(t, skip)
+	})
+}
+
+func TestDefaultMirrors(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Release: config.Release{
+				GitHub:  config.Repo{Owner: "foo", Name: "bar"},
+				Mirrors: []config.Mirror{{Owner: "foo-public", Name: "bar"}},
+			},
+		}, testctx.GitHubTokenType)
+		require.NoError(t, Pipe{}.Default(ctx))
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Release: config.Release{
+				GitHub:  config.Repo{Owner: "foo", Name: "bar"},
+				Mirrors: []config.Mirror{{Owner: "foo-public"}},
+			},
+		}, testctx.GitHubTokenType)
+		require.EqualError(t, Pipe{}.Default(ctx), "release mirror 0: owner and name are required")
+	})
+
+	t.Run("gitlab", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Release: config.Release{
+				GitLab:  config.Repo{Owner: "foo", Name: "bar"},
+				Mirrors: []config.Mirror{{Owner: "foo-public", Name: "bar"}},
+			},
+		}, testctx.GitLabTokenType)
+		require.EqualError(t, Pipe{}.Default(ctx), "release mirrors are only supported on GitHub")
+	})
+}
+
+func TestMirrorContext(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "bar",
+		GitHubURLs: config.GitHubURLs{
+			Download: "https://github.com",
+		},
+		Release: config.Release{
+			GitHub: config.Repo{Owner: "foo", Name: "bar"},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseURL = "https://github.com/foo/bar/releases/tag/v1.0.0"
+
+	mctx, err := mirrorContext(ctx, config.Mirror{
+		Owner: "foo-public",
+		Name:  "{{ .ProjectName }}",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "foo-public/bar", mctx.Config.Release.GitHub.String())
+	require.Equal(t, "https://github.com/foo-public/bar/releases/tag/v1.0.0", mctx.ReleaseURL)
+	require.Same(t, ctx.Artifacts, mctx.Artifacts)
+
+	// the main release is left untouched
+	require.Equal(t, "foo/bar", ctx.Config.Release.GitHub.String())
+	require.Equal(t, "https://github.com/foo/bar/releases/tag/v1.0.0", ctx.ReleaseURL)
+
+	_, err = mirrorContext(ctx, config.Mirror{
+		Owner: "foo-public",
+		Name:  "{{ .Nope }}",
+	})
+	testlib.RequireTemplateError(t, err)
+}
+
+func TestPublishMirror(t *testing.T) {
+	folder := t.TempDir()
+	tarfile := createTmpFile(t, folder, "bin.tar.gz")
+	checksumfile := createTmpFile(t, folder, "checksums.txt")
+
+	ctx := testctx.NewWithCfg(config.Project{
+		GitHubURLs: config.GitHubURLs{
+			Download: "https://github.com",
+		},
+		Release: config.Release{
+			GitHub: config.Repo{Owner: "foo", Name: "bar"},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.UploadableArchive,
+		Name: "bin.tar.gz",
+		Path: tarfile,
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.Checksum,
+		Name: "checksums.txt",
+		Path: checksumfile,
+	})
+
+	mctx, err := mirrorContext(ctx, config.Mirror{Owner: "foo-public", Name: "bar"})
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		cli := &client.Mock{}
+		require.NoError(t, publishMirror(mctx, cli))
+		require.True(t, cli.CreatedRelease)
+		require.True(t, cli.ReleasePublished)
+		require.ElementsMatch(t, []string{"bin.tar.gz", "checksums.txt"}, cli.UploadedFileNames)
+
+		releases := ctx.Artifacts.Filter(artifact.ByType(artifact.Release)).List()
+		require.Len(t, releases, 1)
+		require.Equal(t, "foo-public/bar", releases[0].Name)
+		require.Equal(t, "https://github.com/foo-public/bar/releases/tag/v1.0.0", artifact.ExtraOr(*releases[0], artifact.ExtraURL, ""))
+	})
+
+	t.Run("create release fails", func(t *testing.T) {
+		cli := &client.Mock{FailToCreateRelease: true}
+		require.EqualError(t, publishMirror(mctx, cli), "release failed")
+		require.False(t, cli.UploadedFile)
+	})
+
+	t.Run("upload fails", func(t *testing.T) {
+		cli := &client.Mock{FailToUpload: true}
+		require.Error(t, publishMirror(mctx, cli))
+		require.False(t, cli.ReleasePublished)
+	})
+}
@@ -0,0 +1,59 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// NotesPipe writes the release body to release.notes_file, inside the dist
+// folder, and adds it as an uploadable file.
+//
+// It runs right after the changelog, so the file is checksummed, signed, and
+// uploaded by the publishers like any other file, even if the release itself
+// is disabled.
+type NotesPipe struct{}
+
+func (NotesPipe) String() string { return "release notes file" }
+
+func (NotesPipe) Skip(ctx *context.Context) bool {
+	return ctx.Config.Release.NotesFile == ""
+}
+
+// Run the pipe.
+func (NotesPipe) Run(ctx *context.Context) error {
+	name, err := tmpl.New(ctx).Apply(ctx.Config.Release.NotesFile)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(ctx.Config.Dist, name)
+	if rel, err := filepath.Rel(ctx.Config.Dist, path); err != nil ||
+		filepath.IsAbs(name) ||
+		rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("release.notes_file %q must be a file within the dist directory", name)
+	}
+
+	body, err := describeBody(ctx)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	log.WithField("path", path).Info("writing release notes")
+	if err := os.WriteFile(path, body.Bytes(), 0o644); err != nil { //nolint: gosec
+		return fmt.Errorf("failed to write release notes: %w", err)
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: filepath.Base(path),
+		Path: path,
+		Type: artifact.UploadableFile,
+	})
+	return nil
+}
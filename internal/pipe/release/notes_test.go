@@ -0,0 +1,77 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotesPipeDescription(t *testing.T) {
+	require.NotEmpty(t, NotesPipe{}.String())
+}
+
+func TestNotesPipeSkip(t *testing.T) {
+	require.True(t, NotesPipe{}.Skip(testctx.New()))
+	require.False(t, NotesPipe{}.Skip(testctx.NewWithCfg(config.Project{
+		Release: config.Release{NotesFile: "notes.md"},
+	})))
+}
+
+func TestNotesPipe(t *testing.T) {
+	folder := t.TempDir()
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist: folder,
+		Release: config.Release{
+			Disable:   "true",
+			Header:    "# {{ .Tag }}",
+			Footer:    "bye",
+			NotesFile: "notes/{{ .Tag }}.md",
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	ctx.ReleaseNotes = "the changes"
+	require.NoError(t, NotesPipe{}.Run(ctx))
+
+	path := filepath.Join(folder, "notes", "v1.0.0.md")
+	bts, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "# v1.0.0\nthe changes\nbye\n", string(bts))
+
+	notes := ctx.Artifacts.Filter(artifact.ByType(artifact.UploadableFile)).List()
+	require.Len(t, notes, 1)
+	require.Equal(t, "v1.0.0.md", notes[0].Name)
+	require.Equal(t, path, notes[0].Path)
+}
+
+func TestNotesPipeOutsideDist(t *testing.T) {
+	for name, notesFile := range map[string]string{
+		"parent":   "../notes.md",
+		"nested":   "notes/../../notes.md",
+		"absolute": "/tmp/notes.md",
+		"dist":     ".",
+	} {
+		t.Run(name, func(t *testing.T) {
+			folder := t.TempDir()
+			ctx := testctx.NewWithCfg(config.Project{
+				Dist:    filepath.Join(folder, "dist"),
+				Release: config.Release{NotesFile: notesFile},
+			}, testctx.WithCurrentTag("v1.0.0"))
+			require.ErrorContains(t, NotesPipe{}.Run(ctx), "must be a file within the dist directory")
+			require.NoFileExists(t, filepath.Join(folder, "notes.md"))
+			require.Empty(t, ctx.Artifacts.List())
+		})
+	}
+}
+
+func TestNotesPipeInvalidTemplate(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist:    t.TempDir(),
+		Release: config.Release{NotesFile: "{{ .Nope }"},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	testlib.RequireTemplateError(t, NotesPipe{}.Run(ctx))
+}
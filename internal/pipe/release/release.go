@@ -27,8 +27,12 @@ var ErrMultipleReleases = errors.New("multiple releases are defined. Only one is
 type Pipe struct{}
 
 func (Pipe) String() string { return "scm releases" }
+func (Pipe) Key() string    { return "release" }
 
 func (Pipe) Skip(ctx *context.Context) (bool, error) {
+	if ctx.Nightly && !ctx.Config.Nightly.PublishRelease {
+		return true, nil
+	}
 	return tmpl.New(ctx).Bool(ctx.Config.Release.Disable)
 }
 
@@ -50,6 +54,9 @@ func (p Pipe) Default(ctx *context.Context) error {
 	if numOfReleases > 1 {
 		return ErrMultipleReleases
 	}
+	if err := checkMirrors(ctx); err != nil {
+		return err
+	}
 
 	if ctx.Config.Release.NameTemplate == "" {
 		ctx.Config.Release.NameTemplate = "{{.Tag}}"
@@ -97,6 +104,11 @@ func getRepository(ctx *context.Context) (config.Repo, error) {
 	return repo, nil
 }
 
+// Unrecoverable implements publish.Unrecoverable.
+// The publishers after this one use the release URL, so failing to create it
+// must abort the publishing.
+func (Pipe) Unrecoverable() bool { return true }
+
 // Publish the release.
 func (Pipe) Publish(ctx *context.Context) error {
 	c, err := client.New(ctx)
@@ -104,8 +116,12 @@ func (Pipe) Publish(ctx *context.Context) error {
 		return err
 	}
 	if err := doPublish(ctx, c); err != nil {
+		if pipe.IsSkip(err) {
+			addReleaseArtifact(ctx, releaseRepo(ctx))
+		}
 		return err
 	}
+	addReleaseArtifact(ctx, releaseRepo(ctx))
 	if !ctx.Config.Release.Draft {
 		log.WithField("url", ctx.ReleaseURL).
 			Info("release published")
@@ -115,7 +131,7 @@ func (Pipe) Publish(ctx *context.Context) error {
 
 func doPublish(ctx *context.Context, client client.Client) error {
 	log.WithField("tag", ctx.Git.CurrentTag).
-		WithField("repo", ctx.Config.Release.GitHub.String()).
+		WithField("repo", releaseRepo(ctx).String()).
 		Info("releasing")
 	if err := ctx.Artifacts.Refresh(); err != nil {
 		return err
@@ -156,6 +172,12 @@ func doPublish(ctx *context.Context, client client.Client) error {
 		})
 	}
 
+	return uploadAndPublish(ctx, client, releaseID)
+}
+
+// uploadAndPublish uploads the release artifacts to the given release, and
+// then publishes it.
+func uploadAndPublish(ctx *context.Context, client client.Client, releaseID string) error {
 	typeFilters := []artifact.Filter{
 		artifact.ByType(artifact.UploadableArchive),
 		artifact.ByType(artifact.UploadableBinary),
@@ -165,6 +187,9 @@ func doPublish(ctx *context.Context, client client.Client) error {
 		artifact.ByType(artifact.Signature),
 		artifact.ByType(artifact.Certificate),
 		artifact.ByType(artifact.LinuxPackage),
+		artifact.ByType(artifact.Flatpak),
+		artifact.ByType(artifact.AppImage),
+		artifact.ByType(artifact.Installer),
 		artifact.ByType(artifact.SBOM),
 	}
 	if ctx.Config.Release.IncludeMeta {
@@ -176,7 +201,7 @@ func doPublish(ctx *context.Context, client client.Client) error {
 		filters = artifact.And(filters, artifact.ByIDs(ctx.Config.Release.IDs...))
 	}
 
-	g := semerrgroup.New(ctx.Parallelism)
+	g := semerrgroup.NewShared(ctx)
 	for _, artifact := range ctx.Artifacts.Filter(filters).List() {
 		g.Go(func() error {
 			return upload(ctx, client, releaseID, artifact)
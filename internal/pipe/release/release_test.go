@@ -36,6 +36,8 @@ func TestRunPipeWithoutIDsThenDoesNotFilter(t *testing.T) {
 	checksumfile := createTmpFile(t, folder, "checksum")
 	checksumsigfile := createTmpFile(t, folder, "checksum.sig")
 	checksumpemfile := createTmpFile(t, folder, "checksum.pem")
+	sbomfile := createTmpFile(t, folder, "bin.tar.gz.sbom.json")
+	sbomsigfile := createTmpFile(t, folder, "bin.tar.gz.sbom.json.sig")
 	filteredtarfile := createTmpFile(t, folder, "filtered.tar.gz")
 	filtereddebfile := createTmpFile(t, folder, "filtered.deb")
 
@@ -124,6 +126,22 @@ func TestRunPipeWithoutIDsThenDoesNotFilter(t *testing.T) {
 			artifact.ExtraID: "bar",
 		},
 	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.SBOM,
+		Name: "bin.tar.gz.sbom.json",
+		Path: sbomfile,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "default",
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.Signature,
+		Name: "bin.tar.gz.sbom.json.sig",
+		Path: sbomsigfile,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "default",
+		},
+	})
 	client := &client.Mock{}
 	require.NoError(t, doPublish(ctx, client))
 	require.True(t, client.CreatedRelease)
@@ -138,6 +156,8 @@ func TestRunPipeWithoutIDsThenDoesNotFilter(t *testing.T) {
 	require.Contains(t, client.UploadedFileNames, "checksum")
 	require.Contains(t, client.UploadedFileNames, "checksum.pem")
 	require.Contains(t, client.UploadedFileNames, "checksum.sig")
+	require.Contains(t, client.UploadedFileNames, "bin.tar.gz.sbom.json")
+	require.Contains(t, client.UploadedFileNames, "bin.tar.gz.sbom.json.sig")
 }
 
 func TestRunPipeWithIDsThenFilters(t *testing.T) {
@@ -213,6 +233,56 @@ func TestRunPipeWithIDsThenFilters(t *testing.T) {
 	require.NotContains(t, client.UploadedFileNames, "filtered.tar.gz")
 }
 
+func TestRunPipeWithIDsKeepsSharedFiles(t *testing.T) {
+	folder := t.TempDir()
+	for _, name := range []string{"bin.tar.gz", "filtered.tar.gz", "source.tar.gz", "checksums.txt", "metadata.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(folder, name), []byte("fake"), 0o644))
+	}
+
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist: folder,
+		Release: config.Release{
+			GitHub: config.Repo{
+				Owner: "test",
+				Name:  "test",
+			},
+			IDs:         []string{"foo"},
+			IncludeMeta: true,
+			ExtraFiles: []config.ExtraFile{
+				{Glob: "./testdata/f1.txt"},
+			},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	for _, a := range []struct {
+		name, id string
+		typ      artifact.Type
+	}{
+		{"bin.tar.gz", "foo", artifact.UploadableArchive},
+		{"filtered.tar.gz", "bar", artifact.UploadableArchive},
+		{"source.tar.gz", "", artifact.UploadableSourceArchive},
+		{"checksums.txt", "", artifact.Checksum},
+		{"metadata.json", "", artifact.Metadata},
+	} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Type: a.typ,
+			Name: a.name,
+			Path: filepath.Join(folder, a.name),
+			Extra: map[string]interface{}{
+				artifact.ExtraID: a.id,
+			},
+		})
+	}
+	client := &client.Mock{}
+	require.NoError(t, doPublish(ctx, client))
+	require.ElementsMatch(t, []string{
+		"bin.tar.gz",
+		"source.tar.gz",
+		"checksums.txt",
+		"metadata.json",
+		"f1.txt",
+	}, client.UploadedFileNames)
+}
+
 func TestRunPipeReleaseCreationFailed(t *testing.T) {
 	config := config.Project{
 		Release: config.Release{
@@ -658,6 +728,24 @@ func TestSkip(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("nightly", func(t *testing.T) {
+		ctx := testctx.New(testctx.Nightly)
+		b, err := Pipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.True(t, b)
+	})
+
+	t.Run("nightly publish release", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Nightly: config.Nightly{
+				PublishRelease: true,
+			},
+		}, testctx.Nightly)
+		b, err := Pipe{}.Skip(ctx)
+		require.NoError(t, err)
+		require.False(t, b)
+	})
+
 	t.Run("skip upload", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Env: []string{"FOO=true"},
@@ -3,6 +3,8 @@ package release
 import (
 	"fmt"
 
+	"github.com/goreleaser/goreleaser/v2/internal/client"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
@@ -77,6 +79,11 @@ func setupGitea(ctx *context.Context) error {
 		return err
 	}
 
+	// fail early if the client certificates or the proxy are invalid.
+	if _, err := httpclient.Transport(ctx, client.GiteaHTTPOptions(ctx)); err != nil {
+		return fmt.Errorf("gitea_urls: %w", err)
+	}
+
 	url, err := tmpl.New(ctx).Apply(fmt.Sprintf(
 		"%s/%s/%s/releases/tag/%s",
 		ctx.Config.GiteaURLs.Download,
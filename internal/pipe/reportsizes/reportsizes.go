@@ -21,8 +21,12 @@ func (Pipe) Run(ctx *context.Context) error {
 		artifact.ByType(artifact.UploadableArchive),
 		artifact.ByType(artifact.PublishableSnapcraft),
 		artifact.ByType(artifact.LinuxPackage),
+		artifact.ByType(artifact.Flatpak),
+		artifact.ByType(artifact.AppImage),
+		artifact.ByType(artifact.Installer),
 		artifact.ByType(artifact.CArchive),
 		artifact.ByType(artifact.CShared),
+		artifact.ByType(artifact.Plugin),
 		artifact.ByType(artifact.Header),
 	)).Visit(func(a *artifact.Artifact) error {
 		stat, err := os.Stat(a.Path)
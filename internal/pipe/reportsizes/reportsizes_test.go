@@ -36,6 +36,7 @@ func TestRun(t *testing.T) {
 		artifact.LinuxPackage,
 		artifact.CArchive,
 		artifact.CShared,
+		artifact.Plugin,
 		artifact.Header,
 	} {
 		if i%2 == 0 {
@@ -92,7 +92,7 @@ func setConfigDefaults(cfg *config.SBOM) error {
 
 // Run executes the Pipe.
 func (Pipe) Run(ctx *context.Context) error {
-	g := semerrgroup.New(ctx.Parallelism)
+	g := semerrgroup.NewShared(ctx)
 	for _, cfg := range ctx.Config.SBOMs {
 		g.Go(catalogTask(ctx, cfg))
 	}
@@ -207,8 +207,8 @@ func catalogArtifact(ctx *context.Context, cfg config.SBOM, a *artifact.Artifact
 
 	var b bytes.Buffer
 	w := gio.Safe(&b)
-	cmd.Stderr = io.MultiWriter(logext.NewWriter(), w)
-	cmd.Stdout = io.MultiWriter(logext.NewWriter(), w)
+	cmd.Stderr = io.MultiWriter(logext.NewWriter(logext.WithMaxLineLength(logext.DefaultMaxLineLength)), w)
+	cmd.Stdout = io.MultiWriter(logext.NewWriter(logext.WithMaxLineLength(logext.DefaultMaxLineLength)), w)
 
 	log.WithField("cmd", cfg.Cmd).
 		WithField("artifact", artifactDisplayName).
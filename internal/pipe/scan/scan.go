@@ -0,0 +1,288 @@
+// Package scan implements the Pipe interface scanning the built docker images,
+// and the Publisher interface scanning the pushed ko images, for
+// vulnerabilities, using trivy or grype.
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	scannerTrivy = "trivy"
+	scannerGrype = "grype"
+)
+
+// severities ordered from the lowest to the highest.
+var severities = []string{"unknown", "negligible", "low", "medium", "high", "critical"}
+
+// cmd runs the scanners.
+var cmd shell.Runner = shell.StdRunner{}
+
+// Pipe for docker image scans.
+type Pipe struct{}
+
+func (Pipe) String() string { return "scanning docker images" }
+func (Pipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.Scan) || len(ctx.Config.DockerScans) == 0
+}
+
+// Dependencies implements healthcheck.Healthchecker.
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	var cmds []string
+	for _, scan := range ctx.Config.DockerScans {
+		if !slices.Contains(cmds, scan.Scanner) {
+			cmds = append(cmds, scan.Scanner)
+		}
+	}
+	return cmds
+}
+
+// Default sets the pipe defaults.
+func (Pipe) Default(ctx *context.Context) error {
+	ids := ids.New("docker_scans")
+	for i := range ctx.Config.DockerScans {
+		scan := &ctx.Config.DockerScans[i]
+		if scan.ID == "" {
+			scan.ID = "default"
+		}
+		if scan.Scanner == "" {
+			scan.Scanner = scannerTrivy
+		}
+		if scan.Severity == "" {
+			scan.Severity = "critical"
+		}
+		scan.Severity = strings.ToLower(scan.Severity)
+		if scan.Scanner != scannerTrivy && scan.Scanner != scannerGrype {
+			return fmt.Errorf("docker_scans: invalid scanner: %s, valid options are [%s %s]", scan.Scanner, scannerGrype, scannerTrivy)
+		}
+		if severity(scan.Severity) <= severity("negligible") {
+			return fmt.Errorf("docker_scans: invalid severity: %s, valid options are [low medium high critical]", scan.Severity)
+		}
+		ids.Inc(scan.ID)
+	}
+	return ids.Validate()
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	return runAll(ctx, artifact.ByType(artifact.PublishableDockerImage))
+}
+
+// KoPipe scans the images built and pushed by ko.
+//
+// ko builds and pushes its images in a single step, so they can only be
+// scanned once pushed, before they are signed and released.
+type KoPipe struct{}
+
+func (KoPipe) String() string { return "scanning ko images" }
+func (KoPipe) Key() string    { return "ko-scan" }
+func (KoPipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.Scan, skips.Ko) ||
+		len(ctx.Config.DockerScans) == 0 ||
+		len(ctx.Config.Kos) == 0
+}
+
+// Publish scans the ko images.
+func (KoPipe) Publish(ctx *context.Context) error {
+	var kos []string
+	for _, ko := range ctx.Config.Kos {
+		kos = append(kos, ko.ID)
+	}
+	return runAll(ctx, artifact.And(
+		artifact.ByType(artifact.DockerManifest),
+		artifact.ByIDs(kos...),
+	))
+}
+
+func runAll(ctx *context.Context, filter artifact.Filter) error {
+	skips := pipe.SkipMemento{}
+	for _, scan := range ctx.Config.DockerScans {
+		if err := doRun(ctx, scan, filter); err != nil {
+			if pipe.IsSkip(err) {
+				skips.Remember(err)
+				continue
+			}
+			return err
+		}
+	}
+	return skips.Evaluate()
+}
+
+func doRun(ctx *context.Context, scan config.DockerScan, filter artifact.Filter) error {
+	disable, err := tmpl.New(ctx).Apply(scan.Disable)
+	if err != nil {
+		return err
+	}
+	if disable == "true" {
+		return pipe.Skip("configuration is disabled")
+	}
+
+	filters := []artifact.Filter{filter}
+	if len(scan.IDs) > 0 {
+		filters = append(filters, artifact.ByIDs(scan.IDs...))
+	}
+	images := ctx.Artifacts.Filter(artifact.And(filters...)).List()
+	if len(images) == 0 {
+		return pipe.Skip("no docker images to scan")
+	}
+
+	dir := filepath.Join(ctx.Config.Dist, "scans")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	// scanners keep a local vulnerability database which can't be used
+	// concurrently, so images are scanned one at a time.
+	for _, image := range images {
+		if err := scanImage(ctx, scan, dir, image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanImage(ctx *context.Context, scan config.DockerScan, dir string, image *artifact.Artifact) error {
+	name := strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(image.Name) + "." + scan.Scanner + ".json"
+	report := filepath.Join(dir, name)
+
+	var args []string
+	switch scan.Scanner {
+	case scannerGrype:
+		args = []string{image.Name, "--quiet", "--output", "json", "--file", report}
+	default:
+		args = []string{"image", "--quiet", "--format", "json", "--output", report, image.Name}
+	}
+
+	log.WithField("image", image.Name).WithField("scanner", scan.Scanner).Info("scanning")
+	if out, err := cmd.Exec(ctx, shell.Cmd{
+		Name: scan.Scanner,
+		Args: args,
+		Env:  append(os.Environ(), ctx.Env.Strings()...),
+	}); err != nil {
+		return fmt.Errorf("failed to scan %s: %w: %s", image.Name, err, string(out))
+	}
+
+	bts, err := os.ReadFile(report)
+	if err != nil {
+		return fmt.Errorf("failed to read scan report: %w", err)
+	}
+	vulns, err := parse(scan.Scanner, bts)
+	if err != nil {
+		return fmt.Errorf("failed to parse scan report %s: %w", report, err)
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:   artifact.ScanReport,
+		Name:   name,
+		Path:   report,
+		Goos:   image.Goos,
+		Goarch: image.Goarch,
+		Goarm:  image.Goarm,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:     scan.ID,
+			artifact.ExtraFormat: scan.Scanner,
+		},
+	})
+
+	var found []string
+	for _, vuln := range vulns {
+		if slices.Contains(scan.Ignore, vuln.ID) {
+			continue
+		}
+		if severity(vuln.Severity) >= severity(scan.Severity) {
+			found = append(found, fmt.Sprintf("%s (%s, %s)", vuln.ID, vuln.Package, strings.ToLower(vuln.Severity)))
+		}
+	}
+	log.WithField("image", image.Name).
+		WithField("vulnerabilities", len(vulns)).
+		WithField("report", report).
+		Debug("scanned")
+	if len(found) > 0 {
+		return fmt.Errorf(
+			"found %d vulnerabilities with severity %s or higher in %s: %s",
+			len(found),
+			scan.Severity,
+			image.Name,
+			strings.Join(found, ", "),
+		)
+	}
+	return nil
+}
+
+// severity returns how severe the given severity is, the higher the worse.
+func severity(s string) int {
+	return max(slices.Index(severities, strings.ToLower(s)), 0)
+}
+
+type vulnerability struct {
+	ID       string
+	Package  string
+	Severity string
+}
+
+// parse parses the JSON report of the given scanner.
+func parse(scanner string, bts []byte) ([]vulnerability, error) {
+	var vulns []vulnerability
+	switch scanner {
+	case scannerGrype:
+		var report struct {
+			Matches []struct {
+				Vulnerability struct {
+					ID       string `json:"id"`
+					Severity string `json:"severity"`
+				} `json:"vulnerability"`
+				Artifact struct {
+					Name string `json:"name"`
+				} `json:"artifact"`
+			} `json:"matches"`
+		}
+		if err := json.Unmarshal(bts, &report); err != nil {
+			return nil, err
+		}
+		for _, match := range report.Matches {
+			vulns = append(vulns, vulnerability{
+				ID:       match.Vulnerability.ID,
+				Package:  match.Artifact.Name,
+				Severity: match.Vulnerability.Severity,
+			})
+		}
+	default:
+		var report struct {
+			Results []struct {
+				Vulnerabilities []struct {
+					VulnerabilityID string `json:"VulnerabilityID"`
+					PkgName         string `json:"PkgName"`
+					Severity        string `json:"Severity"`
+				} `json:"Vulnerabilities"`
+			} `json:"Results"`
+		}
+		if err := json.Unmarshal(bts, &report); err != nil {
+			return nil, err
+		}
+		for _, result := range report.Results {
+			for _, vuln := range result.Vulnerabilities {
+				vulns = append(vulns, vulnerability{
+					ID:       vuln.VulnerabilityID,
+					Package:  vuln.PkgName,
+					Severity: vuln.Severity,
+				})
+			}
+		}
+	}
+	return vulns, nil
+}
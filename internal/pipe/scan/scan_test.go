@@ -0,0 +1,357 @@
+package scan
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescription(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func TestSkip(t *testing.T) {
+	t.Run("skip", func(t *testing.T) {
+		require.True(t, Pipe{}.Skip(testctx.New()))
+	})
+
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{}},
+		}, testctx.Skip(skips.Scan))
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{}},
+		})
+		require.False(t, Pipe{}.Skip(ctx))
+	})
+}
+
+func TestDependencies(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		DockerScans: []config.DockerScan{{}, {ID: "a"}, {ID: "b", Scanner: "grype"}},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Equal(t, []string{"trivy", "grype"}, Pipe{}.Dependencies(ctx))
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, config.DockerScan{
+			ID:       "default",
+			Scanner:  "trivy",
+			Severity: "critical",
+		}, ctx.Config.DockerScans[0])
+	})
+
+	t.Run("severity case", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{Severity: "HIGH"}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, "high", ctx.Config.DockerScans[0].Severity)
+	})
+
+	t.Run("duplicate id", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{ID: "foo"}, {ID: "foo"}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "found 2 docker_scans with the ID 'foo', please fix your config")
+	})
+
+	t.Run("invalid scanner", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{Scanner: "nope"}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "docker_scans: invalid scanner: nope, valid options are [grype trivy]")
+	})
+
+	t.Run("invalid severity", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{Severity: "negligible"}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), "docker_scans: invalid severity: negligible, valid options are [low medium high critical]")
+	})
+}
+
+func TestRun(t *testing.T) {
+	for name, tt := range map[string]struct {
+		scan config.DockerScan
+		err  string
+	}{
+		"trivy critical": {
+			scan: config.DockerScan{},
+			err:  "found 1 vulnerabilities with severity critical or higher in owner/img:v1.0.0: CVE-2024-0001 (libcrypto3, critical)",
+		},
+		"trivy medium": {
+			scan: config.DockerScan{Severity: "medium"},
+			err:  "found 2 vulnerabilities with severity medium or higher in owner/img:v1.0.0: CVE-2024-0001 (libcrypto3, critical), CVE-2024-0002 (busybox, medium)",
+		},
+		"trivy ignored": {
+			scan: config.DockerScan{Ignore: []string{"CVE-2024-0001"}},
+		},
+		"grype high": {
+			scan: config.DockerScan{Scanner: "grype", Severity: "high", Ignore: []string{"CVE-2024-0001"}},
+			err:  "found 1 vulnerabilities with severity high or higher in owner/img:v1.0.0: GHSA-xxxx-yyyy-zzzz (golang.org/x/net, high)",
+		},
+		"grype ignored": {
+			scan: config.DockerScan{Scanner: "grype", Ignore: []string{"CVE-2024-0001"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			calls := fakeScanner(t, nil)
+			folder := t.TempDir()
+			ctx := testctx.NewWithCfg(config.Project{
+				Dist:        folder,
+				DockerScans: []config.DockerScan{tt.scan},
+			})
+			addImages(ctx)
+			require.NoError(t, Pipe{}.Default(ctx))
+			err := Pipe{}.Run(ctx)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.err)
+			}
+
+			scanner := ctx.Config.DockerScans[0].Scanner
+			report := filepath.Join(folder, "scans", "owner_img_v1.0.0."+scanner+".json")
+			require.FileExists(t, report)
+			reports := ctx.Artifacts.Filter(artifact.ByType(artifact.ScanReport)).List()
+			require.NotEmpty(t, reports)
+			require.Equal(t, report, reports[0].Path)
+			require.Equal(t, scanner, artifact.ExtraOr(*reports[0], artifact.ExtraFormat, ""))
+
+			switch scanner {
+			case "grype":
+				require.Equal(t, "grype owner/img:v1.0.0 --quiet --output json --file "+report, calls.Lines()[0])
+			default:
+				require.Equal(t, "trivy image --quiet --format json --output "+report+" owner/img:v1.0.0", calls.Lines()[0])
+			}
+		})
+	}
+}
+
+func TestRunIDs(t *testing.T) {
+	calls := fakeScanner(t, nil)
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist: t.TempDir(),
+		DockerScans: []config.DockerScan{{
+			IDs:    []string{"other"},
+			Ignore: []string{"CVE-2024-0001"},
+		}},
+	})
+	addImages(ctx)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Len(t, calls.Cmds(), 1)
+	require.Contains(t, calls.Lines()[0], "owner/other:v1.0.0")
+}
+
+func TestRunEnv(t *testing.T) {
+	t.Setenv("TRIVY_USERNAME", "from-os")
+	calls := fakeScanner(t, nil)
+
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist: t.TempDir(),
+		DockerScans: []config.DockerScan{{
+			Ignore: []string{"CVE-2024-0001"},
+		}},
+	}, testctx.WithEnv(map[string]string{"TRIVY_USERNAME": "from-config"}))
+	addImages(ctx)
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, Pipe{}.Run(ctx))
+	env := calls.Cmds()[0].Env
+
+	// the registry credentials in the environment of the context take
+	// precedence over the ones of the process.
+	require.Less(t, slices.Index(env, "TRIVY_USERNAME=from-os"), slices.Index(env, "TRIVY_USERNAME=from-config"))
+	require.NotEqual(t, -1, slices.Index(env, "TRIVY_USERNAME=from-os"))
+}
+
+func TestRunSkipped(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{Disable: "{{ .Env.NOPE }}"}},
+		}, testctx.WithEnv(map[string]string{"NOPE": "true"}))
+		addImages(ctx)
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+	})
+
+	t.Run("no images", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.AssertSkipped(t, Pipe{}.Run(ctx))
+	})
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Run("bad disable", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{Disable: "{{ .Nope }"}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+
+	t.Run("scanner failed", func(t *testing.T) {
+		fakeScanner(t, errors.New("fake error"))
+		ctx := testctx.NewWithCfg(config.Project{
+			Dist:        t.TempDir(),
+			DockerScans: []config.DockerScan{{}},
+		})
+		addImages(ctx)
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.EqualError(t, Pipe{}.Run(ctx), "failed to scan owner/img:v1.0.0: fake error: some output")
+	})
+
+	t.Run("invalid report", func(t *testing.T) {
+		_, restore := shell.Mock(&cmd, func(c shell.Cmd) ([]byte, error) {
+			return nil, os.WriteFile(c.Args[slices.Index(c.Args, "--output")+1], []byte("nope"), 0o644)
+		})
+		t.Cleanup(restore)
+		ctx := testctx.NewWithCfg(config.Project{
+			Dist:        t.TempDir(),
+			DockerScans: []config.DockerScan{{}},
+		})
+		addImages(ctx)
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.ErrorContains(t, Pipe{}.Run(ctx), "failed to parse scan report")
+	})
+}
+
+func TestKoSkip(t *testing.T) {
+	t.Run("no scans", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Kos: []config.Ko{{}},
+		})
+		require.True(t, KoPipe{}.Skip(ctx))
+	})
+
+	t.Run("no kos", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{}},
+		})
+		require.True(t, KoPipe{}.Skip(ctx))
+	})
+
+	t.Run("skip flag", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{}},
+			Kos:         []config.Ko{{}},
+		}, testctx.Skip(skips.Scan))
+		require.True(t, KoPipe{}.Skip(ctx))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			DockerScans: []config.DockerScan{{}},
+			Kos:         []config.Ko{{}},
+		})
+		require.False(t, KoPipe{}.Skip(ctx))
+	})
+}
+
+func TestKoPublish(t *testing.T) {
+	calls := fakeScanner(t, nil)
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist:        t.TempDir(),
+		DockerScans: []config.DockerScan{{}},
+		Kos:         []config.Ko{{ID: "ko"}},
+	})
+	addImages(ctx)
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "owner/ko:v1.0.0",
+		Path: "owner/ko:v1.0.0",
+		Type: artifact.DockerManifest,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "ko",
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "owner/manifest:v1.0.0",
+		Path: "owner/manifest:v1.0.0",
+		Type: artifact.DockerManifest,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "manifest",
+		},
+	})
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.EqualError(t, KoPipe{}.Publish(ctx), "found 1 vulnerabilities with severity critical or higher in owner/ko:v1.0.0: CVE-2024-0001 (libcrypto3, critical)")
+	require.Len(t, calls.Cmds(), 1)
+	require.Contains(t, calls.Lines()[0], "owner/ko:v1.0.0")
+}
+
+func TestSeverity(t *testing.T) {
+	require.Equal(t, 0, severity("whatever"))
+	require.Equal(t, 0, severity("UNKNOWN"))
+	require.Less(t, severity("Low"), severity("MEDIUM"))
+	require.Less(t, severity("high"), severity("Critical"))
+}
+
+// fakeScanner fakes the scanners, writing the report from testdata.
+func fakeScanner(tb testing.TB, err error) *shell.MockRunner {
+	tb.Helper()
+	mock, restore := shell.Mock(&cmd, func(c shell.Cmd) ([]byte, error) {
+		if err != nil {
+			return []byte("some output"), err
+		}
+		flag := "--output"
+		if c.Name == "grype" {
+			flag = "--file"
+		}
+		bts, err := os.ReadFile(filepath.Join("testdata", c.Name+".json"))
+		if err != nil {
+			return nil, err
+		}
+		return nil, os.WriteFile(c.Args[slices.Index(c.Args, flag)+1], bts, 0o644)
+	})
+	tb.Cleanup(restore)
+	return mock
+}
+
+func addImages(ctx *context.Context) {
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "owner/img:v1.0.0",
+		Path:   "owner/img:v1.0.0",
+		Goos:   "linux",
+		Goarch: "amd64",
+		Type:   artifact.PublishableDockerImage,
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name:   "owner/other:v1.0.0",
+		Path:   "owner/other:v1.0.0",
+		Goos:   "linux",
+		Goarch: "amd64",
+		Type:   artifact.PublishableDockerImage,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "other",
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "owner/img:v1.0.0",
+		Path: "owner/img:v1.0.0",
+		Type: artifact.DockerImage,
+	})
+}
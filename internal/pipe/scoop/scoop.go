@@ -59,6 +59,7 @@ const scoopConfigExtra = "ScoopConfig"
 type Pipe struct{}
 
 func (Pipe) String() string        { return "scoop manifests" }
+func (Pipe) Key() string           { return "scoop" }
 func (Pipe) ContinueOnError() bool { return true }
 func (Pipe) Skip(ctx *context.Context) bool {
 	return skips.Any(ctx, skips.Scoop) || len(ctx.Config.Scoops) == 0
@@ -111,24 +112,7 @@ func runAll(ctx *context.Context, cl client.ReleaseURLTemplater) error {
 }
 
 func doRun(ctx *context.Context, scoop config.Scoop, cl client.ReleaseURLTemplater) error {
-	filters := []artifact.Filter{
-		artifact.ByGoos("windows"),
-		artifact.ByType(artifact.UploadableArchive),
-		artifact.Or(
-			artifact.And(
-				artifact.ByGoarch("amd64"),
-				artifact.ByGoamd64(scoop.Goamd64),
-			),
-			artifact.ByGoarch("arm64"),
-			artifact.ByGoarch("386"),
-		),
-	}
-
-	if len(scoop.IDs) > 0 {
-		filters = append(filters, artifact.ByIDs(scoop.IDs...))
-	}
-
-	filtered := ctx.Artifacts.Filter(artifact.And(filters...))
+	filtered := ctx.Artifacts.Filter(archivesFilter(scoop))
 	archives := filtered.List()
 	for _, platArchives := range filtered.GroupByPlatform() {
 		// there might be multiple archives, but only of for each platform
@@ -188,6 +172,30 @@ func doRun(ctx *context.Context, scoop config.Scoop, cl client.ReleaseURLTemplat
 	return nil
 }
 
+// archivesFilter filters the archives and binaries a manifest installs.
+func archivesFilter(scoop config.Scoop) artifact.Filter {
+	filters := []artifact.Filter{
+		artifact.ByGoos("windows"),
+		artifact.Or(
+			artifact.ByType(artifact.UploadableArchive),
+			artifact.ByType(artifact.UploadableBinary),
+		),
+		artifact.Or(
+			artifact.And(
+				artifact.ByGoarch("amd64"),
+				artifact.ByGoamd64(scoop.Goamd64),
+			),
+			artifact.ByGoarch("arm64"),
+			artifact.ByGoarch("386"),
+		),
+	}
+
+	if len(scoop.IDs) > 0 {
+		filters = append(filters, artifact.ByIDs(scoop.IDs...))
+	}
+	return artifact.And(filters...)
+}
+
 func publishAll(ctx *context.Context, cli client.Client) error {
 	// even if one of them skips, we run them all, and then show return the
 	// skips all at once. this is needed so we actually create the
@@ -219,7 +227,14 @@ func doPublish(ctx *context.Context, manifest *artifact.Artifact, cl client.Clie
 		return pipe.Skip("release is prerelease")
 	}
 
-	commitMessage, err := tmpl.New(ctx).Apply(scoop.CommitMessageTemplate)
+	sums, err := artifact.Checksums("sha256", ctx.Artifacts.Filter(archivesFilter(scoop)).List())
+	if err != nil {
+		return err
+	}
+
+	commitMessage, err := tmpl.New(ctx).WithExtraFields(tmpl.Fields{
+		"Checksums": sums,
+	}).Apply(scoop.CommitMessageTemplate)
 	if err != nil {
 		return err
 	}
@@ -276,7 +291,14 @@ func doPublish(ctx *context.Context, manifest *artifact.Artifact, cl client.Clie
 		return fmt.Errorf("client does not support pull requests")
 	}
 
-	return pcl.OpenPullRequest(ctx, base, repo, commitMessage, scoop.Repository.PullRequest.Draft)
+	url, err := pcl.OpenPullRequest(ctx, base, repo, commitMessage, scoop.Repository.PullRequest.Draft, scoop.Repository.PullRequest.AutoMerge)
+	if err != nil {
+		return err
+	}
+	if url != "" {
+		manifest.Extra[artifact.ExtraPullRequest] = url
+	}
+	return nil
 }
 
 // Manifest represents a scoop.sh App Manifest.
@@ -333,13 +355,13 @@ func dataFor(ctx *context.Context, scoop config.Scoop, cl client.ReleaseURLTempl
 		scoop.URLTemplate = url
 	}
 
-	for _, artifact := range artifacts {
-		if artifact.Goos != "windows" {
+	for _, art := range artifacts {
+		if art.Goos != "windows" {
 			continue
 		}
 
 		var arch string
-		switch artifact.Goarch {
+		switch art.Goarch {
 		case "386":
 			arch = "32bit"
 		case "amd64":
@@ -350,27 +372,32 @@ func dataFor(ctx *context.Context, scoop config.Scoop, cl client.ReleaseURLTempl
 			continue
 		}
 
-		url, err := tmpl.New(ctx).WithArtifact(artifact).Apply(scoop.URLTemplate)
+		url, err := tmpl.New(ctx).WithArtifact(art).Apply(scoop.URLTemplate)
 		if err != nil {
 			return manifest, err
 		}
 
-		sum, err := artifact.Checksum("sha256")
+		sum, err := art.Checksum("sha256")
 		if err != nil {
 			return manifest, err
 		}
 
 		log.
-			WithField("artifactExtras", artifact.Extra).
+			WithField("artifactExtras", art.Extra).
 			WithField("fromURLTemplate", scoop.URLTemplate).
 			WithField("templatedBrewURL", url).
 			WithField("sum", sum).
 			Debug("scoop url templating")
 
-		binaries, err := binaries(*artifact)
+		binaries, err := binaries(*art)
 		if err != nil {
 			return manifest, err
 		}
+		if art.Type == artifact.UploadableBinary {
+			// raw binaries are uploaded with the artifact name, so we tell
+			// scoop to rename them to the binary name once downloaded.
+			url += "#/" + binaries[0]
+		}
 
 		manifest.Architecture[arch] = Resource{
 			URL:  url,
@@ -383,6 +410,10 @@ func dataFor(ctx *context.Context, scoop config.Scoop, cl client.ReleaseURLTempl
 }
 
 func binaries(a artifact.Artifact) ([]string, error) {
+	if a.Type == artifact.UploadableBinary {
+		return []string{artifact.ExtraOr(a, artifact.ExtraBinary, a.Name)}, nil
+	}
+
 	//nolint:prealloc
 	var result []string
 	wrap := artifact.ExtraOr(a, artifact.ExtraWrappedIn, "")
@@ -783,10 +783,12 @@ func TestRunPipePullRequest(t *testing.T) {
 	require.NoError(t, f.Close())
 
 	client := client.NewMock()
+	client.PullRequestURL = "https://github.com/foo/bar/pull/1"
 	require.NoError(t, runAll(ctx, client))
 	require.NoError(t, publishAll(ctx, client))
 	require.True(t, client.CreatedFile)
 	require.True(t, client.OpenedPullRequest)
+	require.Equal(t, "https://github.com/foo/bar/pull/1", artifact.ExtraOr(*ctx.Artifacts.Filter(artifact.ByType(artifact.ScoopManifest)).List()[0], artifact.ExtraPullRequest, ""))
 	require.True(t, client.SyncedFork)
 	golden.RequireEqualJSON(t, []byte(client.Content))
 }
@@ -1061,6 +1063,30 @@ func TestRunPipeScoopWithSkipUpload(t *testing.T) {
 	require.NoError(t, err, "file should exist: "+distFile)
 }
 
+func TestRunPipeScoopCommitMessage(t *testing.T) {
+	directory := t.TempDir()
+	ctx, path := getScoopPipeSkipCtx(directory)
+	ctx.Config.Scoops[0].CommitMessageTemplate = "update {{ .Tag }}\n\n{{ .ReleaseNotes }}\n\n{{ .Checksums }}"
+	ctx.ReleaseNotes = "## Changelog\n* fix bar"
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cli := client.NewMock()
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.NoError(t, runAll(ctx, cli))
+	require.NoError(t, publishAll(ctx, cli))
+	require.Equal(t, []string{
+		"update v1.0.1\n\n## Changelog\n* fix bar\n\ne3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  bin.tar.gz",
+	}, cli.Messages)
+
+	manifests := ctx.Artifacts.Filter(artifact.ByType(artifact.ScoopManifest)).List()
+	require.Len(t, manifests, 1)
+	require.Len(t, manifests[0].Extra, 1)
+	require.Contains(t, manifests[0].Extra, scoopConfigExtra)
+}
+
 func TestWrapInDirectory(t *testing.T) {
 	directory := t.TempDir()
 	file := filepath.Join(directory, "archive")
@@ -1115,6 +1141,64 @@ func TestWrapInDirectory(t *testing.T) {
 	golden.RequireEqualJSON(t, out.Bytes())
 }
 
+func TestRawBinary(t *testing.T) {
+	directory := t.TempDir()
+	file := filepath.Join(directory, "binary")
+	require.NoError(t, os.WriteFile(file, []byte("lorem ipsum"), 0o644))
+
+	ctx := testctx.NewWithCfg(
+		config.Project{
+			GitHubURLs: config.GitHubURLs{
+				Download: "https://github.com",
+			},
+			Dist:        directory,
+			ProjectName: "run-pipe",
+			Release: config.Release{
+				GitHub: config.Repo{
+					Owner: "test",
+					Name:  "test",
+				},
+			},
+			Scoops: []config.Scoop{{
+				Repository: config.RepoRef{
+					Owner: "test",
+					Name:  "test",
+				},
+				Description: "A run pipe test formula",
+				Homepage:    "https://github.com/goreleaser",
+			}},
+		},
+		testctx.GitHubTokenType,
+		testctx.WithCurrentTag("v1.0.1"),
+		testctx.WithVersion("1.0.1"),
+	)
+
+	for _, arch := range []string{"amd64", "arm64"} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:    "foo_windows_" + arch + ".exe",
+			Goos:    "windows",
+			Goarch:  arch,
+			Goamd64: "v1",
+			Path:    file,
+			Type:    artifact.UploadableBinary,
+			Extra: map[string]interface{}{
+				artifact.ExtraID:     "default",
+				artifact.ExtraFormat: "binary",
+				artifact.ExtraBinary: "foo.exe",
+				artifact.ExtraExt:    ".exe",
+			},
+		})
+	}
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	cl := client.NewMock()
+	require.NoError(t, runAll(ctx, cl))
+
+	manifests := ctx.Artifacts.Filter(artifact.ByType(artifact.ScoopManifest)).List()
+	require.Len(t, manifests, 1)
+	golden.RequireEqualJSON(t, golden.RequireReadFile(t, manifests[0].Path))
+}
+
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
 		require.True(t, Pipe{}.Skip(testctx.New()))
@@ -2,6 +2,7 @@ package semver
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -17,9 +18,10 @@ func (Pipe) String() string {
 
 // Run executes the hooks.
 func (Pipe) Run(ctx *context.Context) error {
-	sv, err := semver.NewVersion(ctx.Git.CurrentTag)
+	tag := strings.TrimPrefix(ctx.Git.CurrentTag, ctx.Config.Monorepo.TagPrefix)
+	sv, err := semver.NewVersion(tag)
 	if err != nil {
-		return fmt.Errorf("failed to parse tag '%s' as semver: %w", ctx.Git.CurrentTag, err)
+		return fmt.Errorf("failed to parse tag '%s' as semver: %w", tag, err)
 	}
 	ctx.Semver = context.Semver{
 		Major:      sv.Major(),
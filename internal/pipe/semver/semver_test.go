@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/stretchr/testify/require"
 )
@@ -23,6 +24,18 @@ func TestValidSemver(t *testing.T) {
 	}, ctx.Semver)
 }
 
+func TestMonorepoSemver(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Monorepo: config.Monorepo{TagPrefix: "service-a/"},
+	}, testctx.WithCurrentTag("service-a/v1.2.3"))
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Equal(t, context.Semver{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}, ctx.Semver)
+}
+
 func TestInvalidSemver(t *testing.T) {
 	ctx := testctx.New(testctx.WithCurrentTag("aaaav1.5.2-rc1"))
 	err := Pipe{}.Run(ctx)
@@ -14,6 +14,7 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/gio"
 	"github.com/goreleaser/goreleaser/v2/internal/git"
 	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/ifempty"
 	"github.com/goreleaser/goreleaser/v2/internal/logext"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe"
 	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
@@ -40,7 +41,12 @@ func (Pipe) Dependencies(ctx *context.Context) []string {
 	return cmds
 }
 
-const defaultGpg = "gpg"
+const (
+	defaultGpg = "gpg"
+
+	// phaseBuild signs the binaries before they are archived.
+	phaseBuild = "build"
+)
 
 // Default sets the Pipes defaults.
 func (Pipe) Default(ctx *context.Context) error {
@@ -62,9 +68,24 @@ func (Pipe) Default(ctx *context.Context) error {
 		if len(cfg.Args) == 0 {
 			cfg.Args = []string{"--output", "$signature", "--detach-sig", "$artifact"}
 		}
+		switch cfg.Phase {
+		case "":
+		case phaseBuild:
+			if cfg.Artifacts == "" {
+				cfg.Artifacts = "binary"
+			}
+			if cfg.Artifacts != "binary" {
+				return fmt.Errorf("signs with phase %q can only sign binaries, got artifacts %q", phaseBuild, cfg.Artifacts)
+			}
+		default:
+			return fmt.Errorf("invalid sign phase %q, valid options are %q", cfg.Phase, []string{phaseBuild})
+		}
 		if cfg.Artifacts == "" {
 			cfg.Artifacts = "none"
 		}
+		if err := ifempty.Validate(cfg.IfEmpty); err != nil {
+			return fmt.Errorf("signs: %s: %w", cfg.ID, err)
+		}
 		if cfg.ID == "" {
 			cfg.ID = "default"
 		}
@@ -75,9 +96,13 @@ func (Pipe) Default(ctx *context.Context) error {
 
 // Run executes the Pipe.
 func (Pipe) Run(ctx *context.Context) error {
-	g := semerrgroup.New(ctx.Parallelism)
+	g := semerrgroup.NewSkipAware(semerrgroup.NewShared(ctx))
 	for i := range ctx.Config.Signs {
 		cfg := ctx.Config.Signs[i]
+		if cfg.Phase == phaseBuild {
+			// already signed by the BinaryPipe.
+			continue
+		}
 		g.Go(func() error {
 			var filters []artifact.Filter
 			switch cfg.Artifacts {
@@ -98,6 +123,9 @@ func (Pipe) Run(ctx *context.Context) error {
 					artifact.ByType(artifact.UploadableSourceArchive),
 					artifact.ByType(artifact.Checksum),
 					artifact.ByType(artifact.LinuxPackage),
+					artifact.ByType(artifact.AppImage),
+					artifact.ByType(artifact.Flatpak),
+					artifact.ByType(artifact.Installer),
 					artifact.ByType(artifact.SBOM),
 				))
 			case "archive":
@@ -107,7 +135,13 @@ func (Pipe) Run(ctx *context.Context) error {
 			case "sbom":
 				filters = append(filters, artifact.ByType(artifact.SBOM))
 			case "package":
-				filters = append(filters, artifact.ByType(artifact.LinuxPackage))
+				filters = append(filters, artifact.Or(
+					artifact.ByType(artifact.LinuxPackage),
+					artifact.ByType(artifact.AppImage),
+					artifact.ByType(artifact.Flatpak),
+				))
+			case "installer":
+				filters = append(filters, artifact.ByType(artifact.Installer))
 			case "none": // TODO(caarlos0): this is not very useful, lets remove it.
 				return pipe.ErrSkipSignEnabled
 			default:
@@ -117,20 +151,29 @@ func (Pipe) Run(ctx *context.Context) error {
 			if len(cfg.IDs) > 0 {
 				filters = append(filters, artifact.ByIDs(cfg.IDs...))
 			}
+			if cfg.Filter != "" {
+				filter, err := artifact.ByExpression(cfg.Filter)
+				if err != nil {
+					return fmt.Errorf("sign failed: %w", err)
+				}
+				filters = append(filters, filter)
+			}
 			return sign(ctx, cfg, ctx.Artifacts.Filter(artifact.And(filters...)).List())
 		})
 	}
-	if err := g.Wait(); err != nil {
+	err := g.Wait()
+	if err != nil && !pipe.IsSkip(err) {
 		return err
 	}
-
-	return ctx.Artifacts.Refresh()
+	if err := ctx.Artifacts.Refresh(); err != nil {
+		return err
+	}
+	return err
 }
 
 func sign(ctx *context.Context, cfg config.Sign, artifacts []*artifact.Artifact) error {
 	if len(artifacts) == 0 {
-		log.Warn("no artifacts matching the given filters found")
-		return nil
+		return ifempty.Handle(cfg.IfEmpty, "signs", cfg.ID)
 	}
 	for _, a := range artifacts {
 		if err := a.Refresh(); err != nil {
@@ -214,6 +257,7 @@ func signone(ctx *context.Context, cfg config.Sign, art *artifact.Artifact) ([]*
 		if err != nil {
 			return nil, err
 		}
+		logext.AddSecrets(s)
 		stdin = strings.NewReader(s)
 	} else if cfg.StdinFile != "" {
 		f, err := os.Open(cfg.StdinFile)
@@ -248,7 +292,7 @@ func signone(ctx *context.Context, cfg config.Sign, art *artifact.Artifact) ([]*
 	cmd.Env = env.Strings()
 	log.Info("signing")
 	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("sign: %s failed: %w: %s", cfg.Cmd, err, b.String())
+		return nil, fmt.Errorf("sign: %s failed: %w: %s", cfg.Cmd, err, logext.Redact(b.String()))
 	}
 
 	var result []*artifact.Artifact
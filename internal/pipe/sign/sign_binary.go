@@ -0,0 +1,86 @@
+package sign
+
+import (
+	"fmt"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/ifempty"
+	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
+	"github.com/goreleaser/goreleaser/v2/internal/skips"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// BinaryPipe signs the binaries before they are archived, so the signatures
+// can be added to the archives.
+//
+// Only the signs with `phase: build` are handled here, the others are handled
+// by Pipe, after the archives are created.
+type BinaryPipe struct{}
+
+func (BinaryPipe) String() string { return "signing binaries" }
+
+func (BinaryPipe) Skip(ctx *context.Context) bool {
+	return skips.Any(ctx, skips.Sign) || len(buildPhaseSigns(ctx)) == 0
+}
+
+// Run executes the Pipe.
+func (BinaryPipe) Run(ctx *context.Context) error {
+	g := semerrgroup.NewSkipAware(semerrgroup.NewShared(ctx))
+	for _, cfg := range buildPhaseSigns(ctx) {
+		g.Go(func() error {
+			filters := []artifact.Filter{artifact.Or(
+				artifact.ByType(artifact.Binary),
+				artifact.ByType(artifact.UniversalBinary),
+			)}
+			if len(cfg.IDs) > 0 {
+				filters = append(filters, artifact.ByIDs(cfg.IDs...))
+			}
+			if cfg.Filter != "" {
+				filter, err := artifact.ByExpression(cfg.Filter)
+				if err != nil {
+					return fmt.Errorf("sign failed: %w", err)
+				}
+				filters = append(filters, filter)
+			}
+			return signBinaries(ctx, cfg, ctx.Artifacts.Filter(artifact.And(filters...)).List())
+		})
+	}
+	return g.Wait()
+}
+
+func buildPhaseSigns(ctx *context.Context) []config.Sign {
+	var result []config.Sign
+	for _, cfg := range ctx.Config.Signs {
+		if cfg.Phase == phaseBuild {
+			result = append(result, cfg)
+		}
+	}
+	return result
+}
+
+// signBinaries signs the given binaries, adding the signatures and
+// certificates as BinarySignature artifacts, with the same platform as the
+// binary they belong to.
+func signBinaries(ctx *context.Context, cfg config.Sign, binaries []*artifact.Artifact) error {
+	if len(binaries) == 0 {
+		return ifempty.Handle(cfg.IfEmpty, "signs", cfg.ID)
+	}
+	for _, bin := range binaries {
+		artifacts, err := signone(ctx, cfg, bin)
+		if err != nil {
+			return err
+		}
+		for _, art := range artifacts {
+			art.Type = artifact.BinarySignature
+			art.Goos = bin.Goos
+			art.Goarch = bin.Goarch
+			art.Goarm = bin.Goarm
+			art.Gomips = bin.Gomips
+			art.Goamd64 = bin.Goamd64
+			art.Extra[artifact.ExtraSignatureOf] = bin.Path
+			ctx.Artifacts.Add(art)
+		}
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 type DockerPipe struct{}
 
 func (DockerPipe) String() string { return "signing docker images" }
+func (DockerPipe) Key() string    { return "docker-sign" }
 
 func (DockerPipe) Skip(ctx *context.Context) bool {
 	return skips.Any(ctx, skips.Sign) || len(ctx.Config.DockerSigns) == 0
@@ -28,6 +29,11 @@ func (DockerPipe) Dependencies(ctx *context.Context) []string {
 	return cmds
 }
 
+// MinVersions requires cosign v2, as the default arguments use `--yes`.
+func (DockerPipe) MinVersions(_ *context.Context) map[string]string {
+	return map[string]string{"cosign": "2.0.0"}
+}
+
 // Default sets the Pipes defaults.
 func (DockerPipe) Default(ctx *context.Context) error {
 	ids := ids.New("docker_signs")
@@ -52,7 +58,7 @@ func (DockerPipe) Default(ctx *context.Context) error {
 
 // Publish signs and pushes the docker images signatures.
 func (DockerPipe) Publish(ctx *context.Context) error {
-	g := semerrgroup.New(ctx.Parallelism)
+	g := semerrgroup.NewShared(ctx)
 	for i := range ctx.Config.DockerSigns {
 		cfg := ctx.Config.DockerSigns[i]
 		g.Go(func() error {
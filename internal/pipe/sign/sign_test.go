@@ -16,6 +16,8 @@ import (
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/git"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/sbom"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
@@ -199,8 +201,8 @@ func TestSignArtifacts(t *testing.T) {
 					},
 				},
 			}),
-			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
-			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
+			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
+			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
 		},
 		{
 			desc: "sign archives",
@@ -223,8 +225,8 @@ func TestSignArtifacts(t *testing.T) {
 					},
 				},
 			}),
-			signaturePaths: []string{"package1.deb.sig"},
-			signatureNames: []string{"package1.deb.sig"},
+			signaturePaths: []string{"package1.deb.sig", "package2.flatpak.sig"},
+			signatureNames: []string{"package1.deb.sig", "package2.flatpak.sig"},
 		},
 		{
 			desc: "sign binaries",
@@ -279,8 +281,33 @@ func TestSignArtifacts(t *testing.T) {
 					},
 				},
 			}),
-			signaturePaths: []string{"artifact1.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "artifact5.tar.gz.sig", "package1.deb.sig"},
-			signatureNames: []string{"artifact1.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact5.tar.gz.sig", "package1.deb.sig"},
+			signaturePaths: []string{"artifact1.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "artifact5.tar.gz.sig", "package1.deb.sig", "package2.flatpak.sig"},
+			signatureNames: []string{"artifact1.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact5.tar.gz.sig", "package1.deb.sig", "package2.flatpak.sig"},
+		},
+		{
+			desc: "sign artifacts filtered by expression",
+			ctx: testctx.NewWithCfg(config.Project{
+				Signs: []config.Sign{
+					{
+						Artifacts: "all",
+						Filter:    `type == "Binary" || (id == "foo" && type == "Archive")`,
+					},
+				},
+			}),
+			signaturePaths: []string{"artifact1.sig", "artifact3.sig", "linux_amd64/artifact4.sig"},
+			signatureNames: []string{"artifact1.sig", "artifact3_1.0.0_linux_amd64.sig", "artifact4_1.0.0_linux_amd64.sig"},
+		},
+		{
+			desc: "invalid filter expression",
+			ctx: testctx.NewWithCfg(config.Project{
+				Signs: []config.Sign{
+					{
+						Artifacts: "all",
+						Filter:    `os == "linux"`,
+					},
+				},
+			}),
+			expectedErrMsg: `invalid filter "os == \"linux\"": unknown field "os"`,
 		},
 		{
 			desc: "sign only checksums",
@@ -364,8 +391,8 @@ func TestSignArtifacts(t *testing.T) {
 					fmt.Sprintf("TEST_USER=%s", user),
 				},
 			}),
-			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
-			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
+			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
+			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
 		},
 		{
 			desc: "sign all artifacts with template",
@@ -387,8 +414,8 @@ func TestSignArtifacts(t *testing.T) {
 					fmt.Sprintf("SOME_TEST_USER=%s", user),
 				},
 			}),
-			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
-			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
+			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
+			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
 		},
 		{
 			desc: "sign single with password from stdin",
@@ -413,8 +440,8 @@ func TestSignArtifacts(t *testing.T) {
 					},
 				},
 			}),
-			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
-			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
+			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
+			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
 			user:           passwordUser,
 		},
 		{
@@ -441,8 +468,8 @@ func TestSignArtifacts(t *testing.T) {
 					},
 				},
 			}),
-			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
-			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
+			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
+			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
 			user:           passwordUser,
 		},
 		{
@@ -468,8 +495,8 @@ func TestSignArtifacts(t *testing.T) {
 					},
 				},
 			}),
-			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
-			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
+			signaturePaths: []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
+			signatureNames: []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
 			user:           passwordUser,
 		},
 		{
@@ -516,9 +543,9 @@ func TestSignArtifacts(t *testing.T) {
 					},
 				},
 			}),
-			signaturePaths:   []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
-			signatureNames:   []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig"},
-			certificateNames: []string{"artifact1_honk.pem", "artifact2_honk.pem", "artifact3_1.0.0_linux_amd64_honk.pem", "checksum_honk.pem", "checksum2_honk.pem", "artifact4_1.0.0_linux_amd64_honk.pem", "artifact5_honk.pem", "artifact5.tar.gz.sbom_honk.pem", "package1_honk.pem"},
+			signaturePaths:   []string{"artifact1.sig", "artifact2.sig", "artifact3.sig", "checksum.sig", "checksum2.sig", "linux_amd64/artifact4.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
+			signatureNames:   []string{"artifact1.sig", "artifact2.sig", "artifact3_1.0.0_linux_amd64.sig", "checksum.sig", "checksum2.sig", "artifact4_1.0.0_linux_amd64.sig", "artifact5.tar.gz.sig", "artifact5.tar.gz.sbom.sig", "package1.deb.sig", "package2.flatpak.sig"},
+			certificateNames: []string{"artifact1_honk.pem", "artifact2_honk.pem", "artifact3_1.0.0_linux_amd64_honk.pem", "checksum_honk.pem", "checksum2_honk.pem", "artifact4_1.0.0_linux_amd64_honk.pem", "artifact5_honk.pem", "artifact5.tar.gz.sbom_honk.pem", "package1_honk.pem", "package2.flatpak_honk.pem"},
 		},
 	}
 
@@ -557,7 +584,7 @@ func testSign(
 	ctx.Config.Dist = tmpdir
 
 	// create some fake artifacts
-	artifacts := []string{"artifact1", "artifact2", "artifact3", "checksum", "checksum2", "package1.deb"}
+	artifacts := []string{"artifact1", "artifact2", "artifact3", "checksum", "checksum2", "package1.deb", "package2.flatpak"}
 	require.NoError(tb, os.Mkdir(filepath.Join(tmpdir, "linux_amd64"), os.ModePerm))
 	for _, f := range artifacts {
 		file := filepath.Join(tmpdir, f)
@@ -635,6 +662,14 @@ func testSign(
 			artifact.ExtraID: "foo",
 		},
 	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "package2.flatpak",
+		Path: filepath.Join(tmpdir, "package2.flatpak"),
+		Type: artifact.Flatpak,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "foo",
+		},
+	})
 
 	// configure the pipeline
 	// make sure we are using the test keyring
@@ -741,6 +776,50 @@ func verifySignature(tb testing.TB, ctx *context.Context, sig string, user strin
 	}
 }
 
+func TestSignSBOMs(t *testing.T) {
+	folder := t.TempDir()
+	archive := filepath.Join(folder, "foo_linux_amd64.tar.gz")
+	require.NoError(t, os.WriteFile(archive, []byte("foo"), 0o644))
+
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist: folder,
+		SBOMs: []config.SBOM{
+			{
+				Cmd:  "cp",
+				Args: []string{"$artifact", "$document"},
+			},
+		},
+		Signs: []config.Sign{
+			{
+				Artifacts: "sbom",
+			},
+		},
+	})
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo_linux_amd64.tar.gz",
+		Path: archive,
+		Type: artifact.UploadableArchive,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "foo",
+		},
+	})
+
+	require.NoError(t, sbom.Pipe{}.Default(ctx))
+	require.NoError(t, sbom.Pipe{}.Run(ctx))
+	require.Len(t, ctx.Artifacts.Filter(artifact.ByType(artifact.SBOM)).List(), 1)
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	ctx.Config.Signs[0].Args = append([]string{"--homedir", keyring}, ctx.Config.Signs[0].Args...)
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	sigs := ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List()
+	require.Len(t, sigs, 1)
+	require.Equal(t, "foo_linux_amd64.tar.gz.sbom.json.sig", sigs[0].Name)
+	require.Equal(t, filepath.Join(folder, "foo_linux_amd64.tar.gz.sbom.json.sig"), sigs[0].Path)
+	require.Equal(t, "default", sigs[0].ID())
+	verifySignature(t, ctx, sigs[0].Name, user)
+}
+
 func TestSeveralSignsWithTheSameID(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Signs: []config.Sign{
@@ -790,3 +869,164 @@ func setGpg(tb testing.TB, ctx *context.Context, p string) {
 	_, err := git.Run(ctx, "config", "--local", "--add", "gpg.program", p)
 	require.NoError(tb, err)
 }
+
+func TestSignDefaultPhase(t *testing.T) {
+	t.Run("build", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Signs: []config.Sign{{Phase: "build"}},
+		})
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, "binary", ctx.Config.Signs[0].Artifacts)
+	})
+
+	t.Run("build with other artifacts", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Signs: []config.Sign{{Phase: "build", Artifacts: "archive"}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), `signs with phase "build" can only sign binaries, got artifacts "archive"`)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Signs: []config.Sign{{Phase: "publish"}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), `invalid sign phase "publish", valid options are ["build"]`)
+	})
+}
+
+func TestSignBinaries(t *testing.T) {
+	folder := t.TempDir()
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist: folder,
+		Signs: []config.Sign{
+			{
+				Phase: "build",
+				Cmd:   "cp",
+				Args:  []string{"$artifact", "$signature"},
+				IDs:   []string{"foo"},
+			},
+		},
+	})
+	for _, goos := range []string{"linux", "darwin"} {
+		path := filepath.Join(folder, goos, "mybin")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("bin"), 0o755))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:   "mybin",
+			Path:   path,
+			Goos:   goos,
+			Goarch: "amd64",
+			Type:   artifact.Binary,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: "foo",
+			},
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "other",
+		Path: filepath.Join(folder, "other"),
+		Goos: "linux",
+		Type: artifact.Binary,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "bar",
+		},
+	})
+
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.False(t, BinaryPipe{}.Skip(ctx))
+	require.NoError(t, BinaryPipe{}.Run(ctx))
+
+	sigs := ctx.Artifacts.Filter(artifact.ByType(artifact.BinarySignature)).List()
+	require.Len(t, sigs, 2)
+	for _, sig := range sigs {
+		require.Equal(t, "mybin.sig", sig.Name)
+		require.Equal(t, filepath.Join(folder, sig.Goos, "mybin.sig"), sig.Path)
+		require.Equal(t, filepath.Join(folder, sig.Goos, "mybin"), artifact.ExtraOr(*sig, artifact.ExtraSignatureOf, ""))
+		require.Equal(t, "amd64", sig.Goarch)
+		require.FileExists(t, sig.Path)
+	}
+
+	// the release phase ignores the signs that already ran
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Signature)).List())
+}
+
+func TestBinaryPipeSkip(t *testing.T) {
+	t.Run("no signs", func(t *testing.T) {
+		require.True(t, BinaryPipe{}.Skip(testctx.New()))
+	})
+
+	t.Run("only release phase", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Signs: []config.Sign{{}},
+		})
+		require.True(t, BinaryPipe{}.Skip(ctx))
+	})
+
+	t.Run("skip sign", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Signs: []config.Sign{{Phase: "build"}},
+		}, testctx.Skip(skips.Sign))
+		require.True(t, BinaryPipe{}.Skip(ctx))
+	})
+
+	t.Run("dont skip", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Signs: []config.Sign{{Phase: "build"}},
+		})
+		require.False(t, BinaryPipe{}.Skip(ctx))
+	})
+}
+
+func TestSignIfEmpty(t *testing.T) {
+	makeCtx := func(ifEmpty string) *context.Context {
+		ctx := testctx.NewWithCfg(config.Project{
+			Signs: []config.Sign{
+				{
+					ID:        "nothing",
+					Artifacts: "archive",
+					IDs:       []string{"nope"},
+					IfEmpty:   ifEmpty,
+				},
+			},
+		})
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: "foo.tar.gz",
+			Path: "foo.tar.gz",
+			Type: artifact.UploadableArchive,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: "foo",
+			},
+		})
+		return ctx
+	}
+
+	t.Run("warn", func(t *testing.T) {
+		require.NoError(t, Pipe{}.Run(makeCtx("")))
+		require.NoError(t, Pipe{}.Run(makeCtx("warn")))
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		err := Pipe{}.Run(makeCtx("skip"))
+		require.True(t, pipe.IsSkip(err))
+		require.EqualError(t, err, "signs nothing: no artifacts matched the given filters")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := Pipe{}.Run(makeCtx("error"))
+		require.False(t, pipe.IsSkip(err))
+		require.EqualError(t, err, "signs nothing: no artifacts matched the given filters")
+	})
+
+	t.Run("build phase", func(t *testing.T) {
+		ctx := makeCtx("error")
+		ctx.Config.Signs[0].Phase = "build"
+		ctx.Config.Signs[0].Artifacts = "binary"
+		require.EqualError(t, BinaryPipe{}.Run(ctx), "signs nothing: no artifacts matched the given filters")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx := makeCtx("nope")
+		require.EqualError(t, Pipe{}.Default(ctx), `signs: nothing: invalid if_empty "nope", valid options are ["warn" "skip" "error"]`)
+	})
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/slack-go/slack"
 )
@@ -19,25 +21,38 @@ const (
 
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "slack" }
-func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Announce.Slack.Enabled }
+func (Pipe) String() string                        { return "slack" }
+func (Pipe) Key() string                           { return "slack" }
+func (Pipe) Len(ctx *context.Context) int          { return len(ctx.Config.Announce.Slack) }
+func (Pipe) Skip(ctx *context.Context, i int) bool { return !ctx.Config.Announce.Slack[i].Enabled }
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.Slack[i].AnnounceConditions
+}
 
 type Config struct {
 	Webhook string `env:"SLACK_WEBHOOK,notEmpty"`
 }
 
 func (Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.Slack.MessageTemplate == "" {
-		ctx.Config.Announce.Slack.MessageTemplate = defaultMessageTemplate
-	}
-	if ctx.Config.Announce.Slack.Username == "" {
-		ctx.Config.Announce.Slack.Username = defaultUsername
+	for i := range ctx.Config.Announce.Slack {
+		conf := &ctx.Config.Announce.Slack[i]
+		if conf.MessageTemplate == "" && conf.MessageTemplateFile == "" {
+			conf.MessageTemplate = defaultMessageTemplate
+		}
+		if conf.Username == "" {
+			conf.Username = defaultUsername
+		}
 	}
 	return nil
 }
 
-func (Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Slack.MessageTemplate)
+func (Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.Slack[i]
+	msg, err := tmpl.New(ctx).ApplyInlineOrFile(
+		conf.MessageTemplate,
+		conf.MessageTemplateFile,
+	)
 	if err != nil {
 		return fmt.Errorf("slack: %w", err)
 	}
@@ -50,16 +65,16 @@ func (Pipe) Announce(ctx *context.Context) error {
 	log.Infof("posting: '%s'", msg)
 
 	// optional processing of advanced formatting options
-	blocks, attachments, err := parseAdvancedFormatting(ctx)
+	blocks, attachments, err := parseAdvancedFormatting(ctx, conf)
 	if err != nil {
 		return err
 	}
 
 	wm := &slack.WebhookMessage{
-		Username:  ctx.Config.Announce.Slack.Username,
-		IconEmoji: ctx.Config.Announce.Slack.IconEmoji,
-		IconURL:   ctx.Config.Announce.Slack.IconURL,
-		Channel:   ctx.Config.Announce.Slack.Channel,
+		Username:  conf.Username,
+		IconEmoji: conf.IconEmoji,
+		IconURL:   conf.IconURL,
+		Channel:   conf.Channel,
 		Text:      msg,
 
 		// optional enrichments
@@ -67,7 +82,11 @@ func (Pipe) Announce(ctx *context.Context) error {
 		Attachments: attachments,
 	}
 
-	err = slack.PostWebhook(cfg.Webhook, wm)
+	client, err := httpclient.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("slack: %w", err)
+	}
+	err = slack.PostWebhookCustomHTTPContext(ctx, cfg.Webhook, client, wm)
 	if err != nil {
 		return fmt.Errorf("slack: %w", err)
 	}
@@ -75,9 +94,9 @@ func (Pipe) Announce(ctx *context.Context) error {
 	return nil
 }
 
-func parseAdvancedFormatting(ctx *context.Context) (*slack.Blocks, []slack.Attachment, error) {
+func parseAdvancedFormatting(ctx *context.Context, conf config.Slack) (*slack.Blocks, []slack.Attachment, error) {
 	var blocks *slack.Blocks
-	if in := ctx.Config.Announce.Slack.Blocks; len(in) > 0 {
+	if in := conf.Blocks; len(in) > 0 {
 		blocks = &slack.Blocks{BlockSet: make([]slack.Block, 0, len(in))}
 
 		if err := unmarshal(ctx, in, blocks); err != nil {
@@ -86,7 +105,7 @@ func parseAdvancedFormatting(ctx *context.Context) (*slack.Blocks, []slack.Attac
 	}
 
 	var attachments []slack.Attachment
-	if in := ctx.Config.Announce.Slack.Attachments; len(in) > 0 {
+	if in := conf.Attachments; len(in) > 0 {
 		attachments = make([]slack.Attachment, 0, len(in))
 
 		if err := unmarshal(ctx, in, &attachments); err != nil {
@@ -3,6 +3,10 @@ package slack
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
@@ -19,20 +23,52 @@ func TestStringer(t *testing.T) {
 }
 
 func TestDefault(t *testing.T) {
-	ctx := testctx.New()
+	ctx := testctx.NewWithCfg(config.Project{
+		Announce: config.Announce{
+			Slack: []config.Slack{{}, {MessageTemplate: "foo"}},
+		},
+	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Slack.MessageTemplate)
+	require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Slack[0].MessageTemplate)
+	require.Equal(t, "foo", ctx.Config.Announce.Slack[1].MessageTemplate)
+	require.Equal(t, defaultUsername, ctx.Config.Announce.Slack[1].Username)
 }
 
 func TestAnnounceInvalidTemplate(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Slack: config.Slack{
+			Slack: []config.Slack{{
 				MessageTemplate: "{{ .Foo }",
-			},
+			}},
 		},
 	})
-	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
+}
+
+func TestAnnounceTemplateFile(t *testing.T) {
+	var msg slack.WebhookMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "goreleaser/dev (+foo)", r.Header.Get("User-Agent"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("SLACK_WEBHOOK", srv.URL)
+
+	path := filepath.Join(t.TempDir(), "announce.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte("{{ .ProjectName }} {{ .Tag }} from a file"), 0o644))
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		Announce: config.Announce{
+			Slack: []config.Slack{{
+				MessageTemplateFile: path,
+			}},
+		},
+	}, testctx.WithCurrentTag("v1.0.0"))
+	require.NoError(t, Pipe{}.Default(ctx))
+	require.Empty(t, ctx.Config.Announce.Slack[0].MessageTemplate)
+	require.NoError(t, Pipe{}.Announce(ctx, 0))
+	require.Equal(t, "foo v1.0.0 from a file", msg.Text)
 }
 
 func TestAnnounceWithQuotes(t *testing.T) {
@@ -42,19 +78,19 @@ func TestAnnounceWithQuotes(t *testing.T) {
 	t.Run("with a plain message", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Slack: config.Slack{
+				Slack: []config.Slack{{
 					MessageTemplate: "{{ envOrDefault \"USER\" \"\" }}",
-				},
+				}},
 			},
 		})
-		require.NoError(t, Pipe{}.Announce(ctx))
+		require.NoError(t, Pipe{}.Announce(ctx, 0))
 	})
 
 	t.Run("with rich text", func(t *testing.T) {
 		var project config.Project
 		require.NoError(t, yaml.Unmarshal(goodRichSlackConfWithEnv(), &project))
 		ctx := testctx.NewWithCfg(project)
-		blocks, attachments, err := parseAdvancedFormatting(ctx)
+		blocks, attachments, err := parseAdvancedFormatting(ctx, ctx.Config.Announce.Slack[0])
 		require.NoError(t, err)
 		assert.Len(t, blocks.BlockSet, 2)
 
@@ -74,27 +110,32 @@ func TestAnnounceWithQuotes(t *testing.T) {
 func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Slack: config.Slack{},
+			Slack: []config.Slack{{}},
 		},
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.EqualError(t, Pipe{}.Announce(ctx), `slack: env: environment variable "SLACK_WEBHOOK" should not be empty`)
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `slack: env: environment variable "SLACK_WEBHOOK" should not be empty`)
 }
 
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
-		require.True(t, Pipe{}.Skip(testctx.New()))
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Slack: []config.Slack{{}},
+			},
+		})
+		require.True(t, Pipe{}.Skip(ctx, 0))
 	})
 
 	t.Run("dont skip", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Slack: config.Slack{
+				Slack: []config.Slack{{
 					Enabled: true,
-				},
+				}},
 			},
 		})
-		require.False(t, Pipe{}.Skip(ctx))
+		require.False(t, Pipe{}.Skip(ctx, 0))
 	})
 }
 
@@ -108,7 +149,7 @@ func TestParseRichText(t *testing.T) {
 		var project config.Project
 		require.NoError(t, yaml.Unmarshal(goodRichSlackConf(), &project))
 		ctx := testctx.NewWithCfg(project, testctx.WithVersion(testVersion))
-		blocks, attachments, err := parseAdvancedFormatting(ctx)
+		blocks, attachments, err := parseAdvancedFormatting(ctx, ctx.Config.Announce.Slack[0])
 		require.NoError(t, err)
 		require.Len(t, blocks.BlockSet, 4)
 		require.Len(t, attachments, 2)
@@ -119,7 +160,7 @@ func TestParseRichText(t *testing.T) {
 		var project config.Project
 		require.NoError(t, yaml.Unmarshal(badBlocksSlackConf(), &project))
 		ctx := testctx.NewWithCfg(project, testctx.WithVersion(testVersion))
-		_, _, err := parseAdvancedFormatting(ctx)
+		_, _, err := parseAdvancedFormatting(ctx, ctx.Config.Announce.Slack[0])
 		require.Error(t, err)
 		require.ErrorContains(t, err, "json")
 	})
@@ -129,7 +170,7 @@ func TestParseRichText(t *testing.T) {
 		var project config.Project
 		require.NoError(t, yaml.Unmarshal(badAttachmentsSlackConf(), &project))
 		ctx := testctx.NewWithCfg(project, testctx.WithVersion(testVersion))
-		_, _, err := parseAdvancedFormatting(ctx)
+		_, _, err := parseAdvancedFormatting(ctx, ctx.Config.Announce.Slack[0])
 		require.Error(t, err)
 		require.ErrorContains(t, err, "json")
 	})
@@ -143,14 +184,14 @@ func TestRichText(t *testing.T) {
 		var project config.Project
 		require.NoError(t, yaml.Unmarshal(goodRichSlackConf(), &project))
 		ctx := testctx.NewWithCfg(project, testctx.WithVersion(testVersion))
-		require.NoError(t, Pipe{}.Announce(ctx))
+		require.NoError(t, Pipe{}.Announce(ctx, 0))
 	})
 
 	t.Run("slack config with bad blocks", func(t *testing.T) {
 		var project config.Project
 		require.NoError(t, yaml.Unmarshal(badBlocksSlackConf(), &project))
 		ctx := testctx.NewWithCfg(project, testctx.WithVersion(testVersion))
-		err := Pipe{}.Announce(ctx)
+		err := Pipe{}.Announce(ctx, 0)
 		require.Error(t, err)
 		require.ErrorContains(t, err, "json")
 	})
@@ -179,7 +220,7 @@ func TestUnmarshall(t *testing.T) {
 		require.NoError(t, yaml.Unmarshal(goodTemplateSlackConf(), &project))
 		ctx := testctx.NewWithCfg(project, testctx.WithVersion(testVersion))
 		var blocks slack.Blocks
-		require.NoError(t, unmarshal(ctx, ctx.Config.Announce.Slack.Blocks, &blocks))
+		require.NoError(t, unmarshal(ctx, ctx.Config.Announce.Slack[0].Blocks, &blocks))
 		require.Len(t, blocks.BlockSet, 1)
 		header, ok := blocks.BlockSet[0].(*slack.HeaderBlock)
 		require.True(t, ok)
@@ -192,7 +233,7 @@ func TestUnmarshall(t *testing.T) {
 		require.NoError(t, yaml.Unmarshal(badTemplateSlackConf(), &project))
 		ctx := testctx.NewWithCfg(project, testctx.WithVersion(testVersion))
 		var blocks slack.Blocks
-		require.Error(t, unmarshal(ctx, ctx.Config.Announce.Slack.Blocks, &blocks))
+		require.Error(t, unmarshal(ctx, ctx.Config.Announce.Slack[0].Blocks, &blocks))
 	})
 }
 
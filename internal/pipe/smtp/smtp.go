@@ -19,8 +19,14 @@ const (
 
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "smtp" }
-func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Announce.SMTP.Enabled }
+func (Pipe) String() string                        { return "smtp" }
+func (Pipe) Key() string                           { return "smtp" }
+func (Pipe) Len(ctx *context.Context) int          { return len(ctx.Config.Announce.SMTP) }
+func (Pipe) Skip(ctx *context.Context, i int) bool { return !ctx.Config.Announce.SMTP[i].Enabled }
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.SMTP[i].AnnounceConditions
+}
 
 type Config struct {
 	Host     string `env:"SMTP_HOST"`
@@ -30,24 +36,30 @@ type Config struct {
 }
 
 func (Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.SMTP.BodyTemplate == "" {
-		ctx.Config.Announce.SMTP.BodyTemplate = defaultBodyTemplate
+	for i := range ctx.Config.Announce.SMTP {
+		conf := &ctx.Config.Announce.SMTP[i]
+		if conf.BodyTemplate == "" && conf.BodyTemplateFile == "" {
+			conf.BodyTemplate = defaultBodyTemplate
+		}
+
+		if conf.SubjectTemplate == "" {
+			conf.SubjectTemplate = defaultSubjectTemplate
+		}
 	}
-
-	if ctx.Config.Announce.SMTP.SubjectTemplate == "" {
-		ctx.Config.Announce.SMTP.SubjectTemplate = defaultSubjectTemplate
-	}
-
 	return nil
 }
 
-func (Pipe) Announce(ctx *context.Context) error {
-	subject, err := tmpl.New(ctx).Apply(ctx.Config.Announce.SMTP.SubjectTemplate)
+func (Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.SMTP[i]
+	subject, err := tmpl.New(ctx).Apply(conf.SubjectTemplate)
 	if err != nil {
 		return fmt.Errorf("SMTP: %w", err)
 	}
 
-	body, err := tmpl.New(ctx).Apply(ctx.Config.Announce.SMTP.BodyTemplate)
+	body, err := tmpl.New(ctx).ApplyInlineOrFile(
+		conf.BodyTemplate,
+		conf.BodyTemplateFile,
+	)
 	if err != nil {
 		return fmt.Errorf("SMTP: %w", err)
 	}
@@ -55,10 +67,10 @@ func (Pipe) Announce(ctx *context.Context) error {
 	m := gomail.NewMessage()
 
 	// Set E-Mail sender
-	m.SetHeader("From", ctx.Config.Announce.SMTP.From)
+	m.SetHeader("From", conf.From)
 
 	// Set E-Mail receivers
-	receivers := ctx.Config.Announce.SMTP.To
+	receivers := conf.To
 	m.SetHeader("To", receivers...)
 
 	// Set E-Mail subject
@@ -67,7 +79,7 @@ func (Pipe) Announce(ctx *context.Context) error {
 	// Set E-Mail body. You can set plain text or html with text/html
 	m.SetBody("text/plain", body)
 
-	cfg, err := getConfig(ctx.Config.Announce.SMTP)
+	cfg, err := getConfig(conf)
 	if err != nil {
 		return err
 	}
@@ -77,14 +89,14 @@ func (Pipe) Announce(ctx *context.Context) error {
 
 	// This is only needed when SSL/TLS certificate is not valid on server.
 	// In production this should be set to false.
-	d.TLSConfig = &tls.Config{InsecureSkipVerify: ctx.Config.Announce.SMTP.InsecureSkipVerify}
+	d.TLSConfig = &tls.Config{InsecureSkipVerify: conf.InsecureSkipVerify}
 
 	// Now send E-Mail
 	if err := d.DialAndSend(m); err != nil {
 		return fmt.Errorf("SMTP: %w", err)
 	}
 
-	log.Infof("The mail has been send from %s to %s\n", ctx.Config.Announce.SMTP.From, receivers)
+	log.Infof("The mail has been send from %s to %s\n", conf.From, receivers)
 
 	return nil
 }
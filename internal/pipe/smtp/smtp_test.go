@@ -15,32 +15,37 @@ func TestStringer(t *testing.T) {
 
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
-		require.True(t, Pipe{}.Skip(testctx.New()))
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				SMTP: []config.SMTP{{}},
+			},
+		})
+		require.True(t, Pipe{}.Skip(ctx, 0))
 	})
 
 	t.Run("dont skip", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				SMTP: config.SMTP{
+				SMTP: []config.SMTP{{
 					Enabled: true,
-				},
+				}},
 			},
 		})
-		require.False(t, Pipe{}.Skip(ctx))
+		require.False(t, Pipe{}.Skip(ctx, 0))
 	})
 }
 
 func TestDefault(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			SMTP: config.SMTP{
+			SMTP: []config.SMTP{{
 				Enabled: true,
-			},
+			}},
 		},
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.Equal(t, defaultBodyTemplate, ctx.Config.Announce.SMTP.BodyTemplate)
-	require.Equal(t, defaultSubjectTemplate, ctx.Config.Announce.SMTP.SubjectTemplate)
+	require.Equal(t, defaultBodyTemplate, ctx.Config.Announce.SMTP[0].BodyTemplate)
+	require.Equal(t, defaultSubjectTemplate, ctx.Config.Announce.SMTP[0].SubjectTemplate)
 }
 
 func TestGetConfig(t *testing.T) {
@@ -15,6 +15,7 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/gio"
 	"github.com/goreleaser/goreleaser/v2/internal/ids"
+	"github.com/goreleaser/goreleaser/v2/internal/logext"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe"
 	"github.com/goreleaser/goreleaser/v2/internal/semerrgroup"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
@@ -35,6 +36,9 @@ var ErrNoDescription = errors.New("no description provided for snapcraft")
 // ErrNoSummary is shown when no summary provided.
 var ErrNoSummary = errors.New("no summary provided for snapcraft")
 
+// ErrNoApps is shown when the snap would have no apps.
+var ErrNoApps = errors.New("snapcraft: snap must have at least one app")
+
 // Metadata to generate the snap package.
 type Metadata struct {
 	Name          string
@@ -104,6 +108,7 @@ const defaultNameTemplate = `{{ .ProjectName }}_{{ .Version }}_{{ .Os }}_{{ .Arc
 type Pipe struct{}
 
 func (Pipe) String() string                           { return "snapcraft packages" }
+func (Pipe) Key() string                              { return "snapcraft" }
 func (Pipe) ContinueOnError() bool                    { return true }
 func (Pipe) Dependencies(_ *context.Context) []string { return []string{"snapcraft"} }
 func (Pipe) Skip(ctx *context.Context) bool {
@@ -126,6 +131,19 @@ func (Pipe) Default(ctx *context.Context) error {
 		if snap.Grade == "" {
 			snap.Grade = "stable"
 		}
+		if snap.Grade != "stable" && snap.Grade != "devel" {
+			return fmt.Errorf("snapcraft: invalid grade: %q", snap.Grade)
+		}
+		confinement, err := tmpl.New(ctx).Apply(snap.Confinement)
+		if err != nil {
+			return err
+		}
+		snap.Confinement = confinement
+		switch snap.Confinement {
+		case "", "strict", "devmode", "classic":
+		default:
+			return fmt.Errorf("snapcraft: invalid confinement: %q", snap.Confinement)
+		}
 		if len(snap.ChannelTemplates) == 0 {
 			switch snap.Grade {
 			case "devel":
@@ -180,7 +198,7 @@ func doRun(ctx *context.Context, snap config.Snapcraft) error {
 		return ErrNoSnapcraft
 	}
 
-	g := semerrgroup.NewBlockingFirst(semerrgroup.New(ctx.Parallelism))
+	g := semerrgroup.NewBlockingFirst(semerrgroup.NewShared(ctx))
 	for platform, binaries := range ctx.Artifacts.Filter(
 		artifact.And(
 			artifact.ByGoos("linux"),
@@ -258,9 +276,81 @@ func create(ctx *context.Context, snap config.Snapcraft, arch string, binaries [
 		}
 	}
 
+	metadata, err := newMetadata(ctx, snap, arch, binaries)
+	if err != nil {
+		return err
+	}
+
+	for _, binary := range binaries {
+		// build the binaries and link resources
+		destBinaryPath := filepath.Join(primeDir, filepath.Base(binary.Path))
+		log.WithField("src", binary.Path).
+			WithField("dst", destBinaryPath).
+			Debug("copying")
+
+		if err = gio.CopyWithMode(binary.Path, destBinaryPath, 0o555); err != nil {
+			return fmt.Errorf("failed to copy binary: %w", err)
+		}
+	}
+
+	for _, app := range metadata.Apps {
+		if app.Completer == "" {
+			continue
+		}
+		destCompleterPath := filepath.Join(primeDir, app.Completer)
+		if err := os.MkdirAll(filepath.Dir(destCompleterPath), 0o755); err != nil {
+			return fmt.Errorf("failed to create folder: %w", err)
+		}
+		log.WithField("src", app.Completer).
+			WithField("dst", destCompleterPath).
+			Debug("copy")
+
+		if err := gio.CopyWithMode(app.Completer, destCompleterPath, 0o644); err != nil {
+			return fmt.Errorf("failed to copy completer: %w", err)
+		}
+	}
+
 	file := filepath.Join(primeDir, "meta", "snap.yaml")
 	log.WithField("file", file).Debug("creating snap metadata")
 
+	out, err := yaml.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	log.WithField("file", file).Debugf("writing metadata file")
+	if err = os.WriteFile(file, out, 0o644); err != nil { //nolint: gosec
+		return err
+	}
+
+	snapFile := filepath.Join(ctx.Config.Dist, folder+".snap")
+	log.WithField("snap", snapFile).Info("creating")
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, "snapcraft", "pack", primeDir, "--output", snapFile)
+	if out, err = cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to generate snap package: %w: %s", err, string(out))
+	}
+	if !snap.Publish {
+		return nil
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type:    artifact.PublishableSnapcraft,
+		Name:    folder + ".snap",
+		Path:    snapFile,
+		Goos:    binaries[0].Goos,
+		Goarch:  binaries[0].Goarch,
+		Goarm:   binaries[0].Goarm,
+		Goamd64: binaries[0].Goamd64,
+		Extra: map[string]interface{}{
+			releasesExtra: channels,
+		},
+	})
+	return nil
+}
+
+// newMetadata returns the metadata of the snap package for the given
+// architecture and binaries.
+func newMetadata(ctx *context.Context, snap config.Snapcraft, arch string, binaries []*artifact.Artifact) (*Metadata, error) {
 	metadata := &Metadata{
 		Version:       ctx.Version,
 		Summary:       snap.Summary,
@@ -268,8 +358,11 @@ func create(ctx *context.Context, snap config.Snapcraft, arch string, binaries [
 		Grade:         snap.Grade,
 		Confinement:   snap.Confinement,
 		Architectures: []string{arch},
+		Assumes:       snap.Assumes,
 		Layout:        map[string]LayoutMetadata{},
 		Apps:          map[string]AppMetadata{},
+		Hooks:         snap.Hooks,
+		Plugs:         snap.Plugs,
 	}
 
 	if snap.Title != "" {
@@ -314,18 +407,6 @@ func create(ctx *context.Context, snap config.Snapcraft, arch string, binaries [
 		}
 	}
 
-	for _, binary := range binaries {
-		// build the binaries and link resources
-		destBinaryPath := filepath.Join(primeDir, filepath.Base(binary.Path))
-		log.WithField("src", binary.Path).
-			WithField("dst", destBinaryPath).
-			Debug("copying")
-
-		if err = gio.CopyWithMode(binary.Path, destBinaryPath, 0o555); err != nil {
-			return fmt.Errorf("failed to copy binary: %w", err)
-		}
-	}
-
 	// setup the apps: directive for each binary
 	for name, config := range snap.Apps {
 		command := name
@@ -335,7 +416,7 @@ func create(ctx *context.Context, snap config.Snapcraft, arch string, binaries [
 
 		// TODO: test that the correct binary is used in Command
 		// See https://github.com/goreleaser/goreleaser/pull/1449
-		appMetadata := AppMetadata{
+		metadata.Apps[name] = AppMetadata{
 			Command: strings.TrimSpace(strings.Join([]string{
 				command,
 				config.Args,
@@ -370,88 +451,56 @@ func create(ctx *context.Context, snap config.Snapcraft, arch string, binaries [
 			Timer:            config.Timer,
 			WatchdogTimeout:  config.WatchdogTimeout,
 		}
-
-		if config.Completer != "" {
-			destCompleterPath := filepath.Join(primeDir, config.Completer)
-			if err := os.MkdirAll(filepath.Dir(destCompleterPath), 0o755); err != nil {
-				return fmt.Errorf("failed to create folder: %w", err)
-			}
-			log.WithField("src", config.Completer).
-				WithField("dst", destCompleterPath).
-				Debug("copy")
-
-			if err := gio.CopyWithMode(config.Completer, destCompleterPath, 0o644); err != nil {
-				return fmt.Errorf("failed to copy completer: %w", err)
-			}
-
-			appMetadata.Completer = config.Completer
-		}
-
-		metadata.Apps[name] = appMetadata
-		metadata.Assumes = snap.Assumes
-		metadata.Hooks = snap.Hooks
-		metadata.Plugs = snap.Plugs
 	}
 
-	out, err := yaml.Marshal(metadata)
-	if err != nil {
-		return err
+	if len(metadata.Apps) == 0 {
+		return nil, ErrNoApps
 	}
-
-	log.WithField("file", file).Debugf("writing metadata file")
-	if err = os.WriteFile(file, out, 0o644); err != nil { //nolint: gosec
-		return err
-	}
-
-	snapFile := filepath.Join(ctx.Config.Dist, folder+".snap")
-	log.WithField("snap", snapFile).Info("creating")
-	/* #nosec */
-	cmd := exec.CommandContext(ctx, "snapcraft", "pack", primeDir, "--output", snapFile)
-	if out, err = cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to generate snap package: %w: %s", err, string(out))
-	}
-	if !snap.Publish {
-		return nil
-	}
-	ctx.Artifacts.Add(&artifact.Artifact{
-		Type:    artifact.PublishableSnapcraft,
-		Name:    folder + ".snap",
-		Path:    snapFile,
-		Goos:    binaries[0].Goos,
-		Goarch:  binaries[0].Goarch,
-		Goarm:   binaries[0].Goarm,
-		Goamd64: binaries[0].Goamd64,
-		Extra: map[string]interface{}{
-			releasesExtra: channels,
-		},
-	})
-	return nil
+	return metadata, nil
 }
 
 const (
 	reviewWaitMsg  = `Waiting for previous upload(s) to complete their review process.`
 	humanReviewMsg = `A human will soon review your snap`
 	needsReviewMsg = `(NEEDS REVIEW)`
+
+	// credentialsEnv is the environment variable snapcraft reads the store
+	// credentials from, as exported by `snapcraft export-login`.
+	credentialsEnv = "SNAPCRAFT_STORE_CREDENTIALS"
 )
 
 func push(ctx *context.Context, snap *artifact.Artifact) error {
 	log := log.WithField("snap", snap.Name)
 	releases := artifact.ExtraOr(*snap, releasesExtra, []string{})
+	logext.AddSecrets(ctx.Env[credentialsEnv])
 	/* #nosec */
 	cmd := exec.CommandContext(ctx, "snapcraft", "upload", "--release="+strings.Join(releases, ","), snap.Path)
+	cmd.Env = ctx.Env.Strings()
 	log.WithField("args", cmd.Args).Info("pushing snap")
 	if out, err := cmd.CombinedOutput(); err != nil {
-		if strings.Contains(string(out), reviewWaitMsg) || strings.Contains(string(out), humanReviewMsg) || strings.Contains(string(out), needsReviewMsg) {
-			log.Warn(reviewWaitMsg)
-		} else {
-			return fmt.Errorf("failed to push %s package: %w: %s", snap.Path, err, string(out))
+		output := logext.Redact(string(out))
+		if !isPendingReview(output) {
+			return fmt.Errorf("failed to push %s package: %w: %s", snap.Path, err, output)
 		}
+		log.WithField("output", strings.TrimSpace(output)).
+			Warn("snap uploaded, but it is pending review in the store, and will only be released to the channels once approved")
 	}
+	// the artifact is already in the list, so only its type changes.
 	snap.Type = artifact.Snapcraft
-	ctx.Artifacts.Add(snap)
 	return nil
 }
 
+// isPendingReview tells whether the given upload output means the snap was
+// uploaded, but is waiting for the store review.
+func isPendingReview(output string) bool {
+	for _, msg := range []string{reviewWaitMsg, humanReviewMsg, needsReviewMsg} {
+		if strings.Contains(output, msg) {
+			return true
+		}
+	}
+	return false
+}
+
 func processChannelsTemplates(ctx *context.Context, snap config.Snapcraft) ([]string, error) {
 	//nolint:prealloc
 	var channels []string
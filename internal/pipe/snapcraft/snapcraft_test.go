@@ -8,6 +8,7 @@ import (
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/internal/gio"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe"
 	"github.com/goreleaser/goreleaser/v2/internal/skips"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
@@ -517,6 +518,125 @@ func TestDefaultGradeTmplError(t *testing.T) {
 	testlib.RequireTemplateError(t, Pipe{}.Default(ctx))
 }
 
+func TestDefaultConfinementTmpl(t *testing.T) {
+	cfg := func() config.Project {
+		return config.Project{
+			Builds: []config.Build{{ID: "foo"}},
+			Snapcrafts: []config.Snapcraft{{
+				Grade:       `{{ if .IsSnapshot }}devel{{ else }}stable{{ end }}`,
+				Confinement: `{{ if .IsSnapshot }}devmode{{ else }}strict{{ end }}`,
+			}},
+		}
+	}
+
+	t.Run("snapshot", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg(), testctx.Snapshot)
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, "devel", ctx.Config.Snapcrafts[0].Grade)
+		require.Equal(t, "devmode", ctx.Config.Snapcrafts[0].Confinement)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(cfg())
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Equal(t, "stable", ctx.Config.Snapcrafts[0].Grade)
+		require.Equal(t, "strict", ctx.Config.Snapcrafts[0].Confinement)
+	})
+}
+
+func TestDefaultInvalid(t *testing.T) {
+	t.Run("grade", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Snapcrafts: []config.Snapcraft{{Grade: "beta"}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), `snapcraft: invalid grade: "beta"`)
+	})
+
+	t.Run("confinement", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Snapcrafts: []config.Snapcraft{{Confinement: "loose"}},
+		})
+		require.EqualError(t, Pipe{}.Default(ctx), `snapcraft: invalid confinement: "loose"`)
+	})
+
+	t.Run("confinement template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Snapcrafts: []config.Snapcraft{{Confinement: "{{ .Nope }"}},
+		})
+		testlib.RequireTemplateError(t, Pipe{}.Default(ctx))
+	})
+}
+
+func TestMetadataMultipleApps(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "tool",
+		Snapcrafts: []config.Snapcraft{{
+			Summary:     "test summary",
+			Description: "test description",
+			Confinement: "strict",
+			Base:        "core22",
+			Apps: map[string]config.SnapcraftAppMetadata{
+				"tool": {
+					Plugs: []string{"home", "network"},
+				},
+				"toold": {
+					Command:          "tool",
+					Args:             "serve",
+					Daemon:           "simple",
+					RestartCondition: "on-failure",
+					Plugs:            []string{"network-bind"},
+					Slots:            []string{"tool-socket"},
+				},
+				"helper": {
+					Command: "tool-helper",
+					Aliases: []string{"th"},
+				},
+			},
+			Plugs: map[string]interface{}{
+				"personal-files": map[string]interface{}{
+					"read": []string{"$HOME/.tool"},
+				},
+			},
+			Assumes: []string{"snapd2.38"},
+		}},
+	}, testctx.WithVersion("1.2.3"))
+	require.NoError(t, Pipe{}.Default(ctx))
+
+	metadata, err := newMetadata(ctx, ctx.Config.Snapcrafts[0], "amd64", []*artifact.Artifact{
+		{Name: "tool", Path: "tool"},
+		{Name: "tool-helper", Path: "tool-helper"},
+	})
+	require.NoError(t, err)
+	out, err := yaml.Marshal(metadata)
+	require.NoError(t, err)
+	golden.RequireEqualYaml(t, out)
+}
+
+func TestMetadataDefaultApp(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "tool",
+		Snapcrafts: []config.Snapcraft{{
+			Summary:     "test summary",
+			Description: "test description",
+			Plugs: map[string]interface{}{
+				"personal-files": map[string]interface{}{
+					"read": []string{"$HOME/.tool"},
+				},
+			},
+		}},
+	}, testctx.WithVersion("1.2.3"))
+	require.NoError(t, Pipe{}.Default(ctx))
+
+	metadata, err := newMetadata(ctx, ctx.Config.Snapcrafts[0], "amd64", []*artifact.Artifact{
+		{Name: "subdir/tool", Path: "tool"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]AppMetadata{
+		"tool": {Command: "tool"},
+	}, metadata.Apps)
+	require.Contains(t, metadata.Plugs, "personal-files")
+}
+
 func TestPublish(t *testing.T) {
 	ctx := testctx.New()
 	ctx.Artifacts.Add(&artifact.Artifact{
@@ -533,6 +653,60 @@ func TestPublish(t *testing.T) {
 	require.ErrorContains(t, err, "failed to push nope.snap package")
 }
 
+// fakeSnapcraft puts a snapcraft script with the given body first in the
+// PATH.
+func fakeSnapcraft(tb testing.TB, script string) {
+	tb.Helper()
+	dir := tb.TempDir()
+	require.NoError(tb, os.WriteFile(filepath.Join(dir, "snapcraft"), []byte("#!/bin/sh\n"+script+"\n"), 0o755))
+	tb.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestPublishFake(t *testing.T) {
+	newCtx := func() *context.Context {
+		ctx := testctx.New(testctx.WithEnv(map[string]string{
+			"SNAPCRAFT_STORE_CREDENTIALS": "supersecret",
+		}))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name:   "mybin.snap",
+			Path:   "mybin.snap",
+			Goarch: "amd64",
+			Goos:   "linux",
+			Type:   artifact.PublishableSnapcraft,
+			Extra: map[string]interface{}{
+				releasesExtra: []string{"edge", "candidate"},
+			},
+		})
+		return ctx
+	}
+
+	t.Run("success", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "out")
+		fakeSnapcraft(t, `echo "$SNAPCRAFT_STORE_CREDENTIALS $@" > `+out)
+		ctx := newCtx()
+		require.NoError(t, Pipe{}.Publish(ctx))
+		bts, err := os.ReadFile(out)
+		require.NoError(t, err)
+		require.Equal(t, "supersecret upload --release=edge,candidate mybin.snap\n", string(bts))
+		require.Len(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Snapcraft)).List(), 1)
+	})
+
+	t.Run("pending review", func(t *testing.T) {
+		fakeSnapcraft(t, `echo "Revision 3 of 'mybin' created. (NEEDS REVIEW)"; exit 2`)
+		ctx := newCtx()
+		require.NoError(t, Pipe{}.Publish(ctx))
+		require.Len(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Snapcraft)).List(), 1)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		fakeSnapcraft(t, `echo "invalid credentials: $SNAPCRAFT_STORE_CREDENTIALS"; exit 2`)
+		ctx := newCtx()
+		err := Pipe{}.Publish(ctx)
+		require.EqualError(t, err, "failed to push mybin.snap package: exit status 2: invalid credentials: *****\n")
+		require.Empty(t, ctx.Artifacts.Filter(artifact.ByType(artifact.Snapcraft)).List())
+	})
+}
+
 func TestDefaultSet(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Snapcrafts: []config.Snapcraft{
@@ -4,6 +4,7 @@ package snapshot
 import (
 	"fmt"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
@@ -24,6 +25,9 @@ func (Pipe) Default(ctx *context.Context) error {
 }
 
 func (Pipe) Run(ctx *context.Context) error {
+	if err := setVersion(ctx); err != nil {
+		return err
+	}
 	name, err := tmpl.New(ctx).Apply(ctx.Config.Snapshot.NameTemplate)
 	if err != nil {
 		return fmt.Errorf("failed to parse snapshot name: %w", err)
@@ -35,3 +39,32 @@ func (Pipe) Run(ctx *context.Context) error {
 	log.WithField("version", ctx.Version).Infof("building snapshot...")
 	return nil
 }
+
+// setVersion sets the version and semver of the snapshot from
+// snapshot.version_template, if any, so the next version can be used in the
+// snapshot name and everywhere else.
+func setVersion(ctx *context.Context) error {
+	if ctx.Config.Snapshot.VersionTemplate == "" {
+		return nil
+	}
+	if ctx.Version == "" {
+		// no previous tag.
+		ctx.Version = "0.0.0"
+	}
+	version, err := tmpl.New(ctx).Apply(ctx.Config.Snapshot.VersionTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse snapshot version: %w", err)
+	}
+	sv, err := semver.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("failed to parse snapshot version '%s' as semver: %w", version, err)
+	}
+	ctx.Version = sv.String()
+	ctx.Semver = context.Semver{
+		Major:      sv.Major(),
+		Minor:      sv.Minor(),
+		Patch:      sv.Patch(),
+		Prerelease: sv.Prerelease(),
+	}
+	return nil
+}
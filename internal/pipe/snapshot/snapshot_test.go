@@ -6,6 +6,7 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,6 +60,61 @@ func TestSnapshot(t *testing.T) {
 	require.Equal(t, "v1.2.4", ctx.Version)
 }
 
+func TestSnapshotVersionTemplate(t *testing.T) {
+	t.Run("next patch", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Snapshot: config.Snapshot{
+				NameTemplate:    "{{ .Version }}-SNAPSHOT-{{ .Major }}.{{ .Minor }}.{{ .Patch }}",
+				VersionTemplate: "{{ incpatch .Version }}",
+			},
+		}, testctx.WithCurrentTag("v1.2.3"), testctx.WithVersion("1.2.3"), testctx.WithSemver(1, 2, 3, ""))
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "1.2.4-SNAPSHOT-1.2.4", ctx.Version)
+		require.Equal(t, context.Semver{Major: 1, Minor: 2, Patch: 4}, ctx.Semver)
+	})
+
+	t.Run("prefixed tag", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Snapshot: config.Snapshot{
+				NameTemplate:    "{{ .Version }}",
+				VersionTemplate: "{{ incminor .Tag }}-rc",
+			},
+		}, testctx.WithCurrentTag("v1.2.3"), testctx.WithVersion("1.2.3"))
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "1.3.0-rc", ctx.Version)
+		require.Equal(t, "rc", ctx.Semver.Prerelease)
+	})
+
+	t.Run("no previous tag", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Snapshot: config.Snapshot{
+				NameTemplate:    "{{ .Version }}",
+				VersionTemplate: "{{ incpatch .Version }}",
+			},
+		})
+		require.NoError(t, Pipe{}.Run(ctx))
+		require.Equal(t, "0.0.1", ctx.Version)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Snapshot: config.Snapshot{
+				VersionTemplate: "{{ .Version }",
+			},
+		}, testctx.WithVersion("1.2.3"))
+		testlib.RequireTemplateError(t, Pipe{}.Run(ctx))
+	})
+
+	t.Run("not semver", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Snapshot: config.Snapshot{
+				VersionTemplate: "next",
+			},
+		}, testctx.WithVersion("1.2.3"))
+		require.ErrorContains(t, Pipe{}.Run(ctx), "failed to parse snapshot version 'next' as semver")
+	})
+}
+
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
 		require.True(t, Pipe{}.Skip(testctx.New()))
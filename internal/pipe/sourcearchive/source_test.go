@@ -2,10 +2,14 @@ package sourcearchive
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/golden"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
@@ -124,6 +128,129 @@ subfolder/
 	}
 }
 
+func TestArchiveLayout(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	for _, format := range []string{"tar.gz", "tar", "zip"} {
+		t.Run(format, func(t *testing.T) {
+			testlib.Mktmp(t)
+			require.NoError(t, os.Mkdir("dist", 0o744))
+
+			testlib.GitInit(t)
+			require.NoError(t, os.WriteFile("main.go", []byte("package main"), 0o644))
+			require.NoError(t, os.MkdirAll("internal/foo", 0o755))
+			require.NoError(t, os.WriteFile("internal/foo/foo.go", []byte("package foo"), 0o644))
+			require.NoError(t, os.MkdirAll("testdata", 0o755))
+			require.NoError(t, os.WriteFile("testdata/big.bin", []byte("not wanted"), 0o644))
+			require.NoError(t, os.WriteFile(".gitignore", []byte("VERSION\ndist/\n"), 0o644))
+			require.NoError(t, os.WriteFile(".gitattributes", []byte("testdata/ export-ignore\n.gitattributes export-ignore\n"), 0o644))
+			testlib.GitAdd(t)
+			testlib.GitCommit(t, "feat: first")
+			testlib.GitTag(t, "v1.2.3")
+
+			// generated after the commit, e.g. by a before hook.
+			require.NoError(t, os.WriteFile("VERSION", []byte("1.2.3\n"), 0o644))
+
+			ctx := testctx.NewWithCfg(
+				config.Project{
+					ProjectName: "myproject",
+					Dist:        "dist",
+					Source: config.Source{
+						Format:         format,
+						Enabled:        true,
+						PrefixTemplate: "{{ .ProjectName }}-{{ .Version }}/",
+						Files: []config.File{
+							{Source: "VERSION"},
+						},
+					},
+				},
+				testctx.WithCommit("HEAD"),
+				testctx.WithVersion("1.2.3"),
+				testctx.WithCurrentTag("v1.2.3"),
+			)
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			path := filepath.Join("dist", "myproject-1.2.3."+format)
+			files := testlib.LsArchive(t, path, format)
+			sort.Strings(files)
+			version := testlib.GetFileFromArchive(t, path, format, "myproject-1.2.3/VERSION")
+
+			var out strings.Builder
+			for _, f := range files {
+				out.WriteString(f + "\n")
+			}
+			out.WriteString("\nVERSION: " + string(version))
+
+			require.NoError(t, os.Chdir(wd))
+			golden.RequireEqualTxt(t, []byte(out.String()))
+		})
+	}
+}
+
+func TestArchiveExportSubst(t *testing.T) {
+	for _, format := range []string{"tar.gz", "zip"} {
+		t.Run(format, func(t *testing.T) {
+			testlib.Mktmp(t)
+			require.NoError(t, os.Mkdir("dist", 0o744))
+
+			testlib.GitInit(t)
+			require.NoError(t, os.WriteFile(".gitignore", []byte("dist/\n"), 0o644))
+			require.NoError(t, os.WriteFile(".gitattributes", []byte("version.go export-subst\n"), 0o644))
+			require.NoError(t, os.WriteFile("version.go", []byte(`package main
+
+const (
+	commit    = "$Format:%H$"
+	short     = "$Format:%h$"
+	refs      = "$Format:%d$"
+	committed = "$Format:%ci$"
+)
+`), 0o644))
+			require.NoError(t, os.WriteFile("unchanged.go", []byte(`const commit = "$Format:%H$"`), 0o644))
+			testlib.GitAdd(t)
+			testlib.GitCommit(t, "feat: first")
+			testlib.GitTag(t, "v1.0.0")
+
+			gitOutput := func(args ...string) string {
+				out, err := exec.Command("git", args...).Output()
+				require.NoError(t, err)
+				return strings.TrimSpace(string(out))
+			}
+			commit := gitOutput("rev-parse", "HEAD")
+
+			ctx := testctx.NewWithCfg(
+				config.Project{
+					ProjectName: "foo",
+					Dist:        "dist",
+					Source: config.Source{
+						Format:         format,
+						Enabled:        true,
+						PrefixTemplate: "{{ .ProjectName }}-{{ .Version }}/",
+					},
+				},
+				testctx.WithCommit(commit),
+				testctx.WithVersion("1.0.0"),
+				testctx.WithCurrentTag("v1.0.0"),
+			)
+			require.NoError(t, Pipe{}.Default(ctx))
+			require.NoError(t, Pipe{}.Run(ctx))
+
+			path := filepath.Join("dist", "foo-1.0.0."+format)
+			require.Equal(t, `package main
+
+const (
+	commit    = "`+commit+`"
+	short     = "`+gitOutput("rev-parse", "--short", "HEAD")+`"
+	refs      = " (HEAD -> main, tag: v1.0.0)"
+	committed = "`+gitOutput("log", "-1", "--format=%ci")+`"
+)
+`, string(testlib.GetFileFromArchive(t, path, format, "foo-1.0.0/version.go")))
+			require.Equal(t, `const commit = "$Format:%H$"`, string(testlib.GetFileFromArchive(t, path, format, "foo-1.0.0/unchanged.go")))
+		})
+	}
+}
+
 func doVerifyTestArchive(tb testing.TB, ctx *context.Context, tmp, format string, expected []string) {
 	tb.Helper()
 	require.NoError(tb, Pipe{}.Default(ctx))
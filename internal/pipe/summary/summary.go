@@ -0,0 +1,166 @@
+// Package summary provides the pipe implementation that reports how long each
+// step took, which artifacts were created and which publishers ran.
+package summary
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/announce"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/build"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/publish"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const name = "summary.json"
+
+// stdout is where the summary table is printed to.
+var stdout io.Writer = os.Stdout
+
+// Pipe implementation.
+type Pipe struct{}
+
+func (Pipe) String() string { return "writing summary" }
+
+// Run writes the summary.json file and prints the summary table, unless
+// running in quiet mode.
+func (Pipe) Run(ctx *context.Context) error {
+	s := newSummary(ctx)
+	bts, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(ctx.Config.Dist, name)
+	log.WithField("path", path).Debug("writing")
+	if err := os.WriteFile(path, bts, 0o644); err != nil {
+		return err
+	}
+	if ctx.Quiet {
+		return nil
+	}
+	_, err = io.WriteString(stdout, s.table())
+	return err
+}
+
+type summary struct {
+	ProjectName string         `json:"project_name"`
+	Version     string         `json:"version"`
+	Duration    time.Duration  `json:"duration"`
+	Pipes       []step         `json:"pipes"`
+	Builds      []step         `json:"builds"`
+	Publishers  []step         `json:"publishers"`
+	Announcers  []step         `json:"announcers"`
+	Artifacts   map[string]int `json:"artifacts"`
+	Size        int64          `json:"size"`
+	children    map[string][]step
+}
+
+type step struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+}
+
+func newSummary(ctx *context.Context) summary {
+	s := summary{
+		ProjectName: ctx.Config.ProjectName,
+		Version:     ctx.Version,
+		Pipes:       []step{},
+		Builds:      []step{},
+		Publishers:  []step{},
+		Announcers:  []step{},
+		Artifacts:   map[string]int{},
+		children:    map[string][]step{},
+	}
+	for _, t := range ctx.Timings.List() {
+		st := step{Name: t.Name, Duration: t.Duration}
+		switch t.Parent {
+		case "":
+			s.Pipes = append(s.Pipes, st)
+			s.Duration += t.Duration
+			continue
+		case build.Pipe{}.String():
+			s.Builds = append(s.Builds, st)
+		case publish.Pipe{}.String():
+			s.Publishers = append(s.Publishers, st)
+		case announce.Pipe{}.String():
+			s.Announcers = append(s.Announcers, st)
+		}
+		s.children[t.Parent] = append(s.children[t.Parent], st)
+	}
+	sort.Slice(s.Builds, func(i, j int) bool {
+		return s.Builds[i].Name < s.Builds[j].Name
+	})
+	s.children[build.Pipe{}.String()] = s.Builds
+
+	seen := map[string]bool{}
+	for _, a := range ctx.Artifacts.List() {
+		s.Artifacts[a.Type.String()]++
+		if seen[a.Path] {
+			continue
+		}
+		seen[a.Path] = true
+		info, err := os.Stat(a.Path)
+		if err != nil || !info.Mode().IsRegular() {
+			// not all artifacts are files, e.g. docker images.
+			continue
+		}
+		s.Size += info.Size()
+	}
+	return s
+}
+
+func (s summary) table() string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "STEP\tDURATION")
+	for _, p := range s.Pipes {
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", p.Name, round(p.Duration))
+		for _, c := range s.children[p.Name] {
+			_, _ = fmt.Fprintf(w, "  %s\t%s\n", c.Name, round(c.Duration))
+		}
+	}
+	_, _ = fmt.Fprintf(w, "total\t%s\n", round(s.Duration))
+	_, _ = fmt.Fprintln(w, "\t")
+
+	types := make([]string, 0, len(s.Artifacts))
+	for t := range s.Artifacts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	_, _ = fmt.Fprintln(w, "ARTIFACTS\tCOUNT")
+	for _, t := range types {
+		_, _ = fmt.Fprintf(w, "%s\t%d\n", t, s.Artifacts[t])
+	}
+	_, _ = fmt.Fprintf(w, "total size\t%s\n", humanSize(s.Size))
+	_ = w.Flush()
+	return sb.String()
+}
+
+func round(d time.Duration) time.Duration {
+	if d < time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(100 * time.Millisecond)
+}
+
+func humanSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
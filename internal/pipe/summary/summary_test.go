@@ -0,0 +1,142 @@
+package summary
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.NotEmpty(t, Pipe{}.String())
+}
+
+func setup(tb testing.TB) *context.Context {
+	tb.Helper()
+	dist := tb.TempDir()
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist:        dist,
+		ProjectName: "foo",
+	}, testctx.WithVersion("1.2.3"))
+
+	ctx.Timings.Add("loading environment variables", 10*time.Millisecond, nil)
+	ctx.Timings.Start("building binaries")
+	ctx.Timings.Add("default linux_amd64", 2*time.Second, nil)
+	ctx.Timings.Add("default darwin_arm64", 3*time.Second, nil)
+	ctx.Timings.Add("building binaries", 3*time.Second, nil)
+	ctx.Timings.Start("publishing")
+	ctx.Timings.Add("github/gitlab/gitea releases", time.Second, nil)
+	ctx.Timings.Add("homebrew tap formula", 500*time.Millisecond, nil)
+	ctx.Timings.Add("publishing", 2*time.Second, nil)
+	ctx.Timings.Start("announcing")
+	ctx.Timings.Add("slack", 100*time.Millisecond, nil)
+	ctx.Timings.Add("announcing", 200*time.Millisecond, nil)
+
+	for _, name := range []string{"foo_linux_amd64", "foo_darwin_arm64"} {
+		path := filepath.Join(dist, name)
+		require.NoError(tb, os.WriteFile(path, bytes.Repeat([]byte("a"), 1024), 0o644))
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: name,
+			Path: path,
+			Type: artifact.Binary,
+		})
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: name,
+			Path: path,
+			Type: artifact.UploadableBinary,
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "foo/bar:latest",
+		Path: "foo/bar:latest",
+		Type: artifact.DockerImage,
+	})
+	return ctx
+}
+
+func TestRun(t *testing.T) {
+	ctx := setup(t)
+	var out bytes.Buffer
+	stdout = &out
+	t.Cleanup(func() { stdout = os.Stdout })
+
+	require.NoError(t, Pipe{}.Run(ctx))
+
+	bts, err := os.ReadFile(filepath.Join(ctx.Config.Dist, "summary.json"))
+	require.NoError(t, err)
+	var s summary
+	require.NoError(t, json.Unmarshal(bts, &s))
+	require.Equal(t, "foo", s.ProjectName)
+	require.Equal(t, "1.2.3", s.Version)
+	require.Equal(t, 5210*time.Millisecond, s.Duration)
+	require.Equal(t, []step{
+		{Name: "loading environment variables", Duration: 10 * time.Millisecond},
+		{Name: "building binaries", Duration: 3 * time.Second},
+		{Name: "publishing", Duration: 2 * time.Second},
+		{Name: "announcing", Duration: 200 * time.Millisecond},
+	}, s.Pipes)
+	require.Equal(t, []step{
+		{Name: "default darwin_arm64", Duration: 3 * time.Second},
+		{Name: "default linux_amd64", Duration: 2 * time.Second},
+	}, s.Builds)
+	require.Equal(t, []step{
+		{Name: "github/gitlab/gitea releases", Duration: time.Second},
+		{Name: "homebrew tap formula", Duration: 500 * time.Millisecond},
+	}, s.Publishers)
+	require.Equal(t, []step{
+		{Name: "slack", Duration: 100 * time.Millisecond},
+	}, s.Announcers)
+	require.Equal(t, map[string]int{
+		"Binary":                 4,
+		"Published Docker Image": 1,
+	}, s.Artifacts)
+	require.Equal(t, int64(2048), s.Size)
+
+	table := out.String()
+	require.Contains(t, table, "STEP")
+	require.Contains(t, table, "  default darwin_arm64")
+	require.Contains(t, table, "  github/gitlab/gitea releases")
+	require.Contains(t, table, "  slack")
+	require.Contains(t, table, "Docker Image")
+	require.Contains(t, table, "2.0 KiB")
+}
+
+func TestRunQuiet(t *testing.T) {
+	ctx := setup(t)
+	ctx.Quiet = true
+	var out bytes.Buffer
+	stdout = &out
+	t.Cleanup(func() { stdout = os.Stdout })
+
+	require.NoError(t, Pipe{}.Run(ctx))
+	require.FileExists(t, filepath.Join(ctx.Config.Dist, "summary.json"))
+	require.Empty(t, out.String())
+}
+
+func TestRunNoDist(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Dist: filepath.Join(t.TempDir(), "nope"),
+	})
+	require.ErrorIs(t, Pipe{}.Run(ctx), os.ErrNotExist)
+}
+
+func TestHumanSize(t *testing.T) {
+	for size, expected := range map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1024:            "1.0 KiB",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+		3 << 30:         "3.0 GiB",
+	} {
+		require.Equal(t, expected, humanSize(size))
+	}
+}
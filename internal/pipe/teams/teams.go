@@ -7,7 +7,9 @@ import (
 	"github.com/atc0005/go-teams-notify/v2/messagecard"
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -20,36 +22,49 @@ const (
 
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "teams" }
-func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Announce.Teams.Enabled }
+func (Pipe) String() string                        { return "teams" }
+func (Pipe) Key() string                           { return "teams" }
+func (Pipe) Len(ctx *context.Context) int          { return len(ctx.Config.Announce.Teams) }
+func (Pipe) Skip(ctx *context.Context, i int) bool { return !ctx.Config.Announce.Teams[i].Enabled }
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.Teams[i].AnnounceConditions
+}
 
 type Config struct {
 	Webhook string `env:"TEAMS_WEBHOOK,notEmpty"`
 }
 
 func (p Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.Teams.MessageTemplate == "" {
-		ctx.Config.Announce.Teams.MessageTemplate = defaultMessageTemplate
-	}
-	if ctx.Config.Announce.Teams.TitleTemplate == "" {
-		ctx.Config.Announce.Teams.TitleTemplate = defaultMessageTitle
-	}
-	if ctx.Config.Announce.Teams.IconURL == "" {
-		ctx.Config.Announce.Teams.IconURL = defaultIcon
-	}
-	if ctx.Config.Announce.Teams.Color == "" {
-		ctx.Config.Announce.Teams.Color = defaultColor
+	for i := range ctx.Config.Announce.Teams {
+		conf := &ctx.Config.Announce.Teams[i]
+		if conf.MessageTemplate == "" && conf.MessageTemplateFile == "" {
+			conf.MessageTemplate = defaultMessageTemplate
+		}
+		if conf.TitleTemplate == "" {
+			conf.TitleTemplate = defaultMessageTitle
+		}
+		if conf.IconURL == "" {
+			conf.IconURL = defaultIcon
+		}
+		if conf.Color == "" {
+			conf.Color = defaultColor
+		}
 	}
 	return nil
 }
 
-func (p Pipe) Announce(ctx *context.Context) error {
-	title, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Teams.TitleTemplate)
+func (p Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.Teams[i]
+	title, err := tmpl.New(ctx).Apply(conf.TitleTemplate)
 	if err != nil {
 		return fmt.Errorf("teams: %w", err)
 	}
 
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Teams.MessageTemplate)
+	msg, err := tmpl.New(ctx).ApplyInlineOrFile(
+		conf.MessageTemplate,
+		conf.MessageTemplateFile,
+	)
 	if err != nil {
 		return fmt.Errorf("teams: %w", err)
 	}
@@ -61,23 +76,35 @@ func (p Pipe) Announce(ctx *context.Context) error {
 
 	log.Infof("posting: '%s'", msg)
 
-	client := goteamsnotify.NewTeamsClient()
+	client, err := newClient(ctx)
+	if err != nil {
+		return fmt.Errorf("teams: %w", err)
+	}
 	msgCard := messagecard.NewMessageCard()
 	msgCard.Summary = title
-	msgCard.ThemeColor = ctx.Config.Announce.Teams.Color
+	msgCard.ThemeColor = conf.Color
 
 	messageCardSection := messagecard.NewSection()
 	messageCardSection.ActivityTitle = title
 	messageCardSection.ActivityText = msg
 	messageCardSection.Markdown = true
-	messageCardSection.ActivityImage = ctx.Config.Announce.Teams.IconURL
+	messageCardSection.ActivityImage = conf.IconURL
 	err = msgCard.AddSection(messageCardSection)
 	if err != nil {
 		return fmt.Errorf("teams: %w", err)
 	}
-	err = client.Send(cfg.Webhook, msgCard)
+	err = client.SendWithContext(ctx, cfg.Webhook, msgCard)
 	if err != nil {
 		return fmt.Errorf("teams: %w", err)
 	}
 	return nil
 }
+
+// newClient returns a teams client using the shared HTTP client.
+func newClient(ctx *context.Context) (*goteamsnotify.TeamsClient, error) {
+	client, err := httpclient.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return goteamsnotify.NewTeamsClient().SetHTTPClient(client), nil
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
 	api "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -19,28 +21,38 @@ const (
 
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "telegram" }
-func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Announce.Telegram.Enabled }
+func (Pipe) String() string                        { return "telegram" }
+func (Pipe) Key() string                           { return "telegram" }
+func (Pipe) Len(ctx *context.Context) int          { return len(ctx.Config.Announce.Telegram) }
+func (Pipe) Skip(ctx *context.Context, i int) bool { return !ctx.Config.Announce.Telegram[i].Enabled }
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.Telegram[i].AnnounceConditions
+}
 
 type Config struct {
 	ConsumerToken string `env:"TELEGRAM_TOKEN,notEmpty"`
 }
 
 func (Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.Telegram.MessageTemplate == "" {
-		ctx.Config.Announce.Telegram.MessageTemplate = defaultMessageTemplate
-	}
-	switch ctx.Config.Announce.Telegram.ParseMode {
-	case parseModeHTML, parseModeMarkdown:
-		break
-	default:
-		ctx.Config.Announce.Telegram.ParseMode = parseModeMarkdown
+	for i := range ctx.Config.Announce.Telegram {
+		conf := &ctx.Config.Announce.Telegram[i]
+		if conf.MessageTemplate == "" && conf.MessageTemplateFile == "" {
+			conf.MessageTemplate = defaultMessageTemplate
+		}
+		switch conf.ParseMode {
+		case parseModeHTML, parseModeMarkdown:
+			break
+		default:
+			conf.ParseMode = parseModeMarkdown
+		}
 	}
 	return nil
 }
 
-func (Pipe) Announce(ctx *context.Context) error {
-	msg, chatID, err := getMessageDetails(ctx)
+func (Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.Telegram[i]
+	msg, chatID, err := getMessageDetails(ctx, conf)
 	if err != nil {
 		return err
 	}
@@ -51,7 +63,7 @@ func (Pipe) Announce(ctx *context.Context) error {
 	}
 
 	log.Infof("posting: '%s'", msg)
-	bot, err := api.NewBotAPI(cfg.ConsumerToken)
+	bot, err := newBot(ctx, cfg.ConsumerToken, api.APIEndpoint)
 	if err != nil {
 		return fmt.Errorf("telegram: %w", err)
 	}
@@ -66,12 +78,15 @@ func (Pipe) Announce(ctx *context.Context) error {
 	return nil
 }
 
-func getMessageDetails(ctx *context.Context) (string, int64, error) {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Telegram.MessageTemplate)
+func getMessageDetails(ctx *context.Context, conf config.Telegram) (string, int64, error) {
+	msg, err := tmpl.New(ctx).ApplyInlineOrFile(
+		conf.MessageTemplate,
+		conf.MessageTemplateFile,
+	)
 	if err != nil {
 		return "", 0, fmt.Errorf("telegram: %w", err)
 	}
-	chatIDStr, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Telegram.ChatID)
+	chatIDStr, err := tmpl.New(ctx).Apply(conf.ChatID)
 	if err != nil {
 		return "", 0, fmt.Errorf("telegram: %w", err)
 	}
@@ -82,3 +97,12 @@ func getMessageDetails(ctx *context.Context) (string, int64, error) {
 
 	return msg, chatID, nil
 }
+
+// newBot returns a telegram bot using the shared HTTP client.
+func newBot(ctx *context.Context, token, endpoint string) (*api.BotAPI, error) {
+	client, err := httpclient.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewBotAPIWithClient(token, endpoint, client)
+}
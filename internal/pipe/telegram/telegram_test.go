@@ -1,11 +1,17 @@
 package telegram
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
 	"github.com/goreleaser/goreleaser/v2/internal/testlib"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,22 +21,56 @@ func TestStringer(t *testing.T) {
 
 func TestDefault(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
-		ctx := testctx.New()
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Telegram: []config.Telegram{{}},
+			},
+		})
 		require.NoError(t, Pipe{}.Default(ctx))
-		require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Telegram.MessageTemplate)
-		require.Equal(t, parseModeMarkdown, ctx.Config.Announce.Telegram.ParseMode)
+		require.Equal(t, defaultMessageTemplate, ctx.Config.Announce.Telegram[0].MessageTemplate)
+		require.Equal(t, parseModeMarkdown, ctx.Config.Announce.Telegram[0].ParseMode)
 	})
 	t.Run("markdownv2 parsemode", func(t *testing.T) {
-		ctx := testctx.New()
-		ctx.Config.Announce.Telegram.ParseMode = parseModeMarkdown
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Telegram: []config.Telegram{{}},
+			},
+		})
+		ctx.Config.Announce.Telegram[0].ParseMode = parseModeMarkdown
 		require.NoError(t, Pipe{}.Default(ctx))
-		require.Equal(t, parseModeMarkdown, ctx.Config.Announce.Telegram.ParseMode)
+		require.Equal(t, parseModeMarkdown, ctx.Config.Announce.Telegram[0].ParseMode)
 	})
 	t.Run("html parsemode", func(t *testing.T) {
-		ctx := testctx.New()
-		ctx.Config.Announce.Telegram.ParseMode = parseModeHTML
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Telegram: []config.Telegram{{}},
+			},
+		})
+		ctx.Config.Announce.Telegram[0].ParseMode = parseModeHTML
 		require.NoError(t, Pipe{}.Default(ctx))
-		require.Equal(t, parseModeHTML, ctx.Config.Announce.Telegram.ParseMode)
+		require.Equal(t, parseModeHTML, ctx.Config.Announce.Telegram[0].ParseMode)
+	})
+	t.Run("template file", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Telegram: []config.Telegram{{}},
+			},
+		})
+		ctx.Config.Announce.Telegram[0].MessageTemplateFile = "announce.tmpl"
+		require.NoError(t, Pipe{}.Default(ctx))
+		require.Empty(t, ctx.Config.Announce.Telegram[0].MessageTemplate)
+	})
+	t.Run("template and template file", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Telegram: []config.Telegram{{}},
+			},
+		})
+		ctx.Config.Announce.Telegram[0].MessageTemplate = "foo"
+		ctx.Config.Announce.Telegram[0].MessageTemplateFile = "announce.tmpl"
+		require.NoError(t, Pipe{}.Default(ctx))
+		_, _, err := getMessageDetails(ctx, ctx.Config.Announce.Telegram[0])
+		require.ErrorIs(t, err, tmpl.ErrTemplateAndFile)
 	})
 }
 
@@ -38,35 +78,35 @@ func TestAnnounceInvalidTemplate(t *testing.T) {
 	t.Run("message", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Telegram: config.Telegram{
+				Telegram: []config.Telegram{{
 					MessageTemplate: "{{ .Foo }",
-				},
+				}},
 			},
 		})
-		testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+		testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
 	})
 	t.Run("chatid", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Telegram: config.Telegram{
+				Telegram: []config.Telegram{{
 					MessageTemplate: "test",
 					ChatID:          "{{ .Foo }",
-				},
+				}},
 			},
 		})
-		testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+		testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
 	})
 	t.Run("chatid not int", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Env: []string{"CHAT_ID=test"},
 			Announce: config.Announce{
-				Telegram: config.Telegram{
+				Telegram: []config.Telegram{{
 					MessageTemplate: "test",
 					ChatID:          "{{ .Env.CHAT_ID }}",
-				},
+				}},
 			},
 		})
-		require.EqualError(t, Pipe{}.Announce(ctx), "telegram: strconv.ParseInt: parsing \"test\": invalid syntax")
+		require.EqualError(t, Pipe{}.Announce(ctx, 0), "telegram: strconv.ParseInt: parsing \"test\": invalid syntax")
 	})
 }
 
@@ -74,29 +114,34 @@ func TestAnnounceMissingEnv(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Env: []string{"CHAT_ID=10"},
 		Announce: config.Announce{
-			Telegram: config.Telegram{
+			Telegram: []config.Telegram{{
 				ChatID: "{{ .Env.CHAT_ID }}",
-			},
+			}},
 		},
 	})
 	require.NoError(t, Pipe{}.Default(ctx))
-	require.EqualError(t, Pipe{}.Announce(ctx), `telegram: env: environment variable "TELEGRAM_TOKEN" should not be empty`)
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `telegram: env: environment variable "TELEGRAM_TOKEN" should not be empty`)
 }
 
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
-		require.True(t, Pipe{}.Skip(testctx.New()))
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Telegram: []config.Telegram{{}},
+			},
+		})
+		require.True(t, Pipe{}.Skip(ctx, 0))
 	})
 
 	t.Run("dont skip", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Telegram: config.Telegram{
+				Telegram: []config.Telegram{{
 					Enabled: true,
-				},
+				}},
 			},
 		})
-		require.False(t, Pipe{}.Skip(ctx))
+		require.False(t, Pipe{}.Skip(ctx, 0))
 	})
 }
 
@@ -106,16 +151,55 @@ func TestGetMessageDetails(t *testing.T) {
 			config.Project{
 				ProjectName: "foo",
 				Announce: config.Announce{
-					Telegram: config.Telegram{
+					Telegram: []config.Telegram{{
 						ChatID: "1230212",
-					},
+					}},
 				},
 			},
 			testctx.WithCurrentTag("v1.0.0"),
 		)
 		require.NoError(t, Pipe{}.Default(ctx))
-		msg, _, err := getMessageDetails(ctx)
+		msg, _, err := getMessageDetails(ctx, ctx.Config.Announce.Telegram[0])
 		require.NoError(t, err)
 		require.Equal(t, "foo v1\\.0\\.0 is out\\! Check it out at ", msg)
 	})
+	t.Run("message template file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "announce.tmpl")
+		require.NoError(t, os.WriteFile(path, []byte("{{ .ProjectName }} {{ mdv2escape .Tag }} from file"), 0o644))
+		ctx := testctx.NewWithCfg(
+			config.Project{
+				ProjectName: "foo",
+				Announce: config.Announce{
+					Telegram: []config.Telegram{{
+						ChatID:              "1230212",
+						MessageTemplateFile: path,
+					}},
+				},
+			},
+			testctx.WithCurrentTag("v1.0.0"),
+		)
+		require.NoError(t, Pipe{}.Default(ctx))
+		msg, _, err := getMessageDetails(ctx, ctx.Config.Announce.Telegram[0])
+		require.NoError(t, err)
+		require.Equal(t, "foo v1\\.0\\.0 from file", msg)
+	})
+}
+
+func TestNewBot(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "goreleaser/dev (+foo)", r.Header.Get("User-Agent"))
+		assert.Equal(t, "/botmy-token/getMe", r.URL.Path)
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"username":"foo"}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := testctx.NewWithCfg(config.Project{
+		ProjectName: "foo",
+		HTTP: config.HTTP{
+			CABundle: testlib.ServerCert(t, srv),
+		},
+	})
+	bot, err := newBot(ctx, "my-token", srv.URL+"/bot%s/%s")
+	require.NoError(t, err)
+	require.Equal(t, "foo", bot.Self.UserName)
 }
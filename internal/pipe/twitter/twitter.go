@@ -1,13 +1,17 @@
 package twitter
 
 import (
+	stdctx "context"
 	"fmt"
+	"net/http"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
 	"github.com/dghubble/go-twitter/twitter"
 	"github.com/dghubble/oauth1"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -15,8 +19,14 @@ const defaultMessageTemplate = `{{ .ProjectName }} {{ .Tag }} is out! Check it o
 
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "twitter" }
-func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Announce.Twitter.Enabled }
+func (Pipe) String() string                        { return "twitter" }
+func (Pipe) Key() string                           { return "twitter" }
+func (Pipe) Len(ctx *context.Context) int          { return len(ctx.Config.Announce.Twitter) }
+func (Pipe) Skip(ctx *context.Context, i int) bool { return !ctx.Config.Announce.Twitter[i].Enabled }
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.Twitter[i].AnnounceConditions
+}
 
 type Config struct {
 	ConsumerKey    string `env:"TWITTER_CONSUMER_KEY,notEmpty"`
@@ -26,14 +36,21 @@ type Config struct {
 }
 
 func (Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.Twitter.MessageTemplate == "" {
-		ctx.Config.Announce.Twitter.MessageTemplate = defaultMessageTemplate
+	for i := range ctx.Config.Announce.Twitter {
+		conf := &ctx.Config.Announce.Twitter[i]
+		if conf.MessageTemplate == "" && conf.MessageTemplateFile == "" {
+			conf.MessageTemplate = defaultMessageTemplate
+		}
 	}
 	return nil
 }
 
-func (Pipe) Announce(ctx *context.Context) error {
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Twitter.MessageTemplate)
+func (Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.Twitter[i]
+	msg, err := tmpl.New(ctx).ApplyInlineOrFile(
+		conf.MessageTemplate,
+		conf.MessageTemplateFile,
+	)
 	if err != nil {
 		return fmt.Errorf("twitter: %w", err)
 	}
@@ -44,11 +61,25 @@ func (Pipe) Announce(ctx *context.Context) error {
 	}
 
 	log.Infof("posting: '%s'", msg)
-	config := oauth1.NewConfig(cfg.ConsumerKey, cfg.ConsumerSecret)
-	token := oauth1.NewToken(cfg.AccessToken, cfg.AccessSecret)
-	client := twitter.NewClient(config.Client(oauth1.NoContext, token))
+	httpClient, err := newHTTPClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("twitter: %w", err)
+	}
+	client := twitter.NewClient(httpClient)
 	if _, _, err := client.Statuses.Update(msg, nil); err != nil {
 		return fmt.Errorf("twitter: %w", err)
 	}
 	return nil
 }
+
+// newHTTPClient returns an HTTP client signing the requests with the given
+// credentials, on top of the shared HTTP client.
+func newHTTPClient(ctx *context.Context, cfg Config) (*http.Client, error) {
+	client, err := httpclient.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	config := oauth1.NewConfig(cfg.ConsumerKey, cfg.ConsumerSecret)
+	token := oauth1.NewToken(cfg.AccessToken, cfg.AccessSecret)
+	return config.Client(stdctx.WithValue(ctx, oauth1.HTTPClient, client), token), nil
+}
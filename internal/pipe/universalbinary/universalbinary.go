@@ -27,8 +27,13 @@ import (
 // Pipe for macos universal binaries.
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "universal binaries" }
-func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.UniversalBinaries) == 0 }
+func (Pipe) String() string { return "universal binaries" }
+
+// Skip universal binaries if none are configured, or if doing a partial
+// (single target) build, as only one darwin binary will be available.
+func (Pipe) Skip(ctx *context.Context) bool {
+	return len(ctx.Config.UniversalBinaries) == 0 || ctx.Partial
+}
 
 // Default sets the pipe defaults.
 func (Pipe) Default(ctx *context.Context) error {
@@ -51,7 +56,7 @@ func (Pipe) Default(ctx *context.Context) error {
 
 // Run the pipe.
 func (Pipe) Run(ctx *context.Context) error {
-	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	g := semerrgroup.NewSkipAware(semerrgroup.NewShared(ctx))
 	for _, unibin := range ctx.Config.UniversalBinaries {
 		g.Go(func() error {
 			opts := build.Options{
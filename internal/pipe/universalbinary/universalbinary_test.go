@@ -101,6 +101,13 @@ func TestSkip(t *testing.T) {
 		require.True(t, Pipe{}.Skip(testctx.New()))
 	})
 
+	t.Run("partial", func(t *testing.T) {
+		ctx := testctx.NewWithCfg(config.Project{
+			UniversalBinaries: []config.UniversalBinary{{}},
+		}, testctx.Partial)
+		require.True(t, Pipe{}.Skip(ctx))
+	})
+
 	t.Run("dont skip", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			UniversalBinaries: []config.UniversalBinary{{}},
@@ -15,6 +15,7 @@ type Pipe struct{}
 
 // String returns the description of the pipe.
 func (Pipe) String() string                 { return "http upload" }
+func (Pipe) Key() string                    { return "upload" }
 func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.Uploads) == 0 }
 
 // Default sets the pipe defaults.
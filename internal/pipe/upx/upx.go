@@ -29,8 +29,19 @@ func (Pipe) Default(ctx *context.Context) error {
 	return nil
 }
 func (Pipe) Skip(ctx *context.Context) bool { return len(ctx.Config.UPXs) == 0 }
+
+func (Pipe) Dependencies(ctx *context.Context) []string {
+	var cmds []string
+	for _, upx := range ctx.Config.UPXs {
+		if enabled, _ := tmpl.New(ctx).Bool(upx.Enabled); enabled {
+			cmds = append(cmds, upx.Binary)
+		}
+	}
+	return cmds
+}
+
 func (Pipe) Run(ctx *context.Context) error {
-	g := semerrgroup.NewSkipAware(semerrgroup.New(ctx.Parallelism))
+	g := semerrgroup.NewSkipAware(semerrgroup.NewShared(ctx))
 	for _, upx := range ctx.Config.UPXs {
 		enabled, err := tmpl.New(ctx).Bool(upx.Enabled)
 		if err != nil {
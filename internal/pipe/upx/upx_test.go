@@ -150,6 +150,17 @@ func TestEnabled(t *testing.T) {
 	})
 }
 
+func TestDependencies(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		UPXs: []config.UPX{
+			{Enabled: "true", Binary: "upx"},
+			{Enabled: "false", Binary: "disabled-upx"},
+			{Enabled: "{{ .Env.UPX }}", Binary: "templated-upx"},
+		},
+	}, testctx.WithEnv(map[string]string{"UPX": "true"}))
+	require.Equal(t, []string{"upx", "templated-upx"}, Pipe{}.Dependencies(ctx))
+}
+
 func TestUpxNotInstalled(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		UPXs: []config.UPX{
@@ -1,7 +1,6 @@
 package webhook
 
 import (
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -11,7 +10,9 @@ import (
 
 	"github.com/caarlos0/env/v11"
 	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
 	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -19,15 +20,20 @@ const (
 	defaultMessageTemplate = `{ "message": "{{ .ProjectName }} {{ .Tag }} is out! Check it out at {{ .ReleaseURL }}"}`
 	ContentTypeHeaderKey   = "Content-Type"
 	UserAgentHeaderKey     = "User-Agent"
-	UserAgentHeaderValue   = "goreleaser"
 	AuthorizationHeaderKey = "Authorization"
 	DefaultContentType     = "application/json; charset=utf-8"
 )
 
 type Pipe struct{}
 
-func (Pipe) String() string                 { return "webhook" }
-func (Pipe) Skip(ctx *context.Context) bool { return !ctx.Config.Announce.Webhook.Enabled }
+func (Pipe) String() string                        { return "webhook" }
+func (Pipe) Key() string                           { return "webhook" }
+func (Pipe) Len(ctx *context.Context) int          { return len(ctx.Config.Announce.Webhook) }
+func (Pipe) Skip(ctx *context.Context, i int) bool { return !ctx.Config.Announce.Webhook[i].Enabled }
+
+func (Pipe) Conditions(ctx *context.Context, i int) config.AnnounceConditions {
+	return ctx.Config.Announce.Webhook[i].AnnounceConditions
+}
 
 type Config struct {
 	BasicAuthHeader   string `env:"BASIC_AUTH_HEADER_VALUE"`
@@ -35,22 +41,26 @@ type Config struct {
 }
 
 func (p Pipe) Default(ctx *context.Context) error {
-	if ctx.Config.Announce.Webhook.MessageTemplate == "" {
-		ctx.Config.Announce.Webhook.MessageTemplate = defaultMessageTemplate
-	}
-	if ctx.Config.Announce.Webhook.ContentType == "" {
-		ctx.Config.Announce.Webhook.ContentType = DefaultContentType
+	for i := range ctx.Config.Announce.Webhook {
+		conf := &ctx.Config.Announce.Webhook[i]
+		if conf.MessageTemplate == "" && conf.MessageTemplateFile == "" {
+			conf.MessageTemplate = defaultMessageTemplate
+		}
+		if conf.ContentType == "" {
+			conf.ContentType = DefaultContentType
+		}
 	}
 	return nil
 }
 
-func (p Pipe) Announce(ctx *context.Context) error {
+func (p Pipe) Announce(ctx *context.Context, i int) error {
+	conf := ctx.Config.Announce.Webhook[i]
 	cfg, err := env.ParseAs[Config]()
 	if err != nil {
 		return fmt.Errorf("webhook: %w", err)
 	}
 
-	endpointURLConfig, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Webhook.EndpointURL)
+	endpointURLConfig, err := tmpl.New(ctx).Apply(conf.EndpointURL)
 	if err != nil {
 		return fmt.Errorf("webhook: %w", err)
 	}
@@ -66,16 +76,22 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		return fmt.Errorf("webhook: %w", err)
 	}
 
-	msg, err := tmpl.New(ctx).Apply(ctx.Config.Announce.Webhook.MessageTemplate)
+	msg, err := tmpl.New(ctx).ApplyInlineOrFile(
+		conf.MessageTemplate,
+		conf.MessageTemplateFile,
+	)
 	if err != nil {
 		return fmt.Errorf("webhook: %w", err)
 	}
 
 	log.Infof("posting: '%s'", msg)
-	customTransport := http.DefaultTransport.(*http.Transport).Clone()
-
-	customTransport.TLSClientConfig = &tls.Config{
-		InsecureSkipVerify: ctx.Config.Announce.Webhook.SkipTLSVerify,
+	customTransport, err := httpclient.Transport(ctx, httpclient.Options{
+		TLS: httpclient.TLSOptions{
+			SkipVerify: conf.SkipTLSVerify,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
 	}
 
 	client := &http.Client{
@@ -86,8 +102,14 @@ func (p Pipe) Announce(ctx *context.Context) error {
 	if err != nil {
 		return fmt.Errorf("webhook: %w", err)
 	}
-	req.Header.Add(ContentTypeHeaderKey, ctx.Config.Announce.Webhook.ContentType)
-	req.Header.Add(UserAgentHeaderKey, UserAgentHeaderValue)
+	ua, err := httpclient.UserAgent(ctx, "")
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	req.Header.Add(ContentTypeHeaderKey, conf.ContentType)
+	if !hasHeader(conf.Headers, UserAgentHeaderKey) {
+		req.Header.Add(UserAgentHeaderKey, ua)
+	}
 
 	if cfg.BasicAuthHeader != "" {
 		log.Debugf("set basic auth header")
@@ -97,7 +119,7 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		req.Header.Add(AuthorizationHeaderKey, cfg.BearerTokenHeader)
 	}
 
-	for key, value := range ctx.Config.Announce.Webhook.Headers {
+	for key, value := range conf.Headers {
 		log.Debugf("Header Key %s / Value %s", key, value)
 		req.Header.Add(key, value)
 	}
@@ -117,3 +139,12 @@ func (p Pipe) Announce(ctx *context.Context) error {
 		return fmt.Errorf("request failed with status %v", resp.Status)
 	}
 }
+
+func hasHeader(headers map[string]string, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
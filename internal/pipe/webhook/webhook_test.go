@@ -23,33 +23,33 @@ func TestStringer(t *testing.T) {
 func TestNoEndpoint(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Webhook: config.Webhook{},
+			Webhook: []config.Webhook{{}},
 		},
 	})
-	require.EqualError(t, Pipe{}.Announce(ctx), `webhook: no endpoint url`)
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `webhook: no endpoint url`)
 }
 
 func TestMalformedEndpoint(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Webhook: config.Webhook{
+			Webhook: []config.Webhook{{
 				EndpointURL: "httxxx://example.com",
-			},
+			}},
 		},
 	})
-	require.EqualError(t, Pipe{}.Announce(ctx), `webhook: Post "httxxx://example.com": unsupported protocol scheme "httxxx"`)
+	require.EqualError(t, Pipe{}.Announce(ctx, 0), `webhook: Post "httxxx://example.com": unsupported protocol scheme "httxxx"`)
 }
 
 func TestAnnounceInvalidMessageTemplate(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		Announce: config.Announce{
-			Webhook: config.Webhook{
+			Webhook: []config.Webhook{{
 				EndpointURL:     "https://example.com/webhook",
 				MessageTemplate: "{{ .Foo }",
-			},
+			}},
 		},
 	})
-	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx))
+	testlib.RequireTemplateError(t, Pipe{}.Announce(ctx, 0))
 }
 
 type WebHookServerMockMessage struct {
@@ -80,13 +80,13 @@ func TestAnnounceWebhook(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		ProjectName: "webhook-test",
 		Announce: config.Announce{
-			Webhook: config.Webhook{
+			Webhook: []config.Webhook{{
 				EndpointURL:     srv.URL,
 				MessageTemplate: "{{ .ProjectName }}",
-			},
+			}},
 		},
 	})
-	require.NoError(t, Pipe{}.Announce(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx, 0))
 }
 
 func TestAnnounceTLSWebhook(t *testing.T) {
@@ -110,14 +110,14 @@ func TestAnnounceTLSWebhook(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		ProjectName: "webhook-test",
 		Announce: config.Announce{
-			Webhook: config.Webhook{
+			Webhook: []config.Webhook{{
 				EndpointURL:     srv.URL,
 				MessageTemplate: "{{ .ProjectName }}",
 				SkipTLSVerify:   true,
-			},
+			}},
 		},
 	})
-	require.NoError(t, Pipe{}.Announce(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx, 0))
 }
 
 func TestAnnounceTLSCheckCertWebhook(t *testing.T) {
@@ -138,13 +138,13 @@ func TestAnnounceTLSCheckCertWebhook(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		ProjectName: "webhook-test",
 		Announce: config.Announce{
-			Webhook: config.Webhook{
+			Webhook: []config.Webhook{{
 				EndpointURL:   srv.URL,
 				SkipTLSVerify: false,
-			},
+			}},
 		},
 	})
-	require.Error(t, Pipe{}.Announce(ctx))
+	require.Error(t, Pipe{}.Announce(ctx, 0))
 }
 
 func TestAnnounceBasicAuthWebhook(t *testing.T) {
@@ -174,14 +174,14 @@ func TestAnnounceBasicAuthWebhook(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		ProjectName: "webhook-test",
 		Announce: config.Announce{
-			Webhook: config.Webhook{
+			Webhook: []config.Webhook{{
 				EndpointURL:     srv.URL,
 				MessageTemplate: "{{ .ProjectName }}",
-			},
+			}},
 		},
 	})
 	t.Setenv("BASIC_AUTH_HEADER_VALUE", fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte("user:pass"))))
-	require.NoError(t, Pipe{}.Announce(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx, 0))
 }
 
 func TestAnnounceAdditionalHeadersWebhook(t *testing.T) {
@@ -210,31 +210,67 @@ func TestAnnounceAdditionalHeadersWebhook(t *testing.T) {
 	ctx := testctx.NewWithCfg(config.Project{
 		ProjectName: "webhook-test",
 		Announce: config.Announce{
-			Webhook: config.Webhook{
+			Webhook: []config.Webhook{{
 				EndpointURL:     srv.URL,
 				MessageTemplate: "{{ .ProjectName }}",
 				Headers: map[string]string{
 					"X-Custom-Header": "custom-value",
-				},
+				}},
 			},
 		},
 	})
-	require.NoError(t, Pipe{}.Announce(ctx))
+	require.NoError(t, Pipe{}.Announce(ctx, 0))
+}
+
+func TestAnnounceWebhookUserAgent(t *testing.T) {
+	for name, tt := range map[string]struct {
+		headers  map[string]string
+		expected string
+	}{
+		"default":  {nil, "goreleaser/dev (+webhook-test)"},
+		"override": {map[string]string{"user-agent": "custom"}, "custom"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				require.Equal(t, []string{tt.expected}, r.Header.Values("User-Agent"))
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer srv.Close()
+
+			ctx := testctx.NewWithCfg(config.Project{
+				ProjectName: "webhook-test",
+				Announce: config.Announce{
+					Webhook: []config.Webhook{{
+						EndpointURL:     srv.URL,
+						MessageTemplate: "{{ .ProjectName }}",
+						Headers:         tt.headers,
+					}},
+				},
+			})
+			require.NoError(t, Pipe{}.Announce(ctx, 0))
+		})
+	}
 }
 
 func TestSkip(t *testing.T) {
 	t.Run("skip", func(t *testing.T) {
-		require.True(t, Pipe{}.Skip(testctx.New()))
+		ctx := testctx.NewWithCfg(config.Project{
+			Announce: config.Announce{
+				Webhook: []config.Webhook{{}},
+			},
+		})
+		require.True(t, Pipe{}.Skip(ctx, 0))
 	})
 
 	t.Run("dont skip", func(t *testing.T) {
 		ctx := testctx.NewWithCfg(config.Project{
 			Announce: config.Announce{
-				Webhook: config.Webhook{
+				Webhook: []config.Webhook{{
 					Enabled: true,
-				},
+				}},
 			},
 		})
-		require.False(t, Pipe{}.Skip(ctx))
+		require.False(t, Pipe{}.Skip(ctx, 0))
 	})
 }
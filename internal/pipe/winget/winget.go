@@ -49,6 +49,7 @@ const wingetConfigExtra = "WingetConfig"
 type Pipe struct{}
 
 func (Pipe) String() string        { return "winget" }
+func (Pipe) Key() string           { return "winget" }
 func (Pipe) ContinueOnError() bool { return true }
 func (p Pipe) Skip(ctx *context.Context) bool {
 	return skips.Any(ctx, skips.Winget) || len(ctx.Config.Winget) == 0
@@ -354,7 +355,16 @@ func doPublish(ctx *context.Context, cl client.Client, wingets []*artifact.Artif
 		return fmt.Errorf("client does not support pull requests")
 	}
 
-	return pcl.OpenPullRequest(ctx, base, repo, msg, winget.Repository.PullRequest.Draft)
+	url, err := pcl.OpenPullRequest(ctx, base, repo, msg, winget.Repository.PullRequest.Draft, winget.Repository.PullRequest.AutoMerge)
+	if err != nil {
+		return err
+	}
+	if url != "" {
+		for _, manifest := range wingets {
+			manifest.Extra[artifact.ExtraPullRequest] = url
+		}
+	}
+	return nil
 }
 
 func langserverLineFor(tp artifact.Type) string {
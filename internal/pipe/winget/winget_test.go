@@ -701,6 +701,7 @@ func TestRunPipe(t *testing.T) {
 			})
 
 			client := client.NewMock()
+			client.PullRequestURL = "https://github.com/foo/bar/pull/1"
 			pipe := Pipe{}
 
 			// default
@@ -759,6 +760,9 @@ func TestRunPipe(t *testing.T) {
 			if tt.winget.Repository.PullRequest.Enabled {
 				require.True(t, client.SyncedFork)
 				require.True(t, client.OpenedPullRequest)
+				for _, winget := range ctx.Artifacts.Filter(artifact.ByType(artifact.WingetVersion)).List() {
+					require.Equal(t, "https://github.com/foo/bar/pull/1", artifact.ExtraOr(*winget, artifact.ExtraPullRequest, ""))
+				}
 			}
 		})
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/announce"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/appimage"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/archive"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/aur"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/before"
@@ -13,29 +14,38 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/changelog"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/checksums"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/chocolatey"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/completions"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/defaults"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/dist"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/docker"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/effectiveconfig"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/env"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/flatpak"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/git"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/gomod"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/krew"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/metadata"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/msi"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nfpm"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/nightly"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nix"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/notary"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/nsis"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/partial"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/prebuild"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/preflight"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/publish"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/release"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/reportsizes"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sbom"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/scan"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/scoop"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/semver"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sign"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/snapcraft"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/snapshot"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sourcearchive"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/summary"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/universalbinary"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/upx"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/winget"
@@ -60,12 +70,18 @@ var BuildPipeline = []Piper{
 	git.Pipe{},
 	// parse current tag to a semver
 	semver.Pipe{},
+	// create the tag, if asked to
+	git.TagPipe{},
 	// load default configs
 	defaults.Pipe{},
+	// check needed tools are installed, if asked to
+	preflight.Pipe{},
 	// setup things for partial builds/releases
 	partial.Pipe{},
 	// snapshot version handling
 	snapshot.Pipe{},
+	// nightly version handling
+	nightly.Pipe{},
 	// run global hooks before build
 	before.Pipe{},
 	// ensure ./dist is clean
@@ -92,6 +108,24 @@ var BuildPipeline = []Piper{
 	notary.MacOS{},
 	// upx
 	upx.Pipe{},
+	// shell completions and man pages
+	completions.Pipe{},
+}
+
+// ReleaseNotesPipeline is the pipeline run by goreleaser release-notes.
+//
+//nolint:gochecknoglobals
+var ReleaseNotesPipeline = []Piper{
+	// load and validate environment variables
+	env.Pipe{},
+	// get and validate git repo state
+	git.Pipe{},
+	// parse current tag to a semver
+	semver.Pipe{},
+	// load default configs
+	defaults.Pipe{},
+	// builds the release changelog
+	changelog.Pipe{},
 }
 
 // BuildCmdPipeline is the pipeline run by goreleaser build.
@@ -101,6 +135,7 @@ var BuildCmdPipeline = append(
 	BuildPipeline,
 	reportsizes.Pipe{},
 	metadata.ArtifactsPipe{},
+	summary.Pipe{},
 )
 
 // Pipeline contains all pipe implementations in order.
@@ -110,6 +145,10 @@ var Pipeline = append(
 	BuildPipeline,
 	// builds the release changelog
 	changelog.Pipe{},
+	// writes the release notes file to dist
+	release.NotesPipe{},
+	// sign binaries before archiving them
+	sign.BinaryPipe{},
 	// archive in tar.gz, zip or binary (which does no archiving at all)
 	archive.Pipe{},
 	// archive the source code using git-archive
@@ -118,6 +157,14 @@ var Pipeline = append(
 	nfpm.Pipe{},
 	// archive via snapcraft (snap)
 	snapcraft.Pipe{},
+	// archive via flatpak-builder (flatpak)
+	flatpak.Pipe{},
+	// archive via appimagetool (AppImage)
+	appimage.Pipe{},
+	// windows installers via wixl or the WiX toolset (msi)
+	msi.Pipe{},
+	// windows installers via makensis (setup exe)
+	nsis.Pipe{},
 	// create SBOMs of artifacts
 	sbom.Pipe{},
 	// checksums of the files
@@ -142,10 +189,14 @@ var Pipeline = append(
 	reportsizes.Pipe{},
 	// create and push docker images
 	docker.Pipe{},
+	// scan docker images for vulnerabilities
+	scan.Pipe{},
 	// publishes artifacts
 	publish.New(),
 	// creates a artifacts.json files in the dist directory
 	metadata.ArtifactsPipe{},
 	// announce releases
 	announce.Pipe{},
+	// reports timings, artifacts and publishers of the run
+	summary.Pipe{},
 )
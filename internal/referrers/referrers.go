@@ -0,0 +1,189 @@
+// Package referrers attaches SBOMs to pushed images, as OCI referrers.
+package referrers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/caarlos0/log"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/httpclient"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+const (
+	ToolOras   = "oras"
+	ToolCosign = "cosign"
+)
+
+// cmd runs the attach tools.
+var cmd shell.Runner = shell.StdRunner{}
+
+// supportsReferrers checks whether the registry of the given repository
+// implements the OCI referrers API.
+var supportsReferrers = func(ctx *context.Context, repo name.Repository, digest string) bool {
+	auth, err := authn.DefaultKeychain.Resolve(repo)
+	if err != nil {
+		auth = authn.Anonymous
+	}
+	base, err := httpclient.Transport(ctx, httpclient.Options{})
+	if err != nil {
+		log.WithError(err).Debug("could not check for referrers support")
+		return false
+	}
+	tr, err := transport.NewWithContext(ctx, repo.Registry, auth, base, []string{repo.Scope(transport.PullScope)})
+	if err != nil {
+		log.WithError(err).Debug("could not check for referrers support")
+		return false
+	}
+	u := url.URL{
+		Scheme: repo.Scheme(),
+		Host:   repo.RegistryStr(),
+		Path:   fmt.Sprintf("/v2/%s/referrers/%s", repo.RepositoryStr(), digest),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Accept", string(types.OCIImageIndex))
+	resp, err := (&http.Client{Transport: tr}).Do(req)
+	if err != nil {
+		log.WithError(err).Debug("could not check for referrers support")
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK &&
+		strings.HasPrefix(resp.Header.Get("Content-Type"), string(types.OCIImageIndex))
+}
+
+// Default sets the defaults of the given config.
+func Default(cfg *config.SBOMAttach) error {
+	if len(cfg.IDs) == 0 {
+		return nil
+	}
+	if cfg.Tool == "" {
+		cfg.Tool = ToolOras
+	}
+	if cfg.Tool != ToolOras && cfg.Tool != ToolCosign {
+		return fmt.Errorf("attach_sboms: invalid tool: %s, valid options are [%s %s]", cfg.Tool, ToolCosign, ToolOras)
+	}
+	return nil
+}
+
+// Dependencies returns the binaries needed to attach the SBOMs.
+func Dependencies(cfg config.SBOMAttach) []string {
+	if len(cfg.IDs) == 0 {
+		return nil
+	}
+	return []string{cfg.Tool}
+}
+
+// Attacher attaches SBOMs to images, once per image digest.
+type Attacher struct {
+	lock sync.Mutex
+	done map[string]bool
+}
+
+// New creates a new Attacher.
+func New() *Attacher {
+	return &Attacher{done: map[string]bool{}}
+}
+
+// Attach attaches the SBOMs matching the given config to the given image
+// digest.
+//
+// The SBOMs are attached as OCI referrers when the registry supports it, and
+// with the tag schema otherwise.
+func (a *Attacher) Attach(ctx *context.Context, cfg config.SBOMAttach, image, digest string) error {
+	if len(cfg.IDs) == 0 {
+		return nil
+	}
+	if digest == "" {
+		return fmt.Errorf("attach_sboms: no digest for %s", image)
+	}
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return fmt.Errorf("attach_sboms: %w", err)
+	}
+	subject := ref.Context().Name() + "@" + digest
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.done[subject] {
+		return nil
+	}
+
+	sboms := ctx.Artifacts.Filter(artifact.And(
+		artifact.ByType(artifact.SBOM),
+		artifact.ByIDs(cfg.IDs...),
+	)).List()
+	if len(sboms) == 0 {
+		log.WithField("image", image).Warn("no sboms to attach")
+		return nil
+	}
+
+	referrers := cfg.Tool == ToolOras && supportsReferrers(ctx, ref.Context(), digest)
+	if cfg.Tool == ToolOras && !referrers {
+		log.WithField("registry", ref.Context().RegistryStr()).
+			Warn("registry does not support the referrers API, falling back to the tag schema")
+	}
+
+	for _, sbom := range sboms {
+		log.WithField("image", subject).WithField("sbom", sbom.Name).Info("attaching")
+		dir, args := attachArgs(cfg.Tool, referrers, subject, sbom.Path)
+		if out, err := cmd.Exec(ctx, shell.Cmd{
+			Name: cfg.Tool,
+			Args: args,
+			Dir:  dir,
+			Env:  append(os.Environ(), ctx.Env.Strings()...),
+		}); err != nil {
+			return fmt.Errorf("failed to attach %s to %s: %w: %s", sbom.Name, subject, err, string(out))
+		}
+	}
+	a.done[subject] = true
+	return nil
+}
+
+// attachArgs returns the working directory and arguments to run the given
+// tool with.
+func attachArgs(tool string, referrers bool, subject, path string) (string, []string) {
+	if tool == ToolCosign {
+		return "", []string{"attach", "sbom", "--sbom", path, "--type", sbomType(path), subject}
+	}
+	spec := "v1.1-referrers-tag"
+	if referrers {
+		spec = "v1.1-referrers-api"
+	}
+	// oras only accepts relative paths, so we run it in the sbom directory.
+	mediaType := "application/spdx+json"
+	if sbomType(path) == "cyclonedx" {
+		mediaType = "application/vnd.cyclonedx+json"
+	}
+	return filepath.Dir(path), []string{
+		"attach",
+		"--artifact-type", mediaType,
+		"--distribution-spec", spec,
+		subject,
+		filepath.Base(path) + ":" + mediaType,
+	}
+}
+
+// sbomType guesses the SBOM format from its file name.
+func sbomType(path string) string {
+	name := strings.ToLower(filepath.Base(path))
+	if strings.Contains(name, "cdx") || strings.Contains(name, "cyclonedx") {
+		return "cyclonedx"
+	}
+	return "spdx"
+}
@@ -0,0 +1,221 @@
+package referrers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/goreleaser/goreleaser/v2/internal/artifact"
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+const digest = "sha256:b3ebf0fda4d6a2a3e8282e202b4e5b86f7e4bdc3a8bf6a517f0d7b8a1a5c0f17"
+
+func TestDefault(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		cfg := config.SBOMAttach{}
+		require.NoError(t, Default(&cfg))
+		require.Empty(t, cfg.Tool)
+		require.Empty(t, Dependencies(cfg))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		cfg := config.SBOMAttach{IDs: []string{"foo"}}
+		require.NoError(t, Default(&cfg))
+		require.Equal(t, ToolOras, cfg.Tool)
+		require.Equal(t, []string{"oras"}, Dependencies(cfg))
+	})
+
+	t.Run("invalid tool", func(t *testing.T) {
+		cfg := config.SBOMAttach{IDs: []string{"foo"}, Tool: "nope"}
+		require.EqualError(t, Default(&cfg), "attach_sboms: invalid tool: nope, valid options are [cosign oras]")
+	})
+}
+
+func TestAttach(t *testing.T) {
+	dist := t.TempDir()
+	for name, tt := range map[string]struct {
+		tool      string
+		referrers bool
+		dir       string
+		expected  []string
+	}{
+		"oras referrers": {
+			tool:      ToolOras,
+			referrers: true,
+			dir:       dist,
+			expected: []string{
+				"oras attach --artifact-type application/spdx+json --distribution-spec v1.1-referrers-api ghcr.io/owner/img@" + digest + " foo.spdx.json:application/spdx+json",
+				"oras attach --artifact-type application/vnd.cyclonedx+json --distribution-spec v1.1-referrers-api ghcr.io/owner/img@" + digest + " foo.cdx.json:application/vnd.cyclonedx+json",
+			},
+		},
+		"oras tag schema": {
+			tool: ToolOras,
+			dir:  dist,
+			expected: []string{
+				"oras attach --artifact-type application/spdx+json --distribution-spec v1.1-referrers-tag ghcr.io/owner/img@" + digest + " foo.spdx.json:application/spdx+json",
+				"oras attach --artifact-type application/vnd.cyclonedx+json --distribution-spec v1.1-referrers-tag ghcr.io/owner/img@" + digest + " foo.cdx.json:application/vnd.cyclonedx+json",
+			},
+		},
+		"cosign": {
+			tool:      ToolCosign,
+			referrers: true,
+			expected: []string{
+				"cosign attach sbom --sbom " + filepath.Join(dist, "foo.spdx.json") + " --type spdx ghcr.io/owner/img@" + digest,
+				"cosign attach sbom --sbom " + filepath.Join(dist, "foo.cdx.json") + " --type cyclonedx ghcr.io/owner/img@" + digest,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setSupportsReferrers(t, tt.referrers)
+			calls := fakeCommands(t, nil)
+			ctx := testctx.New()
+			addSBOMs(ctx, dist)
+
+			cfg := config.SBOMAttach{IDs: []string{"foo"}, Tool: tt.tool}
+			attacher := New()
+			require.NoError(t, attacher.Attach(ctx, cfg, "ghcr.io/owner/img:v1.0.0", digest))
+			// same digest, different tag.
+			require.NoError(t, attacher.Attach(ctx, cfg, "ghcr.io/owner/img:latest", digest))
+			require.Equal(t, tt.expected, calls.Lines())
+			for _, c := range calls.Cmds() {
+				require.Equal(t, tt.dir, c.Dir)
+			}
+		})
+	}
+}
+
+func TestAttachEnv(t *testing.T) {
+	t.Setenv("ORAS_PASSWORD", "from-os")
+	setSupportsReferrers(t, true)
+	calls := fakeCommands(t, nil)
+
+	ctx := testctx.New(testctx.WithEnv(map[string]string{"ORAS_PASSWORD": "from-config"}))
+	addSBOMs(ctx, t.TempDir())
+	cfg := config.SBOMAttach{IDs: []string{"foo"}, Tool: ToolOras}
+	require.NoError(t, New().Attach(ctx, cfg, "ghcr.io/owner/img:v1.0.0", digest))
+	env := calls.Cmds()[0].Env
+
+	// os/exec uses the last occurrence, so the environment of the context
+	// takes precedence over the one of the process.
+	require.Less(t, slices.Index(env, "ORAS_PASSWORD=from-os"), slices.Index(env, "ORAS_PASSWORD=from-config"))
+	require.NotEqual(t, -1, slices.Index(env, "ORAS_PASSWORD=from-os"))
+}
+
+func TestAttachNoop(t *testing.T) {
+	calls := fakeCommands(t, nil)
+	ctx := testctx.New()
+	addSBOMs(ctx, t.TempDir())
+
+	t.Run("no ids", func(t *testing.T) {
+		require.NoError(t, New().Attach(ctx, config.SBOMAttach{}, "ghcr.io/owner/img:v1.0.0", ""))
+	})
+
+	t.Run("no sboms", func(t *testing.T) {
+		require.NoError(t, New().Attach(ctx, config.SBOMAttach{IDs: []string{"nope"}, Tool: ToolOras}, "ghcr.io/owner/img:v1.0.0", digest))
+	})
+
+	require.Empty(t, calls.Cmds())
+}
+
+func TestAttachErrors(t *testing.T) {
+	setSupportsReferrers(t, true)
+	cfg := config.SBOMAttach{IDs: []string{"foo"}, Tool: ToolOras}
+
+	t.Run("no digest", func(t *testing.T) {
+		err := New().Attach(testctx.New(), cfg, "ghcr.io/owner/img:v1.0.0", "")
+		require.EqualError(t, err, "attach_sboms: no digest for ghcr.io/owner/img:v1.0.0")
+	})
+
+	t.Run("invalid image", func(t *testing.T) {
+		err := New().Attach(testctx.New(), cfg, "Nope:::", digest)
+		require.ErrorContains(t, err, "attach_sboms: ")
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		fakeCommands(t, errors.New("fake error"))
+		ctx := testctx.New()
+		addSBOMs(ctx, t.TempDir())
+		err := New().Attach(ctx, cfg, "ghcr.io/owner/img:v1.0.0", digest)
+		require.EqualError(t, err, "failed to attach foo.spdx.json to ghcr.io/owner/img@"+digest+": fake error: some output")
+	})
+}
+
+func TestSupportsReferrers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/":
+			w.WriteHeader(http.StatusOK)
+		case "/v2/owner/supported/referrers/" + digest:
+			w.Header().Set("Content-Type", string(types.OCIImageIndex))
+			_, _ = w.Write([]byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	repo := func(s string) name.Repository {
+		r, err := name.NewRepository(host+"/owner/"+s, name.Insecure)
+		require.NoError(t, err)
+		return r
+	}
+
+	require.True(t, supportsReferrers(testctx.New(), repo("supported"), digest))
+	require.False(t, supportsReferrers(testctx.New(), repo("unsupported"), digest))
+}
+
+func setSupportsReferrers(tb testing.TB, supported bool) {
+	tb.Helper()
+	previous := supportsReferrers
+	supportsReferrers = func(*context.Context, name.Repository, string) bool {
+		return supported
+	}
+	tb.Cleanup(func() {
+		supportsReferrers = previous
+	})
+}
+
+func fakeCommands(tb testing.TB, err error) *shell.MockRunner {
+	tb.Helper()
+	mock, restore := shell.Mock(&cmd, func(shell.Cmd) ([]byte, error) {
+		if err != nil {
+			return []byte("some output"), err
+		}
+		return nil, nil
+	})
+	tb.Cleanup(restore)
+	return mock
+}
+
+func addSBOMs(ctx *context.Context, dist string) {
+	for _, name := range []string{"foo.spdx.json", "foo.cdx.json"} {
+		ctx.Artifacts.Add(&artifact.Artifact{
+			Name: name,
+			Path: filepath.Join(dist, name),
+			Type: artifact.SBOM,
+			Extra: map[string]interface{}{
+				artifact.ExtraID: "foo",
+			},
+		})
+	}
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Name: "bar.spdx.json",
+		Path: filepath.Join(dist, "bar.spdx.json"),
+		Type: artifact.SBOM,
+		Extra: map[string]interface{}{
+			artifact.ExtraID: "bar",
+		},
+	})
+}
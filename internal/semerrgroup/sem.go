@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/hashicorp/go-multierror"
 	"golang.org/x/sync/errgroup"
 )
@@ -57,6 +58,83 @@ func New(size int) Group {
 	return &g
 }
 
+// NewShared returns a new Group bounded by the workers shared by all the
+// pipes (see context.Context.AcquireWorker), so the total number of tasks
+// running at the same time is bounded by the parallelism, no matter how many
+// groups are running them.
+//
+// Each task waits for a worker in its own goroutine, so Go never blocks, and
+// tasks start as soon as any worker is free.
+// Wait runs the tasks still waiting for a worker in the calling goroutine, so
+// nested groups, whose callers already hold a worker, never wait on each
+// other.
+// Without workers (parallelism 1), tasks run in the calling goroutine.
+func NewShared(ctx *context.Context) Group {
+	return &sharedGroup{ctx: ctx}
+}
+
+type sharedGroup struct {
+	ctx     *context.Context
+	lock    sync.Mutex
+	pending []func() error
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	err     error
+}
+
+func (g *sharedGroup) Go(fn func() error) {
+	if !g.ctx.HasWorkers() {
+		g.setErr(fn())
+		return
+	}
+	g.wg.Add(1)
+	g.lock.Lock()
+	g.pending = append(g.pending, fn)
+	g.lock.Unlock()
+	go func() {
+		if !g.ctx.AcquireWorker() {
+			// context is done, the task is run by Wait.
+			return
+		}
+		defer g.ctx.ReleaseWorker()
+		g.runNext()
+	}()
+}
+
+// runNext runs the next pending task, if any, returning whether it did.
+func (g *sharedGroup) runNext() bool {
+	g.lock.Lock()
+	if len(g.pending) == 0 {
+		g.lock.Unlock()
+		return false
+	}
+	fn := g.pending[0]
+	g.pending = g.pending[1:]
+	g.lock.Unlock()
+
+	defer g.wg.Done()
+	g.setErr(fn())
+	return true
+}
+
+func (g *sharedGroup) setErr(err error) {
+	if err == nil {
+		return
+	}
+	g.errOnce.Do(func() {
+		g.err = err
+	})
+}
+
+// Wait waits for all the tasks to finish, and returns the first error
+// encountered.
+func (g *sharedGroup) Wait() error {
+	for g.runNext() {
+	}
+	g.wg.Wait()
+	return g.err
+}
+
 var _ Group = &skipAwareGroup{}
 
 // NewSkipAware returns a new Group of a given size and aware of pipe skips.
@@ -3,10 +3,13 @@ package semerrgroup
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/goreleaser/goreleaser/v2/internal/pipe"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
 	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/require"
 )
@@ -167,3 +170,92 @@ func TestSemaphoreSkipAndRealError(t *testing.T) {
 	})
 	require.EqualError(t, g.Wait(), "errrrrr")
 }
+
+// TestSharedBound checks that nested groups don't go over the parallelism,
+// nor wait on each other.
+func TestSharedBound(t *testing.T) {
+	ctx := context.New(config.Project{})
+	ctx.Parallelism = 3
+
+	var running, maxRunning atomic.Int32
+	g := NewShared(ctx)
+	for range 20 {
+		g.Go(func() error {
+			nested := NewShared(ctx)
+			for range 4 {
+				nested.Go(func() error {
+					n := running.Add(1)
+					defer running.Add(-1)
+					for {
+						m := maxRunning.Load()
+						if n <= m || maxRunning.CompareAndSwap(m, n) {
+							break
+						}
+					}
+					time.Sleep(time.Millisecond)
+					return nil
+				})
+			}
+			return nested.Wait()
+		})
+	}
+	require.NoError(t, g.Wait())
+	require.LessOrEqual(t, maxRunning.Load(), int32(3))
+}
+
+func TestSharedOrder(t *testing.T) {
+	ctx := context.New(config.Project{})
+	ctx.Parallelism = 1
+	g := NewShared(ctx)
+	output := []int{}
+	for i := range 10 {
+		g.Go(func() error {
+			output = append(output, i)
+			return nil
+		})
+	}
+	require.NoError(t, g.Wait())
+	require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, output)
+}
+
+func TestSharedError(t *testing.T) {
+	ctx := context.New(config.Project{})
+	g := NewShared(ctx)
+	var count atomic.Int32
+	for range 10 {
+		g.Go(func() error {
+			count.Add(1)
+			return fmt.Errorf("fake err")
+		})
+	}
+	require.EqualError(t, g.Wait(), "fake err")
+	require.Equal(t, int32(10), count.Load())
+}
+
+// TestSharedNoWaves checks that a long task does not keep the other tasks
+// from starting while workers are free.
+func TestSharedNoWaves(t *testing.T) {
+	ctx := context.New(config.Project{})
+	ctx.Parallelism = 3
+
+	var shortDone atomic.Int32
+	var shortsBeforeLong int32
+	g := NewShared(ctx)
+	for i := range 12 {
+		if i == 2 {
+			g.Go(func() error {
+				time.Sleep(300 * time.Millisecond)
+				shortsBeforeLong = shortDone.Load()
+				return nil
+			})
+			continue
+		}
+		g.Go(func() error {
+			time.Sleep(10 * time.Millisecond)
+			shortDone.Add(1)
+			return nil
+		})
+	}
+	require.NoError(t, g.Wait())
+	require.Equal(t, int32(11), shortsBeforeLong)
+}
@@ -0,0 +1,52 @@
+package shell
+
+import (
+	"slices"
+
+	"github.com/caarlos0/go-shellwords"
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// RunHooks runs the given hooks, one after the other, with the given
+// environment plus their own env.
+//
+// The env, dir and cmd of each hook are templated with a template from
+// newTemplate, which also has the environment of the hook in .Env.
+func RunHooks(ctx *context.Context, hooks config.Hooks, env []string, newTemplate func() *tmpl.Template) error {
+	for _, hook := range hooks {
+		tpl := newTemplate()
+		hookEnv := slices.Clone(env)
+		for _, rawEnv := range hook.Env {
+			e, err := tpl.Apply(rawEnv)
+			if err != nil {
+				return err
+			}
+			hookEnv = append(hookEnv, e)
+		}
+
+		tpl = tpl.WithEnvS(hookEnv)
+		dir, err := tpl.Apply(hook.Dir)
+		if err != nil {
+			return err
+		}
+
+		sh, err := tpl.Apply(hook.Cmd)
+		if err != nil {
+			return err
+		}
+
+		log.WithField("hook", sh).Info("running hook")
+		cmd, err := shellwords.Parse(sh)
+		if err != nil {
+			return err
+		}
+
+		if err := Run(ctx, dir, cmd, hookEnv, hook.Output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
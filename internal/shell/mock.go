@@ -0,0 +1,58 @@
+package shell
+
+import (
+	"sync"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// MockRunner is a Runner that records the commands instead of executing
+// them, meant to be used in tests.
+type MockRunner struct {
+	// Fn, if set, is called with each command, and should return its output
+	// and create the files the real command would.
+	Fn func(cmd Cmd) ([]byte, error)
+
+	lock sync.Mutex
+	cmds []Cmd
+}
+
+var _ Runner = &MockRunner{}
+
+// Mock replaces the given runner with a MockRunner calling fn, returning it
+// and a function that restores the previous runner.
+func Mock(runner *Runner, fn func(cmd Cmd) ([]byte, error)) (*MockRunner, func()) {
+	previous := *runner
+	mock := &MockRunner{Fn: fn}
+	*runner = mock
+	return mock, func() {
+		*runner = previous
+	}
+}
+
+// Exec records the given command and calls Fn, if set.
+func (m *MockRunner) Exec(_ *context.Context, cmd Cmd) ([]byte, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.cmds = append(m.cmds, cmd)
+	if m.Fn == nil {
+		return nil, nil
+	}
+	return m.Fn(cmd)
+}
+
+// Cmds returns the commands executed so far.
+func (m *MockRunner) Cmds() []Cmd {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]Cmd(nil), m.cmds...)
+}
+
+// Lines returns the command lines executed so far.
+func (m *MockRunner) Lines() []string {
+	var lines []string
+	for _, cmd := range m.Cmds() {
+		lines = append(lines, cmd.String())
+	}
+	return lines
+}
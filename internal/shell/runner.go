@@ -0,0 +1,60 @@
+package shell
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+)
+
+// Cmd is an external command to be executed by a Runner.
+type Cmd struct {
+	Name string
+	Args []string
+
+	// Dir is the working directory, the current one if empty.
+	Dir string
+
+	// Env is the environment, the one of the current process if nil.
+	Env []string
+}
+
+// String returns the command line.
+func (c Cmd) String() string {
+	return strings.Join(append([]string{c.Name}, c.Args...), " ")
+}
+
+// Runner executes external commands, returning their combined output.
+//
+// Pipes keep theirs in a package variable, so tests can replace it with
+// Mock.
+type Runner interface {
+	Exec(ctx *context.Context, cmd Cmd) ([]byte, error)
+}
+
+// RunnerFunc is a function that can be used as a Runner.
+type RunnerFunc func(ctx *context.Context, cmd Cmd) ([]byte, error)
+
+// Exec calls f.
+func (f RunnerFunc) Exec(ctx *context.Context, cmd Cmd) ([]byte, error) {
+	return f(ctx, cmd)
+}
+
+// StdRunner executes the commands with os/exec.
+type StdRunner struct{}
+
+var _ Runner = StdRunner{}
+
+// Exec executes the given command.
+func (StdRunner) Exec(ctx *context.Context, cmd Cmd) ([]byte, error) {
+	log.WithField("cmd", cmd.Name).
+		WithField("args", cmd.Args).
+		WithField("dir", cmd.Dir).
+		Debug("running")
+	/* #nosec */
+	c := exec.CommandContext(ctx, cmd.Name, cmd.Args...)
+	c.Dir = cmd.Dir
+	c.Env = cmd.Env
+	return c.CombinedOutput()
+}
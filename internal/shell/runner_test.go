@@ -0,0 +1,81 @@
+package shell_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/shell"
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdRunner(t *testing.T) {
+	t.Run("output", func(t *testing.T) {
+		out, err := shell.StdRunner{}.Exec(testctx.New(), shell.Cmd{
+			Name: "sh",
+			Args: []string{"-c", "echo out; echo err >&2"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "out\nerr\n", string(out))
+	})
+
+	t.Run("dir and env", func(t *testing.T) {
+		dir := t.TempDir()
+		out, err := shell.StdRunner{}.Exec(testctx.New(), shell.Cmd{
+			Name: "sh",
+			Args: []string{"-c", `echo "$(pwd) $FOO"`},
+			Dir:  dir,
+			Env:  []string{"FOO=bar"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, dir+" bar\n", string(out))
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		out, err := shell.StdRunner{}.Exec(testctx.New(), shell.Cmd{
+			Name: "sh",
+			Args: []string{"-c", "echo nope; exit 1"},
+		})
+		require.EqualError(t, err, "exit status 1")
+		require.Equal(t, "nope\n", string(out))
+	})
+}
+
+func TestRunnerFunc(t *testing.T) {
+	var got shell.Cmd
+	runner := shell.RunnerFunc(func(_ *context.Context, cmd shell.Cmd) ([]byte, error) {
+		got = cmd
+		return []byte("fake"), nil
+	})
+	out, err := runner.Exec(testctx.New(), shell.Cmd{Name: "foo", Args: []string{"bar", "baz"}})
+	require.NoError(t, err)
+	require.Equal(t, "fake", string(out))
+	require.Equal(t, "foo bar baz", got.String())
+}
+
+func TestMock(t *testing.T) {
+	var runner shell.Runner = shell.StdRunner{}
+	mock, restore := shell.Mock(&runner, func(cmd shell.Cmd) ([]byte, error) {
+		if cmd.Name == "fail" {
+			return []byte("some output"), errors.New("fake error")
+		}
+		return nil, nil
+	})
+	require.Equal(t, mock, runner)
+
+	_, err := runner.Exec(testctx.New(), shell.Cmd{Name: "foo", Args: []string{"bar"}, Dir: "dir"})
+	require.NoError(t, err)
+	out, err := runner.Exec(testctx.New(), shell.Cmd{Name: "fail"})
+	require.EqualError(t, err, "fake error")
+	require.Equal(t, "some output", string(out))
+
+	require.Equal(t, []shell.Cmd{
+		{Name: "foo", Args: []string{"bar"}, Dir: "dir"},
+		{Name: "fail"},
+	}, mock.Cmds())
+	require.Equal(t, []string{"foo bar", "fail"}, mock.Lines())
+
+	restore()
+	require.Equal(t, shell.StdRunner{}, runner)
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/goreleaser/goreleaser/v2/internal/shell"
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/internal/tmpl"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,3 +38,20 @@ func TestRunCommand(t *testing.T) {
 		require.FileExists(t, filepath.Join(dir, "bar"))
 	})
 }
+
+func TestRunHooks(t *testing.T) {
+	dir := t.TempDir()
+	ctx := testctx.New()
+	require.NoError(t, shell.RunHooks(ctx, config.Hooks{
+		{Cmd: "touch {{ .Env.FOO }}", Dir: dir, Env: []string{"FOO={{ .ProjectName }}"}},
+		{Cmd: "touch {{ .Env.BAR }}", Dir: "{{ .Env.DIR }}"},
+	}, []string{"BAR=bar", "DIR=" + dir}, func() *tmpl.Template {
+		return tmpl.New(ctx).WithExtraFields(tmpl.Fields{"ProjectName": "foo"})
+	}))
+	require.FileExists(t, filepath.Join(dir, "foo"))
+	require.FileExists(t, filepath.Join(dir, "bar"))
+
+	require.EqualError(t, shell.RunHooks(ctx, config.Hooks{{Cmd: "sh -c 'exit 1'"}}, nil, func() *tmpl.Template {
+		return tmpl.New(ctx)
+	}), `shell: 'sh -c exit 1': exit status 1: [no output]`)
+}
@@ -33,8 +33,27 @@ const (
 	Chocolatey     Key = "chocolatey"
 	Notarize       Key = "notarize"
 	Archive        Key = "archive"
+	Scan           Key = "scan"
 )
 
+// Namespaced are the keys that can also skip a single one of their
+// announcers or publishers, e.g. announce:twitter.
+var Namespaced = Keys{Announce, Publish}
+
+// Of returns the key that skips only the given name within this key, e.g.
+// Announce.Of("twitter") is announce:twitter.
+func (key Key) Of(name string) Key {
+	return key + ":" + Key(name)
+}
+
+// Skipper is implemented by the announcers and publishers, which can be
+// skipped on their own within their namespace.
+type Skipper interface {
+	// Key returns the name that skips it within its namespace, e.g. twitter
+	// for announce:twitter.
+	Key() string
+}
+
 func String(ctx *context.Context) string {
 	keys := maps.Keys(ctx.Skips)
 	sort.Strings(keys)
@@ -45,11 +64,18 @@ func String(ctx *context.Context) string {
 	return str
 }
 
+// Any returns true if any of the given keys is skipped.
+//
+// A namespaced key, e.g. announce:twitter, is also skipped if its namespace
+// is.
 func Any(ctx *context.Context, keys ...Key) bool {
 	for _, key := range keys {
 		if ctx.Skips[string(key)] {
 			return true
 		}
+		if ns, _, ok := strings.Cut(string(key), ":"); ok && ctx.Skips[ns] {
+			return true
+		}
 	}
 	return false
 }
@@ -68,6 +94,10 @@ var (
 func set(allowed Keys) func(ctx *context.Context, keys ...string) error {
 	return func(ctx *context.Context, keys ...string) error {
 		for _, key := range keys {
+			if ns, name, ok := strings.Cut(key, ":"); ok && name != "" && slices.Contains(allowed.namespaced(), Key(ns)) {
+				ctx.Skips[key] = true
+				continue
+			}
 			if !slices.Contains(allowed, Key(key)) {
 				return fmt.Errorf("--skip=%s is not allowed. Valid options for skip are [%s]", key, allowed)
 			}
@@ -88,6 +118,16 @@ func (keys Keys) String() string {
 	return strings.Join(ss, ", ")
 }
 
+func (keys Keys) namespaced() Keys {
+	var result Keys
+	for _, key := range keys {
+		if slices.Contains(Namespaced, key) {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
 func (keys Keys) Complete(prefix string) []string {
 	var result []string
 	for _, k := range keys {
@@ -118,6 +158,37 @@ var Release = Keys{
 	Before,
 	Notarize,
 	Archive,
+	Scan,
+}
+
+// Requires are the keys each key needs not to be skipped to work, e.g. the
+// homebrew formula needs the archives and the release URL.
+//
+// Everything published to the SCM needs the tag to be pushed first.
+var Requires = map[Key]Keys{
+	Homebrew:                      {Publish.Of("brew")},
+	Scoop:                         {Publish.Of("scoop")},
+	Winget:                        {Publish.Of("winget")},
+	Nix:                           {Publish.Of("nix")},
+	AUR:                           {Publish.Of("aur")},
+	Chocolatey:                    {Publish.Of("chocolatey")},
+	Docker:                        {Publish.Of("docker"), Publish.Of("docker-manifest")},
+	Ko:                            {Publish.Of("ko")},
+	Snapcraft:                     {Publish.Of("snapcraft")},
+	Publish.Of("brew"):            {Homebrew, Archive, Publish.Of("release")},
+	Publish.Of("scoop"):           {Scoop, Archive, Publish.Of("release")},
+	Publish.Of("winget"):          {Winget, Archive, Publish.Of("release")},
+	Publish.Of("nix"):             {Nix, Archive, Publish.Of("release")},
+	Publish.Of("aur"):             {AUR, Archive, Publish.Of("release")},
+	Publish.Of("krew"):            {Archive, Publish.Of("release")},
+	Publish.Of("chocolatey"):      {Chocolatey, Archive, Publish.Of("release")},
+	Publish.Of("docker"):          {Docker},
+	Publish.Of("docker-manifest"): {Docker},
+	Publish.Of("release"):         {Publish.Of("tag")},
+	Publish.Of("release-mirrors"): {Publish.Of("release")},
+	Publish.Of("milestone"):       {Publish.Of("tag")},
+	Publish.Of("ko"):              {Ko},
+	Publish.Of("snapcraft"):       {Snapcraft},
 }
 
 var Build = Keys{
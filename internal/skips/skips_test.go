@@ -31,6 +31,33 @@ func TestAny(t *testing.T) {
 		ctx := testctx.New(testctx.Skip(skips.Publish))
 		require.True(t, skips.Any(ctx, skips.Release...))
 	})
+	t.Run("namespaced", func(t *testing.T) {
+		ctx := testctx.New(testctx.Skip(skips.Announce.Of("twitter")))
+		require.True(t, skips.Any(ctx, skips.Announce.Of("twitter")))
+		require.False(t, skips.Any(ctx, skips.Announce.Of("slack")))
+		require.False(t, skips.Any(ctx, skips.Announce))
+	})
+	t.Run("namespace", func(t *testing.T) {
+		ctx := testctx.New(testctx.Skip(skips.Announce))
+		require.True(t, skips.Any(ctx, skips.Announce.Of("twitter")))
+		require.False(t, skips.Any(ctx, skips.Publish.Of("twitter")))
+	})
+}
+
+func TestSetRelease(t *testing.T) {
+	t.Run("namespaced", func(t *testing.T) {
+		ctx := testctx.New()
+		require.NoError(t, skips.SetRelease(ctx, "announce:twitter", "publish:chocolatey"))
+		require.ElementsMatch(t, []string{"announce:twitter", "publish:chocolatey"}, maps.Keys(ctx.Skips))
+	})
+	for _, key := range []string{"announce:", "sign:foo", "foo"} {
+		t.Run("invalid "+key, func(t *testing.T) {
+			require.ErrorContains(t, skips.SetRelease(testctx.New(), key), "--skip="+key+" is not allowed")
+		})
+	}
+	t.Run("not namespaced on build", func(t *testing.T) {
+		require.Error(t, skips.SetBuild(testctx.New(), "announce:twitter"))
+	})
 }
 
 func TestSet(t *testing.T) {
@@ -99,6 +99,12 @@ func WithDate(t time.Time) Opt {
 	}
 }
 
+func WithParallelism(n int) Opt {
+	return func(ctx *context.Context) {
+		ctx.Parallelism = n
+	}
+}
+
 func WithFakeRuntime(ctx *context.Context) {
 	ctx.Runtime = context.Runtime{
 		Goos:   "fakeos",
@@ -116,6 +122,10 @@ func Snapshot(ctx *context.Context) {
 	ctx.Snapshot = true
 }
 
+func Nightly(ctx *context.Context) {
+	ctx.Nightly = true
+}
+
 func Partial(ctx *context.Context) {
 	ctx.Partial = true
 }
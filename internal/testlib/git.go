@@ -5,6 +5,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/keygen"
 	"github.com/goreleaser/goreleaser/v2/internal/git"
@@ -46,6 +47,14 @@ func GitCommit(tb testing.TB, msg string) {
 	require.Contains(tb, out, "main", msg)
 }
 
+// GitCommitWithDate creates a git commit with the given author date.
+func GitCommitWithDate(tb testing.TB, msg string, date time.Time) {
+	tb.Helper()
+	out, err := fakeGit("commit", "--allow-empty", "--date", date.Format(time.RFC3339), "-m", msg)
+	require.NoError(tb, err)
+	require.Contains(tb, out, "main", msg)
+}
+
 // GitTag creates a git tag.
 func GitTag(tb testing.TB, tag string) {
 	tb.Helper()
@@ -0,0 +1,177 @@
+package tmpl
+
+import (
+	stdctx "context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/caarlos0/log"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultGitHubAPI     = "https://api.github.com/"
+	latestReleaseTimeout = 30 * time.Second
+)
+
+var errUnauthorized = errors.New("unauthorized")
+
+// latestReleases caches the latest release tags by API URL and repository,
+// so each repository is queried only once per run.
+//
+// Concurrent lookups of the same repository share a single request, while
+// different repositories are fetched in parallel.
+var latestReleases = struct {
+	sync.Mutex
+	tags  map[string]string
+	group singleflight.Group
+}{tags: map[string]string{}}
+
+// HTTPClient returns the HTTP client used to get the latest releases from the
+// GitHub API.
+//
+// The client package replaces it with one using the github_urls and http
+// settings, as it can't be used here without an import cycle.
+//
+//nolint:gochecknoglobals
+var HTTPClient = func(ctx *context.Context) (*http.Client, error) {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{
+				//nolint:gosec
+				InsecureSkipVerify: ctx.Config.GitHubURLs.SkipTLSVerify,
+			},
+		},
+	}, nil
+}
+
+// latestRelease returns the tag of the latest release of the given
+// owner/name GitHub repository.
+//
+// It uses the GitHub token when there is one, falling back to unauthenticated
+// requests if there is not or if it gets refused.
+func (t *Template) latestRelease(repo string) (string, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", fmt.Errorf("invalid repository %q, expected owner/name", repo)
+	}
+
+	api := defaultGitHubAPI
+	var token string
+	client := http.DefaultClient
+	parent := stdctx.Background()
+	if t.ctx != nil {
+		parent = t.ctx
+		if t.ctx.Config.GitHubURLs.API != "" {
+			api = t.ctx.Config.GitHubURLs.API
+		}
+		var err error
+		client, err = HTTPClient(t.ctx)
+		if err != nil {
+			return "", err
+		}
+		token = t.ctx.Env["GITHUB_TOKEN"]
+		if t.ctx.TokenType == context.TokenTypeGitHub && t.ctx.Token != "" {
+			token = t.ctx.Token
+		}
+	}
+	url := strings.TrimSuffix(api, "/") + "/repos/" + owner + "/" + name + "/releases/latest"
+
+	latestReleases.Lock()
+	tag, ok := latestReleases.tags[url]
+	latestReleases.Unlock()
+	if ok {
+		return tag, nil
+	}
+
+	v, err, _ := latestReleases.group.Do(url, func() (any, error) {
+		ctx, cancel := stdctx.WithTimeout(parent, latestReleaseTimeout)
+		defer cancel()
+
+		tag, err := fetchLatestRelease(ctx, client, repo, url, token)
+		if errors.Is(err, errUnauthorized) && token != "" {
+			log.WithField("repo", repo).Warn("github token refused, trying the latest release without it")
+			tag, err = fetchLatestRelease(ctx, client, repo, url, "")
+		}
+		if err != nil {
+			return "", err
+		}
+
+		latestReleases.Lock()
+		latestReleases.tags[url] = tag
+		latestReleases.Unlock()
+		return tag, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// fetchLatestRelease gets the latest release tag from the given URL with the
+// given client.
+//
+// The error messages have no colons, otherwise they would get trimmed by
+// newTmplError.
+func fetchLatestRelease(ctx stdctx.Context, client *http.Client, repo, url, token string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return "", errUnauthorized
+	case http.StatusNotFound:
+		return "", fmt.Errorf("no releases found for %s", repo)
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			return "", rateLimitError(repo, resp.Header.Get("X-RateLimit-Reset"), token != "")
+		}
+		fallthrough
+	default:
+		return "", fmt.Errorf("unexpected status %q getting the latest release of %s", resp.Status, repo)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("could not parse the latest release of %s", repo)
+	}
+	if release.TagName == "" {
+		return "", fmt.Errorf("the latest release of %s has no tag", repo)
+	}
+	return release.TagName, nil
+}
+
+func rateLimitError(repo, reset string, authenticated bool) error {
+	msg := "github rate limit exceeded getting the latest release of " + repo
+	if secs, err := strconv.ParseInt(reset, 10, 64); err == nil {
+		msg += ", resets at " + time.Unix(secs, 0).UTC().Format(time.RFC3339)
+	}
+	if !authenticated {
+		msg += ", set GITHUB_TOKEN to increase the limit"
+	}
+	return errors.New(msg)
+}
@@ -0,0 +1,116 @@
+package tmpl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/goreleaser/goreleaser/v2/pkg/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestRelease(t *testing.T) {
+	var calls, slowCalls atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Path {
+		case "/repos/foo/bar/releases/latest":
+			require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"tag_name":"v1.2.3"}`))
+		case "/repos/foo/slow/releases/latest":
+			slowCalls.Add(1)
+			<-release
+			_, _ = w.Write([]byte(`{"tag_name":"v3.0.0"}`))
+		case "/repos/foo/anon/releases/latest":
+			if r.Header.Get("Authorization") != "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"tag_name":"v0.1.0"}`))
+		case "/repos/foo/limited/releases/latest":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1700000000")
+			w.WriteHeader(http.StatusForbidden)
+		case "/repos/foo/broken/releases/latest":
+			_, _ = w.Write([]byte(`nope`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	newCtx := func(token string) *context.Context {
+		ctx := testctx.NewWithCfg(config.Project{
+			GitHubURLs: config.GitHubURLs{API: srv.URL + "/"},
+		})
+		ctx.TokenType = context.TokenTypeGitHub
+		ctx.Token = token
+		return ctx
+	}
+
+	t.Run("cached", func(t *testing.T) {
+		calls.Store(0)
+		tp := New(newCtx("secret"))
+		for range 2 {
+			out, err := tp.Apply(`{{ latestRelease "foo/bar" }}`)
+			require.NoError(t, err)
+			require.Equal(t, "v1.2.3", out)
+		}
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				out, err := New(newCtx("")).Apply(`{{ latestRelease "foo/slow" }}`)
+				require.NoError(t, err)
+				require.Equal(t, "v3.0.0", out)
+			}()
+		}
+
+		// other repositories are not blocked by a pending request.
+		require.Eventually(t, func() bool { return slowCalls.Load() == 1 }, time.Second, time.Millisecond)
+		out, err := New(newCtx("")).Apply(`{{ latestRelease "foo/anon" }}`)
+		require.NoError(t, err)
+		require.Equal(t, "v0.1.0", out)
+
+		close(release)
+		wg.Wait()
+		require.Equal(t, int32(1), slowCalls.Load())
+	})
+
+	t.Run("token refused", func(t *testing.T) {
+		out, err := New(newCtx("secret")).Apply(`{{ latestRelease "foo/anon" }}`)
+		require.NoError(t, err)
+		require.Equal(t, "v0.1.0", out)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		_, err := New(newCtx("")).Apply(`{{ latestRelease "foo/limited" }}`)
+		require.ErrorContains(t, err, "github rate limit exceeded getting the latest release of foo/limited, resets at 2023-11-14T22:13:20Z, set GITHUB_TOKEN to increase the limit")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := New(newCtx("")).Apply(`{{ latestRelease "foo/nope" }}`)
+		require.ErrorContains(t, err, "no releases found for foo/nope")
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		_, err := New(newCtx("")).Apply(`{{ latestRelease "foo/broken" }}`)
+		require.ErrorContains(t, err, "could not parse the latest release of foo/broken")
+	})
+
+	t.Run("invalid repo", func(t *testing.T) {
+		_, err := New(newCtx("")).Apply(`{{ latestRelease "foo" }}`)
+		require.ErrorContains(t, err, `invalid repository "foo", expected owner/name`)
+	})
+}
@@ -3,11 +3,15 @@ package tmpl
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
+	"text/template/parse"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
@@ -20,6 +24,7 @@ import (
 
 // Template holds data that can be applied to a template string.
 type Template struct {
+	ctx    *context.Context
 	fields Fields
 }
 
@@ -33,6 +38,8 @@ const (
 	rawVersion      = "RawVersion"
 	tag             = "Tag"
 	previousTag     = "PreviousTag"
+	prefixedTag     = "PrefixedTag"
+	prefixedPrevTag = "PrefixedPreviousTag"
 	branch          = "Branch"
 	commit          = "Commit"
 	shortCommit     = "ShortCommit"
@@ -41,6 +48,7 @@ const (
 	commitTimestamp = "CommitTimestamp"
 	gitURL          = "GitURL"
 	summary         = "Summary"
+	prefixedSummary = "PrefixedSummary"
 	tagSubject      = "TagSubject"
 	tagContents     = "TagContents"
 	tagBody         = "TagBody"
@@ -73,6 +81,7 @@ const (
 	artifactName = "ArtifactName"
 	artifactExt  = "ArtifactExt"
 	artifactPath = "ArtifactPath"
+	artifactK    = "Artifact"
 
 	// build keys.
 	name   = "Name"
@@ -89,6 +98,9 @@ func New(ctx *context.Context) *Template {
 	if ctx.Git.Dirty {
 		treeState = "dirty"
 	}
+	// the tags and summary are stripped of the monorepo prefix, the full ones
+	// are available as the Prefixed* fields.
+	prefix := ctx.Config.Monorepo.TagPrefix
 
 	fields := map[string]interface{}{}
 	for k, v := range map[string]interface{}{
@@ -96,9 +108,12 @@ func New(ctx *context.Context) *Template {
 		modulePath:      ctx.ModulePath,
 		version:         ctx.Version,
 		rawVersion:      rawVersionV,
-		summary:         ctx.Git.Summary,
-		tag:             ctx.Git.CurrentTag,
-		previousTag:     ctx.Git.PreviousTag,
+		summary:         strings.TrimPrefix(ctx.Git.Summary, prefix),
+		prefixedSummary: ctx.Git.Summary,
+		tag:             strings.TrimPrefix(ctx.Git.CurrentTag, prefix),
+		previousTag:     strings.TrimPrefix(ctx.Git.PreviousTag, prefix),
+		prefixedTag:     ctx.Git.CurrentTag,
+		prefixedPrevTag: ctx.Git.PreviousTag,
 		branch:          ctx.Git.Branch,
 		commit:          ctx.Git.Commit,
 		shortCommit:     ctx.Git.ShortCommit,
@@ -118,7 +133,7 @@ func New(ctx *context.Context) *Template {
 		patch:           ctx.Semver.Patch,
 		prerelease:      ctx.Semver.Prerelease,
 		isSnapshot:      ctx.Snapshot,
-		isNightly:       false,
+		isNightly:       ctx.Nightly,
 		isDraft:         ctx.Config.Release.Draft,
 		releaseNotes:    ctx.ReleaseNotes,
 		releaseURL:      ctx.ReleaseURL,
@@ -131,6 +146,7 @@ func New(ctx *context.Context) *Template {
 	}
 
 	return &Template{
+		ctx:    ctx,
 		fields: fields,
 	}
 }
@@ -175,6 +191,9 @@ func (t *Template) WithArtifact(a *artifact.Artifact) *Template {
 	t.fields[artifactName] = a.Name
 	t.fields[artifactExt] = artifact.ExtraOr(*a, artifact.ExtraExt, "")
 	t.fields[artifactPath] = a.Path
+	t.fields[artifactK] = Fields{
+		"Extra": a.Extra,
+	}
 	return t
 }
 
@@ -207,33 +226,7 @@ func (t *Template) Apply(s string) (string, error) {
 	var out bytes.Buffer
 	tmpl, err := template.New("tmpl").
 		Option("missingkey=error").
-		Funcs(template.FuncMap{
-			"replace": strings.ReplaceAll,
-			"split":   strings.Split,
-			"time": func(s string) string {
-				return time.Now().UTC().Format(s)
-			},
-			"contains":       strings.Contains,
-			"tolower":        strings.ToLower,
-			"toupper":        strings.ToUpper,
-			"trim":           strings.TrimSpace,
-			"trimprefix":     strings.TrimPrefix,
-			"trimsuffix":     strings.TrimSuffix,
-			"title":          cases.Title(language.English).String,
-			"dir":            filepath.Dir,
-			"base":           filepath.Base,
-			"abs":            filepath.Abs,
-			"incmajor":       incMajor,
-			"incminor":       incMinor,
-			"incpatch":       incPatch,
-			"filter":         filter(false),
-			"reverseFilter":  filter(true),
-			"mdv2escape":     mdv2Escape,
-			"envOrDefault":   t.envOrDefault,
-			"isEnvSet":       t.isEnvSet,
-			"map":            makemap,
-			"indexOrDefault": indexOrDefault,
-		}).
+		Funcs(t.funcMap()).
 		Parse(s)
 	if err != nil {
 		return "", newTmplError(s, err)
@@ -243,6 +236,123 @@ func (t *Template) Apply(s string) (string, error) {
 	return out.String(), newTmplError(s, err)
 }
 
+// ErrTemplateAndFile happens when both an inline template and a template file
+// are set.
+var ErrTemplateAndFile = errors.New("template and template file are mutually exclusive")
+
+// ApplyInlineOrFile applies the given inline template or, if path is set, the
+// contents of the template file it points to.
+// The path itself can be a template, and setting both is an error.
+func (t *Template) ApplyInlineOrFile(s, path string) (string, error) {
+	if path == "" {
+		return t.Apply(s)
+	}
+	if s != "" {
+		return "", ErrTemplateAndFile
+	}
+	path, err := t.Apply(path)
+	if err != nil {
+		return "", err
+	}
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return t.Apply(string(bts))
+}
+
+func (t *Template) funcMap() template.FuncMap {
+	return template.FuncMap{
+		"replace": strings.ReplaceAll,
+		"split":   strings.Split,
+		"time": func(s string) string {
+			return time.Now().UTC().Format(s)
+		},
+		"contains":       strings.Contains,
+		"tolower":        strings.ToLower,
+		"toupper":        strings.ToUpper,
+		"trim":           strings.TrimSpace,
+		"trimprefix":     strings.TrimPrefix,
+		"trimsuffix":     strings.TrimSuffix,
+		"title":          cases.Title(language.English).String,
+		"dir":            filepath.Dir,
+		"base":           filepath.Base,
+		"abs":            filepath.Abs,
+		"incmajor":       incMajor,
+		"incminor":       incMinor,
+		"incpatch":       incPatch,
+		"filter":         filter(false),
+		"reverseFilter":  filter(true),
+		"mdv2escape":     mdv2Escape,
+		"envOrDefault":   t.envOrDefault,
+		"isEnvSet":       t.isEnvSet,
+		"map":            makemap,
+		"indexOrDefault": indexOrDefault,
+		"latestRelease":  t.latestRelease,
+	}
+}
+
+// ReferencedEnv parses the given template, without executing it, and returns
+// the sorted names of the environment variables it requires, e.g. `FOO` in
+// `{{ .Env.FOO }}`.
+//
+// References inside the body of `if`, `with` and `range` actions are
+// conditional, and thus not reported.
+// Neither are the ones made with `envOrDefault` and `isEnvSet`.
+func ReferencedEnv(s string) ([]string, error) {
+	tmpl, err := template.New("tmpl").
+		Funcs((&Template{}).funcMap()).
+		Parse(s)
+	if err != nil {
+		return nil, newTmplError(s, err)
+	}
+	refs := map[string]struct{}{}
+	walkEnvRefs(tmpl.Root, refs)
+	result := make([]string, 0, len(refs))
+	for k := range refs {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func walkEnvRefs(node parse.Node, refs map[string]struct{}) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkEnvRefs(child, refs)
+		}
+	case *parse.ActionNode:
+		walkEnvRefs(n.Pipe, refs)
+	case *parse.IfNode:
+		walkEnvRefs(n.Pipe, refs)
+	case *parse.WithNode:
+		walkEnvRefs(n.Pipe, refs)
+	case *parse.RangeNode:
+		walkEnvRefs(n.Pipe, refs)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			walkEnvRefs(cmd, refs)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			walkEnvRefs(arg, refs)
+		}
+	case *parse.ChainNode:
+		walkEnvRefs(n.Node, refs)
+	case *parse.FieldNode:
+		if len(n.Ident) > 1 && n.Ident[0] == env {
+			refs[n.Ident[1]] = struct{}{}
+		}
+	}
+}
+
 // ApplyAll applies all the given strings against the Fields stored in the
 // template. Application stops as soon as an error is encountered.
 func (t *Template) ApplyAll(sps ...*string) error {
@@ -85,6 +85,7 @@ func TestWithArtifact(t *testing.T) {
 		"artifact path: /tmp/foo.exe":         "artifact path: {{ .ArtifactPath }}",
 		"artifact basename: foo.exe":          "artifact basename: {{ base .ArtifactPath }}",
 		"artifact dir: /tmp":                  "artifact dir: {{ dir .ArtifactPath }}",
+		"artifact extra: 7.5":                 "artifact extra: {{ .Artifact.Extra.score }}",
 		"2023":                                `{{ .Now.Format "2006" }}`,
 		"2023-03-09T02:06:02Z":                `{{ .Date }}`,
 		"1678327562":                          `{{ .Timestamp }}`,
@@ -118,6 +119,7 @@ func TestWithArtifact(t *testing.T) {
 					Extra: map[string]interface{}{
 						artifact.ExtraBinary: "binary",
 						artifact.ExtraExt:    ".exe",
+						"score":              7.5,
 					},
 				},
 			).Apply(tmpl)
@@ -178,6 +180,37 @@ func TestEnv(t *testing.T) {
 	}
 }
 
+func TestMonorepoTags(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Monorepo: config.Monorepo{TagPrefix: "service-a/"},
+	}, testctx.WithGitInfo(context.GitInfo{
+		CurrentTag:  "service-a/v1.2.3",
+		PreviousTag: "service-a/v1.2.2",
+		Summary:     "service-a/v1.2.3-1-gabcdef",
+	}))
+	for tmpl, expected := range map[string]string{
+		"{{ .Tag }}":                 "v1.2.3",
+		"{{ .PreviousTag }}":         "v1.2.2",
+		"{{ .Summary }}":             "v1.2.3-1-gabcdef",
+		"{{ .PrefixedTag }}":         "service-a/v1.2.3",
+		"{{ .PrefixedPreviousTag }}": "service-a/v1.2.2",
+		"{{ .PrefixedSummary }}":     "service-a/v1.2.3-1-gabcdef",
+	} {
+		t.Run(tmpl, func(t *testing.T) {
+			out, err := New(ctx).Apply(tmpl)
+			require.NoError(t, err)
+			require.Equal(t, expected, out)
+		})
+	}
+
+	t.Run("no prefix", func(t *testing.T) {
+		ctx := testctx.New(testctx.WithCurrentTag("v1.2.3"))
+		out, err := New(ctx).Apply("{{ .Tag }} {{ .PrefixedTag }}")
+		require.NoError(t, err)
+		require.Equal(t, "v1.2.3 v1.2.3", out)
+	})
+}
+
 func TestWithEnv(t *testing.T) {
 	ctx := testctx.New(
 		testctx.WithEnv(map[string]string{"FOO": "BAR"}),
@@ -453,3 +486,78 @@ func TestWithBuildOptions(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "name_./path_.ext_target_os_arch_amd64_arm_mips", out)
 }
+
+func TestReferencedEnv(t *testing.T) {
+	for tmpl, expected := range map[string][]string{
+		"no templates":       {},
+		"{{ .ProjectName }}": {},
+		"{{ .Env.FOO }}":     {"FOO"},
+		"{{ .Env.FOO }}-{{ .Env.BAR }}-{{.Env.FOO}}":              {"BAR", "FOO"},
+		"{{ .Env.FOO | tolower }}":                                {"FOO"},
+		"{{ replace .Env.FOO \"a\" .Env.BAR }}":                   {"BAR", "FOO"},
+		"{{ if .Env.FOO }}{{ .Env.BAR }}{{ end }}":                {"FOO"},
+		"{{ with .Env.FOO }}{{ . }}{{ end }}":                     {"FOO"},
+		"{{ range split .Env.FOO \",\" }}{{ .Env.BAR }}{{ end }}": {"FOO"},
+		"{{ if isEnvSet \"FOO\" }}{{ .Env.FOO }}{{ end }}":        {},
+		"{{ envOrDefault \"FOO\" \"bar\" }}":                      {},
+		"{{ (.Env.FOO) }}":                                        {"FOO"},
+	} {
+		t.Run(tmpl, func(t *testing.T) {
+			refs, err := ReferencedEnv(tmpl)
+			require.NoError(t, err)
+			require.Equal(t, expected, refs)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ReferencedEnv("{{ .Env.FOO")
+		require.Error(t, err)
+	})
+}
+
+func TestApplyInlineOrFile(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{ProjectName: "proj"})
+	dir := t.TempDir()
+	path := filepath.Join(dir, "proj.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte("from file: {{ .ProjectName }}"), 0o644))
+
+	t.Run("inline", func(t *testing.T) {
+		out, err := New(ctx).ApplyInlineOrFile("inline: {{ .ProjectName }}", "")
+		require.NoError(t, err)
+		require.Equal(t, "inline: proj", out)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		out, err := New(ctx).ApplyInlineOrFile("", path)
+		require.NoError(t, err)
+		require.Equal(t, "from file: proj", out)
+	})
+
+	t.Run("templated path", func(t *testing.T) {
+		out, err := New(ctx).ApplyInlineOrFile("", filepath.Join(dir, "{{ .ProjectName }}.tmpl"))
+		require.NoError(t, err)
+		require.Equal(t, "from file: proj", out)
+	})
+
+	t.Run("both", func(t *testing.T) {
+		_, err := New(ctx).ApplyInlineOrFile("inline", path)
+		require.ErrorIs(t, err, ErrTemplateAndFile)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := New(ctx).ApplyInlineOrFile("", filepath.Join(dir, "nope.tmpl"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("invalid path template", func(t *testing.T) {
+		_, err := New(ctx).ApplyInlineOrFile("", "{{ .Nope }")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid file template", func(t *testing.T) {
+		invalid := filepath.Join(dir, "invalid.tmpl")
+		require.NoError(t, os.WriteFile(invalid, []byte("{{ .Nope }"), 0o644))
+		_, err := New(ctx).ApplyInlineOrFile("", invalid)
+		require.Error(t, err)
+	})
+}
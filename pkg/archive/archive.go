@@ -22,18 +22,20 @@ type Archive interface {
 }
 
 // New archive.
-func New(w io.Writer, format string) (Archive, error) {
+//
+// The given options are only used by the tar based formats.
+func New(w io.Writer, format string, opts ...tar.Option) (Archive, error) {
 	switch format {
 	case "tar.gz", "tgz":
-		return targz.New(w), nil
+		return targz.New(w, opts...), nil
 	case "tar":
-		return tar.New(w), nil
+		return tar.New(w, opts...), nil
 	case "gz":
 		return gzip.New(w), nil
 	case "tar.xz", "txz":
-		return tarxz.New(w), nil
+		return tarxz.New(w, opts...), nil
 	case "tar.zst", "tzst":
-		return tarzst.New(w), nil
+		return tarzst.New(w, opts...), nil
 	case "zip":
 		return zip.New(w), nil
 	}
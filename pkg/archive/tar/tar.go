@@ -3,6 +3,9 @@ package tar
 
 import (
 	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -15,14 +18,53 @@ import (
 type Archive struct {
 	tw    *tar.Writer
 	files map[string]bool
+
+	xattrs        bool
+	onUnsupported func(path string, err error)
+
+	// dedupe holds the regular files added so far by their sizes, if
+	// deduplication is enabled.
+	dedupe map[int64][]*dedupeEntry
+}
+
+// Option customizes a tar archive.
+type Option func(*Archive)
+
+// WithXattrs makes the archive include the extended attributes of the files
+// added to it, as PAX records.
+//
+// If the platform or the file system of a file doesn't support them,
+// onUnsupported is called, and the file is added without them.
+func WithXattrs(onUnsupported func(path string, err error)) Option {
+	return func(a *Archive) {
+		a.xattrs = true
+		a.onUnsupported = onUnsupported
+	}
+}
+
+// WithHardlinkDedupe makes the archive store files with the same contents as
+// a previously added file as hard links to it.
+//
+// Only files with the same size, mode and owner as a previously added file
+// are hashed, so archives without duplicates pay only for these comparisons.
+// Hard links share their metadata, so files that only differ in it are never
+// linked.
+func WithHardlinkDedupe() Option {
+	return func(a *Archive) {
+		a.dedupe = map[int64][]*dedupeEntry{}
+	}
 }
 
 // New tar archive.
-func New(target io.Writer) Archive {
-	return Archive{
+func New(target io.Writer, opts ...Option) Archive {
+	a := Archive{
 		tw:    tar.NewWriter(target),
 		files: map[string]bool{},
 	}
+	for _, opt := range opts {
+		opt(&a)
+	}
+	return a
 }
 
 // Copying creates a new tar with the contents of the given tar.
@@ -89,6 +131,26 @@ func (a Archive) Add(f config.File) error {
 		header.Gid = 0
 		header.Gname = f.Info.Group
 	}
+	if a.xattrs {
+		if err := a.addXattrs(header, f.Source); err != nil {
+			return fmt.Errorf("%s: %w", f.Source, err)
+		}
+	}
+	if a.dedupe != nil && info.Mode().IsRegular() {
+		link, err := a.findDuplicate(f, header)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Source, err)
+		}
+		if link != "" {
+			header.Typeflag = tar.TypeLink
+			header.Linkname = link
+			header.Size = 0
+			if err := a.tw.WriteHeader(header); err != nil {
+				return fmt.Errorf("%s: %w", f.Source, err)
+			}
+			return nil
+		}
+	}
 	if err = a.tw.WriteHeader(header); err != nil {
 		return fmt.Errorf("%s: %w", f.Source, err)
 	}
@@ -105,3 +167,103 @@ func (a Archive) Add(f config.File) error {
 	}
 	return nil
 }
+
+// errXattrsUnsupported is returned when extended attributes are not supported
+// by the platform or the file system.
+var errXattrsUnsupported = errors.New("extended attributes are not supported")
+
+func (a Archive) addXattrs(header *tar.Header, path string) error {
+	xattrs, err := readXattrs(path)
+	if errors.Is(err, errXattrsUnsupported) {
+		if a.onUnsupported != nil {
+			a.onUnsupported(path, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(xattrs) == 0 {
+		return nil
+	}
+	if header.PAXRecords == nil {
+		header.PAXRecords = map[string]string{}
+	}
+	for name, value := range xattrs {
+		header.PAXRecords[paxXattrPrefix+name] = value
+	}
+	header.Format = tar.FormatPAX
+	return nil
+}
+
+// paxXattrPrefix is the prefix of the PAX records holding extended
+// attributes, as written by GNU tar and bsdtar.
+const paxXattrPrefix = "SCHILY.xattr."
+
+type dedupeEntry struct {
+	name   string
+	path   string
+	sum    string
+	header tar.Header
+}
+
+// sameMetadata tells whether the given header has the same mode and owner as
+// the entry, which a hard link to it would share.
+func (e *dedupeEntry) sameMetadata(header *tar.Header) bool {
+	return e.header.Mode == header.Mode &&
+		e.header.Uid == header.Uid &&
+		e.header.Gid == header.Gid &&
+		e.header.Uname == header.Uname &&
+		e.header.Gname == header.Gname
+}
+
+// findDuplicate returns the name of a previously added file with the same
+// contents and metadata as the given one, if any, and remembers the file
+// otherwise.
+func (a Archive) findDuplicate(f config.File, header *tar.Header) (string, error) {
+	entry := &dedupeEntry{
+		name:   f.Destination,
+		path:   f.Source,
+		header: *header,
+	}
+	candidates := a.dedupe[header.Size]
+	if len(candidates) > 0 && header.Size > 0 {
+		for _, c := range candidates {
+			if !c.sameMetadata(header) {
+				continue
+			}
+			if entry.sum == "" {
+				sum, err := sha256sum(entry.path)
+				if err != nil {
+					return "", err
+				}
+				entry.sum = sum
+			}
+			if c.sum == "" {
+				sum, err := sha256sum(c.path)
+				if err != nil {
+					return "", err
+				}
+				c.sum = sum
+			}
+			if c.sum == entry.sum {
+				return c.name, nil
+			}
+		}
+	}
+	a.dedupe[header.Size] = append(candidates, entry)
+	return "", nil
+}
+
+func sha256sum(path string) (string, error) {
+	file, err := os.Open(path) // #nosec
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
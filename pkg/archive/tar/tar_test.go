@@ -203,3 +203,102 @@ func TestCopying(t *testing.T) {
 	require.Equal(t, []string{"foo.txt", "ملف.txt"}, testlib.LsArchive(t, f1.Name(), "tar"))
 	require.Equal(t, []string{"foo.txt", "ملف.txt", "executable", "ملف.exe"}, testlib.LsArchive(t, f2.Name(), "tar"))
 }
+
+func TestTarHardlinkDedupe(t *testing.T) {
+	src := t.TempDir()
+	for name, content := range map[string]string{
+		"bin1":  "same binary",
+		"bin2":  "same binary",
+		"bin3":  "same binary",
+		"other": "different!!",
+		"empty": "",
+		"void":  "",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(src, name), []byte(content), 0o755))
+	}
+
+	write := func(tb testing.TB, opts ...Option) map[string]*tar.Header {
+		tb.Helper()
+		path := filepath.Join(tb.TempDir(), "test.tar")
+		f, err := os.Create(path)
+		require.NoError(tb, err)
+		defer f.Close()
+		archive := New(f, opts...)
+		for _, name := range []string{"bin1", "other", "bin2", "empty", "void", "bin3"} {
+			require.NoError(tb, archive.Add(config.File{
+				Source:      filepath.Join(src, name),
+				Destination: "dir/" + name,
+			}))
+		}
+		require.NoError(tb, archive.Close())
+
+		_, err = f.Seek(0, io.SeekStart)
+		require.NoError(tb, err)
+		headers := map[string]*tar.Header{}
+		r := tar.NewReader(f)
+		for {
+			header, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(tb, err)
+			headers[header.Name] = header
+		}
+		return headers
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		headers := write(t, WithHardlinkDedupe())
+		require.Len(t, headers, 6)
+
+		require.Equal(t, byte(tar.TypeReg), headers["dir/bin1"].Typeflag)
+		require.Equal(t, byte(tar.TypeReg), headers["dir/other"].Typeflag)
+		require.Equal(t, byte(tar.TypeReg), headers["dir/empty"].Typeflag)
+		require.Equal(t, byte(tar.TypeReg), headers["dir/void"].Typeflag)
+		for _, name := range []string{"dir/bin2", "dir/bin3"} {
+			require.Equal(t, byte(tar.TypeLink), headers[name].Typeflag, name)
+			require.Equal(t, "dir/bin1", headers[name].Linkname, name)
+			require.Zero(t, headers[name].Size, name)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		headers := write(t)
+		for name, header := range headers {
+			require.Equal(t, byte(tar.TypeReg), header.Typeflag, name)
+		}
+	})
+	t.Run("different metadata", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.tar")
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		defer f.Close()
+		archive := New(f, WithHardlinkDedupe())
+		for _, file := range []config.File{
+			{Source: filepath.Join(src, "bin1"), Destination: "bin1"},
+			{Source: filepath.Join(src, "bin2"), Destination: "bin2", Info: config.FileInfo{Mode: 0o644}},
+			{Source: filepath.Join(src, "bin3"), Destination: "bin3", Info: config.FileInfo{Owner: "nobody"}},
+			{Source: filepath.Join(src, "bin3"), Destination: "bin4", Info: config.FileInfo{Group: "nobody"}},
+			{Source: filepath.Join(src, "bin2"), Destination: "bin5", Info: config.FileInfo{Mode: 0o644}},
+		} {
+			require.NoError(t, archive.Add(file))
+		}
+		require.NoError(t, archive.Close())
+
+		_, err = f.Seek(0, io.SeekStart)
+		require.NoError(t, err)
+		r := tar.NewReader(f)
+		links := map[string]string{}
+		for {
+			header, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if header.Typeflag == tar.TypeLink {
+				links[header.Name] = header.Linkname
+			}
+		}
+		require.Equal(t, map[string]string{"bin5": "bin2"}, links)
+	})
+}
@@ -0,0 +1,58 @@
+package tar
+
+import (
+	"bytes"
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+// readXattrs reads the extended attributes of the given path, without
+// following symlinks.
+func readXattrs(path string) (map[string]string, error) {
+	size, err := unix.Llistxattr(path, nil)
+	if err != nil {
+		return nil, xattrErr(err)
+	}
+	if size == 0 {
+		return nil, nil
+	}
+	buf := make([]byte, size)
+	size, err = unix.Llistxattr(path, buf)
+	if err != nil {
+		return nil, xattrErr(err)
+	}
+
+	result := map[string]string{}
+	for _, name := range bytes.Split(buf[:size], []byte{0}) {
+		if len(name) == 0 {
+			continue
+		}
+		value, err := readXattr(path, string(name))
+		if err != nil {
+			return nil, err
+		}
+		result[string(name)] = value
+	}
+	return result, nil
+}
+
+func readXattr(path, name string) (string, error) {
+	size, err := unix.Lgetxattr(path, name, nil)
+	if err != nil {
+		return "", xattrErr(err)
+	}
+	buf := make([]byte, size)
+	size, err = unix.Lgetxattr(path, name, buf)
+	if err != nil {
+		return "", xattrErr(err)
+	}
+	return string(buf[:size]), nil
+}
+
+func xattrErr(err error) error {
+	if errors.Is(err, unix.ENOTSUP) {
+		return errXattrsUnsupported
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+package tar
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestTarXattrs(t *testing.T) {
+	tmp := t.TempDir()
+	src := filepath.Join(tmp, "bin")
+	require.NoError(t, os.WriteFile(src, []byte("binary"), 0o755))
+	if err := unix.Setxattr(src, "user.goreleaser", []byte("test"), 0); err != nil {
+		if errors.Is(err, unix.ENOTSUP) {
+			t.Skip("file system does not support extended attributes")
+		}
+		require.NoError(t, err)
+	}
+
+	write := func(tb testing.TB, opts ...Option) string {
+		tb.Helper()
+		path := filepath.Join(tb.TempDir(), "test.tar")
+		f, err := os.Create(path)
+		require.NoError(tb, err)
+		defer f.Close()
+		archive := New(f, opts...)
+		require.NoError(tb, archive.Add(config.File{
+			Source:      src,
+			Destination: "bin",
+		}))
+		require.NoError(tb, archive.Close())
+		return path
+	}
+
+	read := func(tb testing.TB, path string) *tar.Header {
+		tb.Helper()
+		f, err := os.Open(path)
+		require.NoError(tb, err)
+		defer f.Close()
+		r := tar.NewReader(f)
+		header, err := r.Next()
+		require.NoError(tb, err)
+		_, err = r.Next()
+		require.ErrorIs(tb, err, io.EOF)
+		return header
+	}
+
+	t.Run("preserved", func(t *testing.T) {
+		var unsupported []string
+		header := read(t, write(t, WithXattrs(func(path string, _ error) {
+			unsupported = append(unsupported, path)
+		})))
+		require.Empty(t, unsupported)
+		require.Equal(t, "bin", header.Name)
+		require.Equal(t, "test", header.PAXRecords["SCHILY.xattr.user.goreleaser"])
+
+		// extract it and check the attribute survived the round trip.
+		dst := filepath.Join(t.TempDir(), header.Name)
+		require.NoError(t, os.WriteFile(dst, []byte("binary"), 0o755))
+		for key, value := range header.PAXRecords {
+			name, ok := strings.CutPrefix(key, paxXattrPrefix)
+			if !ok {
+				continue
+			}
+			require.NoError(t, unix.Setxattr(dst, name, []byte(value), 0))
+		}
+		xattrs, err := readXattrs(dst)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"user.goreleaser": "test"}, xattrs)
+	})
+
+	t.Run("not preserved", func(t *testing.T) {
+		header := read(t, write(t))
+		require.NotContains(t, header.PAXRecords, "SCHILY.xattr.user.goreleaser")
+	})
+
+	t.Run("copying", func(t *testing.T) {
+		src, err := os.Open(write(t, WithXattrs(nil)))
+		require.NoError(t, err)
+		defer src.Close()
+		path := filepath.Join(t.TempDir(), "copy.tar")
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		defer f.Close()
+		archive, err := Copying(src, f)
+		require.NoError(t, err)
+		require.NoError(t, archive.Close())
+
+		header := read(t, path)
+		require.Equal(t, "test", header.PAXRecords["SCHILY.xattr.user.goreleaser"])
+	})
+}
+
+func TestReadXattrsNoAttributes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, []byte("file"), 0o644))
+	xattrs, err := readXattrs(path)
+	if errors.Is(err, errXattrsUnsupported) {
+		t.Skip("file system does not support extended attributes")
+	}
+	require.NoError(t, err)
+	require.Empty(t, xattrs)
+
+	_, err = readXattrs(filepath.Join(t.TempDir(), "nope"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,7 @@
+//go:build !linux
+
+package tar
+
+func readXattrs(string) (map[string]string, error) {
+	return nil, errXattrsUnsupported
+}
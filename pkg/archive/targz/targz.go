@@ -17,10 +17,10 @@ type Archive struct {
 }
 
 // New tar.gz archive.
-func New(target io.Writer) Archive {
+func New(target io.Writer, opts ...tar.Option) Archive {
 	// the error will be nil since the compression level is valid
 	gw, _ := gzip.NewWriterLevel(target, gzip.BestCompression)
-	tw := tar.New(gw)
+	tw := tar.New(gw, opts...)
 	return Archive{
 		gw: gw,
 		tw: &tw,
@@ -17,9 +17,9 @@ type Archive struct {
 }
 
 // New tar.xz archive.
-func New(target io.Writer) Archive {
+func New(target io.Writer, opts ...tar.Option) Archive {
 	xzw, _ := xz.WriterConfig{DictCap: 16 * 1024 * 1024}.NewWriter(target)
-	tw := tar.New(xzw)
+	tw := tar.New(xzw, opts...)
 	return Archive{
 		xzw: xzw,
 		tw:  &tw,
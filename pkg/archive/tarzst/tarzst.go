@@ -17,9 +17,9 @@ type Archive struct {
 }
 
 // New tar.zst archive.
-func New(target io.Writer) Archive {
+func New(target io.Writer, opts ...tar.Option) Archive {
 	zstw, _ := zstd.NewWriter(target)
-	tw := tar.New(zstw)
+	tw := tar.New(zstw, opts...)
 	return Archive{
 		zstw: zstw,
 		tw:   &tw,
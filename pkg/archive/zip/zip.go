@@ -64,8 +64,8 @@ func Copying(source *os.File, target io.Writer) (Archive, error) {
 		if err != nil {
 			return Archive{}, fmt.Errorf("opening %q from source: %w", zf.Name, err)
 		}
-		defer rr.Close()
 		if _, err = io.Copy(ww, rr); err != nil {
+			_ = rr.Close()
 			return Archive{}, fmt.Errorf("copy from %q source to target: %w", zf.Name, err)
 		}
 		_ = rr.Close()
@@ -79,6 +79,12 @@ func (a Archive) Close() error {
 }
 
 // Add a file to the zip archive.
+//
+// The file contents are streamed into the archive, so memory usage does not
+// depend on the file size.
+// Unless set in the file info, regular files get their permissions normalized
+// to either 0o755 or 0o644, depending on whether they are executable, so the
+// archive doesn't depend on the umask of the machine it is built on.
 func (a Archive) Add(f config.File) error {
 	if _, ok := a.files[f.Destination]; ok {
 		return &fs.PathError{Err: fs.ErrExist, Path: f.Destination, Op: "add"}
@@ -102,6 +108,8 @@ func (a Archive) Add(f config.File) error {
 	}
 	if f.Info.Mode != 0 {
 		header.SetMode(f.Info.Mode)
+	} else if info.Mode().IsRegular() {
+		header.SetMode(normalizedMode(info.Mode()))
 	}
 	w, err := a.z.CreateHeader(header)
 	if err != nil {
@@ -127,4 +135,11 @@ func (a Archive) Add(f config.File) error {
 	return err
 }
 
+func normalizedMode(mode fs.FileMode) fs.FileMode {
+	if mode&0o111 != 0 {
+		return 0o755
+	}
+	return 0o644
+}
+
 // TODO: test fileinfo stuff
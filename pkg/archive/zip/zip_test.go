@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
@@ -150,6 +151,78 @@ func TestZipFileInfo(t *testing.T) {
 	}
 }
 
+func TestZipReproducible(t *testing.T) {
+	mtime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	build := func(t *testing.T, perm fs.FileMode, modified time.Time) []byte {
+		t.Helper()
+		dir := t.TempDir()
+		for name, mode := range map[string]fs.FileMode{
+			"bin":       perm | 0o111,
+			"README.md": perm,
+		} {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(path, []byte("contents of "+name), 0o600))
+			require.NoError(t, os.Chmod(path, mode))
+			require.NoError(t, os.Chtimes(path, modified, modified))
+		}
+
+		var buf bytes.Buffer
+		archive := New(&buf)
+		for _, name := range []string{"README.md", "bin"} {
+			require.NoError(t, archive.Add(config.File{
+				Source:      filepath.Join(dir, name),
+				Destination: name,
+				Info: config.FileInfo{
+					ParsedMTime: mtime,
+				},
+			}))
+		}
+		require.NoError(t, archive.Close())
+		return buf.Bytes()
+	}
+
+	first := build(t, 0o644, time.Now())
+	second := build(t, 0o664, time.Now().Add(-time.Hour))
+	require.Equal(t, first, second)
+
+	r, err := zip.NewReader(bytes.NewReader(first), int64(len(first)))
+	require.NoError(t, err)
+	require.Len(t, r.File, 2)
+	require.Equal(t, fs.FileMode(0o644), r.File[0].Mode())
+	require.Equal(t, fs.FileMode(0o755), r.File[1].Mode())
+	for _, f := range r.File {
+		require.Equal(t, mtime.Unix(), f.Modified.Unix())
+	}
+}
+
+func TestZipLargeFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large file test in short mode")
+	}
+	const size = 300 << 20
+
+	path := filepath.Join(t.TempDir(), "large")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, f.Truncate(size))
+	require.NoError(t, f.Close())
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	archive := New(io.Discard)
+	require.NoError(t, archive.Add(config.File{
+		Source:      path,
+		Destination: "large",
+	}))
+	require.NoError(t, archive.Close())
+
+	runtime.ReadMemStats(&after)
+	allocated := after.TotalAlloc - before.TotalAlloc
+	require.Less(t, allocated, uint64(size/10), "zipping a %d bytes file allocated %d bytes", size, allocated)
+}
+
 func TestTarInvalidLink(t *testing.T) {
 	archive := New(io.Discard)
 	defer archive.Close()
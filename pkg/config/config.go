@@ -23,6 +23,21 @@ type Git struct {
 	TagSort          string   `yaml:"tag_sort,omitempty" json:"tag_sort,omitempty" jsonschema:"enum=-version:refname,enum=-version:creatordate,default=-version:refname"`
 	PrereleaseSuffix string   `yaml:"prerelease_suffix,omitempty" json:"prerelease_suffix,omitempty"`
 	IgnoreTags       []string `yaml:"ignore_tags,omitempty" json:"ignore_tags,omitempty"`
+	Tag              GitTag   `yaml:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// Monorepo configures the tags of a project within a monorepo.
+type Monorepo struct {
+	TagPrefix string `yaml:"tag_prefix,omitempty" json:"tag_prefix,omitempty"`
+	Dir       string `yaml:"dir,omitempty" json:"dir,omitempty"`
+}
+
+// GitTag configures the tag created with --create-tag.
+type GitTag struct {
+	NameTemplate string `yaml:"name_template,omitempty" json:"name_template,omitempty"`
+	Message      string `yaml:"message,omitempty" json:"message,omitempty"`
+	Remote       string `yaml:"remote,omitempty" json:"remote,omitempty"`
+	Sign         bool   `yaml:"sign,omitempty" json:"sign,omitempty"`
 }
 
 // GitHubURLs holds the URLs to be used when using github enterprise.
@@ -31,6 +46,9 @@ type GitHubURLs struct {
 	Upload        string `yaml:"upload,omitempty" json:"upload,omitempty"`
 	Download      string `yaml:"download,omitempty" json:"download,omitempty"`
 	SkipTLSVerify bool   `yaml:"skip_tls_verify,omitempty" json:"skip_tls_verify,omitempty"`
+	UserAgent     string `yaml:"user_agent,omitempty" json:"user_agent,omitempty"`
+	Proxy         string `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+	NoProxy       string `yaml:"no_proxy,omitempty" json:"no_proxy,omitempty"`
 }
 
 // GitLabURLs holds the URLs to be used when using gitlab ce/enterprise.
@@ -40,6 +58,9 @@ type GitLabURLs struct {
 	SkipTLSVerify      bool   `yaml:"skip_tls_verify,omitempty" json:"skip_tls_verify,omitempty"`
 	UsePackageRegistry bool   `yaml:"use_package_registry,omitempty" json:"use_package_registry,omitempty"`
 	UseJobToken        bool   `yaml:"use_job_token,omitempty" json:"use_job_token,omitempty"`
+	UserAgent          string `yaml:"user_agent,omitempty" json:"user_agent,omitempty"`
+	Proxy              string `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+	NoProxy            string `yaml:"no_proxy,omitempty" json:"no_proxy,omitempty"`
 }
 
 // GiteaURLs holds the URLs to be used when using gitea.
@@ -47,6 +68,12 @@ type GiteaURLs struct {
 	API           string `yaml:"api,omitempty" json:"api,omitempty"`
 	Download      string `yaml:"download,omitempty" json:"download,omitempty"`
 	SkipTLSVerify bool   `yaml:"skip_tls_verify,omitempty" json:"skip_tls_verify,omitempty"`
+	UserAgent     string `yaml:"user_agent,omitempty" json:"user_agent,omitempty"`
+	ClientCert    string `yaml:"client_cert,omitempty" json:"client_cert,omitempty"`
+	ClientKey     string `yaml:"client_key,omitempty" json:"client_key,omitempty"`
+	CACert        string `yaml:"ca_cert,omitempty" json:"ca_cert,omitempty"`
+	Proxy         string `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+	NoProxy       string `yaml:"no_proxy,omitempty" json:"no_proxy,omitempty"`
 }
 
 // Repo represents any kind of repo (github, gitlab, etc).
@@ -143,9 +170,10 @@ func (a PullRequestBase) JSONSchema() *jsonschema.Schema {
 }
 
 type PullRequest struct {
-	Enabled bool            `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	Base    PullRequestBase `yaml:"base,omitempty" json:"base,omitempty"`
-	Draft   bool            `yaml:"draft,omitempty" json:"draft,omitempty"`
+	Enabled   bool            `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Base      PullRequestBase `yaml:"base,omitempty" json:"base,omitempty"`
+	Draft     bool            `yaml:"draft,omitempty" json:"draft,omitempty"`
+	AutoMerge bool            `yaml:"auto_merge,omitempty" json:"auto_merge,omitempty"`
 }
 
 // HomebrewDependency represents Homebrew dependency.
@@ -245,7 +273,63 @@ type Homebrew struct {
 	IDs                   []string             `yaml:"ids,omitempty" json:"ids,omitempty"`
 	Goarm                 string               `yaml:"goarm,omitempty" json:"goarm,omitempty" jsonschema:"oneof_type=string;integer"`
 	Goamd64               string               `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
-	Service               string               `yaml:"service,omitempty" json:"service,omitempty"`
+	Service               HomebrewService      `yaml:"service,omitempty" json:"service,omitempty"`
+	Binaries              []HomebrewBinary     `yaml:"binaries,omitempty" json:"binaries,omitempty"`
+}
+
+// HomebrewBinary is a binary to be installed by a Homebrew formula.
+type HomebrewBinary struct {
+	Name   string `yaml:"name,omitempty" json:"name,omitempty"`
+	Rename string `yaml:"rename,omitempty" json:"rename,omitempty"`
+}
+
+// HomebrewService represents the service block of a Homebrew formula.
+type HomebrewService struct {
+	Block        string   `yaml:"block,omitempty" json:"block,omitempty"`
+	Run          []string `yaml:"run,omitempty" json:"run,omitempty"`
+	WorkingDir   string   `yaml:"working_dir,omitempty" json:"working_dir,omitempty"`
+	LogPath      string   `yaml:"log_path,omitempty" json:"log_path,omitempty"`
+	ErrorLogPath string   `yaml:"error_log_path,omitempty" json:"error_log_path,omitempty"`
+	KeepAlive    bool     `yaml:"keep_alive,omitempty" json:"keep_alive,omitempty"`
+	RunType      string   `yaml:"run_type,omitempty" json:"run_type,omitempty" jsonschema:"enum=immediate,enum=interval,enum=cron"`
+	Interval     int      `yaml:"interval,omitempty" json:"interval,omitempty"`
+	Cron         string   `yaml:"cron,omitempty" json:"cron,omitempty"`
+}
+
+// type alias to prevent stack overflowing in the custom unmarshaler.
+type homebrewService HomebrewService
+
+// UnmarshalYAML is a custom unmarshaler that accepts either the raw service
+// block as a string, or its structured form.
+func (a *HomebrewService) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err == nil {
+		a.Block = str
+		return nil
+	}
+
+	var service homebrewService
+	if err := unmarshal(&service); err != nil {
+		return err
+	}
+
+	*a = HomebrewService(service)
+	return nil
+}
+
+func (a HomebrewService) JSONSchema() *jsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		ExpandedStruct: true,
+	}
+	schema := reflector.Reflect(&homebrewService{})
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{
+				Type: "string",
+			},
+			schema,
+		},
+	}
 }
 
 type Nix struct {
@@ -365,6 +449,7 @@ type Ko struct {
 	Main                string            `yaml:"main,omitempty" json:"main,omitempty"`
 	WorkingDir          string            `yaml:"working_dir,omitempty" json:"working_dir,omitempty"`
 	BaseImage           string            `yaml:"base_image,omitempty" json:"base_image,omitempty"`
+	PlatformBaseImages  map[string]string `yaml:"platform_base_images,omitempty" json:"platform_base_images,omitempty"`
 	Labels              map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
 	Repository          string            `yaml:"repository,omitempty" json:"repository,omitempty"`
 	Platforms           []string          `yaml:"platforms,omitempty" json:"platforms,omitempty"`
@@ -378,6 +463,8 @@ type Ko struct {
 	Bare                bool              `yaml:"bare,omitempty" json:"bare,omitempty"`
 	PreserveImportPaths bool              `yaml:"preserve_import_paths,omitempty" json:"preserve_import_paths,omitempty"`
 	BaseImportPaths     bool              `yaml:"base_import_paths,omitempty" json:"base_import_paths,omitempty"`
+	ContinueOnError     bool              `yaml:"continue_on_error,omitempty" json:"continue_on_error,omitempty"`
+	AttachSBOMs         SBOMAttach        `yaml:"attach_sboms,omitempty" json:"attach_sboms,omitempty"`
 }
 
 // Scoop contains the scoop.sh section.
@@ -498,6 +585,8 @@ type Build struct {
 	Main            string          `yaml:"main,omitempty" json:"main,omitempty"`
 	Binary          string          `yaml:"binary,omitempty" json:"binary,omitempty"`
 	Hooks           BuildHookConfig `yaml:"hooks,omitempty" json:"hooks,omitempty"`
+	Completions     BuildCompletion `yaml:"completions,omitempty" json:"completions,omitempty"`
+	ManPage         BuildManPage    `yaml:"manpage,omitempty" json:"manpage,omitempty"`
 	Builder         string          `yaml:"builder,omitempty" json:"builder,omitempty"`
 	ModTimestamp    string          `yaml:"mod_timestamp,omitempty" json:"mod_timestamp,omitempty"`
 	Skip            bool            `yaml:"skip,omitempty" json:"skip,omitempty"`
@@ -505,6 +594,7 @@ type Build struct {
 	Command         string          `yaml:"command,omitempty" json:"command,omitempty"`
 	NoUniqueDistDir bool            `yaml:"no_unique_dist_dir,omitempty" json:"no_unique_dist_dir,omitempty"`
 	NoMainCheck     bool            `yaml:"no_main_check,omitempty" json:"no_main_check,omitempty"`
+	Cache           bool            `yaml:"cache,omitempty" json:"cache,omitempty"`
 	UnproxiedMain   string          `yaml:"-" json:"-"` // used by gomod.proxy
 	UnproxiedDir    string          `yaml:"-" json:"-"` // used by gomod.proxy
 
@@ -512,23 +602,38 @@ type Build struct {
 	BuildDetailsOverrides []BuildDetailsOverride `yaml:"overrides,omitempty" json:"overrides,omitempty"`
 }
 
+// BuildCompletion allows to generate shell completions by running the binary
+// built for the host platform.
+type BuildCompletion struct {
+	Cmd    string   `yaml:"cmd,omitempty" json:"cmd,omitempty"`
+	Shells []string `yaml:"shells,omitempty" json:"shells,omitempty" jsonschema:"enum=bash,enum=zsh,enum=fish"`
+}
+
+// BuildManPage allows to generate a man page by running the binary built for
+// the host platform.
+type BuildManPage struct {
+	Cmd string `yaml:"cmd,omitempty" json:"cmd,omitempty"`
+}
+
 type BuildDetailsOverride struct {
 	Goos         string `yaml:"goos,omitempty" json:"goos,omitempty"`
 	Goarch       string `yaml:"goarch,omitempty" json:"goarch,omitempty"`
 	Goarm        string `yaml:"goarm,omitempty" json:"goarm,omitempty" jsonschema:"oneof_type=string;integer"`
 	Gomips       string `yaml:"gomips,omitempty" json:"gomips,omitempty"`
 	Goamd64      string `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	LdflagsMode  string `yaml:"ldflags_mode,omitempty" json:"ldflags_mode,omitempty" jsonschema:"enum=replace,enum=append,default=replace"`
 	BuildDetails `yaml:",inline" json:",inline"`
 }
 
 type BuildDetails struct {
-	Buildmode string      `yaml:"buildmode,omitempty" json:"buildmode,omitempty" jsonschema:"enum=c-archive,enum=c-shared,enum=pie,enum=,default="`
-	Ldflags   StringArray `yaml:"ldflags,omitempty" json:"ldflags,omitempty"`
-	Tags      FlagArray   `yaml:"tags,omitempty" json:"tags,omitempty"`
-	Flags     FlagArray   `yaml:"flags,omitempty" json:"flags,omitempty"`
-	Asmflags  StringArray `yaml:"asmflags,omitempty" json:"asmflags,omitempty"`
-	Gcflags   StringArray `yaml:"gcflags,omitempty" json:"gcflags,omitempty"`
-	Env       []string    `yaml:"env,omitempty" json:"env,omitempty"`
+	Buildmode   string      `yaml:"buildmode,omitempty" json:"buildmode,omitempty" jsonschema:"enum=c-archive,enum=c-shared,enum=pie,enum=plugin,enum=exe,enum=default,enum=,default="`
+	Ldflags     StringArray `yaml:"ldflags,omitempty" json:"ldflags,omitempty"`
+	LdflagsFile string      `yaml:"ldflags_file,omitempty" json:"ldflags_file,omitempty"`
+	Tags        FlagArray   `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Flags       FlagArray   `yaml:"flags,omitempty" json:"flags,omitempty"`
+	Asmflags    StringArray `yaml:"asmflags,omitempty" json:"asmflags,omitempty"`
+	Gcflags     StringArray `yaml:"gcflags,omitempty" json:"gcflags,omitempty"`
+	Env         []string    `yaml:"env,omitempty" json:"env,omitempty"`
 }
 
 type BuildHookConfig struct {
@@ -624,6 +729,7 @@ type File struct {
 	Destination string   `yaml:"dst,omitempty" json:"dst,omitempty"`
 	StripParent bool     `yaml:"strip_parent,omitempty" json:"strip_parent,omitempty"`
 	Info        FileInfo `yaml:"info,omitempty" json:"info,omitempty"`
+	Exclude     []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
 	Default     bool     `yaml:"-" json:"-"`
 }
 
@@ -693,10 +799,23 @@ type UPX struct {
 	Brute    bool     `yaml:"brute,omitempty" json:"brute,omitempty"`
 }
 
+// Completion is a shell completion script to be packaged.
+type Completion struct {
+	Shell string `yaml:"shell,omitempty" json:"shell,omitempty" jsonschema:"enum=bash,enum=zsh,enum=fish"`
+	Src   string `yaml:"src,omitempty" json:"src,omitempty"`
+	Name  string `yaml:"name,omitempty" json:"name,omitempty"`
+}
+
+// ManPage is a man page to be packaged.
+type ManPage struct {
+	Src string `yaml:"src,omitempty" json:"src,omitempty"`
+}
+
 // Archive config used for the archive.
 type Archive struct {
 	ID                        string           `yaml:"id,omitempty" json:"id,omitempty"`
 	Builds                    []string         `yaml:"builds,omitempty" json:"builds,omitempty"`
+	Filter                    string           `yaml:"filter,omitempty" json:"filter,omitempty"`
 	BuildsInfo                FileInfo         `yaml:"builds_info,omitempty" json:"builds_info,omitempty"`
 	NameTemplate              string           `yaml:"name_template,omitempty" json:"name_template,omitempty"`
 	Format                    string           `yaml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=tar,enum=tgz,enum=tar.gz,enum=zip,enum=gz,enum=tar.xz,enum=txz,enum=binary,default=tar.gz"`
@@ -706,15 +825,33 @@ type Archive struct {
 	Files                     []File           `yaml:"files,omitempty" json:"files,omitempty"`
 	Meta                      bool             `yaml:"meta,omitempty" json:"meta,omitempty"`
 	AllowDifferentBinaryCount bool             `yaml:"allow_different_binary_count,omitempty" json:"allow_different_binary_count,omitempty"`
+	IncludeVersionFile        bool             `yaml:"include_version_file,omitempty" json:"include_version_file,omitempty"`
+	VersionFile               VersionFile      `yaml:"version_file,omitempty" json:"version_file,omitempty"`
+	PreserveXattrs            bool             `yaml:"preserve_xattrs,omitempty" json:"preserve_xattrs,omitempty"`
+	Dedupe                    string           `yaml:"dedupe,omitempty" json:"dedupe,omitempty" jsonschema:"enum=hardlink"`
+	Hooks                     ArchiveHooks     `yaml:"hooks,omitempty" json:"hooks,omitempty"`
+}
+
+// ArchiveHooks are the hooks run for each archive created.
+type ArchiveHooks struct {
+	After Hooks `yaml:"after,omitempty" json:"after,omitempty"`
+}
+
+// VersionFile is a file generated from a template and added to an archive.
+type VersionFile struct {
+	Name    string `yaml:"name,omitempty" json:"name,omitempty"`
+	Content string `yaml:"content,omitempty" json:"content,omitempty"`
 }
 
 type ReleaseNotesMode string
 
 const (
-	ReleaseNotesModeKeepExisting ReleaseNotesMode = "keep-existing"
-	ReleaseNotesModeAppend       ReleaseNotesMode = "append"
-	ReleaseNotesModeReplace      ReleaseNotesMode = "replace"
-	ReleaseNotesModePrepend      ReleaseNotesMode = "prepend"
+	ReleaseNotesModeKeepExisting  ReleaseNotesMode = "keep-existing"
+	ReleaseNotesModeAppend        ReleaseNotesMode = "append"
+	ReleaseNotesModeReplace       ReleaseNotesMode = "replace"
+	ReleaseNotesModePrepend       ReleaseNotesMode = "prepend"
+	ReleaseNotesModeAppendDedupe  ReleaseNotesMode = "append-dedupe"
+	ReleaseNotesModePrependDedupe ReleaseNotesMode = "prepend-dedupe"
 )
 
 // Release config used for the GitHub/GitLab release.
@@ -722,6 +859,7 @@ type Release struct {
 	GitHub                 Repo        `yaml:"github,omitempty" json:"github,omitempty"`
 	GitLab                 Repo        `yaml:"gitlab,omitempty" json:"gitlab,omitempty"`
 	Gitea                  Repo        `yaml:"gitea,omitempty" json:"gitea,omitempty"`
+	Mirrors                []Mirror    `yaml:"mirrors,omitempty" json:"mirrors,omitempty"`
 	Draft                  bool        `yaml:"draft,omitempty" json:"draft,omitempty"`
 	ReplaceExistingDraft   bool        `yaml:"replace_existing_draft,omitempty" json:"replace_existing_draft,omitempty"`
 	TargetCommitish        string      `yaml:"target_commitish,omitempty" json:"target_commitish,omitempty"`
@@ -735,10 +873,20 @@ type Release struct {
 	DiscussionCategoryName string      `yaml:"discussion_category_name,omitempty" json:"discussion_category_name,omitempty"`
 	Header                 string      `yaml:"header,omitempty" json:"header,omitempty"`
 	Footer                 string      `yaml:"footer,omitempty" json:"footer,omitempty"`
+	NotesFile              string      `yaml:"notes_file,omitempty" json:"notes_file,omitempty"`
 
-	ReleaseNotesMode         ReleaseNotesMode `yaml:"mode,omitempty" json:"mode,omitempty" jsonschema:"enum=keep-existing,enum=append,enum=prepend,enum=replace,default=keep-existing"`
+	ReleaseNotesMode         ReleaseNotesMode `yaml:"mode,omitempty" json:"mode,omitempty" jsonschema:"enum=keep-existing,enum=append,enum=prepend,enum=replace,enum=append-dedupe,enum=prepend-dedupe,default=keep-existing"`
 	ReplaceExistingArtifacts bool             `yaml:"replace_existing_artifacts,omitempty" json:"replace_existing_artifacts,omitempty"`
 	IncludeMeta              bool             `yaml:"include_meta,omitempty" json:"include_meta,omitempty"`
+
+	IncludeExtraFilesInChecksums bool `yaml:"include_extra_files_in_checksums,omitempty" json:"include_extra_files_in_checksums,omitempty"`
+}
+
+// Mirror is an additional GitHub repository the release is published to.
+type Mirror struct {
+	Owner string `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Name  string `yaml:"name,omitempty" json:"name,omitempty"`
+	Token string `yaml:"token,omitempty" json:"token,omitempty"`
 }
 
 // Milestone config used for VCS milestone.
@@ -780,6 +928,7 @@ type Libdirs struct {
 	Header   string `yaml:"header,omitempty" json:"header,omitempty"`
 	CArchive string `yaml:"carchive,omitempty" json:"carchive,omitempty"`
 	CShared  string `yaml:"cshared,omitempty" json:"cshared,omitempty"`
+	Plugin   string `yaml:"plugin,omitempty" json:"plugin,omitempty"`
 }
 
 // NFPMScripts is used to specify maintainer scripts.
@@ -925,11 +1074,14 @@ type Sign struct {
 	Signature   string   `yaml:"signature,omitempty" json:"signature,omitempty"`
 	Artifacts   string   `yaml:"artifacts,omitempty" json:"artifacts,omitempty" jsonschema:"enum=all,enum=manifests,enum=images,enum=checksum,enum=source,enum=package,enum=archive,enum=binary,enum=sbom"`
 	IDs         []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Filter      string   `yaml:"filter,omitempty" json:"filter,omitempty"`
 	Stdin       *string  `yaml:"stdin,omitempty" json:"stdin,omitempty"`
 	StdinFile   string   `yaml:"stdin_file,omitempty" json:"stdin_file,omitempty"`
 	Env         []string `yaml:"env,omitempty" json:"env,omitempty"`
 	Certificate string   `yaml:"certificate,omitempty" json:"certificate,omitempty"`
 	Output      bool     `yaml:"output,omitempty" json:"output,omitempty"`
+	Phase       string   `yaml:"phase,omitempty" json:"phase,omitempty" jsonschema:"enum=build"`
+	IfEmpty     string   `yaml:"if_empty,omitempty" json:"if_empty,omitempty" jsonschema:"enum=warn,enum=skip,enum=error,default=warn"`
 }
 
 type Notarize struct {
@@ -1025,6 +1177,70 @@ type Snapcraft struct {
 	Files []SnapcraftExtraFiles `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
 }
 
+// Flatpak config.
+type Flatpak struct {
+	ID             string   `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs            []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	NameTemplate   string   `yaml:"name_template,omitempty" json:"name_template,omitempty"`
+	Goamd64        string   `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	AppID          string   `yaml:"app_id,omitempty" json:"app_id,omitempty"`
+	Runtime        string   `yaml:"runtime,omitempty" json:"runtime,omitempty"`
+	RuntimeVersion string   `yaml:"runtime_version,omitempty" json:"runtime_version,omitempty"`
+	SDK            string   `yaml:"sdk,omitempty" json:"sdk,omitempty"`
+	Command        string   `yaml:"command,omitempty" json:"command,omitempty"`
+	FinishArgs     []string `yaml:"finish_args,omitempty" json:"finish_args,omitempty"`
+	Disable        string   `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// AppImage config.
+type AppImage struct {
+	ID           string   `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs          []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	NameTemplate string   `yaml:"name_template,omitempty" json:"name_template,omitempty"`
+	Goamd64      string   `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	Goarm        string   `yaml:"goarm,omitempty" json:"goarm,omitempty" jsonschema:"oneof_type=string;integer"`
+	Name         string   `yaml:"name,omitempty" json:"name,omitempty"`
+	Comment      string   `yaml:"comment,omitempty" json:"comment,omitempty"`
+	Icon         string   `yaml:"icon,omitempty" json:"icon,omitempty"`
+	Categories   []string `yaml:"categories,omitempty" json:"categories,omitempty"`
+	Terminal     bool     `yaml:"terminal,omitempty" json:"terminal,omitempty"`
+	Command      string   `yaml:"command,omitempty" json:"command,omitempty"`
+	Disable      string   `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// MSI config.
+type MSI struct {
+	ID           string   `yaml:"id,omitempty" json:"id,omitempty"`
+	Name         string   `yaml:"name,omitempty" json:"name,omitempty"`
+	WXS          string   `yaml:"wxs,omitempty" json:"wxs,omitempty"`
+	IDs          []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Goamd64      string   `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	ExtraFiles   []string `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
+	Replace      bool     `yaml:"replace,omitempty" json:"replace,omitempty"`
+	ProductName  string   `yaml:"product_name,omitempty" json:"product_name,omitempty"`
+	Manufacturer string   `yaml:"manufacturer,omitempty" json:"manufacturer,omitempty"`
+	UpgradeCode  string   `yaml:"upgrade_code,omitempty" json:"upgrade_code,omitempty"`
+	InstallDir   string   `yaml:"install_dir,omitempty" json:"install_dir,omitempty"`
+	Version      string   `yaml:"version,omitempty" json:"version,omitempty"`
+	ModTimestamp string   `yaml:"mod_timestamp,omitempty" json:"mod_timestamp,omitempty"`
+	Disable      string   `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
+// NSIS config.
+type NSIS struct {
+	ID           string   `yaml:"id,omitempty" json:"id,omitempty"`
+	Name         string   `yaml:"name,omitempty" json:"name,omitempty"`
+	Script       string   `yaml:"script,omitempty" json:"script,omitempty"`
+	IDs          []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Goamd64      string   `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
+	ExtraFiles   []string `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
+	ProductName  string   `yaml:"product_name,omitempty" json:"product_name,omitempty"`
+	License      string   `yaml:"license,omitempty" json:"license,omitempty"`
+	Shortcut     bool     `yaml:"shortcut,omitempty" json:"shortcut,omitempty"`
+	ModTimestamp string   `yaml:"mod_timestamp,omitempty" json:"mod_timestamp,omitempty"`
+	Disable      string   `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+}
+
 // SnapcraftExtraFiles config.
 type SnapcraftExtraFiles struct {
 	Source      string `yaml:"source" json:"source"`
@@ -1034,14 +1250,22 @@ type SnapcraftExtraFiles struct {
 
 // Snapshot config.
 type Snapshot struct {
-	NameTemplate string `yaml:"name_template,omitempty" json:"name_template,omitempty"`
+	NameTemplate    string `yaml:"name_template,omitempty" json:"name_template,omitempty"`
+	VersionTemplate string `yaml:"version_template,omitempty" json:"version_template,omitempty"`
+}
+
+// Nightly config.
+type Nightly struct {
+	NameTemplate   string `yaml:"name_template,omitempty" json:"name_template,omitempty"`
+	TagName        string `yaml:"tag_name,omitempty" json:"tag_name,omitempty"`
+	PublishRelease bool   `yaml:"publish_release,omitempty" json:"publish_release,omitempty"`
 }
 
 // Checksum config.
 type Checksum struct {
 	NameTemplate string      `yaml:"name_template,omitempty" json:"name_template,omitempty"`
 	Algorithm    string      `yaml:"algorithm,omitempty" json:"algorithm,omitempty"`
-	Split        bool        `yaml:"split,omitempty" json:"split,omitempty"`
+	Split        string      `yaml:"split,omitempty" json:"split,omitempty" jsonschema:"oneof_type=string;boolean,enum=true,enum=false,enum=by-id,enum="`
 	IDs          []string    `yaml:"ids,omitempty" json:"ids,omitempty"`
 	Disable      bool        `yaml:"disable,omitempty" json:"disable,omitempty"`
 	ExtraFiles   []ExtraFile `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
@@ -1056,23 +1280,63 @@ type Docker struct {
 	Goarm              string   `yaml:"goarm,omitempty" json:"goarm,omitempty" jsonschema:"oneof_type=string;integer"`
 	Goamd64            string   `yaml:"goamd64,omitempty" json:"goamd64,omitempty"`
 	Dockerfile         string   `yaml:"dockerfile,omitempty" json:"dockerfile,omitempty"`
+	Context            string   `yaml:"context,omitempty" json:"context,omitempty"`
 	ImageTemplates     []string `yaml:"image_templates,omitempty" json:"image_templates,omitempty"`
 	SkipPush           string   `yaml:"skip_push,omitempty" json:"skip_push,omitempty" jsonschema:"oneof_type=string;boolean"`
 	Files              []string `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
 	BuildFlagTemplates []string `yaml:"build_flag_templates,omitempty" json:"build_flag_templates,omitempty"`
 	PushFlags          []string `yaml:"push_flags,omitempty" json:"push_flags,omitempty"`
-	Use                string   `yaml:"use,omitempty" json:"use,omitempty" jsonschema:"enum=docker,enum=buildx,default=docker"`
+	Use                string   `yaml:"use,omitempty" json:"use,omitempty" jsonschema:"enum=docker,enum=buildx,enum=bake,default=docker"`
+	ContinueOnError    bool     `yaml:"continue_on_error,omitempty" json:"continue_on_error,omitempty"`
+
+	RegistryAuth []DockerRegistryAuth `yaml:"registry_auth,omitempty" json:"registry_auth,omitempty"`
+	AttachSBOMs  SBOMAttach           `yaml:"attach_sboms,omitempty" json:"attach_sboms,omitempty"`
+	Bake         DockerBake           `yaml:"bake,omitempty" json:"bake,omitempty"`
+}
+
+// DockerBake configures the bake file used when building with
+// `docker buildx bake`.
+type DockerBake struct {
+	File   string `yaml:"file,omitempty" json:"file,omitempty" jsonschema:"default=docker-bake.hcl"`
+	Target string `yaml:"target,omitempty" json:"target,omitempty" jsonschema:"default=default"`
+}
+
+// SBOMAttach configures which SBOMs are attached to the pushed images, as OCI
+// referrers.
+type SBOMAttach struct {
+	IDs  []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Tool string   `yaml:"tool,omitempty" json:"tool,omitempty" jsonschema:"enum=oras,enum=cosign,default=oras"`
+}
+
+// DockerRegistryAuth holds the credentials used to log in to a registry
+// before pushing to it.
+type DockerRegistryAuth struct {
+	Registry string `yaml:"registry,omitempty" json:"registry,omitempty"`
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
+	Token    string `yaml:"token,omitempty" json:"token,omitempty"`
+}
+
+// DockerScan config.
+type DockerScan struct {
+	ID       string   `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs      []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Scanner  string   `yaml:"scanner,omitempty" json:"scanner,omitempty" jsonschema:"enum=trivy,enum=grype,default=trivy"`
+	Severity string   `yaml:"severity,omitempty" json:"severity,omitempty" jsonschema:"enum=low,enum=medium,enum=high,enum=critical,default=critical"`
+	Ignore   []string `yaml:"ignore,omitempty" json:"ignore,omitempty"`
+	Disable  string   `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
 }
 
 // DockerManifest config.
 type DockerManifest struct {
-	ID             string   `yaml:"id,omitempty" json:"id,omitempty"`
-	NameTemplate   string   `yaml:"name_template,omitempty" json:"name_template,omitempty"`
-	SkipPush       string   `yaml:"skip_push,omitempty" json:"skip_push,omitempty" jsonschema:"oneof_type=string;boolean"`
-	ImageTemplates []string `yaml:"image_templates,omitempty" json:"image_templates,omitempty"`
-	CreateFlags    []string `yaml:"create_flags,omitempty" json:"create_flags,omitempty"`
-	PushFlags      []string `yaml:"push_flags,omitempty" json:"push_flags,omitempty"`
-	Use            string   `yaml:"use,omitempty" json:"use,omitempty"`
+	ID              string   `yaml:"id,omitempty" json:"id,omitempty"`
+	NameTemplate    string   `yaml:"name_template,omitempty" json:"name_template,omitempty"`
+	SkipPush        string   `yaml:"skip_push,omitempty" json:"skip_push,omitempty" jsonschema:"oneof_type=string;boolean"`
+	ImageTemplates  []string `yaml:"image_templates,omitempty" json:"image_templates,omitempty"`
+	CreateFlags     []string `yaml:"create_flags,omitempty" json:"create_flags,omitempty"`
+	PushFlags       []string `yaml:"push_flags,omitempty" json:"push_flags,omitempty"`
+	Use             string   `yaml:"use,omitempty" json:"use,omitempty"`
+	ContinueOnError bool     `yaml:"continue_on_error,omitempty" json:"continue_on_error,omitempty"`
 }
 
 // Filters config.
@@ -1084,9 +1348,9 @@ type Filters struct {
 // Changelog Config.
 type Changelog struct {
 	Filters Filters          `yaml:"filters,omitempty" json:"filters,omitempty"`
-	Sort    string           `yaml:"sort,omitempty" json:"sort,omitempty" jsonschema:"enum=asc,enum=desc,enum=,default="`
+	Sort    string           `yaml:"sort,omitempty" json:"sort,omitempty" jsonschema:"enum=asc,enum=desc,enum=date,enum=,default="`
 	Disable string           `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
-	Use     string           `yaml:"use,omitempty" json:"use,omitempty" jsonschema:"enum=git,enum=github,enum=github-native,enum=gitlab,default=git"`
+	Use     string           `yaml:"use,omitempty" json:"use,omitempty" jsonschema:"enum=git,enum=github,enum=github-native,enum=github-pr,enum=gitlab,default=git"`
 	Format  string           `yaml:"format,omitempty" json:"format,omitempty"`
 	Groups  []ChangelogGroup `yaml:"groups,omitempty" json:"groups,omitempty"`
 	Abbrev  int              `yaml:"abbrev,omitempty" json:"abbrev,omitempty"`
@@ -1094,9 +1358,10 @@ type Changelog struct {
 
 // ChangelogGroup holds the grouping criteria for the changelog.
 type ChangelogGroup struct {
-	Title  string `yaml:"title,omitempty" json:"title,omitempty"`
-	Regexp string `yaml:"regexp,omitempty" json:"regexp,omitempty"`
-	Order  int    `yaml:"order,omitempty" json:"order,omitempty"`
+	Title  string   `yaml:"title,omitempty" json:"title,omitempty"`
+	Regexp string   `yaml:"regexp,omitempty" json:"regexp,omitempty"`
+	Labels []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Order  int      `yaml:"order,omitempty" json:"order,omitempty"`
 }
 
 // EnvFiles holds paths to files that contains environment variables
@@ -1114,22 +1379,27 @@ type Before struct {
 
 // Blob contains config for GO CDK blob.
 type Blob struct {
-	Bucket             string      `yaml:"bucket,omitempty" json:"bucket,omitempty"`
-	Provider           string      `yaml:"provider,omitempty" json:"provider,omitempty"`
-	Region             string      `yaml:"region,omitempty" json:"region,omitempty"`
-	DisableSSL         bool        `yaml:"disable_ssl,omitempty" json:"disable_ssl,omitempty"`
-	Directory          string      `yaml:"directory,omitempty" json:"directory,omitempty"`
-	KMSKey             string      `yaml:"kms_key,omitempty" json:"kms_key,omitempty"`
-	IDs                []string    `yaml:"ids,omitempty" json:"ids,omitempty"`
-	Endpoint           string      `yaml:"endpoint,omitempty" json:"endpoint,omitempty"` // used for minio for example
-	ExtraFiles         []ExtraFile `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
-	Disable            string      `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
-	S3ForcePathStyle   *bool       `yaml:"s3_force_path_style,omitempty" json:"s3_force_path_style,omitempty"`
-	ACL                string      `yaml:"acl,omitempty" json:"acl,omitempty"`
-	CacheControl       []string    `yaml:"cache_control,omitempty" json:"cache_control,omitempty"`
-	ContentDisposition string      `yaml:"content_disposition,omitempty" json:"content_disposition,omitempty"`
-	IncludeMeta        bool        `yaml:"include_meta,omitempty" json:"include_meta,omitempty"`
-	ExtraFilesOnly     bool        `yaml:"extra_files_only,omitempty" json:"extra_files_only,omitempty"`
+	Bucket             string            `yaml:"bucket,omitempty" json:"bucket,omitempty"`
+	Provider           string            `yaml:"provider,omitempty" json:"provider,omitempty"`
+	Region             string            `yaml:"region,omitempty" json:"region,omitempty"`
+	DisableSSL         bool              `yaml:"disable_ssl,omitempty" json:"disable_ssl,omitempty"`
+	Directory          string            `yaml:"directory,omitempty" json:"directory,omitempty"`
+	KMSKey             string            `yaml:"kms_key,omitempty" json:"kms_key,omitempty"`
+	IDs                []string          `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Endpoint           string            `yaml:"endpoint,omitempty" json:"endpoint,omitempty"` // used for minio for example
+	ExtraFiles         []ExtraFile       `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
+	Disable            string            `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+	S3ForcePathStyle   *bool             `yaml:"s3_force_path_style,omitempty" json:"s3_force_path_style,omitempty"`
+	ACL                string            `yaml:"acl,omitempty" json:"acl,omitempty"`
+	CacheControl       []string          `yaml:"cache_control,omitempty" json:"cache_control,omitempty"`
+	ContentDisposition string            `yaml:"content_disposition,omitempty" json:"content_disposition,omitempty"`
+	IncludeMeta        bool              `yaml:"include_meta,omitempty" json:"include_meta,omitempty"`
+	ExtraFilesOnly     bool              `yaml:"extra_files_only,omitempty" json:"extra_files_only,omitempty"`
+	ContentTypes       map[string]string `yaml:"content_types,omitempty" json:"content_types,omitempty"`
+	ContinueOnError    bool              `yaml:"continue_on_error,omitempty" json:"continue_on_error,omitempty"`
+	Proxy              string            `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+	NoProxy            string            `yaml:"no_proxy,omitempty" json:"no_proxy,omitempty"`
+	IfEmpty            string            `yaml:"if_empty,omitempty" json:"if_empty,omitempty" jsonschema:"enum=warn,enum=skip,enum=error,default=warn"`
 }
 
 // Upload configuration.
@@ -1137,6 +1407,7 @@ type Upload struct {
 	Name               string            `yaml:"name,omitempty" json:"name,omitempty"`
 	IDs                []string          `yaml:"ids,omitempty" json:"ids,omitempty"`
 	Exts               []string          `yaml:"exts,omitempty" json:"exts,omitempty"`
+	Filter             string            `yaml:"filter,omitempty" json:"filter,omitempty"`
 	Target             string            `yaml:"target,omitempty" json:"target,omitempty"`
 	Username           string            `yaml:"username,omitempty" json:"username,omitempty"`
 	Mode               string            `yaml:"mode,omitempty" json:"mode,omitempty"`
@@ -1152,20 +1423,49 @@ type Upload struct {
 	CustomHeaders      map[string]string `yaml:"custom_headers,omitempty" json:"custom_headers,omitempty"`
 	ExtraFiles         []ExtraFile       `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
 	ExtraFilesOnly     bool              `yaml:"extra_files_only,omitempty" json:"extra_files_only,omitempty"`
+	ContentTypes       map[string]string `yaml:"content_types,omitempty" json:"content_types,omitempty"`
+	ContinueOnError    bool              `yaml:"continue_on_error,omitempty" json:"continue_on_error,omitempty"`
+	Proxy              string            `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+	NoProxy            string            `yaml:"no_proxy,omitempty" json:"no_proxy,omitempty"`
+	IfEmpty            string            `yaml:"if_empty,omitempty" json:"if_empty,omitempty" jsonschema:"enum=warn,enum=skip,enum=error,default=warn"`
+}
+
+// ArtifactoryBuildInfo configures the build info publishing of one of the
+// artifactories.
+type ArtifactoryBuildInfo struct {
+	Artifactory string `yaml:"artifactory,omitempty" json:"artifactory,omitempty"`
+	Target      string `yaml:"target,omitempty" json:"target,omitempty"`
+	Name        string `yaml:"name,omitempty" json:"name,omitempty"`
+	Number      string `yaml:"number,omitempty" json:"number,omitempty"`
+	URL         string `yaml:"url,omitempty" json:"url,omitempty"`
 }
 
 // Publisher configuration.
 type Publisher struct {
-	Name       string      `yaml:"name,omitempty" json:"name,omitempty"`
-	IDs        []string    `yaml:"ids,omitempty" json:"ids,omitempty"`
-	Checksum   bool        `yaml:"checksum,omitempty" json:"checksum,omitempty"`
-	Signature  bool        `yaml:"signature,omitempty" json:"signature,omitempty"`
-	Meta       bool        `yaml:"meta,omitempty" json:"meta,omitempty"`
-	Dir        string      `yaml:"dir,omitempty" json:"dir,omitempty"`
-	Cmd        string      `yaml:"cmd,omitempty" json:"cmd,omitempty"`
-	Env        []string    `yaml:"env,omitempty" json:"env,omitempty"`
-	ExtraFiles []ExtraFile `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
-	Disable    string      `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Name            string      `yaml:"name,omitempty" json:"name,omitempty"`
+	IDs             []string    `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Checksum        bool        `yaml:"checksum,omitempty" json:"checksum,omitempty"`
+	Signature       bool        `yaml:"signature,omitempty" json:"signature,omitempty"`
+	Meta            bool        `yaml:"meta,omitempty" json:"meta,omitempty"`
+	Dir             string      `yaml:"dir,omitempty" json:"dir,omitempty"`
+	Cmd             string      `yaml:"cmd,omitempty" json:"cmd,omitempty"`
+	Env             []string    `yaml:"env,omitempty" json:"env,omitempty"`
+	ExtraFiles      []ExtraFile `yaml:"extra_files,omitempty" json:"extra_files,omitempty"`
+	Disable         string      `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+	ContinueOnError bool        `yaml:"continue_on_error,omitempty" json:"continue_on_error,omitempty"`
+}
+
+// Plugin configuration.
+type Plugin struct {
+	Name            string         `yaml:"name,omitempty" json:"name,omitempty"`
+	IDs             []string       `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Cmd             string         `yaml:"cmd,omitempty" json:"cmd,omitempty"`
+	Args            []string       `yaml:"args,omitempty" json:"args,omitempty"`
+	Dir             string         `yaml:"dir,omitempty" json:"dir,omitempty"`
+	Env             []string       `yaml:"env,omitempty" json:"env,omitempty"`
+	Config          map[string]any `yaml:"config,omitempty" json:"config,omitempty"`
+	Disable         string         `yaml:"disable,omitempty" json:"disable,omitempty" jsonschema:"oneof_type=string;boolean"`
+	ContinueOnError bool           `yaml:"continue_on_error,omitempty" json:"continue_on_error,omitempty"`
 }
 
 // Source configuration.
@@ -1195,7 +1495,12 @@ type Project struct {
 	Archives        []Archive        `yaml:"archives,omitempty" json:"archives,omitempty"`
 	NFPMs           []NFPM           `yaml:"nfpms,omitempty" json:"nfpms,omitempty"`
 	Snapcrafts      []Snapcraft      `yaml:"snapcrafts,omitempty" json:"snapcrafts,omitempty"`
+	Flatpaks        []Flatpak        `yaml:"flatpaks,omitempty" json:"flatpaks,omitempty"`
+	AppImages       []AppImage       `yaml:"appimages,omitempty" json:"appimages,omitempty"`
+	MSIs            []MSI            `yaml:"msi,omitempty" json:"msi,omitempty"`
+	NSIS            []NSIS           `yaml:"nsis,omitempty" json:"nsis,omitempty"`
 	Snapshot        Snapshot         `yaml:"snapshot,omitempty" json:"snapshot,omitempty"`
+	Nightly         Nightly          `yaml:"nightly,omitempty" json:"nightly,omitempty"`
 	Checksum        Checksum         `yaml:"checksum,omitempty" json:"checksum,omitempty"`
 	Dockers         []Docker         `yaml:"dockers,omitempty" json:"dockers,omitempty"`
 	DockerManifests []DockerManifest `yaml:"docker_manifests,omitempty" json:"docker_manifests,omitempty"`
@@ -1203,11 +1508,13 @@ type Project struct {
 	Uploads         []Upload         `yaml:"uploads,omitempty" json:"uploads,omitempty"`
 	Blobs           []Blob           `yaml:"blobs,omitempty" json:"blobs,omitempty"`
 	Publishers      []Publisher      `yaml:"publishers,omitempty" json:"publishers,omitempty"`
+	Plugins         []Plugin         `yaml:"plugins,omitempty" json:"plugins,omitempty"`
 	Changelog       Changelog        `yaml:"changelog,omitempty" json:"changelog,omitempty"`
 	Dist            string           `yaml:"dist,omitempty" json:"dist,omitempty"`
 	Signs           []Sign           `yaml:"signs,omitempty" json:"signs,omitempty"`
 	Notarize        Notarize         `yaml:"notarize,omitempty" json:"notarize,omitempty"`
 	DockerSigns     []Sign           `yaml:"docker_signs,omitempty" json:"docker_signs,omitempty"`
+	DockerScans     []DockerScan     `yaml:"docker_scans,omitempty" json:"docker_scans,omitempty"`
 	EnvFiles        EnvFiles         `yaml:"env_files,omitempty" json:"env_files,omitempty"`
 	Before          Before           `yaml:"before,omitempty" json:"before,omitempty"`
 	Source          Source           `yaml:"source,omitempty" json:"source,omitempty"`
@@ -1216,11 +1523,18 @@ type Project struct {
 	SBOMs           []SBOM           `yaml:"sboms,omitempty" json:"sboms,omitempty"`
 	Chocolateys     []Chocolatey     `yaml:"chocolateys,omitempty" json:"chocolateys,omitempty"`
 	Git             Git              `yaml:"git,omitempty" json:"git,omitempty"`
+	Monorepo        Monorepo         `yaml:"monorepo,omitempty" json:"monorepo,omitempty"`
 	ReportSizes     bool             `yaml:"report_sizes,omitempty" json:"report_sizes,omitempty"`
+	CheckEnv        bool             `yaml:"check_env,omitempty" json:"check_env,omitempty"`
 	Metadata        ProjectMetadata  `yaml:"metadata,omitempty" json:"metadata,omitempty"`
 
 	UniversalBinaries []UniversalBinary `yaml:"universal_binaries,omitempty" json:"universal_binaries,omitempty"`
 	UPXs              []UPX             `yaml:"upx,omitempty" json:"upx,omitempty"`
+	Completions       []Completion      `yaml:"completions,omitempty" json:"completions,omitempty"`
+	ManPages          []ManPage         `yaml:"manpages,omitempty" json:"manpages,omitempty"`
+
+	// build infos to publish, each to the artifactory with the given name
+	ArtifactoryBuildInfos []ArtifactoryBuildInfo `yaml:"artifactory_build_infos,omitempty" json:"artifactory_build_infos,omitempty"`
 
 	// force the SCM token to use when multiple are set
 	ForceToken string `yaml:"force_token,omitempty" json:"force_token,omitempty" jsonschema:"enum=github,enum=gitlab,enum=gitea,enum=,default="`
@@ -1233,6 +1547,17 @@ type Project struct {
 
 	// should be set if using Gitea
 	GiteaURLs GiteaURLs `yaml:"gitea_urls,omitempty" json:"gitea_urls,omitempty"`
+
+	// HTTP client settings
+	HTTP HTTP `yaml:"http,omitempty" json:"http,omitempty"`
+}
+
+// HTTP configures the HTTP clients used to talk to external services.
+type HTTP struct {
+	UserAgent           string        `yaml:"user_agent,omitempty" json:"user_agent,omitempty"`
+	CABundle            string        `yaml:"ca_bundle,omitempty" json:"ca_bundle,omitempty"`
+	MaxIdleConnsPerHost int           `yaml:"max_idle_conns_per_host,omitempty" json:"max_idle_conns_per_host,omitempty"`
+	IdleConnTimeout     time.Duration `yaml:"idle_conn_timeout,omitempty" json:"idle_conn_timeout,omitempty"`
 }
 
 type ProjectMetadata struct {
@@ -1248,40 +1573,100 @@ type GoMod struct {
 }
 
 type Announce struct {
-	Skip           string         `yaml:"skip,omitempty" json:"skip,omitempty" jsonschema:"oneof_type=string;boolean"`
-	Twitter        Twitter        `yaml:"twitter,omitempty" json:"twitter,omitempty"`
-	Mastodon       Mastodon       `yaml:"mastodon,omitempty" json:"mastodon,omitempty"`
-	Reddit         Reddit         `yaml:"reddit,omitempty" json:"reddit,omitempty"`
-	Slack          Slack          `yaml:"slack,omitempty" json:"slack,omitempty"`
-	Discord        Discord        `yaml:"discord,omitempty" json:"discord,omitempty"`
-	Teams          Teams          `yaml:"teams,omitempty" json:"teams,omitempty"`
-	SMTP           SMTP           `yaml:"smtp,omitempty" json:"smtp,omitempty"`
-	Mattermost     Mattermost     `yaml:"mattermost,omitempty" json:"mattermost,omitempty"`
-	LinkedIn       LinkedIn       `yaml:"linkedin,omitempty" json:"linkedin,omitempty"`
-	Telegram       Telegram       `yaml:"telegram,omitempty" json:"telegram,omitempty"`
-	Webhook        Webhook        `yaml:"webhook,omitempty" json:"webhook,omitempty"`
-	OpenCollective OpenCollective `yaml:"opencollective,omitempty" json:"opencolletive,omitempty"`
-	Bluesky        Bluesky        `yaml:"bluesky,omitempty" json:"bluesky,omitempty"`
+	Skip           string                          `yaml:"skip,omitempty" json:"skip,omitempty" jsonschema:"oneof_type=string;boolean"`
+	Twitter        AnnounceTargets[Twitter]        `yaml:"twitter,omitempty" json:"twitter,omitempty"`
+	Mastodon       AnnounceTargets[Mastodon]       `yaml:"mastodon,omitempty" json:"mastodon,omitempty"`
+	Reddit         AnnounceTargets[Reddit]         `yaml:"reddit,omitempty" json:"reddit,omitempty"`
+	Slack          AnnounceTargets[Slack]          `yaml:"slack,omitempty" json:"slack,omitempty"`
+	Discord        AnnounceTargets[Discord]        `yaml:"discord,omitempty" json:"discord,omitempty"`
+	Teams          AnnounceTargets[Teams]          `yaml:"teams,omitempty" json:"teams,omitempty"`
+	SMTP           AnnounceTargets[SMTP]           `yaml:"smtp,omitempty" json:"smtp,omitempty"`
+	Mattermost     AnnounceTargets[Mattermost]     `yaml:"mattermost,omitempty" json:"mattermost,omitempty"`
+	LinkedIn       AnnounceTargets[LinkedIn]       `yaml:"linkedin,omitempty" json:"linkedin,omitempty"`
+	Telegram       AnnounceTargets[Telegram]       `yaml:"telegram,omitempty" json:"telegram,omitempty"`
+	Webhook        AnnounceTargets[Webhook]        `yaml:"webhook,omitempty" json:"webhook,omitempty"`
+	OpenCollective AnnounceTargets[OpenCollective] `yaml:"opencollective,omitempty" json:"opencolletive,omitempty"`
+	Bluesky        AnnounceTargets[Bluesky]        `yaml:"bluesky,omitempty" json:"bluesky,omitempty"`
+}
+
+// AnnounceTargets is a list of configurations of an announcer, which can also
+// be declared as a single one.
+type AnnounceTargets[T any] []T
+
+// UnmarshalYAML is a custom unmarshaler that wraps a single target in an array.
+func (a *AnnounceTargets[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw interface{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw.([]interface{}); ok {
+		var targets []T
+		if err := unmarshal(&targets); err != nil {
+			return err
+		}
+		*a = targets
+		return nil
+	}
+
+	var target T
+	if err := unmarshal(&target); err != nil {
+		return err
+	}
+	*a = []T{target}
+	return nil
+}
+
+func (a AnnounceTargets[T]) JSONSchema() *jsonschema.Schema {
+	reflector := jsonschema.Reflector{
+		ExpandedStruct: true,
+	}
+	var t T
+	schema := reflector.Reflect(&t)
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			schema,
+			{
+				Type:  "array",
+				Items: schema,
+			},
+		},
+	}
+}
+
+// AnnounceConditions are the conditions shared by all announcers to decide
+// whether they should announce a release.
+type AnnounceConditions struct {
+	Skip           string `yaml:"skip,omitempty" json:"skip,omitempty" jsonschema:"oneof_type=string;boolean"`
+	SkipPrerelease bool   `yaml:"skip_prerelease,omitempty" json:"skip_prerelease,omitempty"`
 }
 
 type Webhook struct {
-	Enabled         bool              `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	SkipTLSVerify   bool              `yaml:"skip_tls_verify,omitempty" json:"skip_tls_verify,omitempty"`
-	MessageTemplate string            `yaml:"message_template,omitempty" json:"message_template,omitempty"`
-	EndpointURL     string            `yaml:"endpoint_url,omitempty" json:"endpoint_url,omitempty"`
-	Headers         map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
-	ContentType     string            `yaml:"content_type,omitempty" json:"content_type,omitempty"`
+	Enabled             bool              `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	SkipTLSVerify       bool              `yaml:"skip_tls_verify,omitempty" json:"skip_tls_verify,omitempty"`
+	MessageTemplate     string            `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	MessageTemplateFile string            `yaml:"message_template_file,omitempty" json:"message_template_file,omitempty"`
+	EndpointURL         string            `yaml:"endpoint_url,omitempty" json:"endpoint_url,omitempty"`
+	Headers             map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	ContentType         string            `yaml:"content_type,omitempty" json:"content_type,omitempty"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 type Twitter struct {
-	Enabled         bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	Enabled             bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	MessageTemplate     string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	MessageTemplateFile string `yaml:"message_template_file,omitempty" json:"message_template_file,omitempty"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 type Mastodon struct {
-	Enabled         bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
-	Server          string `yaml:"server" json:"server"`
+	Enabled             bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	MessageTemplate     string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	MessageTemplateFile string `yaml:"message_template_file,omitempty" json:"message_template_file,omitempty"`
+	Server              string `yaml:"server" json:"server"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 type Reddit struct {
@@ -1291,44 +1676,71 @@ type Reddit struct {
 	TitleTemplate string `yaml:"title_template,omitempty" json:"title_template,omitempty"`
 	URLTemplate   string `yaml:"url_template,omitempty" json:"url_template,omitempty"`
 	Sub           string `yaml:"sub,omitempty" json:"sub,omitempty"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 type Slack struct {
-	Enabled         bool              `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	MessageTemplate string            `yaml:"message_template,omitempty" json:"message_template,omitempty"`
-	Channel         string            `yaml:"channel,omitempty" json:"channel,omitempty"`
-	Username        string            `yaml:"username,omitempty" json:"username,omitempty"`
-	IconEmoji       string            `yaml:"icon_emoji,omitempty" json:"icon_emoji,omitempty"`
-	IconURL         string            `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
-	Blocks          []SlackBlock      `yaml:"blocks,omitempty" json:"blocks,omitempty"`
-	Attachments     []SlackAttachment `yaml:"attachments,omitempty" json:"attachments,omitempty"`
+	Enabled             bool              `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	MessageTemplate     string            `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	MessageTemplateFile string            `yaml:"message_template_file,omitempty" json:"message_template_file,omitempty"`
+	Channel             string            `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Username            string            `yaml:"username,omitempty" json:"username,omitempty"`
+	IconEmoji           string            `yaml:"icon_emoji,omitempty" json:"icon_emoji,omitempty"`
+	IconURL             string            `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
+	Blocks              []SlackBlock      `yaml:"blocks,omitempty" json:"blocks,omitempty"`
+	Attachments         []SlackAttachment `yaml:"attachments,omitempty" json:"attachments,omitempty"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 type Discord struct {
-	Enabled         bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
-	Author          string `yaml:"author,omitempty" json:"author,omitempty"`
-	Color           string `yaml:"color,omitempty" json:"color,omitempty"`
-	IconURL         string `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
+	Enabled             bool         `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	MessageTemplate     string       `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	MessageTemplateFile string       `yaml:"message_template_file,omitempty" json:"message_template_file,omitempty"`
+	Author              string       `yaml:"author,omitempty" json:"author,omitempty"`
+	Color               string       `yaml:"color,omitempty" json:"color,omitempty"`
+	IconURL             string       `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
+	Embed               DiscordEmbed `yaml:"embed,omitempty" json:"embed,omitempty"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
+}
+
+type DiscordEmbed struct {
+	Title       string              `yaml:"title,omitempty" json:"title,omitempty"`
+	Description string              `yaml:"description,omitempty" json:"description,omitempty"`
+	Fields      []DiscordEmbedField `yaml:"fields,omitempty" json:"fields,omitempty"`
+}
+
+type DiscordEmbedField struct {
+	Name   string `yaml:"name,omitempty" json:"name,omitempty"`
+	Value  string `yaml:"value,omitempty" json:"value,omitempty"`
+	Inline bool   `yaml:"inline,omitempty" json:"inline,omitempty"`
 }
 
 type Teams struct {
-	Enabled         bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	TitleTemplate   string `yaml:"title_template,omitempty" json:"title_template,omitempty"`
-	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
-	Color           string `yaml:"color,omitempty" json:"color,omitempty"`
-	IconURL         string `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
+	Enabled             bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	TitleTemplate       string `yaml:"title_template,omitempty" json:"title_template,omitempty"`
+	MessageTemplate     string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	MessageTemplateFile string `yaml:"message_template_file,omitempty" json:"message_template_file,omitempty"`
+	Color               string `yaml:"color,omitempty" json:"color,omitempty"`
+	IconURL             string `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 type Mattermost struct {
-	Enabled         bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
-	TitleTemplate   string `yaml:"title_template,omitempty" json:"title_template,omitempty"`
-	Color           string `yaml:"color,omitempty" json:"color,omitempty"`
-	Channel         string `yaml:"channel,omitempty" json:"channel,omitempty"`
-	Username        string `yaml:"username,omitempty" json:"username,omitempty"`
-	IconEmoji       string `yaml:"icon_emoji,omitempty" json:"icon_emoji,omitempty"`
-	IconURL         string `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
+	Enabled             bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	MessageTemplate     string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	MessageTemplateFile string `yaml:"message_template_file,omitempty" json:"message_template_file,omitempty"`
+	TitleTemplate       string `yaml:"title_template,omitempty" json:"title_template,omitempty"`
+	Color               string `yaml:"color,omitempty" json:"color,omitempty"`
+	Channel             string `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Username            string `yaml:"username,omitempty" json:"username,omitempty"`
+	IconEmoji           string `yaml:"icon_emoji,omitempty" json:"icon_emoji,omitempty"`
+	IconURL             string `yaml:"icon_url,omitempty" json:"icon_url,omitempty"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 type SMTP struct {
@@ -1340,33 +1752,48 @@ type SMTP struct {
 	To                 []string `yaml:"to,omitempty" json:"to,omitempty"`
 	SubjectTemplate    string   `yaml:"subject_template,omitempty" json:"subject_template,omitempty"`
 	BodyTemplate       string   `yaml:"body_template,omitempty" json:"body_template,omitempty"`
+	BodyTemplateFile   string   `yaml:"body_template_file,omitempty" json:"body_template_file,omitempty"`
 	InsecureSkipVerify bool     `yaml:"insecure_skip_verify,omitempty" json:"insecure_skip_verify,omitempty"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 type LinkedIn struct {
-	Enabled         bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	Enabled             bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	MessageTemplate     string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	MessageTemplateFile string `yaml:"message_template_file,omitempty" json:"message_template_file,omitempty"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 type Telegram struct {
-	Enabled         bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
-	ChatID          string `yaml:"chat_id,omitempty" json:"chat_id,omitempty" jsonschema:"oneof_type=string;integer"`
-	ParseMode       string `yaml:"parse_mode,omitempty" json:"parse_mode,omitempty" jsonschema:"enum=MarkdownV2,enum=HTML,default=MarkdownV2"`
+	Enabled             bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	MessageTemplate     string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	MessageTemplateFile string `yaml:"message_template_file,omitempty" json:"message_template_file,omitempty"`
+	ChatID              string `yaml:"chat_id,omitempty" json:"chat_id,omitempty" jsonschema:"oneof_type=string;integer"`
+	ParseMode           string `yaml:"parse_mode,omitempty" json:"parse_mode,omitempty" jsonschema:"enum=MarkdownV2,enum=HTML,default=MarkdownV2"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 type OpenCollective struct {
-	Enabled         bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	Slug            string `yaml:"slug,omitempty" json:"slug,omitempty"`
-	TitleTemplate   string `yaml:"title_template,omitempty" json:"title_template,omitempty"`
-	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	Enabled             bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Slug                string `yaml:"slug,omitempty" json:"slug,omitempty"`
+	TitleTemplate       string `yaml:"title_template,omitempty" json:"title_template,omitempty"`
+	MessageTemplate     string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	MessageTemplateFile string `yaml:"message_template_file,omitempty" json:"message_template_file,omitempty"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 // Bluesky represents the data required to announce to the Bluesky social network
 type Bluesky struct {
-	Enabled         bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
-	Username        string `yaml:"username,omitempty" json:"username,omitempty"`
-	MessageTemplate string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	Enabled             bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Username            string `yaml:"username,omitempty" json:"username,omitempty"`
+	MessageTemplate     string `yaml:"message_template,omitempty" json:"message_template,omitempty"`
+	MessageTemplateFile string `yaml:"message_template_file,omitempty" json:"message_template_file,omitempty"`
+
+	AnnounceConditions `yaml:",inline" json:",inline"`
 }
 
 // SlackBlock represents the untyped structure of a rich slack message layout.
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/internal/yaml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnounceTargets_single(t *testing.T) {
+	var actual Announce
+
+	err := yaml.UnmarshalStrict([]byte(`slack:
+  enabled: true
+  channel: "#releases"
+`), &actual)
+	require.NoError(t, err)
+	require.Equal(t, AnnounceTargets[Slack]{
+		{
+			Enabled: true,
+			Channel: "#releases",
+		},
+	}, actual.Slack)
+}
+
+func TestAnnounceTargets_list(t *testing.T) {
+	var actual Announce
+
+	err := yaml.UnmarshalStrict([]byte(`slack:
+  - enabled: true
+    channel: "#releases"
+  - enabled: true
+    channel: "#announcements"
+    skip_prerelease: true
+`), &actual)
+	require.NoError(t, err)
+	require.Equal(t, AnnounceTargets[Slack]{
+		{
+			Enabled: true,
+			Channel: "#releases",
+		},
+		{
+			Enabled: true,
+			Channel: "#announcements",
+			AnnounceConditions: AnnounceConditions{
+				SkipPrerelease: true,
+			},
+		},
+	}, actual.Slack)
+}
+
+func TestAnnounceTargets_invalid(t *testing.T) {
+	for name, in := range map[string]string{
+		"single":          "slack:\n  nope: true\n",
+		"list":            "slack:\n  - nope: true\n",
+		"string":          "slack: foo\n",
+		"list of strings": "slack:\n  - foo\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			var actual Announce
+			require.Error(t, yaml.UnmarshalStrict([]byte(in), &actual))
+		})
+	}
+}
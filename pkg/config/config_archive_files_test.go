@@ -93,3 +93,17 @@ files:
 		},
 	}, actual.Files)
 }
+
+func TestArchiveWrapInDirectory(t *testing.T) {
+	for input, expected := range map[string]string{
+		"true":                     "true",
+		"false":                    "false",
+		`"{{ .ProjectName }}_dir"`: "{{ .ProjectName }}_dir",
+	} {
+		t.Run(input, func(t *testing.T) {
+			var actual Archive
+			require.NoError(t, yaml.UnmarshalStrict([]byte("wrap_in_directory: "+input), &actual))
+			require.Equal(t, expected, actual.WrapInDirectory)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalHomebrewService(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		conf := `
+version: 2
+brews:
+- name: foo
+  service: |
+    run foo/bar
+    keep_alive true
+`
+		prop, err := LoadReader(strings.NewReader(conf))
+		require.NoError(t, err)
+		require.Equal(t, HomebrewService{
+			Block: "run foo/bar\nkeep_alive true\n",
+		}, prop.Brews[0].Service)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		conf := `
+version: 2
+brews:
+- name: foo
+  service:
+    run: [foo, serve]
+    log_path: log/foo.log
+    keep_alive: true
+    run_type: immediate
+`
+		prop, err := LoadReader(strings.NewReader(conf))
+		require.NoError(t, err)
+		require.Equal(t, HomebrewService{
+			Run:       []string{"foo", "serve"},
+			LogPath:   "log/foo.log",
+			KeepAlive: true,
+			RunType:   "immediate",
+		}, prop.Brews[0].Service)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		conf := `
+version: 2
+brews:
+- name: foo
+  service:
+    runner: foo
+`
+		_, err := LoadReader(strings.NewReader(conf))
+		require.EqualError(t, err, "yaml: unmarshal errors:\n  line 6: field runner not found in type config.homebrewService")
+	})
+}
@@ -40,16 +40,16 @@ func TestUnmarshalSlackBlocks(t *testing.T) {
 			},
 		}
 		// assert Unmarshal from YAML
-		require.Equal(t, expectedBlocks, prop.Announce.Slack.Blocks)
+		require.Equal(t, expectedBlocks, prop.Announce.Slack[0].Blocks)
 
-		jazon, err := json.Marshal(prop.Announce.Slack.Blocks)
+		jazon, err := json.Marshal(prop.Announce.Slack[0].Blocks)
 		require.NoError(t, err)
 
 		var untyped []SlackBlock
 		require.NoError(t, json.Unmarshal(jazon, &untyped))
 
 		// assert that JSON Marshal didn't alter the struct
-		require.Equal(t, expectedBlocks, prop.Announce.Slack.Blocks)
+		require.Equal(t, expectedBlocks, prop.Announce.Slack[0].Blocks)
 	})
 
 	t.Run("invalid blocks", func(t *testing.T) {
@@ -91,16 +91,16 @@ func TestUnmarshalSlackAttachments(t *testing.T) {
 			},
 		}
 		// assert Unmarshal from YAML
-		require.Equal(t, expectedAttachments, prop.Announce.Slack.Attachments)
+		require.Equal(t, expectedAttachments, prop.Announce.Slack[0].Attachments)
 
-		jazon, err := json.Marshal(prop.Announce.Slack.Attachments)
+		jazon, err := json.Marshal(prop.Announce.Slack[0].Attachments)
 		require.NoError(t, err)
 
 		var untyped []SlackAttachment
 		require.NoError(t, json.Unmarshal(jazon, &untyped))
 
 		// assert that JSON Marshal didn't alter the struct
-		require.Equal(t, expectedAttachments, prop.Announce.Slack.Attachments)
+		require.Equal(t, expectedAttachments, prop.Announce.Slack[0].Attachments)
 	})
 
 	t.Run("invalid attachments", func(t *testing.T) {
@@ -13,14 +13,14 @@ import (
 // VersionError will happen if the goreleaser config file version does not
 // match the current GoReleaser version.
 type VersionError struct {
-	current int
+	Current int
 }
 
 func (e VersionError) Error() string {
 	return fmt.Sprintf(
 		"only configurations files on %s are supported, yours is %s, please update your configuration",
 		logext.Keyword("version: 2"),
-		logext.Keyword(fmt.Sprintf("version: %d", e.current)),
+		logext.Keyword(fmt.Sprintf("version: %d", e.Current)),
 	)
 }
 
@@ -11,10 +11,12 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/goreleaser/goreleaser/v2/internal/artifact"
 	"github.com/goreleaser/goreleaser/v2/pkg/config"
+	"golang.org/x/sync/semaphore"
 )
 
 // GitInfo includes tags and diffs used in some point.
@@ -100,16 +102,25 @@ type Context struct {
 	ModulePath        string
 	PartialTarget     string
 	Snapshot          bool
+	Nightly           bool
+	DistinctNightly   bool
+	CreateTag         bool
 	FailFast          bool
+	CollectErrors     bool
 	Partial           bool
 	SkipTokenCheck    bool
 	Clean             bool
 	PreRelease        bool
 	Deprecated        bool
+	Healthcheck       bool
+	Quiet             bool
 	Parallelism       int
+	Workers           *Workers
 	Semver            Semver
 	Runtime           Runtime
 	Skips             map[string]bool
+	DebugPipes        []string
+	Timings           *Timings
 }
 
 type Runtime struct {
@@ -117,6 +128,104 @@ type Runtime struct {
 	Goarch string
 }
 
+// Timing is how long a step took to run.
+type Timing struct {
+	Name     string
+	Parent   string
+	Duration time.Duration
+	Failed   bool
+}
+
+// Timings records how long each step took, safe for concurrent use.
+//
+// Steps added while another step is running have it as their parent.
+type Timings struct {
+	lock    sync.Mutex
+	current string
+	items   []Timing
+}
+
+// Start sets the given step as the parent of the steps added until it is
+// added itself.
+func (t *Timings) Start(name string) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.current = name
+}
+
+// Add records how long the given step took, and whether it failed.
+func (t *Timings) Add(name string, took time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	timing := Timing{Name: name, Duration: took, Failed: err != nil}
+	if t.current == name {
+		t.current = ""
+	} else {
+		timing.Parent = t.current
+	}
+	t.items = append(t.items, timing)
+}
+
+// List returns the recorded timings, in the order they finished.
+func (t *Timings) List() []Timing {
+	if t == nil {
+		return nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]Timing(nil), t.items...)
+}
+
+// Workers bounds the number of tasks running concurrently across all the
+// pipes, so pipes running several groups of tasks, or nested ones, don't run
+// more than Parallelism tasks at the same time.
+//
+// It has one worker less than Parallelism, as the goroutine waiting for the
+// tasks also runs them while they wait for a worker.
+type Workers struct {
+	once sync.Once
+	sem  *semaphore.Weighted
+}
+
+// workers returns the workers semaphore, sized from Parallelism on the first
+// call, or nil if there are no workers.
+func (ctx *Context) workers() *semaphore.Weighted {
+	if ctx.Workers == nil {
+		return nil
+	}
+	ctx.Workers.once.Do(func() {
+		if size := ctx.Parallelism - 1; size > 0 {
+			ctx.Workers.sem = semaphore.NewWeighted(int64(size))
+		}
+	})
+	return ctx.Workers.sem
+}
+
+// HasWorkers tells whether tasks can be run in other goroutines at all.
+func (ctx *Context) HasWorkers() bool {
+	return ctx.workers() != nil
+}
+
+// AcquireWorker blocks until a worker is available to run a task in a new
+// goroutine, in which case it must be given back with ReleaseWorker.
+//
+// It returns false if there are no workers, or the context is done.
+func (ctx *Context) AcquireWorker() bool {
+	sem := ctx.workers()
+	return sem != nil && sem.Acquire(ctx, 1) == nil
+}
+
+// ReleaseWorker gives back a worker reserved by AcquireWorker.
+func (ctx *Context) ReleaseWorker() {
+	ctx.Workers.sem.Release(1)
+}
+
 // Semver represents a semantic version.
 type Semver struct {
 	Major      uint64
@@ -143,9 +252,11 @@ func Wrap(ctx stdctx.Context, config config.Project) *Context {
 		Config:      config,
 		Env:         ToEnv(append(os.Environ(), config.Env...)),
 		Parallelism: 4,
+		Workers:     &Workers{},
 		Artifacts:   artifact.New(),
 		Date:        time.Now(),
 		Skips:       map[string]bool{},
+		Timings:     &Timings{},
 		Runtime: Runtime{
 			Goos:   runtime.GOOS,
 			Goarch: runtime.GOARCH,
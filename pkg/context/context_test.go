@@ -1,6 +1,8 @@
 package context
 
 import (
+	stdctx "context"
+	"errors"
 	"runtime"
 	"testing"
 	"time"
@@ -38,3 +40,63 @@ func TestToEnv(t *testing.T) {
 	require.Equal(t, Env{"FOO": "BAR"}, ToEnv([]string{"nope", "FOO=BAR"}))
 	require.Equal(t, Env{"FOO": "BAR", "nope": ""}, ToEnv([]string{"nope=", "FOO=BAR"}))
 }
+
+func TestTimings(t *testing.T) {
+	ctx := New(config.Project{})
+	ctx.Timings.Add("before", time.Second, nil)
+	ctx.Timings.Start("build")
+	ctx.Timings.Add("linux_amd64", time.Millisecond, errors.New("fake"))
+	ctx.Timings.Add("build", 2*time.Second, nil)
+	ctx.Timings.Add("after", time.Second, nil)
+	require.Equal(t, []Timing{
+		{Name: "before", Duration: time.Second},
+		{Name: "linux_amd64", Parent: "build", Duration: time.Millisecond, Failed: true},
+		{Name: "build", Duration: 2 * time.Second},
+		{Name: "after", Duration: time.Second},
+	}, ctx.Timings.List())
+
+	var nilTimings *Timings
+	nilTimings.Start("foo")
+	nilTimings.Add("foo", time.Second, nil)
+	require.Empty(t, nilTimings.List())
+}
+
+func TestWorkers(t *testing.T) {
+	ctx := New(config.Project{})
+	ctx.Parallelism = 3
+	require.True(t, ctx.HasWorkers())
+
+	// one less than the parallelism, the caller is a worker too
+	require.True(t, ctx.AcquireWorker())
+	require.True(t, ctx.AcquireWorker())
+
+	// shared with copies of the context, and blocks until one is released
+	cp := *ctx
+	acquired := make(chan bool)
+	go func() { acquired <- cp.AcquireWorker() }()
+	select {
+	case <-acquired:
+		t.Fatal("should block while all workers are busy")
+	case <-time.After(10 * time.Millisecond):
+	}
+	ctx.ReleaseWorker()
+	require.True(t, <-acquired)
+
+	t.Run("cancelled", func(t *testing.T) {
+		parent, cancel := stdctx.WithCancel(stdctx.Background())
+		cctx := Wrap(parent, config.Project{})
+		cctx.Parallelism = 2
+		require.True(t, cctx.AcquireWorker())
+		cancel()
+		require.False(t, cctx.AcquireWorker())
+	})
+
+	t.Run("no workers", func(t *testing.T) {
+		require.False(t, (&Context{Parallelism: 4}).HasWorkers())
+		require.False(t, (&Context{Parallelism: 4}).AcquireWorker())
+		single := New(config.Project{})
+		single.Parallelism = 1
+		require.False(t, single.HasWorkers())
+		require.False(t, single.AcquireWorker())
+	})
+}
@@ -5,6 +5,7 @@ package defaults
 import (
 	"fmt"
 
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/appimage"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/archive"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/artifactory"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/aur"
@@ -15,8 +16,10 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/changelog"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/checksums"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/chocolatey"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/completions"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/discord"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/docker"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/flatpak"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/gomod"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/ko"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/krew"
@@ -24,14 +27,18 @@ import (
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/mastodon"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/mattermost"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/milestone"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/msi"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nfpm"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/nightly"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nix"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/notary"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/nsis"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/opencollective"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/project"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/reddit"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/release"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sbom"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/scan"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/scoop"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sign"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/slack"
@@ -64,6 +71,7 @@ type Defaulter interface {
 //nolint:gochecknoglobals
 var Defaulters = []Defaulter{
 	snapshot.Pipe{},
+	nightly.Pipe{},
 	release.Pipe{},
 	project.Pipe{},
 	changelog.Pipe{},
@@ -72,16 +80,22 @@ var Defaulters = []Defaulter{
 	universalbinary.Pipe{},
 	notary.MacOS{},
 	upx.Pipe{},
+	completions.Pipe{},
 	sourcearchive.Pipe{},
 	archive.Pipe{},
 	nfpm.Pipe{},
 	snapcraft.Pipe{},
+	flatpak.Pipe{},
+	appimage.Pipe{},
+	msi.Pipe{},
+	nsis.Pipe{},
 	checksums.Pipe{},
 	sign.Pipe{},
 	sign.DockerPipe{},
 	sbom.Pipe{},
 	docker.Pipe{},
 	docker.ManifestPipe{},
+	scan.Pipe{},
 	artifactory.Pipe{},
 	blob.Pipe{},
 	upload.Pipe{},
@@ -3,14 +3,27 @@
 package healthcheck
 
 import (
+	stdctx "context"
 	"fmt"
+	"os/exec"
+	"regexp"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/goreleaser/goreleaser/v2/internal/middleware/skip"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/appimage"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/chocolatey"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/docker"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/flatpak"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/ko"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/msi"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/nix"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/nsis"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sbom"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/scan"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/sign"
 	"github.com/goreleaser/goreleaser/v2/internal/pipe/snapcraft"
+	"github.com/goreleaser/goreleaser/v2/internal/pipe/upx"
 	"github.com/goreleaser/goreleaser/v2/pkg/context"
 )
 
@@ -22,22 +35,147 @@ type Healthchecker interface {
 	Dependencies(ctx *context.Context) []string
 }
 
+// MinVersioner can be implemented by healthcheckers that need a minimum
+// version of some of their dependencies.
+type MinVersioner interface {
+	// MinVersions returns the minimum version needed, by binary.
+	MinVersions(ctx *context.Context) map[string]string
+}
+
+// Hinter can be implemented by healthcheckers that want to tell the user
+// how to install their missing dependencies.
+type Hinter interface {
+	// Hints returns how to install the dependencies, by binary.
+	Hints(ctx *context.Context) map[string]string
+}
+
 // Healthcheckers is the list of healthchekers.
 //
 //nolint:gochecknoglobals
 var Healthcheckers = []Healthchecker{
 	system{},
 	snapcraft.Pipe{},
+	flatpak.Pipe{},
+	appimage.Pipe{},
+	msi.Pipe{},
+	nsis.Pipe{},
 	sign.Pipe{},
 	sign.DockerPipe{},
 	sbom.Pipe{},
 	docker.Pipe{},
 	docker.ManifestPipe{},
+	ko.Pipe{},
+	scan.Pipe{},
 	chocolatey.Pipe{},
 	nix.NewPublish(),
+	upx.Pipe{},
 }
 
 type system struct{}
 
 func (system) String() string                           { return "system" }
 func (system) Dependencies(_ *context.Context) []string { return []string{"git", "go"} }
+
+// Result is the result of checking a single dependency.
+type Result struct {
+	// Checker is the name of the healthchecker that needs the dependency.
+	Checker string
+	// Tool is the dependency binary.
+	Tool string
+	// Version is the version found, only set if MinVersion is set.
+	Version string
+	// MinVersion is the minimum version needed, if any.
+	MinVersion string
+	// Err is set if the dependency is missing or too old.
+	Err error
+}
+
+// Run checks the dependencies of all non-skipped healthcheckers.
+// Each tool is only checked once, even if needed by more than one pipe.
+func Run(ctx *context.Context) ([]Result, error) {
+	var results []Result
+	checked := map[string]bool{}
+	for _, hc := range Healthcheckers {
+		if err := skip.Maybe(hc, func(ctx *context.Context) error {
+			var mins, hints map[string]string
+			if mv, ok := hc.(MinVersioner); ok {
+				mins = mv.MinVersions(ctx)
+			}
+			if h, ok := hc.(Hinter); ok {
+				hints = h.Hints(ctx)
+			}
+			for _, tool := range hc.Dependencies(ctx) {
+				if checked[tool] {
+					continue
+				}
+				checked[tool] = true
+				result := Check(ctx, tool, mins[tool])
+				result.Checker = hc.String()
+				if result.Err != nil && hints[tool] != "" {
+					result.Err = fmt.Errorf("%w: %s", result.Err, hints[tool])
+				}
+				results = append(results, result)
+			}
+			return nil
+		})(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// versionArgs are the arguments needed to get the version of tools that do
+// not support `--version`.
+//
+//nolint:gochecknoglobals
+var versionArgs = map[string][]string{
+	"go":     {"version"},
+	"cosign": {"version"},
+}
+
+var versionRe = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// Check checks that the given tool is in the $PATH, and, if minVersion is
+// not empty, that its version is at least minVersion.
+func Check(ctx stdctx.Context, tool, minVersion string) Result {
+	result := Result{
+		Tool:       tool,
+		MinVersion: minVersion,
+	}
+	path, err := exec.LookPath(tool)
+	if err != nil {
+		result.Err = fmt.Errorf("%s: not present in path", tool)
+		return result
+	}
+	if minVersion == "" {
+		return result
+	}
+
+	minV, err := semver.NewVersion(minVersion)
+	if err != nil {
+		result.Err = fmt.Errorf("%s: invalid minimum version %q: %w", tool, minVersion, err)
+		return result
+	}
+
+	args, ok := versionArgs[tool]
+	if !ok {
+		args = []string{"--version"}
+	}
+	ctx, cancel := stdctx.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, args...).CombinedOutput()
+	if err != nil {
+		result.Err = fmt.Errorf("%s: could not get version: %w", tool, err)
+		return result
+	}
+	version, err := semver.NewVersion(versionRe.FindString(string(out)))
+	if err != nil {
+		result.Err = fmt.Errorf("%s: could not parse version from %q", tool, string(out))
+		return result
+	}
+	result.Version = version.String()
+	if version.LessThan(minV) {
+		result.Err = fmt.Errorf("%s: version %s is older than the minimum required %s", tool, version, minV)
+	}
+	return result
+}
@@ -1,9 +1,11 @@
 package healthcheck
 
 import (
+	"context"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/v2/internal/testctx"
+	"github.com/goreleaser/goreleaser/v2/pkg/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,3 +17,68 @@ func TestDependencies(t *testing.T) {
 func TestStringer(t *testing.T) {
 	require.NotEmpty(t, system{}.String())
 }
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	t.Run("present", func(t *testing.T) {
+		result := Check(ctx, "go", "")
+		require.NoError(t, result.Err)
+		require.Empty(t, result.Version)
+	})
+	t.Run("min version", func(t *testing.T) {
+		result := Check(ctx, "go", "1.0.0")
+		require.NoError(t, result.Err)
+		require.NotEmpty(t, result.Version)
+	})
+	t.Run("too old", func(t *testing.T) {
+		result := Check(ctx, "go", "999.0.0")
+		require.ErrorContains(t, result.Err, "is older than the minimum required 999.0.0")
+	})
+	t.Run("invalid min version", func(t *testing.T) {
+		result := Check(ctx, "go", "nope")
+		require.ErrorContains(t, result.Err, "invalid minimum version")
+	})
+	t.Run("missing", func(t *testing.T) {
+		result := Check(ctx, "this-tool-does-not-exist", "")
+		require.ErrorContains(t, result.Err, "not present in path")
+	})
+}
+
+func TestRun(t *testing.T) {
+	ctx := testctx.NewWithCfg(config.Project{
+		Signs: []config.Sign{
+			{Cmd: "go"},
+			{Cmd: "this-tool-does-not-exist"},
+		},
+	})
+	results, err := Run(ctx)
+	require.NoError(t, err)
+
+	var tools []string
+	for _, result := range results {
+		tools = append(tools, result.Tool)
+		if result.Tool == "this-tool-does-not-exist" {
+			require.Error(t, result.Err)
+			require.Equal(t, "signing artifacts", result.Checker)
+		}
+	}
+	// go is needed by both system and signs, but only checked once.
+	require.Equal(t, []string{"git", "go", "this-tool-does-not-exist"}, tools)
+}
+
+func TestRunHints(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	ctx := testctx.NewWithCfg(config.Project{
+		AppImages: []config.AppImage{{}},
+	})
+	results, err := Run(ctx)
+	require.NoError(t, err)
+
+	for _, result := range results {
+		if result.Tool == "appimagetool" {
+			require.ErrorContains(t, result.Err, "appimagetool: not present in path: download it from")
+			return
+		}
+	}
+	t.Fatal("appimagetool was not checked")
+}
@@ -0,0 +1,102 @@
+// Package plugin allows to write GoReleaser publishers as separate binaries,
+// using hashicorp/go-plugin over gRPC.
+//
+// A plugin publisher implements Publisher, and calls Serve from its main:
+//
+//	func main() {
+//		plugin.Serve(myPublisher{})
+//	}
+//
+// GoReleaser launches it once per `plugins` configuration, handing it the
+// project information, the artifacts and the plugin configuration.
+package plugin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/plugin/proto"
+	goplugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Name is the name the publisher is dispensed as.
+const Name = "publisher"
+
+// Handshake is used by GoReleaser and the plugins to make sure they are
+// talking to each other.
+// Plugins are not meant to be run directly, the magic cookie prevents that.
+var Handshake = goplugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "GORELEASER_PLUGIN",
+	MagicCookieValue: "goreleaser",
+}
+
+// Publisher is implemented by plugin publishers.
+type Publisher interface {
+	Publish(ctx context.Context, req *proto.PublishRequest) error
+}
+
+// Plugins returns the plugins GoReleaser and the plugins should serve and
+// dispense, using the given implementation, which might be nil on the client
+// side.
+func Plugins(impl Publisher) map[string]goplugin.Plugin {
+	return map[string]goplugin.Plugin{
+		Name: &GRPCPlugin{Impl: impl},
+	}
+}
+
+// Serve serves the given publisher.
+// It should be called from the plugin's main function, and blocks until
+// GoReleaser is done with it.
+func Serve(impl Publisher) {
+	goplugin.Serve(&goplugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         Plugins(impl),
+		GRPCServer:      goplugin.DefaultGRPCServer,
+	})
+}
+
+// GRPCPlugin implements go-plugin's GRPCPlugin for publishers.
+type GRPCPlugin struct {
+	goplugin.NetRPCUnsupportedPlugin
+	Impl Publisher
+}
+
+// GRPCServer implements goplugin.GRPCPlugin.
+func (p *GRPCPlugin) GRPCServer(_ *goplugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterPublisherServer(s, &server{impl: p.Impl})
+	return nil
+}
+
+// GRPCClient implements goplugin.GRPCPlugin.
+func (p *GRPCPlugin) GRPCClient(_ context.Context, _ *goplugin.GRPCBroker, c *grpc.ClientConn) (any, error) {
+	return &client{client: proto.NewPublisherClient(c)}, nil
+}
+
+type server struct {
+	proto.UnimplementedPublisherServer
+	impl Publisher
+}
+
+func (s *server) Publish(ctx context.Context, req *proto.PublishRequest) (*proto.PublishResponse, error) {
+	if err := s.impl.Publish(ctx, req); err != nil {
+		return nil, err
+	}
+	return &proto.PublishResponse{}, nil
+}
+
+type client struct {
+	client proto.PublisherClient
+}
+
+func (c *client) Publish(ctx context.Context, req *proto.PublishRequest) error {
+	if _, err := c.client.Publish(ctx, req); err != nil {
+		if st, ok := status.FromError(err); ok {
+			return errors.New(st.Message())
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/v2/pkg/plugin/proto"
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePublisher struct {
+	req *proto.PublishRequest
+	err error
+}
+
+func (f *fakePublisher) Publish(_ context.Context, req *proto.PublishRequest) error {
+	f.req = req
+	return f.err
+}
+
+func dispense(tb testing.TB, impl Publisher) Publisher {
+	tb.Helper()
+	client, _ := goplugin.TestPluginGRPCConn(tb, false, Plugins(impl))
+	tb.Cleanup(func() { _ = client.Close() })
+	raw, err := client.Dispense(Name)
+	require.NoError(tb, err)
+	return raw.(Publisher)
+}
+
+func TestPublish(t *testing.T) {
+	impl := &fakePublisher{}
+	err := dispense(t, impl).Publish(context.Background(), &proto.PublishRequest{
+		Project: &proto.Project{Name: "foo", Version: "1.2.3"},
+		Artifacts: []*proto.Artifact{
+			{Name: "foo.tar.gz", Path: "dist/foo.tar.gz", Type: "Archive"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "foo", impl.req.GetProject().GetName())
+	require.Equal(t, "1.2.3", impl.req.GetProject().GetVersion())
+	require.Len(t, impl.req.GetArtifacts(), 1)
+	require.Equal(t, "dist/foo.tar.gz", impl.req.GetArtifacts()[0].GetPath())
+}
+
+func TestPublishError(t *testing.T) {
+	impl := &fakePublisher{err: errors.New("upload failed")}
+	err := dispense(t, impl).Publish(context.Background(), &proto.PublishRequest{})
+	require.EqualError(t, err, "upload failed")
+}